@@ -0,0 +1,59 @@
+package build
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils/cliutils"
+)
+
+const buildInfoGoModulePath = "github.com/jfrog/build-info-go"
+
+// Returns the version of this library, as recorded in the binary, which uses it. When it's built from its sources (for example, by 'go run'), the version is '(devel)'.
+func getBuildInfoGoVersion() string {
+	binaryInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if binaryInfo.Main.Path == buildInfoGoModulePath {
+		return binaryInfo.Main.Version
+	}
+	for _, module := range binaryInfo.Deps {
+		if module.Path == buildInfoGoModulePath {
+			return module.Version
+		}
+	}
+	return ""
+}
+
+// Records this library as the agent and the build agent of the build-info, unless they were already set, and the details of the machine, which runs it, on the build agent.
+func (b *Build) setAgentsDetails(buildInfo *entities.BuildInfo) {
+	if b.skipAgentsDetails {
+		return
+	}
+	version := getBuildInfoGoVersion()
+	if buildInfo.Agent == nil {
+		buildInfo.Agent = &entities.Agent{}
+	}
+	if buildInfo.Agent.Name == "" {
+		buildInfo.Agent.Name, buildInfo.Agent.Version = cliutils.ClientAgent, version
+	}
+	if buildInfo.BuildAgent == nil {
+		buildInfo.BuildAgent = &entities.Agent{}
+	}
+	// The build agent is named 'GENERIC' by default, so it's considered set only if its version was set.
+	if buildInfo.BuildAgent.Version == "" {
+		buildInfo.BuildAgent.Name, buildInfo.BuildAgent.Version = cliutils.ClientAgent, version
+	}
+	buildInfo.BuildAgent.GoVersion = runtime.Version()
+	buildInfo.BuildAgent.Os = runtime.GOOS
+	buildInfo.BuildAgent.Arch = runtime.GOARCH
+	hostname, err := os.Hostname()
+	if err != nil {
+		b.logger.Debug("Couldn't get the hostname of the build agent:", err.Error())
+		return
+	}
+	buildInfo.BuildAgent.Hostname = hostname
+}
@@ -0,0 +1,202 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency property, which holds the path of a component in the Android SDK, as used by sdkmanager (for example: 'platforms;android-34').
+	AndroidSdkPathProperty = "android.sdkPath"
+)
+
+// AndroidModule records the Android SDK components, which are used to build an Android project: the SDK platforms, the build-tools and the NDKs.
+// These components affect the content of the APKs built by the project, so they're recorded as the dependencies of a separate tooling module.
+type AndroidModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	sdkRoot         string
+}
+
+// Pass an empty string for srcPath if the root of the Android project is the working directory.
+func newAndroidModule(srcPath string, containingBuild *Build) (*AndroidModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, err := buildutils.GetAndroidRootProjectName(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No root project name is set in the Gradle settings file. Using the directory name: %s as module name.", name))
+	}
+
+	return &AndroidModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the Android SDK components, whose versions are set in the Gradle build files of the project.
+func (am *AndroidModule) CalcDependencies() error {
+	started := time.Now()
+	if !am.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := am.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: am.name, Type: entities.Android, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(am.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return am.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (am *AndroidModule) SetName(name string) {
+	am.name = name
+}
+
+func (am *AndroidModule) SetProperties(properties map[string]string) {
+	am.properties = properties
+}
+
+// SetSdkRoot sets the path of the Android SDK, in which the components are installed.
+// By default, the sdk.dir property in the local.properties file of the project is used, or the ANDROID_HOME (or ANDROID_SDK_ROOT) environment variable.
+func (am *AndroidModule) SetSdkRoot(sdkRoot string) {
+	am.sdkRoot = sdkRoot
+}
+
+func (am *AndroidModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !am.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: am.name, ModuleType: entities.Android, Artifacts: artifacts}
+	return am.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (am *AndroidModule) loadDependencies() ([]entities.Dependency, error) {
+	sdkRoot := am.sdkRoot
+	if sdkRoot == "" {
+		var err error
+		if sdkRoot, err = buildutils.GetAndroidSdkRoot(am.srcPath); err != nil {
+			return nil, err
+		}
+		if sdkRoot == "" {
+			return nil, errors.New("couldn't find the Android SDK. Set the sdk.dir property in the local.properties file of the project, or the ANDROID_HOME environment variable")
+		}
+	}
+	versions, err := buildutils.GetAndroidComponentsVersions(am.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []entities.Dependency
+	for _, apiLevel := range versions.Platforms {
+		platform, err := buildutils.GetAndroidPlatform(sdkRoot, apiLevel)
+		if err != nil {
+			return nil, err
+		}
+		dependency, err := am.createPlatformDependency(apiLevel, platform)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	for _, version := range versions.BuildTools {
+		buildTools, err := buildutils.GetAndroidBuildTools(sdkRoot, version)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, am.createComponentDependency("build-tools", version, "android-build-tools", buildTools))
+	}
+	ndks, err := am.loadNdks(sdkRoot, versions.Ndks)
+	if err != nil {
+		return nil, err
+	}
+	dependencies = append(dependencies, ndks...)
+	// The components are used by all the modules of the project, so all of them are considered as direct dependencies.
+	for i := range dependencies {
+		dependencies[i].RequestedBy = [][]string{{am.name}}
+	}
+	return dependencies, nil
+}
+
+// Returns the NDKs, whose versions are set in the Gradle build files.
+// If no version is set, the NDK set by the (deprecated) ndk.dir property in the local.properties file of the project is returned.
+func (am *AndroidModule) loadNdks(sdkRoot string, versions []string) ([]entities.Dependency, error) {
+	var dependencies []entities.Dependency
+	for _, version := range versions {
+		ndk, err := buildutils.GetAndroidNdk(sdkRoot, version)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, am.createComponentDependency("ndk", version, "android-ndk", ndk))
+	}
+	if len(versions) > 0 {
+		return dependencies, nil
+	}
+	localProperties, err := buildutils.ReadJavaPropertiesFile(filepath.Join(am.srcPath, buildutils.AndroidLocalPropertiesFileName))
+	if err != nil || localProperties["ndk.dir"] == "" {
+		return nil, err
+	}
+	ndkDir := localProperties["ndk.dir"]
+	if !filepath.IsAbs(ndkDir) {
+		ndkDir = filepath.Join(am.srcPath, ndkDir)
+	}
+	ndk, err := buildutils.GetAndroidNdkFromDir(ndkDir)
+	if err != nil || ndk == nil {
+		return nil, err
+	}
+	return []entities.Dependency{am.createComponentDependency("ndk", ndk.Revision, "android-ndk", ndk)}, nil
+}
+
+// Creates the build-info dependency of an SDK platform, for example: 'android-34:3', where '3' is the revision of the installed platform.
+// Its checksums are the checksums of the android.jar file of the platform, which the project is compiled against.
+func (am *AndroidModule) createPlatformDependency(apiLevel string, platform *buildutils.AndroidSdkComponent) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: "android-" + apiLevel, Type: "android-platform"}
+	if platform == nil {
+		am.containingBuild.logger.Debug(fmt.Sprintf("The Android SDK platform %s isn't installed. Its revision and checksums can't be collected.", apiLevel))
+		return dependency, nil
+	}
+	if platform.Revision != "" {
+		dependency.Id += ":" + platform.Revision
+	}
+	setDependencyProperties(&dependency, map[string]string{AndroidSdkPathProperty: platform.SdkPath})
+	jarPath := platform.GetPlatformJarPath()
+	exists, err := utils.IsFileExists(jarPath, true)
+	if err != nil || !exists {
+		return dependency, err
+	}
+	checksum, err := am.containingBuild.getFileChecksum(jarPath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
+
+// Creates the build-info dependency of a versioned component, for example: 'build-tools:34.0.0'.
+func (am *AndroidModule) createComponentDependency(name, version, dependencyType string, component *buildutils.AndroidSdkComponent) entities.Dependency {
+	dependency := entities.Dependency{Id: name + ":" + version, Type: dependencyType}
+	if component == nil {
+		am.containingBuild.logger.Debug(fmt.Sprintf("The Android SDK component %s %s isn't installed.", name, version))
+		return dependency
+	}
+	setDependencyProperties(&dependency, map[string]string{AndroidSdkPathProperty: component.SdkPath})
+	return dependency
+}
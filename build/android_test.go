@@ -0,0 +1,82 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForAndroidProject(t *testing.T) {
+	service := NewBuildInfoService()
+	androidBuild, err := service.GetOrCreateBuild("build-info-go-test-android", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, androidBuild.Clean())
+	}()
+	androidModule, err := androidBuild.AddAndroidModule(filepath.Join("testdata", "android", "project"))
+	if assert.NoError(t, err) {
+		androidModule.SetSdkRoot(filepath.Join("testdata", "android", "sdk"))
+		err = androidModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := androidBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Android, module.Type)
+		assert.Equal(t, "HelloApp", module.Id)
+
+		// The compileSdk version in the app/build directory is skipped.
+		assert.Len(t, module.Dependencies, 4)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			switch dependency.Id {
+			case "android-33":
+				// The platform isn't installed.
+				assert.Equal(t, "android-platform", dependency.Type)
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Empty(t, dependency.Properties)
+			case "android-34:3":
+				assert.Equal(t, "android-platform", dependency.Type)
+				assert.Equal(t, entities.Checksum{Md5: "819cef8270322fc7ee38c99e713ba3f8", Sha1: "28eff533f3dad8cf4ccc880f706f5214070233b0", Sha256: "4c4011384be27def771ce98d62fd42b71ca88b7972a84278b8d69e6df9db0e2e"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{AndroidSdkPathProperty: "platforms;android-34"}, dependency.Properties)
+			case "build-tools:34.0.0":
+				assert.Equal(t, "android-build-tools", dependency.Type)
+				assert.Equal(t, map[string]string{AndroidSdkPathProperty: "build-tools;34.0.0"}, dependency.Properties)
+			case "ndk:26.1.10909125":
+				assert.Equal(t, "android-ndk", dependency.Type)
+				assert.Equal(t, map[string]string{AndroidSdkPathProperty: "ndk;26.1.10909125"}, dependency.Properties)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForAndroidProjectWithNdkDir(t *testing.T) {
+	service := NewBuildInfoService()
+	androidBuild, err := service.GetOrCreateBuild("build-info-go-test-android-ndk-dir", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, androidBuild.Clean())
+	}()
+	androidModule, err := androidBuild.AddAndroidModule(filepath.Join("testdata", "android", "ndkproject"))
+	if assert.NoError(t, err) {
+		androidModule.SetSdkRoot(filepath.Join("testdata", "android", "sdk"))
+		err = androidModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := androidBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		// The settings file doesn't exist, so the directory name is used.
+		assert.Equal(t, "ndkproject", module.Id)
+
+		var ids []string
+		for _, dependency := range module.Dependencies {
+			ids = append(ids, dependency.Id)
+		}
+		assert.ElementsMatch(t, []string{"android-34:3", "ndk:21.4.7075529"}, ids)
+	}
+}
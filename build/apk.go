@@ -0,0 +1,154 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the architecture of a package, the source package it was built from, the aports commit it was built from, and its license.
+	ApkArchProperty    = "apk.arch"
+	ApkOriginProperty  = "apk.origin"
+	ApkCommitProperty  = "apk.commit"
+	ApkLicenseProperty = "apk.license"
+
+	// The name of the module, if the system doesn't have an os-release file.
+	defaultApkModuleName = "alpine"
+)
+
+type ApkModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	// The root directory of the system, whose installed packages are collected.
+	rootDir string
+}
+
+// Pass an empty string for rootDir to collect the packages installed on the running system.
+func newApkModule(rootDir string, containingBuild *Build) (*ApkModule, error) {
+	if rootDir == "" {
+		rootDir = string(filepath.Separator)
+	}
+	name, err := buildutils.GetOsModuleId(rootDir, defaultApkModuleName)
+	if err != nil {
+		return nil, err
+	}
+	if name == defaultApkModuleName {
+		containingBuild.logger.Debug(fmt.Sprintf("No os-release file was found in %s. Using the default name: %s as module name.", rootDir, name))
+	}
+	return &ApkModule{name: name, rootDir: rootDir, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages installed on the system.
+func (am *ApkModule) CalcDependencies() error {
+	started := time.Now()
+	if !am.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := am.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: am.name, Type: entities.Apk, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(am.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return am.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (am *ApkModule) SetName(name string) {
+	am.name = name
+}
+
+func (am *ApkModule) SetProperties(properties map[string]string) {
+	am.properties = properties
+}
+
+func (am *ApkModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !am.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: am.name, ModuleType: entities.Apk, Artifacts: artifacts}
+	return am.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// Returns the installed packages from the installed database of the system, or (if the database doesn't exist) from the output of 'apk info -v'.
+func (am *ApkModule) loadPackages() ([]buildutils.ApkPackage, error) {
+	exists, err := utils.IsFileExists(filepath.Join(am.rootDir, buildutils.ApkInstalledDbPath), true)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return buildutils.ReadApkInstalledDb(am.rootDir)
+	}
+	am.containingBuild.logger.Debug(fmt.Sprintf("The APK installed database wasn't found in %s. Listing the installed packages with 'apk info'.", am.rootDir))
+	return buildutils.RunApkInfo(am.rootDir)
+}
+
+func (am *ApkModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := am.loadPackages()
+	if err != nil {
+		return nil, err
+	}
+	world, err := buildutils.ReadApkWorld(am.rootDir)
+	if err != nil {
+		return nil, err
+	}
+	// The dependencies of a package may refer to the names of other packages, or to the names they provide (for example: 'so:libc.musl-x86_64.so.1').
+	providers := make(map[string]string)
+	for _, pkg := range packages {
+		for _, provided := range pkg.Provides {
+			providers[buildutils.GetApkDependencyName(provided)] = pkg.Id()
+		}
+	}
+	for _, pkg := range packages {
+		providers[pkg.Name] = pkg.Id()
+	}
+
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	required := make(map[string]bool)
+	for _, pkg := range packages {
+		dependency := entities.Dependency{Id: pkg.Id(), Type: "apk", Checksum: entities.Checksum{Sha1: pkg.Sha1}}
+		setDependencyProperties(&dependency, map[string]string{
+			ApkArchProperty:    pkg.Arch,
+			ApkOriginProperty:  pkg.Origin,
+			ApkCommitProperty:  pkg.Commit,
+			ApkLicenseProperty: pkg.License,
+		})
+		dependenciesMap[pkg.Id()] = dependency
+		for _, name := range pkg.Depends {
+			childId, ok := providers[buildutils.GetApkDependencyName(name)]
+			if !ok || childId == pkg.Id() {
+				continue
+			}
+			dependenciesGraph[pkg.Id()] = append(dependenciesGraph[pkg.Id()], childId)
+			required[childId] = true
+		}
+	}
+	if len(world) > 0 {
+		for _, name := range world {
+			if id, ok := providers[name]; ok {
+				dependenciesGraph[am.name] = append(dependenciesGraph[am.name], id)
+			}
+		}
+	} else {
+		// Without a world file, the packages, which aren't required by other packages, are considered as the explicitly installed ones.
+		for _, pkg := range packages {
+			if !required[pkg.Id()] {
+				dependenciesGraph[am.name] = append(dependenciesGraph[am.name], pkg.Id())
+			}
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(am.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, am.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
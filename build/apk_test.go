@@ -0,0 +1,90 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForApkPackages(t *testing.T) {
+	service := NewBuildInfoService()
+	apkBuild, err := service.GetOrCreateBuild("build-info-go-test-apk", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, apkBuild.Clean())
+	}()
+	apkModule, err := apkBuild.AddApkModule(filepath.Join("testdata", "apk", "root"))
+	if assert.NoError(t, err) {
+		err = apkModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := apkBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Apk, module.Type)
+		assert.Equal(t, "alpine:3.18.4", module.Id)
+
+		assert.Len(t, module.Dependencies, 7)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "apk", dependency.Type)
+			assert.NotEmpty(t, dependency.Sha1)
+			assert.Equal(t, "x86_64", dependency.Properties[ApkArchProperty])
+			switch dependency.Id {
+			case "busybox:1.36.1-r5", "curl:8.4.0-r0":
+				// The packages listed in the world file.
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "musl:1.2.4-r2":
+				assert.Equal(t, entities.Checksum{Sha1: "bfb7f66e1fc13a416fadc90d6bd12e6691877f8a"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{
+					ApkArchProperty:    "x86_64",
+					ApkOriginProperty:  "musl",
+					ApkCommitProperty:  "a3f8c5b9e1d4f6a7b8c9d0e1f2a3b4c5d6e7f8a9",
+					ApkLicenseProperty: "MIT",
+				}, dependency.Properties)
+				assert.Contains(t, dependency.RequestedBy, []string{"busybox:1.36.1-r5", module.Id})
+			case "libcurl:8.4.0-r0":
+				// Required through the library it provides.
+				assert.Equal(t, "curl", dependency.Properties[ApkOriginProperty])
+				assert.Equal(t, [][]string{{"curl:8.4.0-r0", module.Id}}, dependency.RequestedBy)
+			case "libssl3:3.1.4-r1":
+				assert.Equal(t, [][]string{{"libcurl:8.4.0-r0", "curl:8.4.0-r0", module.Id}}, dependency.RequestedBy)
+			case "libcrypto3:3.1.4-r1":
+				assert.Contains(t, dependency.RequestedBy, []string{"libssl3:3.1.4-r1", "libcurl:8.4.0-r0", "curl:8.4.0-r0", module.Id})
+			case "ca-certificates-bundle:20230506-r0":
+				assert.Contains(t, dependency.RequestedBy, []string{"curl:8.4.0-r0", module.Id})
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForApkPackagesWithoutWorld(t *testing.T) {
+	service := NewBuildInfoService()
+	apkBuild, err := service.GetOrCreateBuild("build-info-go-test-apk-no-world", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, apkBuild.Clean())
+	}()
+	apkModule, err := apkBuild.AddApkModule(filepath.Join("testdata", "apk", "root-no-world"))
+	if assert.NoError(t, err) {
+		err = apkModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := apkBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		module := buildInfo.Modules[0]
+		// The system doesn't have an os-release file.
+		assert.Equal(t, "alpine", module.Id)
+		assert.Len(t, module.Dependencies, 7)
+		for _, dependency := range module.Dependencies {
+			// The packages, which aren't required by other packages, are considered as the explicitly installed ones.
+			if dependency.Id == "busybox:1.36.1-r5" || dependency.Id == "curl:8.4.0-r0" {
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			} else {
+				assert.NotContains(t, dependency.RequestedBy, []string{module.Id})
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package build
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// The number of files, whose checksums are calculated in parallel, when collecting artifacts.
+const collectArtifactsThreads = 3
+
+// CollectArtifacts returns the files, which match the glob patterns (for example, 'dist/**/*.zip'), as artifacts. See utils.ListFilesByGlob() for the patterns.
+// The checksums of the files are calculated in parallel. The paths of the artifacts are the paths of the files, as matched by the patterns, and their types are the extensions of the files.
+// A file, which several patterns match, is collected once.
+func (b *Build) CollectArtifacts(patterns ...string) ([]entities.Artifact, error) {
+	var files []string
+	collected := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := utils.ListFilesByGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range matches {
+			if !collected[file] {
+				collected[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	artifacts := make([]entities.Artifact, len(files))
+	errs := make([]error, len(files))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < collectArtifactsThreads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				checksum, err := b.getFileChecksum(files[index])
+				if err != nil {
+					errs[index] = err
+					continue
+				}
+				file := filepath.ToSlash(files[index])
+				artifacts[index] = entities.Artifact{Name: filepath.Base(file), Type: strings.TrimPrefix(filepath.Ext(file), "."), Path: file, Checksum: checksum}
+			}
+		}()
+	}
+	for i := range files {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return artifacts, nil
+}
+
+// AddArtifactsByPattern collects the files, which match the glob patterns, and adds them as the artifacts of a generic module. See CollectArtifacts().
+// To add the artifacts to a module of another type, pass the collected artifacts to its AddArtifacts() method.
+func (b *Build) AddArtifactsByPattern(moduleId string, patterns ...string) error {
+	if !b.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	artifacts, err := b.CollectArtifacts(patterns...)
+	if err != nil {
+		return err
+	}
+	partial := &entities.Partial{ModuleId: moduleId, ModuleType: entities.Generic, Artifacts: artifacts}
+	return b.SavePartialBuildInfo(partial)
+}
@@ -0,0 +1,47 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddArtifactsByPattern(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "dist", "linux"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dist", "app.zip"), []byte("build-info"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dist", "linux", "app.tar.gz"), []byte("build-info"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dist", "notes.txt"), []byte("build-info"), 0600))
+
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-artifacts-pattern-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	// The zip file matches both patterns, but it's collected once.
+	assert.NoError(t, build.AddArtifactsByPattern("my-module", filepath.Join(dir, "dist", "**", "*.zip"), filepath.Join(dir, "dist", "**", "*.gz")))
+	assert.Error(t, build.AddArtifactsByPattern("my-module", filepath.Join(dir, "[")))
+
+	buildInfo, err := build.ToBuildInfo()
+	assert.NoError(t, err)
+	assert.Len(t, buildInfo.Modules, 1)
+	assert.Equal(t, entities.Generic, buildInfo.Modules[0].Type)
+	artifacts := buildInfo.Modules[0].Artifacts
+	assert.Len(t, artifacts, 2)
+	for _, artifact := range artifacts {
+		assert.Equal(t, "95201f2d94449f4d01740df7b074440bae2d9e8832265cf903eef49294173869", artifact.Sha256)
+	}
+	assert.ElementsMatch(t, []string{"app.zip", "app.tar.gz"}, []string{artifacts[0].Name, artifacts[1].Name})
+	for _, artifact := range artifacts {
+		if artifact.Name == "app.zip" {
+			assert.Equal(t, "zip", artifact.Type)
+			assert.Equal(t, filepath.ToSlash(filepath.Join(dir, "dist", "app.zip")), artifact.Path)
+		} else {
+			assert.Equal(t, "gz", artifact.Type)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+type BazelModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the Bazel workspace in the working directory.
+func newBazelModule(srcPath string, containingBuild *Build) (*BazelModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = findBazelWorkspaceDir(srcPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, err := buildutils.GetBazelProjectName(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the %s or WORKSPACE files. Using the directory name: %s as module name.", buildutils.BazelModuleFileName, name))
+	}
+
+	return &BazelModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// Returns the root directory of the Bazel workspace, which contains a MODULE.bazel or WORKSPACE file, starting the search from the given directory.
+func findBazelWorkspaceDir(dirPath string) (string, error) {
+	var err error
+	for _, fileName := range []string{buildutils.BazelModuleFileName, buildutils.BazelWorkspaceFileName, buildutils.BazelWorkspaceLegacyFileName} {
+		var srcPath string
+		if srcPath, err = utils.FindFileInDirAndParents(dirPath, fileName); err == nil {
+			return srcPath, nil
+		}
+	}
+	return "", err
+}
+
+// CalcDependencies collects the external repositories of the workspace.
+// With Bzlmod, the modules in the graph returned by 'bazel mod graph' are collected. The repositories defined in the WORKSPACE file are collected using 'bazel query'.
+func (bm *BazelModule) CalcDependencies() error {
+	started := time.Now()
+	if !bm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := bm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: bm.name, Type: entities.Bazel, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(bm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return bm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (bm *BazelModule) SetName(name string) {
+	bm.name = name
+}
+
+func (bm *BazelModule) SetProperties(properties map[string]string) {
+	bm.properties = properties
+}
+
+func (bm *BazelModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !bm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: bm.name, ModuleType: entities.Bazel, Artifacts: artifacts}
+	return bm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// AddTargetsArtifacts adds the output files of the given built targets (for example: '//app:server') as artifacts of the module.
+func (bm *BazelModule) AddTargetsArtifacts(targets ...string) error {
+	files, err := buildutils.RunBazelCqueryFiles(bm.srcPath, targets)
+	if err != nil {
+		return err
+	}
+	var artifacts []entities.Artifact
+	for _, file := range files {
+		checksum, err := bm.containingBuild.getFileChecksum(filepath.Join(bm.srcPath, file))
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, entities.Artifact{Name: filepath.Base(file), Type: strings.TrimPrefix(filepath.Ext(file), "."), Path: file, Checksum: checksum})
+	}
+	return bm.AddArtifacts(artifacts...)
+}
+
+func (bm *BazelModule) loadDependencies() ([]entities.Dependency, error) {
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	bzlmodEnabled, err := utils.IsFileExists(filepath.Join(bm.srcPath, buildutils.BazelModuleFileName), true)
+	if err != nil {
+		return nil, err
+	}
+	if bzlmodEnabled {
+		if err = bm.loadBzlmodDependencies(dependenciesMap, dependenciesGraph); err != nil {
+			return nil, err
+		}
+	}
+	workspaceFileExists, err := hasBazelWorkspaceFile(bm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if workspaceFileExists {
+		repositories, err := buildutils.RunBazelQueryExternalRepositories(bm.srcPath)
+		if err != nil {
+			if !bzlmodEnabled {
+				return nil, err
+			}
+			// The //external package isn't available when the WORKSPACE is disabled.
+			bm.containingBuild.logger.Debug("Couldn't query the repositories defined in the WORKSPACE:", err.Error())
+		}
+		addBazelRepositoriesDependencies(bm.name, repositories, dependenciesMap, dependenciesGraph)
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(bm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, bm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Returns true if a WORKSPACE file exists in the given directory.
+func hasBazelWorkspaceFile(srcPath string) (bool, error) {
+	for _, fileName := range []string{buildutils.BazelWorkspaceFileName, buildutils.BazelWorkspaceLegacyFileName} {
+		exists, err := utils.IsFileExists(filepath.Join(srcPath, fileName), true)
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
+
+func (bm *BazelModule) loadBzlmodDependencies(dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) error {
+	root, err := buildutils.RunBazelModGraph(bm.srcPath)
+	if err != nil {
+		return err
+	}
+	modulesKeys := addBazelModulesToGraph(bm.name, root.Dependencies, dependenciesMap, dependenciesGraph)
+	if len(modulesKeys) == 0 {
+		return nil
+	}
+	// The download checksums are taken from the repositories' definitions.
+	keys := make([]string, 0, len(modulesKeys))
+	for key := range modulesKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	repositories, err := buildutils.RunBazelShowRepo(bm.srcPath, keys)
+	if err != nil {
+		bm.containingBuild.logger.Debug("Couldn't get the checksums of the modules using 'bazel mod show_repo':", err.Error())
+		return nil
+	}
+	setBazelModulesChecksums(repositories, modulesKeys, dependenciesMap)
+	return nil
+}
+
+// Adds the modules in the tree returned by 'bazel mod graph' to the dependencies map and graph.
+// Returns a map of the modules' keys to their dependencies IDs.
+func addBazelModulesToGraph(parentId string, modules []buildutils.BazelModule, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) map[string]string {
+	modulesKeys := make(map[string]string)
+	for _, module := range modules {
+		dependenciesGraph[parentId] = append(dependenciesGraph[parentId], module.Id())
+		if _, exists := dependenciesMap[module.Id()]; exists {
+			continue
+		}
+		dependenciesMap[module.Id()] = entities.Dependency{Id: module.Id(), Type: "bazel_module"}
+		modulesKeys[module.Key] = module.Id()
+		for key, id := range addBazelModulesToGraph(module.Id(), module.Dependencies, dependenciesMap, dependenciesGraph) {
+			modulesKeys[key] = id
+		}
+	}
+	return modulesKeys
+}
+
+func setBazelModulesChecksums(repositories []buildutils.BazelRepository, modulesKeys map[string]string, dependenciesMap map[string]entities.Dependency) {
+	for _, repository := range repositories {
+		id, ok := modulesKeys[repository.ModuleKey]
+		if !ok || repository.Sha256 == "" {
+			continue
+		}
+		dependency := dependenciesMap[id]
+		dependency.Sha256 = repository.Sha256
+		dependenciesMap[id] = dependency
+	}
+}
+
+// Adds the repositories defined in the WORKSPACE as direct dependencies of the module.
+func addBazelRepositoriesDependencies(moduleId string, repositories []buildutils.BazelRepository, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) {
+	for _, repository := range repositories {
+		if _, exists := dependenciesMap[repository.Name]; exists {
+			continue
+		}
+		dependenciesMap[repository.Name] = entities.Dependency{Id: repository.Name, Type: repository.Kind, Checksum: entities.Checksum{Sha256: repository.Sha256}}
+		dependenciesGraph[moduleId] = append(dependenciesGraph[moduleId], repository.Name)
+	}
+}
@@ -0,0 +1,84 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBazelDependencies(t *testing.T) {
+	service := NewBuildInfoService()
+	bazelBuild, err := service.GetOrCreateBuild("build-info-go-test-bazel", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, bazelBuild.Clean())
+	}()
+	bazelModule, err := bazelBuild.AddBazelModule(filepath.Join("testdata", "bazel", "project"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	// The module is named after the name and version in the MODULE.bazel file.
+	assert.Equal(t, "hello:1.0.0", bazelModule.name)
+
+	// Bzlmod modules
+	output, err := os.ReadFile(filepath.Join("testdata", "bazel", "mod-graph.json"))
+	assert.NoError(t, err)
+	root, err := buildutils.ParseBazelModGraph(output)
+	assert.NoError(t, err)
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	modulesKeys := addBazelModulesToGraph(bazelModule.name, root.Dependencies, dependenciesMap, dependenciesGraph)
+	assert.Equal(t, map[string]string{
+		"protobuf@21.7":   "protobuf:21.7",
+		"rules_cc@0.0.9":  "rules_cc:0.0.9",
+		"platforms@0.0.7": "platforms:0.0.7",
+		"zlib@1.2.13":     "zlib:1.2.13",
+	}, modulesKeys)
+	output, err = os.ReadFile(filepath.Join("testdata", "bazel", "show-repo.txt"))
+	assert.NoError(t, err)
+	repositories, err := buildutils.ParseBazelRepositories(output)
+	assert.NoError(t, err)
+	setBazelModulesChecksums(repositories, modulesKeys, dependenciesMap)
+
+	// WORKSPACE repositories
+	output, err = os.ReadFile(filepath.Join("testdata", "bazel", "query-output.txt"))
+	assert.NoError(t, err)
+	repositories, err = buildutils.ParseBazelRepositories(output)
+	assert.NoError(t, err)
+	addBazelRepositoriesDependencies(bazelModule.name, repositories, dependenciesMap, dependenciesGraph)
+
+	populateRequestedByField(bazelModule.name, [][]string{{}}, dependenciesMap, dependenciesGraph, entities.DefaultRequestedByLimits)
+	dependencies := dependenciesMapToList(dependenciesMap)
+	assert.Len(t, dependencies, 6)
+	for _, dependency := range dependencies {
+		switch dependency.Id {
+		case "protobuf:21.7":
+			assert.Equal(t, "bazel_module", dependency.Type)
+			assert.Equal(t, "5493a21f5ed3fc502e66fec6b9449c06a551ced63002fa48903c40dfa8de7a4a", dependency.Sha256)
+			assert.Equal(t, [][]string{{"hello:1.0.0"}}, dependency.RequestedBy)
+		case "rules_cc:0.0.9":
+			assert.Equal(t, "2037875b9a4456dce4a79d112a8ae885bbc4aad968e6587dca6e64f3a0900cdf", dependency.Sha256)
+			assert.ElementsMatch(t, [][]string{{"protobuf:21.7", "hello:1.0.0"}, {"hello:1.0.0"}}, dependency.RequestedBy)
+		case "platforms:0.0.7":
+			assert.Equal(t, "3a561c99e7bdbe9173aa653fd579fe849f1d8d67395780ab4770b1f381721d51", dependency.Sha256)
+			assert.Contains(t, dependency.RequestedBy, []string{"rules_cc:0.0.9", "protobuf:21.7", "hello:1.0.0"})
+		case "zlib:1.2.13":
+			// Local repositories have no download checksum.
+			assert.Empty(t, dependency.Sha256)
+			assert.Equal(t, [][]string{{"protobuf:21.7", "hello:1.0.0"}}, dependency.RequestedBy)
+		case "com_google_googletest":
+			assert.Equal(t, "http_archive", dependency.Type)
+			assert.Equal(t, "8ad598c73ad796e0d8280b082cebd82a630d73e73cd3c70057938a6501bba5d7", dependency.Sha256)
+			assert.Equal(t, [][]string{{"hello:1.0.0"}}, dependency.RequestedBy)
+		case "hello_data":
+			assert.Equal(t, "http_file", dependency.Type)
+			assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", dependency.Sha256)
+		default:
+			assert.Fail(t, "Unexpected dependency "+dependency.Id)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/jfrog/build-info-go/utils/pythonutils"
 
+	buildutils "github.com/jfrog/build-info-go/build/utils"
 	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/build-info-go/utils"
 )
@@ -36,6 +37,21 @@ type Build struct {
 	buildAgentVersion string
 	principal         string
 	buildUrl          string
+	// If true, this library and the machine, which runs it, aren't recorded as the agents of the build-info.
+	skipAgentsDetails bool
+	// If true, the SHA-512 checksums of the files of the dependencies and the artifacts are calculated too.
+	calcSha512Checksums bool
+	// Bounds the RequestedBy paths of the dependencies. If it's nil, the default limits are used.
+	requestedByLimits *entities.RequestedByLimits
+	// How the dependencies, which several modules have, are recorded in the build-info.
+	dependencyDeduplicationPolicy entities.DependencyDeduplicationPolicy
+	// If set, only the dependencies, which match the include filter and don't match the exclude filter, are saved.
+	includeDependencies *entities.DependencyFilter
+	excludeDependencies *entities.DependencyFilter
+	// If true, the build-infos, which the modules save in the local cache, are compressed by gzip.
+	compressBuildInfo bool
+	// Stores the partials of the build. If it's nil, they're stored in the builds directory.
+	partialsStore PartialsStore
 }
 
 func NewBuild(buildName, buildNumber, projectKey, tempDirPath string, logger utils.Log) *Build {
@@ -63,6 +79,59 @@ func (b *Build) SetBuildAgentVersion(buildAgentVersion string) {
 	b.buildAgentVersion = buildAgentVersion
 }
 
+// SetCollectAgentsDetails sets whether to record this library (its name and version) as the agent and the build agent, if they weren't set,
+// and the details of the machine (the Go version, OS, architecture and hostname) on the build agent. They're recorded by default.
+// This field is not saved in local cache. It is used when saving a build-info using the SaveBuildInfo() function, and when creating a build-info using the ToBuildInfo() function.
+func (b *Build) SetCollectAgentsDetails(collectAgentsDetails bool) {
+	b.skipAgentsDetails = !collectAgentsDetails
+}
+
+// SetCalcSha512Checksums sets whether to calculate the SHA-512 checksums of the dependencies and the artifacts, in addition to their MD5, SHA-1 and SHA-256 checksums.
+// They're calculated only from files, which the modules read locally. The checksums, which are taken from lock files, or calculated by the Maven, Gradle, npm and .NET modules, don't include SHA-512. They aren't calculated by default.
+// This field is not saved in local cache. It is used when the modules of the build calculate their dependencies and artifacts.
+func (b *Build) SetCalcSha512Checksums(calcSha512Checksums bool) {
+	b.calcSha512Checksums = calcSha512Checksums
+}
+
+// SetRequestedByLimits sets the maximum depth and number of the RequestedBy paths of each dependency, for projects, whose dependency graphs are too deep or dense to record all their paths.
+// By default, each dependency has up to entities.RequestedByMaxLength paths, and their depth isn't limited. The Maven, Gradle, npm, Yarn, Python and .NET modules always use the default limits.
+// This field is not saved in local cache. It is used when the modules of the build calculate their dependencies.
+func (b *Build) SetRequestedByLimits(limits entities.RequestedByLimits) {
+	b.requestedByLimits = &limits
+}
+
+// SetDependencyDeduplicationPolicy sets how the dependencies, which several modules of the build have, are recorded. By default, each module keeps the full details of its dependencies.
+// See entities.BuildInfo.DeduplicateDependencies() for the policies.
+// This field is not saved in local cache. It is used only when creating a build-info using the ToBuildInfo() function.
+func (b *Build) SetDependencyDeduplicationPolicy(policy entities.DependencyDeduplicationPolicy) {
+	b.dependencyDeduplicationPolicy = policy
+}
+
+// SetIncludeDependencies sets a filter, so only the dependencies, which match it, are saved (for example, to keep only the dependencies, which are shipped).
+// This field is not saved in local cache. It is used when the modules of the build save their dependencies, using the SaveBuildInfo() function.
+func (b *Build) SetIncludeDependencies(filter entities.DependencyFilter) {
+	b.includeDependencies = &filter
+}
+
+// SetExcludeDependencies sets a filter, so the dependencies, which match it, aren't saved (for example, internal-only or test dependencies).
+// This field is not saved in local cache. It is used when the modules of the build save their dependencies, using the SaveBuildInfo() function.
+func (b *Build) SetExcludeDependencies(filter entities.DependencyFilter) {
+	b.excludeDependencies = &filter
+}
+
+// SetCompressBuildInfo sets whether to compress the build-infos, which the modules save in the local cache, by gzip. Large builds (such as monorepo builds) may save build-infos of hundreds of megabytes.
+// The saved build-infos are read whether they're compressed or not, so the build can be created by processes, which compress them differently. They aren't compressed by default.
+// This field is not saved in local cache. It is used when saving a build-info using the SaveBuildInfo() function, and when the Maven and Gradle modules update the build-infos, which their extractors generate.
+func (b *Build) SetCompressBuildInfo(compressBuildInfo bool) {
+	b.compressBuildInfo = compressBuildInfo
+}
+
+// SetPartialsStore sets where the partials of the build are stored. By default, they're stored as files in the builds directory.
+// All the processes, which collect the partials of the build, must use the same store.
+func (b *Build) SetPartialsStore(partialsStore PartialsStore) {
+	b.partialsStore = partialsStore
+}
+
 // This field is not saved in local cache. It is used only when creating a build-info using the ToBuildInfo() function.
 func (b *Build) SetPrincipal(principal string) {
 	b.principal = principal
@@ -78,6 +147,11 @@ func (b *Build) AddGoModule(srcPath string) (*GoModule, error) {
 	return newGoModule(srcPath, b)
 }
 
+// AddGoWorkspace adds the modules of a Go workspace to this Build. Pass srcPath as an empty string if the dir of the go.work file is the working directory.
+func (b *Build) AddGoWorkspace(srcPath string) (*GoWorkspace, error) {
+	return newGoWorkspace(srcPath, b)
+}
+
 // AddMavenModule adds a Maven module to this Build. Pass srcPath as an empty string if the root of the Maven project is the working directory.
 func (b *Build) AddMavenModule(srcPath string) (*MavenModule, error) {
 	return newMavenModule(b, srcPath)
@@ -113,6 +187,214 @@ func (b *Build) AddDotnetModules(srcPath string) (*DotnetModule, error) {
 	return newDotnetModule(srcPath, b)
 }
 
+// AddCargoModule adds a Cargo module to this Build. Pass srcPath as an empty string if the root of the Cargo project is the working directory.
+func (b *Build) AddCargoModule(srcPath string) (*CargoModule, error) {
+	return newCargoModule(srcPath, b)
+}
+
+// AddComposerModule adds a Composer module to this Build. Pass srcPath as an empty string if the root of the Composer project is the working directory.
+func (b *Build) AddComposerModule(srcPath string) (*ComposerModule, error) {
+	return newComposerModule(srcPath, b)
+}
+
+// AddRubyModule adds a Ruby (Bundler) module to this Build. Pass srcPath as an empty string if the root of the Ruby project is the working directory.
+func (b *Build) AddRubyModule(srcPath string) (*RubyModule, error) {
+	return newRubyModule(srcPath, b)
+}
+
+// AddCocoapodsModule adds a CocoaPods module to this Build. Pass srcPath as an empty string if the root of the CocoaPods project is the working directory.
+func (b *Build) AddCocoapodsModule(srcPath string) (*CocoapodsModule, error) {
+	return newCocoapodsModule(srcPath, b)
+}
+
+// AddSwiftModule adds a Swift Package Manager module to this Build. Pass srcPath as an empty string if the root of the Swift package is the working directory.
+func (b *Build) AddSwiftModule(srcPath string) (*SwiftModule, error) {
+	return newSwiftModule(srcPath, b)
+}
+
+// AddConanModule adds a Conan module to this Build. Pass srcPath as an empty string if the root of the Conan project is the working directory.
+func (b *Build) AddConanModule(srcPath string) (*ConanModule, error) {
+	return newConanModule(srcPath, b)
+}
+
+// AddCondaModule adds a Conda module to this Build. Pass srcPath as an empty string if the environment.yml file of the project (if exists) is in the working directory.
+// By default, the dependencies are collected from the active Conda environment.
+func (b *Build) AddCondaModule(srcPath string) (*CondaModule, error) {
+	return newCondaModule(srcPath, b)
+}
+
+// AddBazelModule adds a Bazel module to this Build. Pass srcPath as an empty string if the root of the Bazel workspace is the working directory.
+func (b *Build) AddBazelModule(srcPath string) (*BazelModule, error) {
+	return newBazelModule(srcPath, b)
+}
+
+// AddSbtModule adds an sbt module to this Build. Pass srcPath as an empty string if the root of the sbt project is the working directory.
+func (b *Build) AddSbtModule(srcPath string) (*SbtModule, error) {
+	return newSbtModule(srcPath, b)
+}
+
+// AddPubModule adds a Dart pub module to this Build. Pass srcPath as an empty string if the root of the Dart project is the working directory.
+func (b *Build) AddPubModule(srcPath string) (*PubModule, error) {
+	return newPubModule(srcPath, b)
+}
+
+// AddMixModule adds a Mix module to this Build. Pass srcPath as an empty string if the root of the Mix project is the working directory.
+func (b *Build) AddMixModule(srcPath string) (*MixModule, error) {
+	return newMixModule(srcPath, b)
+}
+
+// AddHelmModule adds a Helm module to this Build. Pass srcPath as an empty string if the directory of the chart is the working directory.
+func (b *Build) AddHelmModule(srcPath string) (*HelmModule, error) {
+	return newHelmModule(srcPath, b)
+}
+
+// AddTerraformModule adds a Terraform module to this Build. Pass srcPath as an empty string if the Terraform working directory is the working directory.
+func (b *Build) AddTerraformModule(srcPath string) (*TerraformModule, error) {
+	return newTerraformModule(srcPath, b)
+}
+
+// AddDenoModule adds a Deno module to this Build. Pass srcPath as an empty string if the root of the Deno project is the working directory.
+func (b *Build) AddDenoModule(srcPath string) (*DenoModule, error) {
+	return newDenoModule(srcPath, b)
+}
+
+// AddNixModule adds a Nix module to this Build. Pass srcPath as an empty string if the directory of the flake is the working directory.
+func (b *Build) AddNixModule(srcPath string) (*NixModule, error) {
+	return newNixModule(srcPath, b)
+}
+
+// AddHaskellModule adds a Haskell module to this Build. Pass srcPath as an empty string if the root of the Haskell project is the working directory.
+func (b *Build) AddHaskellModule(srcPath string) (*HaskellModule, error) {
+	return newHaskellModule(srcPath, b)
+}
+
+// AddCMakeModule adds a CMake module to this Build. Pass srcPath as an empty string if the source directory of the CMake project is the working directory.
+func (b *Build) AddCMakeModule(srcPath string) (*CMakeModule, error) {
+	return newCMakeModule(srcPath, b)
+}
+
+// AddVcpkgModule adds a vcpkg module to this Build. Pass srcPath as an empty string if the root of the vcpkg project is the working directory.
+func (b *Build) AddVcpkgModule(srcPath string) (*VcpkgModule, error) {
+	return newVcpkgModule(srcPath, b)
+}
+
+// AddZigModule adds a Zig module to this Build. Pass srcPath as an empty string if the root of the Zig project is the working directory.
+func (b *Build) AddZigModule(srcPath string) (*ZigModule, error) {
+	return newZigModule(srcPath, b)
+}
+
+// AddJuliaModule adds a Julia module to this Build. Pass srcPath as an empty string if the root of the Julia project is the working directory.
+func (b *Build) AddJuliaModule(srcPath string) (*JuliaModule, error) {
+	return newJuliaModule(srcPath, b)
+}
+
+// AddRModule adds an R module to this Build. Pass srcPath as an empty string if the root of the R project is the working directory.
+func (b *Build) AddRModule(srcPath string) (*RModule, error) {
+	return newRModule(srcPath, b)
+}
+
+// AddHomebrewModule adds a Homebrew module to this Build. Pass srcPath as an empty string if the bottles of the formula were built in the working directory.
+func (b *Build) AddHomebrewModule(srcPath string) (*HomebrewModule, error) {
+	return newHomebrewModule(srcPath, b)
+}
+
+// AddApkModule adds an APK module, which holds the Alpine packages installed on a system, to this Build. Pass rootDir as an empty string to collect the packages installed on the running system.
+func (b *Build) AddApkModule(rootDir string) (*ApkModule, error) {
+	return newApkModule(rootDir, b)
+}
+
+// AddDpkgModule adds a dpkg module, which holds the Debian packages installed on a system, to this Build. Pass rootDir as an empty string to collect the packages installed on the running system.
+func (b *Build) AddDpkgModule(rootDir string) (*DpkgModule, error) {
+	return newDpkgModule(rootDir, b)
+}
+
+// AddRpmModule adds an RPM module, which holds the RPM packages installed on a system, to this Build. Pass rootDir as an empty string to collect the packages installed on the running system.
+func (b *Build) AddRpmModule(rootDir string) (*RpmModule, error) {
+	return newRpmModule(rootDir, b)
+}
+
+// AddClojureModule adds a Clojure module to this Build. Pass srcPath as an empty string if the root of the Clojure project is the working directory.
+func (b *Build) AddClojureModule(srcPath string) (*ClojureModule, error) {
+	return newClojureModule(srcPath, b)
+}
+
+// AddMesonModule adds a Meson module to this Build. Pass srcPath as an empty string if the root of the Meson project is the working directory.
+func (b *Build) AddMesonModule(srcPath string) (*MesonModule, error) {
+	return newMesonModule(srcPath, b)
+}
+
+// AddYoctoModule adds a Yocto module, which holds the recipes installed in an image built by BitBake, to this Build. Pass srcPath as an empty string if the license.manifest file of the image is in the working directory.
+func (b *Build) AddYoctoModule(srcPath string) (*YoctoModule, error) {
+	return newYoctoModule(srcPath, b)
+}
+
+// AddUnityModule adds a Unity module to this Build. Pass srcPath as an empty string if the root of the Unity project is the working directory.
+func (b *Build) AddUnityModule(srcPath string) (*UnityModule, error) {
+	return newUnityModule(srcPath, b)
+}
+
+// AddPerlModule adds a Perl module to this Build. Pass srcPath as an empty string if the root of the Perl project is the working directory.
+func (b *Build) AddPerlModule(srcPath string) (*PerlModule, error) {
+	return newPerlModule(srcPath, b)
+}
+
+// AddLuaRocksModule adds a LuaRocks module to this Build. Pass srcPath as an empty string if the root of the LuaRocks project is the working directory.
+func (b *Build) AddLuaRocksModule(srcPath string) (*LuaRocksModule, error) {
+	return newLuaRocksModule(srcPath, b)
+}
+
+// AddOpamModule adds an opam module, which holds the packages installed in the opam switch of an OCaml project, to this Build. Pass srcPath as an empty string if the root of the project is the working directory.
+func (b *Build) AddOpamModule(srcPath string) (*OpamModule, error) {
+	return newOpamModule(srcPath, b)
+}
+
+// AddRebarModule adds a rebar3 module to this Build. Pass srcPath as an empty string if the root of the rebar3 project is the working directory.
+func (b *Build) AddRebarModule(srcPath string) (*RebarModule, error) {
+	return newRebarModule(srcPath, b)
+}
+
+// AddShardsModule adds a Crystal shards module to this Build. Pass srcPath as an empty string if the root of the Crystal project is the working directory.
+func (b *Build) AddShardsModule(srcPath string) (*ShardsModule, error) {
+	return newShardsModule(srcPath, b)
+}
+
+// AddAndroidModule adds an Android tooling module, which records the Android SDK components used to build the project, to this Build.
+// Pass srcPath as an empty string if the root of the Android project is the working directory.
+func (b *Build) AddAndroidModule(srcPath string) (*AndroidModule, error) {
+	return newAndroidModule(srcPath, b)
+}
+
+// AddCarthageModule adds a Carthage module to this Build. Pass srcPath as an empty string if the root of the Carthage project is the working directory.
+func (b *Build) AddCarthageModule(srcPath string) (*CarthageModule, error) {
+	return newCarthageModule(srcPath, b)
+}
+
+// AddBuildrootModule adds a Buildroot module to this Build. Pass srcPath as an empty string if the output directory of Buildroot (or its legal-info directory) is the working directory.
+func (b *Build) AddBuildrootModule(srcPath string) (*BuildrootModule, error) {
+	return newBuildrootModule(srcPath, b)
+}
+
+// AddSbomModule adds a module, whose dependencies are imported from a CycloneDX or an SPDX SBOM in the JSON format, to this Build.
+func (b *Build) AddSbomModule(sbomPath string) (*SbomModule, error) {
+	return newSbomModule(sbomPath, b)
+}
+
+// AddSyftModules adds modules, whose dependencies are the packages found by Syft in a directory or an image, to this Build. A module is added for each package type.
+func (b *Build) AddSyftModules(syftOutputPath string) (*SyftModule, error) {
+	return newSyftModule(syftOutputPath, b)
+}
+
+// AddCollector adds a module, which is collected by the collector, to this Build. The collector runs when CalcDependencies() is called.
+func (b *Build) AddCollector(collector Collector) (*CollectorModule, error) {
+	return newCollectorModule(collector, b)
+}
+
+// AddCustomModule adds a module of a custom type, which was registered using RegisterModuleType(), to this Build.
+// srcPath is passed to the collector of the type.
+func (b *Build) AddCustomModule(moduleType entities.ModuleType, srcPath string) (*CustomModule, error) {
+	return newCustomModule(moduleType, srcPath, b)
+}
+
 func (b *Build) CollectEnv() error {
 	if !b.buildNameAndNumberProvided() {
 		return errors.New("a build name must be provided in order to collect environment variables")
@@ -128,7 +410,48 @@ func (b *Build) CollectEnv() error {
 	return b.SavePartialBuildInfo(partial)
 }
 
+// AddProperties adds the properties to the build. They're saved in the 'properties' field of the build-info, with its environment variables.
+func (b *Build) AddProperties(properties map[string]string) error {
+	if !b.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add properties")
+	}
+	partial := &entities.Partial{Env: properties}
+	return b.SavePartialBuildInfo(partial)
+}
+
+// AddModuleProperties adds the properties to the module, whose ID is moduleId, when the build-info is created. The module may be added to the build before or after its properties.
+func (b *Build) AddModuleProperties(moduleId string, properties map[string]string) error {
+	if !b.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add properties")
+	}
+	partial := &entities.Partial{ModuleId: moduleId, ModuleProperties: properties}
+	return b.SavePartialBuildInfo(partial)
+}
+
+// AddPropertiesFile reads the properties from a YAML or a properties file (see buildutils.ReadPropertiesFile()), such as a file, which a CI server writes,
+// and adds them to the modules, whose IDs are given, or to the build if no module IDs are given.
+func (b *Build) AddPropertiesFile(path string, moduleIds ...string) error {
+	properties, err := buildutils.ReadPropertiesFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the properties file %s: %w", path, err)
+	}
+	if len(moduleIds) == 0 {
+		return b.AddProperties(properties)
+	}
+	for _, moduleId := range moduleIds {
+		if err = b.AddModuleProperties(moduleId, properties); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *Build) Clean() error {
+	if b.partialsStore != nil {
+		if err := b.partialsStore.RemovePartials(b.getBuildId()); err != nil {
+			return err
+		}
+	}
 	tempDirPath, err := utils.GetBuildDir(b.buildName, b.buildNumber, b.projectKey, b.tempDirPath)
 	if err != nil {
 		return err
@@ -147,7 +470,7 @@ func (b *Build) ToBuildInfo() (*entities.BuildInfo, error) {
 	if !b.buildNameAndNumberProvided() {
 		return nil, errors.New("a build name must be provided in order to generate build-info")
 	}
-	buildInfo, err := b.createBuildInfoFromPartials()
+	buildInfo, modulesProperties, err := b.createBuildInfoFromPartials()
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +479,7 @@ func (b *Build) ToBuildInfo() (*entities.BuildInfo, error) {
 	buildInfo.SetBuildAgentVersion(b.buildAgentVersion)
 	buildInfo.Principal = b.principal
 	buildInfo.BuildUrl = b.buildUrl
+	b.setAgentsDetails(buildInfo)
 
 	generatedBuildsInfo, err := b.getGeneratedBuildsInfo()
 	if err != nil {
@@ -164,30 +488,39 @@ func (b *Build) ToBuildInfo() (*entities.BuildInfo, error) {
 	for _, v := range generatedBuildsInfo {
 		buildInfo.Append(v)
 	}
-
+	addModulesProperties(buildInfo, modulesProperties)
+	buildInfo.SetStandardScopes()
+	if err = buildInfo.DeduplicateDependencies(b.dependencyDeduplicationPolicy); err != nil {
+		return nil, err
+	}
+	// The build is considered finished when its build-info is created.
+	buildInfo.SetFinished(time.Now())
 	return buildInfo, nil
 }
 
-func (b *Build) getGeneratedBuildsInfo() ([]*entities.BuildInfo, error) {
-	buildDir, err := utils.GetBuildDir(b.buildName, b.buildNumber, b.projectKey, b.tempDirPath)
+// ToSlsaProvenance creates an in-toto statement with a SLSA v1 build provenance predicate from the build-info of this Build.
+// Call it after the build has completed, because the current time is recorded as the time the build finished at.
+// builderId identifies the platform, which ran the build (for example, the URL of a CI job).
+func (b *Build) ToSlsaProvenance(builderId string) (*entities.InTotoStatement, error) {
+	if builderId == "" {
+		return nil, errors.New("a builder ID must be provided in order to generate SLSA provenance")
+	}
+	buildInfo, err := b.ToBuildInfo()
 	if err != nil {
 		return nil, err
 	}
-	buildFiles, err := utils.ListFiles(buildDir, true)
+	return buildInfo.ToSlsaProvenance(builderId, time.Now()), nil
+}
+
+func (b *Build) getGeneratedBuildsInfo() ([]*entities.BuildInfo, error) {
+	buildFiles, err := b.getGeneratedBuildInfoFiles()
 	if err != nil {
 		return nil, err
 	}
 
 	var generatedBuildsInfo []*entities.BuildInfo
 	for _, buildFile := range buildFiles {
-		dir, err := utils.IsDirExists(buildFile, true)
-		if err != nil {
-			return nil, err
-		}
-		if dir {
-			continue
-		}
-		content, err := os.ReadFile(buildFile)
+		content, err := utils.ReadFileDecompressed(buildFile)
 		if err != nil {
 			return nil, err
 		}
@@ -204,7 +537,34 @@ func (b *Build) getGeneratedBuildsInfo() ([]*entities.BuildInfo, error) {
 	return generatedBuildsInfo, nil
 }
 
+// Returns the paths of the build-infos, which the modules saved in the build directory.
+func (b *Build) getGeneratedBuildInfoFiles() ([]string, error) {
+	buildDir, err := utils.GetBuildDir(b.buildName, b.buildNumber, b.projectKey, b.tempDirPath)
+	if err != nil {
+		return nil, err
+	}
+	buildFiles, err := utils.ListFiles(buildDir, true)
+	if err != nil {
+		return nil, err
+	}
+	var generatedBuildInfoFiles []string
+	for _, buildFile := range buildFiles {
+		dir, err := utils.IsDirExists(buildFile, true)
+		if err != nil {
+			return nil, err
+		}
+		if !dir {
+			generatedBuildInfoFiles = append(generatedBuildInfoFiles, buildFile)
+		}
+	}
+	return generatedBuildInfoFiles, nil
+}
+
 func (b *Build) SaveBuildInfo(buildInfo *entities.BuildInfo) (err error) {
+	if err = b.filterDependencies(buildInfo); err != nil {
+		return
+	}
+	b.setAgentsDetails(buildInfo)
 	buildJson, err := json.Marshal(buildInfo)
 	if err != nil {
 		return
@@ -214,6 +574,10 @@ func (b *Build) SaveBuildInfo(buildInfo *entities.BuildInfo) (err error) {
 	if err != nil {
 		return
 	}
+	encodedContent, err := b.encodeBuildInfoContent(content.Bytes())
+	if err != nil {
+		return
+	}
 	dirPath, err := utils.GetBuildDir(b.buildName, b.buildNumber, b.projectKey, b.tempDirPath)
 	if err != nil {
 		return
@@ -229,46 +593,47 @@ func (b *Build) SaveBuildInfo(buildInfo *entities.BuildInfo) (err error) {
 			err = e
 		}
 	}()
-	_, err = tempFile.Write(content.Bytes())
+	_, err = tempFile.Write(encodedContent)
 	return
 }
 
-// SavePartialBuildInfo saves the given partial in the builds directory.
+// Returns the content of a build-info, as it's saved in the local cache.
+func (b *Build) encodeBuildInfoContent(content []byte) ([]byte, error) {
+	if !b.compressBuildInfo {
+		return content, nil
+	}
+	return utils.CompressGzip(content)
+}
+
+// SavePartialBuildInfo saves the given partial in the partials store of the build (by default, in the builds directory).
 // The partial's Timestamp field is set inside this function.
 func (b *Build) SavePartialBuildInfo(partial *entities.Partial) (err error) {
-	partial.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
-	partialJson, err := json.Marshal(&partial)
-	if err != nil {
-		return
-	}
-	var content bytes.Buffer
-	err = json.Indent(&content, partialJson, "", "  ")
-	if err != nil {
-		return
-	}
-	dirPath, err := utils.GetPartialsBuildDir(b.buildName, b.buildNumber, b.projectKey, b.tempDirPath)
-	if err != nil {
-		return
+	for _, artifact := range partial.Artifacts {
+		if artifact.OriginalDeploymentRepo != "" && artifact.Path == "" {
+			return fmt.Errorf("the path of the artifact %s in the repository %s must be set", artifact.Name, artifact.OriginalDeploymentRepo)
+		}
 	}
-	b.logger.Debug("Creating temp build file at:", dirPath)
-	tempFile, err := os.CreateTemp(dirPath, "temp")
-	if err != nil {
-		return
+	partial.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
+	return b.getPartialsStore().SavePartial(b.getBuildId(), partial)
+}
+
+func (b *Build) getPartialsStore() PartialsStore {
+	if b.partialsStore == nil {
+		return NewFileSystemPartialsStore(b.tempDirPath, b.logger)
 	}
-	defer func() {
-		e := tempFile.Close()
-		if err == nil {
-			err = e
-		}
-	}()
-	_, err = tempFile.Write(content.Bytes())
-	return
+	return b.partialsStore
+}
+
+func (b *Build) getBuildId() BuildId {
+	return BuildId{Name: b.buildName, Number: b.buildNumber, ProjectKey: b.projectKey}
 }
 
-func (b *Build) createBuildInfoFromPartials() (*entities.BuildInfo, error) {
-	partials, err := b.readPartialBuildInfoFiles()
+// Returns the build-info, which is created from the partials, and the properties, which were added to modules, by the IDs of the modules.
+// These properties are returned separately, because the modules may be added by the generated build-infos.
+func (b *Build) createBuildInfoFromPartials() (*entities.BuildInfo, map[string]map[string]string, error) {
+	partials, err := b.getPartialsStore().LoadPartials(b.getBuildId())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	sort.Sort(partials)
 
@@ -277,12 +642,12 @@ func (b *Build) createBuildInfoFromPartials() (*entities.BuildInfo, error) {
 	buildInfo.Number = b.buildNumber
 	buildGeneralDetails, err := b.readBuildInfoGeneralDetails()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	buildInfo.Started = buildGeneralDetails.Timestamp.Format(entities.TimeFormat)
-	modules, env, vcsList, issues, err := extractBuildInfoData(partials)
+	modules, env, vcsList, issues, modulesProperties, err := extractBuildInfoData(partials)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(env) != 0 {
 		buildInfo.Properties = env
@@ -300,40 +665,7 @@ func (b *Build) createBuildInfoFromPartials() (*entities.BuildInfo, error) {
 		}
 		buildInfo.Modules = append(buildInfo.Modules, module)
 	}
-	return buildInfo, nil
-}
-
-func (b *Build) readPartialBuildInfoFiles() (entities.Partials, error) {
-	var partials entities.Partials
-	partialsBuildDir, err := utils.GetPartialsBuildDir(b.buildName, b.buildNumber, b.projectKey, b.tempDirPath)
-	if err != nil {
-		return nil, err
-	}
-	buildFiles, err := utils.ListFiles(partialsBuildDir, true)
-	if err != nil {
-		return nil, err
-	}
-	for _, buildFile := range buildFiles {
-		dir, err := utils.IsDirExists(buildFile, true)
-		if err != nil {
-			return nil, err
-		}
-		if dir || strings.HasSuffix(buildFile, BuildInfoDetails) {
-			continue
-		}
-		content, err := os.ReadFile(buildFile)
-		if err != nil {
-			return nil, err
-		}
-		partial := new(entities.Partial)
-		err = json.Unmarshal(content, &partial)
-		if err != nil {
-			return nil, err
-		}
-		partials = append(partials, partial)
-	}
-
-	return partials, nil
+	return buildInfo, modulesProperties, nil
 }
 
 func (b *Build) readBuildInfoGeneralDetails() (*entities.General, error) {
@@ -379,17 +711,18 @@ type partialModule struct {
 	checksum     entities.Checksum
 }
 
-func extractBuildInfoData(partials entities.Partials) ([]entities.Module, entities.Env, []entities.Vcs, entities.Issues, error) {
+func extractBuildInfoData(partials entities.Partials) ([]entities.Module, entities.Env, []entities.Vcs, entities.Issues, map[string]map[string]string, error) {
 	var vcs []entities.Vcs
 	var issues entities.Issues
 	env := make(map[string]string)
+	modulesProperties := make(map[string]map[string]string)
 	partialModules := make(map[string]*partialModule)
 	issuesMap := make(map[string]*entities.AffectedIssue)
 	for _, partial := range partials {
 		moduleId := partial.ModuleId
 		// If type is not set but module has artifacts / dependencies, throw error.
 		if (partial.Artifacts != nil || partial.Dependencies != nil) && partial.ModuleType == "" {
-			return nil, nil, nil, entities.Issues{}, errors.New("module with artifacts or dependencies but no Type is not supported")
+			return nil, nil, nil, entities.Issues{}, nil, errors.New("module with artifacts or dependencies but no Type is not supported")
 		}
 		// Avoid adding redundant modules without type (for issues, env, etc)
 		if partialModules[moduleId] == nil && partial.ModuleType != "" {
@@ -423,11 +756,18 @@ func extractBuildInfoData(partials entities.Partials) ([]entities.Module, entiti
 			for k, v := range partial.Env {
 				env[k] = v
 			}
+		case partial.ModuleProperties != nil:
+			if modulesProperties[moduleId] == nil {
+				modulesProperties[moduleId] = make(map[string]string)
+			}
+			for k, v := range partial.ModuleProperties {
+				modulesProperties[moduleId][k] = v
+			}
 		case partial.ModuleType == entities.Build:
 			partialModules[moduleId].checksum = partial.Checksum
 		}
 	}
-	return partialModulesToModules(partialModules), env, vcs, issuesMapToArray(issues, issuesMap), nil
+	return partialModulesToModules(partialModules), env, vcs, issuesMapToArray(issues, issuesMap), modulesProperties, nil
 }
 
 func partialModulesToModules(partialModules map[string]*partialModule) []entities.Module {
@@ -452,7 +792,7 @@ func addArtifactToPartialModule(artifact entities.Artifact, moduleId string, par
 	if partialModules[moduleId].artifacts == nil {
 		partialModules[moduleId].artifacts = make(map[string]entities.Artifact)
 	}
-	key := fmt.Sprintf("%s-%s-%s", artifact.Path, artifact.Sha1, artifact.Md5)
+	key := fmt.Sprintf("%s-%s-%s-%s", artifact.OriginalDeploymentRepo, artifact.Path, artifact.Sha1, artifact.Md5)
 	partialModules[moduleId].artifacts[key] = artifact
 }
 
@@ -481,6 +821,19 @@ func dependenciesMapToList(dependenciesMap map[string]entities.Dependency) []ent
 	return dependencies
 }
 
+// Sets the properties with non-empty values on the dependency.
+func setDependencyProperties(dependency *entities.Dependency, properties map[string]string) {
+	for key, value := range properties {
+		if value == "" {
+			continue
+		}
+		if dependency.Properties == nil {
+			dependency.Properties = make(map[string]string)
+		}
+		dependency.Properties[key] = value
+	}
+}
+
 func createModule(moduleId string, moduleType entities.ModuleType, checksum entities.Checksum, artifacts []entities.Artifact, dependencies []entities.Dependency) *entities.Module {
 	module := createDefaultModule(moduleId)
 	module.Type = moduleType
@@ -497,12 +850,84 @@ func createModule(moduleId string, moduleType entities.ModuleType, checksum enti
 func createDefaultModule(moduleId string) *entities.Module {
 	return &entities.Module{
 		Id:           moduleId,
-		Properties:   map[string][]string{},
 		Artifacts:    []entities.Artifact{},
 		Dependencies: []entities.Dependency{},
 	}
 }
 
+// Reads the build-info, which was generated by the Maven or Gradle extractor, updates it and writes it back.
+// If no build-info was generated, nothing is updated.
+func (b *Build) updateGeneratedBuildInfo(buildInfoPath string, update func(buildInfo *entities.BuildInfo) error) error {
+	content, err := utils.ReadFileDecompressed(buildInfoPath)
+	if err != nil || len(content) == 0 {
+		return err
+	}
+	buildInfo := &entities.BuildInfo{}
+	if err = json.Unmarshal(content, buildInfo); err != nil {
+		return err
+	}
+	if err = update(buildInfo); err != nil {
+		return err
+	}
+	content, err = json.Marshal(buildInfo)
+	if err != nil {
+		return err
+	}
+	if content, err = b.encodeBuildInfoContent(content); err != nil {
+		return err
+	}
+	return os.WriteFile(buildInfoPath, content, 0644)
+}
+
+// Adds the properties to all the modules of the build-info, for module types, which may produce several modules.
+// The properties, which the modules already have (for example, the ones generated by the Maven and Gradle extractors), are kept, unless the added properties override them.
+func setModulesProperties(buildInfo *entities.BuildInfo, properties map[string]string) {
+	for i := range buildInfo.Modules {
+		buildInfo.Modules[i].AddProperties(properties)
+	}
+}
+
+// Adds the properties to the modules of the build-info, by their IDs. The added properties override the properties, which the modules already have.
+func addModulesProperties(buildInfo *entities.BuildInfo, modulesProperties map[string]map[string]string) {
+	for i := range buildInfo.Modules {
+		buildInfo.Modules[i].AddProperties(modulesProperties[buildInfo.Modules[i].Id])
+	}
+}
+
+// Sets the timing of all the modules of the build-info, whose collection started at the given time, and has just finished.
+func setModulesTiming(buildInfo *entities.BuildInfo, started time.Time) {
+	finished := time.Now()
+	for i := range buildInfo.Modules {
+		buildInfo.Modules[i].SetTiming(started, finished)
+	}
+}
+
+// Returns the checksums of the file, including its SHA-512 checksum if the build calculates it.
+func (b *Build) getFileChecksum(filePath string) (entities.Checksum, error) {
+	// Modules, which are created without a build (for example, in tests), calculate the default checksums.
+	return utils.GetFileChecksum(filePath, b != nil && b.calcSha512Checksums)
+}
+
+// Applies the include and exclude dependency filters of the build, if they're set.
+func (b *Build) filterDependencies(buildInfo *entities.BuildInfo) error {
+	if b.includeDependencies != nil {
+		if err := buildInfo.IncludeDependencies(*b.includeDependencies); err != nil {
+			return err
+		}
+	}
+	if b.excludeDependencies != nil {
+		return buildInfo.ExcludeDependencies(*b.excludeDependencies)
+	}
+	return nil
+}
+
+func (b *Build) getRequestedByLimits() entities.RequestedByLimits {
+	if b == nil || b.requestedByLimits == nil {
+		return entities.DefaultRequestedByLimits
+	}
+	return *b.requestedByLimits
+}
+
 func createEmptyBuildInfoFile(containingBuild *Build) (string, error) {
 	buildDir, err := utils.CreateTempBuildFile(containingBuild.buildName, containingBuild.buildNumber, containingBuild.projectKey, containingBuild.tempDirPath, containingBuild.logger)
 	if err != nil {
@@ -1,9 +1,13 @@
 package build
 
 import (
+	"encoding/json"
 	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -79,3 +83,209 @@ func TestCollectEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestToSlsaProvenance(t *testing.T) {
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-slsa-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	artifactsPartial := &entities.Partial{
+		ModuleId:   "my-module",
+		ModuleType: entities.Generic,
+		Artifacts:  []entities.Artifact{{Name: "my-module.zip", Checksum: entities.Checksum{Sha256: "s1"}}},
+	}
+	assert.NoError(t, build.SavePartialBuildInfo(artifactsPartial))
+	dependenciesPartial := &entities.Partial{
+		ModuleId:     "my-module",
+		ModuleType:   entities.Generic,
+		Dependencies: []entities.Dependency{{Id: "dep:1.0", Checksum: entities.Checksum{Sha1: "d1"}}},
+	}
+	assert.NoError(t, build.SavePartialBuildInfo(dependenciesPartial))
+
+	_, err = build.ToSlsaProvenance("")
+	assert.Error(t, err)
+	statement, err := build.ToSlsaProvenance("https://ci.example.com/builder")
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.InTotoResourceDescriptor{{Name: "my-module.zip", Digest: map[string]string{"sha256": "s1"}}}, statement.Subject)
+	provenance, ok := statement.Predicate.(*entities.SlsaProvenance)
+	assert.True(t, ok)
+	assert.Equal(t, "https://ci.example.com/builder", provenance.RunDetails.Builder.Id)
+	assert.NotEmpty(t, provenance.RunDetails.Metadata.FinishedOn)
+	assert.Equal(t, []entities.InTotoResourceDescriptor{{Name: "dep:1.0", Digest: map[string]string{"sha1": "d1"}}}, provenance.BuildDefinition.ResolvedDependencies)
+}
+
+func TestSetAgentsDetails(t *testing.T) {
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-agents-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	build.SetAgentName("my-ci")
+	build.SetAgentVersion("1.2.3")
+	buildInfo, err := build.ToBuildInfo()
+	assert.NoError(t, err)
+	// The agent, which was set, isn't replaced.
+	assert.Equal(t, "my-ci", buildInfo.Agent.Name)
+	assert.Equal(t, "1.2.3", buildInfo.Agent.Version)
+	assert.Equal(t, "build-info-go", buildInfo.BuildAgent.Name)
+	assert.Equal(t, runtime.Version(), buildInfo.BuildAgent.GoVersion)
+	assert.Equal(t, runtime.GOOS, buildInfo.BuildAgent.Os)
+	assert.Equal(t, runtime.GOARCH, buildInfo.BuildAgent.Arch)
+	hostname, err := os.Hostname()
+	assert.NoError(t, err)
+	assert.Equal(t, hostname, buildInfo.BuildAgent.Hostname)
+
+	build.SetCollectAgentsDetails(false)
+	build.SetBuildAgentVersion("2.0.0")
+	buildInfo, err = build.ToBuildInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.Agent{Name: "GENERIC", Version: "2.0.0"}, buildInfo.BuildAgent)
+}
+
+func TestSaveArtifactsDeploymentPath(t *testing.T) {
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-deployment-path-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	artifact := entities.Artifact{Name: "my-module-1.0.zip", Checksum: entities.Checksum{Sha1: "s1"}}
+	artifact.SetDeploymentPath("generic-local", "my-module/1.0")
+	// The same artifact, deployed to another repository.
+	promoted := artifact
+	promoted.OriginalDeploymentRepo = "generic-release"
+	assert.NoError(t, build.SavePartialBuildInfo(&entities.Partial{ModuleId: "my-module", ModuleType: entities.Generic, Artifacts: []entities.Artifact{artifact, promoted}}))
+	// The path must be set along with the repository.
+	assert.Error(t, build.SavePartialBuildInfo(&entities.Partial{ModuleId: "my-module", ModuleType: entities.Generic, Artifacts: []entities.Artifact{{Name: "a.zip", OriginalDeploymentRepo: "generic-local"}}}))
+
+	buildInfo, err := build.ToBuildInfo()
+	assert.NoError(t, err)
+	assert.Len(t, buildInfo.Modules, 1)
+	assert.ElementsMatch(t, []entities.Artifact{artifact, promoted}, buildInfo.Modules[0].Artifacts)
+	assert.Equal(t, "my-module/1.0/my-module-1.0.zip", buildInfo.Modules[0].Artifacts[0].Path)
+}
+
+func TestCalcSha512Checksums(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "artifact.txt")
+	assert.NoError(t, os.WriteFile(filePath, []byte("build-info"), 0600))
+	build := NewBuild("bi-sha512-test", "1", "", "", nil)
+	checksum, err := build.getFileChecksum(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "95201f2d94449f4d01740df7b074440bae2d9e8832265cf903eef49294173869", checksum.Sha256)
+	// SHA-512 isn't calculated by default.
+	assert.Empty(t, checksum.Sha512)
+
+	build.SetCalcSha512Checksums(true)
+	checksum, err = build.getFileChecksum(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "e79aaab0bdb568be1ac3edaa023e64490cad8390eeb8a6d665865252e28593bceaedd33d1c62591ec91dee4e5098acd0d4e9d61d9207cb2359a6e1a95f87d929", checksum.Sha512)
+	assert.Equal(t, "95201f2d94449f4d01740df7b074440bae2d9e8832265cf903eef49294173869", checksum.Sha256)
+}
+
+func TestSaveBuildInfoDependencyFilters(t *testing.T) {
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-dependency-filters-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	build.SetIncludeDependencies(entities.DependencyFilter{Ids: []string{"com.example:*"}})
+	build.SetExcludeDependencies(entities.DependencyFilter{Scopes: []string{"test"}})
+	assert.NoError(t, build.SaveBuildInfo(&entities.BuildInfo{Modules: []entities.Module{{Id: "my-module", Type: entities.Maven, Dependencies: []entities.Dependency{
+		{Id: "com.example:lib:1.0"},
+		{Id: "com.example:test-utils:1.0", Scopes: []string{"test"}},
+		{Id: "org.other:lib:1.0"},
+	}}}}))
+
+	buildInfo, err := build.ToBuildInfo()
+	assert.NoError(t, err)
+	assert.Len(t, buildInfo.Modules, 1)
+	assert.Len(t, buildInfo.Modules[0].Dependencies, 1)
+	assert.Equal(t, "com.example:lib:1.0", buildInfo.Modules[0].Dependencies[0].Id)
+}
+
+func TestSetModulesProperties(t *testing.T) {
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{
+		{Id: "module-a", Properties: map[string]string{"maven.packaging": "jar", "team": "backend"}},
+		{Id: "module-b"},
+	}}
+	properties := map[string]string{"team": "platform"}
+	setModulesProperties(buildInfo, properties)
+	// The properties, which the modules already have, are kept, unless they're overridden.
+	assert.Equal(t, map[string]string{"maven.packaging": "jar", "team": "platform"}, buildInfo.Modules[0].GetProperties())
+	assert.Equal(t, map[string]string{"team": "platform"}, buildInfo.Modules[1].GetProperties())
+	// Each module has its own copy of the properties.
+	buildInfo.Modules[1].Properties.(map[string]string)["component"] = "api"
+	assert.Equal(t, map[string]string{"team": "platform"}, properties)
+	assert.NotContains(t, buildInfo.Modules[0].GetProperties(), "component")
+}
+
+func TestAddPropertiesFile(t *testing.T) {
+	propertiesPath := filepath.Join(t.TempDir(), "ci.props")
+	assert.NoError(t, os.WriteFile(propertiesPath, []byte("ci.job=release\nci.attempt=2\n"), 0600))
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-properties-file-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	assert.NoError(t, build.AddPropertiesFile(propertiesPath))
+	// The properties of a module are added, whether the module is added before or after them.
+	assert.NoError(t, build.AddPropertiesFile(propertiesPath, "generated-module", "partial-module"))
+	assert.NoError(t, build.SaveBuildInfo(&entities.BuildInfo{Modules: []entities.Module{{Id: "generated-module", Type: entities.Go, Properties: map[string]string{"team": "platform"}}}}))
+	assert.NoError(t, build.SavePartialBuildInfo(&entities.Partial{ModuleId: "partial-module", ModuleType: entities.Generic, Artifacts: []entities.Artifact{{Name: "a.zip"}}}))
+
+	buildInfo, err := build.ToBuildInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "release", buildInfo.Properties["ci.job"])
+	assert.Len(t, buildInfo.Modules, 2)
+	for _, module := range buildInfo.Modules {
+		switch module.Id {
+		case "generated-module":
+			assert.Equal(t, map[string]string{"team": "platform", "ci.job": "release", "ci.attempt": "2"}, module.GetProperties())
+		case "partial-module":
+			assert.Equal(t, map[string]string{"ci.job": "release", "ci.attempt": "2"}, module.GetProperties())
+		default:
+			assert.Fail(t, "unexpected module "+module.Id)
+		}
+	}
+}
+
+func TestCompressBuildInfo(t *testing.T) {
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-compress-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	build.SetCompressBuildInfo(true)
+	assert.NoError(t, build.SaveBuildInfo(&entities.BuildInfo{Modules: []entities.Module{{Id: "compressed", Type: entities.Go}}}))
+	// Build-infos, which were saved without compression, are read too.
+	build.SetCompressBuildInfo(false)
+	assert.NoError(t, build.SaveBuildInfo(&entities.BuildInfo{Modules: []entities.Module{{Id: "plain", Type: entities.Go}}}))
+
+	buildDir, err := utils.GetBuildDir(build.buildName, build.buildNumber, build.projectKey, build.tempDirPath)
+	assert.NoError(t, err)
+	files, err := utils.ListFilesByFilterFunc(buildDir, func(string) (bool, error) { return true, nil })
+	assert.NoError(t, err)
+	compressedFiles := 0
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		if !json.Valid(content) {
+			compressedFiles++
+		}
+	}
+	assert.Equal(t, 1, compressedFiles)
+
+	buildInfo, err := build.ToBuildInfo()
+	assert.NoError(t, err)
+	var moduleIds []string
+	for _, module := range buildInfo.Modules {
+		moduleIds = append(moduleIds, module.Id)
+	}
+	assert.ElementsMatch(t, []string{"compressed", "plain"}, moduleIds)
+}
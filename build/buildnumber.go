@@ -0,0 +1,156 @@
+package build
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// The build number of a build, which has no previous build numbers.
+	firstBuildNumber = "1"
+	// How long to wait for another process, which increments the same counter file.
+	counterFileLockTimeout = 10 * time.Second
+	counterFileLockRetry   = 100 * time.Millisecond
+	// How long to wait for Artifactory to return the build numbers of a build.
+	buildRunsRequestTimeout = 30 * time.Second
+)
+
+// NextBuildNumberFromFile returns the next build number, by incrementing the build number in the counter file, which is created if it doesn't exist.
+// The counter file holds the last build number, which was returned. While it's incremented, it's locked by a '.lock' file next to it, so several processes can share it.
+func NextBuildNumberFromFile(counterFilePath string) (buildNumber string, err error) {
+	unlock, err := lockCounterFile(counterFilePath)
+	if err != nil {
+		return
+	}
+	defer func() {
+		e := unlock()
+		if err == nil {
+			err = e
+		}
+	}()
+	content, err := os.ReadFile(counterFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+	last := 0
+	if trimmed := strings.TrimSpace(string(content)); trimmed != "" {
+		if last, err = strconv.Atoi(trimmed); err != nil {
+			return "", fmt.Errorf("the counter file %s doesn't hold a build number: %w", counterFilePath, err)
+		}
+	}
+	buildNumber = strconv.Itoa(last + 1)
+	err = os.WriteFile(counterFilePath, []byte(buildNumber+"\n"), 0644)
+	return
+}
+
+// Creates the lock file of the counter file, and returns a function, which removes it.
+func lockCounterFile(counterFilePath string) (func() error, error) {
+	lockFilePath := counterFilePath + ".lock"
+	deadline := time.Now().Add(counterFileLockTimeout)
+	for {
+		lockFile, err := os.OpenFile(lockFilePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			if err = lockFile.Close(); err != nil {
+				return nil, err
+			}
+			return func() error { return os.Remove(lockFilePath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the counter file %s is locked. If no other process increments it, remove %s", counterFilePath, lockFilePath)
+		}
+		time.Sleep(counterFileLockRetry)
+	}
+}
+
+// The response of Artifactory's Build Runs REST API.
+type buildRuns struct {
+	BuildsNumbers []struct {
+		// The build number, prefixed by a slash (for example, '/52').
+		Uri string `json:"uri"`
+	} `json:"buildsNumbers"`
+}
+
+// The response of Artifactory's REST API, when a request fails.
+type artifactoryErrorResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// NextBuildNumberFromServer returns the next build number, by incrementing the highest numeric build number of the build, which was published to Artifactory.
+// artifactoryUrl is the URL of Artifactory (for example, 'https://acme.jfrog.io/artifactory'), and accessToken is used to authenticate, if it's not empty.
+// If the build wasn't published yet, its first build number is returned. Other errors, such as a wrong URL of Artifactory, are returned. Build numbers, which aren't numeric, are ignored.
+// The build number isn't reserved on the server, so builds, which run concurrently, may get the same build number.
+func NextBuildNumberFromServer(artifactoryUrl, accessToken, buildName, projectKey string) (buildNumber string, err error) {
+	if buildName == "" {
+		return "", errors.New("a build name must be provided in order to get the next build number")
+	}
+	buildRunsUrl := strings.TrimSuffix(artifactoryUrl, "/") + "/api/build/" + url.PathEscape(buildName)
+	if projectKey != "" {
+		buildRunsUrl += "?project=" + url.QueryEscape(projectKey)
+	}
+	request, err := http.NewRequest(http.MethodGet, buildRunsUrl, nil)
+	if err != nil {
+		return
+	}
+	if accessToken != "" {
+		request.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	client := &http.Client{Timeout: buildRunsRequestTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if deferErr := response.Body.Close(); err == nil {
+			err = deferErr
+		}
+	}()
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// Artifactory returns 404 if the build wasn't published yet, but also if the URL isn't of Artifactory's REST API.
+		if isBuildNotFoundResponse(response) {
+			return firstBuildNumber, nil
+		}
+		return "", fmt.Errorf("failed to get the build numbers of %s from Artifactory. Make sure that %s is the URL of Artifactory. status code: %s", buildName, artifactoryUrl, response.Status)
+	default:
+		return "", fmt.Errorf("failed to get the build numbers of %s from Artifactory. status code: %s", buildName, response.Status)
+	}
+	runs := buildRuns{}
+	if err = json.NewDecoder(response.Body).Decode(&runs); err != nil {
+		return
+	}
+	last := 0
+	for _, run := range runs.BuildsNumbers {
+		number, err := strconv.Atoi(strings.TrimPrefix(run.Uri, "/"))
+		if err == nil && number > last {
+			last = number
+		}
+	}
+	return strconv.Itoa(last + 1), nil
+}
+
+// Returns true if the body of the response is Artifactory's error, which means that no build of the requested name was published.
+func isBuildNotFoundResponse(response *http.Response) bool {
+	errorResponse := artifactoryErrorResponse{}
+	if err := json.NewDecoder(response.Body).Decode(&errorResponse); err != nil {
+		return false
+	}
+	for _, responseError := range errorResponse.Errors {
+		if strings.HasPrefix(responseError.Message, "No build was found") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package build
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextBuildNumberFromFile(t *testing.T) {
+	counterFilePath := filepath.Join(t.TempDir(), "build-number")
+	for _, expected := range []string{"1", "2", "3"} {
+		buildNumber, err := NextBuildNumberFromFile(counterFilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, buildNumber)
+	}
+	assert.NoFileExists(t, counterFilePath+".lock")
+
+	assert.NoError(t, os.WriteFile(counterFilePath, []byte("41\n"), 0644))
+	buildNumber, err := NextBuildNumberFromFile(counterFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", buildNumber)
+
+	assert.NoError(t, os.WriteFile(counterFilePath, []byte("release"), 0644))
+	_, err = NextBuildNumberFromFile(counterFilePath)
+	assert.Error(t, err)
+}
+
+func TestNextBuildNumberFromServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/build/my build":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assert.Equal(t, "proj", r.URL.Query().Get("project"))
+			_, err := w.Write([]byte(`{"uri":"https://acme.jfrog.io/artifactory/api/build/my%20build","buildsNumbers":[{"uri":"/9"},{"uri":"/12"},{"uri":"/release-1"}]}`))
+			assert.NoError(t, err)
+		case "/artifactory/api/build/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/artifactory/api/build/new-build":
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errors":[{"status":404,"message":"No build was found for build name: new-build"}]}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	buildNumber, err := NextBuildNumberFromServer(server.URL+"/artifactory/", "token", "my build", "proj")
+	assert.NoError(t, err)
+	assert.Equal(t, "13", buildNumber)
+
+	buildNumber, err = NextBuildNumberFromServer(server.URL+"/artifactory", "", "new-build", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", buildNumber)
+
+	_, err = NextBuildNumberFromServer(server.URL+"/artifactory", "", "unauthorized", "")
+	assert.Error(t, err)
+
+	// A 404 response, which isn't Artifactory's "build not found" error, means that the URL is wrong.
+	_, err = NextBuildNumberFromServer(server.URL+"/not-artifactory", "", "new-build", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency properties, which hold the license of a package and the site its source was downloaded from.
+	BuildrootLicenseProperty    = "buildroot.license"
+	BuildrootSourceSiteProperty = "buildroot.sourceSite"
+)
+
+type BuildrootModule struct {
+	containingBuild     *Build
+	name                string
+	properties          map[string]string
+	legalInfoDir        string
+	includeHostPackages bool
+}
+
+// Pass an empty string for srcPath if the output directory of Buildroot (or its legal-info directory) is the working directory.
+// The legal-info directory is created by running 'make legal-info'.
+func newBuildrootModule(srcPath string, containingBuild *Build) (*BuildrootModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+	legalInfoDir, err := buildutils.FindBuildrootLegalInfoDir(srcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read module name
+	name, err := buildutils.GetBuildrootDefconfigName(legalInfoDir)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No defconfig is set in the configuration of the image. Using the directory name: %s as module name.", name))
+	}
+
+	return &BuildrootModule{name: name, legalInfoDir: legalInfoDir, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages listed in the manifest.csv file of the legal-info directory.
+func (bm *BuildrootModule) CalcDependencies() error {
+	started := time.Now()
+	if !bm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := bm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: bm.name, Type: entities.Buildroot, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(bm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return bm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (bm *BuildrootModule) SetName(name string) {
+	bm.name = name
+}
+
+func (bm *BuildrootModule) SetProperties(properties map[string]string) {
+	bm.properties = properties
+}
+
+// SetIncludeHostPackages sets whether to include the host packages, which were built to run on the build machine (such as the toolchain), listed in the host-manifest.csv file.
+// By default, only the target packages, which were compiled into the image, are included.
+func (bm *BuildrootModule) SetIncludeHostPackages(includeHostPackages bool) {
+	bm.includeHostPackages = includeHostPackages
+}
+
+func (bm *BuildrootModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !bm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: bm.name, ModuleType: entities.Buildroot, Artifacts: artifacts}
+	return bm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (bm *BuildrootModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := buildutils.ReadBuildrootManifest(bm.legalInfoDir, buildutils.BuildrootManifestFileName)
+	if err != nil {
+		return nil, err
+	}
+	if bm.includeHostPackages {
+		hostPackages, err := buildutils.ReadBuildrootManifest(bm.legalInfoDir, buildutils.BuildrootHostManifestFileName)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, hostPackages...)
+	}
+	packagesIds := make(map[string]string)
+	for _, pkg := range packages {
+		packagesIds[pkg.Name] = pkg.Id()
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	requested := make(map[string]bool)
+	for _, pkg := range packages {
+		dependency, err := bm.createBuildrootDependency(bm.legalInfoDir, pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[pkg.Id()] = dependency
+		// Dependencies on packages, which aren't included (such as host packages), are skipped.
+		dependenciesGraph[pkg.Id()] = getMixPackagesIds(packagesIds, pkg.Dependencies)
+		for _, childId := range dependenciesGraph[pkg.Id()] {
+			requested[childId] = true
+		}
+	}
+	// The packages, which aren't required by other packages, are considered as direct dependencies of the image.
+	for _, pkg := range packages {
+		if !requested[pkg.Id()] && !slices.Contains(dependenciesGraph[bm.name], pkg.Id()) {
+			dependenciesGraph[bm.name] = append(dependenciesGraph[bm.name], pkg.Id())
+		}
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(bm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, bm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a Buildroot package. Its checksums are calculated from its source archive, as saved to the legal-info directory.
+// Packages, whose sources can't be redistributed (or which have no sources, such as virtual packages), have no checksums.
+func (bm *BuildrootModule) createBuildrootDependency(legalInfoDir string, pkg buildutils.BuildrootPackage) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: "buildroot"}
+	setDependencyProperties(&dependency, map[string]string{
+		BuildrootLicenseProperty:    pkg.License,
+		BuildrootSourceSiteProperty: pkg.SourceSite,
+	})
+	if pkg.SourceArchive == "" {
+		return dependency, nil
+	}
+	archivePath := pkg.GetSourceArchivePath(legalInfoDir)
+	exists, err := utils.IsFileExists(archivePath, true)
+	if err != nil || !exists {
+		return dependency, err
+	}
+	checksum, err := bm.containingBuild.getFileChecksum(archivePath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
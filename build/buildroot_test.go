@@ -0,0 +1,104 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForBuildrootImage(t *testing.T) {
+	service := NewBuildInfoService()
+	buildrootBuild, err := service.GetOrCreateBuild("build-info-go-test-buildroot", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, buildrootBuild.Clean())
+	}()
+	// The legal-info directory is found in the Buildroot directory.
+	buildrootModule, err := buildrootBuild.AddBuildrootModule(filepath.Join("testdata", "buildroot"))
+	if assert.NoError(t, err) {
+		err = buildrootModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := buildrootBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Buildroot, module.Type)
+		assert.Equal(t, "qemu_x86_64", module.Id)
+
+		// The host packages aren't included by default.
+		assert.Len(t, module.Dependencies, 5)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "busybox:1.36.1":
+				assert.Equal(t, "buildroot", dependency.Type)
+				assert.Equal(t, entities.Checksum{Md5: "be91e98c8454d8106eca685da1fad3e2", Sha1: "8cbe11d06f5ba672b37a9280c9fbb50d67870732", Sha256: "da4d9de7e2af318d44a5a43b901f0e6579e7f03bbfbcb32ef141c654d7d86f43"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{BuildrootLicenseProperty: "GPL-2.0, bzip2-1.0.4", BuildrootSourceSiteProperty: "https://www.busybox.net/downloads"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "dropbear:2022.83", "linux:6.1.44":
+				// The source archives weren't saved.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "skeleton-init-sysv":
+				assert.Equal(t, map[string]string{BuildrootLicenseProperty: "GPL-2.0"}, dependency.Properties)
+				assert.Equal(t, [][]string{{"busybox:1.36.1", module.Id}}, dependency.RequestedBy)
+			case "zlib:1.3":
+				assert.Equal(t, [][]string{{"dropbear:2022.83", module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForBuildrootImageWithHostPackages(t *testing.T) {
+	service := NewBuildInfoService()
+	buildrootBuild, err := service.GetOrCreateBuild("build-info-go-test-buildroot-host", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, buildrootBuild.Clean())
+	}()
+	buildrootModule, err := buildrootBuild.AddBuildrootModule(filepath.Join("testdata", "buildroot", "output", "legal-info"))
+	if assert.NoError(t, err) {
+		buildrootModule.SetIncludeHostPackages(true)
+		err = buildrootModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := buildrootBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+
+		assert.Len(t, module.Dependencies, 7)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "host-gcc-final:12.3.0":
+				assert.Equal(t, [][]string{{"linux:6.1.44", module.Id}}, dependency.RequestedBy)
+			case "host-skeleton":
+				assert.ElementsMatch(t, [][]string{
+					{"busybox:1.36.1", module.Id},
+					{"dropbear:2022.83", module.Id},
+					{"zlib:1.3", "dropbear:2022.83", module.Id},
+					{"host-gcc-final:12.3.0", "linux:6.1.44", module.Id},
+				}, dependency.RequestedBy)
+			}
+		}
+	}
+}
+
+func TestBuildrootModuleNameFallback(t *testing.T) {
+	service := NewBuildInfoService()
+	buildrootBuild, err := service.GetOrCreateBuild("build-info-go-test-buildroot-name", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, buildrootBuild.Clean())
+	}()
+	// The configuration of the image wasn't saved, so the directory name is used.
+	buildrootModule, err := buildrootBuild.AddBuildrootModule(filepath.Join("testdata", "buildroot", "nodefconfig"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "nodefconfig", buildrootModule.name)
+	}
+
+	_, err = buildrootBuild.AddBuildrootModule(filepath.Join("testdata", "buildroot", "nodefconfig", "missing"))
+	assert.ErrorContains(t, err, "Run 'make legal-info' to create it")
+}
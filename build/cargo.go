@@ -0,0 +1,172 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+type CargoModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	// The ID of the package defined in the Cargo.toml file. Empty if the project is a virtual workspace.
+	packageId string
+}
+
+func newCargoModule(srcPath string, containingBuild *Build) (*CargoModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.CargoManifestFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	packageId, err := buildutils.GetCargoPackageId(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := packageId
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No package is defined in %s. Using the directory name: %s as module name.", buildutils.CargoManifestFileName, name))
+	}
+
+	return &CargoModule{name: name, srcPath: srcPath, packageId: packageId, containingBuild: containingBuild}, nil
+}
+
+func (cm *CargoModule) CalcDependencies() error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := cm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: cm.name, Type: entities.Cargo, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(cm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return cm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (cm *CargoModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *CargoModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+func (cm *CargoModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: entities.Cargo, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (cm *CargoModule) loadDependencies() ([]entities.Dependency, error) {
+	// The Cargo.lock file of a workspace member is located in the root of the workspace.
+	lockDir, err := utils.FindFileInDirAndParents(cm.srcPath, buildutils.CargoLockFileName)
+	if err != nil {
+		return nil, fmt.Errorf("%s. Run 'cargo generate-lockfile' to create it", err.Error())
+	}
+	packages, err := buildutils.ReadCargoLock(filepath.Join(lockDir, buildutils.CargoLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	dependenciesGraph := buildutils.GetCargoDependenciesGraph(packages)
+
+	// The packages of the module are its own package, or all members of the workspace if it's a virtual workspace.
+	modulePackages := make(map[string]bool)
+	var directDependencies []string
+	for _, pkg := range packages {
+		if (cm.packageId == "" && pkg.Source == "") || pkg.Id() == cm.packageId {
+			modulePackages[pkg.Id()] = true
+			directDependencies = append(directDependencies, dependenciesGraph[pkg.Id()]...)
+		}
+	}
+	dependenciesGraph[cm.name] = directDependencies
+
+	cachePath, err := buildutils.GetCargoRegistryCachePath()
+	if err != nil {
+		return nil, err
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	for _, pkg := range getReachableCargoPackages(cm.name, packages, dependenciesGraph) {
+		if modulePackages[pkg.Id()] {
+			continue
+		}
+		dependency, err := cm.getCargoDependency(cachePath, pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[dependency.Id] = dependency
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(cm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, cm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Returns the packages, which are reachable in the graph from the given root.
+// Packages of other members of the workspace and their dependencies are listed in the same Cargo.lock file, and therefore should be filtered out.
+func getReachableCargoPackages(rootId string, packages []buildutils.CargoPackage, dependenciesGraph map[string][]string) []buildutils.CargoPackage {
+	visited := make(map[string]bool)
+	queue := []string{rootId}
+	for len(queue) > 0 {
+		currentId := queue[0]
+		queue = queue[1:]
+		for _, childId := range dependenciesGraph[currentId] {
+			if !visited[childId] {
+				visited[childId] = true
+				queue = append(queue, childId)
+			}
+		}
+	}
+	var reachable []buildutils.CargoPackage
+	for _, pkg := range packages {
+		if visited[pkg.Id()] {
+			reachable = append(reachable, pkg)
+		}
+	}
+	return reachable
+}
+
+// Creates the build-info dependency of a package.
+// The checksums are calculated from the .crate file in the registry cache. If it's missing, the sha256 checksum from the Cargo.lock file is used.
+func (cm *CargoModule) getCargoDependency(cachePath string, pkg buildutils.CargoPackage) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: "crate"}
+	cratePath, err := buildutils.FindCargoCrate(cachePath, pkg)
+	if err != nil {
+		return dependency, err
+	}
+	if cratePath == "" {
+		if pkg.Checksum == "" {
+			cm.containingBuild.logger.Debug(fmt.Sprintf("The crate of %s was not found in the registry cache and has no checksum in %s. Its checksums will not be calculated.", pkg.Id(), buildutils.CargoLockFileName))
+		}
+		dependency.Checksum = entities.Checksum{Sha256: pkg.Checksum}
+		return dependency, nil
+	}
+	checksum, err := cm.containingBuild.getFileChecksum(cratePath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
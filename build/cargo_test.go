@@ -0,0 +1,63 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForCargoProject(t *testing.T) {
+	cargoHome, err := filepath.Abs(filepath.Join("testdata", "cargo", "cargohome"))
+	assert.NoError(t, err)
+	t.Setenv("CARGO_HOME", cargoHome)
+
+	service := NewBuildInfoService()
+	cargoBuild, err := service.GetOrCreateBuild("build-info-go-test-cargo", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cargoBuild.Clean())
+	}()
+	cargoModule, err := cargoBuild.AddCargoModule(filepath.Join("testdata", "cargo", "project"))
+	if assert.NoError(t, err) {
+		err = cargoModule.CalcDependencies()
+		assert.NoError(t, err)
+		err = cargoModule.AddArtifacts(entities.Artifact{Name: "artifactName", Type: "artifactType", Path: "artifactPath", Checksum: entities.Checksum{Sha1: "123", Md5: "456", Sha256: "789"}})
+		assert.NoError(t, err)
+		buildInfo, err := cargoBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Cargo, module.Type)
+		assert.Equal(t, "cargo-project:0.1.0", module.Id)
+		assert.Len(t, module.Artifacts, 1)
+
+		// The dependencies of the other workspace member ('rand:0.7.3') are excluded.
+		expectedRequestedBy := map[string][][]string{
+			"serde:1.0.188":    {{module.Id}},
+			"rand:0.8.5":       {{module.Id}},
+			"rand_core:0.6.4":  {{"rand:0.8.5", module.Id}},
+			"getrandom:0.2.10": {{"rand_core:0.6.4", "rand:0.8.5", module.Id}},
+			"cfg-if:1.0.0":     {{"getrandom:0.2.10", "rand_core:0.6.4", "rand:0.8.5", module.Id}},
+		}
+		assert.Len(t, module.Dependencies, len(expectedRequestedBy))
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, expectedRequestedBy[dependency.Id], dependency.RequestedBy, dependency.Id)
+			switch dependency.Id {
+			case "rand_core:0.6.4", "cfg-if:1.0.0":
+				// Calculated from the .crate files in the registry cache.
+				assert.NotEmpty(t, dependency.Md5)
+				assert.NotEmpty(t, dependency.Sha1)
+				assert.NotEmpty(t, dependency.Sha256)
+			case "serde:1.0.188":
+				// Git dependencies have no checksums.
+				assert.True(t, dependency.Checksum.IsEmpty())
+			default:
+				// Taken from the Cargo.lock file.
+				assert.Empty(t, dependency.Sha1)
+				assert.NotEmpty(t, dependency.Sha256)
+			}
+		}
+	}
+}
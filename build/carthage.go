@@ -0,0 +1,160 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency property, which holds the URL of the git repository of a dependency, or of its binary project specification.
+	CarthageUrlProperty = "carthage.url"
+)
+
+type CarthageModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the Cartfile.resolved file in the working directory or in its parents.
+func newCarthageModule(srcPath string, containingBuild *Build) (*CarthageModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.CartfileResolvedFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The Cartfile doesn't define a name, so the module is named after the project's directory.
+	return &CarthageModule{name: filepath.Base(srcPath), srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the dependencies listed in the Cartfile.resolved file, and the frameworks built for them in the Carthage/Build directory.
+func (cm *CarthageModule) CalcDependencies() error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := cm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: cm.name, Type: entities.Carthage, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(cm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return cm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (cm *CarthageModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *CarthageModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+func (cm *CarthageModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: entities.Carthage, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (cm *CarthageModule) loadDependencies() ([]entities.Dependency, error) {
+	resolved, err := buildutils.ReadCartfileResolved(cm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'carthage update' to create it", buildutils.CartfileResolvedFileName, err.Error())
+	}
+	idsByName := make(map[string]string)
+	for _, dependency := range resolved {
+		idsByName[dependency.Name()] = dependency.Id()
+	}
+	projectDependencies, err := buildutils.GetCartfileDependenciesNames(cm.srcPath, true)
+	if err != nil {
+		return nil, err
+	}
+	dependenciesGraph := map[string][]string{cm.name: getMixPackagesIds(idsByName, projectDependencies)}
+	dependenciesMap := make(map[string]entities.Dependency)
+	requested := make(map[string]bool)
+	buildDir := filepath.Join(cm.srcPath, buildutils.CarthageBuildDir)
+	for _, dependency := range resolved {
+		dependenciesMap[dependency.Id()] = createCarthageDependency(dependency)
+		// The Cartfile.resolved file doesn't list the dependencies of the dependencies, so they are read from their checkouts.
+		names, err := buildutils.GetCarthageCheckoutDependenciesNames(cm.srcPath, dependency.Name())
+		if err != nil {
+			return nil, err
+		}
+		dependenciesGraph[dependency.Id()] = getMixPackagesIds(idsByName, names)
+		for _, childId := range dependenciesGraph[dependency.Id()] {
+			requested[childId] = true
+		}
+		// The frameworks built for the dependency are its children.
+		frameworks, err := buildutils.ReadCarthageVersionFile(buildDir, dependency.Name())
+		if err != nil {
+			return nil, err
+		}
+		for _, framework := range frameworks {
+			frameworkDependency, err := cm.createCarthageFrameworkDependency(buildDir, dependency.Version, framework)
+			if err != nil {
+				return nil, err
+			}
+			dependenciesMap[frameworkDependency.Id] = frameworkDependency
+			dependenciesGraph[dependency.Id()] = append(dependenciesGraph[dependency.Id()], frameworkDependency.Id)
+		}
+	}
+	// Dependencies, which aren't required by other dependencies (for example, if they weren't checked out), are considered as direct dependencies too.
+	for _, dependency := range resolved {
+		if !requested[dependency.Id()] && !slices.Contains(dependenciesGraph[cm.name], dependency.Id()) {
+			dependenciesGraph[cm.name] = append(dependenciesGraph[cm.name], dependency.Id())
+		}
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(cm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, cm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a dependency listed in the Cartfile.resolved file.
+// The sha1 checksum of git dependencies, which are resolved to a commit rather than to a tag, is the commit.
+func createCarthageDependency(dependency buildutils.CarthageDependency) entities.Dependency {
+	buildInfoDependency := entities.Dependency{Id: dependency.Id(), Type: "git"}
+	if dependency.Origin == buildutils.CarthageBinaryOrigin {
+		buildInfoDependency.Type = "binary"
+	} else if buildutils.IsGitCommit(dependency.Version) {
+		buildInfoDependency.Sha1 = dependency.Version
+	}
+	setDependencyProperties(&buildInfoDependency, map[string]string{CarthageUrlProperty: dependency.GetSourceUrl()})
+	return buildInfoDependency
+}
+
+// Creates the build-info dependency of a framework built for a dependency, for example: 'iOS/Alamofire.framework:5.8.1'.
+// Its checksums are calculated from the binary of the framework. If the binary doesn't exist, its SHA-256 checksum is taken from the version file.
+func (cm *CarthageModule) createCarthageFrameworkDependency(buildDir, version string, framework buildutils.CarthageFramework) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: framework.GetRelativePath() + ":" + version, Type: "framework", Checksum: entities.Checksum{Sha256: framework.Hash}}
+	binaryPath := framework.GetBinaryPath(buildDir)
+	exists, err := utils.IsFileExists(binaryPath, true)
+	if err != nil || !exists {
+		return dependency, err
+	}
+	checksum, err := cm.containingBuild.getFileChecksum(binaryPath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
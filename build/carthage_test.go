@@ -0,0 +1,68 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForCarthageProject(t *testing.T) {
+	service := NewBuildInfoService()
+	carthageBuild, err := service.GetOrCreateBuild("build-info-go-test-carthage", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, carthageBuild.Clean())
+	}()
+	carthageModule, err := carthageBuild.AddCarthageModule(filepath.Join("testdata", "carthage", "project"))
+	if assert.NoError(t, err) {
+		err = carthageModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := carthageBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Carthage, module.Type)
+		assert.Equal(t, "project", module.Id)
+
+		assert.Len(t, module.Dependencies, 9)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "FirebaseAnalyticsBinary:10.18.0":
+				assert.Equal(t, "binary", dependency.Type)
+				assert.Equal(t, map[string]string{CarthageUrlProperty: "https://dl.google.com/dl/firebase/ios/carthage/FirebaseAnalyticsBinary.json"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "CwlPreconditionTesting:2.1.2":
+				assert.Equal(t, "git", dependency.Type)
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, [][]string{{"Nimble:v13.0.0", module.Id}}, dependency.RequestedBy)
+			case "Alamofire:5.8.1":
+				assert.Equal(t, map[string]string{CarthageUrlProperty: "https://github.com/Alamofire/Alamofire.git"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "Alamofire.xcframework/ios-arm64:5.8.1":
+				assert.Equal(t, "framework", dependency.Type)
+				assert.Equal(t, entities.Checksum{Md5: "36b44b5898314caddca82c5b0f29fed3", Sha1: "c2e1a5887945848940112e2e45e2dfeb1a2a0577", Sha256: "322517478aec6ed9905e72f8c3c516ff3d209cf83e6c3426f616196dc53b4fb9"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{"Alamofire:5.8.1", module.Id}}, dependency.RequestedBy)
+			case "Alamofire.xcframework/ios-arm64_x86_64-simulator:5.8.1":
+				// The binary of the framework doesn't exist, so its checksum is taken from the version file.
+				assert.Equal(t, entities.Checksum{Sha256: "7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1a0f9e8d7c6b"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{"Alamofire:5.8.1", module.Id}}, dependency.RequestedBy)
+			case "Nimble:v13.0.0":
+				// A private dependency of the project.
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "RxSwift:b4307ba0b6425c0ba4178e138799946c3da594f8":
+				// Resolved to a commit.
+				assert.Equal(t, entities.Checksum{Sha1: "b4307ba0b6425c0ba4178e138799946c3da594f8"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "iOS/RxSwift.framework:b4307ba0b6425c0ba4178e138799946c3da594f8":
+				assert.Equal(t, entities.Checksum{Md5: "46b8badc5a5ff03b00ec3539bae0a385", Sha1: "5b468e7a0d09a0bc9d8dcffeb75de3643426385d", Sha256: "234cbe489038bf7454fc3e373ee131040a478a223c54b135da5b48b922f35d5e"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{"RxSwift:b4307ba0b6425c0ba4178e138799946c3da594f8", module.Id}}, dependency.RequestedBy)
+			case "iOS/RxCocoa.framework:b4307ba0b6425c0ba4178e138799946c3da594f8":
+				assert.Equal(t, entities.Checksum{Sha256: "1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f809"}, dependency.Checksum)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+type ClojureModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	// Indicates that the project is a Leiningen project (with a project.clj file), rather than a project with a deps.edn file.
+	leiningen bool
+	// The local Maven repository, in which the jars of the dependencies are found. The default one (~/.m2/repository) is used if empty.
+	localRepository string
+}
+
+// Pass an empty string for srcPath if the root of the Clojure project (which holds its deps.edn or project.clj file) is the working directory.
+func newClojureModule(srcPath string, containingBuild *Build) (*ClojureModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+	leiningen, err := buildutils.IsLeiningenProject(srcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read module name
+	name := ""
+	if leiningen {
+		name, err = buildutils.GetLeiningenProjectId(srcPath)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		exists, err := utils.IsFileExists(filepath.Join(srcPath, buildutils.ClojureDepsFileName), true)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("neither a %s file nor a %s file was found in %s", buildutils.ClojureDepsFileName, buildutils.LeiningenProjectFileName, srcPath)
+		}
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No project name is defined. Using the directory name: %s as module name.", name))
+	}
+
+	return &ClojureModule{name: name, srcPath: srcPath, leiningen: leiningen, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies runs 'clojure -Stree' (or 'lein deps :tree' in Leiningen projects) to collect the resolved dependencies of the project.
+func (cm *ClojureModule) CalcDependencies() error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	var roots []*buildutils.ClojureDependency
+	var err error
+	if cm.leiningen {
+		roots, err = buildutils.RunLeiningenDepsTree(cm.srcPath)
+	} else {
+		roots, err = buildutils.RunClojureTree(cm.srcPath)
+	}
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: cm.name, Type: entities.Clojure, Dependencies: cm.getClojureDependencies(roots)}
+	buildInfoModule.AddProperties(cm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return cm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (cm *ClojureModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *ClojureModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+// SetLocalRepository sets the local Maven repository, to which the dependencies were downloaded, if the project sets ':mvn/local-repo' (or ':local-repo' in Leiningen projects).
+func (cm *ClojureModule) SetLocalRepository(localRepository string) {
+	cm.localRepository = localRepository
+}
+
+func (cm *ClojureModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: entities.Clojure, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// Converts the dependency tree of the project to build-info dependencies.
+func (cm *ClojureModule) getClojureDependencies(roots []*buildutils.ClojureDependency) []entities.Dependency {
+	repositoryPath := cm.localRepository
+	if repositoryPath == "" {
+		var err error
+		repositoryPath, err = buildutils.GetMavenLocalRepositoryPath()
+		if err != nil {
+			cm.containingBuild.logger.Debug("Couldn't find the local Maven repository:", err.Error())
+		}
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	cm.addClojureDependenciesToGraph(cm.name, roots, repositoryPath, dependenciesMap, dependenciesGraph)
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(cm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, cm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap)
+}
+
+func (cm *ClojureModule) addClojureDependenciesToGraph(parentId string, dependencies []*buildutils.ClojureDependency, repositoryPath string, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) {
+	for _, dependency := range dependencies {
+		if !slices.Contains(dependenciesGraph[parentId], dependency.Id) {
+			dependenciesGraph[parentId] = append(dependenciesGraph[parentId], dependency.Id)
+		}
+		if _, exists := dependenciesMap[dependency.Id]; exists {
+			continue
+		}
+		dependenciesMap[dependency.Id] = cm.createClojureDependency(dependency.Id, repositoryPath)
+		cm.addClojureDependenciesToGraph(dependency.Id, dependency.Dependencies, repositoryPath, dependenciesMap, dependenciesGraph)
+	}
+}
+
+// Creates the build-info dependency of a Clojure dependency.
+// The checksums are calculated from the jar in the local Maven repository, to which both the Clojure CLI and Leiningen download the Maven artifacts.
+// Git and local dependencies aren't downloaded to the local Maven repository, so they have no checksums.
+func (cm *ClojureModule) createClojureDependency(id, repositoryPath string) entities.Dependency {
+	dependency := entities.Dependency{Id: id, Type: "jar"}
+	parts := strings.SplitN(id, ":", 3)
+	if repositoryPath == "" || len(parts) != 3 {
+		return dependency
+	}
+	jarPath, err := buildutils.FindMavenLocalRepositoryJar(repositoryPath, parts[0], parts[1], parts[2])
+	if err != nil || jarPath == "" {
+		cm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't find the jar of %s in the local Maven repository.", id))
+		return dependency
+	}
+	checksum, err := cm.containingBuild.getFileChecksum(jarPath)
+	if err != nil {
+		cm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't calculate the checksums of %s: %s", jarPath, err.Error()))
+		return dependency
+	}
+	dependency.Checksum = checksum
+	return dependency
+}
@@ -0,0 +1,78 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClojureDependencies(t *testing.T) {
+	service := NewBuildInfoService()
+	clojureBuild, err := service.GetOrCreateBuild("build-info-go-test-clojure", "1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, clojureBuild.Clean())
+	}()
+	clojureModule, err := clojureBuild.AddClojureModule(filepath.Join("testdata", "clojure", "deps-project"))
+	require.NoError(t, err)
+	// The deps.edn file doesn't define a name, so the directory name is used.
+	assert.Equal(t, "deps-project", clojureModule.name)
+	assert.False(t, clojureModule.leiningen)
+	clojureModule.SetLocalRepository(filepath.Join("testdata", "clojure", "m2"))
+
+	output, err := os.ReadFile(filepath.Join("testdata", "clojure", "clojure-stree.txt"))
+	require.NoError(t, err)
+	roots, err := buildutils.ParseClojureTree(output)
+	require.NoError(t, err)
+
+	dependencies := clojureModule.getClojureDependencies(roots)
+	assert.Len(t, dependencies, 9)
+	for _, dependency := range dependencies {
+		assert.Equal(t, "jar", dependency.Type)
+		switch dependency.Id {
+		case "org.clojure:clojure:1.11.1":
+			assert.Equal(t, [][]string{{clojureModule.name}}, dependency.RequestedBy)
+			// The checksums of the jar in the local Maven repository.
+			assert.Equal(t, "99bac2a3604eb336840f6ab676dcd5d5", dependency.Md5)
+			assert.NotEmpty(t, dependency.Sha1)
+			assert.NotEmpty(t, dependency.Sha256)
+		case "cheshire:cheshire:5.12.0":
+			assert.Equal(t, [][]string{{clojureModule.name}}, dependency.RequestedBy)
+			assert.NotEmpty(t, dependency.Sha1)
+		case "io.github.cognitect-labs:test-runner:dfb30dd":
+			// A git dependency, which isn't in the local Maven repository.
+			assert.Equal(t, [][]string{{clojureModule.name}}, dependency.RequestedBy)
+			assert.True(t, dependency.Checksum.IsEmpty())
+		case "org.clojure:spec.alpha:0.3.218", "org.clojure:core.specs.alpha:0.2.62":
+			assert.Equal(t, [][]string{{"org.clojure:clojure:1.11.1", clojureModule.name}}, dependency.RequestedBy)
+		case "com.fasterxml.jackson.core:jackson-core:2.15.2", "com.fasterxml.jackson.dataformat:jackson-dataformat-smile:2.15.2", "tigris:tigris:0.1.2":
+			assert.Equal(t, [][]string{{"cheshire:cheshire:5.12.0", clojureModule.name}}, dependency.RequestedBy)
+		case "org.clojure:tools.cli:1.0.206":
+			assert.Equal(t, [][]string{{"io.github.cognitect-labs:test-runner:dfb30dd", clojureModule.name}}, dependency.RequestedBy)
+		default:
+			assert.Fail(t, "Unexpected dependency "+dependency.Id)
+		}
+	}
+}
+
+func TestClojureLeiningenModule(t *testing.T) {
+	service := NewBuildInfoService()
+	clojureBuild, err := service.GetOrCreateBuild("build-info-go-test-clojure", "1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, clojureBuild.Clean())
+	}()
+	clojureModule, err := clojureBuild.AddClojureModule(filepath.Join("testdata", "clojure", "lein-project"))
+	require.NoError(t, err)
+	// The module is named after the defproject form in the project.clj file.
+	assert.Equal(t, "org.example:hello:0.1.0-SNAPSHOT", clojureModule.name)
+	assert.True(t, clojureModule.leiningen)
+
+	// A directory without a deps.edn file or a project.clj file.
+	_, err = clojureBuild.AddClojureModule(filepath.Join("testdata", "clojure"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the URL (of the git repository or the archive) and the git tag of a dependency downloaded by FetchContent.
+	CMakeUrlProperty    = "cmake.url"
+	CMakeGitTagProperty = "cmake.gitTag"
+)
+
+type CMakeModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	buildDir        string
+}
+
+// Pass an empty string for srcPath if the source directory of the CMake project is the working directory.
+// The build directory is the source directory, if the project was configured in-source, or its 'build' sub-directory otherwise. Use SetBuildDir to set a different directory.
+func newCMakeModule(srcPath string, containingBuild *Build) (*CMakeModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.CMakeListsFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, version, err := buildutils.GetCMakeProjectNameAndVersion(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No project name is defined in the %s file. Using the directory name: %s as module name.", buildutils.CMakeListsFileName, name))
+	} else if version != "" {
+		name += ":" + version
+	}
+
+	buildDir := srcPath
+	inSource, err := utils.IsFileExists(filepath.Join(srcPath, buildutils.CMakeCacheFileName), true)
+	if err != nil {
+		return nil, err
+	}
+	if !inSource {
+		buildDir = filepath.Join(srcPath, "build")
+	}
+	return &CMakeModule{name: name, srcPath: srcPath, buildDir: buildDir, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the dependencies downloaded by FetchContent (or CPM) to the build directory.
+func (cm *CMakeModule) CalcDependencies() error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := cm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: cm.name, Type: entities.CMake, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(cm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return cm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (cm *CMakeModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *CMakeModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+// SetBuildDir sets the build directory of the project, which contains the CMakeCache.txt file.
+func (cm *CMakeModule) SetBuildDir(buildDir string) {
+	cm.buildDir = buildDir
+}
+
+func (cm *CMakeModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: entities.CMake, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (cm *CMakeModule) loadDependencies() ([]entities.Dependency, error) {
+	fetchedDependencies, err := buildutils.GetCMakeFetchedDependencies(cm.buildDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the build directory %s: %s. Run 'cmake' to configure the project", cm.buildDir, err.Error())
+	}
+	var dependencies []entities.Dependency
+	for _, fetchedDependency := range fetchedDependencies {
+		dependency, err := cm.createCMakeDependency(fetchedDependency)
+		if err != nil {
+			return nil, err
+		}
+		// The build directory doesn't specify which dependencies declared each other, so they're all considered as direct dependencies.
+		dependency.RequestedBy = [][]string{{cm.name}}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+// Creates the build-info dependency of a dependency downloaded by FetchContent.
+// The checksums of archives are calculated from the downloaded archive if it's kept in the build directory, or taken from their expected URL hash otherwise.
+// The sha1 checksum of git repositories is the commit checked out in their source directory.
+func (cm *CMakeModule) createCMakeDependency(fetchedDependency buildutils.CMakeFetchedDependency) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: fetchedDependency.Id()}
+	if fetchedDependency.GitRepository != "" {
+		dependency.Type = "git"
+		setDependencyProperties(&dependency, map[string]string{CMakeUrlProperty: fetchedDependency.GitRepository, CMakeGitTagProperty: fetchedDependency.GitTag})
+		if buildutils.IsGitCommit(fetchedDependency.GitTag) {
+			dependency.Sha1 = fetchedDependency.GitTag
+			return dependency, nil
+		}
+		if fetchedDependency.SourceDir != "" {
+			commit, err := buildutils.GetGitHeadCommit(fetchedDependency.SourceDir)
+			if err != nil {
+				return dependency, err
+			}
+			dependency.Sha1 = commit
+		}
+		return dependency, nil
+	}
+
+	dependency.Type = "archive"
+	setDependencyProperties(&dependency, map[string]string{CMakeUrlProperty: fetchedDependency.Url})
+	if fetchedDependency.ArchivePath != "" {
+		checksum, err := cm.containingBuild.getFileChecksum(fetchedDependency.ArchivePath)
+		if err != nil {
+			return dependency, err
+		}
+		dependency.Checksum = checksum
+		return dependency, nil
+	}
+	if algorithm, hash, found := strings.Cut(fetchedDependency.UrlHash, "="); found {
+		switch strings.ToUpper(algorithm) {
+		case "MD5":
+			dependency.Md5 = strings.ToLower(hash)
+		case "SHA1":
+			dependency.Sha1 = strings.ToLower(hash)
+		case "SHA256":
+			dependency.Sha256 = strings.ToLower(hash)
+		}
+	}
+	return dependency, nil
+}
@@ -0,0 +1,75 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForCMakeProject(t *testing.T) {
+	service := NewBuildInfoService()
+	cmakeBuild, err := service.GetOrCreateBuild("build-info-go-test-cmake", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cmakeBuild.Clean())
+	}()
+	cmakeModule, err := cmakeBuild.AddCMakeModule(filepath.Join("testdata", "cmake", "project"))
+	if assert.NoError(t, err) {
+		err = cmakeModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := cmakeBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.CMake, module.Type)
+		assert.Equal(t, "hello:1.0.0", module.Id)
+
+		// The 'local' dependency was provided using FETCHCONTENT_SOURCE_DIR_LOCAL, so it's not included.
+		assert.Len(t, module.Dependencies, 2)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			switch dependency.Id {
+			case "fmt:10.1.1":
+				assert.Equal(t, "git", dependency.Type)
+				assert.Equal(t, map[string]string{CMakeUrlProperty: "https://github.com/fmtlib/fmt.git", CMakeGitTagProperty: "10.1.1"}, dependency.Properties)
+				// The source directory isn't a git repository, so the commit is unknown.
+				assert.True(t, dependency.Checksum.IsEmpty())
+			case "json:3.11.2":
+				assert.Equal(t, "archive", dependency.Type)
+				assert.Equal(t, map[string]string{CMakeUrlProperty: "https://github.com/nlohmann/json/releases/download/v3.11.2/json-3.11.2.tar.xz"}, dependency.Properties)
+				// Calculated from the archive kept in the sub-build.
+				assert.Equal(t, entities.Checksum{
+					Sha1:   "e1097ff40ff5b6b983c3df2a3ab1688f99dae5ce",
+					Md5:    "adbb55353303f54794a1de3f2ed75ab2",
+					Sha256: "441ed29b7036a6eb54af393b067c99cebf07cac9d088fa29223c62cc3c5aef7d",
+				}, dependency.Checksum)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
+
+func TestCreateCMakeDependency(t *testing.T) {
+	cmakeModule := &CMakeModule{}
+	dependency, err := cmakeModule.createCMakeDependency(buildutils.CMakeFetchedDependency{
+		Name:    "zlib",
+		Url:     "https://zlib.net/zlib-1.3.tar.gz",
+		UrlHash: "SHA256=FF0BA4C292013DBC27530B3A81E1F9A813CD39DE01CA5E0F8BF355702EFA593E",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "zlib:1.3", dependency.Id)
+	assert.Equal(t, entities.Checksum{Sha256: "ff0ba4c292013dbc27530b3a81e1f9a813cd39de01ca5e0f8bf355702efa593e"}, dependency.Checksum)
+
+	dependency, err = cmakeModule.createCMakeDependency(buildutils.CMakeFetchedDependency{
+		Name:          "googletest",
+		GitRepository: "https://github.com/google/googletest.git",
+		GitTag:        "f8d7d77c06936315286eb55f8de22cd23c188571",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "googletest:f8d7d77c06936315286eb55f8de22cd23c188571", dependency.Id)
+	assert.Equal(t, entities.Checksum{Sha1: "f8d7d77c06936315286eb55f8de22cd23c188571"}, dependency.Checksum)
+}
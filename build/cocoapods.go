@@ -0,0 +1,102 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+type CocoapodsModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the CocoaPods project in the working directory.
+func newCocoapodsModule(srcPath string, containingBuild *Build) (*CocoapodsModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, "Podfile")
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The Podfile doesn't define a name, so the module is named after the project's directory.
+	return &CocoapodsModule{name: filepath.Base(srcPath), srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the pods listed in the Podfile.lock file of the project.
+func (cm *CocoapodsModule) CalcDependencies() error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := cm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: cm.name, Type: entities.Cocoapods, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(cm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return cm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (cm *CocoapodsModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *CocoapodsModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+func (cm *CocoapodsModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: entities.Cocoapods, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (cm *CocoapodsModule) loadDependencies() ([]entities.Dependency, error) {
+	pods, directDependencies, err := buildutils.ReadPodfileLock(cm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'pod install' to create it", buildutils.PodfileLockFileName, err.Error())
+	}
+	podsIds := make(map[string]string)
+	for _, pod := range pods {
+		podsIds[pod.Name] = pod.Id()
+	}
+	dependenciesGraph := map[string][]string{cm.name: getPodsIds(podsIds, directDependencies)}
+	dependenciesMap := make(map[string]entities.Dependency)
+	for _, pod := range pods {
+		dependenciesGraph[pod.Id()] = getPodsIds(podsIds, pod.Dependencies)
+		// The spec checksum is the sha1 checksum of the podspec.
+		dependenciesMap[pod.Id()] = entities.Dependency{Id: pod.Id(), Type: "pod", Checksum: entities.Checksum{Sha1: pod.SpecChecksum}}
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(cm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, cm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+func getPodsIds(podsIds map[string]string, names []string) []string {
+	var ids []string
+	for _, name := range names {
+		if id, ok := podsIds[name]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,54 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForCocoapodsProject(t *testing.T) {
+	service := NewBuildInfoService()
+	cocoapodsBuild, err := service.GetOrCreateBuild("build-info-go-test-cocoapods", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cocoapodsBuild.Clean())
+	}()
+	cocoapodsModule, err := cocoapodsBuild.AddCocoapodsModule(filepath.Join("testdata", "cocoapods", "project"))
+	if assert.NoError(t, err) {
+		err = cocoapodsModule.CalcDependencies()
+		assert.NoError(t, err)
+		err = cocoapodsModule.AddArtifacts(entities.Artifact{Name: "artifactName", Type: "artifactType", Path: "artifactPath", Checksum: entities.Checksum{Sha1: "123", Md5: "456", Sha256: "789"}})
+		assert.NoError(t, err)
+		buildInfo, err := cocoapodsBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Cocoapods, module.Type)
+		assert.Equal(t, "project", module.Id)
+		assert.Len(t, module.Artifacts, 1)
+
+		expectedRequestedBy := map[string][][]string{
+			"Alamofire:5.8.0":            {{module.Id}},
+			"Firebase/Analytics:10.15.0": {{module.Id}},
+			"Firebase/Core:10.15.0":      {{"Firebase/Analytics:10.15.0", module.Id}},
+			"Firebase/CoreOnly:10.15.0":  {{"Firebase/Core:10.15.0", "Firebase/Analytics:10.15.0", module.Id}},
+			"FirebaseAnalytics:10.15.0":  {{"Firebase/Core:10.15.0", "Firebase/Analytics:10.15.0", module.Id}},
+			"FirebaseCore:10.15.0": {
+				{"Firebase/CoreOnly:10.15.0", "Firebase/Core:10.15.0", "Firebase/Analytics:10.15.0", module.Id},
+				{"FirebaseAnalytics:10.15.0", "Firebase/Core:10.15.0", "Firebase/Analytics:10.15.0", module.Id},
+			},
+		}
+		assert.Len(t, module.Dependencies, len(expectedRequestedBy))
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "pod", dependency.Type)
+			assert.Equal(t, expectedRequestedBy[dependency.Id], dependency.RequestedBy, dependency.Id)
+			assert.NotEmpty(t, dependency.Sha1, dependency.Id)
+			if dependency.Id == "Firebase/Core:10.15.0" {
+				// Subspecs share the checksum of their pod's podspec.
+				assert.Equal(t, "66043bd4579e5b73811f96829c694c7af8d67435", dependency.Sha1)
+			}
+		}
+	}
+}
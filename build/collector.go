@@ -0,0 +1,136 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+)
+
+// Collector collects a module, so packages outside this library can contribute modules to builds, as the built-in modules do.
+// The returned module has its ID and type, its dependencies, and optionally its artifacts and sub-modules. The RequestedBy paths of the dependencies should end with the ID of the module.
+type Collector interface {
+	Collect(ctx context.Context) (entities.Module, error)
+}
+
+// EnvCollector is implemented by collectors, which also collect environment variables of the build (for example, the configuration of their package manager).
+// The variables are added to the properties of the build-info, with the 'buildInfo.env.' prefix, and can be filtered as the other environment variables.
+type EnvCollector interface {
+	CollectEnv(ctx context.Context) (map[string]string, error)
+}
+
+// CollectorModule is a module, which is collected by a Collector.
+type CollectorModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	moduleType      entities.ModuleType
+	collector       Collector
+}
+
+func newCollectorModule(collector Collector, containingBuild *Build) (*CollectorModule, error) {
+	if collector == nil {
+		return nil, errors.New("a collector must be provided in order to add its module")
+	}
+	return &CollectorModule{collector: collector, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies runs the collector and adds the module it returns to the build, without a deadline.
+func (cm *CollectorModule) CalcDependencies() error {
+	return cm.CalcDependenciesContext(context.Background())
+}
+
+// CalcDependenciesContext runs the collector with the context, and adds the module it returns to the build.
+// If the collector also collects environment variables, they're added to the build too.
+func (cm *CollectorModule) CalcDependenciesContext(ctx context.Context) error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	module, err := cm.collector.Collect(ctx)
+	if err != nil {
+		return err
+	}
+	if cm.name != "" {
+		module.Id = cm.name
+	}
+	if module.Id == "" {
+		return errors.New("the collector returned a module without an ID, and no name was set")
+	}
+	cm.name = module.Id
+	if cm.moduleType != "" {
+		module.Type = cm.moduleType
+	} else if module.Type == "" {
+		module.Type = entities.Generic
+	}
+	cm.moduleType = module.Type
+	if cm.properties != nil {
+		module.Properties = cm.properties
+	}
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{module}}
+	setModulesTiming(buildInfo, started)
+	if err = cm.containingBuild.SaveBuildInfo(buildInfo); err != nil {
+		return err
+	}
+	envCollector, ok := cm.collector.(EnvCollector)
+	if !ok {
+		return nil
+	}
+	env, err := envCollector.CollectEnv(ctx)
+	if err != nil || len(env) == 0 {
+		return err
+	}
+	envMap := make(map[string]string, len(env))
+	for key, value := range env {
+		envMap[entities.BuildInfoEnvPrefix+key] = value
+	}
+	return cm.containingBuild.SavePartialBuildInfo(&entities.Partial{Env: envMap})
+}
+
+func (cm *CollectorModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *CollectorModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+// SetModuleType sets the type of the module, instead of the type of the collected module. If neither is set, the type is generic.
+func (cm *CollectorModule) SetModuleType(moduleType entities.ModuleType) {
+	cm.moduleType = moduleType
+}
+
+func (cm *CollectorModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	if cm.name == "" {
+		return errors.New("the name of the module must be set, or its dependencies calculated, before adding artifacts")
+	}
+	moduleType := cm.moduleType
+	if moduleType == "" {
+		moduleType = entities.Generic
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: moduleType, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// Adapts a CustomCollector of a registered module type to a Collector.
+type customTypeCollector struct {
+	moduleType entities.ModuleType
+	srcPath    string
+	collect    CustomCollector
+}
+
+func (ctc *customTypeCollector) Collect(context.Context) (entities.Module, error) {
+	module, err := ctc.collect(ctc.srcPath)
+	if err != nil {
+		return entities.Module{}, fmt.Errorf("failed to collect the %s module: %w", ctc.moduleType, err)
+	}
+	if module == nil {
+		return entities.Module{}, fmt.Errorf("the collector of the %s modules returned no module", ctc.moduleType)
+	}
+	return *module, nil
+}
@@ -0,0 +1,53 @@
+package build
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCollector struct{}
+
+func (tc *testCollector) Collect(ctx context.Context) (entities.Module, error) {
+	if err := ctx.Err(); err != nil {
+		return entities.Module{}, err
+	}
+	return entities.Module{Id: "plugin-module", Dependencies: []entities.Dependency{{Id: "dep:1.0", RequestedBy: [][]string{{"plugin-module"}}}}}, nil
+}
+
+func (tc *testCollector) CollectEnv(context.Context) (map[string]string, error) {
+	return map[string]string{"PLUGIN_REGISTRY": "https://registry.example.com"}, nil
+}
+
+func TestCollectorModule(t *testing.T) {
+	service := NewBuildInfoService()
+	collectorBuild, err := service.GetOrCreateBuild("build-info-go-test-collector", "1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, collectorBuild.Clean())
+	}()
+	collectorModule, err := collectorBuild.AddCollector(&testCollector{})
+	require.NoError(t, err)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, collectorModule.CalcDependenciesContext(canceled), context.Canceled)
+
+	assert.NoError(t, collectorModule.CalcDependencies())
+	assert.NoError(t, collectorModule.AddArtifacts(entities.Artifact{Name: "plugin.zip", Checksum: entities.Checksum{Sha1: "p1"}}))
+
+	buildInfo, err := collectorBuild.ToBuildInfo()
+	require.NoError(t, err)
+	require.Len(t, buildInfo.Modules, 1)
+	module := buildInfo.Modules[0]
+	assert.Equal(t, "plugin-module", module.Id)
+	assert.Equal(t, entities.Generic, module.Type)
+	assert.Len(t, module.Dependencies, 1)
+	assert.Len(t, module.Artifacts, 1)
+	assert.Equal(t, "https://registry.example.com", buildInfo.Properties["buildInfo.env.PLUGIN_REGISTRY"])
+
+	_, err = collectorBuild.AddCollector(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// The scope of dependencies, which are listed under 'packages-dev' in the composer.lock file.
+const composerDevScope = "dev"
+
+type ComposerModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	composerArgs    []string
+}
+
+// Pass an empty string for srcPath to find the Composer project in the working directory.
+func newComposerModule(srcPath string, containingBuild *Build) (*ComposerModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.ComposerManifestFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	manifest, err := buildutils.ReadComposerManifest(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := manifest.Name
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in %s. Using the directory name: %s as module name.", buildutils.ComposerManifestFileName, name))
+	}
+	if manifest.Version != "" {
+		name += ":" + manifest.Version
+	}
+
+	return &ComposerModule{name: name, srcPath: srcPath, containingBuild: containingBuild, composerArgs: []string{"install"}}, nil
+}
+
+// Build runs Composer with the arguments set by SetArgs ('install' by default), collects the project's dependencies and saves them in the build-info module.
+func (cm *ComposerModule) Build() error {
+	executablePath, err := exec.LookPath("composer")
+	if err != nil {
+		return err
+	}
+	command := exec.Command(executablePath, cm.composerArgs...)
+	command.Dir = cm.srcPath
+	command.Stdout = os.Stderr
+	command.Stderr = os.Stderr
+	err = command.Run()
+	if _, ok := err.(*exec.ExitError); ok {
+		err = errors.New(err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return nil
+	}
+	return cm.CalcDependencies()
+}
+
+// CalcDependencies collects the dependencies from the composer.lock file of the project, without running Composer.
+func (cm *ComposerModule) CalcDependencies() error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := cm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: cm.name, Type: entities.Composer, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(cm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return cm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (cm *ComposerModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *ComposerModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+func (cm *ComposerModule) SetArgs(composerArgs []string) {
+	cm.composerArgs = composerArgs
+}
+
+func (cm *ComposerModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: entities.Composer, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (cm *ComposerModule) loadDependencies() ([]entities.Dependency, error) {
+	manifest, err := buildutils.ReadComposerManifest(cm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := buildutils.ReadComposerLock(cm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'composer install' or 'composer update' to create it", buildutils.ComposerLockFileName, err.Error())
+	}
+	cachePath, err := buildutils.GetComposerCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	packagesIds := make(map[string]string)
+	for _, pkg := range append(lock.Packages, lock.PackagesDev...) {
+		packagesIds[pkg.Name] = pkg.Id()
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := map[string][]string{cm.name: getComposerRequiredIds(packagesIds, manifest.Require, manifest.RequireDev)}
+	for _, pkg := range lock.Packages {
+		dependency, err := cm.getComposerDependency(cachePath, pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[pkg.Id()] = dependency
+		dependenciesGraph[pkg.Id()] = getComposerRequiredIds(packagesIds, pkg.Require)
+	}
+	for _, pkg := range lock.PackagesDev {
+		dependency, err := cm.getComposerDependency(cachePath, pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependency.Scopes = []string{composerDevScope}
+		dependenciesMap[pkg.Id()] = dependency
+		dependenciesGraph[pkg.Id()] = getComposerRequiredIds(packagesIds, pkg.Require)
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(cm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, cm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Returns the IDs of the required packages.
+// Platform packages (such as 'php' and 'ext-json') aren't listed in the composer.lock file, and therefore are skipped.
+func getComposerRequiredIds(packagesIds map[string]string, requires ...map[string]string) []string {
+	var requiredIds []string
+	for _, require := range requires {
+		for name := range require {
+			if id, ok := packagesIds[name]; ok {
+				requiredIds = append(requiredIds, id)
+			}
+		}
+	}
+	sort.Strings(requiredIds)
+	return requiredIds
+}
+
+// Creates the build-info dependency of a package.
+// The checksums are calculated from the archive in the Composer cache. If it's missing, the sha1 checksum from the composer.lock file is used (if exists).
+func (cm *ComposerModule) getComposerDependency(cachePath string, pkg buildutils.ComposerPackage) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: pkg.Dist.Type}
+	archivePath, err := buildutils.GetComposerCachedArchivePath(cachePath, pkg)
+	if err != nil {
+		return dependency, err
+	}
+	if archivePath == "" {
+		cm.containingBuild.logger.Debug(fmt.Sprintf("The archive of %s was not found in the Composer cache.", pkg.Id()))
+		dependency.Checksum = entities.Checksum{Sha1: pkg.Dist.Shasum}
+		return dependency, nil
+	}
+	checksum, err := cm.containingBuild.getFileChecksum(archivePath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
@@ -0,0 +1,59 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForComposerProject(t *testing.T) {
+	cachePath, err := filepath.Abs(filepath.Join("testdata", "composer", "cache"))
+	assert.NoError(t, err)
+	t.Setenv("COMPOSER_CACHE_DIR", cachePath)
+
+	service := NewBuildInfoService()
+	composerBuild, err := service.GetOrCreateBuild("build-info-go-test-composer", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, composerBuild.Clean())
+	}()
+	composerModule, err := composerBuild.AddComposerModule(filepath.Join("testdata", "composer", "project"))
+	if assert.NoError(t, err) {
+		err = composerModule.CalcDependencies()
+		assert.NoError(t, err)
+		err = composerModule.AddArtifacts(entities.Artifact{Name: "artifactName", Type: "artifactType", Path: "artifactPath", Checksum: entities.Checksum{Sha1: "123", Md5: "456", Sha256: "789"}})
+		assert.NoError(t, err)
+		buildInfo, err := composerBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Composer, module.Type)
+		assert.Equal(t, "jfrog/composer-project:1.0.0", module.Id)
+		assert.Len(t, module.Artifacts, 1)
+
+		assert.Len(t, module.Dependencies, 3)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "zip", dependency.Type)
+			switch dependency.Id {
+			case "monolog/monolog:3.4.0":
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+				assert.Empty(t, dependency.Scopes)
+				assert.NotEmpty(t, dependency.Sha256)
+			case "psr/log:3.0.0":
+				assert.Equal(t, [][]string{{"monolog/monolog:3.4.0", module.Id}}, dependency.RequestedBy)
+				assert.NotEmpty(t, dependency.Md5)
+				assert.NotEmpty(t, dependency.Sha1)
+				assert.NotEmpty(t, dependency.Sha256)
+			case "phpunit/php-timer:6.0.0":
+				// The archive is missing from the cache, so the checksum is taken from the composer.lock file.
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+				assert.Equal(t, []string{composerDevScope}, dependency.Scopes)
+				assert.Equal(t, entities.Checksum{Sha1: "1f1c2a3b4d5e6f708192a3b4c5d6e7f8091a2b3c"}, dependency.Checksum)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
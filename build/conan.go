@@ -0,0 +1,161 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the revisions and the package ID of Conan packages.
+	ConanRecipeRevisionProperty  = "conan.recipeRevision"
+	ConanPackageIdProperty       = "conan.packageId"
+	ConanPackageRevisionProperty = "conan.packageRevision"
+
+	// The scope of tool requirements, which are used only during the build.
+	conanBuildScope = "build"
+	// The scope of test requirements.
+	conanTestScope = "test"
+)
+
+type ConanModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	conanArgs       []string
+}
+
+// Pass an empty string for srcPath to find the Conan project in the working directory.
+func newConanModule(srcPath string, containingBuild *Build) (*ConanModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = findConanfileDir(srcPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, version, err := buildutils.GetConanfileNameAndVersion(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the conanfile. Using the directory name: %s as module name.", name))
+	} else if version != "" {
+		name += "/" + version
+	}
+
+	return &ConanModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// Returns the directory of the conanfile.py or conanfile.txt file, starting the search from the given directory.
+func findConanfileDir(dirPath string) (string, error) {
+	srcPath, err := utils.FindFileInDirAndParents(dirPath, buildutils.ConanfilePyFileName)
+	if err == nil {
+		return srcPath, nil
+	}
+	return utils.FindFileInDirAndParents(dirPath, buildutils.ConanfileTxtFileName)
+}
+
+// CalcDependencies runs 'conan graph info' to collect the dependencies of the project.
+func (cm *ConanModule) CalcDependencies() error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	graph, err := buildutils.RunConanGraphInfo(cm.srcPath, cm.conanArgs)
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: cm.name, Type: entities.Conan, Dependencies: cm.getConanDependencies(graph)}
+	buildInfoModule.AddProperties(cm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return cm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (cm *ConanModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *ConanModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+// SetArgs sets additional arguments for the 'conan graph info' command, such as profiles and settings.
+func (cm *ConanModule) SetArgs(conanArgs []string) {
+	cm.conanArgs = conanArgs
+}
+
+func (cm *ConanModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: entities.Conan, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// Converts the nodes of the Conan graph to build-info dependencies.
+func (cm *ConanModule) getConanDependencies(graph *buildutils.ConanGraph) []entities.Dependency {
+	nodes := graph.Graph.Nodes
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	for nodeId, node := range nodes {
+		parentId := node.Reference()
+		if _, isRoot := graph.Graph.Root[nodeId]; isRoot {
+			parentId = cm.name
+		} else {
+			dependenciesMap[parentId] = cm.createConanDependency(node)
+		}
+		for childNodeId := range node.Dependencies {
+			if childNode, ok := nodes[childNodeId]; ok {
+				dependenciesGraph[parentId] = append(dependenciesGraph[parentId], childNode.Reference())
+			}
+		}
+		sort.Strings(dependenciesGraph[parentId])
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(cm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, cm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap)
+}
+
+// Creates the build-info dependency of a Conan package.
+// The checksums are the checksums of the recipe's manifest, which lists the checksums of all recipe files.
+func (cm *ConanModule) createConanDependency(node buildutils.ConanNode) entities.Dependency {
+	dependency := entities.Dependency{Id: node.Reference(), Type: "conan"}
+	setDependencyProperties(&dependency, map[string]string{
+		ConanRecipeRevisionProperty:  node.Rrev,
+		ConanPackageIdProperty:       node.PackageId,
+		ConanPackageRevisionProperty: node.Prev,
+	})
+	if node.Context == conanBuildScope {
+		dependency.Scopes = append(dependency.Scopes, conanBuildScope)
+	}
+	if node.Test {
+		dependency.Scopes = append(dependency.Scopes, conanTestScope)
+	}
+	if node.RecipeFolder != "" {
+		manifestPath := filepath.Join(node.RecipeFolder, buildutils.ConanManifestFileName)
+		if checksum, err := cm.containingBuild.getFileChecksum(manifestPath); err != nil {
+			cm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't calculate the checksums of the recipe of %s: %s", dependency.Id, err.Error()))
+		} else {
+			dependency.Checksum = checksum
+		}
+	}
+	return dependency
+}
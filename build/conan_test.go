@@ -0,0 +1,69 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConanDependencies(t *testing.T) {
+	service := NewBuildInfoService()
+	conanBuild, err := service.GetOrCreateBuild("build-info-go-test-conan", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, conanBuild.Clean())
+	}()
+	conanModule, err := conanBuild.AddConanModule(filepath.Join("testdata", "conan", "project"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	// The module is named after the name and version in the conanfile.py.
+	assert.Equal(t, "hello/1.0", conanModule.name)
+
+	recipeFolder, err := filepath.Abs(filepath.Join("testdata", "conan", "recipes", "zlib"))
+	assert.NoError(t, err)
+	output, err := os.ReadFile(filepath.Join("testdata", "conan", "graph.json"))
+	assert.NoError(t, err)
+	graph, err := buildutils.ParseConanGraph([]byte(strings.ReplaceAll(string(output), "RECIPE_FOLDER", filepath.ToSlash(recipeFolder))))
+	assert.NoError(t, err)
+
+	dependencies := conanModule.getConanDependencies(graph)
+	assert.Len(t, dependencies, 4)
+	for _, dependency := range dependencies {
+		assert.Equal(t, "conan", dependency.Type)
+		switch dependency.Id {
+		case "openssl/3.1.2":
+			assert.Equal(t, [][]string{{"hello/1.0"}}, dependency.RequestedBy)
+			assert.Equal(t, map[string]string{
+				ConanRecipeRevisionProperty:  "8879e931d726a8aad7f372e28470faa1",
+				ConanPackageIdProperty:       "b647c43bfefae3f830561ca202b6cfd935b56205",
+				ConanPackageRevisionProperty: "d7a0b9b29c3e4a8b1c2d3e4f5a6b7c8d",
+			}, dependency.Properties)
+			assert.Empty(t, dependency.Scopes)
+		case "zlib/1.2.13":
+			assert.Equal(t, [][]string{{"openssl/3.1.2", "hello/1.0"}, {"hello/1.0"}}, dependency.RequestedBy)
+			// The checksums of the recipe's manifest.
+			assert.NotEmpty(t, dependency.Md5)
+			assert.NotEmpty(t, dependency.Sha1)
+			assert.NotEmpty(t, dependency.Sha256)
+		case "cmake/3.27.1":
+			assert.Equal(t, []string{conanBuildScope}, dependency.Scopes)
+			assert.NotContains(t, dependency.Properties, ConanPackageRevisionProperty)
+		case "gtest/1.14.0@google/stable":
+			assert.Equal(t, []string{conanTestScope}, dependency.Scopes)
+		default:
+			assert.Fail(t, "Unexpected dependency "+dependency.Id)
+		}
+	}
+}
+
+func TestConanNodeReference(t *testing.T) {
+	node := buildutils.ConanNode{Ref: "conanfile.txt"}
+	assert.Equal(t, "conanfile.txt", node.Reference())
+	node = buildutils.ConanNode{Ref: "zlib/1.2.13#97d5730b529b4224045fe7090592d4c1", Name: "zlib", Version: "1.2.13"}
+	assert.Equal(t, "zlib/1.2.13", node.Reference())
+}
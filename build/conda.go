@@ -0,0 +1,282 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency properties, which hold the channel and the build string of Conda packages.
+	CondaChannelProperty = "conda.channel"
+	CondaBuildProperty   = "conda.build"
+)
+
+type CondaModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	// The prefix (root directory) of the Conda environment.
+	prefix string
+	// The conda-lock.yml file, from which the packages are read instead of the environment, and the platform, whose locked packages are read.
+	lockFilePath string
+	platform     string
+}
+
+// Pass an empty string for srcPath if the project's environment.yml file (if exists) is in the working directory.
+// If a conda-lock.yml file exists in srcPath, the packages are read from it rather than from the active environment.
+func newCondaModule(srcPath string, containingBuild *Build) (*CondaModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name := filepath.Base(srcPath)
+	environmentFile, err := buildutils.ReadCondaEnvironmentFile(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if environmentFile != nil && environmentFile.Name != "" {
+		name = environmentFile.Name
+	}
+
+	lockFilePath := filepath.Join(srcPath, buildutils.CondaLockFileName)
+	exists, err := utils.IsFileExists(lockFilePath, true)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		lockFilePath = ""
+	}
+
+	return &CondaModule{name: name, srcPath: srcPath, prefix: os.Getenv("CONDA_PREFIX"), lockFilePath: lockFilePath, platform: buildutils.GetCondaPlatform(), containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages installed in the Conda environment.
+func (cm *CondaModule) CalcDependencies() error {
+	started := time.Now()
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := cm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: cm.name, Type: entities.Conda, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(cm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return cm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (cm *CondaModule) SetName(name string) {
+	cm.name = name
+}
+
+func (cm *CondaModule) SetProperties(properties map[string]string) {
+	cm.properties = properties
+}
+
+// SetPrefix sets the prefix (root directory) of the Conda environment. By default, the active environment (CONDA_PREFIX) is used.
+// The packages are collected from the environment, even if the project has a conda-lock.yml file.
+func (cm *CondaModule) SetPrefix(prefix string) {
+	cm.prefix = prefix
+	cm.lockFilePath = ""
+}
+
+// SetLockFile sets the path of the conda-lock.yml file, from which the packages are collected, without instantiating the environment.
+// By default, the conda-lock.yml file in the project's directory is used (if exists).
+func (cm *CondaModule) SetLockFile(lockFilePath string) {
+	cm.lockFilePath = lockFilePath
+}
+
+// SetPlatform sets the platform (for example: 'linux-64' or 'osx-arm64'), whose packages are collected from the conda-lock.yml file.
+// By default, the platform of the current operating system and architecture is used.
+func (cm *CondaModule) SetPlatform(platform string) {
+	cm.platform = platform
+}
+
+func (cm *CondaModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !cm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: cm.name, ModuleType: entities.Conda, Artifacts: artifacts}
+	return cm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (cm *CondaModule) loadDependencies() ([]entities.Dependency, error) {
+	if cm.lockFilePath != "" {
+		return cm.loadLockedDependencies()
+	}
+	if cm.prefix == "" {
+		return nil, errors.New("no Conda environment is active. Activate the environment or set its prefix")
+	}
+	packages, err := buildutils.ReadCondaMeta(cm.prefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no Conda packages were found in the environment at %s", cm.prefix)
+	}
+	packagesIds := make(map[string]string)
+	for _, pkg := range packages {
+		packagesIds[pkg.Name] = pkg.Id()
+	}
+
+	dependenciesGraph := make(map[string][]string)
+	// The direct dependencies are those listed in the environment.yml file.
+	// Without it, the packages which were explicitly requested when creating or updating the environment are used instead.
+	environmentFile, err := buildutils.ReadCondaEnvironmentFile(cm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if environmentFile != nil {
+		dependenciesGraph[cm.name] = getCondaPackagesIds(packagesIds, environmentFile.GetDependenciesNames())
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	for _, pkg := range packages {
+		if environmentFile == nil && pkg.RequestedSpec != "" {
+			dependenciesGraph[cm.name] = append(dependenciesGraph[cm.name], pkg.Id())
+		}
+		var dependsNames []string
+		for _, spec := range pkg.Depends {
+			dependsNames = append(dependsNames, buildutils.GetCondaMatchSpecName(spec))
+		}
+		dependenciesGraph[pkg.Id()] = getCondaPackagesIds(packagesIds, dependsNames)
+		dependency, err := cm.createCondaDependency(pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[pkg.Id()] = dependency
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(cm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, cm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+func getCondaPackagesIds(packagesIds map[string]string, names []string) []string {
+	var ids []string
+	for _, name := range names {
+		if id, ok := packagesIds[name]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Creates the build-info dependency of a Conda package.
+// The checksums are calculated from the package file in the packages cache. If it was removed (for example, by 'conda clean'), the md5 and sha256 checksums from the conda-meta directory are used.
+func (cm *CondaModule) createCondaDependency(pkg buildutils.CondaPackage) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: getCondaPackageType(pkg.Fn), Checksum: entities.Checksum{Md5: pkg.Md5, Sha256: pkg.Sha256}}
+	setDependencyProperties(&dependency, map[string]string{CondaChannelProperty: pkg.Channel, CondaBuildProperty: pkg.Build})
+	if pkg.PackageTarballFullPath == "" {
+		return dependency, nil
+	}
+	exists, err := utils.IsFileExists(pkg.PackageTarballFullPath, true)
+	if err != nil || !exists {
+		return dependency, err
+	}
+	checksum, err := cm.containingBuild.getFileChecksum(pkg.PackageTarballFullPath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
+
+// Returns the type of the package file: 'conda' or 'tar.bz2'.
+func getCondaPackageType(fileName string) string {
+	if filepath.Ext(fileName) == ".bz2" {
+		return "tar.bz2"
+	}
+	return "conda"
+}
+
+// Collects the packages locked for the platform in the conda-lock.yml file, including the pip packages.
+func (cm *CondaModule) loadLockedDependencies() ([]entities.Dependency, error) {
+	lockFile, err := buildutils.ReadCondaLockFile(cm.lockFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s", cm.lockFilePath, err.Error())
+	}
+	packages := lockFile.GetPackages(cm.platform)
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no packages are locked for the %s platform in %s. The locked platforms are: %s", cm.platform, cm.lockFilePath, strings.Join(lockFile.Metadata.Platforms, ", "))
+	}
+	// The requirements of pip packages may be satisfied by conda packages, so the conda packages take precedence.
+	packagesIds := make(map[string]string)
+	for _, pkg := range packages {
+		if pkg.Manager == buildutils.CondaLockPipManager {
+			packagesIds[strings.ToLower(pkg.Name)] = pkg.Id()
+		}
+	}
+	for _, pkg := range packages {
+		if pkg.Manager != buildutils.CondaLockPipManager {
+			packagesIds[strings.ToLower(pkg.Name)] = pkg.Id()
+		}
+	}
+
+	dependenciesGraph := make(map[string][]string)
+	dependenciesMap := make(map[string]entities.Dependency)
+	requested := make(map[string]bool)
+	for _, pkg := range packages {
+		dependenciesMap[pkg.Id()] = createCondaLockDependency(pkg)
+		for _, childId := range getCondaPackagesIds(packagesIds, pkg.GetDependenciesNames()) {
+			if childId != pkg.Id() {
+				dependenciesGraph[pkg.Id()] = append(dependenciesGraph[pkg.Id()], childId)
+				requested[childId] = true
+			}
+		}
+	}
+	// The direct dependencies are those listed in the environment.yml file (if exists).
+	// Packages, which aren't required by other packages (such as the pip requirements in the environment file), are considered as direct dependencies too.
+	environmentFile, err := buildutils.ReadCondaEnvironmentFile(cm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if environmentFile != nil {
+		dependenciesGraph[cm.name] = getCondaPackagesIds(packagesIds, environmentFile.GetDependenciesNames())
+	}
+	for _, pkg := range packages {
+		if !requested[pkg.Id()] && !slices.Contains(dependenciesGraph[cm.name], pkg.Id()) {
+			dependenciesGraph[cm.name] = append(dependenciesGraph[cm.name], pkg.Id())
+		}
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(cm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, cm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a package locked in a conda-lock.yml file. Its checksums are the checksums listed in the lock file.
+func createCondaLockDependency(pkg buildutils.CondaLockPackage) entities.Dependency {
+	dependency := entities.Dependency{Id: pkg.Id(), Checksum: entities.Checksum{Md5: pkg.Hash.Md5, Sha256: pkg.Hash.Sha256}}
+	if pkg.Manager == buildutils.CondaLockPipManager {
+		// The type of pip packages is the type of their files, as with the dependencies of Python modules.
+		dependency.Type = getCondaLockPipPackageType(pkg.GetFileName())
+		return dependency
+	}
+	dependency.Type = getCondaPackageType(pkg.GetFileName())
+	setDependencyProperties(&dependency, map[string]string{CondaChannelProperty: pkg.GetChannel(), CondaBuildProperty: pkg.GetBuild()})
+	return dependency
+}
+
+// Returns the type of the file of a pip package: 'whl' or 'tar.gz'.
+func getCondaLockPipPackageType(fileName string) string {
+	if strings.HasSuffix(fileName, ".tar.gz") {
+		return "tar.gz"
+	}
+	return strings.TrimPrefix(filepath.Ext(fileName), ".")
+}
@@ -0,0 +1,116 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForCondaEnvironment(t *testing.T) {
+	service := NewBuildInfoService()
+	condaBuild, err := service.GetOrCreateBuild("build-info-go-test-conda", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, condaBuild.Clean())
+	}()
+	for _, withEnvironmentFile := range []bool{true, false} {
+		srcPath := filepath.Join("testdata", "conda", "env")
+		expectedModuleId := "env"
+		if withEnvironmentFile {
+			srcPath = filepath.Join("testdata", "conda", "project")
+			expectedModuleId = "data-science"
+		}
+		condaModule, err := condaBuild.AddCondaModule(srcPath)
+		if !assert.NoError(t, err) {
+			return
+		}
+		condaModule.SetPrefix(filepath.Join("testdata", "conda", "env"))
+		dependencies, err := condaModule.loadDependencies()
+		assert.NoError(t, err)
+		assert.Equal(t, expectedModuleId, condaModule.name)
+
+		// The direct dependencies are taken from the environment file, or from the requested specs in the conda-meta directory.
+		expectedRequestedBy := map[string][][]string{
+			"python:3.11.5": {{"numpy:1.26.0", expectedModuleId}, {expectedModuleId}},
+			"numpy:1.26.0":  {{expectedModuleId}},
+			"zlib:1.2.13":   {{"python:3.11.5", "numpy:1.26.0", expectedModuleId}, {"python:3.11.5", expectedModuleId}},
+		}
+		assert.Len(t, dependencies, len(expectedRequestedBy))
+		for _, dependency := range dependencies {
+			assert.ElementsMatch(t, expectedRequestedBy[dependency.Id], dependency.RequestedBy, dependency.Id)
+			assert.NotEmpty(t, dependency.Md5)
+			assert.NotEmpty(t, dependency.Sha256)
+			assert.NotEmpty(t, dependency.Properties[CondaChannelProperty])
+			switch dependency.Id {
+			case "zlib:1.2.13":
+				// Calculated from the package file in the packages cache.
+				assert.NotEmpty(t, dependency.Sha1)
+				assert.Equal(t, "h5eee18b_1", dependency.Properties[CondaBuildProperty])
+			case "numpy:1.26.0":
+				assert.Equal(t, "tar.bz2", dependency.Type)
+				assert.Equal(t, "https://conda.anaconda.org/conda-forge/linux-64", dependency.Properties[CondaChannelProperty])
+			default:
+				assert.Equal(t, "conda", dependency.Type)
+				assert.Empty(t, dependency.Sha1)
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForCondaLockFile(t *testing.T) {
+	service := NewBuildInfoService()
+	condaBuild, err := service.GetOrCreateBuild("build-info-go-test-conda-lock", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, condaBuild.Clean())
+	}()
+	condaModule, err := condaBuild.AddCondaModule(filepath.Join("testdata", "conda", "lockproject"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "ml-project", condaModule.name)
+	condaModule.SetPlatform("linux-64")
+	dependencies, err := condaModule.loadDependencies()
+	assert.NoError(t, err)
+
+	// The pip requirements in the environment file aren't required by other packages, so they're direct dependencies too.
+	expectedRequestedBy := map[string][][]string{
+		"numpy:1.26.0":    {{"ml-project"}},
+		"python:3.11.5":   {{"ml-project"}, {"numpy:1.26.0", "ml-project"}},
+		"libzlib:1.2.13":  {{"numpy:1.26.0", "ml-project"}, {"python:3.11.5", "ml-project"}, {"python:3.11.5", "numpy:1.26.0", "ml-project"}},
+		"requests:2.31.0": {{"ml-project"}},
+		"urllib3:2.0.7":   {{"requests:2.31.0", "ml-project"}},
+	}
+	assert.Len(t, dependencies, len(expectedRequestedBy))
+	for _, dependency := range dependencies {
+		assert.ElementsMatch(t, expectedRequestedBy[dependency.Id], dependency.RequestedBy, dependency.Id)
+		assert.NotEmpty(t, dependency.Sha256)
+		switch dependency.Id {
+		case "python:3.11.5":
+			assert.Equal(t, "conda", dependency.Type)
+			assert.Equal(t, entities.Checksum{Md5: "f0288cb82594b1cbc71111d1cd3c5422", Sha256: "27f4a6c3adbb4d9f2e5e5d7d4b5e9c6a3e2e1d0c9b8a7f6e5d4c3b2a1f0e9d8c"}, dependency.Checksum)
+			assert.Equal(t, map[string]string{CondaChannelProperty: "https://conda.anaconda.org/conda-forge/linux-64", CondaBuildProperty: "hab00c5b_0_cpython"}, dependency.Properties)
+		case "libzlib:1.2.13":
+			assert.Equal(t, "tar.bz2", dependency.Type)
+			assert.Equal(t, "hd590300_5", dependency.Properties[CondaBuildProperty])
+		case "requests:2.31.0":
+			assert.Equal(t, "whl", dependency.Type)
+			assert.Empty(t, dependency.Properties)
+		case "urllib3:2.0.7":
+			assert.Equal(t, "tar.gz", dependency.Type)
+		}
+	}
+
+	// A platform, which isn't locked.
+	condaModule.SetPlatform("win-64")
+	_, err = condaModule.loadDependencies()
+	assert.ErrorContains(t, err, "The locked platforms are: linux-64, osx-arm64")
+
+	// Setting the prefix of an environment makes the module collect the packages from the environment instead.
+	condaModule.SetPrefix(filepath.Join("testdata", "conda", "env"))
+	dependencies, err = condaModule.loadDependencies()
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 3)
+}
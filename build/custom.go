@@ -0,0 +1,67 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jfrog/build-info-go/entities"
+)
+
+// CustomCollector collects a module of a custom type, such as a module of an in-house package manager, from its source directory.
+// It returns the module with its dependencies, and optionally its artifacts and sub-modules. The type of the returned module is ignored.
+// The RequestedBy paths of the dependencies should end with the ID of the module.
+type CustomCollector func(srcPath string) (*entities.Module, error)
+
+var (
+	customCollectors      = make(map[entities.ModuleType]CustomCollector)
+	customCollectorsMutex sync.RWMutex
+)
+
+// RegisterModuleType registers a custom module type and its collector, so modules of the type can be added to builds using AddCustomModule().
+// An error is returned if the type is already registered.
+func RegisterModuleType(moduleType entities.ModuleType, collector CustomCollector) error {
+	if moduleType == "" || collector == nil {
+		return errors.New("a module type and a collector must be provided in order to register a custom module type")
+	}
+	customCollectorsMutex.Lock()
+	defer customCollectorsMutex.Unlock()
+	if _, exists := customCollectors[moduleType]; exists {
+		return fmt.Errorf("the module type '%s' is already registered", moduleType)
+	}
+	customCollectors[moduleType] = collector
+	return nil
+}
+
+// UnregisterModuleType removes a custom module type, which was registered using RegisterModuleType(). Modules of the type, which were already added to builds, keep its collector.
+func UnregisterModuleType(moduleType entities.ModuleType) {
+	customCollectorsMutex.Lock()
+	defer customCollectorsMutex.Unlock()
+	delete(customCollectors, moduleType)
+}
+
+func getCustomCollector(moduleType entities.ModuleType) (CustomCollector, bool) {
+	customCollectorsMutex.RLock()
+	defer customCollectorsMutex.RUnlock()
+	collector, exists := customCollectors[moduleType]
+	return collector, exists
+}
+
+// CustomModule is a module of a custom type, whose dependencies are collected by the collector registered for the type.
+type CustomModule struct {
+	*CollectorModule
+}
+
+func newCustomModule(moduleType entities.ModuleType, srcPath string, containingBuild *Build) (*CustomModule, error) {
+	collect, exists := getCustomCollector(moduleType)
+	if !exists {
+		return nil, fmt.Errorf("the module type '%s' isn't registered", moduleType)
+	}
+	collectorModule, err := newCollectorModule(&customTypeCollector{moduleType: moduleType, srcPath: srcPath, collect: collect}, containingBuild)
+	if err != nil {
+		return nil, err
+	}
+	// The type of the collected module is ignored.
+	collectorModule.SetModuleType(moduleType)
+	return &CustomModule{CollectorModule: collectorModule}, nil
+}
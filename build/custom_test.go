@@ -0,0 +1,53 @@
+package build
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const inHouseModuleType entities.ModuleType = "in-house"
+
+func collectInHouseModule(srcPath string) (*entities.Module, error) {
+	if srcPath == "" {
+		return nil, errors.New("no project")
+	}
+	return &entities.Module{Id: "my-app:1.0", Type: entities.Npm, Dependencies: []entities.Dependency{{Id: "lib:2.0", RequestedBy: [][]string{{"my-app:1.0"}}}}}, nil
+}
+
+func TestCustomModule(t *testing.T) {
+	require.NoError(t, RegisterModuleType(inHouseModuleType, collectInHouseModule))
+	defer UnregisterModuleType(inHouseModuleType)
+	assert.Error(t, RegisterModuleType(inHouseModuleType, collectInHouseModule))
+
+	service := NewBuildInfoService()
+	customBuild, err := service.GetOrCreateBuild("build-info-go-test-custom", "1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, customBuild.Clean())
+	}()
+	_, err = customBuild.AddCustomModule("unregistered", "project")
+	assert.Error(t, err)
+
+	customModule, err := customBuild.AddCustomModule(inHouseModuleType, "project")
+	require.NoError(t, err)
+	customModule.SetProperties(map[string]string{"team": "platform"})
+	assert.NoError(t, customModule.CalcDependencies())
+	assert.NoError(t, customModule.AddArtifacts(entities.Artifact{Name: "my-app-1.0.tgz", Checksum: entities.Checksum{Sha1: "a1"}}))
+	failingModule, err := customBuild.AddCustomModule(inHouseModuleType, "")
+	require.NoError(t, err)
+	assert.ErrorContains(t, failingModule.CalcDependencies(), "no project")
+
+	buildInfo, err := customBuild.ToBuildInfo()
+	require.NoError(t, err)
+	require.Len(t, buildInfo.Modules, 1)
+	module := buildInfo.Modules[0]
+	assert.Equal(t, "my-app:1.0", module.Id)
+	assert.Equal(t, inHouseModuleType, module.Type)
+	assert.Equal(t, map[string]string{"team": "platform"}, module.GetProperties())
+	assert.Len(t, module.Dependencies, 1)
+	assert.Len(t, module.Artifacts, 1)
+}
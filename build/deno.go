@@ -0,0 +1,124 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// The dependency property, which holds the integrity of a jsr or npm package, as listed in the deno.lock file.
+const DenoIntegrityProperty = "deno.integrity"
+
+type DenoModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the Deno project in the working directory.
+func newDenoModule(srcPath string, containingBuild *Build) (*DenoModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.DenoLockFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	config, err := buildutils.ReadDenoConfig(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := config.Name
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the %s file. Using the directory name: %s as module name.", buildutils.DenoConfigFileName, name))
+	} else if config.Version != "" {
+		name += ":" + config.Version
+	}
+
+	return &DenoModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the jsr and npm packages and the remote modules listed in the deno.lock file.
+func (dm *DenoModule) CalcDependencies() error {
+	started := time.Now()
+	if !dm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := dm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: dm.name, Type: entities.Deno, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(dm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return dm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (dm *DenoModule) SetName(name string) {
+	dm.name = name
+}
+
+func (dm *DenoModule) SetProperties(properties map[string]string) {
+	dm.properties = properties
+}
+
+func (dm *DenoModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !dm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: dm.name, ModuleType: entities.Deno, Artifacts: artifacts}
+	return dm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (dm *DenoModule) loadDependencies() ([]entities.Dependency, error) {
+	lock, err := buildutils.ReadDenoLock(dm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'deno install' to create it", buildutils.DenoLockFileName, err.Error())
+	}
+	dependenciesGraph := map[string][]string{dm.name: lock.DirectDependencies}
+	dependenciesMap := make(map[string]entities.Dependency)
+	for _, pkg := range lock.Packages {
+		dependenciesGraph[pkg.Id()] = pkg.Dependencies
+		dependency, err := createDenoPackageDependency(pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[pkg.Id()] = dependency
+	}
+	// The lock file doesn't specify which modules import the remote modules, so they're all considered as direct dependencies.
+	for _, remoteModule := range lock.RemoteModules {
+		dependenciesGraph[dm.name] = append(dependenciesGraph[dm.name], remoteModule.Url)
+		dependenciesMap[remoteModule.Url] = entities.Dependency{Id: remoteModule.Url, Type: "remote", Checksum: entities.Checksum{Sha256: remoteModule.Hash}}
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(dm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, dm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a jsr or npm package.
+func createDenoPackageDependency(pkg buildutils.DenoPackage) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: pkg.Kind}
+	setDependencyProperties(&dependency, map[string]string{DenoIntegrityProperty: pkg.Integrity})
+	checksum, err := pkg.GetChecksum()
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
@@ -0,0 +1,62 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForDenoProject(t *testing.T) {
+	service := NewBuildInfoService()
+	denoBuild, err := service.GetOrCreateBuild("build-info-go-test-deno", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, denoBuild.Clean())
+	}()
+	denoModule, err := denoBuild.AddDenoModule(filepath.Join("testdata", "deno", "project"))
+	if assert.NoError(t, err) {
+		err = denoModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := denoBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Deno, module.Type)
+		assert.Equal(t, "@jfrog/deno-example:1.0.0", module.Id)
+
+		expectedRequestedBy := map[string][][]string{
+			"@std/path:1.0.8":   {{module.Id}},
+			"@std/assert:1.0.6": {{"@std/path:1.0.8", module.Id}},
+			"chalk:5.3.0":       {{module.Id}},
+			"debug:4.3.4":       {{module.Id}},
+			"ms:2.1.2":          {{"debug:4.3.4", module.Id}},
+			"https://deno.land/std@0.200.0/fmt/colors.ts": {{module.Id}},
+		}
+		assert.Len(t, module.Dependencies, len(expectedRequestedBy))
+		for _, dependency := range module.Dependencies {
+			requestedBy, ok := expectedRequestedBy[dependency.Id]
+			if !assert.True(t, ok, "Unexpected dependency "+dependency.Id) {
+				continue
+			}
+			assert.Equal(t, requestedBy, dependency.RequestedBy, dependency.Id)
+			switch dependency.Id {
+			case "@std/path:1.0.8":
+				assert.Equal(t, "jsr", dependency.Type)
+				assert.Equal(t, "548fa456bb6a04d3c1a1e7477986b6cffbce95102d0bb447c67c4ee70e0364be", dependency.Sha256)
+				assert.Equal(t, map[string]string{DenoIntegrityProperty: dependency.Sha256}, dependency.Properties)
+			case "ms:2.1.2":
+				assert.Equal(t, "npm", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha1: "d09d1f357b443f493382a8eb3ccd183872ae6009"}, dependency.Checksum)
+			case "chalk:5.3.0":
+				// The integrity uses the sha512 algorithm, so it's only kept as a property.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Contains(t, dependency.Properties[DenoIntegrityProperty], "sha512-")
+			case "https://deno.land/std@0.200.0/fmt/colors.ts":
+				assert.Equal(t, "remote", dependency.Type)
+				assert.NotEmpty(t, dependency.Sha256)
+			}
+		}
+	}
+}
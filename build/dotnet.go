@@ -8,11 +8,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type DotnetModule struct {
 	containingBuild *Build
 	name            string
+	properties      map[string]string
 	toolchainType   dotnet.ToolchainType
 	subCommand      string
 	argAndFlags     []string
@@ -38,6 +40,11 @@ func (dm *DotnetModule) SetName(name string) {
 	dm.name = name
 }
 
+// SetProperties sets the properties of the modules of the solution's projects.
+func (dm *DotnetModule) SetProperties(properties map[string]string) {
+	dm.properties = properties
+}
+
 func (dm *DotnetModule) SetSubcommand(subCommand string) {
 	dm.subCommand = subCommand
 }
@@ -73,6 +80,7 @@ func (dm *DotnetModule) GetToolchainType() dotnet.ToolchainType {
 // CalcDependencies exec all type of dotnet commands - install, update, add, restore.
 // Collects the dotnet project's dependencies and saves them in the build-info module.
 func (dm *DotnetModule) CalcDependencies() error {
+	started := time.Now()
 	err := dm.runCmd()
 	if err != nil {
 		return err
@@ -92,6 +100,8 @@ func (dm *DotnetModule) CalcDependencies() error {
 	if err != nil {
 		return err
 	}
+	setModulesProperties(buildInfo, dm.properties)
+	setModulesTiming(buildInfo, started)
 	return dm.containingBuild.SaveBuildInfo(buildInfo)
 }
 
@@ -0,0 +1,152 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+)
+
+const (
+	// The dependency properties, which hold the architecture of a package, and the source package it was built from.
+	DpkgArchitectureProperty = "dpkg.architecture"
+	DpkgSourceProperty       = "dpkg.source"
+
+	// The name of the module, if the system doesn't have an os-release file.
+	defaultDpkgModuleName = "debian"
+)
+
+type DpkgModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	// The root directory of the system, whose installed packages are collected.
+	rootDir string
+}
+
+// Pass an empty string for rootDir to collect the packages installed on the running system.
+func newDpkgModule(rootDir string, containingBuild *Build) (*DpkgModule, error) {
+	if rootDir == "" {
+		rootDir = string(filepath.Separator)
+	}
+	name, err := buildutils.GetOsModuleId(rootDir, defaultDpkgModuleName)
+	if err != nil {
+		return nil, err
+	}
+	if name == defaultDpkgModuleName {
+		containingBuild.logger.Debug(fmt.Sprintf("No os-release file was found in %s. Using the default name: %s as module name.", rootDir, name))
+	}
+	return &DpkgModule{name: name, rootDir: rootDir, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages installed on the system.
+func (dm *DpkgModule) CalcDependencies() error {
+	started := time.Now()
+	if !dm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := dm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: dm.name, Type: entities.Dpkg, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(dm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return dm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (dm *DpkgModule) SetName(name string) {
+	dm.name = name
+}
+
+func (dm *DpkgModule) SetProperties(properties map[string]string) {
+	dm.properties = properties
+}
+
+func (dm *DpkgModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !dm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: dm.name, ModuleType: entities.Dpkg, Artifacts: artifacts}
+	return dm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (dm *DpkgModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := buildutils.ReadDpkgPackages(dm.rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no installed packages were found in the dpkg database in %s", dm.rootDir)
+	}
+	autoInstalled, err := buildutils.ReadAptAutoInstalled(dm.rootDir)
+	if err != nil {
+		return nil, err
+	}
+	// The dependencies of a package may refer to the names of other packages, or to the virtual packages they provide (for example: 'awk').
+	providers := make(map[string]string)
+	for _, pkg := range packages {
+		for _, provided := range pkg.Provides {
+			providers[provided] = pkg.Id()
+		}
+	}
+	for _, pkg := range packages {
+		providers[pkg.Name] = pkg.Id()
+	}
+
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	required := make(map[string]bool)
+	for _, pkg := range packages {
+		dependency, err := dm.createDpkgDependency(pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[pkg.Id()] = dependency
+		for _, alternatives := range pkg.Depends {
+			// The first installed alternative is considered as the one satisfying the dependency.
+			for _, name := range alternatives {
+				if childId, ok := providers[name]; ok {
+					if childId != pkg.Id() {
+						dependenciesGraph[pkg.Id()] = append(dependenciesGraph[pkg.Id()], childId)
+						required[childId] = true
+					}
+					break
+				}
+			}
+		}
+	}
+	for _, pkg := range packages {
+		// The packages, which apt didn't install automatically, and the packages, which aren't required by other packages, are considered as the explicitly installed ones.
+		if !required[pkg.Id()] || (autoInstalled != nil && !autoInstalled[pkg.Name]) {
+			dependenciesGraph[dm.name] = append(dependenciesGraph[dm.name], pkg.Id())
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(dm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, dm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a Debian package.
+// The status database doesn't hold the checksums of the installed .deb files, so the checksums of the dependency are calculated from the md5sums file of the package, which identifies the files it installed.
+func (dm *DpkgModule) createDpkgDependency(pkg buildutils.DpkgPackage) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: "deb"}
+	if pkg.Md5sumsPath != "" {
+		checksum, err := dm.containingBuild.getFileChecksum(pkg.Md5sumsPath)
+		if err != nil {
+			return dependency, err
+		}
+		dependency.Checksum = checksum
+	}
+	setDependencyProperties(&dependency, map[string]string{
+		DpkgArchitectureProperty: pkg.Architecture,
+		DpkgSourceProperty:       pkg.Source,
+	})
+	return dependency, nil
+}
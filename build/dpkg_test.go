@@ -0,0 +1,97 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForDpkgPackages(t *testing.T) {
+	service := NewBuildInfoService()
+	dpkgBuild, err := service.GetOrCreateBuild("build-info-go-test-dpkg", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, dpkgBuild.Clean())
+	}()
+	dpkgModule, err := dpkgBuild.AddDpkgModule(filepath.Join("testdata", "dpkg", "root"))
+	if assert.NoError(t, err) {
+		err = dpkgModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := dpkgBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Dpkg, module.Type)
+		assert.Equal(t, "debian:12", module.Id)
+
+		// The configuration files of vim-tiny remain, but the package was removed.
+		assert.Len(t, module.Dependencies, 8)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "deb", dependency.Type)
+			assert.Equal(t, "amd64", dependency.Properties[DpkgArchitectureProperty])
+			switch dependency.Id {
+			case "curl:7.88.1-10+deb12u5", "mawk:1.3.4.20200120-3.1":
+				// Packages, which aren't required by other packages.
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "libcurl4:7.88.1-10+deb12u5":
+				assert.Equal(t, entities.Checksum{
+					Sha1:   "721b1371380e6270b97277fddede94454c3496a9",
+					Md5:    "aeac0e21d51b151372088a4d13fdeb05",
+					Sha256: "1e1273a447bcbbd1b290c2f2f347293235ff26dab1a76fc5376119045eff0273",
+				}, dependency.Checksum)
+				assert.Equal(t, map[string]string{DpkgArchitectureProperty: "amd64", DpkgSourceProperty: "curl"}, dependency.Properties)
+				assert.Equal(t, [][]string{{"curl:7.88.1-10+deb12u5", module.Id}}, dependency.RequestedBy)
+			case "libssl3:3.0.11-1~deb12u2":
+				// Installed automatically.
+				assert.Equal(t, [][]string{{"libcurl4:7.88.1-10+deb12u5", "curl:7.88.1-10+deb12u5", module.Id}}, dependency.RequestedBy)
+			case "zlib1g:1:1.2.13.dfsg-1":
+				// Required by other packages, but installed explicitly.
+				assert.Contains(t, dependency.RequestedBy, []string{module.Id})
+				assert.Contains(t, dependency.RequestedBy, []string{"curl:7.88.1-10+deb12u5", module.Id})
+			case "libc6:2.36-9+deb12u4":
+				assert.Equal(t, "glibc", dependency.Properties[DpkgSourceProperty])
+				assert.Contains(t, dependency.RequestedBy, []string{"curl:7.88.1-10+deb12u5", module.Id})
+			case "libgcc-s1:12.2.0-14", "gcc-12-base:12.2.0-14":
+				// The version of the source package is removed.
+				assert.Equal(t, "gcc-12", dependency.Properties[DpkgSourceProperty])
+				assert.NotEmpty(t, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForDpkgPackagesInDistroless(t *testing.T) {
+	service := NewBuildInfoService()
+	dpkgBuild, err := service.GetOrCreateBuild("build-info-go-test-dpkg-distroless", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, dpkgBuild.Clean())
+	}()
+	dpkgModule, err := dpkgBuild.AddDpkgModule(filepath.Join("testdata", "dpkg", "distroless"))
+	if assert.NoError(t, err) {
+		err = dpkgModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := dpkgBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		module := buildInfo.Modules[0]
+		// The system doesn't have an os-release file.
+		assert.Equal(t, "debian", module.Id)
+		assert.Len(t, module.Dependencies, 2)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "base-files:12.4+deb12u5":
+				assert.Equal(t, "4d4f15fe62b0ab92fede9e0e50c2a369", dependency.Md5)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "tzdata:2024a-0+deb12u1":
+				// The package doesn't have an md5sums file.
+				assert.True(t, dependency.Checksum.IsEmpty())
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
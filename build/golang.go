@@ -1,20 +1,138 @@
 package build
 
 import (
+	"archive/zip"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"time"
 	"unicode"
 
+	buildutils "github.com/jfrog/build-info-go/build/utils"
 	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/mod/sumdb/dirhash"
 )
 
+const (
+	// The dependency property, which indicates how the dependency changed relative to the delta base.
+	GoDeltaProperty = "go.delta"
+	// The dependency property, which holds the version of the dependency in the delta base.
+	GoDeltaBaseVersionProperty = "go.delta.baseVersion"
+
+	GoDeltaAdded      = "added"
+	GoDeltaUpgraded   = "upgraded"
+	GoDeltaDowngraded = "downgraded"
+
+	// The dependency property, which holds the original version of the dependency, if it was canonicalized.
+	GoOriginalVersionProperty = "go.originalVersion"
+
+	// The dependency property, which holds the module, which replaces the dependency by a 'replace' directive, in the 'path@version' format, or the path of the local dir, which replaces it.
+	GoReplacementProperty = "go.replacement"
+
+	// The module properties, which record the Go toolchain, which builds the module: the version of the toolchain (GOVERSION), which Go selects for the module,
+	// the 'go' and 'toolchain' directives of its go.mod file, and the experimental features (GOEXPERIMENT), which are enabled.
+	GoVersionProperty            = "go.version"
+	GoDirectiveProperty          = "go.mod.go"
+	GoToolchainDirectiveProperty = "go.mod.toolchain"
+	GoExperimentProperty         = "go.experiment"
+
+	// The dependency property, which indicates whether the go.mod file of the module requires the dependency directly ('direct'), or only its dependencies require it ('indirect').
+	GoRelationProperty = "go.relation"
+
+	// The artifact properties, which hold the target platform (GOOS and GOARCH), which a binary was built for by BuildArtifacts().
+	GoOsProperty   = "go.os"
+	GoArchProperty = "go.arch"
+	// The type of the artifacts, which are built by BuildArtifacts().
+	goBinaryArtifactType = "binary"
+
+	// The type of the dependencies, which are collected from the vendor directory.
+	goVendoredDependencyType = "vendor"
+	// The type of the dependencies, which are replaced by local dirs.
+	goLocalDependencyType = "local"
+	// The type and the scope of the native dependencies of cgo, which are the system libraries and the pkg-config packages.
+	goNativeDependencyType = "native"
+	goSystemScope          = "system"
+
+	// Dependencies are listed by 'go list all', which includes the dependencies of the tests of the module's packages, without marking them.
+	// The modules of Go workspaces list only the dependencies of their packages.
+	GoTestDependenciesDefault GoTestDependenciesMode = ""
+	// The dependencies of the packages and of their tests are collected, and the dependencies, which only the tests use, are marked with the 'test' scope.
+	GoTestDependenciesInclude GoTestDependenciesMode = "include"
+	// Only the dependencies of the packages are collected.
+	GoTestDependenciesExclude GoTestDependenciesMode = "exclude"
+
+	// The zips of the dependencies aren't verified. This is the default.
+	GoSumVerificationOff GoSumVerificationMode = ""
+	// A warning is logged for each dependency, whose zip doesn't match its hash in go.sum.
+	GoSumVerificationWarn GoSumVerificationMode = "warn"
+	// Collecting the dependencies fails if the zip of a dependency doesn't match its hash in go.sum.
+	GoSumVerificationFail GoSumVerificationMode = "fail"
+
+	// The scope of dependencies which are used only by tests.
+	goTestScope = "test"
+	// The suffix of the ID of modules, which hold the dependencies of test binaries.
+	goTestModuleSuffix = "[test]"
+)
+
+// GoTestDependenciesMode sets whether the dependencies, which only the tests of a Go module use, are collected.
+type GoTestDependenciesMode string
+
+// GoSumVerificationMode sets what happens when the zip of a dependency in the local Go cache doesn't match its hash in go.sum.
+type GoSumVerificationMode string
+
+// GoPlatform is a target platform, which BuildArtifacts() builds a binary for.
+type GoPlatform struct {
+	GOOS   string
+	GOARCH string
+}
+
 type GoModule struct {
 	containingBuild *Build
 	name            string
+	properties      map[string]string
 	srcPath         string
+	deltaBase       *goDeltaBase
+	// If true, each dependency is checked against the 'retract' directives of its module.
+	checkRetractions bool
+	// If true, the versions of the dependencies are canonicalized before creating their IDs and looking them up in the Go cache.
+	normalizeVersions bool
+	// If true, the licenses of the dependencies are detected from the license files in their zips.
+	collectLicenses bool
+	// Resolves the checksum of dependencies, whose zip is missing from the local Go cache.
+	missingZipResolver func(moduleId string) (entities.Checksum, error)
+	goSumVerification  GoSumVerificationMode
+	// Whether the dependencies, which only the tests use, are collected.
+	testDependenciesMode GoTestDependenciesMode
+	// The build tags and the target platform, which the dependencies are collected for.
+	buildConstraints utils.GoBuildConstraints
+	// If true, the dependencies are collected from the vendor directory of the module, instead of from the local Go cache.
+	vendorMode bool
+	// The dir of the go.work file, if the module is a member of a Go workspace, in which case only the dependencies of its own packages are collected.
+	workspaceDir string
+	// The binary of the module, whose dynamically linked system libraries are collected as native dependencies.
+	cgoBinaryPath string
+	// If true, the pkg-config packages, which the cgo directives use, are collected as native dependencies.
+	collectPkgConfigDependencies bool
+	// The credentials, which the go commands use to authenticate to module proxies and private repositories, in addition to the ones in the .netrc file.
+	netrcMachines []utils.NetrcMachine
+	// The private modules settings of Go, which are read lazily.
+	privateSettings *utils.GoPrivateSettings
+}
+
+// The base, which the collected dependencies are compared to, when collecting only a delta of dependencies.
+type goDeltaBase struct {
+	goModPath string
+	goSumPath string
+	buildInfo *entities.BuildInfo
 }
 
 func newGoModule(srcPath string, containingBuild *Build) (*GoModule, error) {
@@ -36,24 +154,221 @@ func newGoModule(srcPath string, containingBuild *Build) (*GoModule, error) {
 }
 
 func (gm *GoModule) CalcDependencies() error {
+	started := time.Now()
 	if !gm.containingBuild.buildNameAndNumberProvided() {
 		return errors.New("a build name must be provided in order to collect the project's dependencies")
 	}
-	buildInfoDependencies, err := gm.loadDependencies()
+	buildInfoModule, err := gm.createBuildInfoModule()
 	if err != nil {
 		return err
 	}
 
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return gm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (gm *GoModule) createBuildInfoModule() (entities.Module, error) {
+	var buildInfoDependencies []entities.Dependency
+	err := gm.runWithNetrc(func(constraints utils.GoBuildConstraints) (err error) {
+		if buildInfoDependencies, err = gm.loadDependencies(constraints); err != nil {
+			return
+		}
+		nativeDependencies, err := gm.loadNativeDependencies(constraints)
+		buildInfoDependencies = append(buildInfoDependencies, nativeDependencies...)
+		return
+	})
+	if err != nil {
+		return entities.Module{}, err
+	}
 	buildInfoModule := entities.Module{Id: gm.name, Type: entities.Go, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(gm.getModuleProperties())
+	return buildInfoModule, nil
+}
+
+// CalcTestDependencies calculates the dependencies of the test binary of the given package (as compiled by 'go test -c'),
+// and stores them in a separate module, whose ID is the name of this module suffixed with '[test]'.
+// Dependencies which are not used by the package itself (but only by its tests) are marked with the 'test' scope.
+func (gm *GoModule) CalcTestDependencies(testPackage string) error {
+	started := time.Now()
+	if !gm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	log := gm.containingBuild.logger
+	var buildInfoDependencies []entities.Dependency
+	err := gm.runWithNetrc(func(constraints utils.GoBuildConstraints) error {
+		testModulesMap, err := utils.GetConstrainedPackagesDependenciesList(gm.srcPath, []string{testPackage}, true, constraints, log)
+		if err != nil {
+			return addGoPrivateModulesHint(err)
+		}
+		packageModulesMap, err := utils.GetConstrainedPackagesDependenciesList(gm.srcPath, []string{testPackage}, false, constraints, log)
+		if err != nil {
+			return addGoPrivateModulesHint(err)
+		}
+		if buildInfoDependencies, err = gm.loadDependenciesOfModules(testModulesMap, constraints); err != nil {
+			return err
+		}
+		markGoTestDependencies(buildInfoDependencies, packageModulesMap)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	buildInfoModule := entities.Module{Id: gm.name + goTestModuleSuffix, Type: entities.Go, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(gm.getModuleProperties())
 	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
 
 	return gm.containingBuild.SaveBuildInfo(buildInfo)
 }
 
+// Returns the properties of the toolchain, which builds the module, and the properties, which were set by SetProperties(), which override them.
+func (gm *GoModule) getModuleProperties() map[string]string {
+	properties := gm.getToolchainProperties()
+	for key, value := range gm.properties {
+		properties[key] = value
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+// The toolchain properties, which can't be read, are skipped.
+func (gm *GoModule) getToolchainProperties() map[string]string {
+	log := gm.containingBuild.logger
+	properties := make(map[string]string)
+	setProperty := func(key, value string) {
+		if value != "" {
+			properties[key] = value
+		}
+	}
+	if goEnv, err := utils.GetGoEnv(gm.srcPath, gm.buildConstraints.Env, "GOVERSION", "GOEXPERIMENT"); err == nil {
+		setProperty(GoVersionProperty, goEnv["GOVERSION"])
+		setProperty(GoExperimentProperty, goEnv["GOEXPERIMENT"])
+	} else {
+		log.Debug("Couldn't read the version of the Go toolchain:", err.Error())
+	}
+	if goVersion, toolchain, err := utils.GetGoModToolchain(filepath.Join(gm.srcPath, "go.mod")); err == nil {
+		setProperty(GoDirectiveProperty, goVersion)
+		setProperty(GoToolchainDirectiveProperty, toolchain)
+	} else {
+		log.Debug("Couldn't read the toolchain directives of the go.mod file:", err.Error())
+	}
+	return properties
+}
+
 func (gm *GoModule) SetName(name string) {
 	gm.name = name
 }
 
+func (gm *GoModule) SetProperties(properties map[string]string) {
+	gm.properties = properties
+}
+
+// SetCheckRetractions sets whether to check if the version of each dependency is retracted by its module.
+// The check uses the go.mod file of the latest version of the module, which is found in the local Go cache.
+// Dependencies of modules without a cached go.mod file are not checked.
+func (gm *GoModule) SetCheckRetractions(checkRetractions bool) {
+	gm.checkRetractions = checkRetractions
+}
+
+// SetNormalizeVersions sets whether to canonicalize the versions of the dependencies (for example, 'v1.2' becomes 'v1.2.0').
+// The canonical versions are used in the dependencies IDs and when looking up the dependencies in the Go cache.
+// If a version was changed, its original form is kept in the 'go.originalVersion' property of the dependency.
+func (gm *GoModule) SetNormalizeVersions(normalizeVersions bool) {
+	gm.normalizeVersions = normalizeVersions
+}
+
+// SetCollectLicenses sets whether to detect the licenses of the dependencies, from the license files (such as 'LICENSE') at the root of their zips in the local Go cache.
+// Licenses, which aren't recognized, are not collected.
+func (gm *GoModule) SetCollectLicenses(collectLicenses bool) {
+	gm.collectLicenses = collectLicenses
+}
+
+// SetMissingZipResolver sets a function, which is invoked for each dependency whose zip file is missing from the local Go cache.
+// The function gets the dependency's module ID in the 'path:version' format and returns its checksum, for example, from an internal registry or proxy.
+// If the function returns an error or an empty checksum, the dependency is skipped, as it is when no resolver is set.
+func (gm *GoModule) SetMissingZipResolver(missingZipResolver func(moduleId string) (entities.Checksum, error)) {
+	gm.missingZipResolver = missingZipResolver
+}
+
+// SetGoSumVerification sets whether to verify the zips of the dependencies in the local Go cache against their hashes in the go.sum file (and in go.work.sum, in Go workspaces),
+// which guards against poisoned caches. The hashes are calculated from the content of the zips, as 'go mod verify' does, so the '.ziphash' files in the cache aren't trusted.
+// Dependencies without hashes in go.sum, and dependencies, which aren't taken from zips (such as the ones replaced by local dirs), aren't verified.
+func (gm *GoModule) SetGoSumVerification(goSumVerification GoSumVerificationMode) {
+	gm.goSumVerification = goSumVerification
+}
+
+// SetTestDependenciesMode sets whether the dependencies, which only the tests of the module's packages use, are collected (with the 'test' scope) or excluded.
+// By default, the dependencies are listed by 'go list all', which includes the dependencies of the tests, without marking them.
+func (gm *GoModule) SetTestDependenciesMode(testDependenciesMode GoTestDependenciesMode) {
+	gm.testDependenciesMode = testDependenciesMode
+}
+
+// SetBuildTags sets the build tags (as passed to 'go build -tags'), which the dependencies are collected for.
+// The dependencies, which are used only by files that the tags exclude, aren't collected.
+func (gm *GoModule) SetBuildTags(tags ...string) {
+	gm.buildConstraints.Tags = tags
+}
+
+// SetTargetPlatform sets the GOOS and GOARCH, which the dependencies are collected for, when cross-compiling.
+// The dependencies, which are used only on other platforms, aren't collected. Pass an empty string to keep the current OS or architecture.
+func (gm *GoModule) SetTargetPlatform(goos, goarch string) {
+	gm.buildConstraints.GOOS = goos
+	gm.buildConstraints.GOARCH = goarch
+}
+
+// SetEnv sets environment variables (for example, GOFLAGS, GONOSUMDB or GOMODCACHE), which are added to the environment of all the go commands, which collect the dependencies
+// and build the artifacts of the module, without changing the environment of the current process. The zips of the dependencies are taken from the modules cache, which they select.
+// GOOS and GOARCH are overridden by SetTargetPlatform() and BuildArtifacts().
+func (gm *GoModule) SetEnv(env map[string]string) {
+	gm.buildConstraints.Env = env
+	gm.privateSettings = nil
+}
+
+// AddNetrcCredentials adds the credentials of a host (for example, 'artifactory.example.com'), which the go commands authenticate to, when downloading modules from a proxy or a private repository.
+// The credentials are written to a temporary .netrc file, which NETRC is set to while the go commands run, followed by the content of the .netrc file, which Go uses by default.
+// The login and the password can't include whitespace.
+func (gm *GoModule) AddNetrcCredentials(machine, login, password string) {
+	gm.netrcMachines = append(gm.netrcMachines, utils.NetrcMachine{Machine: machine, Login: login, Password: password})
+}
+
+// SetVendorMode sets whether to collect the dependencies from the vendor directory of the module (as created by 'go mod vendor'), instead of from the local Go cache.
+// The dependencies are the modules, whose packages are listed in vendor/modules.txt, and their checksums are calculated from their vendored files.
+// This allows collecting the dependencies of air-gapped builds, which never populate the Go cache. The retraction check isn't done, and the build tags and the target platform aren't applied in this mode.
+func (gm *GoModule) SetVendorMode(vendorMode bool) {
+	gm.vendorMode = vendorMode
+}
+
+// SetCgoBinary sets a binary of the module, which was built with cgo. The system libraries, which the binary links dynamically (such as 'libssl.so.3'),
+// are collected as dependencies of the 'native' type and the 'system' scope, since Go doesn't list them. ELF, Mach-O and PE binaries are supported.
+func (gm *GoModule) SetCgoBinary(binaryPath string) {
+	gm.cgoBinaryPath = binaryPath
+}
+
+// SetCollectPkgConfigDependencies sets whether to collect the pkg-config packages, which the cgo directives of the packages use (for example, '#cgo pkg-config: openssl'),
+// as dependencies of the 'native' type and the 'system' scope. Their versions are the versions, which pkg-config finds on the machine.
+// Nothing is collected when cgo is disabled.
+func (gm *GoModule) SetCollectPkgConfigDependencies(collectPkgConfigDependencies bool) {
+	gm.collectPkgConfigDependencies = collectPkgConfigDependencies
+}
+
+// SetDeltaBase sets the go.mod and go.sum files of a base version of the project (for example, the base branch of a pull request).
+// When set, the build-info includes only the dependencies which were added or changed relative to the base.
+// Pass an empty string for one of the paths to use only the other file.
+func (gm *GoModule) SetDeltaBase(goModPath, goSumPath string) {
+	gm.deltaBase = &goDeltaBase{goModPath: goModPath, goSumPath: goSumPath}
+}
+
+// SetDeltaBaseBuildInfo sets the build-info of a base version of the project.
+// When set, the build-info includes only the dependencies which were added or changed relative to the dependencies of the base build-info.
+func (gm *GoModule) SetDeltaBaseBuildInfo(baseBuildInfo *entities.BuildInfo) {
+	gm.deltaBase = &goDeltaBase{buildInfo: baseBuildInfo}
+}
+
 func (gm *GoModule) AddArtifacts(artifacts ...entities.Artifact) error {
 	if !gm.containingBuild.buildNameAndNumberProvided() {
 		return errors.New("a build name must be provided in order to add artifacts")
@@ -62,53 +377,692 @@ func (gm *GoModule) AddArtifacts(artifacts ...entities.Artifact) error {
 	return gm.containingBuild.SavePartialBuildInfo(partial)
 }
 
-func (gm *GoModule) loadDependencies() ([]entities.Dependency, error) {
-	cachePath, err := utils.GetCachePath()
+// BuildArtifacts runs 'go build' for the package (for example, './cmd/app') for each of the platforms, with the build tags of the module (see SetBuildTags()),
+// and adds the binaries as the artifacts of the module. The binaries are written to outputDir, and named '<name>_<GOOS>_<GOARCH>', with the '.exe' extension for Windows,
+// where the name is the last element of the package path. Each artifact has the 'go.os' and 'go.arch' properties of its platform.
+// buildArgs are added to the flags of 'go build', for example, '-trimpath'. The added artifacts are returned.
+func (gm *GoModule) BuildArtifacts(pkg, outputDir string, platforms []GoPlatform, buildArgs ...string) ([]entities.Artifact, error) {
+	if !gm.containingBuild.buildNameAndNumberProvided() {
+		return nil, errors.New("a build name must be provided in order to add artifacts")
+	}
+	if len(platforms) == 0 {
+		return nil, errors.New("at least one target platform must be provided in order to build artifacts")
+	}
+	outputDir, err := filepath.Abs(outputDir)
 	if err != nil {
 		return nil, err
 	}
-	dependenciesGraph, err := utils.GetDependenciesGraph(gm.srcPath, gm.containingBuild.logger)
+	if err = os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, err
+	}
+	name := path.Base(filepath.ToSlash(pkg))
+	if name == "." || name == "/" {
+		name = path.Base(gm.name)
+	}
+	var artifacts []entities.Artifact
+	for _, platform := range platforms {
+		if platform.GOOS == "" || platform.GOARCH == "" {
+			return nil, fmt.Errorf("the target platform '%s/%s' must have both GOOS and GOARCH", platform.GOOS, platform.GOARCH)
+		}
+		binaryName := fmt.Sprintf("%s_%s_%s", name, platform.GOOS, platform.GOARCH)
+		if platform.GOOS == "windows" {
+			binaryName += ".exe"
+		}
+		binaryPath := filepath.Join(outputDir, binaryName)
+		err = gm.runWithNetrc(func(constraints utils.GoBuildConstraints) error {
+			constraints.GOOS, constraints.GOARCH = platform.GOOS, platform.GOARCH
+			return utils.BuildGoBinary(gm.srcPath, pkg, binaryPath, constraints, buildArgs, gm.containingBuild.logger)
+		})
+		if err != nil {
+			return nil, addGoPrivateModulesHint(err)
+		}
+		checksum, err := gm.containingBuild.getFileChecksum(binaryPath)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, entities.Artifact{
+			Name:       binaryName,
+			Type:       goBinaryArtifactType,
+			Path:       filepath.ToSlash(binaryPath),
+			Properties: map[string]string{GoOsProperty: platform.GOOS, GoArchProperty: platform.GOARCH},
+			Checksum:   checksum,
+		})
+	}
+	return artifacts, gm.AddArtifacts(artifacts...)
+}
+
+func (gm *GoModule) loadDependencies(constraints utils.GoBuildConstraints) ([]entities.Dependency, error) {
+	if gm.vendorMode {
+		return gm.loadVendoredDependencies(constraints)
+	}
+	includeTests := gm.testDependenciesMode == GoTestDependenciesInclude
+	modulesMap, err := gm.listModules(includeTests, constraints)
 	if err != nil {
 		return nil, err
 	}
-	dependenciesMap, err := gm.getGoDependencies(cachePath)
+	dependencies, err := gm.loadDependenciesOfModules(modulesMap, constraints)
+	if err != nil || !includeTests {
+		return dependencies, err
+	}
+	packageModulesMap, err := gm.listModules(false, constraints)
+	if err != nil {
+		return nil, err
+	}
+	markGoTestDependencies(dependencies, packageModulesMap)
+	return dependencies, nil
+}
+
+// Loads the system libraries, which the cgo binary links, and the pkg-config packages, which the cgo directives use, if their collection is enabled.
+func (gm *GoModule) loadNativeDependencies(constraints utils.GoBuildConstraints) ([]entities.Dependency, error) {
+	var dependencies []entities.Dependency
+	if gm.cgoBinaryPath != "" {
+		libraries, err := utils.GetBinaryImportedLibraries(gm.cgoBinaryPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, library := range libraries {
+			dependencies = append(dependencies, gm.newNativeDependency(library))
+		}
+	}
+	if gm.collectPkgConfigDependencies {
+		packages, err := utils.GetCgoPkgConfigPackages(gm.srcPath, constraints, gm.containingBuild.logger)
+		if err != nil {
+			return nil, err
+		}
+		for _, pkg := range packages {
+			id := pkg
+			if version, err := utils.GetPkgConfigVersion(pkg); err == nil {
+				id += ":" + version
+			} else {
+				gm.containingBuild.logger.Debug("Couldn't get the version of the pkg-config package", pkg+":", err.Error())
+			}
+			dependencies = append(dependencies, gm.newNativeDependency(id))
+		}
+	}
+	return dependencies, nil
+}
+
+func (gm *GoModule) newNativeDependency(id string) entities.Dependency {
+	return entities.Dependency{Id: id, Type: goNativeDependencyType, Scopes: []string{goSystemScope}, RequestedBy: [][]string{{gm.name}}}
+}
+
+// Lists the modules, which provide the packages of the module and their dependencies, in the 'path:version' format.
+// If includeTests is true, the dependencies of the tests of the packages are listed too.
+func (gm *GoModule) listModules(includeTests bool, constraints utils.GoBuildConstraints) (modulesMap map[string]bool, err error) {
+	log := gm.containingBuild.logger
+	switch {
+	case gm.workspaceDir != "":
+		modulesMap, err = utils.GetWorkspaceModuleDependenciesList(gm.srcPath, includeTests, constraints, log)
+	case gm.testDependenciesMode == GoTestDependenciesDefault:
+		modulesMap, err = utils.GetConstrainedDependenciesList(gm.srcPath, constraints, log)
+	default:
+		modulesMap, err = utils.GetConstrainedPackagesDependenciesList(gm.srcPath, []string{"./..."}, includeTests, constraints, log)
+	}
+	return modulesMap, addGoPrivateModulesHint(err)
+}
+
+// Marks the dependencies, whose modules aren't used by the packages themselves (but only by their tests), with the 'test' scope.
+// packageModulesMap - Map of the modules, which the packages use, in the 'path:version' format.
+func markGoTestDependencies(dependencies []entities.Dependency, packageModulesMap map[string]bool) {
+	for i := range dependencies {
+		if !packageModulesMap[decodeGoModuleId(dependencies[i].Id)] {
+			dependencies[i].Scopes = append(dependencies[i].Scopes, goTestScope)
+		}
+	}
+}
+
+// Creates the build-info dependencies of the given modules.
+// modulesMap - Map of the modules in the 'path:version' format, as returned by 'go list'.
+func (gm *GoModule) loadDependenciesOfModules(modulesMap map[string]bool, constraints utils.GoBuildConstraints) ([]entities.Dependency, error) {
+	cachePath, err := gm.getCachePath()
+	if err != nil {
+		return nil, err
+	}
+	dependenciesGraph, err := utils.GetDependenciesGraphWithEnv(gm.srcPath, constraints.Env, gm.containingBuild.logger)
+	if err != nil {
+		return nil, addGoPrivateModulesHint(err)
+	}
+	if gm.workspaceDir != "" {
+		addWorkspaceModulesRequirements(gm.name, modulesMap, dependenciesGraph)
+	}
+	var originalVersions map[string]string
+	if gm.normalizeVersions {
+		modulesMap, dependenciesGraph, originalVersions = canonicalizeGoModules(modulesMap, dependenciesGraph)
+	}
+	dependenciesMap, err := gm.getGoDependencies(cachePath, modulesMap)
 	if err != nil {
 		return nil, err
 	}
+	return gm.completeDependencies(dependenciesMap, dependenciesGraph, originalVersions)
+}
+
+// Returns the download dir of the Go modules cache, which the zips of the dependencies are taken from.
+// When environment variables are set for the go commands, the cache is the one, which they select (for example, by GOMODCACHE).
+func (gm *GoModule) getCachePath() (string, error) {
+	if len(gm.buildConstraints.Env) == 0 {
+		return utils.GetCachePath()
+	}
+	goEnv, err := utils.GetGoEnv(gm.srcPath, gm.buildConstraints.Env, "GOMODCACHE")
+	if err != nil {
+		return "", err
+	}
+	if goEnv["GOMODCACHE"] == "" {
+		return "", errors.New("could not find the Go modules cache, since GOMODCACHE is empty")
+	}
+	return filepath.Join(goEnv["GOMODCACHE"], "cache", "download"), nil
+}
+
+// Collects the dependencies from the modules, which are vendored in the vendor directory of the module.
+func (gm *GoModule) loadVendoredDependencies(constraints utils.GoBuildConstraints) ([]entities.Dependency, error) {
+	log := gm.containingBuild.logger
+	vendorDir := filepath.Join(gm.srcPath, "vendor")
+	vendoredModules, err := utils.GetVendoredModules(vendorDir)
+	if err != nil {
+		return nil, err
+	}
+	modulesMap := make(map[string]bool)
+	vendoredModulesMap := make(map[string]utils.VendoredModule)
+	var explicitModules []string
+	for _, vendoredModule := range vendoredModules {
+		// Modules without vendored packages aren't used by the build. Modules, which are replaced by a local dir for all their versions, have no version.
+		if len(vendoredModule.Packages) == 0 || vendoredModule.Version == "" {
+			continue
+		}
+		moduleId := vendoredModule.Path + ":" + vendoredModule.Version
+		modulesMap[moduleId] = true
+		vendoredModulesMap[moduleId] = vendoredModule
+		if vendoredModule.Explicit {
+			explicitModules = append(explicitModules, moduleId)
+		}
+	}
+	dependenciesGraph, err := utils.GetDependenciesGraphWithEnv(gm.srcPath, constraints.Env, log)
+	if err != nil {
+		// 'go mod graph' requires the go.mod files of the dependencies, which may not be available in air-gapped builds.
+		// The modules.txt file records only which modules are required directly.
+		log.Debug("Only the direct dependencies are linked to the module, since its dependencies graph couldn't be read:", err.Error())
+		dependenciesGraph = map[string][]string{gm.name: explicitModules}
+	}
+	var originalVersions map[string]string
+	if gm.normalizeVersions {
+		modulesMap, dependenciesGraph, originalVersions = canonicalizeGoModules(modulesMap, dependenciesGraph)
+	}
+	dependenciesMap := make(map[string]entities.Dependency, len(modulesMap))
+	for moduleId := range modulesMap {
+		modulePath, version, _ := strings.Cut(moduleId, ":")
+		if originalVersion, ok := originalVersions[moduleId]; ok {
+			version = originalVersion
+		}
+		vendoredModule := vendoredModulesMap[modulePath+":"+version]
+		checksum, err := utils.GetVendoredModuleChecksum(vendorDir, vendoredModule, gm.containingBuild.calcSha512Checksums)
+		if err != nil {
+			return nil, err
+		}
+		dependency := entities.Dependency{Id: goModEncode(moduleId), Type: goVendoredDependencyType, Checksum: checksum}
+		if gm.collectLicenses {
+			dependency.Licenses = getGoDirLicenses(filepath.Join(vendorDir, filepath.FromSlash(modulePath)))
+		}
+		if vendoredModule.ReplacementPath != "" {
+			replacement := vendoredModule.ReplacementPath
+			if vendoredModule.ReplacementVersion != "" {
+				replacement += "@" + vendoredModule.ReplacementVersion
+			}
+			setGoReplacementProperty(&dependency, replacement)
+		}
+		dependenciesMap[moduleId] = dependency
+	}
+	return gm.completeDependencies(dependenciesMap, dependenciesGraph, originalVersions)
+}
+
+// Detects the licenses of a module from the license files in its root dir, such as its dir in the vendor directory, or the local dir, which replaces it.
+func getGoDirLicenses(moduleDir string) []string {
+	entries, err := os.ReadDir(moduleDir)
+	if err != nil {
+		return nil
+	}
+	var licenses []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !buildutils.IsLicenseFileName(entry.Name()) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(moduleDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		if license := buildutils.DetectLicenseFromText(string(content)); license != "" && !slices.Contains(licenses, license) {
+			licenses = append(licenses, license)
+		}
+	}
+	return licenses
+}
+
+// Adds the original versions, the RequestedBy paths and the delta annotations to the collected dependencies, and returns them as a list.
+// dependenciesMap - Map of the dependencies, with the decoded 'path:version' as its keys.
+func (gm *GoModule) completeDependencies(dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string, originalVersions map[string]string) ([]entities.Dependency, error) {
+	for moduleId, originalVersion := range originalVersions {
+		if dependency, ok := dependenciesMap[moduleId]; ok {
+			if dependency.Properties == nil {
+				dependency.Properties = make(map[string]string)
+			}
+			dependency.Properties[GoOriginalVersionProperty] = originalVersion
+			dependenciesMap[moduleId] = dependency
+		}
+	}
+	if err := gm.setDependenciesRelations(dependenciesMap); err != nil {
+		return nil, err
+	}
 	emptyRequestedBy := [][]string{{}}
-	populateRequestedByField(gm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph)
+	populateRequestedByField(gm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, gm.containingBuild.getRequestedByLimits())
+	if gm.deltaBase != nil {
+		baseVersions, err := gm.deltaBase.getVersions()
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap = getDeltaDependencies(dependenciesMap, baseVersions)
+	}
 	return dependenciesMapToList(dependenciesMap), nil
 }
 
-func (gm *GoModule) getGoDependencies(cachePath string) (map[string]entities.Dependency, error) {
-	modulesMap, err := utils.GetDependenciesList(gm.srcPath, gm.containingBuild.logger)
-	if err != nil || len(modulesMap) == 0 {
+// Sets the 'go.relation' property of the dependencies, by the '// indirect' comments in the go.mod file of the module.
+// Unlike the RequestedBy paths, which are taken from the requirements in the go.mod file, this tells apart the dependencies, which the code of the module imports,
+// from the ones, which are listed only to complete the module graph.
+func (gm *GoModule) setDependenciesRelations(dependenciesMap map[string]entities.Dependency) error {
+	directRequirements, err := utils.GetGoModDirectRequirements(filepath.Join(gm.srcPath, "go.mod"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for moduleId, dependency := range dependenciesMap {
+		modulePath, _, _ := strings.Cut(moduleId, ":")
+		relation := entities.IndirectDependency
+		if directRequirements[modulePath] {
+			relation = entities.DirectDependency
+		}
+		if dependency.Properties == nil {
+			dependency.Properties = make(map[string]string)
+		}
+		dependency.Properties[GoRelationProperty] = relation
+		dependenciesMap[moduleId] = dependency
+	}
+	return nil
+}
+
+// The other workspace modules, which a workspace member uses, are listed without versions and have no zips, so they aren't collected as its dependencies.
+// Instead, their requirements are added to the requirements of the member in the dependencies graph, as if it requested them directly.
+func addWorkspaceModulesRequirements(memberName string, modulesMap map[string]bool, dependenciesGraph map[string][]string) {
+	for moduleId := range modulesMap {
+		modulePath, version, _ := strings.Cut(moduleId, ":")
+		if version != "" || modulePath == memberName {
+			continue
+		}
+		for _, child := range dependenciesGraph[modulePath] {
+			if !slices.Contains(dependenciesGraph[memberName], child) {
+				dependenciesGraph[memberName] = append(dependenciesGraph[memberName], child)
+			}
+		}
+	}
+}
+
+// Returns a map of the modules in the delta base to their versions.
+func (base *goDeltaBase) getVersions() (map[string]string, error) {
+	versions := make(map[string]string)
+	if base.buildInfo != nil {
+		for _, buildInfoModule := range base.buildInfo.GetAllModules() {
+			for _, dependency := range buildInfoModule.Dependencies {
+				// Dependencies IDs in the build-info are encoded, while the collected dependencies are compared by their decoded module path.
+				modulePath, version, found := strings.Cut(decodeGoModuleId(dependency.Id), ":")
+				if !found {
+					continue
+				}
+				versions[modulePath] = version
+			}
+		}
+		return versions, nil
+	}
+	if base.goSumPath != "" {
+		goSumVersions, err := utils.GetGoSumVersions(base.goSumPath)
+		if err != nil {
+			return nil, err
+		}
+		for modulePath, version := range goSumVersions {
+			versions[modulePath] = version
+		}
+	}
+	// The go.mod file holds the selected versions, so it overrides the versions from go.sum.
+	if base.goModPath != "" {
+		goModVersions, err := utils.GetGoModRequirements(base.goModPath)
+		if err != nil {
+			return nil, err
+		}
+		for modulePath, version := range goModVersions {
+			versions[modulePath] = version
+		}
+	}
+	return versions, nil
+}
+
+// Returns only the dependencies which were added, upgraded or downgraded relative to the base versions, annotated with their change.
+// dependenciesMap - Map of the dependencies, with the decoded 'path:version' as its keys.
+// baseVersions    - Map of the modules in the base to their versions.
+func getDeltaDependencies(dependenciesMap map[string]entities.Dependency, baseVersions map[string]string) map[string]entities.Dependency {
+	deltaDependencies := make(map[string]entities.Dependency)
+	for moduleId, dependency := range dependenciesMap {
+		modulePath, version, _ := strings.Cut(moduleId, ":")
+		baseVersion, existsInBase := baseVersions[modulePath]
+		var delta string
+		switch {
+		case !existsInBase:
+			delta = GoDeltaAdded
+		case semver.Compare(version, baseVersion) > 0:
+			delta = GoDeltaUpgraded
+		case semver.Compare(version, baseVersion) < 0:
+			delta = GoDeltaDowngraded
+		default:
+			continue
+		}
+		if dependency.Properties == nil {
+			dependency.Properties = make(map[string]string)
+		}
+		dependency.Properties[GoDeltaProperty] = delta
+		if existsInBase {
+			dependency.Properties[GoDeltaBaseVersionProperty] = baseVersion
+		}
+		deltaDependencies[moduleId] = dependency
+	}
+	return deltaDependencies
+}
+
+func (gm *GoModule) getGoDependencies(cachePath string, modulesMap map[string]bool) (map[string]entities.Dependency, error) {
+	if len(modulesMap) == 0 {
+		return nil, nil
+	}
+	replacements, err := gm.getReplacements()
+	if err != nil {
 		return nil, err
 	}
+	var goSumHashes map[string]string
+	if gm.goSumVerification != GoSumVerificationOff {
+		if goSumHashes, err = gm.getGoSumHashes(); err != nil {
+			return nil, err
+		}
+	}
 	// Create a map from dependency to parents
 	buildInfoDependencies := make(map[string]entities.Dependency)
 	for moduleId := range modulesMap {
 		// If the path includes capital letters, the Go convention is to use "!" before the letter. The letter itself is in lowercase.
 		encodedDependencyId := goModEncode(moduleId)
+		modulePath, version, _ := strings.Cut(moduleId, ":")
+		// The dependency keeps its declared ID, while its content is taken from the module or the local dir, which replaces it.
+		replacement := utils.FindGoReplacement(replacements, modulePath, version)
+		if replacement != nil && replacement.IsLocal() {
+			localDependency, err := gm.getLocalReplacementDependency(encodedDependencyId, replacement)
+			if err != nil {
+				return nil, err
+			}
+			buildInfoDependencies[moduleId] = localDependency
+			continue
+		}
+		zipModuleId := encodedDependencyId
+		zipModulePath, zipVersion := modulePath, version
+		if replacement != nil {
+			zipModuleId = goModEncode(replacement.NewPath + ":" + replacement.NewVersion)
+			zipModulePath, zipVersion = replacement.NewPath, replacement.NewVersion
+		}
 
 		// We first check if this dependency has a zip in the local Go cache.
 		// If it does not, nil is returned. This seems to be a bug in Go.
-		zipPath, err := gm.getPackageZipLocation(cachePath, encodedDependencyId)
+		zipPath, err := gm.getPackageZipLocation(cachePath, zipModuleId)
 		if err != nil {
 			return nil, err
 		}
 		if zipPath == "" {
+			resolvedDependency, resolved := gm.resolveMissingZip(moduleId, encodedDependencyId)
+			if resolved {
+				buildInfoDependencies[moduleId] = resolvedDependency
+			} else if version != "" && gm.getGoPrivateSettings().IsPrivate(zipModulePath) {
+				gm.containingBuild.logger.Warn(fmt.Sprintf("The dependency %s isn't collected, since the zip of the private module %s@%s is missing from the Go cache in %s. "+
+					"Make sure it's downloaded (for example, by 'go mod download %s@%s') with access to its repository or proxy, by the credentials in the .netrc file, which Go uses.",
+					moduleId, zipModulePath, zipVersion, cachePath, zipModulePath, zipVersion))
+			}
 			continue
 		}
-		zipDependency, err := populateZip(encodedDependencyId, zipPath)
+		if goSumHashes != nil {
+			if err = gm.verifyZip(zipPath, zipModulePath+"@"+zipVersion, goSumHashes); err != nil {
+				return nil, err
+			}
+		}
+		zipDependency, err := gm.populateZip(encodedDependencyId, zipPath)
 		if err != nil {
 			return nil, err
 		}
+		// The retractions of the replacing module don't apply to the declared version.
+		if gm.checkRetractions && replacement == nil {
+			gm.updateRetraction(&zipDependency, filepath.Dir(zipPath))
+		}
+		if gm.collectLicenses {
+			if zipDependency.Licenses, err = getGoZipLicenses(zipPath); err != nil {
+				gm.containingBuild.logger.Debug("Couldn't detect the license of the dependency", moduleId+":", err.Error())
+			}
+		}
+		if replacement != nil {
+			setGoReplacementProperty(&zipDependency, replacement.NewPath+"@"+replacement.NewVersion)
+		}
 		buildInfoDependencies[moduleId] = zipDependency
 	}
 	return buildInfoDependencies, nil
 }
 
+// Returns the hashes of the zips in the go.sum file of the module, and in the go.work.sum file of its workspace, if any.
+func (gm *GoModule) getGoSumHashes() (map[string]string, error) {
+	goSumPaths := []string{filepath.Join(gm.srcPath, "go.sum")}
+	if gm.workspaceDir != "" {
+		goSumPaths = append(goSumPaths, filepath.Join(gm.workspaceDir, "go.work.sum"))
+	}
+	hashes := make(map[string]string)
+	for _, goSumPath := range goSumPaths {
+		goSumHashes, err := utils.GetGoSumHashes(goSumPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for module, hash := range goSumHashes {
+			hashes[module] = hash
+		}
+	}
+	return hashes, nil
+}
+
+// Verifies that the hash of the zip matches the hash of its module in go.sum.
+// module - The module of the zip, in the 'path@version' format.
+func (gm *GoModule) verifyZip(zipPath, module string, goSumHashes map[string]string) error {
+	log := gm.containingBuild.logger
+	expectedHash, exists := goSumHashes[module]
+	if !exists {
+		modulePath, _, _ := strings.Cut(module, "@")
+		if gm.getGoPrivateSettings().IsNoSumDB(modulePath) {
+			log.Warn("The zip of", module, "can't be verified, since go.sum has no hash of its zip, and Go doesn't verify it against the checksum database (see GONOSUMDB and GOPRIVATE)")
+		} else {
+			log.Debug("Skipping the verification of", module, "since go.sum has no hash of its zip")
+		}
+		return nil
+	}
+	hash, err := dirhash.HashZip(zipPath, dirhash.Hash1)
+	if err != nil {
+		return err
+	}
+	if hash == expectedHash {
+		return nil
+	}
+	message := fmt.Sprintf("the zip of %s in the local Go cache (%s) doesn't match its hash in go.sum. expected: %s, actual: %s", module, zipPath, expectedHash, hash)
+	if gm.goSumVerification == GoSumVerificationFail {
+		return errors.New(message)
+	}
+	log.Warn(message)
+	return nil
+}
+
+// Returns the private modules settings of Go for the module. They're read once, and aren't set if they couldn't be read.
+func (gm *GoModule) getGoPrivateSettings() utils.GoPrivateSettings {
+	if gm.privateSettings == nil {
+		privateSettings, err := utils.GetGoPrivateSettings(gm.srcPath, gm.buildConstraints.Env)
+		if err != nil {
+			gm.containingBuild.logger.Debug("Couldn't read the private modules settings of Go:", err.Error())
+		}
+		gm.privateSettings = &privateSettings
+	}
+	return *gm.privateSettings
+}
+
+// Runs the function with a copy of the build constraints of the module, whose environment sets NETRC to a temporary .netrc file,
+// which holds the credentials, which were added by AddNetrcCredentials(). Without credentials, the environment isn't changed.
+// The go commands, which the function runs, must use the given constraints.
+func (gm *GoModule) runWithNetrc(run func(constraints utils.GoBuildConstraints) error) (err error) {
+	constraints := gm.buildConstraints
+	if len(gm.netrcMachines) == 0 {
+		return run(constraints)
+	}
+	tempDir, err := utils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := utils.RemoveTempDir(tempDir)
+		if err == nil {
+			err = e
+		}
+	}()
+	netrcPath, err := utils.CreateGoNetrcFile(tempDir, gm.netrcMachines, gm.buildConstraints.Env)
+	if err != nil {
+		return err
+	}
+	constraints.Env = map[string]string{"NETRC": netrcPath}
+	for key, value := range gm.buildConstraints.Env {
+		if key != "NETRC" {
+			constraints.Env[key] = value
+		}
+	}
+	return run(constraints)
+}
+
+// The errors of the go commands, which indicate that a module couldn't be downloaded, since its repository or proxy requires access, which wasn't configured.
+var goAccessErrorPatterns = []string{"terminal prompts disabled", "could not read Username", "401 Unauthorized", "403 Forbidden", "404 Not Found", "410 Gone", "verifying module"}
+
+// Adds a hint on configuring the access to private modules to an error of a go command, which couldn't download a module.
+func addGoPrivateModulesHint(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, pattern := range goAccessErrorPatterns {
+		if strings.Contains(err.Error(), pattern) {
+			return fmt.Errorf("%w\nIf the module is private, make sure that its path matches GOPRIVATE (or GONOPROXY and GONOSUMDB), so it isn't looked up in the public proxy and checksum database, "+
+				"and that the credentials of its repository or proxy are in the .netrc file, which Go uses", err)
+		}
+	}
+	return err
+}
+
+// Returns the 'replace' directives of the go.mod file of the module.
+func (gm *GoModule) getReplacements() ([]utils.GoReplacement, error) {
+	replacements, err := utils.GetGoModReplacements(filepath.Join(gm.srcPath, "go.mod"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return replacements, err
+}
+
+// Creates the dependency of a module, which is replaced by a local dir. Its checksums are calculated from the content of the dir.
+func (gm *GoModule) getLocalReplacementDependency(encodedDependencyId string, replacement *utils.GoReplacement) (entities.Dependency, error) {
+	replacementDir := replacement.NewPath
+	if !filepath.IsAbs(replacementDir) {
+		replacementDir = filepath.Join(gm.srcPath, replacementDir)
+	}
+	checksum, err := utils.GetDirChecksum(replacementDir, gm.containingBuild.calcSha512Checksums)
+	if err != nil {
+		return entities.Dependency{}, err
+	}
+	dependency := entities.Dependency{Id: encodedDependencyId, Type: goLocalDependencyType, Checksum: checksum}
+	if gm.collectLicenses {
+		dependency.Licenses = getGoDirLicenses(replacementDir)
+	}
+	setGoReplacementProperty(&dependency, replacement.NewPath)
+	return dependency, nil
+}
+
+func setGoReplacementProperty(dependency *entities.Dependency, replacement string) {
+	if dependency.Properties == nil {
+		dependency.Properties = make(map[string]string)
+	}
+	dependency.Properties[GoReplacementProperty] = replacement
+}
+
+// Uses the missing zip resolver (if set) to get the checksum of a dependency, whose zip is missing from the local Go cache.
+// Returns false if the dependency should be skipped.
+func (gm *GoModule) resolveMissingZip(moduleId, encodedDependencyId string) (entities.Dependency, bool) {
+	// The main module has no version, and there's nothing to resolve for it.
+	if gm.missingZipResolver == nil || strings.HasSuffix(moduleId, ":") {
+		return entities.Dependency{}, false
+	}
+	checksum, err := gm.missingZipResolver(moduleId)
+	if err != nil {
+		gm.containingBuild.logger.Debug("Skipping the dependency", moduleId, "since its checksum couldn't be resolved:", err.Error())
+		return entities.Dependency{}, false
+	}
+	if checksum.IsEmpty() {
+		gm.containingBuild.logger.Debug("Skipping the dependency", moduleId, "since no checksum was resolved for it")
+		return entities.Dependency{}, false
+	}
+	return entities.Dependency{Id: encodedDependencyId, Type: "zip", Checksum: checksum}, true
+}
+
+// Canonicalizes the versions of the modules in the modules map and in the dependencies graph.
+// Returns the canonicalized map and graph, and a map of the modified modules (in their canonical 'path:version' form) to their original versions.
+func canonicalizeGoModules(modulesMap map[string]bool, dependenciesGraph map[string][]string) (map[string]bool, map[string][]string, map[string]string) {
+	originalVersions := make(map[string]string)
+	canonicalizeId := func(moduleId string) string {
+		modulePath, version, found := strings.Cut(moduleId, ":")
+		if !found {
+			return moduleId
+		}
+		canonicalVersion := utils.CanonicalizeGoVersion(version)
+		if canonicalVersion == version {
+			return moduleId
+		}
+		canonicalId := modulePath + ":" + canonicalVersion
+		originalVersions[canonicalId] = version
+		return canonicalId
+	}
+	canonicalModulesMap := make(map[string]bool, len(modulesMap))
+	for moduleId := range modulesMap {
+		canonicalModulesMap[canonicalizeId(moduleId)] = true
+	}
+	canonicalGraph := make(map[string][]string, len(dependenciesGraph))
+	for parent, children := range dependenciesGraph {
+		canonicalParent := canonicalizeId(parent)
+		for _, child := range children {
+			canonicalGraph[canonicalParent] = append(canonicalGraph[canonicalParent], canonicalizeId(child))
+		}
+	}
+	return canonicalModulesMap, canonicalGraph, originalVersions
+}
+
+// Returns the decoded 'path:version' of an encoded build-info dependency ID.
+func decodeGoModuleId(dependencyId string) string {
+	modulePath, version, found := strings.Cut(dependencyId, ":")
+	if !found {
+		return dependencyId
+	}
+	if decodedPath, err := module.UnescapePath(modulePath); err == nil {
+		modulePath = decodedPath
+	}
+	if decodedVersion, err := module.UnescapeVersion(version); err == nil {
+		version = decodedVersion
+	}
+	return modulePath + ":" + version
+}
+
 // Returns the actual path to the dependency.
 // If the path includes capital letters, the Go convention is to use "!" before the letter.
 // The letter itself is in lowercase.
@@ -160,31 +1114,134 @@ func (gm *GoModule) getPackagePathIfExists(cachePath, encodedDependencyId string
 	return zipPath, nil
 }
 
+// Marks the dependency as retracted, if its version falls under a 'retract' directive in the go.mod of the latest cached version of its module.
+// versionsDir - The '@v' directory of the module in the Go cache.
+func (gm *GoModule) updateRetraction(dependency *entities.Dependency, versionsDir string) {
+	log := gm.containingBuild.logger
+	latestGoMod := getLatestCachedGoMod(versionsDir)
+	if latestGoMod == "" {
+		log.Debug("Skipping the retraction check of", dependency.Id, "since no go.mod file of its module was found in", versionsDir)
+		return
+	}
+	_, encodedVersion, _ := strings.Cut(dependency.Id, ":")
+	version, err := module.UnescapeVersion(encodedVersion)
+	if err != nil {
+		version = encodedVersion
+	}
+	retracted, rationale, err := utils.GetRetraction(latestGoMod, version)
+	if err != nil {
+		log.Debug("Skipping the retraction check of", dependency.Id+":", err.Error())
+		return
+	}
+	dependency.Retracted = retracted
+	dependency.RetractionRationale = rationale
+}
+
+// Returns the path to the go.mod file of the latest version of a module in its '@v' directory in the Go cache, or an empty string if none exists.
+// Similarly to '@latest', release versions are preferred over pre-release versions.
+func getLatestCachedGoMod(versionsDir string) string {
+	goModFiles, err := filepath.Glob(filepath.Join(versionsDir, "*.mod"))
+	if err != nil {
+		return ""
+	}
+	var latestGoMod, latestVersion string
+	for _, goModFile := range goModFiles {
+		version, err := module.UnescapeVersion(strings.TrimSuffix(filepath.Base(goModFile), ".mod"))
+		if err != nil || !semver.IsValid(version) {
+			continue
+		}
+		if isLaterVersion(version, latestVersion) {
+			latestGoMod, latestVersion = goModFile, version
+		}
+	}
+	return latestGoMod
+}
+
+// Returns true if version is later than otherVersion, while preferring release versions over pre-release versions.
+func isLaterVersion(version, otherVersion string) bool {
+	if otherVersion == "" {
+		return true
+	}
+	isRelease, isOtherRelease := semver.Prerelease(version) == "", semver.Prerelease(otherVersion) == ""
+	if isRelease != isOtherRelease {
+		return isRelease
+	}
+	return semver.Compare(version, otherVersion) > 0
+}
+
 // populateZip adds the zip file as build-info dependency
-func populateZip(packageId, zipPath string) (zipDependency entities.Dependency, err error) {
+func (gm *GoModule) populateZip(packageId, zipPath string) (zipDependency entities.Dependency, err error) {
 	// Zip file dependency for the build-info
 	zipDependency = entities.Dependency{Id: packageId}
-	md5, sha1, sha2, err := utils.GetFileChecksums(zipPath)
+	checksum, err := gm.containingBuild.getFileChecksum(zipPath)
 	if err != nil {
 		return
 	}
 	zipDependency.Type = "zip"
-	zipDependency.Checksum = entities.Checksum{Sha1: sha1, Md5: md5, Sha256: sha2}
+	zipDependency.Checksum = checksum
 	return
 }
 
-func populateRequestedByField(parentId string, parentRequestedBy [][]string, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) {
+// Detects the licenses of a Go module from the license files at the root of its zip. The files in the zip are under the '<module>@<version>/' directory.
+func getGoZipLicenses(zipPath string) ([]string, error) {
+	zipReader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = zipReader.Close()
+	}()
+	var licenses []string
+	for _, file := range zipReader.File {
+		_, modulePath, found := strings.Cut(file.Name, "@")
+		if !found {
+			continue
+		}
+		_, fileName, _ := strings.Cut(modulePath, "/")
+		if strings.Contains(fileName, "/") || !buildutils.IsLicenseFileName(fileName) {
+			continue
+		}
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if license := buildutils.DetectLicenseFromText(string(content)); license != "" && !slices.Contains(licenses, license) {
+			licenses = append(licenses, license)
+		}
+	}
+	return licenses, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	return io.ReadAll(reader)
+}
+
+// Adds the paths of the parent to the RequestedBy fields of its children, and then does the same for their children recursively, within the limits.
+// The recursion stops when the RequestedBy field of a child doesn't change, which also stops loops, whose paths were cut at the maximum depth.
+func populateRequestedByField(parentId string, parentRequestedBy [][]string, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string, limits entities.RequestedByLimits) {
 	for _, childName := range dependenciesGraph[parentId] {
 		if childDep, ok := dependenciesMap[childName]; ok {
-			if childDep.NodeHasLoop() || len(childDep.RequestedBy) >= entities.RequestedByMaxLength {
+			if childDep.NodeHasLoop() || limits.IsFull(&childDep) {
 				continue
 			}
+			previousRequestedBy := childDep.RequestedBy
 			// Update RequestedBy field from parent's RequestedBy.
 			childDep.UpdateRequestedBy(parentId, parentRequestedBy)
+			childDep.LimitRequestedBy(limits)
+			if reflect.DeepEqual(previousRequestedBy, childDep.RequestedBy) {
+				continue
+			}
 			// Reassign map entry with new entry copy
 			dependenciesMap[childName] = childDep
 			// Run recursive call on child dependencies
-			populateRequestedByField(childName, childDep.RequestedBy, dependenciesMap, dependenciesGraph)
+			populateRequestedByField(childName, childDep.RequestedBy, dependenciesMap, dependenciesGraph, limits)
 		}
 	}
 }
@@ -1,11 +1,19 @@
 package build
 
 import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/mod/sumdb/dirhash"
 )
 
 func TestGenerateBuildInfoForGoProject(t *testing.T) {
@@ -29,6 +37,356 @@ func TestGenerateBuildInfoForGoProject(t *testing.T) {
 	}
 }
 
+func TestGenerateBuildInfoForGoWorkspace(t *testing.T) {
+	service := NewBuildInfoService()
+	goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-workspace", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, goBuild.Clean())
+	}()
+	goWorkspace, err := goBuild.AddGoWorkspace(filepath.Join("testdata", "golang", "workspace"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, goWorkspace.Modules(), 2) {
+		assert.Equal(t, "example.com/a", goWorkspace.Modules()[0].name)
+		assert.Equal(t, "example.com/b", goWorkspace.Modules()[1].name)
+	}
+	assert.NoError(t, goWorkspace.CalcDependencies())
+	buildInfo, err := goBuild.ToBuildInfo()
+	if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 2) {
+		return
+	}
+	// Module a uses the packages of module b, so it has the dependencies of b, as if it requested them directly.
+	moduleA := buildInfo.Modules[0]
+	assert.Equal(t, "example.com/a", moduleA.Id)
+	expectedRequestedBy := map[string][][]string{
+		"rsc.io/quote:v1.5.2":                                  {{"example.com/a"}},
+		"rsc.io/sampler:v1.3.0":                                {{"rsc.io/quote:v1.5.2", "example.com/a"}},
+		"golang.org/x/text:v0.0.0-20170915032832-14c0d48ead0c": {{"rsc.io/sampler:v1.3.0", "rsc.io/quote:v1.5.2", "example.com/a"}},
+		"github.com/pkg/errors:v0.8.0":                         {{"example.com/a"}},
+	}
+	assert.Len(t, moduleA.Dependencies, len(expectedRequestedBy))
+	for _, dependency := range moduleA.Dependencies {
+		assert.Equal(t, expectedRequestedBy[dependency.Id], dependency.RequestedBy, dependency.Id)
+	}
+	// Module b doesn't use module a, so it has only its own dependencies.
+	moduleB := buildInfo.Modules[1]
+	assert.Equal(t, "example.com/b", moduleB.Id)
+	if assert.Len(t, moduleB.Dependencies, 1) {
+		assert.Equal(t, "github.com/pkg/errors:v0.8.0", moduleB.Dependencies[0].Id)
+		assert.Equal(t, [][]string{{"example.com/b"}}, moduleB.Dependencies[0].RequestedBy)
+	}
+}
+
+func TestGenerateBuildInfoForGoVendorProject(t *testing.T) {
+	service := NewBuildInfoService()
+	goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-vendor", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, goBuild.Clean())
+	}()
+	goModule, err := goBuild.AddGoModule(filepath.Join("testdata", "golang", "vendorproject"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	goModule.SetVendorMode(true)
+	goModule.SetCollectLicenses(true)
+	assert.NoError(t, goModule.CalcDependencies())
+	buildInfo, err := goBuild.ToBuildInfo()
+	if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+		return
+	}
+	vendorDir := filepath.Join("testdata", "golang", "vendorproject", "vendor")
+	dependencies := buildInfo.Modules[0].Dependencies
+	assert.Len(t, dependencies, 3)
+	for _, dependency := range dependencies {
+		assert.Equal(t, goVendoredDependencyType, dependency.Type)
+		switch dependency.Id {
+		case "rsc.io/quote:v1.5.2":
+			expectedChecksum, err := utils.GetVendoredModuleChecksum(vendorDir, utils.VendoredModule{Path: "rsc.io/quote", Packages: []string{"rsc.io/quote"}}, false)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedChecksum, dependency.Checksum)
+			assert.Equal(t, []string{"BSD-3-Clause"}, dependency.Licenses)
+			assert.Equal(t, entities.DirectDependency, dependency.Properties[GoRelationProperty])
+		case "rsc.io/sampler:v1.3.0", "golang.org/x/text:v0.0.0-20170915032832-14c0d48ead0c":
+			assert.False(t, dependency.Checksum.IsEmpty())
+			assert.Empty(t, dependency.Licenses)
+			assert.Equal(t, entities.IndirectDependency, dependency.Properties[GoRelationProperty])
+		default:
+			assert.Fail(t, "Unexpected dependency "+dependency.Id)
+		}
+		assert.NotEmpty(t, dependency.RequestedBy, dependency.Id)
+	}
+}
+
+func TestGenerateBuildInfoForGoProjectWithReplacements(t *testing.T) {
+	service := NewBuildInfoService()
+	goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-replace", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, goBuild.Clean())
+	}()
+	projectPath := filepath.Join("testdata", "golang", "replaceproject")
+	goModule, err := goBuild.AddGoModule(projectPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, goModule.CalcDependencies())
+	buildInfo, err := goBuild.ToBuildInfo()
+	if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+		return
+	}
+	cachePath, err := utils.GetCachePath()
+	assert.NoError(t, err)
+	dependencies := buildInfo.Modules[0].Dependencies
+	assert.Len(t, dependencies, 2)
+	for _, dependency := range dependencies {
+		// The dependencies keep their declared IDs and RequestedBy paths.
+		assert.Equal(t, [][]string{{"github.com/jfrog/replaceproject"}}, dependency.RequestedBy)
+		switch dependency.Id {
+		case "github.com/pkg/errors:v0.8.0":
+			// The checksums are of the zip of the replacing version.
+			expectedChecksum, err := utils.GetFileChecksum(filepath.Join(cachePath, "github.com", "pkg", "errors", "@v", "v0.9.1.zip"), false)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedChecksum, dependency.Checksum)
+			assert.Equal(t, "zip", dependency.Type)
+			assert.Equal(t, map[string]string{GoReplacementProperty: "github.com/pkg/errors@v0.9.1", GoRelationProperty: entities.DirectDependency}, dependency.Properties)
+		case "example.com/local:v0.0.0":
+			expectedChecksum, err := utils.GetDirChecksum(filepath.Join(projectPath, "local"), false)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedChecksum, dependency.Checksum)
+			assert.Equal(t, goLocalDependencyType, dependency.Type)
+			assert.Equal(t, map[string]string{GoReplacementProperty: "./local", GoRelationProperty: entities.DirectDependency}, dependency.Properties)
+		default:
+			assert.Fail(t, "Unexpected dependency "+dependency.Id)
+		}
+	}
+}
+
+func TestGenerateBuildInfoForGoProjectWithBuildConstraints(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		tags                 []string
+		goos                 string
+		env                  map[string]string
+		expectedDependencies []string
+	}{
+		{"default", nil, "", nil, nil},
+		{"tags", []string{"special"}, "", nil, []string{"github.com/pkg/errors:v0.8.0"}},
+		{"goos", []string{"special"}, "windows", nil, []string{"github.com/!burnt!sushi/toml:v0.4.2-0.20211125115023-7d0236fe7476"}},
+		// The build tags of GOFLAGS are applied, since the environment is passed to the go commands.
+		{"env", nil, "", map[string]string{"GOFLAGS": "-mod=mod -tags=special"}, []string{"github.com/pkg/errors:v0.8.0"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := NewBuildInfoService()
+			goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-constraints", "1")
+			assert.NoError(t, err)
+			defer func() {
+				assert.NoError(t, goBuild.Clean())
+			}()
+			goModule, err := goBuild.AddGoModule(filepath.Join("testdata", "golang", "tagsproject"))
+			if !assert.NoError(t, err) {
+				return
+			}
+			goModule.SetBuildTags(testCase.tags...)
+			goModule.SetTargetPlatform(testCase.goos, "")
+			goModule.SetEnv(testCase.env)
+			assert.NoError(t, goModule.CalcDependencies())
+			buildInfo, err := goBuild.ToBuildInfo()
+			if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+				return
+			}
+			// The dependencies of the rsc.io/quote module are used by all the builds.
+			expectedDependencies := append([]string{"golang.org/x/text:v0.0.0-20170915032832-14c0d48ead0c", "rsc.io/quote:v1.5.2", "rsc.io/sampler:v1.3.0"}, testCase.expectedDependencies...)
+			var dependencies []string
+			for _, dependency := range buildInfo.Modules[0].Dependencies {
+				dependencies = append(dependencies, dependency.Id)
+			}
+			assert.ElementsMatch(t, expectedDependencies, dependencies)
+		})
+	}
+}
+
+func TestGoModuleCachePathWithEnv(t *testing.T) {
+	goModule := &GoModule{srcPath: filepath.Join("testdata", "golang", "project"), containingBuild: NewBuild("", "", "", "", &utils.NullLog{})}
+	defaultCachePath, err := utils.GetCachePath()
+	assert.NoError(t, err)
+	cachePath, err := goModule.getCachePath()
+	assert.NoError(t, err)
+	assert.Equal(t, defaultCachePath, cachePath)
+
+	goModCachePath := t.TempDir()
+	goModule.SetEnv(map[string]string{"GOMODCACHE": goModCachePath})
+	cachePath, err = goModule.getCachePath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(goModCachePath, "cache", "download"), cachePath)
+}
+
+func TestGoModuleRunWithNetrc(t *testing.T) {
+	goModule := &GoModule{containingBuild: NewBuild("", "", "", "", &utils.NullLog{})}
+	goModule.SetEnv(map[string]string{"GOFLAGS": "-mod=mod", "NETRC": filepath.Join(t.TempDir(), ".netrc")})
+	goModule.SetBuildTags("integration")
+	originalEnv := map[string]string{"GOFLAGS": "-mod=mod", "NETRC": goModule.buildConstraints.Env["NETRC"]}
+	// Without credentials, the environment isn't changed.
+	assert.NoError(t, goModule.runWithNetrc(func(constraints utils.GoBuildConstraints) error {
+		assert.Equal(t, originalEnv, constraints.Env)
+		return nil
+	}))
+
+	goModule.AddNetrcCredentials("proxy.example.com", "admin", "secret")
+	var netrcPath string
+	assert.NoError(t, goModule.runWithNetrc(func(constraints utils.GoBuildConstraints) error {
+		netrcPath = constraints.Env["NETRC"]
+		assert.NotEqual(t, originalEnv["NETRC"], netrcPath)
+		assert.Equal(t, "-mod=mod", constraints.Env["GOFLAGS"])
+		assert.Equal(t, []string{"integration"}, constraints.Tags)
+		// The environment of the module isn't changed, since the NETRC is set in a copy of it.
+		assert.Equal(t, originalEnv, goModule.buildConstraints.Env)
+		content, err := os.ReadFile(netrcPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "machine proxy.example.com login admin password secret\n", string(content))
+		return nil
+	}))
+	// The temporary .netrc file is removed.
+	assert.NoFileExists(t, netrcPath)
+	assert.Equal(t, originalEnv, goModule.buildConstraints.Env)
+}
+
+func TestAddGoPrivateModulesHint(t *testing.T) {
+	assert.NoError(t, addGoPrivateModulesHint(nil))
+	err := errors.New("exit status 1")
+	assert.Equal(t, err, addGoPrivateModulesHint(err))
+	err = errors.New("github.com/my-org/repo@v1.0.0: reading https://proxy.golang.org/github.com/my-org/repo/@v/v1.0.0.mod: 404 Not Found")
+	hintedErr := addGoPrivateModulesHint(err)
+	assert.ErrorIs(t, hintedErr, err)
+	assert.Contains(t, hintedErr.Error(), "GOPRIVATE")
+}
+
+func TestGoModuleToolchainProperties(t *testing.T) {
+	service := NewBuildInfoService()
+	goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-toolchain", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, goBuild.Clean())
+	}()
+	projectPath := filepath.Join("testdata", "golang", "tagsproject")
+	goModule, err := goBuild.AddGoModule(projectPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// The properties, which were set explicitly, override the toolchain properties.
+	goModule.SetProperties(map[string]string{GoToolchainDirectiveProperty: "custom", "key": "value"})
+	assert.NoError(t, goModule.CalcDependencies())
+	buildInfo, err := goBuild.ToBuildInfo()
+	if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+		return
+	}
+	goEnv, err := utils.GetGoEnv(projectPath, nil, "GOVERSION")
+	assert.NoError(t, err)
+	properties := buildInfo.Modules[0].GetProperties()
+	assert.Equal(t, goEnv["GOVERSION"], properties[GoVersionProperty])
+	assert.Equal(t, "1.19", properties[GoDirectiveProperty])
+	assert.Equal(t, "custom", properties[GoToolchainDirectiveProperty])
+	assert.Equal(t, "value", properties["key"])
+}
+
+func TestGoModuleBuildArtifacts(t *testing.T) {
+	service := NewBuildInfoService()
+	goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-artifacts", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, goBuild.Clean())
+	}()
+	goModule, err := goBuild.AddGoModule(filepath.Join("testdata", "golang", "tagsproject"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = goModule.BuildArtifacts(".", t.TempDir(), nil)
+	assert.Error(t, err)
+
+	outputDir := t.TempDir()
+	platforms := []GoPlatform{{GOOS: "linux", GOARCH: "amd64"}, {GOOS: "windows", GOARCH: "arm64"}}
+	artifacts, err := goModule.BuildArtifacts(".", outputDir, platforms, "-trimpath")
+	if !assert.NoError(t, err) || !assert.Len(t, artifacts, 2) {
+		return
+	}
+	buildInfo, err := goBuild.ToBuildInfo()
+	if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+		return
+	}
+	assert.Equal(t, "github.com/jfrog/tagsproject", buildInfo.Modules[0].Id)
+	// The artifacts of the build-info aren't ordered.
+	assert.ElementsMatch(t, artifacts, buildInfo.Modules[0].Artifacts)
+	artifactsByName := make(map[string]entities.Artifact)
+	for _, artifact := range buildInfo.Modules[0].Artifacts {
+		artifactsByName[artifact.Name] = artifact
+	}
+	expectedNames := []string{"tagsproject_linux_amd64", "tagsproject_windows_arm64.exe"}
+	for i, name := range expectedNames {
+		artifact, exists := artifactsByName[name]
+		if !assert.True(t, exists, name) {
+			continue
+		}
+		assert.Equal(t, goBinaryArtifactType, artifact.Type)
+		assert.Equal(t, map[string]string{GoOsProperty: platforms[i].GOOS, GoArchProperty: platforms[i].GOARCH}, artifact.Properties)
+		assert.FileExists(t, filepath.Join(outputDir, artifact.Name))
+		assert.NotEmpty(t, artifact.Sha256)
+	}
+	assert.NotEqual(t, artifactsByName[expectedNames[0]].Sha256, artifactsByName[expectedNames[1]].Sha256)
+}
+
+func TestGenerateBuildInfoForGoCgoProject(t *testing.T) {
+	// The names of the system libraries, which the binary links, are of Linux.
+	if runtime.GOOS != "linux" {
+		t.Skip("Skipping the test since not running on Linux")
+	}
+	if _, err := exec.LookPath("gcc"); err != nil {
+		t.Skip("Skipping the test since gcc isn't installed")
+	}
+	if _, err := exec.LookPath("pkg-config"); err != nil {
+		t.Skip("Skipping the test since pkg-config isn't installed")
+	}
+	projectPath, err := filepath.Abs(filepath.Join("testdata", "golang", "cgoproject"))
+	assert.NoError(t, err)
+	t.Setenv("PKG_CONFIG_PATH", filepath.Join(projectPath, "pkgconfig"))
+	t.Setenv("CGO_ENABLED", "1")
+	binaryPath := filepath.Join(t.TempDir(), "cgoproject")
+	if !assert.NoError(t, utils.BuildGoBinary(projectPath, ".", binaryPath, utils.GoBuildConstraints{}, nil, &utils.NullLog{})) {
+		return
+	}
+
+	service := NewBuildInfoService()
+	goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-cgo", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, goBuild.Clean())
+	}()
+	goModule, err := goBuild.AddGoModule(projectPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	goModule.SetCgoBinary(binaryPath)
+	goModule.SetCollectPkgConfigDependencies(true)
+	assert.NoError(t, goModule.CalcDependencies())
+	buildInfo, err := goBuild.ToBuildInfo()
+	if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+		return
+	}
+	dependencies := make(map[string]entities.Dependency)
+	for _, dependency := range buildInfo.Modules[0].Dependencies {
+		dependencies[dependency.Id] = dependency
+	}
+	for _, id := range []string{"fakemath:1.2.3", "libm.so.6", "libc.so.6"} {
+		if assert.Contains(t, dependencies, id) {
+			assert.Equal(t, goNativeDependencyType, dependencies[id].Type)
+			assert.Equal(t, []string{goSystemScope}, dependencies[id].Scopes)
+			assert.Equal(t, [][]string{{"github.com/jfrog/cgoproject"}}, dependencies[id].RequestedBy)
+		}
+	}
+}
+
 func validateRequestedBy(t *testing.T, module entities.Module) {
 	for _, dep := range module.Dependencies {
 		if assert.NotEmpty(t, dep.RequestedBy, dep.Id+" RequestedBy field is empty") {
@@ -61,3 +419,326 @@ func validateRequestedBy(t *testing.T, module entities.Module) {
 		}
 	}
 }
+
+func TestGetDeltaDependencies(t *testing.T) {
+	dependenciesMap := map[string]entities.Dependency{
+		"rsc.io/quote:v1.5.2":          {Id: "rsc.io/quote:v1.5.2"},
+		"rsc.io/sampler:v1.3.0":        {Id: "rsc.io/sampler:v1.3.0"},
+		"github.com/pkg/errors:v0.8.0": {Id: "github.com/pkg/errors:v0.8.0"},
+		"golang.org/x/text:v0.3.3":     {Id: "golang.org/x/text:v0.3.3"},
+	}
+	baseVersions := map[string]string{
+		"rsc.io/quote":          "v1.5.2",
+		"rsc.io/sampler":        "v1.2.0",
+		"github.com/pkg/errors": "v0.9.1",
+	}
+	delta := getDeltaDependencies(dependenciesMap, baseVersions)
+	assert.Len(t, delta, 3)
+	assert.NotContains(t, delta, "rsc.io/quote:v1.5.2")
+	assert.Equal(t, map[string]string{GoDeltaProperty: GoDeltaUpgraded, GoDeltaBaseVersionProperty: "v1.2.0"}, delta["rsc.io/sampler:v1.3.0"].Properties)
+	assert.Equal(t, map[string]string{GoDeltaProperty: GoDeltaDowngraded, GoDeltaBaseVersionProperty: "v0.9.1"}, delta["github.com/pkg/errors:v0.8.0"].Properties)
+	assert.Equal(t, map[string]string{GoDeltaProperty: GoDeltaAdded}, delta["golang.org/x/text:v0.3.3"].Properties)
+}
+
+func TestGetDeltaBaseVersionsFromBuildInfo(t *testing.T) {
+	baseBuildInfo := &entities.BuildInfo{Modules: []entities.Module{{Dependencies: []entities.Dependency{
+		{Id: "github.com/!burnt!sushi/toml:v0.4.1"},
+		{Id: "rsc.io/quote:v1.5.2"},
+	}}}}
+	base := &goDeltaBase{buildInfo: baseBuildInfo}
+	versions, err := base.getVersions()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"github.com/BurntSushi/toml": "v0.4.1", "rsc.io/quote": "v1.5.2"}, versions)
+}
+
+func TestGetLatestCachedGoMod(t *testing.T) {
+	versionsDir := filepath.Join("testdata", "golang", "cache", "example.com", "retracted", "@v")
+	// The pre-release version v1.3.0-beta is expected to be skipped.
+	assert.Equal(t, filepath.Join(versionsDir, "v1.2.0.mod"), getLatestCachedGoMod(versionsDir))
+	assert.Empty(t, getLatestCachedGoMod(filepath.Join("testdata", "golang", "cache", "not-exist")))
+}
+
+func TestUpdateRetraction(t *testing.T) {
+	versionsDir := filepath.Join("testdata", "golang", "cache", "example.com", "retracted", "@v")
+	goModule := &GoModule{containingBuild: NewBuild("", "", "", "", &utils.NullLog{})}
+	tests := []struct {
+		dependencyId      string
+		expectedRetracted bool
+		expectedRationale string
+	}{
+		{"example.com/retracted:v1.0.3", true, "Contains a security vulnerability."},
+		{"example.com/retracted:v1.1.0", true, "Published accidentally."},
+		{"example.com/retracted:v1.2.0", false, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.dependencyId, func(t *testing.T) {
+			dependency := entities.Dependency{Id: test.dependencyId}
+			goModule.updateRetraction(&dependency, versionsDir)
+			assert.Equal(t, test.expectedRetracted, dependency.Retracted)
+			assert.Equal(t, test.expectedRationale, dependency.RetractionRationale)
+		})
+	}
+}
+
+func TestGenerateBuildInfoForGoTestBinary(t *testing.T) {
+	service := NewBuildInfoService()
+	goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-test-binary", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, goBuild.Clean())
+	}()
+	goModule, err := goBuild.AddGoModule(filepath.Join("testdata", "golang", "testproject"))
+	if assert.NoError(t, err) {
+		assert.NoError(t, goModule.CalcTestDependencies("."))
+		buildInfo, err := goBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		if assert.Len(t, buildInfo.Modules, 1) {
+			validateModule(t, buildInfo.Modules[0], 4, 0, "github.com/jfrog/testproject[test]", entities.Go, true)
+			for _, dep := range buildInfo.Modules[0].Dependencies {
+				switch dep.Id {
+				// Used by the package itself:
+				case "github.com/pkg/errors:v0.8.0":
+					assert.Empty(t, dep.Scopes)
+					assert.False(t, dep.IsTestOnly())
+				// Used only by the package's tests:
+				case "rsc.io/quote:v1.5.2", "rsc.io/sampler:v1.3.0", "golang.org/x/text:v0.0.0-20170915032832-14c0d48ead0c":
+					assert.Equal(t, []string{goTestScope}, dep.Scopes)
+					assert.True(t, dep.IsTestOnly())
+				default:
+					assert.Fail(t, "Unexpected dependency "+dep.Id)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForGoProjectTestDependencies(t *testing.T) {
+	testCases := []struct {
+		mode                 GoTestDependenciesMode
+		expectedDependencies []string
+	}{
+		{GoTestDependenciesInclude, []string{"github.com/pkg/errors:v0.8.0", "rsc.io/quote:v1.5.2", "rsc.io/sampler:v1.3.0", "golang.org/x/text:v0.0.0-20170915032832-14c0d48ead0c"}},
+		{GoTestDependenciesExclude, []string{"github.com/pkg/errors:v0.8.0"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(string(testCase.mode), func(t *testing.T) {
+			service := NewBuildInfoService()
+			goBuild, err := service.GetOrCreateBuild("build-info-go-test-golang-test-dependencies", "1")
+			assert.NoError(t, err)
+			defer func() {
+				assert.NoError(t, goBuild.Clean())
+			}()
+			goModule, err := goBuild.AddGoModule(filepath.Join("testdata", "golang", "testproject"))
+			if !assert.NoError(t, err) {
+				return
+			}
+			goModule.SetTestDependenciesMode(testCase.mode)
+			assert.NoError(t, goModule.CalcDependencies())
+			buildInfo, err := goBuild.ToBuildInfo()
+			if !assert.NoError(t, err) || !assert.Len(t, buildInfo.Modules, 1) {
+				return
+			}
+			var dependencies []string
+			for _, dep := range buildInfo.Modules[0].Dependencies {
+				dependencies = append(dependencies, dep.Id)
+				// Only github.com/pkg/errors is used by the package itself.
+				assert.Equal(t, dep.Id != "github.com/pkg/errors:v0.8.0", dep.IsTestOnly(), dep.Id)
+			}
+			assert.ElementsMatch(t, testCase.expectedDependencies, dependencies)
+		})
+	}
+}
+
+func TestCanonicalizeGoModules(t *testing.T) {
+	modulesMap := map[string]bool{
+		"github.com/jfrog/dependency:": true,
+		"rsc.io/quote:v1.5":            true,
+		"rsc.io/sampler:v1.3.0":        true,
+	}
+	dependenciesGraph := map[string][]string{
+		"github.com/jfrog/dependency": {"rsc.io/quote:v1.5"},
+		"rsc.io/quote:v1.5":           {"rsc.io/sampler:v1.3.0"},
+	}
+	canonicalModulesMap, canonicalGraph, originalVersions := canonicalizeGoModules(modulesMap, dependenciesGraph)
+	assert.Equal(t, map[string]bool{"github.com/jfrog/dependency:": true, "rsc.io/quote:v1.5.0": true, "rsc.io/sampler:v1.3.0": true}, canonicalModulesMap)
+	assert.Equal(t, map[string][]string{
+		"github.com/jfrog/dependency": {"rsc.io/quote:v1.5.0"},
+		"rsc.io/quote:v1.5.0":         {"rsc.io/sampler:v1.3.0"},
+	}, canonicalGraph)
+	assert.Equal(t, map[string]string{"rsc.io/quote:v1.5.0": "v1.5"}, originalVersions)
+}
+
+func TestGetGoDependenciesWithMissingZipResolver(t *testing.T) {
+	cachePath, cleanup := createTempDirWithCallbackAndAssert(t)
+	defer cleanup()
+	goModule := &GoModule{containingBuild: NewBuild("", "", "", "", &utils.NullLog{})}
+	modulesMap := map[string]bool{
+		"github.com/jfrog/dependency:":             true,
+		"github.com/BurntSushi/toml:v1.1.0":        true,
+		"github.com/jfrog/unresolved:v1.0.0":       true,
+		"github.com/jfrog/empty-checksum:v1.0.0":   true,
+		"github.com/jfrog/without-resolver:v1.0.0": true,
+	}
+
+	// Without a resolver, all dependencies are expected to be skipped, since the cache is empty.
+	dependencies, err := goModule.getGoDependencies(cachePath, modulesMap)
+	assert.NoError(t, err)
+	assert.Empty(t, dependencies)
+
+	var resolvedIds []string
+	goModule.SetMissingZipResolver(func(moduleId string) (entities.Checksum, error) {
+		resolvedIds = append(resolvedIds, moduleId)
+		switch moduleId {
+		case "github.com/BurntSushi/toml:v1.1.0":
+			return entities.Checksum{Sha1: "123", Md5: "456", Sha256: "789"}, nil
+		case "github.com/jfrog/empty-checksum:v1.0.0":
+			return entities.Checksum{}, nil
+		default:
+			return entities.Checksum{}, errors.New("not found")
+		}
+	})
+	dependencies, err = goModule.getGoDependencies(cachePath, modulesMap)
+	assert.NoError(t, err)
+	// The resolver isn't expected to be called for the main module.
+	assert.NotContains(t, resolvedIds, "github.com/jfrog/dependency:")
+	assert.Len(t, resolvedIds, 4)
+	assert.Equal(t, map[string]entities.Dependency{
+		"github.com/BurntSushi/toml:v1.1.0": {Id: "github.com/!burnt!sushi/toml:v1.1.0", Type: "zip", Checksum: entities.Checksum{Sha1: "123", Md5: "456", Sha256: "789"}},
+	}, dependencies)
+}
+
+func TestGetGoDependenciesWithGoSumVerification(t *testing.T) {
+	cachePath, projectPath := t.TempDir(), t.TempDir()
+	zipDir := filepath.Join(cachePath, "github.com", "jfrog", "dependency", "@v")
+	assert.NoError(t, os.MkdirAll(zipDir, 0755))
+	zipPath := filepath.Join(zipDir, "v1.0.0.zip")
+	createZip := func(content string) {
+		zipFile, err := os.Create(zipPath)
+		assert.NoError(t, err)
+		zipWriter := zip.NewWriter(zipFile)
+		writer, err := zipWriter.Create("github.com/jfrog/dependency@v1.0.0/main.go")
+		assert.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		assert.NoError(t, err)
+		assert.NoError(t, zipWriter.Close())
+		assert.NoError(t, zipFile.Close())
+	}
+	createZip("package main")
+	hash, err := dirhash.HashZip(zipPath, dirhash.Hash1)
+	assert.NoError(t, err)
+	goSum := "github.com/jfrog/dependency v1.0.0 " + hash + "\ngithub.com/jfrog/dependency v1.0.0/go.mod h1:AAAA=\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.sum"), []byte(goSum), 0600))
+
+	goModule := &GoModule{srcPath: projectPath, containingBuild: NewBuild("", "", "", "", &utils.NullLog{})}
+	modulesMap := map[string]bool{"github.com/jfrog/dependency:v1.0.0": true}
+	goModule.SetGoSumVerification(GoSumVerificationFail)
+	dependencies, err := goModule.getGoDependencies(cachePath, modulesMap)
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 1)
+
+	// Poison the zip in the cache.
+	createZip("package main\n\nfunc init() {}")
+	_, err = goModule.getGoDependencies(cachePath, modulesMap)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "github.com/jfrog/dependency@v1.0.0")
+	}
+	goModule.SetGoSumVerification(GoSumVerificationWarn)
+	dependencies, err = goModule.getGoDependencies(cachePath, modulesMap)
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 1)
+
+	// Dependencies without hashes in go.sum aren't verified.
+	assert.NoError(t, os.Remove(filepath.Join(projectPath, "go.sum")))
+	goModule.SetGoSumVerification(GoSumVerificationFail)
+	dependencies, err = goModule.getGoDependencies(cachePath, modulesMap)
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 1)
+}
+
+func TestGetGoSumHashesOfWorkspaceModules(t *testing.T) {
+	testCases := []struct {
+		name string
+		// The dirs of the workspace modules, relative to the dir of the go.work file.
+		modulesDirs []string
+	}{
+		{"nested", []string{"tools/x", "b"}},
+		{"root", []string{".", "b"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			workspaceDir := t.TempDir()
+			goWork := "go 1.19\n\nuse (\n"
+			for i, moduleDir := range testCase.modulesDirs {
+				goWork += "\t./" + moduleDir + "\n"
+				assert.NoError(t, os.MkdirAll(filepath.Join(workspaceDir, moduleDir), 0755))
+				goMod := fmt.Sprintf("module example.com/module%d\n\ngo 1.19\n", i)
+				assert.NoError(t, os.WriteFile(filepath.Join(workspaceDir, moduleDir, "go.mod"), []byte(goMod), 0600))
+			}
+			goWork += ")\n"
+			assert.NoError(t, os.WriteFile(filepath.Join(workspaceDir, "go.work"), []byte(goWork), 0600))
+			goWorkSum := "rsc.io/quote v1.5.2 h1:w5fcysjrx7yqtD/aO+QwRjYZOKnaM9Uh2b40tElTs3Y=\n"
+			assert.NoError(t, os.WriteFile(filepath.Join(workspaceDir, "go.work.sum"), []byte(goWorkSum), 0600))
+
+			goWorkspace, err := newGoWorkspace(workspaceDir, NewBuild("", "", "", "", &utils.NullLog{}))
+			if !assert.NoError(t, err) || !assert.Len(t, goWorkspace.Modules(), len(testCase.modulesDirs)) {
+				return
+			}
+			// The hashes of go.work.sum apply to all the modules of the workspace, wherever they are.
+			for _, goModule := range goWorkspace.Modules() {
+				hashes, err := goModule.getGoSumHashes()
+				assert.NoError(t, err)
+				assert.Equal(t, map[string]string{"rsc.io/quote@v1.5.2": "h1:w5fcysjrx7yqtD/aO+QwRjYZOKnaM9Uh2b40tElTs3Y="}, hashes, goModule.name)
+			}
+		})
+	}
+}
+
+func TestGetGoZipLicenses(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "v1.0.0.zip")
+	zipFile, err := os.Create(zipPath)
+	assert.NoError(t, err)
+	zipWriter := zip.NewWriter(zipFile)
+	for name, content := range map[string]string{
+		"github.com/jfrog/dependency@v1.0.0/LICENSE":              "Permission is hereby granted, free of charge, to any person obtaining a copy",
+		"github.com/jfrog/dependency@v1.0.0/main.go":              "package main",
+		"github.com/jfrog/dependency@v1.0.0/vendor/other/LICENSE": "Apache License\nVersion 2.0, January 2004",
+	} {
+		writer, err := zipWriter.Create(name)
+		assert.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zipWriter.Close())
+	assert.NoError(t, zipFile.Close())
+
+	// Only the license files at the root of the module are read.
+	licenses, err := getGoZipLicenses(zipPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"MIT"}, licenses)
+}
+
+func TestPopulateRequestedByFieldLimits(t *testing.T) {
+	// Each layer depends on all the dependencies of the next layer, so the number of paths grows exponentially with the depth.
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := map[string][]string{"module": {"l0-0", "l0-1", "l0-2"}}
+	for layer := 0; layer < 8; layer++ {
+		for i := 0; i < 3; i++ {
+			id := fmt.Sprintf("l%d-%d", layer, i)
+			dependenciesMap[id] = entities.Dependency{Id: id}
+			if layer < 7 {
+				dependenciesGraph[id] = []string{fmt.Sprintf("l%d-0", layer+1), fmt.Sprintf("l%d-1", layer+1), fmt.Sprintf("l%d-2", layer+1)}
+			}
+		}
+	}
+	populateRequestedByField("module", [][]string{{}}, dependenciesMap, dependenciesGraph, entities.RequestedByLimits{MaxDepth: 3, MaxPaths: 4})
+
+	assert.Equal(t, [][]string{{"module"}}, dependenciesMap["l0-1"].RequestedBy)
+	for _, dependency := range dependenciesMap {
+		assert.NotEmpty(t, dependency.RequestedBy, dependency.Id)
+		assert.LessOrEqual(t, len(dependency.RequestedBy), 4, dependency.Id)
+		for _, path := range dependency.RequestedBy {
+			assert.LessOrEqual(t, len(path), 3, dependency.Id)
+		}
+	}
+	assert.Contains(t, dependenciesMap["l7-2"].RequestedBy, []string{"l6-0", "l5-0", "l4-0"})
+}
@@ -0,0 +1,83 @@
+package build
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// GoWorkspace collects the modules of a Go workspace, which is defined by a go.work file.
+// Each module of the workspace is collected as a separate build-info module, whose dependencies are only the modules, which its own packages use.
+type GoWorkspace struct {
+	containingBuild *Build
+	name            string
+	srcPath         string
+	modules         []*GoModule
+	// If true, the modules of the workspace are saved as sub-modules of a module, which represents the workspace.
+	nestModules bool
+}
+
+func newGoWorkspace(srcPath string, containingBuild *Build) (*GoWorkspace, error) {
+	// The working directory is used if srcPath is empty.
+	srcPath, err := filepath.Abs(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	modulesDirs, err := utils.GetWorkspaceModulesDirs(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	workspace := &GoWorkspace{containingBuild: containingBuild, name: filepath.Base(srcPath), srcPath: srcPath}
+	for _, moduleDir := range modulesDirs {
+		name, err := utils.GetModulePathFromGoMod(moduleDir)
+		if err != nil {
+			return nil, err
+		}
+		workspace.modules = append(workspace.modules, &GoModule{name: name, srcPath: moduleDir, containingBuild: containingBuild, workspaceDir: srcPath})
+	}
+	return workspace, nil
+}
+
+// Modules returns the modules of the workspace, in the order of the go.work file. They can be configured (for example, by SetProperties() or SetNormalizeVersions()) before calculating the dependencies.
+func (gw *GoWorkspace) Modules() []*GoModule {
+	return gw.modules
+}
+
+// SetName sets the ID of the module, which represents the workspace, when the modules are nested. It's the name of the workspace dir by default.
+func (gw *GoWorkspace) SetName(name string) {
+	gw.name = name
+}
+
+// SetNestModules sets whether to save the modules of the workspace as sub-modules of a module, which represents the workspace, instead of as separate modules.
+func (gw *GoWorkspace) SetNestModules(nestModules bool) {
+	gw.nestModules = nestModules
+}
+
+// CalcDependencies calculates the dependencies of all the modules of the workspace, and saves them in a single build-info.
+func (gw *GoWorkspace) CalcDependencies() error {
+	started := time.Now()
+	if !gw.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	var buildInfoModules []entities.Module
+	for _, module := range gw.modules {
+		buildInfoModule, err := module.createBuildInfoModule()
+		if err != nil {
+			return err
+		}
+		buildInfoModules = append(buildInfoModules, buildInfoModule)
+	}
+	if gw.nestModules {
+		workspaceModule := entities.Module{Id: gw.name, Type: entities.Go}
+		workspaceModule.AddSubModules(buildInfoModules...)
+		buildInfoModules = []entities.Module{workspaceModule}
+	}
+
+	buildInfo := &entities.BuildInfo{Modules: buildInfoModules}
+	setModulesTiming(buildInfo, started)
+
+	return gw.containingBuild.SaveBuildInfo(buildInfo)
+}
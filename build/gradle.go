@@ -7,7 +7,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
+	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/build-info-go/utils"
 )
 
@@ -26,6 +28,7 @@ type GradleModule struct {
 	srcPath string
 	// The Gradle extractor (dependency) which calculates the build-info.
 	gradleExtractorDetails *gradleExtractorDetails
+	properties             map[string]string
 }
 
 type gradleExtractorDetails struct {
@@ -78,6 +81,7 @@ func (gm *GradleModule) SetExtractorDetails(localExtractorPath, extractorPropsDi
 
 // Generates Gradle build-info.
 func (gm *GradleModule) CalcDependencies() (err error) {
+	started := time.Now()
 	gm.containingBuild.logger.Info("Running gradle...")
 	if gm.srcPath == "" {
 		if gm.srcPath, err = os.Getwd(); err != nil {
@@ -100,7 +104,19 @@ func (gm *GradleModule) CalcDependencies() (err error) {
 	if err != nil {
 		return err
 	}
-	return gradleRunConfig.runCmd()
+	if err = gradleRunConfig.runCmd(); err != nil {
+		return err
+	}
+	return gm.containingBuild.updateGeneratedBuildInfo(gradleRunConfig.buildInfoPath, func(buildInfo *entities.BuildInfo) error {
+		setModulesTiming(buildInfo, started)
+		setModulesProperties(buildInfo, gm.properties)
+		return gm.containingBuild.filterDependencies(buildInfo)
+	})
+}
+
+// SetProperties sets the properties of the modules of the Gradle project.
+func (gm *GradleModule) SetProperties(properties map[string]string) {
+	gm.properties = properties
 }
 
 func (gm *GradleModule) createGradleRunConfig() (*gradleRunConfig, error) {
@@ -120,6 +136,7 @@ func (gm *GradleModule) createGradleRunConfig() (*gradleRunConfig, error) {
 		env:                gm.gradleExtractorDetails.props,
 		gradle:             gradleExecPath,
 		extractorPropsFile: extractorPropsFile,
+		buildInfoPath:      buildInfoPath,
 		tasks:              strings.Join(gm.gradleExtractorDetails.tasks, " "),
 		initScript:         gm.gradleExtractorDetails.initScript,
 		logger:             gm.containingBuild.logger,
@@ -176,10 +193,12 @@ func getGradleExecPath(useWrapper bool) (string, error) {
 type gradleRunConfig struct {
 	gradle             string
 	extractorPropsFile string
-	tasks              string
-	initScript         string
-	env                map[string]string
-	logger             utils.Log
+	// The build-info, which is generated by the Gradle extractor.
+	buildInfoPath string
+	tasks         string
+	initScript    string
+	env           map[string]string
+	logger        utils.Log
 }
 
 func (config *gradleRunConfig) GetCmd() *exec.Cmd {
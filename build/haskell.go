@@ -0,0 +1,160 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the flags of a package (from the cabal.project.freeze file), the hashes of its .cabal file and pantry tree (from the stack.yaml.lock file), and the URL of its git repository or archive.
+	HaskellFlagsProperty         = "haskell.flags"
+	HaskellCabalFileHashProperty = "haskell.cabalFileHash"
+	HaskellPantryTreeProperty    = "haskell.pantryTree"
+	HaskellUrlProperty           = "haskell.url"
+)
+
+type HaskellModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath if the root of the Haskell project is the working directory.
+func newHaskellModule(srcPath string, containingBuild *Build) (*HaskellModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, version, err := buildutils.GetHaskellPackageNameAndVersion(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No package name was found in the .cabal or package.yaml files. Using the directory name: %s as module name.", name))
+	} else if version != "" {
+		name += ":" + version
+	}
+
+	return &HaskellModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages pinned in the cabal.project.freeze file, or (if it doesn't exist) the extra-deps listed in the stack.yaml.lock file.
+func (hm *HaskellModule) CalcDependencies() error {
+	started := time.Now()
+	if !hm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := hm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: hm.name, Type: entities.Haskell, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(hm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return hm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (hm *HaskellModule) SetName(name string) {
+	hm.name = name
+}
+
+func (hm *HaskellModule) SetProperties(properties map[string]string) {
+	hm.properties = properties
+}
+
+func (hm *HaskellModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !hm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: hm.name, ModuleType: entities.Haskell, Artifacts: artifacts}
+	return hm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (hm *HaskellModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := hm.readPackages()
+	if err != nil {
+		return nil, err
+	}
+	cachePath, err := buildutils.GetCabalPackagesCachePath()
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []entities.Dependency
+	for _, pkg := range packages {
+		dependency, err := hm.createHaskellDependency(cachePath, pkg)
+		if err != nil {
+			return nil, err
+		}
+		// The lock files don't specify which packages depend on each other, so they're all considered as direct dependencies.
+		dependency.RequestedBy = [][]string{{hm.name}}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+func (hm *HaskellModule) readPackages() ([]buildutils.HaskellPackage, error) {
+	freezeFileExists, err := utils.IsFileExists(filepath.Join(hm.srcPath, buildutils.CabalFreezeFileName), true)
+	if err != nil {
+		return nil, err
+	}
+	if freezeFileExists {
+		return buildutils.ReadCabalFreeze(hm.srcPath)
+	}
+	stackLockExists, err := utils.IsFileExists(filepath.Join(hm.srcPath, buildutils.StackLockFileName), true)
+	if err != nil {
+		return nil, err
+	}
+	if stackLockExists {
+		return buildutils.ReadStackLock(hm.srcPath)
+	}
+	return nil, fmt.Errorf("could not find the %s or %s files in %s. Run 'cabal freeze' or 'stack build' to create them", buildutils.CabalFreezeFileName, buildutils.StackLockFileName, hm.srcPath)
+}
+
+// Creates the build-info dependency of a Haskell package.
+// The checksums of Hackage packages are calculated from their tarballs in the cabal packages cache, if they were downloaded.
+// The sha256 checksum of archive packages is taken from the stack.yaml.lock file, and the sha1 checksum of git packages is their commit.
+func (hm *HaskellModule) createHaskellDependency(cachePath string, pkg buildutils.HaskellPackage) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: pkg.Source}
+	setDependencyProperties(&dependency, map[string]string{
+		HaskellFlagsProperty:         strings.Join(pkg.Flags, " "),
+		HaskellCabalFileHashProperty: pkg.CabalFileHash,
+		HaskellPantryTreeProperty:    pkg.PantryTreeHash,
+		HaskellUrlProperty:           pkg.Url,
+	})
+	switch pkg.Source {
+	case buildutils.HaskellGitSource:
+		dependency.Sha1 = pkg.GitCommit
+		return dependency, nil
+	case buildutils.HaskellUrlSource:
+		dependency.Sha256 = pkg.ArchiveHash
+		return dependency, nil
+	}
+	tarballPath := buildutils.GetHackageTarballPath(cachePath, pkg)
+	exists, err := utils.IsFileExists(tarballPath, true)
+	if err != nil || !exists {
+		return dependency, err
+	}
+	checksum, err := hm.containingBuild.getFileChecksum(tarballPath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
@@ -0,0 +1,87 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForCabalProject(t *testing.T) {
+	t.Setenv("CABAL_DIR", filepath.Join("testdata", "haskell", "cabal"))
+	service := NewBuildInfoService()
+	haskellBuild, err := service.GetOrCreateBuild("build-info-go-test-cabal", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, haskellBuild.Clean())
+	}()
+	haskellModule, err := haskellBuild.AddHaskellModule(filepath.Join("testdata", "haskell", "cabal-project"))
+	if assert.NoError(t, err) {
+		err = haskellModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := haskellBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Haskell, module.Type)
+		assert.Equal(t, "hello:0.1.0.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 4)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "hackage", dependency.Type)
+			assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			switch dependency.Id {
+			case "text-short:0.1.5":
+				// Calculated from the tarball in the cabal packages cache.
+				assert.NotEmpty(t, dependency.Sha256)
+				assert.Equal(t, map[string]string{HaskellFlagsProperty: "-asserts"}, dependency.Properties)
+			case "aeson:2.1.2.1":
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, map[string]string{HaskellFlagsProperty: "-cffi +ordered-keymap"}, dependency.Properties)
+			case "base:4.17.2.0", "ghc-prim:0.9.1":
+				assert.True(t, dependency.Checksum.IsEmpty())
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForStackProject(t *testing.T) {
+	t.Setenv("CABAL_DIR", filepath.Join("testdata", "haskell", "cabal"))
+	service := NewBuildInfoService()
+	haskellBuild, err := service.GetOrCreateBuild("build-info-go-test-stack", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, haskellBuild.Clean())
+	}()
+	haskellModule, err := haskellBuild.AddHaskellModule(filepath.Join("testdata", "haskell", "stack-project"))
+	if assert.NoError(t, err) {
+		err = haskellModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := haskellBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, "stack-hello:1.2.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 3)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "acme-missiles:0.3":
+				assert.Equal(t, "hackage", dependency.Type)
+				assert.Equal(t, "2ba66a092a32593880a87fb00f3213762d7bca65a687d45965778deb8694c5d1", dependency.Properties[HaskellCabalFileHashProperty])
+				assert.Equal(t, "614bc0cca76937507ea0a5ccc17a504c997ce458d7f2f9e43b15a10c8eaeb033", dependency.Properties[HaskellPantryTreeProperty])
+			case "text-short:0.1.5":
+				assert.Equal(t, "git", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha1: "1d0b391b2aa0fa3e6e6cc31d7c0fbd3c1f8e0c0e"}, dependency.Checksum)
+			case "split:0.2.4":
+				assert.Equal(t, "archive", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha256: "9c0b8ce2b1fbd0b74d1ce6c8bc6b5d2e6f8c8b1a4f3e2d1c0b9a8f7e6d5c4b3a"}, dependency.Checksum)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// The dependency property, which holds the repository of the chart.
+const HelmRepositoryProperty = "helm.repository"
+
+type HelmModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	// The name and version of the chart, which are used to find its package.
+	chartName    string
+	chartVersion string
+}
+
+// Pass an empty string for srcPath to find the Helm chart in the working directory.
+func newHelmModule(srcPath string, containingBuild *Build) (*HelmModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.HelmChartFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	chart, err := buildutils.ReadHelmChart(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := chart.Name
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the %s file. Using the directory name: %s as module name.", buildutils.HelmChartFileName, name))
+	} else if chart.Version != "" {
+		name += ":" + chart.Version
+	}
+
+	return &HelmModule{name: name, srcPath: srcPath, chartName: chart.Name, chartVersion: chart.Version, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the dependencies listed in the Chart.lock file.
+func (hm *HelmModule) CalcDependencies() error {
+	started := time.Now()
+	if !hm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := hm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: hm.name, Type: entities.Helm, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(hm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return hm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (hm *HelmModule) SetName(name string) {
+	hm.name = name
+}
+
+func (hm *HelmModule) SetProperties(properties map[string]string) {
+	hm.properties = properties
+}
+
+func (hm *HelmModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !hm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: hm.name, ModuleType: entities.Helm, Artifacts: artifacts}
+	return hm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// AddChartArtifact adds the chart archive created by 'helm package' as an artifact of the module.
+// Pass an empty string for archivePath to use the archive in the chart's directory, which is named after the name and version of the chart.
+func (hm *HelmModule) AddChartArtifact(archivePath string) error {
+	if archivePath == "" {
+		archivePath = filepath.Join(hm.srcPath, buildutils.GetHelmChartArchiveName(hm.chartName, hm.chartVersion))
+	}
+	checksum, err := hm.containingBuild.getFileChecksum(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed calculating the checksums of the chart archive %s: %s. Run 'helm package' to create it", archivePath, err.Error())
+	}
+	artifact := entities.Artifact{Name: filepath.Base(archivePath), Type: "tgz", Path: filepath.Base(archivePath), Checksum: checksum}
+	return hm.AddArtifacts(artifact)
+}
+
+func (hm *HelmModule) loadDependencies() ([]entities.Dependency, error) {
+	lock, err := buildutils.ReadHelmChartLock(hm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'helm dependency update' to create it", buildutils.HelmChartLockFileName, err.Error())
+	}
+	var dependencies []entities.Dependency
+	for _, chartDependency := range lock.Dependencies {
+		dependency, err := hm.createHelmDependency(hm.srcPath, chartDependency)
+		if err != nil {
+			return nil, err
+		}
+		dependency.RequestedBy = [][]string{{hm.name}}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+// Creates the build-info dependency of a chart dependency.
+// The checksums are calculated from the archive of the dependency in the charts directory, if it was downloaded by 'helm dependency build'.
+func (hm *HelmModule) createHelmDependency(srcPath string, chartDependency buildutils.HelmChartDependency) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: chartDependency.Id(), Type: "helm"}
+	setDependencyProperties(&dependency, map[string]string{HelmRepositoryProperty: chartDependency.Repository})
+	archivePath := buildutils.GetHelmChartArchivePath(srcPath, chartDependency)
+	exists, err := utils.IsFileExists(archivePath, true)
+	if err != nil || !exists {
+		return dependency, err
+	}
+	checksum, err := hm.containingBuild.getFileChecksum(archivePath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
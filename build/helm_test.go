@@ -0,0 +1,55 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForHelmChart(t *testing.T) {
+	service := NewBuildInfoService()
+	helmBuild, err := service.GetOrCreateBuild("build-info-go-test-helm", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, helmBuild.Clean())
+	}()
+	helmModule, err := helmBuild.AddHelmModule(filepath.Join("testdata", "helm", "chart"))
+	if assert.NoError(t, err) {
+		err = helmModule.CalcDependencies()
+		assert.NoError(t, err)
+		err = helmModule.AddChartArtifact("")
+		assert.NoError(t, err)
+		buildInfo, err := helmBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Helm, module.Type)
+		assert.Equal(t, "hello:0.1.0", module.Id)
+		if assert.Len(t, module.Artifacts, 1) {
+			assert.Equal(t, "hello-0.1.0.tgz", module.Artifacts[0].Name)
+			assert.NotEmpty(t, module.Artifacts[0].Sha256)
+		}
+
+		assert.Len(t, module.Dependencies, 2)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "helm", dependency.Type)
+			assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			switch dependency.Id {
+			case "postgresql:12.5.8":
+				assert.Equal(t, map[string]string{HelmRepositoryProperty: "https://charts.bitnami.com/bitnami"}, dependency.Properties)
+				// Calculated from the archive in the charts directory.
+				assert.NotEmpty(t, dependency.Md5)
+				assert.NotEmpty(t, dependency.Sha1)
+				assert.NotEmpty(t, dependency.Sha256)
+			case "common:2.6.0":
+				assert.Equal(t, map[string]string{HelmRepositoryProperty: "oci://registry-1.docker.io/bitnamicharts"}, dependency.Properties)
+				// The dependency wasn't downloaded to the charts directory.
+				assert.True(t, dependency.Checksum.IsEmpty())
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the tap of a formula, and the URL of the bottle it was installed from.
+	HomebrewTapProperty       = "brew.tap"
+	HomebrewBottleUrlProperty = "brew.bottleUrl"
+)
+
+type HomebrewModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	// The name of the formula, whose bottles were built.
+	formula string
+	bottles []buildutils.HomebrewBottle
+}
+
+// Pass an empty string for srcPath if the bottles and their JSON files (created by 'brew bottle --json') are in the working directory.
+func newHomebrewModule(srcPath string, containingBuild *Build) (*HomebrewModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+	bottles, err := buildutils.ReadHomebrewBottles(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(bottles) == 0 {
+		return nil, fmt.Errorf("no bottle JSON files were found in %s. Run 'brew bottle --json <formula>' to build the bottles of the formula", srcPath)
+	}
+	for _, bottle := range bottles {
+		if bottle.FormulaName != bottles[0].FormulaName {
+			return nil, fmt.Errorf("the bottles of several formulae were found in %s: %s and %s. A module can only contain the bottles of a single formula", srcPath, bottles[0].FormulaName, bottle.FormulaName)
+		}
+	}
+	return &HomebrewModule{name: bottles[0].FormulaId(), srcPath: srcPath, formula: bottles[0].FormulaName, bottles: bottles, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the built bottles as the artifacts of the module, and the installed dependencies of the formula as its dependencies.
+// The formula must be installed (for example, using 'brew install --build-bottle'), so that its dependencies are resolved.
+func (hm *HomebrewModule) CalcDependencies() error {
+	started := time.Now()
+	if !hm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	formulae, err := buildutils.RunBrewInfo()
+	if err != nil {
+		return err
+	}
+	buildInfoDependencies, err := hm.loadDependencies(formulae)
+	if err != nil {
+		return err
+	}
+	buildInfoArtifacts, err := hm.getBottleArtifacts()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: hm.name, Type: entities.Homebrew, Artifacts: buildInfoArtifacts, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(hm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return hm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (hm *HomebrewModule) SetName(name string) {
+	hm.name = name
+}
+
+func (hm *HomebrewModule) SetProperties(properties map[string]string) {
+	hm.properties = properties
+}
+
+func (hm *HomebrewModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !hm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: hm.name, ModuleType: entities.Homebrew, Artifacts: artifacts}
+	return hm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// Returns the built bottles. The checksums of the bottles are calculated from their files, or (if the files were uploaded and removed) taken from their JSON files.
+func (hm *HomebrewModule) getBottleArtifacts() ([]entities.Artifact, error) {
+	var artifacts []entities.Artifact
+	for _, bottle := range hm.bottles {
+		artifact := entities.Artifact{Name: bottle.LocalFileName, Type: "bottle", Checksum: entities.Checksum{Sha256: bottle.Sha256}}
+		bottlePath := filepath.Join(hm.srcPath, bottle.LocalFileName)
+		exists, err := utils.IsFileExists(bottlePath, true)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			checksum, err := hm.containingBuild.getFileChecksum(bottlePath)
+			if err != nil {
+				return nil, err
+			}
+			artifact.Checksum = checksum
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
+// Returns the runtime dependencies of the formula (recursively), and its build dependencies.
+// The sha256 checksum of each dependency is the checksum of the bottle it was installed from, for the platform of the built bottles.
+func (hm *HomebrewModule) loadDependencies(formulae map[string]buildutils.HomebrewFormula) ([]entities.Dependency, error) {
+	formula, ok := formulae[hm.formula]
+	if !ok {
+		return nil, fmt.Errorf("the formula %s isn't installed. Run 'brew install --build-bottle %s' to build it", hm.formula, hm.formula)
+	}
+	tag := hm.bottles[0].Tag
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+
+	// Returns the ID of a dependency of the given formula, from its installed version.
+	getId := func(parent buildutils.HomebrewFormula, name string) string {
+		if dependencyFormula, ok := formulae[name]; ok {
+			return dependencyFormula.Id()
+		}
+		return name + ":" + parent.InstalledDependencies[name]
+	}
+	var addFormula func(id string, dependencyFormula buildutils.HomebrewFormula, scopes []string)
+	addFormula = func(id string, dependencyFormula buildutils.HomebrewFormula, scopes []string) {
+		if _, ok := dependenciesMap[id]; ok {
+			return
+		}
+		dependency := entities.Dependency{Id: id, Type: "brew", Scopes: scopes}
+		bottle, _ := dependencyFormula.GetInstalledBottle(tag)
+		dependency.Sha256 = bottle.Sha256
+		setDependencyProperties(&dependency, map[string]string{HomebrewTapProperty: dependencyFormula.Tap, HomebrewBottleUrlProperty: bottle.Url})
+		dependenciesMap[id] = dependency
+		for _, name := range dependencyFormula.Dependencies {
+			childId := getId(dependencyFormula, name)
+			dependenciesGraph[id] = append(dependenciesGraph[id], childId)
+			addFormula(childId, formulae[name], scopes)
+		}
+	}
+	for _, name := range formula.Dependencies {
+		id := getId(formula, name)
+		dependenciesGraph[hm.name] = append(dependenciesGraph[hm.name], id)
+		addFormula(id, formulae[name], nil)
+	}
+	for _, name := range formula.BuildDependencies {
+		buildFormula, ok := formulae[name]
+		if !ok {
+			// Build dependencies aren't installed when the formula is installed from a bottle.
+			continue
+		}
+		dependenciesGraph[hm.name] = append(dependenciesGraph[hm.name], buildFormula.Id())
+		addFormula(buildFormula.Id(), buildFormula, []string{"build"})
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(hm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, hm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
@@ -0,0 +1,81 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHomebrewModuleBottles(t *testing.T) {
+	service := NewBuildInfoService()
+	brewBuild, err := service.GetOrCreateBuild("build-info-go-test-homebrew", "1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, brewBuild.Clean())
+	}()
+	brewModule, err := brewBuild.AddHomebrewModule(filepath.Join("testdata", "homebrew", "bottles"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello:2.12.1", brewModule.name)
+
+	artifacts, err := brewModule.getBottleArtifacts()
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.Artifact{{
+		Name: "hello--2.12.1.arm64_sonoma.bottle.tar.gz",
+		Type: "bottle",
+		Checksum: entities.Checksum{
+			Sha1:   "4f328ae2eadb31bbaed69027ab61414621bf2c54",
+			Md5:    "2fabb14284c4738f8822dec783fb1d4d",
+			Sha256: "612e85ee25d7d1cc38fc13f5859663ea9f31b09d73ec4d6af5d1d34c9a38f949",
+		},
+	}}, artifacts)
+}
+
+func TestHomebrewModuleDependencies(t *testing.T) {
+	service := NewBuildInfoService()
+	brewBuild, err := service.GetOrCreateBuild("build-info-go-test-homebrew", "1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, brewBuild.Clean())
+	}()
+	brewModule, err := brewBuild.AddHomebrewModule(filepath.Join("testdata", "homebrew", "bottles"))
+	require.NoError(t, err)
+
+	output, err := os.ReadFile(filepath.Join("testdata", "homebrew", "brew-info.json"))
+	require.NoError(t, err)
+	formulae, err := buildutils.ParseBrewInfo(output)
+	require.NoError(t, err)
+	dependencies, err := brewModule.loadDependencies(formulae)
+	assert.NoError(t, err)
+
+	assert.Len(t, dependencies, 3)
+	for _, dependency := range dependencies {
+		assert.Equal(t, "brew", dependency.Type)
+		assert.Equal(t, "homebrew/core", dependency.Properties[HomebrewTapProperty])
+		switch dependency.Id {
+		case "gettext:0.22_1":
+			assert.Equal(t, entities.Checksum{Sha256: "8717a573ce4851b4f4923fdc9a1ad4bde3b7d0d6b3c6bf0b32b6ad5e5e68d6c3"}, dependency.Checksum)
+			assert.Equal(t, "https://ghcr.io/v2/homebrew/core/gettext/blobs/sha256:8717a573ce4851b4f4923fdc9a1ad4bde3b7d0d6b3c6bf0b32b6ad5e5e68d6c3", dependency.Properties[HomebrewBottleUrlProperty])
+			assert.Equal(t, [][]string{{"hello:2.12.1"}}, dependency.RequestedBy)
+		case "libunistring:1.1":
+			// The installed version is older than the version of the bottles in the tap.
+			assert.True(t, dependency.Checksum.IsEmpty())
+			assert.Equal(t, [][]string{{"gettext:0.22_1", "hello:2.12.1"}}, dependency.RequestedBy)
+		case "pkg-config:0.29.2_3":
+			assert.Equal(t, []string{"build"}, dependency.Scopes)
+			// The bottle for all platforms.
+			assert.Equal(t, entities.Checksum{Sha256: "3d9b8bf9b7b4bd08086be1104e3e18afb1c437dfaca03e6e7df8f2710b9c1c1a"}, dependency.Checksum)
+		default:
+			assert.Fail(t, "Unexpected dependency "+dependency.Id)
+		}
+	}
+
+	// The formula must be installed.
+	delete(formulae, "hello")
+	_, err = brewModule.loadDependencies(formulae)
+	assert.ErrorContains(t, err, "the formula hello isn't installed")
+}
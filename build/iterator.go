@@ -0,0 +1,152 @@
+package build
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// ModulesIterator iterates over the modules, which the build collected so far, as they were saved. See Build.Modules().
+type ModulesIterator struct {
+	build *Build
+	// The build-infos, which the modules saved, and weren't read yet.
+	buildInfoFiles []string
+	// The build-info, which is currently read.
+	file   io.ReadCloser
+	reader *entities.BuildInfoReader
+	// The modules, which were collected from the partials. They're read after the saved build-infos.
+	partialModules []entities.Module
+	partialsRead   bool
+}
+
+// Modules returns an iterator over the modules, which the build collected so far, without creating its build-info.
+// The build-infos, which the modules saved, are read lazily, module by module. The modules, which were collected from partials (such as the artifacts added by AddArtifacts()), are read after them.
+// The modules are returned as they were saved. Modules, which were saved several times (for example, by several processes), are returned several times, and the processing done by ToBuildInfo()
+// (such as setting the standard scopes, adding the module properties and deduplicating the dependencies) isn't done. Close the iterator after using it.
+func (b *Build) Modules() (*ModulesIterator, error) {
+	if !b.buildNameAndNumberProvided() {
+		return nil, errors.New("a build name must be provided in order to iterate over its modules")
+	}
+	buildInfoFiles, err := b.getGeneratedBuildInfoFiles()
+	if err != nil {
+		return nil, err
+	}
+	return &ModulesIterator{build: b, buildInfoFiles: buildInfoFiles}, nil
+}
+
+// Next returns the next module, or io.EOF after the last module.
+func (mi *ModulesIterator) Next() (*entities.Module, error) {
+	for mi.reader != nil || len(mi.buildInfoFiles) > 0 {
+		if mi.reader == nil {
+			if err := mi.openNextBuildInfo(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		module, err := mi.reader.NextModule()
+		if err != io.EOF {
+			return module, err
+		}
+		if err = mi.closeBuildInfo(); err != nil {
+			return nil, err
+		}
+	}
+	if !mi.partialsRead {
+		partials, err := mi.build.getPartialsStore().LoadPartials(mi.build.getBuildId())
+		if err != nil {
+			return nil, err
+		}
+		if mi.partialModules, _, _, _, _, err = extractBuildInfoData(partials); err != nil {
+			return nil, err
+		}
+		mi.partialsRead = true
+	}
+	if len(mi.partialModules) == 0 {
+		return nil, io.EOF
+	}
+	module := mi.partialModules[0]
+	mi.partialModules = mi.partialModules[1:]
+	if module.Id == "" {
+		module.Id = mi.build.buildName
+	}
+	return &module, nil
+}
+
+// Close closes the build-info, which is currently read.
+func (mi *ModulesIterator) Close() error {
+	if mi.file == nil {
+		return nil
+	}
+	return mi.closeBuildInfo()
+}
+
+func (mi *ModulesIterator) openNextBuildInfo() error {
+	buildInfoFile := mi.buildInfoFiles[0]
+	mi.buildInfoFiles = mi.buildInfoFiles[1:]
+	fileInfo, err := os.Stat(buildInfoFile)
+	if err != nil {
+		return err
+	}
+	// The Maven and Gradle modules create empty files, which their extractors write the build-infos to.
+	if fileInfo.Size() == 0 {
+		return nil
+	}
+	if mi.file, err = utils.OpenFileDecompressed(buildInfoFile); err != nil {
+		return err
+	}
+	mi.reader = entities.NewBuildInfoReader(mi.file)
+	return nil
+}
+
+func (mi *ModulesIterator) closeBuildInfo() error {
+	err := mi.file.Close()
+	mi.file, mi.reader = nil, nil
+	return err
+}
+
+// DependenciesIterator iterates over the dependencies of the modules, which the build collected so far. See Build.DependenciesIter().
+type DependenciesIterator struct {
+	modules *ModulesIterator
+	// The current module and its sub-modules, whose dependencies weren't returned yet.
+	pendingModules []entities.Module
+	index          int
+}
+
+// DependenciesIter returns an iterator over the dependencies of the modules (and their sub-modules), which the build collected so far, without creating its build-info.
+// The modules are read lazily, as described in Modules(), so the dependencies, which several modules have, or which were saved several times, are returned several times. Close the iterator after using it.
+func (b *Build) DependenciesIter() (*DependenciesIterator, error) {
+	modules, err := b.Modules()
+	if err != nil {
+		return nil, err
+	}
+	return &DependenciesIterator{modules: modules}, nil
+}
+
+// Next returns the next dependency and the ID of the module, which has it, or io.EOF after the last dependency.
+func (di *DependenciesIterator) Next() (moduleId string, dependency *entities.Dependency, err error) {
+	for {
+		if len(di.pendingModules) > 0 {
+			module := &di.pendingModules[0]
+			if di.index < len(module.Dependencies) {
+				di.index++
+				return module.Id, &module.Dependencies[di.index-1], nil
+			}
+			di.pendingModules = append(di.pendingModules[1:], module.Modules...)
+			di.index = 0
+			continue
+		}
+		module, err := di.modules.Next()
+		if err != nil {
+			return "", nil, err
+		}
+		di.pendingModules = []entities.Module{*module}
+	}
+}
+
+// Close closes the build-info, which is currently read.
+func (di *DependenciesIterator) Close() error {
+	return di.modules.Close()
+}
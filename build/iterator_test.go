@@ -0,0 +1,76 @@
+package build
+
+import (
+	"io"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModulesIterator(t *testing.T) {
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-modules-iterator-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	assert.NoError(t, build.SaveBuildInfo(&entities.BuildInfo{Modules: []entities.Module{
+		{Id: "go-module", Type: entities.Go, Dependencies: []entities.Dependency{{Id: "a:1"}, {Id: "b:1"}}},
+		{Id: "maven-module", Type: entities.Maven, Modules: []entities.Module{{Id: "maven-submodule", Type: entities.Maven, Dependencies: []entities.Dependency{{Id: "c:1"}}}}},
+	}}))
+	// Compressed build-infos are read too.
+	build.SetCompressBuildInfo(true)
+	assert.NoError(t, build.SaveBuildInfo(&entities.BuildInfo{Modules: []entities.Module{{Id: "npm-module", Type: entities.Npm, Dependencies: []entities.Dependency{{Id: "d:1"}}}}}))
+	assert.NoError(t, build.SavePartialBuildInfo(&entities.Partial{ModuleId: "generic-module", ModuleType: entities.Generic, Dependencies: []entities.Dependency{{Id: "e:1"}}}))
+
+	modules, err := build.Modules()
+	assert.NoError(t, err)
+	var moduleIds []string
+	for {
+		module, err := modules.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		moduleIds = append(moduleIds, module.Id)
+	}
+	assert.NoError(t, modules.Close())
+	assert.ElementsMatch(t, []string{"go-module", "maven-module", "npm-module", "generic-module"}, moduleIds)
+	// The modules, which were collected from the partials, are returned last.
+	assert.Equal(t, "generic-module", moduleIds[len(moduleIds)-1])
+
+	dependencies, err := build.DependenciesIter()
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, dependencies.Close())
+	}()
+	dependencyModules := make(map[string]string)
+	for {
+		moduleId, dependency, err := dependencies.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		dependencyModules[dependency.Id] = moduleId
+	}
+	assert.Equal(t, map[string]string{"a:1": "go-module", "b:1": "go-module", "c:1": "maven-submodule", "d:1": "npm-module", "e:1": "generic-module"}, dependencyModules)
+}
+
+func TestModulesIteratorClose(t *testing.T) {
+	service := NewBuildInfoService()
+	build, err := service.GetOrCreateBuild("bi-modules-iterator-close-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	assert.NoError(t, build.SaveBuildInfo(&entities.BuildInfo{Modules: []entities.Module{{Id: "first", Type: entities.Go}, {Id: "second", Type: entities.Go}}}))
+	modules, err := build.Modules()
+	assert.NoError(t, err)
+	module, err := modules.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", module.Id)
+	// The build-info, which is currently read, is closed.
+	assert.NoError(t, modules.Close())
+	assert.NoError(t, modules.Close())
+}
@@ -0,0 +1,150 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the UUID of a package, the repository and revision of a package added by URL, and the path of a developed package.
+	JuliaUuidProperty    = "julia.uuid"
+	JuliaRepoUrlProperty = "julia.repoUrl"
+	JuliaRepoRevProperty = "julia.repoRev"
+	JuliaPathProperty    = "julia.path"
+)
+
+type JuliaModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the Project.toml file in the working directory or in its parents.
+func newJuliaModule(srcPath string, containingBuild *Build) (*JuliaModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.JuliaProjectFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	project, err := buildutils.ReadJuliaProject(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := project.Name
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the %s file. Using the directory name: %s as module name.", buildutils.JuliaProjectFileName, name))
+	} else if project.Version != "" {
+		name += ":" + project.Version
+	}
+
+	return &JuliaModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages listed in the Manifest.toml file.
+func (jm *JuliaModule) CalcDependencies() error {
+	started := time.Now()
+	if !jm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := jm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: jm.name, Type: entities.Julia, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(jm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return jm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (jm *JuliaModule) SetName(name string) {
+	jm.name = name
+}
+
+func (jm *JuliaModule) SetProperties(properties map[string]string) {
+	jm.properties = properties
+}
+
+func (jm *JuliaModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !jm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: jm.name, ModuleType: entities.Julia, Artifacts: artifacts}
+	return jm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (jm *JuliaModule) loadDependencies() ([]entities.Dependency, error) {
+	project, err := buildutils.ReadJuliaProject(jm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	packages, err := buildutils.ReadJuliaManifest(jm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file in %s: %s. Run 'Pkg.resolve()' to create it", buildutils.JuliaManifestFileName, jm.srcPath, err.Error())
+	}
+	idsByUuid := make(map[string]string)
+	for _, pkg := range packages {
+		idsByUuid[pkg.Uuid] = pkg.Id()
+	}
+	getIds := func(uuids []string) (ids []string) {
+		for _, uuid := range uuids {
+			if id, ok := idsByUuid[uuid]; ok {
+				ids = append(ids, id)
+			}
+		}
+		return
+	}
+
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	for _, pkg := range packages {
+		dependenciesMap[pkg.Id()] = createJuliaDependency(pkg)
+		dependenciesGraph[pkg.Id()] = getIds(pkg.Dependencies)
+	}
+	var directUuids []string
+	for _, uuid := range project.Deps {
+		directUuids = append(directUuids, uuid)
+	}
+	dependenciesGraph[jm.name] = getIds(directUuids)
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(jm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, jm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a Julia package.
+// The sha1 checksum of the package is its git tree hash, which Pkg verifies when installing the package.
+func createJuliaDependency(pkg buildutils.JuliaPackage) entities.Dependency {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: "julia", Checksum: entities.Checksum{Sha1: pkg.GitTreeSha1}}
+	switch {
+	case pkg.Path != "":
+		dependency.Type = "path"
+	case pkg.IsStdlib():
+		dependency.Type = "stdlib"
+	}
+	setDependencyProperties(&dependency, map[string]string{
+		JuliaUuidProperty:    pkg.Uuid,
+		JuliaRepoUrlProperty: pkg.RepoUrl,
+		JuliaRepoRevProperty: pkg.RepoRev,
+		JuliaPathProperty:    pkg.Path,
+	})
+	return dependency
+}
@@ -0,0 +1,76 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForJuliaProject(t *testing.T) {
+	service := NewBuildInfoService()
+	juliaBuild, err := service.GetOrCreateBuild("build-info-go-test-julia", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, juliaBuild.Clean())
+	}()
+	juliaModule, err := juliaBuild.AddJuliaModule(filepath.Join("testdata", "julia", "project"))
+	if assert.NoError(t, err) {
+		err = juliaModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := juliaBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Julia, module.Type)
+		assert.Equal(t, "Hello:0.1.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 14)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "JSON:0.21.4":
+				assert.Equal(t, "julia", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha1: "31e996f0a15c7b280ba9f76636b3ff9e2ae58c9a"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{JuliaUuidProperty: "682c06a0-de6a-54ab-a142-c8b1cf79cde6"}, dependency.Properties)
+				assert.ElementsMatch(t, [][]string{{module.Id}, {"Utils:0.2.0", module.Id}}, dependency.RequestedBy)
+			case "PrecompileTools:1.2.0":
+				assert.Equal(t, "https://github.com/JuliaLang/PrecompileTools.jl.git", dependency.Properties[JuliaRepoUrlProperty])
+				assert.Equal(t, "main", dependency.Properties[JuliaRepoRevProperty])
+			case "Utils:0.2.0":
+				assert.Equal(t, "path", dependency.Type)
+				assert.Equal(t, "lib/Utils", dependency.Properties[JuliaPathProperty])
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "Dates":
+				assert.Equal(t, "stdlib", dependency.Type)
+				assert.Contains(t, dependency.RequestedBy, []string{module.Id})
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForJuliaProjectWithOldManifestFormat(t *testing.T) {
+	service := NewBuildInfoService()
+	juliaBuild, err := service.GetOrCreateBuild("build-info-go-test-julia-v1", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, juliaBuild.Clean())
+	}()
+	juliaModule, err := juliaBuild.AddJuliaModule(filepath.Join("testdata", "julia", "project-v1"))
+	if assert.NoError(t, err) {
+		err = juliaModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := juliaBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		module := buildInfo.Modules[0]
+		// The project has no name, so the directory name is used.
+		assert.Equal(t, "project-v1", module.Id)
+		assert.Len(t, module.Dependencies, 7)
+		for _, dependency := range module.Dependencies {
+			if dependency.Id == "Parsers:0.3.12" {
+				assert.Equal(t, entities.Checksum{Sha1: "0c16b3179190d3046c073440d94172cfc3bb0553"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{"JSON:0.21.0", module.Id}}, dependency.RequestedBy)
+			}
+		}
+	}
+}
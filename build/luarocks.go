@@ -0,0 +1,178 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency properties, which hold the URL of the source of a rock, and the tag (or branch) fetched from version control systems.
+	LuaRocksUrlProperty = "luarocks.url"
+	LuaRocksTagProperty = "luarocks.tag"
+)
+
+type LuaRocksModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	rocksTree       string
+}
+
+// Pass an empty string for srcPath if the root of the LuaRocks project is the working directory.
+func newLuaRocksModule(srcPath string, containingBuild *Build) (*LuaRocksModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name := ""
+	rockspecPath, err := buildutils.FindRockspec(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if rockspecPath != "" {
+		rockspec, err := buildutils.ReadRockspec(rockspecPath)
+		if err != nil {
+			return nil, err
+		}
+		if rockspec.Package != "" {
+			name = rockspec.Id()
+		}
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No rockspec file was found. Using the directory name: %s as module name.", name))
+	}
+
+	return &LuaRocksModule{name: name, srcPath: srcPath, rocksTree: filepath.Join(srcPath, buildutils.LuaRocksProjectTreeDir), containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the rocks installed in the rocks tree of the project.
+func (lm *LuaRocksModule) CalcDependencies() error {
+	started := time.Now()
+	if !lm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := lm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: lm.name, Type: entities.LuaRocks, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(lm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return lm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (lm *LuaRocksModule) SetName(name string) {
+	lm.name = name
+}
+
+func (lm *LuaRocksModule) SetProperties(properties map[string]string) {
+	lm.properties = properties
+}
+
+// SetRocksTree sets the rocks tree, in which the dependencies were installed, if it isn't the 'lua_modules' directory of the project (for example: '~/.luarocks').
+func (lm *LuaRocksModule) SetRocksTree(rocksTree string) {
+	lm.rocksTree = rocksTree
+}
+
+func (lm *LuaRocksModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !lm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: lm.name, ModuleType: entities.LuaRocks, Artifacts: artifacts}
+	return lm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (lm *LuaRocksModule) loadDependencies() ([]entities.Dependency, error) {
+	exists, err := utils.IsDirExists(lm.rocksTree, false)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("the rocks tree %s doesn't exist. Run 'luarocks init' and install the dependencies of the project, or set the rocks tree they were installed in", lm.rocksTree)
+	}
+	rocks, err := buildutils.ReadRocksTree(lm.rocksTree)
+	if err != nil {
+		return nil, err
+	}
+	lockedVersions, err := buildutils.ReadLuaRocksLock(lm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	// The dependencies of rocks are resolved by their names. If several versions of a rock are installed in the tree,
+	// the version pinned in the luarocks.lock file is used, or (if the project has no lock file) the last version by name.
+	idsByName := make(map[string]string)
+	for _, rock := range rocks {
+		dependency := entities.Dependency{Id: rock.Id(), Type: "rock"}
+		if rock.SourceMd5 != "" {
+			dependency.Md5 = rock.SourceMd5
+		}
+		setDependencyProperties(&dependency, map[string]string{
+			LuaRocksUrlProperty: rock.SourceUrl,
+			LuaRocksTagProperty: rock.SourceTag,
+		})
+		dependenciesMap[dependency.Id] = dependency
+		if lockedVersion, exists := lockedVersions[rock.Package]; !exists || lockedVersion == rock.Version {
+			idsByName[rock.Package] = dependency.Id
+		}
+	}
+	requested := make(map[string]bool)
+	for _, rock := range rocks {
+		for _, childId := range resolveRockDependencies(rock.Dependencies, idsByName) {
+			dependenciesGraph[rock.Id()] = append(dependenciesGraph[rock.Id()], childId)
+			requested[childId] = true
+		}
+	}
+	rockspecPath, err := buildutils.FindRockspec(lm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if rockspecPath != "" {
+		rockspec, err := buildutils.ReadRockspec(rockspecPath)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesGraph[lm.name] = resolveRockDependencies(rockspec.Dependencies, idsByName)
+	}
+	// Rocks, which aren't required by other rocks, are considered as direct dependencies too.
+	// This covers projects without a rockspec file, and rocks installed without being listed in it.
+	for _, rock := range rocks {
+		if id := rock.Id(); !requested[id] && !slices.Contains(dependenciesGraph[lm.name], id) {
+			dependenciesGraph[lm.name] = append(dependenciesGraph[lm.name], id)
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(lm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, lm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Returns the IDs of the installed rocks with the given names, without duplicates.
+// Dependencies, which aren't installed in the tree, such as 'lua' itself, are skipped.
+func resolveRockDependencies(names []string, idsByName map[string]string) []string {
+	var ids []string
+	for _, name := range names {
+		if id, exists := idsByName[name]; exists && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
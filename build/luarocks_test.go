@@ -0,0 +1,51 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForLuaRocksProject(t *testing.T) {
+	service := NewBuildInfoService()
+	luaRocksBuild, err := service.GetOrCreateBuild("build-info-go-test-luarocks", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, luaRocksBuild.Clean())
+	}()
+	luaRocksModule, err := luaRocksBuild.AddLuaRocksModule(filepath.Join("testdata", "luarocks", "project"))
+	if assert.NoError(t, err) {
+		err = luaRocksModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := luaRocksBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.LuaRocks, module.Type)
+		assert.Equal(t, "hello:1.0-1", module.Id)
+
+		assert.Len(t, module.Dependencies, 3)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "rock", dependency.Type)
+			switch dependency.Id {
+			case "ansicolors:1.0.2-3":
+				// Not listed in the rockspec of the project, nor required by other rocks.
+				assert.Equal(t, entities.Checksum{Md5: "7a47d8c6e9d0f4ab3d3ce4e8c0d2b1a6"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "luafilesystem:1.8.0-1":
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, map[string]string{LuaRocksUrlProperty: "git+https://github.com/keplerproject/luafilesystem", LuaRocksTagProperty: "v1_8_0"}, dependency.Properties)
+				assert.ElementsMatch(t, [][]string{{module.Id}, {"penlight:1.13.1-1", module.Id}}, dependency.RequestedBy)
+			case "penlight:1.13.1-1":
+				// The URL in the rockspec is concatenated from variables.
+				assert.Equal(t, entities.Checksum{Md5: "a0c5d6b1a2e4c3f8b7d9e0f1a2b3c4d5"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{LuaRocksUrlProperty: "https://github.com/lunarmodules/penlight/archive/1.13.1.tar.gz"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
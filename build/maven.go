@@ -9,7 +9,10 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/build-info-go/utils"
 )
 
@@ -21,6 +24,7 @@ const (
 	mavenExtractorRemotePath        = "org/jfrog/buildinfo/build-info-extractor-maven3/%s"
 	GeneratedBuildInfoTempPrefix    = "generatedBuildInfo"
 	MavenExtractorDependencyVersion = "2.38.1"
+	mavenLocalRepositoryOpt         = "-Dmaven.repo.local="
 
 	ClassworldsConf = `main is org.apache.maven.cli.MavenCli from plexus.core
 
@@ -39,6 +43,9 @@ type MavenModule struct {
 	srcPath string
 	// The Maven extractor (dependency) which calculates the build-info.
 	extractorDetails *extractorDetails
+	// If true, the licenses of the dependencies are read from their POMs in the local Maven repository.
+	collectLicenses bool
+	properties      map[string]string
 }
 
 // Maven extractor is the engine for calculating the project dependencies.
@@ -100,6 +107,17 @@ func (mm *MavenModule) SetMavenOpts(mavenOpts ...string) {
 	mm.extractorDetails.mavenOpts = mavenOpts
 }
 
+// SetProperties sets the properties of the modules of the Maven project.
+func (mm *MavenModule) SetProperties(properties map[string]string) {
+	mm.properties = properties
+}
+
+// SetCollectLicenses sets whether to read the licenses of the dependencies from their POMs (or their parent POMs) in the local Maven repository.
+// The local repository is ~/.m2/repository, unless it's set by the 'maven.repo.local' system property in the Maven options.
+func (mm *MavenModule) SetCollectLicenses(collectLicenses bool) {
+	mm.collectLicenses = collectLicenses
+}
+
 func (mm *MavenModule) createMvnRunConfig() (*mvnRunConfig, error) {
 	var javaExecPath string
 	mavenHome, err := mm.loadMavenHome()
@@ -139,12 +157,14 @@ func (mm *MavenModule) createMvnRunConfig() (*mvnRunConfig, error) {
 		workspace:           mm.srcPath,
 		goals:               mm.extractorDetails.goals,
 		buildInfoProperties: extractorProps,
+		buildInfoPath:       buildInfoPath,
 		mavenOpts:           mm.extractorDetails.mavenOpts,
 	}, nil
 }
 
 // Generates Maven build-info.
 func (mm *MavenModule) CalcDependencies() error {
+	started := time.Now()
 	if mm.srcPath == "" {
 		var err error
 		if mm.srcPath, err = os.Getwd(); err != nil {
@@ -163,7 +183,51 @@ func (mm *MavenModule) CalcDependencies() error {
 
 	defer os.Remove(mvnRunConfig.buildInfoProperties)
 	mm.containingBuild.logger.Info("Running Mvn...")
-	return mvnRunConfig.runCmd()
+	if err = mvnRunConfig.runCmd(); err != nil {
+		return err
+	}
+	return mm.containingBuild.updateGeneratedBuildInfo(mvnRunConfig.buildInfoPath, func(buildInfo *entities.BuildInfo) error {
+		setModulesTiming(buildInfo, started)
+		if err := mm.containingBuild.filterDependencies(buildInfo); err != nil {
+			return err
+		}
+		return mm.updateGeneratedBuildInfo(buildInfo)
+	})
+}
+
+// Sets the properties of the modules in the build-info, which was generated by the Maven extractor, and the licenses of their dependencies, if requested.
+func (mm *MavenModule) updateGeneratedBuildInfo(buildInfo *entities.BuildInfo) error {
+	setModulesProperties(buildInfo, mm.properties)
+	if !mm.collectLicenses {
+		return nil
+	}
+	repositoryPath, err := mm.getLocalRepositoryPath()
+	if err != nil {
+		return err
+	}
+	for i := range buildInfo.Modules {
+		dependencies := buildInfo.Modules[i].Dependencies
+		for j := range dependencies {
+			// The IDs of Maven dependencies are 'groupId:artifactId:version'.
+			idParts := strings.Split(dependencies[j].Id, ":")
+			if len(idParts) != 3 {
+				continue
+			}
+			if dependencies[j].Licenses, err = buildutils.GetMavenLocalRepositoryLicenses(repositoryPath, idParts[0], idParts[1], idParts[2]); err != nil {
+				mm.containingBuild.logger.Debug("Couldn't read the license of the dependency", dependencies[j].Id+":", err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func (mm *MavenModule) getLocalRepositoryPath() (string, error) {
+	for _, mavenOpt := range mm.extractorDetails.mavenOpts {
+		if strings.HasPrefix(mavenOpt, mavenLocalRepositoryOpt) {
+			return strings.TrimPrefix(mavenOpt, mavenLocalRepositoryOpt), nil
+		}
+	}
+	return buildutils.GetMavenLocalRepositoryPath()
 }
 
 func (mm *MavenModule) loadMavenHome() (mavenHome string, err error) {
@@ -254,7 +318,9 @@ type mvnRunConfig struct {
 	workspace           string
 	goals               []string
 	buildInfoProperties string
-	mavenOpts           []string
+	// The build-info, which is generated by the Maven extractor.
+	buildInfoPath string
+	mavenOpts     []string
 }
 
 func (config *mvnRunConfig) runCmd() error {
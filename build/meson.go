@@ -0,0 +1,157 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the URL and revision of a subproject, and the URL and sha256 checksum of the patch applied to it.
+	MesonUrlProperty       = "meson.url"
+	MesonRevisionProperty  = "meson.revision"
+	MesonPatchUrlProperty  = "meson.patchUrl"
+	MesonPatchHashProperty = "meson.patchHash"
+
+	// The directory in the subprojects directory, to which Meson downloads the source archives and patches.
+	mesonPackageCacheDir = "packagecache"
+)
+
+type MesonModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the meson.build file in the working directory or in its parents.
+func newMesonModule(srcPath string, containingBuild *Build) (*MesonModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.MesonBuildFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, version, err := buildutils.GetMesonProjectNameAndVersion(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No project name is defined in the %s file. Using the directory name: %s as module name.", buildutils.MesonBuildFileName, name))
+	} else if version != "" {
+		name += ":" + version
+	}
+
+	return &MesonModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the subprojects described by the wrap files in the subprojects directory of the project.
+func (mm *MesonModule) CalcDependencies() error {
+	started := time.Now()
+	if !mm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := mm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: mm.name, Type: entities.Meson, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(mm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return mm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (mm *MesonModule) SetName(name string) {
+	mm.name = name
+}
+
+func (mm *MesonModule) SetProperties(properties map[string]string) {
+	mm.properties = properties
+}
+
+func (mm *MesonModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !mm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: mm.name, ModuleType: entities.Meson, Artifacts: artifacts}
+	return mm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (mm *MesonModule) loadDependencies() ([]entities.Dependency, error) {
+	wraps, err := buildutils.ReadMesonWraps(mm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []entities.Dependency
+	for _, wrap := range wraps {
+		dependency, err := mm.createMesonDependency(wrap)
+		if err != nil {
+			return nil, err
+		}
+		// Meson promotes the subprojects of subprojects to the subprojects directory of the project, so all the subprojects are considered as direct dependencies.
+		dependency.RequestedBy = [][]string{{mm.name}}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+// Creates the build-info dependency of a subproject. The type of the dependency is the type of its wrap, for example: 'file' or 'git'.
+// The checksums of subprojects of type 'file' are calculated from their source archives in the package cache, or (if the archives weren't downloaded) taken from their wrap files.
+// The sha1 checksum of subprojects cloned from git repositories is their revision, if it's a commit, or the commit checked out in their directories.
+func (mm *MesonModule) createMesonDependency(wrap buildutils.MesonWrap) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: wrap.Id(), Type: wrap.Type}
+	switch wrap.Type {
+	case "file":
+		dependency.Sha256 = wrap.SourceHash
+		if wrap.SourceFileName != "" {
+			archivePath := filepath.Join(mm.srcPath, buildutils.MesonSubprojectsDir, mesonPackageCacheDir, wrap.SourceFileName)
+			exists, err := utils.IsFileExists(archivePath, true)
+			if err != nil {
+				return dependency, err
+			}
+			if exists {
+				checksum, err := mm.containingBuild.getFileChecksum(archivePath)
+				if err != nil {
+					return dependency, err
+				}
+				if wrap.SourceHash != "" && checksum.Sha256 != wrap.SourceHash {
+					return dependency, fmt.Errorf("the sha256 checksum of %s doesn't match the source_hash in the wrap file of %s", archivePath, wrap.Name)
+				}
+				dependency.Checksum = checksum
+			}
+		}
+	case "git":
+		if buildutils.IsGitCommit(wrap.Revision) {
+			dependency.Sha1 = wrap.Revision
+		} else {
+			commit, err := buildutils.GetGitHeadCommit(filepath.Join(mm.srcPath, buildutils.MesonSubprojectsDir, wrap.Directory))
+			if err != nil {
+				mm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't get the commit of the %s subproject: %s", wrap.Name, err.Error()))
+			}
+			dependency.Sha1 = commit
+		}
+	}
+	setDependencyProperties(&dependency, map[string]string{
+		MesonUrlProperty:       wrap.Url,
+		MesonRevisionProperty:  wrap.Revision,
+		MesonPatchUrlProperty:  wrap.PatchUrl,
+		MesonPatchHashProperty: wrap.PatchHash,
+	})
+	return dependency, nil
+}
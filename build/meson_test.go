@@ -0,0 +1,67 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForMesonProject(t *testing.T) {
+	service := NewBuildInfoService()
+	mesonBuild, err := service.GetOrCreateBuild("build-info-go-test-meson", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, mesonBuild.Clean())
+	}()
+	mesonModule, err := mesonBuild.AddMesonModule(filepath.Join("testdata", "meson", "project"))
+	if assert.NoError(t, err) {
+		err = mesonModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := mesonBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Meson, module.Type)
+		assert.Equal(t, "hello:1.2.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 5)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			switch dependency.Id {
+			case "zlib:1.3-4":
+				assert.Equal(t, "file", dependency.Type)
+				// The checksums of the downloaded source archive.
+				assert.Equal(t, entities.Checksum{
+					Sha1:   "48f4ba54fee4120996fa5a40537bf7f9155cb1f3",
+					Md5:    "7d39a3793bc02161b703e9a44589fda2",
+					Sha256: "efc617e4bf0caee9d04fd52db828698aba46acc6abfbc7d5c358644251b04797",
+				}, dependency.Checksum)
+				assert.Equal(t, map[string]string{
+					MesonUrlProperty:       "http://zlib.net/fossils/zlib-1.3.tar.xz",
+					MesonPatchUrlProperty:  "https://wrapdb.mesonbuild.com/v2/zlib_1.3-4/get_patch",
+					MesonPatchHashProperty: "2a4d7b4a1b2c3d4e5f60718293a4b5c6d7e8f9011223344556677889900aabbc",
+				}, dependency.Properties)
+			case "fmt:10.1.1":
+				// The source archive wasn't downloaded, so the checksum is taken from the wrap file, and the version from its directory.
+				assert.Equal(t, entities.Checksum{Sha256: "78b8c0a72b1c35e4443a7e308df52498252d1cefc2b08c9a97bc9ee6cfe61f8b"}, dependency.Checksum)
+			case "gtest:1.14.0-1":
+				// Redirected to the wrap file of a subproject.
+				assert.Equal(t, "file", dependency.Type)
+				assert.Equal(t, "https://github.com/google/googletest/archive/refs/tags/v1.14.0.tar.gz", dependency.Properties[MesonUrlProperty])
+			case "json:9cca280a4d0ccf0c08f47a99aa71d1b0e52f8d03":
+				assert.Equal(t, "git", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha1: "9cca280a4d0ccf0c08f47a99aa71d1b0e52f8d03"}, dependency.Checksum)
+				assert.Equal(t, "https://github.com/nlohmann/json.git", dependency.Properties[MesonUrlProperty])
+			case "libfoo:v2.0.1":
+				// The subproject wasn't cloned, so its commit is unknown.
+				assert.Equal(t, "git", dependency.Type)
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, "v2.0.1", dependency.Properties[MesonRevisionProperty])
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+type MixModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the Mix project in the working directory.
+func newMixModule(srcPath string, containingBuild *Build) (*MixModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.MixExsFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	project, err := buildutils.ReadMixProject(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := project.App
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No app is defined in the %s file. Using the directory name: %s as module name.", buildutils.MixExsFileName, name))
+	} else if project.Version != "" {
+		name += ":" + project.Version
+	}
+
+	return &MixModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages listed in the mix.lock file.
+func (mm *MixModule) CalcDependencies() error {
+	started := time.Now()
+	if !mm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := mm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: mm.name, Type: entities.Mix, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(mm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return mm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (mm *MixModule) SetName(name string) {
+	mm.name = name
+}
+
+func (mm *MixModule) SetProperties(properties map[string]string) {
+	mm.properties = properties
+}
+
+func (mm *MixModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !mm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: mm.name, ModuleType: entities.Mix, Artifacts: artifacts}
+	return mm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (mm *MixModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := buildutils.ReadMixLock(mm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'mix deps.get' to create it", buildutils.MixLockFileName, err.Error())
+	}
+	project, err := buildutils.ReadMixProject(mm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	cachePath, err := buildutils.GetHexPackagesCachePath()
+	if err != nil {
+		return nil, err
+	}
+	packagesIds := make(map[string]string)
+	for _, pkg := range packages {
+		packagesIds[pkg.Name] = pkg.Id()
+	}
+	// The environments of the direct dependencies, which are used as their scopes.
+	scopes := make(map[string][]string)
+	var directDependencies []string
+	for _, requirement := range project.Dependencies {
+		directDependencies = append(directDependencies, requirement.Name)
+		scopes[requirement.Name] = requirement.Only
+	}
+	dependenciesGraph := map[string][]string{mm.name: getMixPackagesIds(packagesIds, directDependencies)}
+	dependenciesMap := make(map[string]entities.Dependency)
+	for _, pkg := range packages {
+		dependenciesGraph[pkg.Id()] = getMixPackagesIds(packagesIds, pkg.Dependencies)
+		dependency, err := mm.createMixDependency(cachePath, pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependency.Scopes = scopes[pkg.Name]
+		dependenciesMap[pkg.Id()] = dependency
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(mm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, mm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+func getMixPackagesIds(packagesIds map[string]string, names []string) []string {
+	var ids []string
+	for _, name := range names {
+		if id, ok := packagesIds[name]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Creates the build-info dependency of a Mix package.
+// The checksums of Hex packages are calculated from their tarballs in the Hex cache. If they aren't cached, the outer checksum from the mix.lock file is used as the sha256 checksum.
+// The sha1 checksum of git packages is their commit.
+func (mm *MixModule) createMixDependency(cachePath string, pkg buildutils.MixPackage) (entities.Dependency, error) {
+	if pkg.Source == buildutils.MixGitSource {
+		return entities.Dependency{Id: pkg.Id(), Type: "git", Checksum: entities.Checksum{Sha1: pkg.GitCommit}}, nil
+	}
+	dependency := entities.Dependency{Id: pkg.Id(), Type: "hex", Checksum: entities.Checksum{Sha256: pkg.OuterChecksum}}
+	tarballPath := buildutils.GetHexTarballPath(cachePath, pkg)
+	exists, err := utils.IsFileExists(tarballPath, true)
+	if err != nil || !exists {
+		return dependency, err
+	}
+	checksum, err := mm.containingBuild.getFileChecksum(tarballPath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
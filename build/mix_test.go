@@ -0,0 +1,73 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForMixProject(t *testing.T) {
+	hexHome, err := filepath.Abs(filepath.Join("testdata", "mix", "hexhome"))
+	assert.NoError(t, err)
+	t.Setenv("HEX_HOME", hexHome)
+
+	service := NewBuildInfoService()
+	mixBuild, err := service.GetOrCreateBuild("build-info-go-test-mix", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, mixBuild.Clean())
+	}()
+	mixModule, err := mixBuild.AddMixModule(filepath.Join("testdata", "mix", "project"))
+	if assert.NoError(t, err) {
+		err = mixModule.CalcDependencies()
+		assert.NoError(t, err)
+		err = mixModule.AddArtifacts(entities.Artifact{Name: "artifactName", Type: "artifactType", Path: "artifactPath", Checksum: entities.Checksum{Sha1: "123", Md5: "456", Sha256: "789"}})
+		assert.NoError(t, err)
+		buildInfo, err := mixBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Mix, module.Type)
+		assert.Equal(t, "hello:0.1.0", module.Id)
+		assert.Len(t, module.Artifacts, 1)
+
+		expectedRequestedBy := map[string][][]string{
+			"plug_cowboy:2.6.1": {{module.Id}},
+			"jason:1.4.1":       {{module.Id}, {"credo:1.7.1", module.Id}},
+			"my_lib:8a7b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b": {{module.Id}},
+			"credo:1.7.1":   {{module.Id}},
+			"bunt:0.2.1":    {{"credo:1.7.1", module.Id}},
+			"cowboy:2.10.0": {{"plug_cowboy:2.6.1", module.Id}},
+			"cowlib:2.12.1": {{"cowboy:2.10.0", "plug_cowboy:2.6.1", module.Id}},
+			"ranch:1.8.0":   {{"cowboy:2.10.0", "plug_cowboy:2.6.1", module.Id}},
+		}
+		assert.Len(t, module.Dependencies, len(expectedRequestedBy))
+		for _, dependency := range module.Dependencies {
+			assert.ElementsMatch(t, expectedRequestedBy[dependency.Id], dependency.RequestedBy, dependency.Id)
+			switch dependency.Id {
+			case "jason:1.4.1":
+				// Calculated from the tarball in the Hex cache.
+				assert.NotEmpty(t, dependency.Md5)
+				assert.NotEmpty(t, dependency.Sha1)
+				assert.NotEqual(t, "fbb01ecdfd565b56261302f7e1fcc27c4fb8f32d56eab74db621fc154604a7a1", dependency.Sha256)
+			case "my_lib:8a7b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b":
+				assert.Equal(t, "git", dependency.Type)
+				assert.Equal(t, "8a7b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b", dependency.Sha1)
+			case "ranch:1.8.0":
+				// Lock entries created by older Hex versions have no outer checksum.
+				assert.True(t, dependency.Checksum.IsEmpty())
+			case "credo:1.7.1":
+				assert.Equal(t, []string{"dev", "test"}, dependency.Scopes)
+				assert.Equal(t, "e9871c6095a4c0381c89b6aa98bc6260a8ba6addccf7f6a53da8849c748a58a2", dependency.Sha256)
+			default:
+				// The outer checksum from the mix.lock file.
+				assert.Equal(t, "hex", dependency.Type)
+				assert.Empty(t, dependency.Sha1)
+				assert.NotEmpty(t, dependency.Sha256)
+				assert.Empty(t, dependency.Scopes)
+			}
+		}
+	}
+}
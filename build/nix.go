@@ -0,0 +1,191 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency properties, which hold the locked flake reference and the NAR hash of a flake input, or the store path and the NAR hash of a path in the closure.
+	NixUrlProperty       = "nix.url"
+	NixStorePathProperty = "nix.storePath"
+	NixNarHashProperty   = "nix.narHash"
+)
+
+type NixModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	// The installables (for example: '.#default'), whose closures are added as dependencies.
+	installables []string
+}
+
+// Pass an empty string for srcPath to find the flake in the working directory.
+func newNixModule(srcPath string, containingBuild *Build) (*NixModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.NixFlakeFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &NixModule{name: filepath.Base(srcPath), srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the inputs listed in the flake.lock file, and the closures of the installables set by SetInstallables.
+func (nm *NixModule) CalcDependencies() error {
+	started := time.Now()
+	if !nm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := nm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: nm.name, Type: entities.Nix, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(nm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return nm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (nm *NixModule) SetName(name string) {
+	nm.name = name
+}
+
+func (nm *NixModule) SetProperties(properties map[string]string) {
+	nm.properties = properties
+}
+
+// SetInstallables sets the built derivations (for example: '.#default' or '/nix/store/...-hello-2.12.1'), whose runtime closures are added as dependencies of the module.
+// The closures are collected using 'nix path-info --recursive', so the installables must be built before calling CalcDependencies.
+func (nm *NixModule) SetInstallables(installables ...string) {
+	nm.installables = installables
+}
+
+func (nm *NixModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !nm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: nm.name, ModuleType: entities.Nix, Artifacts: artifacts}
+	return nm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (nm *NixModule) loadDependencies() ([]entities.Dependency, error) {
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+
+	lockFileExists, err := utils.IsFileExists(filepath.Join(nm.srcPath, buildutils.NixFlakeLockFileName), true)
+	if err != nil {
+		return nil, err
+	}
+	if lockFileExists {
+		lock, err := buildutils.ReadFlakeLock(nm.srcPath)
+		if err != nil {
+			return nil, err
+		}
+		if err = addFlakeInputsToGraph(nm.name, lock, dependenciesMap, dependenciesGraph); err != nil {
+			return nil, err
+		}
+	} else {
+		nm.containingBuild.logger.Debug(fmt.Sprintf("No %s file was found in %s. Run 'nix flake lock' to create it.", buildutils.NixFlakeLockFileName, nm.srcPath))
+	}
+
+	if len(nm.installables) > 0 {
+		storePaths, err := buildutils.RunNixPathInfo(nm.srcPath, nm.installables)
+		if err != nil {
+			return nil, err
+		}
+		if err = addNixStorePathsToGraph(nm.name, storePaths, dependenciesMap, dependenciesGraph); err != nil {
+			return nil, err
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(nm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, nm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+func addFlakeInputsToGraph(rootId string, lock *buildutils.FlakeLock, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) error {
+	getIds := func(keys []string) (ids []string) {
+		for _, key := range keys {
+			if input, ok := lock.Inputs[key]; ok {
+				ids = append(ids, input.Id())
+			}
+		}
+		return
+	}
+	dependenciesGraph[rootId] = append(dependenciesGraph[rootId], getIds(lock.RootInputs)...)
+	for _, input := range lock.Inputs {
+		dependency := entities.Dependency{Id: input.Id(), Type: "flake"}
+		setDependencyProperties(&dependency, map[string]string{NixUrlProperty: input.Url, NixNarHashProperty: input.NarHash})
+		if input.NarHash != "" {
+			sha256, err := buildutils.NarHashToSha256(input.NarHash)
+			if err != nil {
+				return err
+			}
+			dependency.Sha256 = sha256
+		}
+		dependenciesMap[input.Id()] = dependency
+		dependenciesGraph[input.Id()] = getIds(input.Inputs)
+	}
+	return nil
+}
+
+// Adds the store paths in the closures of the installables to the dependencies map and graph.
+// The top-level store paths (which aren't referenced by other paths in the closure) are the outputs of the installables, so only the paths they reference are added as direct dependencies.
+func addNixStorePathsToGraph(rootId string, storePaths []buildutils.NixStorePath, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) error {
+	ids := make(map[string]string)
+	referenced := make(map[string]bool)
+	for _, storePath := range storePaths {
+		ids[storePath.Path] = storePath.Id()
+		for _, reference := range storePath.References {
+			if reference != storePath.Path {
+				referenced[reference] = true
+			}
+		}
+	}
+	for _, storePath := range storePaths {
+		var referencesIds []string
+		for _, reference := range storePath.References {
+			if id, ok := ids[reference]; ok && reference != storePath.Path {
+				referencesIds = append(referencesIds, id)
+			}
+		}
+		if !referenced[storePath.Path] {
+			for _, id := range referencesIds {
+				// The outputs of several installables may reference the same paths.
+				if !slices.Contains(dependenciesGraph[rootId], id) {
+					dependenciesGraph[rootId] = append(dependenciesGraph[rootId], id)
+				}
+			}
+			continue
+		}
+		dependency := entities.Dependency{Id: storePath.Id(), Type: "nix"}
+		setDependencyProperties(&dependency, map[string]string{NixStorePathProperty: storePath.Path, NixNarHashProperty: storePath.NarHash})
+		if storePath.NarHash != "" {
+			sha256, err := buildutils.NarHashToSha256(storePath.NarHash)
+			if err != nil {
+				return err
+			}
+			dependency.Sha256 = sha256
+		}
+		dependenciesMap[dependency.Id] = dependency
+		dependenciesGraph[dependency.Id] = referencesIds
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateBuildInfoForNixFlake(t *testing.T) {
+	service := NewBuildInfoService()
+	nixBuild, err := service.GetOrCreateBuild("build-info-go-test-nix", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, nixBuild.Clean())
+	}()
+	nixModule, err := nixBuild.AddNixModule(filepath.Join("testdata", "nix", "flake"))
+	if assert.NoError(t, err) {
+		err = nixModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := nixBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Nix, module.Type)
+		assert.Equal(t, "flake", module.Id)
+
+		expectedRequestedBy := map[string][][]string{
+			"nixpkgs:6df37dc6a77654682fe9f071c62b4242b5342e04":      {{module.Id}, {"home-manager:d5824a76bc6bb93d1dce9ebbbcb09a9b6abcc224", module.Id}},
+			"flake-utils:4022d587cbbfd70fe950c1e2083a02621806a725":  {{module.Id}},
+			"home-manager:d5824a76bc6bb93d1dce9ebbbcb09a9b6abcc224": {{module.Id}},
+			"systems:da67096a3b9bf56a91d16901293e51ba5b49a27e":      {{"flake-utils:4022d587cbbfd70fe950c1e2083a02621806a725", module.Id}},
+		}
+		assert.Len(t, module.Dependencies, len(expectedRequestedBy))
+		for _, dependency := range module.Dependencies {
+			requestedBy, ok := expectedRequestedBy[dependency.Id]
+			if !assert.True(t, ok, "Unexpected dependency "+dependency.Id) {
+				continue
+			}
+			assert.Equal(t, "flake", dependency.Type)
+			assert.ElementsMatch(t, requestedBy, dependency.RequestedBy, dependency.Id)
+			assert.NotEmpty(t, dependency.Sha256)
+			assert.NotEmpty(t, dependency.Properties[NixUrlProperty])
+			assert.NotEmpty(t, dependency.Properties[NixNarHashProperty])
+		}
+	}
+}
+
+func TestAddNixStorePathsToGraph(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("testdata", "nix", "path-info.json"))
+	require.NoError(t, err)
+	storePaths, err := buildutils.ParseNixPathInfo(output)
+	require.NoError(t, err)
+
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	assert.NoError(t, addNixStorePathsToGraph("module", storePaths, dependenciesMap, dependenciesGraph))
+	populateRequestedByField("module", [][]string{{}}, dependenciesMap, dependenciesGraph, entities.DefaultRequestedByLimits)
+
+	// The output of the installable isn't a dependency of itself.
+	assert.Len(t, dependenciesMap, 2)
+	glibc := dependenciesMap["glibc:2.38-44"]
+	assert.Equal(t, "nix", glibc.Type)
+	assert.Equal(t, [][]string{{"module"}}, glibc.RequestedBy)
+	assert.Equal(t, "1b11757d33424678ba2d9d6fbd9eb6293a7bfa196dca15a29af179af778c235d", glibc.Sha256)
+	assert.Equal(t, "/nix/store/ddwyrxif62r8n6xclvskjyy6szdhvj60-glibc-2.38-44", glibc.Properties[NixStorePathProperty])
+	libgcc := dependenciesMap["xgcc:12.3.0-libgcc"]
+	assert.Equal(t, [][]string{{"glibc:2.38-44", "module"}}, libgcc.RequestedBy)
+	assert.Equal(t, "572d6bab901a46e2f33b172df27cf84fac25832511ef32d4df4f64f66042efaf", libgcc.Sha256)
+}
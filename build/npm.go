@@ -2,7 +2,9 @@ package build
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"time"
 
 	buildutils "github.com/jfrog/build-info-go/build/utils"
 	"github.com/jfrog/build-info-go/entities"
@@ -11,24 +13,29 @@ import (
 
 const minSupportedNpmVersion = "5.4.0"
 
+// The package managers which are supported by the npm module, in addition to npm.
+const (
+	pnpmPackageManager = "pnpm"
+	bunPackageManager  = "bun"
+)
+
 type NpmModule struct {
 	containingBuild *Build
 	name            string
+	properties      map[string]string
 	srcPath         string
 	executablePath  string
 	npmArgs         []string
+	// The package manager of the project, if it isn't managed by npm.
+	packageManager string
+	// If true, the licenses of the dependencies are read from their package.json files in the node_modules directory.
+	collectLicenses bool
 }
 
 // Pass an empty string for srcPath to find the npm project in the working directory.
+// If the project is managed by pnpm (the nearest lock file in its directory or in the root of its workspace is pnpm-lock.yaml), pnpm is used instead of npm.
+// If the project is managed by Bun (the nearest lock file is bun.lock), the dependencies are collected from the bun.lock file.
 func newNpmModule(srcPath string, containingBuild *Build) (*NpmModule, error) {
-	npmVersion, executablePath, err := buildutils.GetNpmVersionAndExecPath(containingBuild.logger)
-	if err != nil {
-		return nil, err
-	}
-	if npmVersion.Compare(minSupportedNpmVersion) > 0 {
-		return nil, errors.New("npm CLI must have version " + minSupportedNpmVersion + " or higher. The current version is: " + npmVersion.GetVersion())
-	}
-
 	if srcPath == "" {
 		wd, err := os.Getwd()
 		if err != nil {
@@ -40,6 +47,31 @@ func newNpmModule(srcPath string, containingBuild *Build) (*NpmModule, error) {
 		}
 	}
 
+	pnpmLockDir, err := buildutils.FindPnpmLockDir(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if pnpmLockDir != "" {
+		containingBuild.logger.Debug(fmt.Sprintf("Found %s in %s. Using pnpm to collect the project's dependencies.", buildutils.PnpmLockFileName, pnpmLockDir))
+		executablePath, err := buildutils.GetPnpmExecPath(containingBuild.logger)
+		if err != nil {
+			return nil, err
+		}
+		return newNpmModuleWithPackageManager(srcPath, containingBuild, executablePath, pnpmPackageManager)
+	}
+	if bunLockDir, bunLockFileName := buildutils.FindBunLockDir(srcPath); bunLockDir != "" {
+		containingBuild.logger.Debug(fmt.Sprintf("Found %s in %s. Collecting the project's dependencies from the Bun lock file.", bunLockFileName, bunLockDir))
+		return newNpmModuleWithPackageManager(srcPath, containingBuild, "", bunPackageManager)
+	}
+
+	npmVersion, executablePath, err := buildutils.GetNpmVersionAndExecPath(containingBuild.logger)
+	if err != nil {
+		return nil, err
+	}
+	if npmVersion.Compare(minSupportedNpmVersion) > 0 {
+		return nil, errors.New("npm CLI must have version " + minSupportedNpmVersion + " or higher. The current version is: " + npmVersion.GetVersion())
+	}
+
 	// Read module name
 	packageInfo, err := buildutils.ReadPackageInfoFromPackageJson(srcPath, npmVersion)
 	if err != nil {
@@ -50,16 +82,42 @@ func newNpmModule(srcPath string, containingBuild *Build) (*NpmModule, error) {
 	return &NpmModule{name: name, srcPath: srcPath, containingBuild: containingBuild, executablePath: executablePath}, nil
 }
 
+func newNpmModuleWithPackageManager(srcPath string, containingBuild *Build, executablePath, packageManager string) (*NpmModule, error) {
+	// Read module name
+	packageInfo, err := buildutils.ReadPackageInfoFromPackageJson(srcPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NpmModule{name: packageInfo.BuildInfoModuleId(), srcPath: srcPath, containingBuild: containingBuild, executablePath: executablePath, packageManager: packageManager}, nil
+}
+
 func (nm *NpmModule) CalcDependencies() error {
+	started := time.Now()
 	if !nm.containingBuild.buildNameAndNumberProvided() {
 		return errors.New("a build name must be provided in order to collect the project's dependencies")
 	}
-	buildInfoDependencies, err := buildutils.CalculateNpmDependenciesList(nm.executablePath, nm.srcPath, nm.name, nm.npmArgs, true, nm.containingBuild.logger)
+	var buildInfoDependencies []entities.Dependency
+	var err error
+	switch nm.packageManager {
+	case pnpmPackageManager:
+		buildInfoDependencies, err = buildutils.CalculatePnpmDependenciesList(nm.executablePath, nm.srcPath, nm.name, nm.npmArgs, nm.containingBuild.logger)
+	case bunPackageManager:
+		buildInfoDependencies, err = buildutils.CalculateBunDependenciesList(nm.srcPath, nm.name, nm.containingBuild.logger)
+	default:
+		buildInfoDependencies, err = buildutils.CalculateNpmDependenciesList(nm.executablePath, nm.srcPath, nm.name, nm.npmArgs, true, nm.containingBuild.logger)
+	}
 	if err != nil {
 		return err
 	}
+	if nm.collectLicenses {
+		if err = nm.updateDependenciesLicenses(buildInfoDependencies); err != nil {
+			return err
+		}
+	}
 	buildInfoModule := entities.Module{Id: nm.name, Type: entities.Npm, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(nm.properties)
 	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
 	return nm.containingBuild.SaveBuildInfo(buildInfo)
 }
 
@@ -67,10 +125,31 @@ func (nm *NpmModule) SetName(name string) {
 	nm.name = name
 }
 
+func (nm *NpmModule) SetProperties(properties map[string]string) {
+	nm.properties = properties
+}
+
 func (nm *NpmModule) SetNpmArgs(npmArgs []string) {
 	nm.npmArgs = npmArgs
 }
 
+// SetCollectLicenses sets whether to read the licenses of the dependencies from their package.json files ('license' or the deprecated 'licenses' fields).
+// The packages must be installed in the node_modules directory of the project. Licenses, which aren't valid SPDX license expressions, are not collected.
+func (nm *NpmModule) SetCollectLicenses(collectLicenses bool) {
+	nm.collectLicenses = collectLicenses
+}
+
+func (nm *NpmModule) updateDependenciesLicenses(dependencies []entities.Dependency) error {
+	licenses, err := buildutils.GetNodeModulesLicenses(nm.srcPath)
+	if err != nil {
+		return err
+	}
+	for i := range dependencies {
+		dependencies[i].Licenses = licenses[dependencies[i].Id]
+	}
+	return nil
+}
+
 func (nm *NpmModule) AddArtifacts(artifacts ...entities.Artifact) error {
 	if !nm.containingBuild.buildNameAndNumberProvided() {
 		return errors.New("a build name must be provided in order to add artifacts")
@@ -0,0 +1,184 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency property, which holds the URL of the source archive (or repository) of a package.
+	OpamUrlProperty = "opam.url"
+)
+
+type OpamModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	switchPrefix    string
+}
+
+// Pass an empty string for srcPath if the root of the opam project is the working directory.
+func newOpamModule(srcPath string, containingBuild *Build) (*OpamModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	projectPackages, err := buildutils.GetOpamProjectPackages(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(srcPath)
+	if len(projectPackages) == 1 {
+		for _, pkg := range projectPackages {
+			name = pkg.Name
+			if pkg.Version != "" {
+				name += ":" + pkg.Version
+			}
+		}
+	} else {
+		containingBuild.logger.Debug(fmt.Sprintf("The project doesn't define a single opam package. Using the directory name: %s as module name.", name))
+	}
+
+	return &OpamModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages installed in the opam switch of the project.
+func (om *OpamModule) CalcDependencies() error {
+	started := time.Now()
+	if !om.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := om.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: om.name, Type: entities.Opam, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(om.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return om.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (om *OpamModule) SetName(name string) {
+	om.name = name
+}
+
+func (om *OpamModule) SetProperties(properties map[string]string) {
+	om.properties = properties
+}
+
+// SetSwitchPrefix sets the prefix of the opam switch, in which the dependencies were installed (for example: '~/.opam/default').
+// By default, the local switch of the project is used, or (if it doesn't exist) the current switch.
+func (om *OpamModule) SetSwitchPrefix(switchPrefix string) {
+	om.switchPrefix = switchPrefix
+}
+
+func (om *OpamModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !om.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: om.name, ModuleType: entities.Opam, Artifacts: artifacts}
+	return om.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (om *OpamModule) loadDependencies() ([]entities.Dependency, error) {
+	switchPrefix := om.switchPrefix
+	if switchPrefix == "" {
+		var err error
+		if switchPrefix, err = buildutils.GetOpamSwitchPrefix(om.srcPath); err != nil {
+			return nil, err
+		}
+	}
+	packages, err := buildutils.ReadOpamSwitch(switchPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the opam switch %s: %s", switchPrefix, err.Error())
+	}
+	projectPackages, err := buildutils.GetOpamProjectPackages(om.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	idsByName := make(map[string]string)
+	for _, pkg := range packages {
+		// The packages of the project itself are installed in its local switch, if it was created without '--deps-only'.
+		if _, isProjectPackage := projectPackages[pkg.Name]; !isProjectPackage {
+			dependenciesMap[pkg.Id()] = createOpamDependency(pkg)
+			idsByName[pkg.Name] = pkg.Id()
+		}
+	}
+	requested := make(map[string]bool)
+	for _, pkg := range packages {
+		for _, childId := range resolveOpamDependencies(pkg.Dependencies, idsByName) {
+			if childId != pkg.Id() {
+				dependenciesGraph[pkg.Id()] = append(dependenciesGraph[pkg.Id()], childId)
+				requested[childId] = true
+			}
+		}
+	}
+	// The direct dependencies are the dependencies of the packages of the project, or (if the project has no opam files) the packages installed explicitly.
+	if len(projectPackages) > 0 {
+		for _, projectPackage := range projectPackages {
+			for _, id := range resolveOpamDependencies(projectPackage.Dependencies, idsByName) {
+				if !slices.Contains(dependenciesGraph[om.name], id) {
+					dependenciesGraph[om.name] = append(dependenciesGraph[om.name], id)
+				}
+			}
+		}
+	} else {
+		for _, pkg := range packages {
+			if pkg.Root {
+				dependenciesGraph[om.name] = append(dependenciesGraph[om.name], pkg.Id())
+			}
+		}
+	}
+	// Packages, which aren't required by other packages, are considered as direct dependencies too.
+	for _, pkg := range packages {
+		if _, exists := dependenciesMap[pkg.Id()]; exists && !requested[pkg.Id()] && !slices.Contains(dependenciesGraph[om.name], pkg.Id()) {
+			dependenciesGraph[om.name] = append(dependenciesGraph[om.name], pkg.Id())
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(om.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, om.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Returns the IDs of the installed packages with the given names, without duplicates.
+// Alternatives and optional dependencies, which aren't installed in the switch, are skipped.
+func resolveOpamDependencies(names []string, idsByName map[string]string) []string {
+	var ids []string
+	for _, name := range names {
+		if id, exists := idsByName[name]; exists && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Creates the build-info dependency of an opam package. Its checksums are the checksums of its source archive, as listed in its opam file.
+// The sha1 checksum of packages pinned to a commit of a git repository (for example: 'git+https://github.com/org/repo.git#<commit>') is the commit.
+func createOpamDependency(pkg *buildutils.OpamPackage) entities.Dependency {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: "opam"}
+	dependency.Checksum = entities.Checksum{Md5: pkg.Checksums["md5"], Sha256: pkg.Checksums["sha256"]}
+	if _, fragment, found := strings.Cut(pkg.SourceUrl, "#"); found && buildutils.IsGitCommit(fragment) {
+		dependency.Sha1 = fragment
+	}
+	setDependencyProperties(&dependency, map[string]string{OpamUrlProperty: pkg.SourceUrl})
+	return dependency
+}
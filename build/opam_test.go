@@ -0,0 +1,71 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForOpamProject(t *testing.T) {
+	service := NewBuildInfoService()
+	opamBuild, err := service.GetOrCreateBuild("build-info-go-test-opam", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, opamBuild.Clean())
+	}()
+	// The project has a local switch in its _opam directory.
+	opamModule, err := opamBuild.AddOpamModule(filepath.Join("testdata", "opam", "project"))
+	if assert.NoError(t, err) {
+		err = opamModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := opamBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Opam, module.Type)
+		assert.Equal(t, "hello:0.1.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 9)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "opam", dependency.Type)
+			switch dependency.Id {
+			case "alcotest:1.7.0":
+				assert.Equal(t, entities.Checksum{Sha256: "812bacdb34b45e88995e07d7306bdab2f72479ef1996637f1d5d1f41667902df"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{OpamUrlProperty: "https://github.com/mirage/alcotest/releases/download/1.7.0/alcotest-1.7.0.tbz"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "astring:0.8.5":
+				assert.Equal(t, entities.Checksum{Md5: "e148907c24157d1df43bec89b58b3ec8"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{"alcotest:1.7.0", module.Id}}, dependency.RequestedBy)
+			case "base-threads:base":
+				// A virtual package, without a source archive.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Nil(t, dependency.Properties)
+				assert.ElementsMatch(t, [][]string{{"dune:3.11.1", module.Id}, {"dune:3.11.1", "alcotest:1.7.0", module.Id}, {"dune:3.11.1", "mylib:dev", module.Id}}, dependency.RequestedBy)
+			case "cmdliner:1.2.0":
+				// Only a sha512 checksum is listed.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.ElementsMatch(t, [][]string{{module.Id}, {"alcotest:1.7.0", module.Id}}, dependency.RequestedBy)
+			case "dune:3.11.1":
+				assert.Equal(t, entities.Checksum{Md5: "c0a5d7d2b5e7f9b1f7a43c5e4bb4cd7d", Sha256: "866f2307adadaf7604f3bf9d98bb4098792baa046953a6726c96c40fc5ed3f71"}, dependency.Checksum)
+				assert.ElementsMatch(t, [][]string{{module.Id}, {"alcotest:1.7.0", module.Id}, {"mylib:dev", module.Id}}, dependency.RequestedBy)
+			case "mylib:dev":
+				// Pinned to a commit of a git repository, and not required by the project.
+				assert.Equal(t, entities.Checksum{Sha1: "0123456789abcdef0123456789abcdef01234567"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "ocaml:5.1.0":
+				assert.Contains(t, dependency.RequestedBy, []string{module.Id})
+			case "ocaml-base-compiler:5.1.0":
+				// Its 'post' dependency on ocaml doesn't create a loop.
+				assert.Contains(t, dependency.RequestedBy, []string{"ocaml:5.1.0", module.Id})
+				assert.Contains(t, dependency.RequestedBy, []string{"ocaml-config:3", "ocaml:5.1.0", module.Id})
+				assert.False(t, dependency.NodeHasLoop())
+			case "ocaml-config:3":
+				assert.Contains(t, dependency.RequestedBy, []string{"ocaml:5.1.0", module.Id})
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package build
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// BuildId identifies the build, to which partials belong.
+type BuildId struct {
+	Name       string
+	Number     string
+	ProjectKey string
+}
+
+// PartialsStore stores the partial build-infos of builds, until their build-infos are created.
+// By default, the partials are stored as files in the builds directory (see FileSystemPartialsStore). Long-running agents, which collect many partials, may store them in memory, or in an SQLite database, instead.
+type PartialsStore interface {
+	// SavePartial saves a partial of the build.
+	SavePartial(buildId BuildId, partial *entities.Partial) error
+	// LoadPartials returns the partials of the build, in any order.
+	LoadPartials(buildId BuildId) (entities.Partials, error)
+	// RemovePartials removes the partials of the build, when the build is cleaned.
+	RemovePartials(buildId BuildId) error
+}
+
+// FileSystemPartialsStore stores each partial as a JSON file in the partials directory of the build, in the builds directory.
+// The partials of a build can be saved by several processes, which share the builds directory.
+type FileSystemPartialsStore struct {
+	buildsDirPath string
+	logger        utils.Log
+}
+
+func NewFileSystemPartialsStore(buildsDirPath string, logger utils.Log) *FileSystemPartialsStore {
+	return &FileSystemPartialsStore{buildsDirPath: buildsDirPath, logger: logger}
+}
+
+func (fps *FileSystemPartialsStore) SavePartial(buildId BuildId, partial *entities.Partial) (err error) {
+	partialJson, err := json.Marshal(&partial)
+	if err != nil {
+		return
+	}
+	var content bytes.Buffer
+	err = json.Indent(&content, partialJson, "", "  ")
+	if err != nil {
+		return
+	}
+	dirPath, err := utils.GetPartialsBuildDir(buildId.Name, buildId.Number, buildId.ProjectKey, fps.buildsDirPath)
+	if err != nil {
+		return
+	}
+	fps.logger.Debug("Creating temp build file at:", dirPath)
+	tempFile, err := os.CreateTemp(dirPath, "temp")
+	if err != nil {
+		return
+	}
+	defer func() {
+		e := tempFile.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	_, err = tempFile.Write(content.Bytes())
+	return
+}
+
+func (fps *FileSystemPartialsStore) LoadPartials(buildId BuildId) (entities.Partials, error) {
+	var partials entities.Partials
+	partialsBuildDir, err := utils.GetPartialsBuildDir(buildId.Name, buildId.Number, buildId.ProjectKey, fps.buildsDirPath)
+	if err != nil {
+		return nil, err
+	}
+	buildFiles, err := utils.ListFiles(partialsBuildDir, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, buildFile := range buildFiles {
+		dir, err := utils.IsDirExists(buildFile, true)
+		if err != nil {
+			return nil, err
+		}
+		if dir || strings.HasSuffix(buildFile, BuildInfoDetails) {
+			continue
+		}
+		content, err := os.ReadFile(buildFile)
+		if err != nil {
+			return nil, err
+		}
+		partial := new(entities.Partial)
+		err = json.Unmarshal(content, &partial)
+		if err != nil {
+			return nil, err
+		}
+		partials = append(partials, partial)
+	}
+
+	return partials, nil
+}
+
+// RemovePartials removes the partial files of the build. The general details of the build, which are saved in the same directory, are kept.
+func (fps *FileSystemPartialsStore) RemovePartials(buildId BuildId) error {
+	partialsBuildDir, err := utils.GetPartialsBuildDir(buildId.Name, buildId.Number, buildId.ProjectKey, fps.buildsDirPath)
+	if err != nil {
+		return err
+	}
+	buildFiles, err := utils.ListFiles(partialsBuildDir, false)
+	if err != nil {
+		return err
+	}
+	for _, buildFile := range buildFiles {
+		if strings.HasSuffix(buildFile, BuildInfoDetails) {
+			continue
+		}
+		if err = os.Remove(buildFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MemoryPartialsStore stores the partials in memory, so they're available only to the process, which saved them.
+// It's safe for concurrent use.
+type MemoryPartialsStore struct {
+	mutex    sync.Mutex
+	partials map[BuildId]entities.Partials
+}
+
+func NewMemoryPartialsStore() *MemoryPartialsStore {
+	return &MemoryPartialsStore{partials: make(map[BuildId]entities.Partials)}
+}
+
+func (mps *MemoryPartialsStore) SavePartial(buildId BuildId, partial *entities.Partial) error {
+	// The partial is deep-copied (including its artifacts, dependencies and maps), so changing it after it's saved doesn't change the stored partial.
+	content, err := json.Marshal(partial)
+	if err != nil {
+		return err
+	}
+	storedPartial := new(entities.Partial)
+	if err = json.Unmarshal(content, storedPartial); err != nil {
+		return err
+	}
+	mps.mutex.Lock()
+	defer mps.mutex.Unlock()
+	mps.partials[buildId] = append(mps.partials[buildId], storedPartial)
+	return nil
+}
+
+func (mps *MemoryPartialsStore) LoadPartials(buildId BuildId) (entities.Partials, error) {
+	mps.mutex.Lock()
+	defer mps.mutex.Unlock()
+	return append(entities.Partials{}, mps.partials[buildId]...), nil
+}
+
+func (mps *MemoryPartialsStore) RemovePartials(buildId BuildId) error {
+	mps.mutex.Lock()
+	defer mps.mutex.Unlock()
+	delete(mps.partials, buildId)
+	return nil
+}
+
+// SqlPartialsStore stores the partials as JSON in the build_info_partials table of an SQLite database, which is opened with any database/sql SQLite driver
+// (for example, modernc.org/sqlite or github.com/mattn/go-sqlite3). The database can be shared by several processes, which collect the partials of the same builds.
+type SqlPartialsStore struct {
+	db *sql.DB
+}
+
+// NewSqlPartialsStore creates the build_info_partials table in the database, if it doesn't exist.
+func NewSqlPartialsStore(db *sql.DB) (*SqlPartialsStore, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS build_info_partials (
+	build_name TEXT NOT NULL,
+	build_number TEXT NOT NULL,
+	project_key TEXT NOT NULL,
+	content TEXT NOT NULL
+)`)
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS build_info_partials_build ON build_info_partials (build_name, build_number, project_key)`)
+	if err != nil {
+		return nil, err
+	}
+	return &SqlPartialsStore{db: db}, nil
+}
+
+func (sps *SqlPartialsStore) SavePartial(buildId BuildId, partial *entities.Partial) error {
+	content, err := json.Marshal(partial)
+	if err != nil {
+		return err
+	}
+	_, err = sps.db.Exec(`INSERT INTO build_info_partials (build_name, build_number, project_key, content) VALUES (?, ?, ?, ?)`,
+		buildId.Name, buildId.Number, buildId.ProjectKey, string(content))
+	return err
+}
+
+func (sps *SqlPartialsStore) LoadPartials(buildId BuildId) (partials entities.Partials, err error) {
+	rows, err := sps.db.Query(`SELECT content FROM build_info_partials WHERE build_name = ? AND build_number = ? AND project_key = ?`,
+		buildId.Name, buildId.Number, buildId.ProjectKey)
+	if err != nil {
+		return
+	}
+	defer func() {
+		e := rows.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	for rows.Next() {
+		var content string
+		if err = rows.Scan(&content); err != nil {
+			return
+		}
+		partial := new(entities.Partial)
+		if err = json.Unmarshal([]byte(content), partial); err != nil {
+			return
+		}
+		partials = append(partials, partial)
+	}
+	err = rows.Err()
+	return
+}
+
+func (sps *SqlPartialsStore) RemovePartials(buildId BuildId) error {
+	_, err := sps.db.Exec(`DELETE FROM build_info_partials WHERE build_name = ? AND build_number = ? AND project_key = ?`,
+		buildId.Name, buildId.Number, buildId.ProjectKey)
+	return err
+}
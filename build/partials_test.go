@@ -0,0 +1,257 @@
+package build
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryPartialsStore(t *testing.T) {
+	store := NewMemoryPartialsStore()
+	service := NewBuildInfoService()
+	service.SetPartialsStore(store)
+	build, err := service.GetOrCreateBuild("bi-memory-partials-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	partial := &entities.Partial{
+		ModuleId:         "my-module",
+		ModuleType:       entities.Generic,
+		Artifacts:        []entities.Artifact{{Name: "a.zip"}},
+		Dependencies:     []entities.Dependency{{Id: "dep:1.0.0"}},
+		ModuleProperties: map[string]string{"team": "platform"},
+	}
+	assert.NoError(t, build.SavePartialBuildInfo(partial))
+	// Changing the partial after it's saved, including its slices and maps, doesn't change the stored partial.
+	partial.ModuleId = "changed"
+	partial.Artifacts[0].Name = "changed.zip"
+	partial.Dependencies[0].Id = "changed:1.0.0"
+	partial.ModuleProperties["team"] = "changed"
+	storedPartials, err := store.LoadPartials(build.getBuildId())
+	assert.NoError(t, err)
+	if assert.Len(t, storedPartials, 1) {
+		assert.Equal(t, "my-module", storedPartials[0].ModuleId)
+		assert.Equal(t, "a.zip", storedPartials[0].Artifacts[0].Name)
+		assert.Equal(t, "dep:1.0.0", storedPartials[0].Dependencies[0].Id)
+		assert.Equal(t, map[string]string{"team": "platform"}, storedPartials[0].ModuleProperties)
+	}
+
+	// The partials aren't saved in the builds directory.
+	partials, err := NewFileSystemPartialsStore(build.tempDirPath, &utils.NullLog{}).LoadPartials(build.getBuildId())
+	assert.NoError(t, err)
+	assert.Empty(t, partials)
+
+	buildInfo, err := build.ToBuildInfo()
+	assert.NoError(t, err)
+	if assert.Len(t, buildInfo.Modules, 1) {
+		assert.Equal(t, "my-module", buildInfo.Modules[0].Id)
+		assert.Equal(t, "a.zip", buildInfo.Modules[0].Artifacts[0].Name)
+	}
+
+	assert.NoError(t, build.Clean())
+	partials, err = store.LoadPartials(build.getBuildId())
+	assert.NoError(t, err)
+	assert.Empty(t, partials)
+}
+
+func TestFileSystemPartialsStore(t *testing.T) {
+	service := NewBuildInfoService()
+	service.SetTempDirPath(t.TempDir())
+	build, err := service.GetOrCreateBuild("bi-file-system-partials-test", "1")
+	assert.NoError(t, err)
+	store := NewFileSystemPartialsStore(build.tempDirPath, &utils.NullLog{})
+	otherBuildId := BuildId{Name: "bi-file-system-partials-test", Number: "2"}
+	assert.NoError(t, store.SavePartial(build.getBuildId(), &entities.Partial{ModuleId: "my-module", ModuleType: entities.Generic, Artifacts: []entities.Artifact{{Name: "a.zip"}}}))
+	assert.NoError(t, store.SavePartial(otherBuildId, &entities.Partial{Env: entities.Env{"key": "value"}}))
+
+	partials, err := store.LoadPartials(build.getBuildId())
+	assert.NoError(t, err)
+	assert.Len(t, partials, 1)
+	assert.Equal(t, "my-module", partials[0].ModuleId)
+
+	assert.NoError(t, store.RemovePartials(build.getBuildId()))
+	partials, err = store.LoadPartials(build.getBuildId())
+	assert.NoError(t, err)
+	assert.Empty(t, partials)
+	partials, err = store.LoadPartials(otherBuildId)
+	assert.NoError(t, err)
+	assert.Len(t, partials, 1)
+	// The general details of the build are kept, so its build-info can still be created.
+	_, err = build.ToBuildInfo()
+	assert.NoError(t, err)
+}
+
+func TestSqlPartialsStore(t *testing.T) {
+	db, err := sql.Open(partialsTestDriverName, t.Name())
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, db.Close())
+	}()
+	store, err := NewSqlPartialsStore(db)
+	assert.NoError(t, err)
+	// Creating the store again, for example by another process, keeps the existing table.
+	_, err = NewSqlPartialsStore(db)
+	assert.NoError(t, err)
+
+	service := NewBuildInfoService()
+	service.SetPartialsStore(store)
+	build, err := service.GetOrCreateBuild("bi-sql-partials-test", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.Clean())
+	}()
+	otherBuildId := BuildId{Name: "bi-sql-partials-test", Number: "2", ProjectKey: "proj"}
+	assert.NoError(t, build.SavePartialBuildInfo(&entities.Partial{ModuleId: "my-module", ModuleType: entities.Generic, Artifacts: []entities.Artifact{{Name: "a.zip"}}}))
+	assert.NoError(t, build.SavePartialBuildInfo(&entities.Partial{ModuleId: "my-module", ModuleType: entities.Generic, Dependencies: []entities.Dependency{{Id: "dep:1.0.0"}}}))
+	assert.NoError(t, store.SavePartial(otherBuildId, &entities.Partial{Env: entities.Env{"key": "value"}}))
+
+	partials, err := store.LoadPartials(build.getBuildId())
+	assert.NoError(t, err)
+	assert.Len(t, partials, 2)
+	buildInfo, err := build.ToBuildInfo()
+	assert.NoError(t, err)
+	if assert.Len(t, buildInfo.Modules, 1) {
+		assert.Equal(t, "my-module", buildInfo.Modules[0].Id)
+		assert.Len(t, buildInfo.Modules[0].Artifacts, 1)
+		assert.Len(t, buildInfo.Modules[0].Dependencies, 1)
+	}
+
+	// Only the partials of the cleaned build are removed.
+	assert.NoError(t, build.Clean())
+	partials, err = store.LoadPartials(build.getBuildId())
+	assert.NoError(t, err)
+	assert.Empty(t, partials)
+	partials, err = store.LoadPartials(otherBuildId)
+	assert.NoError(t, err)
+	if assert.Len(t, partials, 1) {
+		assert.Equal(t, entities.Env{"key": "value"}, partials[0].Env)
+	}
+}
+
+// A database/sql driver, which keeps the build_info_partials table in memory, and supports only the statements, which SqlPartialsStore runs.
+// The databases are shared by the connections, which are opened with the same name.
+const partialsTestDriverName = "build-info-partials-test"
+
+func init() {
+	sql.Register(partialsTestDriverName, &partialsTestDriver{databases: make(map[string]*partialsTestDatabase)})
+}
+
+type partialsTestDriver struct {
+	mutex     sync.Mutex
+	databases map[string]*partialsTestDatabase
+}
+
+type partialsTestDatabase struct {
+	mutex sync.Mutex
+	// The rows of the table: build_name, build_number, project_key and content.
+	rows [][4]string
+}
+
+func (ptd *partialsTestDriver) Open(name string) (driver.Conn, error) {
+	ptd.mutex.Lock()
+	defer ptd.mutex.Unlock()
+	if ptd.databases[name] == nil {
+		ptd.databases[name] = &partialsTestDatabase{}
+	}
+	return &partialsTestConn{db: ptd.databases[name]}, nil
+}
+
+type partialsTestConn struct {
+	db *partialsTestDatabase
+}
+
+func (ptc *partialsTestConn) Prepare(query string) (driver.Stmt, error) {
+	return &partialsTestStmt{db: ptc.db, query: strings.TrimSpace(query)}, nil
+}
+
+func (ptc *partialsTestConn) Close() error {
+	return nil
+}
+
+func (ptc *partialsTestConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions aren't supported")
+}
+
+type partialsTestStmt struct {
+	db    *partialsTestDatabase
+	query string
+}
+
+func (pts *partialsTestStmt) Close() error {
+	return nil
+}
+
+func (pts *partialsTestStmt) NumInput() int {
+	return strings.Count(pts.query, "?")
+}
+
+func (pts *partialsTestStmt) Exec(args []driver.Value) (driver.Result, error) {
+	pts.db.mutex.Lock()
+	defer pts.db.mutex.Unlock()
+	switch {
+	case strings.HasPrefix(pts.query, "CREATE TABLE IF NOT EXISTS build_info_partials"), strings.HasPrefix(pts.query, "CREATE INDEX IF NOT EXISTS"):
+	case strings.HasPrefix(pts.query, "INSERT INTO build_info_partials"):
+		pts.db.rows = append(pts.db.rows, [4]string{args[0].(string), args[1].(string), args[2].(string), args[3].(string)})
+	case strings.HasPrefix(pts.query, "DELETE FROM build_info_partials"):
+		var kept [][4]string
+		for _, row := range pts.db.rows {
+			if !pts.matches(row, args) {
+				kept = append(kept, row)
+			}
+		}
+		pts.db.rows = kept
+	default:
+		return nil, errors.New("unsupported statement: " + pts.query)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (pts *partialsTestStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !strings.HasPrefix(pts.query, "SELECT content FROM build_info_partials") {
+		return nil, errors.New("unsupported query: " + pts.query)
+	}
+	pts.db.mutex.Lock()
+	defer pts.db.mutex.Unlock()
+	rows := &partialsTestRows{}
+	for _, row := range pts.db.rows {
+		if pts.matches(row, args) {
+			rows.contents = append(rows.contents, row[3])
+		}
+	}
+	return rows, nil
+}
+
+// Returns true if the row belongs to the build, whose name, number and project key are the arguments of the statement.
+func (pts *partialsTestStmt) matches(row [4]string, args []driver.Value) bool {
+	return row[0] == args[0].(string) && row[1] == args[1].(string) && row[2] == args[2].(string)
+}
+
+type partialsTestRows struct {
+	contents []string
+}
+
+func (ptr *partialsTestRows) Columns() []string {
+	return []string{"content"}
+}
+
+func (ptr *partialsTestRows) Close() error {
+	return nil
+}
+
+func (ptr *partialsTestRows) Next(dest []driver.Value) error {
+	if len(ptr.contents) == 0 {
+		return io.EOF
+	}
+	dest[0] = ptr.contents[0]
+	ptr.contents = ptr.contents[1:]
+	return nil
+}
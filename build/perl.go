@@ -0,0 +1,166 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency property, which holds the path of a distribution's archive in CPAN, for example: 'D/DA/DAGOLDEN/Class-Tiny-1.008.tar.gz'.
+	PerlPathnameProperty = "perl.pathname"
+)
+
+type PerlModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the cpanfile.snapshot file in the working directory or in its parents.
+func newPerlModule(srcPath string, containingBuild *Build) (*PerlModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.CpanfileSnapshotFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, version, err := buildutils.GetPerlProjectNameAndVersion(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No META.json file was found. Using the directory name: %s as module name.", name))
+	} else if version != "" {
+		name += ":" + version
+	}
+
+	return &PerlModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the CPAN distributions listed in the cpanfile.snapshot file.
+func (pm *PerlModule) CalcDependencies() error {
+	started := time.Now()
+	if !pm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := pm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: pm.name, Type: entities.Perl, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(pm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return pm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (pm *PerlModule) SetName(name string) {
+	pm.name = name
+}
+
+func (pm *PerlModule) SetProperties(properties map[string]string) {
+	pm.properties = properties
+}
+
+func (pm *PerlModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !pm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: pm.name, ModuleType: entities.Perl, Artifacts: artifacts}
+	return pm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (pm *PerlModule) loadDependencies() ([]entities.Dependency, error) {
+	distributions, err := buildutils.ReadCpanfileSnapshot(pm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'carton install' to create it", buildutils.CpanfileSnapshotFileName, err.Error())
+	}
+	requirements, err := buildutils.ReadCpanfileRequirements(pm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	// The distributions are required through the modules they provide.
+	providers := make(map[string]string)
+	for _, distribution := range distributions {
+		dependency, err := pm.createPerlDependency(distribution)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[dependency.Id] = dependency
+		for module := range distribution.Provides {
+			providers[module] = dependency.Id
+		}
+	}
+	requested := make(map[string]bool)
+	for _, distribution := range distributions {
+		id := distribution.Id()
+		// Modules, which aren't provided by any distribution in the snapshot, are core modules of Perl (or Perl itself).
+		for _, childId := range resolvePerlRequirements(distribution.Requirements, providers) {
+			if childId != id {
+				dependenciesGraph[id] = append(dependenciesGraph[id], childId)
+				requested[childId] = true
+			}
+		}
+	}
+	dependenciesGraph[pm.name] = resolvePerlRequirements(requirements, providers)
+	// Distributions, which aren't required by other distributions, are considered as direct dependencies too.
+	// This covers projects without a cpanfile, and distributions installed without being required by it.
+	for _, distribution := range distributions {
+		if id := distribution.Id(); !requested[id] && !slices.Contains(dependenciesGraph[pm.name], id) {
+			dependenciesGraph[pm.name] = append(dependenciesGraph[pm.name], id)
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(pm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, pm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Returns the IDs of the distributions, which provide the given modules, without duplicates.
+func resolvePerlRequirements(modules []string, providers map[string]string) []string {
+	var ids []string
+	for _, module := range modules {
+		if id, exists := providers[module]; exists && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Creates the build-info dependency of a CPAN distribution. Its checksums are calculated from its archive, if it's found in the cache of the project.
+func (pm *PerlModule) createPerlDependency(distribution buildutils.CpanDistribution) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: distribution.Id(), Type: "cpan"}
+	archivePath, err := buildutils.FindCpanDistributionArchive(pm.srcPath, distribution)
+	if err != nil {
+		return dependency, err
+	}
+	if archivePath != "" {
+		checksum, err := pm.containingBuild.getFileChecksum(archivePath)
+		if err != nil {
+			return dependency, err
+		}
+		dependency.Checksum = checksum
+	}
+	setDependencyProperties(&dependency, map[string]string{PerlPathnameProperty: distribution.Pathname})
+	return dependency, nil
+}
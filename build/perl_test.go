@@ -0,0 +1,54 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForPerlProject(t *testing.T) {
+	service := NewBuildInfoService()
+	perlBuild, err := service.GetOrCreateBuild("build-info-go-test-perl", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, perlBuild.Clean())
+	}()
+	perlModule, err := perlBuild.AddPerlModule(filepath.Join("testdata", "perl", "project"))
+	if assert.NoError(t, err) {
+		err = perlModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := perlBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Perl, module.Type)
+		assert.Equal(t, "Hello-App:1.2.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 6)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "cpan", dependency.Type)
+			switch dependency.Id {
+			case "Moo:2.005005":
+				// The checksums of the archive in the vendor/cache directory.
+				assert.Equal(t, "20f54d248ec53dfd19d69de00822274848ff570f", dependency.Sha1)
+				assert.Equal(t, "40370ff183bd0e660054c3939aae7a00", dependency.Md5)
+				assert.NotEmpty(t, dependency.Sha256)
+				assert.Equal(t, map[string]string{PerlPathnameProperty: "H/HA/HAARG/Moo-2.005005.tar.gz"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "Test-Needs:0.002010":
+				// Required in the test phase.
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "Try-Tiny:0.31":
+				// Not required by the cpanfile nor by other distributions.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "Class-Method-Modifiers:2.15", "Role-Tiny:2.002004", "Sub-Quote:2.006008":
+				assert.Equal(t, [][]string{{"Moo:2.005005", module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// The scope of dev dependencies.
+const pubDevScope = "dev"
+
+// The paths of the outputs of 'flutter build', relative to the root of the project.
+var flutterOutputsPatterns = []string{
+	filepath.Join("build", "app", "outputs", "flutter-apk", "*.apk"),
+	filepath.Join("build", "app", "outputs", "bundle", "*", "*.aab"),
+	filepath.Join("build", "ios", "ipa", "*.ipa"),
+}
+
+// The directory of the web bundle built by 'flutter build web'.
+var flutterWebOutputDir = filepath.Join("build", "web")
+
+type PubModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the Dart project in the working directory.
+func newPubModule(srcPath string, containingBuild *Build) (*PubModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.PubspecFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	pubspec, err := buildutils.ReadPubspec(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := pubspec.Name
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the %s file. Using the directory name: %s as module name.", buildutils.PubspecFileName, name))
+	} else if pubspec.Version != "" {
+		name += ":" + pubspec.Version
+	}
+
+	return &PubModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages listed in the pubspec.lock file.
+// The dependencies of each package are read from its pubspec.yaml file in the pub cache, so 'dart pub get' (or 'flutter pub get') must be run first.
+func (pm *PubModule) CalcDependencies() error {
+	started := time.Now()
+	if !pm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := pm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: pm.name, Type: entities.Pub, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(pm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return pm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (pm *PubModule) SetName(name string) {
+	pm.name = name
+}
+
+func (pm *PubModule) SetProperties(properties map[string]string) {
+	pm.properties = properties
+}
+
+func (pm *PubModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !pm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: pm.name, ModuleType: entities.Pub, Artifacts: artifacts}
+	return pm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// AddFlutterOutputsArtifacts adds the outputs of 'flutter build' as artifacts of the module: the APK, App Bundle and IPA files, and the files of the web bundle.
+func (pm *PubModule) AddFlutterOutputsArtifacts() error {
+	var outputsPaths []string
+	for _, pattern := range flutterOutputsPatterns {
+		matches, err := filepath.Glob(filepath.Join(pm.srcPath, pattern))
+		if err != nil {
+			return err
+		}
+		outputsPaths = append(outputsPaths, matches...)
+	}
+	webOutputDir := filepath.Join(pm.srcPath, flutterWebOutputDir)
+	exists, err := utils.IsDirExists(webOutputDir, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		err = filepath.WalkDir(webOutputDir, func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() {
+				outputsPaths = append(outputsPaths, path)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(outputsPaths) == 0 {
+		return fmt.Errorf("no build outputs were found in %s. Run 'flutter build' first", filepath.Join(pm.srcPath, "build"))
+	}
+	var artifacts []entities.Artifact
+	for _, outputPath := range outputsPaths {
+		checksum, err := pm.containingBuild.getFileChecksum(outputPath)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(pm.srcPath, outputPath)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, entities.Artifact{Name: filepath.Base(outputPath), Type: strings.TrimPrefix(filepath.Ext(outputPath), "."), Path: filepath.ToSlash(relativePath), Checksum: checksum})
+	}
+	return pm.AddArtifacts(artifacts...)
+}
+
+func (pm *PubModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := buildutils.ReadPubspecLock(pm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'dart pub get' to create it", buildutils.PubspecLockFileName, err.Error())
+	}
+	cachePath, err := buildutils.GetPubCachePath()
+	if err != nil {
+		return nil, err
+	}
+	packagesIds := make(map[string]string)
+	for _, pkg := range packages {
+		packagesIds[pkg.Name] = pkg.Id()
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	for _, pkg := range packages {
+		if pkg.Dependency == buildutils.PubDirectMainDependency || pkg.Dependency == buildutils.PubDirectDevDependency {
+			dependenciesGraph[pm.name] = append(dependenciesGraph[pm.name], pkg.Id())
+		}
+		if packageDir := buildutils.GetPubPackageDir(cachePath, pm.srcPath, pkg); packageDir != "" {
+			pubspec, err := buildutils.ReadPubspec(packageDir)
+			if err != nil {
+				pm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't read the %s file of %s: %s", buildutils.PubspecFileName, pkg.Id(), err.Error()))
+			} else {
+				dependenciesGraph[pkg.Id()] = getPubPackagesIds(packagesIds, pubspec.GetDependenciesNames())
+			}
+		}
+		dependency, err := createPubDependency(cachePath, pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[pkg.Id()] = dependency
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(pm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, pm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+func getPubPackagesIds(packagesIds map[string]string, names []string) []string {
+	var ids []string
+	for _, name := range names {
+		if id, ok := packagesIds[name]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Creates the build-info dependency of a pub package.
+// The sha256 checksum of hosted packages is taken from the pubspec.lock file, or from the pub cache for lock files created by older Dart versions.
+// The sha1 checksum of git packages is the resolved commit.
+func createPubDependency(cachePath string, pkg buildutils.PubPackage) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: pkg.Id(), Type: "pub"}
+	if pkg.Dependency == buildutils.PubDirectDevDependency {
+		dependency.Scopes = []string{pubDevScope}
+	}
+	switch pkg.Source {
+	case buildutils.PubHostedSource:
+		dependency.Sha256 = pkg.Description.Sha256
+		if dependency.Sha256 == "" {
+			var err error
+			if dependency.Sha256, err = buildutils.GetPubHostedHash(cachePath, pkg); err != nil {
+				return dependency, err
+			}
+		}
+	case buildutils.PubGitSource:
+		dependency.Sha1 = pkg.Description.ResolvedRef
+	}
+	return dependency, nil
+}
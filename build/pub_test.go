@@ -0,0 +1,70 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForPubProject(t *testing.T) {
+	pubCache, err := filepath.Abs(filepath.Join("testdata", "pub", "pubcache"))
+	assert.NoError(t, err)
+	t.Setenv("PUB_CACHE", pubCache)
+
+	service := NewBuildInfoService()
+	pubBuild, err := service.GetOrCreateBuild("build-info-go-test-pub", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, pubBuild.Clean())
+	}()
+	pubModule, err := pubBuild.AddPubModule(filepath.Join("testdata", "pub", "project"))
+	if assert.NoError(t, err) {
+		err = pubModule.CalcDependencies()
+		assert.NoError(t, err)
+		err = pubModule.AddFlutterOutputsArtifacts()
+		assert.NoError(t, err)
+		buildInfo, err := pubBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Pub, module.Type)
+		assert.Equal(t, "hello_app:1.0.0+1", module.Id)
+
+		var artifactsPaths []string
+		for _, artifact := range module.Artifacts {
+			artifactsPaths = append(artifactsPaths, artifact.Path)
+		}
+		assert.ElementsMatch(t, []string{"build/app/outputs/flutter-apk/app-release.apk", "build/web/main.dart.js", "build/web/assets/AssetManifest.json"}, artifactsPaths)
+
+		expectedRequestedBy := map[string][][]string{
+			"flutter:0.0.0":  {{module.Id}},
+			"http:1.1.0":     {{module.Id}},
+			"my_utils:0.2.0": {{module.Id}},
+			"lints:2.1.1":    {{module.Id}},
+			"async:2.11.0":   {{"http:1.1.0", module.Id}},
+			"meta:1.9.1":     {{"async:2.11.0", "http:1.1.0", module.Id}, {"http:1.1.0", module.Id}, {"my_utils:0.2.0", module.Id}},
+		}
+		assert.Len(t, module.Dependencies, len(expectedRequestedBy))
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "pub", dependency.Type)
+			assert.ElementsMatch(t, expectedRequestedBy[dependency.Id], dependency.RequestedBy, dependency.Id)
+			switch dependency.Id {
+			case "flutter:0.0.0":
+				// SDK packages have no checksums.
+				assert.True(t, dependency.Checksum.IsEmpty())
+			case "my_utils:0.2.0":
+				// The resolved commit of the git package.
+				assert.Equal(t, "4f2a6b0c2d1e9f8a7b6c5d4e3f2a1b0c9d8e7f6a", dependency.Sha1)
+			case "lints:2.1.1":
+				// Taken from the pub cache, because the pubspec.lock file doesn't list it.
+				assert.Equal(t, "0cd7215de606e986e1f78fb395ca27ea1c6ea8ed85f9d2d7b3e4d4a91e6d3a9f", dependency.Sha256)
+				assert.Equal(t, []string{pubDevScope}, dependency.Scopes)
+			default:
+				assert.NotEmpty(t, dependency.Sha256)
+				assert.Empty(t, dependency.Scopes)
+			}
+		}
+	}
+}
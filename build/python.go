@@ -3,6 +3,7 @@ package build
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/build-info-go/utils/pythonutils"
@@ -12,9 +13,12 @@ type PythonModule struct {
 	containingBuild            *Build
 	tool                       pythonutils.PythonTool
 	name                       string
+	properties                 map[string]string
 	srcPath                    string
 	localDependenciesPath      string
 	updateDepsChecksumInfoFunc func(dependenciesMap map[string]entities.Dependency, srcPath string) error
+	// If true, the licenses of the dependencies are read from the metadata of the installed distributions.
+	collectLicenses bool
 }
 
 func newPythonModule(srcPath string, tool pythonutils.PythonTool, containingBuild *Build) (*PythonModule, error) {
@@ -29,6 +33,7 @@ func newPythonModule(srcPath string, tool pythonutils.PythonTool, containingBuil
 }
 
 func (pm *PythonModule) RunInstallAndCollectDependencies(commandArgs []string) error {
+	started := time.Now()
 	dependenciesMap, err := pythonutils.GetPythonDependenciesFiles(pm.tool, commandArgs, pm.containingBuild.logger, pm.srcPath)
 	if err != nil {
 		return err
@@ -57,9 +62,16 @@ func (pm *PythonModule) RunInstallAndCollectDependencies(commandArgs []string) e
 			return err
 		}
 	}
+	if pm.collectLicenses {
+		if err = pythonutils.UpdateDepsLicenses(pm.tool, pm.srcPath, dependenciesMap); err != nil {
+			return fmt.Errorf("failed while attempting to get %s dependencies licenses: %s", pm.tool, err.Error())
+		}
+	}
 	pythonutils.UpdateDepsIdsAndRequestedBy(dependenciesMap, dependenciesGraph, topLevelPackagesList, packageName, pm.name)
 	buildInfoModule := entities.Module{Id: pm.name, Type: entities.Python, Dependencies: dependenciesMapToList(dependenciesMap)}
+	buildInfoModule.AddProperties(pm.properties)
 	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
 
 	return pm.containingBuild.SaveBuildInfo(buildInfo)
 }
@@ -74,6 +86,10 @@ func (pm *PythonModule) SetName(name string) {
 	pm.name = name
 }
 
+func (pm *PythonModule) SetProperties(properties map[string]string) {
+	pm.properties = properties
+}
+
 func (pm *PythonModule) SetLocalDependenciesPath(localDependenciesPath string) {
 	pm.localDependenciesPath = localDependenciesPath
 }
@@ -81,3 +97,8 @@ func (pm *PythonModule) SetLocalDependenciesPath(localDependenciesPath string) {
 func (pm *PythonModule) SetUpdateDepsChecksumInfoFunc(updateDepsChecksumInfoFunc func(dependenciesMap map[string]entities.Dependency, srcPath string) error) {
 	pm.updateDepsChecksumInfoFunc = updateDepsChecksumInfoFunc
 }
+
+// SetCollectLicenses sets whether to read the licenses of the dependencies from the METADATA files of the distributions, which are installed in the Python environment of the project.
+func (pm *PythonModule) SetCollectLicenses(collectLicenses bool) {
+	pm.collectLicenses = collectLicenses
+}
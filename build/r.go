@@ -0,0 +1,144 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the hash renv calculates for a package, the URL of the repository it was installed from, and the git repository and commit of packages installed from Bioconductor or from remotes.
+	RenvHashProperty          = "renv.hash"
+	RenvRepositoryUrlProperty = "renv.repositoryUrl"
+	RenvGitUrlProperty        = "renv.gitUrl"
+	RenvGitCommitProperty     = "renv.gitCommit"
+)
+
+type RModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the renv.lock file in the working directory or in its parents.
+func newRModule(srcPath string, containingBuild *Build) (*RModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.RenvLockFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, version, err := buildutils.GetRPackageNameAndVersion(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No package name is defined in a %s file. Using the directory name: %s as module name.", buildutils.RDescriptionFileName, name))
+	} else if version != "" {
+		name += ":" + version
+	}
+
+	return &RModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages listed in the renv.lock file.
+func (rm *RModule) CalcDependencies() error {
+	started := time.Now()
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := rm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: rm.name, Type: entities.R, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(rm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return rm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (rm *RModule) SetName(name string) {
+	rm.name = name
+}
+
+func (rm *RModule) SetProperties(properties map[string]string) {
+	rm.properties = properties
+}
+
+func (rm *RModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: rm.name, ModuleType: entities.R, Artifacts: artifacts}
+	return rm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (rm *RModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := buildutils.ReadRenvLock(rm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file in %s: %s. Run 'renv::snapshot()' to create it", buildutils.RenvLockFileName, rm.srcPath, err.Error())
+	}
+	idsByName := make(map[string]string)
+	for _, pkg := range packages {
+		idsByName[pkg.Name] = pkg.Id()
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	required := make(map[string]bool)
+	for _, pkg := range packages {
+		dependenciesMap[pkg.Id()] = createRDependency(pkg)
+		for _, requirement := range pkg.Requirements {
+			dependenciesGraph[pkg.Id()] = append(dependenciesGraph[pkg.Id()], idsByName[requirement])
+			required[requirement] = true
+		}
+	}
+	// The lock file doesn't specify which packages the project uses directly, so the packages, which aren't required by other packages, are considered as its direct dependencies.
+	for _, pkg := range packages {
+		if !required[pkg.Name] {
+			dependenciesGraph[rm.name] = append(dependenciesGraph[rm.name], pkg.Id())
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(rm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, rm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of an R package.
+// The type of the dependency is the name of the repository, from which the package was installed (for example: 'cran'), or its source (for example: 'bioconductor' or 'github').
+// The sha1 checksum of packages installed from git repositories is their commit.
+func createRDependency(pkg buildutils.RenvPackage) entities.Dependency {
+	dependencyType := strings.ToLower(pkg.Source)
+	if dependencyType == "repository" && pkg.Repository != "" {
+		dependencyType = strings.ToLower(pkg.Repository)
+	}
+	dependency := entities.Dependency{Id: pkg.Id(), Type: dependencyType}
+	if buildutils.IsGitCommit(pkg.GitCommit) {
+		dependency.Sha1 = pkg.GitCommit
+	}
+	setDependencyProperties(&dependency, map[string]string{
+		RenvHashProperty:          pkg.Hash,
+		RenvRepositoryUrlProperty: pkg.RepositoryUrl,
+		RenvGitUrlProperty:        pkg.GitUrl,
+		RenvGitCommitProperty:     pkg.GitCommit,
+	})
+	return dependency
+}
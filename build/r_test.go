@@ -0,0 +1,79 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForRProject(t *testing.T) {
+	service := NewBuildInfoService()
+	rBuild, err := service.GetOrCreateBuild("build-info-go-test-r", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, rBuild.Clean())
+	}()
+	rModule, err := rBuild.AddRModule(filepath.Join("testdata", "r", "project"))
+	if assert.NoError(t, err) {
+		err = rModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := rBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.R, module.Type)
+		// The project isn't a package, so the directory name is used.
+		assert.Equal(t, "project", module.Id)
+
+		assert.Len(t, module.Dependencies, 6)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "BiocGenerics:0.46.0":
+				assert.Equal(t, "bioconductor", dependency.Type)
+				// The commit is abbreviated, so it's not used as the sha1 checksum.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, map[string]string{
+					RenvHashProperty:      "c179e3b1a8b7d82d6c1ad6b2bd6b2a2e",
+					RenvGitUrlProperty:    "https://git.bioconductor.org/packages/BiocGenerics",
+					RenvGitCommitProperty: "a90f0c5",
+				}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "cli:3.6.1", "glue:1.6.2":
+				assert.Equal(t, "cran", dependency.Type)
+				assert.Equal(t, "https://cloud.r-project.org", dependency.Properties[RenvRepositoryUrlProperty])
+				assert.Equal(t, [][]string{{"lifecycle:1.0.3", module.Id}}, dependency.RequestedBy)
+			case "rlang:1.1.1":
+				assert.Equal(t, "github", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha1: "1a6c5bf8bd5796fc1e0e8be8e1e04a2c0a5a8a86"}, dependency.Checksum)
+				assert.Equal(t, "https://github.com/r-lib/rlang", dependency.Properties[RenvGitUrlProperty])
+				assert.Equal(t, [][]string{{"lifecycle:1.0.3", module.Id}}, dependency.RequestedBy)
+			case "lifecycle:1.0.3", "renv:1.0.3":
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForRPackage(t *testing.T) {
+	service := NewBuildInfoService()
+	rBuild, err := service.GetOrCreateBuild("build-info-go-test-r-package", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, rBuild.Clean())
+	}()
+	rModule, err := rBuild.AddRModule(filepath.Join("testdata", "r", "package"))
+	if assert.NoError(t, err) {
+		err = rModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := rBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, "hello:0.1.0", module.Id)
+		assert.Len(t, module.Dependencies, 1)
+		assert.Equal(t, "jsonlite:1.8.7", module.Dependencies[0].Id)
+	}
+}
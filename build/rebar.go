@@ -0,0 +1,162 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency property, which holds the URL of the git repository of a git package.
+	RebarGitUrlProperty = "rebar.gitUrl"
+
+	rebarDefaultHexRepo = "hexpm"
+)
+
+type RebarModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the rebar.config file in the working directory or in its parents.
+func newRebarModule(srcPath string, containingBuild *Build) (*RebarModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.RebarConfigFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, version, err := buildutils.GetRebarApplicationNameAndVersion(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No application is defined in the src directory. Using the directory name: %s as module name.", name))
+	} else if version != "" {
+		name += ":" + version
+	}
+
+	return &RebarModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages listed in the rebar.lock file.
+func (rm *RebarModule) CalcDependencies() error {
+	started := time.Now()
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := rm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: rm.name, Type: entities.Rebar, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(rm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return rm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (rm *RebarModule) SetName(name string) {
+	rm.name = name
+}
+
+func (rm *RebarModule) SetProperties(properties map[string]string) {
+	rm.properties = properties
+}
+
+func (rm *RebarModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: rm.name, ModuleType: entities.Rebar, Artifacts: artifacts}
+	return rm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (rm *RebarModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := buildutils.ReadRebarLock(rm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'rebar3 get-deps' to create it", buildutils.RebarLockFileName, err.Error())
+	}
+	cachePath, err := buildutils.GetRebarHexCachePath(rebarDefaultHexRepo)
+	if err != nil {
+		return nil, err
+	}
+	packagesIds := make(map[string]string)
+	for _, pkg := range packages {
+		packagesIds[pkg.Name] = pkg.Id()
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	// The lock file doesn't list the dependencies of the packages, so they are read from the packages fetched to the default profile.
+	libPath := filepath.Join(rm.srcPath, "_build", "default", "lib")
+	requested := make(map[string]bool)
+	for _, pkg := range packages {
+		dependency, err := rm.createRebarDependency(cachePath, pkg)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[pkg.Id()] = dependency
+		dependencies, err := buildutils.GetRebarPackageDependencies(filepath.Join(libPath, pkg.Name))
+		if err != nil {
+			// The configuration files of packages may use Erlang expressions, which can't be parsed without evaluating them.
+			rm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't read the dependencies of %s: %s", pkg.Name, err.Error()))
+		}
+		dependenciesGraph[pkg.Id()] = getMixPackagesIds(packagesIds, dependencies)
+		for _, childId := range dependenciesGraph[pkg.Id()] {
+			requested[childId] = true
+		}
+	}
+	// The packages at the top level of the lock file are the dependencies listed in the rebar.config file of the project.
+	// Packages, which aren't required by other packages (for example, if the dependencies weren't fetched), are considered as direct dependencies too.
+	for _, pkg := range packages {
+		if pkg.Level == 0 || !requested[pkg.Id()] {
+			dependenciesGraph[rm.name] = append(dependenciesGraph[rm.name], pkg.Id())
+		}
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(rm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, rm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a rebar3 package.
+// The checksums of Hex packages are calculated from their tarballs in the rebar3 cache. If they aren't cached, the outer checksum from the rebar.lock file is used as the sha256 checksum.
+// The sha1 checksum of git packages is their commit.
+func (rm *RebarModule) createRebarDependency(cachePath string, pkg buildutils.RebarPackage) (entities.Dependency, error) {
+	if pkg.Source == buildutils.RebarGitSource {
+		dependency := entities.Dependency{Id: pkg.Id(), Type: "git"}
+		if buildutils.IsGitCommit(pkg.GitCommit) {
+			dependency.Sha1 = pkg.GitCommit
+		}
+		setDependencyProperties(&dependency, map[string]string{RebarGitUrlProperty: pkg.GitUrl})
+		return dependency, nil
+	}
+	dependency := entities.Dependency{Id: pkg.Id(), Type: "hex", Checksum: entities.Checksum{Sha256: pkg.OuterChecksum}}
+	tarballPath := filepath.Join(cachePath, pkg.PkgName+"-"+pkg.Version+".tar")
+	exists, err := utils.IsFileExists(tarballPath, true)
+	if err != nil || !exists {
+		return dependency, err
+	}
+	checksum, err := rm.containingBuild.getFileChecksum(tarballPath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
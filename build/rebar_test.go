@@ -0,0 +1,54 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForRebarProject(t *testing.T) {
+	t.Setenv("REBAR_CACHE_DIR", filepath.Join("testdata", "rebar", "cache"))
+	service := NewBuildInfoService()
+	rebarBuild, err := service.GetOrCreateBuild("build-info-go-test-rebar", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, rebarBuild.Clean())
+	}()
+	rebarModule, err := rebarBuild.AddRebarModule(filepath.Join("testdata", "rebar", "project"))
+	if assert.NoError(t, err) {
+		err = rebarModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := rebarBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Rebar, module.Type)
+		assert.Equal(t, "hello:0.1.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 4)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "cowboy:2.10.0":
+				// The outer checksum from the lock file, since the tarball isn't cached.
+				assert.Equal(t, "hex", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha256: "3afdccb7183cc6f143cb14d3cf51fa00e53db9ec80cdcd525482f5e99bc41d6b"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "cowlib:2.12.1":
+				// The checksums of the tarball in the rebar3 cache.
+				assert.Equal(t, entities.Checksum{Sha1: "9af6f674e12051720e62e07b9b68578d73310820", Md5: "023ad6c8a6b48c39afdf9dba0b176bc3", Sha256: "ce8f12dab651741a957ad284bfed94b60e82fc5f2f843d18e14bc99443e2430a"}, dependency.Checksum)
+				assert.ElementsMatch(t, [][]string{{"cowboy:2.10.0", module.Id}, {"mylib:0123456789abcdef0123456789abcdef01234567", module.Id}}, dependency.RequestedBy)
+			case "mylib:0123456789abcdef0123456789abcdef01234567":
+				assert.Equal(t, "git", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha1: "0123456789abcdef0123456789abcdef01234567"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{RebarGitUrlProperty: "https://github.com/example/mylib.git"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "ranch:1.8.0":
+				assert.Equal(t, [][]string{{"cowboy:2.10.0", module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+)
+
+const (
+	// The dependency properties, which hold the architecture of a package, the source RPM it was built from, its license and its vendor.
+	RpmArchProperty      = "rpm.arch"
+	RpmSourceRpmProperty = "rpm.sourceRpm"
+	RpmLicenseProperty   = "rpm.license"
+	RpmVendorProperty    = "rpm.vendor"
+
+	// The name of the module, if the system doesn't have an os-release file.
+	defaultRpmModuleName = "rpm"
+)
+
+type RpmModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	// The root directory of the system, whose installed packages are collected.
+	rootDir string
+}
+
+// Pass an empty string for rootDir to collect the packages installed on the running system.
+func newRpmModule(rootDir string, containingBuild *Build) (*RpmModule, error) {
+	if rootDir == "" {
+		rootDir = string(filepath.Separator)
+	}
+	name, err := buildutils.GetOsModuleId(rootDir, defaultRpmModuleName)
+	if err != nil {
+		return nil, err
+	}
+	if name == defaultRpmModuleName {
+		containingBuild.logger.Debug(fmt.Sprintf("No os-release file was found in %s. Using the default name: %s as module name.", rootDir, name))
+	}
+	return &RpmModule{name: name, rootDir: rootDir, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages installed on the system, by querying its rpmdb.
+func (rm *RpmModule) CalcDependencies() error {
+	started := time.Now()
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	packages, err := buildutils.RunRpmQuery(rm.rootDir)
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: rm.name, Type: entities.Rpm, Dependencies: rm.loadDependencies(packages)}
+	buildInfoModule.AddProperties(rm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return rm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (rm *RpmModule) SetName(name string) {
+	rm.name = name
+}
+
+func (rm *RpmModule) SetProperties(properties map[string]string) {
+	rm.properties = properties
+}
+
+func (rm *RpmModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: rm.name, ModuleType: entities.Rpm, Artifacts: artifacts}
+	return rm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// Returns the dependencies of the module from the installed packages.
+// The rpmdb doesn't record which packages were installed explicitly, so the packages, which aren't required by other packages, are considered as the direct dependencies of the module.
+func (rm *RpmModule) loadDependencies(packages []buildutils.RpmPackage) []entities.Dependency {
+	// The requirements of a package may refer to the names of other packages, or to the capabilities they provide (for example: 'libc.so.6()(64bit)' or '/bin/sh').
+	providers := make(map[string]string)
+	for _, pkg := range packages {
+		for _, provided := range pkg.Provides {
+			providers[provided] = pkg.Id()
+		}
+	}
+	for _, pkg := range packages {
+		providers[pkg.Name] = pkg.Id()
+	}
+
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	required := make(map[string]bool)
+	for _, pkg := range packages {
+		dependency := entities.Dependency{Id: pkg.Id(), Type: "rpm", Checksum: entities.Checksum{Md5: pkg.SigMd5, Sha256: pkg.Sha256Header}}
+		setDependencyProperties(&dependency, map[string]string{
+			RpmArchProperty:      pkg.Arch,
+			RpmSourceRpmProperty: pkg.SourceRpm,
+			RpmLicenseProperty:   pkg.License,
+			RpmVendorProperty:    pkg.Vendor,
+		})
+		dependenciesMap[pkg.Id()] = dependency
+		// A package usually requires several capabilities of the same package, so each child is added once.
+		children := make(map[string]bool)
+		for _, requirement := range pkg.Requires {
+			childId, ok := providers[requirement]
+			if !ok || childId == pkg.Id() || children[childId] {
+				continue
+			}
+			children[childId] = true
+			dependenciesGraph[pkg.Id()] = append(dependenciesGraph[pkg.Id()], childId)
+			required[childId] = true
+		}
+	}
+	for _, pkg := range packages {
+		if !required[pkg.Id()] {
+			dependenciesGraph[rm.name] = append(dependenciesGraph[rm.name], pkg.Id())
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(rm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, rm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap)
+}
@@ -0,0 +1,61 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRpmModuleDependencies(t *testing.T) {
+	service := NewBuildInfoService()
+	rpmBuild, err := service.GetOrCreateBuild("build-info-go-test-rpm", "1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, rpmBuild.Clean())
+	}()
+	rpmModule, err := rpmBuild.AddRpmModule(filepath.Join("testdata", "rpm", "root"))
+	require.NoError(t, err)
+	assert.Equal(t, "rhel:9.3", rpmModule.name)
+
+	output, err := os.ReadFile(filepath.Join("testdata", "rpm", "rpm-qa.txt"))
+	require.NoError(t, err)
+	packages, err := buildutils.ParseRpmQuery(output)
+	require.NoError(t, err)
+	dependencies := rpmModule.loadDependencies(packages)
+	assert.Len(t, dependencies, 8)
+	for _, dependency := range dependencies {
+		assert.Equal(t, "rpm", dependency.Type)
+		assert.NotEmpty(t, dependency.RequestedBy)
+		switch dependency.Id {
+		case "openssl-libs:1:3.0.7-24.el9":
+			assert.Equal(t, entities.Checksum{Md5: "1a2b3c4d5e6f70718293a4b5c6d7e8f9", Sha256: "6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d"}, dependency.Checksum)
+			assert.Equal(t, map[string]string{
+				RpmArchProperty:      "x86_64",
+				RpmSourceRpmProperty: "openssl-3.0.7-24.el9.src.rpm",
+				RpmLicenseProperty:   "ASL 2.0",
+				RpmVendorProperty:    "Red Hat, Inc.",
+			}, dependency.Properties)
+			// The only package, which isn't required by other packages.
+			assert.Equal(t, [][]string{{rpmModule.name}}, dependency.RequestedBy)
+		case "glibc:2.34-60.el9":
+			assert.Contains(t, dependency.RequestedBy, []string{"openssl-libs:1:3.0.7-24.el9", rpmModule.name})
+		case "bash:5.1.8-6.el9_1":
+			// Required through the '/bin/sh' file it provides.
+			assert.Contains(t, dependency.RequestedBy, []string{"glibc:2.34-60.el9", "openssl-libs:1:3.0.7-24.el9", rpmModule.name})
+		case "ncurses-libs:6.2-8.20210508.el9":
+			// Required through the library it provides.
+			assert.Contains(t, dependency.RequestedBy, []string{"bash:5.1.8-6.el9_1", "glibc:2.34-60.el9", "openssl-libs:1:3.0.7-24.el9", rpmModule.name})
+		case "basesystem:11-13.el9":
+			// The package was installed without a header digest.
+			assert.Equal(t, entities.Checksum{Md5: "e1f2a3b4c5d60718293a4b5c6d7e8f90"}, dependency.Checksum)
+		case "filesystem:3.16-2.el9", "glibc-common:2.34-60.el9", "setup:2.13.7-9.el9":
+		default:
+			assert.Fail(t, "Unexpected dependency "+dependency.Id)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+type RubyModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	bundleArgs      []string
+}
+
+// Pass an empty string for srcPath to find the Ruby project in the working directory.
+func newRubyModule(srcPath string, containingBuild *Build) (*RubyModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, "Gemfile")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name.
+	// If the project is a gem, the module is named after it. Otherwise, the directory name is used.
+	name := filepath.Base(srcPath)
+	if exists, _ := utils.IsFileExists(filepath.Join(srcPath, buildutils.GemfileLockFileName), true); exists {
+		lock, err := buildutils.ReadGemfileLock(srcPath)
+		if err != nil {
+			return nil, err
+		}
+		if projectGem := getProjectGem(lock); projectGem != nil {
+			name = projectGem.Id()
+		}
+	}
+
+	return &RubyModule{name: name, srcPath: srcPath, containingBuild: containingBuild, bundleArgs: []string{"install"}}, nil
+}
+
+// Build runs Bundler with the arguments set by SetArgs ('install' by default), collects the project's dependencies and saves them in the build-info module.
+func (rm *RubyModule) Build() error {
+	executablePath, err := exec.LookPath("bundle")
+	if err != nil {
+		return err
+	}
+	command := exec.Command(executablePath, rm.bundleArgs...)
+	command.Dir = rm.srcPath
+	command.Stdout = os.Stderr
+	command.Stderr = os.Stderr
+	err = command.Run()
+	if _, ok := err.(*exec.ExitError); ok {
+		err = errors.New(err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return nil
+	}
+	return rm.CalcDependencies()
+}
+
+// CalcDependencies collects the dependencies from the Gemfile.lock file of the project, without running Bundler.
+func (rm *RubyModule) CalcDependencies() error {
+	started := time.Now()
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := rm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: rm.name, Type: entities.Ruby, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(rm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return rm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (rm *RubyModule) SetName(name string) {
+	rm.name = name
+}
+
+func (rm *RubyModule) SetProperties(properties map[string]string) {
+	rm.properties = properties
+}
+
+func (rm *RubyModule) SetArgs(bundleArgs []string) {
+	rm.bundleArgs = bundleArgs
+}
+
+func (rm *RubyModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !rm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: rm.name, ModuleType: entities.Ruby, Artifacts: artifacts}
+	return rm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// AddGemArtifacts adds the given .gem files (for example, those created by 'gem build') as artifacts of the module.
+func (rm *RubyModule) AddGemArtifacts(gemPaths ...string) error {
+	var artifacts []entities.Artifact
+	for _, gemPath := range gemPaths {
+		checksum, err := rm.containingBuild.getFileChecksum(gemPath)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, entities.Artifact{Name: filepath.Base(gemPath), Type: "gem", Path: gemPath, Checksum: checksum})
+	}
+	return rm.AddArtifacts(artifacts...)
+}
+
+func (rm *RubyModule) loadDependencies() ([]entities.Dependency, error) {
+	lock, err := buildutils.ReadGemfileLock(rm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'bundle install' or 'bundle lock' to create it", buildutils.GemfileLockFileName, err.Error())
+	}
+	// Several specs of the same gem may be listed, one per platform.
+	specsIds := make(map[string][]string)
+	for _, spec := range lock.Specs {
+		specsIds[spec.Name] = append(specsIds[spec.Name], spec.Id())
+	}
+	projectGem := getProjectGem(lock)
+	dependenciesGraph := make(map[string][]string)
+	for _, name := range lock.Dependencies {
+		if projectGem != nil && name == projectGem.Name {
+			// The dependencies of the project's gem are its own.
+			for _, dependencyName := range projectGem.Dependencies {
+				dependenciesGraph[rm.name] = append(dependenciesGraph[rm.name], specsIds[dependencyName]...)
+			}
+			continue
+		}
+		dependenciesGraph[rm.name] = append(dependenciesGraph[rm.name], specsIds[name]...)
+	}
+
+	cachePaths := buildutils.GetGemCachePaths(rm.srcPath, rm.containingBuild.logger)
+	dependenciesMap := make(map[string]entities.Dependency)
+	for _, spec := range lock.Specs {
+		if projectGem != nil && spec.Id() == projectGem.Id() {
+			continue
+		}
+		for _, dependencyName := range spec.Dependencies {
+			dependenciesGraph[spec.Id()] = append(dependenciesGraph[spec.Id()], specsIds[dependencyName]...)
+		}
+		dependency, err := rm.getGemDependency(cachePaths, spec, lock.Checksums[spec.Id()])
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[spec.Id()] = dependency
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(rm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, rm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Returns the gem of the project itself, if it's a gem (that is, it has a gemspec which is referenced from the Gemfile).
+// Such a gem is listed in the PATH section of the Gemfile.lock file, with '.' as its remote.
+func getProjectGem(lock *buildutils.GemfileLock) *buildutils.GemSpec {
+	for i, spec := range lock.Specs {
+		if spec.SourceType == "PATH" && spec.Remote == "." {
+			return &lock.Specs[i]
+		}
+	}
+	return nil
+}
+
+// Creates the build-info dependency of a gem.
+// The checksums are calculated from the .gem file in the cache. If it's missing, the sha256 checksum from the Gemfile.lock file is used (if exists).
+func (rm *RubyModule) getGemDependency(cachePaths []string, spec buildutils.GemSpec, lockChecksum string) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: spec.Id(), Type: "gem"}
+	gemPath, err := buildutils.FindCachedGem(cachePaths, spec)
+	if err != nil {
+		return dependency, err
+	}
+	if gemPath == "" {
+		rm.containingBuild.logger.Debug(fmt.Sprintf("The .gem file of %s was not found in the gems cache.", spec.Id()))
+		dependency.Checksum = entities.Checksum{Sha256: lockChecksum}
+		return dependency, nil
+	}
+	checksum, err := rm.containingBuild.getFileChecksum(gemPath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
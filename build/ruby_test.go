@@ -0,0 +1,66 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForRubyProject(t *testing.T) {
+	gemHome, err := filepath.Abs(filepath.Join("testdata", "ruby", "gemhome"))
+	assert.NoError(t, err)
+	t.Setenv("GEM_HOME", gemHome)
+	t.Setenv("GEM_PATH", "")
+
+	service := NewBuildInfoService()
+	rubyBuild, err := service.GetOrCreateBuild("build-info-go-test-ruby", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, rubyBuild.Clean())
+	}()
+	projectPath := filepath.Join("testdata", "ruby", "project")
+	rubyModule, err := rubyBuild.AddRubyModule(projectPath)
+	if assert.NoError(t, err) {
+		err = rubyModule.CalcDependencies()
+		assert.NoError(t, err)
+		err = rubyModule.AddGemArtifacts(filepath.Join(projectPath, "ruby-project.gemspec"))
+		assert.NoError(t, err)
+		buildInfo, err := rubyBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Ruby, module.Type)
+		// The project is a gem, so the module is named after it.
+		assert.Equal(t, "ruby-project:1.0.0", module.Id)
+		if assert.Len(t, module.Artifacts, 1) {
+			assert.Equal(t, "ruby-project.gemspec", module.Artifacts[0].Name)
+			assert.NotEmpty(t, module.Artifacts[0].Sha256)
+		}
+
+		expectedRequestedBy := map[string][][]string{
+			"minitest:5.20.0":              {{module.Id}},
+			"rack:3.0.8":                   {{module.Id}},
+			"nokogiri:1.15.4-arm64-darwin": {{module.Id}},
+			"nokogiri:1.15.4-x86_64-linux": {{module.Id}},
+			"racc:1.7.1":                   {{"nokogiri:1.15.4-arm64-darwin", module.Id}, {"nokogiri:1.15.4-x86_64-linux", module.Id}},
+		}
+		assert.Len(t, module.Dependencies, len(expectedRequestedBy))
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "gem", dependency.Type)
+			assert.Equal(t, expectedRequestedBy[dependency.Id], dependency.RequestedBy, dependency.Id)
+			switch dependency.Id {
+			case "rack:3.0.8", "racc:1.7.1":
+				// Calculated from the .gem files in the cache.
+				assert.NotEmpty(t, dependency.Md5)
+				assert.NotEmpty(t, dependency.Sha1)
+				assert.NotEmpty(t, dependency.Sha256)
+			default:
+				// Taken from the CHECKSUMS section of the Gemfile.lock file.
+				assert.Empty(t, dependency.Sha1)
+				assert.NotEmpty(t, dependency.Sha256)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+)
+
+// SbomModule imports the dependencies listed in an SBOM, created by a third-party tool (such as a scanner), into the build.
+type SbomModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	moduleType      entities.ModuleType
+	sbomPath        string
+}
+
+// sbomPath is the path of a CycloneDX or an SPDX SBOM, in the JSON format.
+func newSbomModule(sbomPath string, containingBuild *Build) (*SbomModule, error) {
+	if sbomPath == "" {
+		return nil, errors.New("the path of the SBOM must be provided")
+	}
+	if _, err := os.Stat(sbomPath); err != nil {
+		return nil, err
+	}
+	return &SbomModule{sbomPath: sbomPath, moduleType: entities.Generic, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies converts the components (or packages) of the SBOM to dependencies of the module.
+// If no name was set, the name of the module is taken from the component (or package) the SBOM describes, or from the name of the SBOM file.
+func (sm *SbomModule) CalcDependencies() error {
+	started := time.Now()
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	content, err := os.ReadFile(sm.sbomPath)
+	if err != nil {
+		return err
+	}
+	module, err := entities.SbomToModule(content, sm.name, sm.moduleType)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", sm.sbomPath, err)
+	}
+	if module.Id == "" {
+		module.Id = strings.TrimSuffix(filepath.Base(sm.sbomPath), filepath.Ext(sm.sbomPath))
+		sm.containingBuild.logger.Debug(fmt.Sprintf("The SBOM doesn't describe a component. Using the file name: %s as module name.", module.Id))
+	}
+	sm.name = module.Id
+	module.AddProperties(sm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{*module}}
+	setModulesTiming(buildInfo, started)
+	return sm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (sm *SbomModule) SetName(name string) {
+	sm.name = name
+}
+
+func (sm *SbomModule) SetProperties(properties map[string]string) {
+	sm.properties = properties
+}
+
+// SetModuleType sets the type of the module. The default type is generic.
+func (sm *SbomModule) SetModuleType(moduleType entities.ModuleType) {
+	sm.moduleType = moduleType
+}
+
+func (sm *SbomModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	if sm.name == "" {
+		return errors.New("the name of the module must be set, or its dependencies calculated, before adding artifacts")
+	}
+	partial := &entities.Partial{ModuleId: sm.name, ModuleType: sm.moduleType, Artifacts: artifacts}
+	return sm.containingBuild.SavePartialBuildInfo(partial)
+}
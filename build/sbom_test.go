@@ -0,0 +1,56 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoFromSbom(t *testing.T) {
+	service := NewBuildInfoService()
+	sbomBuild, err := service.GetOrCreateBuild("build-info-go-test-sbom", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, sbomBuild.Clean())
+	}()
+	sbomModule, err := sbomBuild.AddSbomModule(filepath.Join("testdata", "sbom", "bom.cdx.json"))
+	if assert.NoError(t, err) {
+		sbomModule.SetModuleType(entities.Npm)
+		sbomModule.SetProperties(map[string]string{"team": "frontend"})
+		err = sbomModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := sbomBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Npm, module.Type)
+		// The name of the module is taken from the component the SBOM describes.
+		assert.Equal(t, "my-app:1.0.0", module.Id)
+		assert.Equal(t, map[string]string{"team": "frontend"}, module.GetProperties())
+		assert.NotEmpty(t, module.Started)
+		assert.NotEmpty(t, module.Finished)
+		assert.NotEmpty(t, buildInfo.Finished)
+
+		assert.Len(t, module.Dependencies, 3)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "express:4.18.2":
+				assert.Equal(t, entities.Checksum{Sha1: "3fabe08296e930c796c19e3c516979386ba9fd59"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{entities.CycloneDxLicenseProperty: "MIT"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "body-parser:1.20.1":
+				assert.Equal(t, [][]string{{"express:4.18.2", module.Id}}, dependency.RequestedBy)
+			case "@types:node:20.0.0":
+				// No component depends on it, so it's a direct dependency of the module.
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+
+	_, err = sbomBuild.AddSbomModule(filepath.Join("testdata", "sbom", "missing.json"))
+	assert.Error(t, err)
+}
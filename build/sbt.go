@@ -0,0 +1,151 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+type SbtModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	// The sbt project to collect the dependencies of. All the projects of the build are collected if empty.
+	project string
+}
+
+// Pass an empty string for srcPath to find the sbt project in the working directory.
+func newSbtModule(srcPath string, containingBuild *Build) (*SbtModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.SbtBuildFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, err := buildutils.GetSbtProjectId(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is set in the %s file. Using the directory name: %s as module name.", buildutils.SbtBuildFileName, name))
+	}
+
+	return &SbtModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies runs 'sbt dependencyTree' to collect the resolved dependencies of the project.
+func (sm *SbtModule) CalcDependencies() error {
+	started := time.Now()
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	roots, err := buildutils.RunSbtDependencyTree(sm.srcPath, sm.project)
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: sm.name, Type: entities.Sbt, Dependencies: sm.getSbtDependencies(roots)}
+	buildInfoModule.AddProperties(sm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return sm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (sm *SbtModule) SetName(name string) {
+	sm.name = name
+}
+
+func (sm *SbtModule) SetProperties(properties map[string]string) {
+	sm.properties = properties
+}
+
+// SetProject sets the sbt project (for example: 'core') to collect the dependencies of, in multi-project builds.
+func (sm *SbtModule) SetProject(project string) {
+	sm.project = project
+}
+
+func (sm *SbtModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: sm.name, ModuleType: entities.Sbt, Artifacts: artifacts}
+	return sm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// Converts the trees returned by 'sbt dependencyTree' to build-info dependencies.
+// Each root is an sbt project, so the dependencies of all roots are the direct dependencies of the module.
+func (sm *SbtModule) getSbtDependencies(roots []*buildutils.SbtDependency) []entities.Dependency {
+	cachePath, err := buildutils.GetCoursierCachePath()
+	if err != nil {
+		sm.containingBuild.logger.Debug("Couldn't find the Coursier cache:", err.Error())
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	for _, root := range roots {
+		sm.addSbtDependenciesToGraph(sm.name, root.Dependencies, cachePath, dependenciesMap, dependenciesGraph)
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(sm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, sm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap)
+}
+
+func (sm *SbtModule) addSbtDependenciesToGraph(parentId string, dependencies []*buildutils.SbtDependency, cachePath string, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) {
+	for _, dependency := range dependencies {
+		if !slices.Contains(dependenciesGraph[parentId], dependency.Id) {
+			dependenciesGraph[parentId] = append(dependenciesGraph[parentId], dependency.Id)
+		}
+		// The subtrees of dependencies are printed again wherever they appear in the tree.
+		if _, exists := dependenciesMap[dependency.Id]; exists {
+			continue
+		}
+		dependenciesMap[dependency.Id] = sm.createSbtDependency(dependency.Id, cachePath)
+		sm.addSbtDependenciesToGraph(dependency.Id, dependency.Dependencies, cachePath, dependenciesMap, dependenciesGraph)
+	}
+}
+
+// Creates the build-info dependency of an sbt dependency.
+// The checksums are calculated from the jar in the Coursier cache, or in the Ivy cache if it's not found there.
+func (sm *SbtModule) createSbtDependency(id, cachePath string) entities.Dependency {
+	dependency := entities.Dependency{Id: id, Type: "jar"}
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return dependency
+	}
+	organization, name, revision := parts[0], parts[1], parts[2]
+	var jarPath string
+	var err error
+	if cachePath != "" {
+		jarPath, err = buildutils.FindCoursierJar(cachePath, organization, name, revision)
+	}
+	if err == nil && jarPath == "" {
+		jarPath, err = buildutils.FindIvyJar(organization, name, revision)
+	}
+	if err != nil || jarPath == "" {
+		sm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't find the jar of %s in the Coursier and Ivy caches.", id))
+		return dependency
+	}
+	checksum, err := sm.containingBuild.getFileChecksum(jarPath)
+	if err != nil {
+		sm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't calculate the checksums of %s: %s", jarPath, err.Error()))
+		return dependency
+	}
+	dependency.Checksum = checksum
+	return dependency
+}
@@ -0,0 +1,61 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSbtDependencies(t *testing.T) {
+	coursierCache, err := filepath.Abs(filepath.Join("testdata", "sbt", "coursier"))
+	assert.NoError(t, err)
+	t.Setenv("COURSIER_CACHE", coursierCache)
+
+	service := NewBuildInfoService()
+	sbtBuild, err := service.GetOrCreateBuild("build-info-go-test-sbt", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, sbtBuild.Clean())
+	}()
+	sbtModule, err := sbtBuild.AddSbtModule(filepath.Join("testdata", "sbt", "project"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	// The module is named after the organization, name and version in the build.sbt file.
+	assert.Equal(t, "com.example:hello:0.1.0", sbtModule.name)
+
+	output, err := os.ReadFile(filepath.Join("testdata", "sbt", "dependency-tree.txt"))
+	assert.NoError(t, err)
+	roots, err := buildutils.ParseSbtDependencyTree(output)
+	assert.NoError(t, err)
+
+	dependencies := sbtModule.getSbtDependencies(roots)
+	assert.Len(t, dependencies, 4)
+	for _, dependency := range dependencies {
+		assert.Equal(t, "jar", dependency.Type)
+		switch dependency.Id {
+		case "com.typesafe:config:1.4.2":
+			assert.Equal(t, [][]string{{sbtModule.name}}, dependency.RequestedBy)
+			// The checksums of the jar in the Coursier cache.
+			assert.NotEmpty(t, dependency.Md5)
+			assert.NotEmpty(t, dependency.Sha1)
+			assert.NotEmpty(t, dependency.Sha256)
+		case "org.typelevel:cats-core_2.13:2.9.0":
+			assert.Equal(t, [][]string{{sbtModule.name}}, dependency.RequestedBy)
+			assert.Empty(t, dependency.Sha1)
+		case "org.typelevel:cats-kernel_2.13:2.9.0":
+			assert.Equal(t, [][]string{{"org.typelevel:cats-core_2.13:2.9.0", sbtModule.name}}, dependency.RequestedBy)
+		case "org.scala-lang:scala-library:2.13.12":
+			assert.ElementsMatch(t, [][]string{
+				{"org.typelevel:cats-core_2.13:2.9.0", sbtModule.name},
+				{"org.typelevel:cats-kernel_2.13:2.9.0", "org.typelevel:cats-core_2.13:2.9.0", sbtModule.name},
+				{sbtModule.name},
+			}, dependency.RequestedBy)
+		default:
+			assert.Fail(t, "Unexpected dependency "+dependency.Id)
+		}
+	}
+}
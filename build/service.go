@@ -14,8 +14,9 @@ import (
 const BuildsTempPath = "jfrog/builds/"
 
 type BuildInfoService struct {
-	tempDirPath string
-	logger      utils.Log
+	tempDirPath   string
+	logger        utils.Log
+	partialsStore PartialsStore
 }
 
 func NewBuildInfoService() *BuildInfoService {
@@ -30,6 +31,11 @@ func (bis *BuildInfoService) SetLogger(logger utils.Log) {
 	bis.logger = logger
 }
 
+// SetPartialsStore sets where the builds, which the service gets or creates, store their partials. See Build.SetPartialsStore().
+func (bis *BuildInfoService) SetPartialsStore(partialsStore PartialsStore) {
+	bis.partialsStore = partialsStore
+}
+
 // GetOrCreateBuild gets a build from cache, or creates a new one if it doesn't exist.
 // It's important to invoke this function at the very beginning of the build, so that the start time property in the build-info will be accurate.
 func (bis *BuildInfoService) GetOrCreateBuild(buildName, buildNumber string) (*Build, error) {
@@ -45,7 +51,9 @@ func (bis *BuildInfoService) GetOrCreateBuildWithProject(buildName, buildNumber,
 			return nil, err
 		}
 	}
-	return NewBuild(buildName, buildNumber, projectKey, bis.tempDirPath, bis.logger), nil
+	build := NewBuild(buildName, buildNumber, projectKey, bis.tempDirPath, bis.logger)
+	build.SetPartialsStore(bis.partialsStore)
+	return build, nil
 }
 
 func saveBuildGeneralDetails(buildName, buildNumber, projectKey, buildsDirPath string, log utils.Log) error {
@@ -0,0 +1,164 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency property, which holds the URL of the repository of a shard (or its path, for path shards).
+	ShardsUrlProperty = "shards.url"
+)
+
+type ShardsModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the shard.yml file in the working directory or in its parents.
+func newShardsModule(srcPath string, containingBuild *Build) (*ShardsModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.ShardFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	shard, err := buildutils.ReadShard(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := shard.Name
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the %s file. Using the directory name: %s as module name.", buildutils.ShardFileName, name))
+	} else if shard.Version != "" {
+		name += ":" + shard.Version
+	}
+
+	return &ShardsModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the shards listed in the shard.lock file.
+func (sm *ShardsModule) CalcDependencies() error {
+	started := time.Now()
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := sm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: sm.name, Type: entities.Shards, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(sm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return sm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (sm *ShardsModule) SetName(name string) {
+	sm.name = name
+}
+
+func (sm *ShardsModule) SetProperties(properties map[string]string) {
+	sm.properties = properties
+}
+
+func (sm *ShardsModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: sm.name, ModuleType: entities.Shards, Artifacts: artifacts}
+	return sm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (sm *ShardsModule) loadDependencies() ([]entities.Dependency, error) {
+	shards, err := buildutils.ReadShardLock(sm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'shards install' to create it", buildutils.ShardLockFileName, err.Error())
+	}
+	project, err := buildutils.ReadShard(sm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	cachePath, err := buildutils.GetShardsCachePath()
+	if err != nil {
+		return nil, err
+	}
+	shardsIds := make(map[string]string)
+	for _, shard := range shards {
+		shardsIds[shard.Name] = shard.Id()
+	}
+	// The development dependencies of the project are installed too, but not those of its dependencies.
+	dependenciesGraph := map[string][]string{sm.name: getMixPackagesIds(shardsIds, project.GetDependenciesNames(true))}
+	dependenciesMap := make(map[string]entities.Dependency)
+	requested := make(map[string]bool)
+	for _, shard := range shards {
+		dependenciesMap[shard.Id()] = sm.createShardDependency(cachePath, shard)
+		// The lock file doesn't list the dependencies of the shards, so they are read from the shards installed in the lib directory.
+		installedShard, err := buildutils.GetInstalledShard(sm.srcPath, shard.Name)
+		if err != nil {
+			return nil, err
+		}
+		if installedShard != nil {
+			dependenciesGraph[shard.Id()] = getMixPackagesIds(shardsIds, installedShard.GetDependenciesNames(false))
+			for _, childId := range dependenciesGraph[shard.Id()] {
+				requested[childId] = true
+			}
+		}
+	}
+	// Shards, which aren't required by other shards (for example, if the shards weren't installed), are considered as direct dependencies too.
+	for _, shard := range shards {
+		if !requested[shard.Id()] && !slices.Contains(dependenciesGraph[sm.name], shard.Id()) {
+			dependenciesGraph[sm.name] = append(dependenciesGraph[sm.name], shard.Id())
+		}
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(sm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, sm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a shard. The type of the dependency is 'git', or the source of the shard, for example: 'path'.
+// The sha1 checksum of git shards is their commit. The commits of shards locked to releases are resolved from their tags in the shards cache.
+func (sm *ShardsModule) createShardDependency(cachePath string, shard buildutils.LockedShard) entities.Dependency {
+	dependency := entities.Dependency{Id: shard.Id()}
+	switch {
+	case shard.IsGitShard():
+		dependency.Type = "git"
+		commit := shard.GetCommit()
+		if commit == "" {
+			var err error
+			if commit, err = buildutils.ResolveShardCommit(cachePath, shard); err != nil {
+				sm.containingBuild.logger.Debug(fmt.Sprintf("Couldn't resolve the commit of the %s shard: %s", shard.Name, err.Error()))
+			}
+		}
+		if buildutils.IsGitCommit(commit) {
+			dependency.Sha1 = commit
+		}
+	case shard.Hg != "":
+		dependency.Type = "hg"
+	case shard.Fossil != "":
+		dependency.Type = "fossil"
+	default:
+		dependency.Type = "path"
+	}
+	setDependencyProperties(&dependency, map[string]string{ShardsUrlProperty: shard.GetSourceUrl()})
+	return dependency
+}
@@ -0,0 +1,59 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForShardsProject(t *testing.T) {
+	// The repositories of the shards aren't cached.
+	t.Setenv("SHARDS_CACHE_PATH", t.TempDir())
+	service := NewBuildInfoService()
+	shardsBuild, err := service.GetOrCreateBuild("build-info-go-test-shards", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, shardsBuild.Clean())
+	}()
+	shardsModule, err := shardsBuild.AddShardsModule(filepath.Join("testdata", "shards", "project"))
+	if assert.NoError(t, err) {
+		err = shardsModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := shardsBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Shards, module.Type)
+		assert.Equal(t, "hello:0.1.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 6)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "ameba:1.5.0":
+				// A development dependency of the project.
+				assert.Equal(t, "git", dependency.Type)
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "backtracer:1.2.2":
+				assert.ElementsMatch(t, [][]string{{"kemal:1.4.0", module.Id}, {"exception_page:0.3.1+git.commit.1d8528a1d6ad1ef5a3b9a5a2bb7d0c9d4c59d6f7", "kemal:1.4.0", module.Id}}, dependency.RequestedBy)
+			case "exception_page:0.3.1+git.commit.1d8528a1d6ad1ef5a3b9a5a2bb7d0c9d4c59d6f7":
+				// Locked to a commit.
+				assert.Equal(t, entities.Checksum{Sha1: "1d8528a1d6ad1ef5a3b9a5a2bb7d0c9d4c59d6f7"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{ShardsUrlProperty: "https://github.com/crystal-loot/exception_page.git"}, dependency.Properties)
+				assert.Equal(t, [][]string{{"kemal:1.4.0", module.Id}}, dependency.RequestedBy)
+			case "kemal:1.4.0":
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "mylib:0.2.0":
+				assert.Equal(t, "path", dependency.Type)
+				assert.Equal(t, map[string]string{ShardsUrlProperty: "../mylib"}, dependency.Properties)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "radix:0.4.1":
+				assert.Equal(t, [][]string{{"kemal:1.4.0", module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
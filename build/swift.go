@@ -0,0 +1,142 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+type SwiftModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the Swift package in the working directory.
+func newSwiftModule(srcPath string, containingBuild *Build) (*SwiftModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.SwiftManifestFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, err := buildutils.GetSwiftPackageName(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("Couldn't find the package name in %s. Using the directory name: %s as module name.", buildutils.SwiftManifestFileName, name))
+	}
+
+	return &SwiftModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages pinned in the Package.resolved file of the project.
+// If Swift is installed, the dependencies graph is taken from 'swift package show-dependencies'. Otherwise, all packages are considered direct dependencies.
+func (sm *SwiftModule) CalcDependencies() error {
+	started := time.Now()
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := sm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: sm.name, Type: entities.Swift, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(sm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return sm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (sm *SwiftModule) SetName(name string) {
+	sm.name = name
+}
+
+func (sm *SwiftModule) SetProperties(properties map[string]string) {
+	sm.properties = properties
+}
+
+func (sm *SwiftModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: sm.name, ModuleType: entities.Swift, Artifacts: artifacts}
+	return sm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (sm *SwiftModule) loadDependencies() ([]entities.Dependency, error) {
+	pins, err := buildutils.ReadSwiftResolved(sm.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Run 'swift package resolve' to create it", buildutils.SwiftResolvedFileName, err.Error())
+	}
+	pinsIds := make(map[string]string)
+	dependenciesMap := make(map[string]entities.Dependency)
+	for _, pin := range pins {
+		pinsIds[pin.Identity] = pin.Id()
+		// The checksum of a package is the SHA-1 of its pinned Git commit.
+		dependenciesMap[pin.Id()] = entities.Dependency{Id: pin.Id(), Type: "git", Checksum: entities.Checksum{Sha1: pin.Revision}}
+	}
+
+	dependenciesGraph := make(map[string][]string)
+	if root := sm.getDependenciesTree(); root != nil {
+		addSwiftDependenciesToGraph(sm.name, root.Dependencies, pinsIds, dependenciesGraph)
+	} else {
+		for _, pin := range pins {
+			dependenciesGraph[sm.name] = append(dependenciesGraph[sm.name], pin.Id())
+		}
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(sm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, sm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Returns the dependencies tree from 'swift package show-dependencies', or nil if Swift isn't installed or the command failed.
+func (sm *SwiftModule) getDependenciesTree() *buildutils.SwiftDependency {
+	if !buildutils.IsSwiftInstalled() {
+		return nil
+	}
+	root, err := buildutils.RunSwiftShowDependencies(sm.srcPath)
+	if err != nil {
+		sm.containingBuild.logger.Debug("Couldn't get the dependencies graph using 'swift package show-dependencies':", err.Error())
+		return nil
+	}
+	return root
+}
+
+// Adds the dependencies in the tree returned by 'swift package show-dependencies' to the graph.
+func addSwiftDependenciesToGraph(parentId string, dependencies []buildutils.SwiftDependency, pinsIds map[string]string, dependenciesGraph map[string][]string) {
+	if _, added := dependenciesGraph[parentId]; added {
+		return
+	}
+	dependenciesGraph[parentId] = []string{}
+	for _, dependency := range dependencies {
+		identity := dependency.Identity
+		if identity == "" {
+			identity = buildutils.GetSwiftPackageIdentity(dependency.Url)
+		}
+		dependencyId, ok := pinsIds[identity]
+		if !ok {
+			// Local packages aren't pinned.
+			continue
+		}
+		dependenciesGraph[parentId] = append(dependenciesGraph[parentId], dependencyId)
+		addSwiftDependenciesToGraph(dependencyId, dependency.Dependencies, pinsIds, dependenciesGraph)
+	}
+}
@@ -0,0 +1,67 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForSwiftProject(t *testing.T) {
+	service := NewBuildInfoService()
+	swiftBuild, err := service.GetOrCreateBuild("build-info-go-test-swift", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, swiftBuild.Clean())
+	}()
+	swiftModule, err := swiftBuild.AddSwiftModule(filepath.Join("testdata", "swift", "project"))
+	if assert.NoError(t, err) {
+		err = swiftModule.CalcDependencies()
+		assert.NoError(t, err)
+		err = swiftModule.AddArtifacts(entities.Artifact{Name: "artifactName", Type: "artifactType", Path: "artifactPath", Checksum: entities.Checksum{Sha1: "123", Md5: "456", Sha256: "789"}})
+		assert.NoError(t, err)
+		buildInfo, err := swiftBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Swift, module.Type)
+		assert.Equal(t, "SwiftProject", module.Id)
+		assert.Len(t, module.Artifacts, 1)
+
+		expectedRevisions := map[string]string{
+			"swift-argument-parser:1.2.3": "8f4d2753f0e4778c76d5f05ad16c74f707390531",
+			"swift-log:1.5.3":             "532d8b529501fb73a2455b179e0bbb6d49b652ed",
+			// Packages pinned to a branch are identified by their revision.
+			"vapor:4e4ff7f3e6c3a4b5e1c4d6e89db6b9e3f2c1a0b9": "4e4ff7f3e6c3a4b5e1c4d6e89db6b9e3f2c1a0b9",
+		}
+		assert.Len(t, module.Dependencies, len(expectedRevisions))
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, expectedRevisions[dependency.Id], dependency.Sha1, dependency.Id)
+			assert.NotEmpty(t, dependency.RequestedBy, dependency.Id)
+		}
+	}
+}
+
+func TestAddSwiftDependenciesToGraph(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("testdata", "swift", "show-dependencies.json"))
+	assert.NoError(t, err)
+	root, err := buildutils.ParseSwiftShowDependencies(output)
+	assert.NoError(t, err)
+
+	pinsIds := map[string]string{
+		"swift-argument-parser": "swift-argument-parser:1.2.3",
+		"swift-log":             "swift-log:1.5.3",
+		"vapor":                 "vapor:4e4ff7f",
+	}
+	graph := make(map[string][]string)
+	addSwiftDependenciesToGraph("SwiftProject", root.Dependencies, pinsIds, graph)
+	assert.Equal(t, map[string][]string{
+		"SwiftProject":                {"swift-argument-parser:1.2.3", "vapor:4e4ff7f"},
+		"swift-argument-parser:1.2.3": {},
+		"vapor:4e4ff7f":               {"swift-log:1.5.3"},
+		"swift-log:1.5.3":             {},
+	}, graph)
+}
@@ -0,0 +1,64 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+)
+
+// SyftModule imports the packages, which Syft found in a directory or an image, into the build.
+// Syft finds packages of several types, so a module is added to the build for each package type.
+type SyftModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	syftOutputPath  string
+}
+
+// syftOutputPath is the path of the output of Syft in its JSON format ('syft -o json').
+func newSyftModule(syftOutputPath string, containingBuild *Build) (*SyftModule, error) {
+	if syftOutputPath == "" {
+		return nil, errors.New("the path of the Syft JSON output must be provided")
+	}
+	if _, err := os.Stat(syftOutputPath); err != nil {
+		return nil, err
+	}
+	return &SyftModule{syftOutputPath: syftOutputPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies converts the packages found by Syft to the dependencies of the modules, whose IDs are '<name>/<Syft package type>'.
+// If no name was set, the name of the directory or the image scanned by Syft is used.
+func (sm *SyftModule) CalcDependencies() error {
+	started := time.Now()
+	if !sm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	content, err := os.ReadFile(sm.syftOutputPath)
+	if err != nil {
+		return err
+	}
+	modules, err := entities.SyftJsonToModules(content, sm.name)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", sm.syftOutputPath, err)
+	}
+	if len(modules) == 0 {
+		sm.containingBuild.logger.Info("No packages were found in the Syft output " + sm.syftOutputPath)
+		return nil
+	}
+	buildInfo := &entities.BuildInfo{Modules: modules}
+	setModulesProperties(buildInfo, sm.properties)
+	setModulesTiming(buildInfo, started)
+	return sm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (sm *SyftModule) SetName(name string) {
+	sm.name = name
+}
+
+// SetProperties sets the properties of all the modules, which are added for the package types.
+func (sm *SyftModule) SetProperties(properties map[string]string) {
+	sm.properties = properties
+}
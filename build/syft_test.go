@@ -0,0 +1,59 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoFromSyft(t *testing.T) {
+	service := NewBuildInfoService()
+	syftBuild, err := service.GetOrCreateBuild("build-info-go-test-syft", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, syftBuild.Clean())
+	}()
+	syftModule, err := syftBuild.AddSyftModules(filepath.Join("testdata", "syft", "syft.json"))
+	if assert.NoError(t, err) {
+		err = syftModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := syftBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		// A module for each package type, named after the scanned directory.
+		assert.Len(t, buildInfo.Modules, 2)
+		for _, module := range buildInfo.Modules {
+			switch module.Id {
+			case "my-app/npm":
+				assert.Equal(t, entities.Npm, module.Type)
+				assert.Len(t, module.Dependencies, 2)
+				for _, dependency := range module.Dependencies {
+					switch dependency.Id {
+					case "express:4.18.2":
+						assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+						assert.Equal(t, "MIT", dependency.Properties[entities.SyftLicenseProperty])
+					case "body-parser:1.20.1":
+						assert.Equal(t, [][]string{{"express:4.18.2", module.Id}}, dependency.RequestedBy)
+					default:
+						assert.Fail(t, "Unexpected dependency "+dependency.Id)
+					}
+				}
+			case "my-app/java-archive":
+				assert.Equal(t, entities.Maven, module.Type)
+				if assert.Len(t, module.Dependencies, 1) {
+					dependency := module.Dependencies[0]
+					assert.Equal(t, "commons-io:commons-io:2.11.0", dependency.Id)
+					assert.Equal(t, "a2503f302b11ebde7ebc3df41daebe0e4eea3689", dependency.Sha1)
+					assert.Equal(t, "Apache-2.0", dependency.Properties[entities.SyftLicenseProperty])
+					assert.Equal(t, "/app/lib/commons-io-2.11.0.jar", dependency.Properties[entities.SyftLocationProperty])
+				}
+			default:
+				assert.Fail(t, "Unexpected module "+module.Id)
+			}
+		}
+	}
+
+	_, err = syftBuild.AddSyftModules(filepath.Join("testdata", "syft", "missing.json"))
+	assert.Error(t, err)
+}
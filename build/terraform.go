@@ -0,0 +1,156 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the version constraints and the hashes of Terraform providers, as listed in the dependency lock file.
+	TerraformConstraintsProperty = "terraform.constraints"
+	TerraformHashesProperty      = "terraform.hashes"
+)
+
+type TerraformModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath if the Terraform working directory is the working directory.
+func newTerraformModule(srcPath string, containingBuild *Build) (*TerraformModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &TerraformModule{name: filepath.Base(srcPath), srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the providers listed in the .terraform.lock.hcl file, and the remote modules installed by 'terraform init'.
+func (tm *TerraformModule) CalcDependencies() error {
+	started := time.Now()
+	if !tm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := tm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: tm.name, Type: entities.Terraform, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(tm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return tm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (tm *TerraformModule) SetName(name string) {
+	tm.name = name
+}
+
+func (tm *TerraformModule) SetProperties(properties map[string]string) {
+	tm.properties = properties
+}
+
+func (tm *TerraformModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !tm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: tm.name, ModuleType: entities.Terraform, Artifacts: artifacts}
+	return tm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (tm *TerraformModule) loadDependencies() ([]entities.Dependency, error) {
+	dataDir := buildutils.GetTerraformDataDir(tm.srcPath)
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+
+	lockFileExists, err := utils.IsFileExists(filepath.Join(tm.srcPath, buildutils.TerraformLockFileName), true)
+	if err != nil {
+		return nil, err
+	}
+	if lockFileExists {
+		providers, err := buildutils.ReadTerraformLockFile(tm.srcPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, provider := range providers {
+			dependency, err := tm.createTerraformProviderDependency(dataDir, provider)
+			if err != nil {
+				return nil, err
+			}
+			dependenciesMap[provider.Id()] = dependency
+			dependenciesGraph[tm.name] = append(dependenciesGraph[tm.name], provider.Id())
+		}
+	}
+
+	modules, err := buildutils.ReadTerraformModulesManifest(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	addTerraformModulesToGraph(tm.name, modules, dependenciesMap, dependenciesGraph)
+
+	if len(dependenciesMap) == 0 {
+		tm.containingBuild.logger.Debug(fmt.Sprintf("No providers or remote modules were found in %s. Run 'terraform init' to install them.", tm.srcPath))
+	}
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(tm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, tm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a provider.
+// The checksums are calculated from the provider's executable for the current platform, if it was installed.
+func (tm *TerraformModule) createTerraformProviderDependency(dataDir string, provider buildutils.TerraformProvider) (entities.Dependency, error) {
+	dependency := entities.Dependency{Id: provider.Id(), Type: "provider"}
+	setDependencyProperties(&dependency, map[string]string{
+		TerraformConstraintsProperty: provider.Constraints,
+		TerraformHashesProperty:      strings.Join(provider.Hashes, ","),
+	})
+	executablePath, err := buildutils.FindTerraformProviderExecutable(dataDir, provider)
+	if err != nil || executablePath == "" {
+		return dependency, err
+	}
+	checksum, err := tm.containingBuild.getFileChecksum(executablePath)
+	if err != nil {
+		return dependency, err
+	}
+	dependency.Checksum = checksum
+	return dependency, nil
+}
+
+// Adds the remote modules to the dependencies map and graph.
+// Local modules aren't dependencies, so the remote modules they call are added as if they were called by the closest remote module above them (or by the root module).
+func addTerraformModulesToGraph(rootId string, modules []buildutils.TerraformModuleRecord, dependenciesMap map[string]entities.Dependency, dependenciesGraph map[string][]string) {
+	modulesByKeys := make(map[string]buildutils.TerraformModuleRecord)
+	for _, module := range modules {
+		modulesByKeys[module.Key] = module
+	}
+	for _, module := range modules {
+		if module.Key == "" || module.IsLocal() {
+			continue
+		}
+		parentId := rootId
+		for parentKey := module.GetParentKey(); parentKey != ""; {
+			parent := modulesByKeys[parentKey]
+			if !parent.IsLocal() {
+				parentId = parent.Id()
+				break
+			}
+			parentKey = parent.GetParentKey()
+		}
+		dependenciesGraph[parentId] = append(dependenciesGraph[parentId], module.Id())
+		dependenciesMap[module.Id()] = entities.Dependency{Id: module.Id(), Type: "module"}
+	}
+}
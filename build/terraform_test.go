@@ -0,0 +1,65 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForTerraformProject(t *testing.T) {
+	// Install the aws provider in the plugin cache, for the current platform.
+	pluginCacheDir := t.TempDir()
+	providerDir := filepath.Join(pluginCacheDir, "registry.terraform.io", "hashicorp", "aws", "5.17.0", runtime.GOOS+"_"+runtime.GOARCH)
+	assert.NoError(t, os.MkdirAll(providerDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(providerDir, "terraform-provider-aws_v5.17.0_x5"), []byte("provider"), 0755))
+	t.Setenv("TF_PLUGIN_CACHE_DIR", pluginCacheDir)
+
+	service := NewBuildInfoService()
+	terraformBuild, err := service.GetOrCreateBuild("build-info-go-test-terraform", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, terraformBuild.Clean())
+	}()
+	terraformModule, err := terraformBuild.AddTerraformModule(filepath.Join("testdata", "terraform", "project"))
+	if assert.NoError(t, err) {
+		err = terraformModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := terraformBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Terraform, module.Type)
+		assert.Equal(t, "project", module.Id)
+
+		assert.Len(t, module.Dependencies, 4)
+		for _, dependency := range module.Dependencies {
+			// The modules called by the local 'network' module are dependencies of the root module.
+			assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy, dependency.Id)
+			switch dependency.Id {
+			case "registry.terraform.io/hashicorp/aws:5.17.0":
+				assert.Equal(t, "provider", dependency.Type)
+				assert.Equal(t, map[string]string{
+					TerraformConstraintsProperty: "~> 5.0",
+					TerraformHashesProperty:      "h1:rplvK7UGP7FuzM44t2eRX+QYYPC0aUIoKdi5XayRI8M=,zh:0087b9dd2c9c638fd63e527e5b9b70988008e263d480a199f180ad2a0c0e7c2a,zh:0fd6b7d5f0e6f1a1e4c2b0e0a7a0e8b7d6e3f2b8c1f4a3e0d2c7b6a5f4e3d2c1",
+				}, dependency.Properties)
+				// Calculated from the provider's executable in the plugin cache.
+				assert.NotEmpty(t, dependency.Md5)
+				assert.NotEmpty(t, dependency.Sha1)
+				assert.NotEmpty(t, dependency.Sha256)
+			case "registry.terraform.io/hashicorp/random:3.5.1":
+				assert.Equal(t, "provider", dependency.Type)
+				assert.Equal(t, "h1:IL9mSatmwov+e0+++YX2V6uel+dV6bn+fC/cnGDK3Ls=", dependency.Properties[TerraformHashesProperty])
+				// The provider isn't installed.
+				assert.True(t, dependency.Checksum.IsEmpty())
+			case "registry.terraform.io/terraform-aws-modules/vpc/aws:5.1.2", "git::https://github.com/cloudposse/terraform-null-label.git?ref=0.25.0":
+				assert.Equal(t, "module", dependency.Type)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+plugins {
+    id("com.android.application")
+}
+
+android {
+    namespace = "com.example.hello"
+    compileSdk = 34
+    buildToolsVersion = "34.0.0"
+    ndkVersion = "26.1.10909125"
+
+    defaultConfig {
+        applicationId = "com.example.hello"
+        minSdk = 24
+        targetSdk = 34
+    }
+}
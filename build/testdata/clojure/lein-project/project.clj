@@ -0,0 +1,5 @@
+(defproject org.example/hello "0.1.0-SNAPSHOT"
+  :description "A sample Leiningen project"
+  :dependencies [[org.clojure/clojure "1.11.1"]
+                 [cheshire "5.12.0"]
+                 [clj-http "3.12.3"]])
@@ -0,0 +1 @@
+#include "fmt/core.h"
@@ -0,0 +1 @@
+#include "nlohmann/json.hpp"
@@ -0,0 +1,14 @@
+from conan import ConanFile
+
+
+class HelloConan(ConanFile):
+    name = "hello"
+    version = "1.0"
+    settings = "os", "compiler", "build_type", "arch"
+
+    def requirements(self):
+        self.requires("openssl/3.1.2")
+
+    def build_requirements(self):
+        self.tool_requires("cmake/3.27.1")
+        self.test_requires("gtest/1.14.0")
@@ -0,0 +1,5 @@
+import { join } from "@std/path";
+import chalk from "chalk";
+import { red } from "https://deno.land/std@0.200.0/fmt/colors.ts";
+
+console.log(chalk.green(join("a", "b")), red("!"));
@@ -0,0 +1,9 @@
+module example.com/retracted
+
+go 1.16
+
+retract (
+	// Contains a security vulnerability.
+	[v1.0.0, v1.0.5]
+	v1.1.0 // Published accidentally.
+)
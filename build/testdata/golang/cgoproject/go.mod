@@ -0,0 +1,3 @@
+module github.com/jfrog/cgoproject
+
+go 1.19
@@ -0,0 +1,12 @@
+package main
+
+// #cgo pkg-config: fakemath
+// #include <math.h>
+// double hypotenuse(double a, double b) { return hypot(a, b); }
+import "C"
+
+import "fmt"
+
+func main() {
+	fmt.Println(C.hypotenuse(3, 4))
+}
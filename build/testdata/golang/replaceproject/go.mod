@@ -0,0 +1,12 @@
+module github.com/jfrog/replaceproject
+
+go 1.19
+
+require (
+	example.com/local v0.0.0
+	github.com/pkg/errors v0.8.0
+)
+
+replace github.com/pkg/errors v0.8.0 => github.com/pkg/errors v0.9.1
+
+replace example.com/local => ./local
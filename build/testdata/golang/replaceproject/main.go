@@ -0,0 +1,12 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/local"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	fmt.Println(errors.New(local.Name()))
+}
@@ -0,0 +1,7 @@
+//go:build !special && !windows
+
+package main
+
+func extra() string {
+	return ""
+}
@@ -0,0 +1,16 @@
+module github.com/jfrog/tagsproject
+
+go 1.19
+
+toolchain go1.21.0
+
+require (
+	github.com/BurntSushi/toml v0.4.2-0.20211125115023-7d0236fe7476
+	github.com/pkg/errors v0.8.0
+	rsc.io/quote v1.5.2
+)
+
+require (
+	golang.org/x/text v0.0.0-20170915032832-14c0d48ead0c // indirect
+	rsc.io/sampler v1.3.0 // indirect
+)
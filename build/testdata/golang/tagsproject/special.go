@@ -0,0 +1,9 @@
+//go:build special && !windows
+
+package main
+
+import "github.com/pkg/errors"
+
+func extra() string {
+	return errors.New("special").Error()
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import "github.com/BurntSushi/toml"
+
+func extra() string {
+	var value map[string]string
+	_, _ = toml.Decode("", &value)
+	return ""
+}
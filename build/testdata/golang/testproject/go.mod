@@ -0,0 +1,13 @@
+module github.com/jfrog/testproject
+
+go 1.17
+
+require (
+	github.com/pkg/errors v0.8.0
+	rsc.io/quote v1.5.2
+)
+
+require (
+	golang.org/x/text v0.0.0-20170915032832-14c0d48ead0c // indirect
+	rsc.io/sampler v1.3.0 // indirect
+)
@@ -0,0 +1,7 @@
+package testproject
+
+import "github.com/pkg/errors"
+
+func NewError() error {
+	return errors.New("abc")
+}
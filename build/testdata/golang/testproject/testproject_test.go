@@ -0,0 +1,13 @@
+package testproject
+
+import (
+	"testing"
+
+	"rsc.io/quote"
+)
+
+func TestNewError(t *testing.T) {
+	if NewError() == nil {
+		t.Error(quote.Hello())
+	}
+}
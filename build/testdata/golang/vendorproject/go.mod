@@ -0,0 +1,10 @@
+module github.com/jfrog/vendorproject
+
+go 1.19
+
+require rsc.io/quote v1.5.2
+
+require (
+	golang.org/x/text v0.0.0-20170915032832-14c0d48ead0c // indirect
+	rsc.io/sampler v1.3.0 // indirect
+)
@@ -0,0 +1,8 @@
+package a
+
+import (
+	"example.com/b"
+	"rsc.io/quote"
+)
+
+func Hello() string { return quote.Hello() + b.Name() }
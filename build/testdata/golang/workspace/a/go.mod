@@ -0,0 +1,5 @@
+module example.com/a
+
+go 1.19
+
+require rsc.io/quote v1.5.2
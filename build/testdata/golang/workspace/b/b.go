@@ -0,0 +1,5 @@
+package b
+
+import "github.com/pkg/errors"
+
+func Name() string { return errors.New("b").Error() }
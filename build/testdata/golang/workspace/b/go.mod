@@ -0,0 +1,5 @@
+module example.com/b
+
+go 1.19
+
+require github.com/pkg/errors v0.8.0
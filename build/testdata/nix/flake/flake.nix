@@ -0,0 +1,17 @@
+{
+  description = "A flake for testing the build-info Nix module";
+
+  inputs = {
+    nixpkgs.url = "github:NixOS/nixpkgs/nixos-23.11";
+    flake-utils.url = "github:numtide/flake-utils";
+    home-manager = {
+      url = "git+https://github.com/nix-community/home-manager?ref=release-23.11";
+      inputs.nixpkgs.follows = "nixpkgs";
+    };
+  };
+
+  outputs = { self, nixpkgs, flake-utils, home-manager }:
+    flake-utils.lib.eachDefaultSystem (system: {
+      packages.default = nixpkgs.legacyPackages.${system}.hello;
+    });
+}
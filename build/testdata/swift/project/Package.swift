@@ -0,0 +1,16 @@
+// swift-tools-version:5.7
+import PackageDescription
+
+let package = Package(
+    name: "SwiftProject",
+    dependencies: [
+        .package(url: "https://github.com/apple/swift-argument-parser", from: "1.2.0"),
+        .package(url: "https://github.com/vapor/vapor.git", branch: "main"),
+    ],
+    targets: [
+        .executableTarget(name: "SwiftProject", dependencies: [
+            .product(name: "ArgumentParser", package: "swift-argument-parser"),
+            .product(name: "Vapor", package: "vapor"),
+        ]),
+    ]
+)
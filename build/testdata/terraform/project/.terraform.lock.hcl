@@ -0,0 +1,18 @@
+# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.17.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:rplvK7UGP7FuzM44t2eRX+QYYPC0aUIoKdi5XayRI8M=",
+    "zh:0087b9dd2c9c638fd63e527e5b9b70988008e263d480a199f180ad2a0c0e7c2a",
+    "zh:0fd6b7d5f0e6f1a1e4c2b0e0a7a0e8b7d6e3f2b8c1f4a3e0d2c7b6a5f4e3d2c1",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version     = "3.5.1"
+  constraints = "3.5.1"
+  hashes      = ["h1:IL9mSatmwov+e0+++YX2V6uel+dV6bn+fC/cnGDK3Ls="]
+}
@@ -0,0 +1,3 @@
+module "labels" {
+  source = "git::https://github.com/cloudposse/terraform-null-label.git?ref=0.25.0"
+}
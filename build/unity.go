@@ -0,0 +1,179 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency property, which holds the URL of the registry or of the git repository, from which a package was installed.
+	UnityUrlProperty = "unity.url"
+)
+
+type UnityModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the Packages/packages-lock.json file in the working directory or in its parents.
+func newUnityModule(srcPath string, containingBuild *Build) (*UnityModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, filepath.Join(buildutils.UnityPackagesDir, buildutils.UnityPackagesLockFileName))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	name, version, err := buildutils.GetUnityProductNameAndVersion(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No product name is set in the player settings. Using the directory name: %s as module name.", name))
+	} else if version != "" {
+		name += ":" + version
+	}
+
+	return &UnityModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the packages listed in the Packages/packages-lock.json file.
+func (um *UnityModule) CalcDependencies() error {
+	started := time.Now()
+	if !um.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := um.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: um.name, Type: entities.Unity, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(um.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return um.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (um *UnityModule) SetName(name string) {
+	um.name = name
+}
+
+func (um *UnityModule) SetProperties(properties map[string]string) {
+	um.properties = properties
+}
+
+func (um *UnityModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !um.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: um.name, ModuleType: entities.Unity, Artifacts: artifacts}
+	return um.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (um *UnityModule) loadDependencies() ([]entities.Dependency, error) {
+	packages, err := buildutils.ReadUnityPackagesLock(um.srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the %s file: %s. Open the project in the Unity Editor to resolve its packages", buildutils.GetUnityPackagesLockPath(um.srcPath), err.Error())
+	}
+	cacheRoot, err := buildutils.GetUnityCacheRoot()
+	if err != nil {
+		um.containingBuild.logger.Debug("Couldn't find the global cache of the Unity Package Manager:", err.Error())
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	idsByName := make(map[string]string)
+	for _, pkg := range packages {
+		dependency, err := um.createUnityDependency(pkg, cacheRoot)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[dependency.Id] = dependency
+		idsByName[pkg.Name] = dependency.Id
+	}
+	for _, pkg := range packages {
+		id := idsByName[pkg.Name]
+		for _, name := range pkg.Dependencies {
+			dependenciesGraph[id] = append(dependenciesGraph[id], idsByName[name])
+		}
+		// The packages listed in the manifest of the project have depth 0.
+		if pkg.Depth == 0 {
+			dependenciesGraph[um.name] = append(dependenciesGraph[um.name], id)
+		}
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(um.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, um.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a Unity package. The type of the dependency is the source of the package, for example: 'registry' or 'git'.
+// The checksums of registry packages are calculated from their tarballs in the global cache, and the checksums of local tarballs from their files.
+// The sha1 checksum of packages installed from git repositories is their commit.
+func (um *UnityModule) createUnityDependency(pkg buildutils.UnityPackage, cacheRoot string) (entities.Dependency, error) {
+	version := pkg.Version
+	tarballPath := ""
+	var err error
+	switch pkg.Source {
+	case buildutils.UnityRegistrySource:
+		if cacheRoot != "" {
+			if tarballPath, err = buildutils.FindUnityRegistryTarball(cacheRoot, pkg); err != nil {
+				return entities.Dependency{}, err
+			}
+		}
+	case buildutils.UnityGitSource:
+		if pkg.Hash != "" {
+			version = pkg.Hash
+		}
+	case buildutils.UnityEmbeddedSource, buildutils.UnityLocalSource:
+		// The versions of these packages are taken from their package.json files, instead of their paths.
+		localVersion, err := buildutils.GetUnityPackageVersion(pkg.GetLocalPath(um.srcPath))
+		if err != nil {
+			return entities.Dependency{}, err
+		}
+		if localVersion != "" {
+			version = localVersion
+		}
+	case buildutils.UnityLocalTarballSource:
+		tarballPath = pkg.GetLocalPath(um.srcPath)
+	}
+	dependency := entities.Dependency{Id: pkg.Name + ":" + version, Type: pkg.Source}
+	if tarballPath != "" {
+		exists, err := utils.IsFileExists(tarballPath, true)
+		if err != nil {
+			return dependency, err
+		}
+		if exists {
+			checksum, err := um.containingBuild.getFileChecksum(tarballPath)
+			if err != nil {
+				return dependency, err
+			}
+			dependency.Checksum = checksum
+		}
+	}
+	url := pkg.Url
+	if pkg.Source == buildutils.UnityGitSource {
+		url = pkg.Version
+		if buildutils.IsGitCommit(pkg.Hash) {
+			dependency.Sha1 = pkg.Hash
+		}
+	}
+	setDependencyProperties(&dependency, map[string]string{UnityUrlProperty: url})
+	return dependency, nil
+}
@@ -0,0 +1,64 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForUnityProject(t *testing.T) {
+	t.Setenv("UPM_CACHE_ROOT", filepath.Join("testdata", "unity", "cache"))
+	service := NewBuildInfoService()
+	unityBuild, err := service.GetOrCreateBuild("build-info-go-test-unity", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, unityBuild.Clean())
+	}()
+	unityModule, err := unityBuild.AddUnityModule(filepath.Join("testdata", "unity", "project"))
+	if assert.NoError(t, err) {
+		err = unityModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := unityBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Unity, module.Type)
+		// The module is named after the product name and the version in the player settings.
+		assert.Equal(t, "Hello Game:0.3.1", module.Id)
+
+		assert.Len(t, module.Dependencies, 7)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "com.company.embedded:0.1.0", "com.company.local:3.4.5":
+				// The versions are taken from the package.json files of the packages.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "com.company.tarball:file:../Tarballs/com.company.tarball-2.0.0.tgz":
+				assert.Equal(t, "local-tarball", dependency.Type)
+				assert.Equal(t, "aa10a215cba4895be93c145eb55ab976", dependency.Md5)
+				assert.NotEmpty(t, dependency.Sha256)
+			case "com.github.tools:5e1f1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6":
+				assert.Equal(t, "git", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha1: "5e1f1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6"}, dependency.Checksum)
+				assert.Equal(t, map[string]string{UnityUrlProperty: "https://github.com/example/unity-tools.git#v1.2.0"}, dependency.Properties)
+			case "com.unity.ext.nunit:1.0.6":
+				assert.Equal(t, "registry", dependency.Type)
+				// The checksums of the tarball in the global cache.
+				assert.Equal(t, "8cc7d07b7739054c905ab1e874ab6803", dependency.Md5)
+				assert.Equal(t, map[string]string{UnityUrlProperty: "https://packages.unity.com"}, dependency.Properties)
+				assert.Equal(t, [][]string{{"com.unity.test-framework:1.1.33", module.Id}}, dependency.RequestedBy)
+			case "com.unity.modules.ui:1.0.0":
+				assert.Equal(t, "builtin", dependency.Type)
+				assert.ElementsMatch(t, [][]string{{"com.company.embedded:0.1.0", module.Id}, {"com.unity.test-framework:1.1.33", module.Id}}, dependency.RequestedBy)
+			case "com.unity.test-framework:1.1.33":
+				// The tarball isn't in the global cache.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
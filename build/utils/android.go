@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	AndroidLocalPropertiesFileName = "local.properties"
+	// The properties file, which describes a component installed in the Android SDK.
+	androidSourcePropertiesFileName = "source.properties"
+	androidPlatformJarFileName      = "android.jar"
+)
+
+var (
+	// The versions of the SDK platform, build-tools and NDK, which are set in the Gradle build files of the modules of an Android project,
+	// for example: 'compileSdk 34', 'compileSdkVersion "android-34"', 'buildToolsVersion = "34.0.0"' or 'ndkVersion "26.1.10909125"'.
+	// Versions, which are set using variables, can't be resolved without evaluating the build files.
+	androidGradleVersionRegExp   = regexp.MustCompile(`(?m)^\s*(compileSdk|compileSdkVersion|buildToolsVersion|ndkVersion)\s*(?:=\s*|\(\s*)?["']?(?:android-)?(\d[\w.\-]*)["']?`)
+	androidRootProjectNameRegExp = regexp.MustCompile(`rootProject\.name\s*=\s*["']([^"']+)["']`)
+)
+
+// AndroidComponentsVersions holds the versions of the Android SDK components, which are used by the modules of an Android project.
+type AndroidComponentsVersions struct {
+	// The API levels of the SDK platforms, which the modules are compiled against, for example: '34'.
+	Platforms  []string
+	BuildTools []string
+	Ndks       []string
+}
+
+// AndroidSdkComponent represents a component installed in the Android SDK.
+type AndroidSdkComponent struct {
+	// The path of the component in the SDK, as used by sdkmanager, for example: 'platforms;android-34' or 'build-tools;34.0.0'.
+	SdkPath  string
+	Revision string
+	// The directory, in which the component is installed.
+	Dir string
+}
+
+// GetAndroidRootProjectName returns the name of the root project, as set in the settings.gradle(.kts) file of an Android project.
+// An empty string is returned if the name isn't set.
+func GetAndroidRootProjectName(srcPath string) (string, error) {
+	for _, settingsFileName := range []string{"settings.gradle", "settings.gradle.kts"} {
+		content, err := readFileIfExists(filepath.Join(srcPath, settingsFileName))
+		if err != nil {
+			return "", err
+		}
+		if match := androidRootProjectNameRegExp.FindSubmatch(content); match != nil {
+			return string(match[1]), nil
+		}
+	}
+	return "", nil
+}
+
+// GetAndroidSdkRoot returns the path of the Android SDK, as set by the sdk.dir property in the local.properties file of the project,
+// or by the ANDROID_HOME or ANDROID_SDK_ROOT environment variables.
+func GetAndroidSdkRoot(srcPath string) (string, error) {
+	localProperties, err := ReadJavaPropertiesFile(filepath.Join(srcPath, AndroidLocalPropertiesFileName))
+	if err != nil {
+		return "", err
+	}
+	if sdkDir := localProperties["sdk.dir"]; sdkDir != "" {
+		return sdkDir, nil
+	}
+	if androidHome := os.Getenv("ANDROID_HOME"); androidHome != "" {
+		return androidHome, nil
+	}
+	return os.Getenv("ANDROID_SDK_ROOT"), nil
+}
+
+// GetAndroidComponentsVersions returns the versions of the Android SDK components, which are set in the Gradle build files of the project and of its modules.
+func GetAndroidComponentsVersions(srcPath string) (*AndroidComponentsVersions, error) {
+	versions := new(AndroidComponentsVersions)
+	err := filepath.WalkDir(srcPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			// Skip the build outputs and the hidden directories, such as .gradle and .git.
+			if path != srcPath && (entry.Name() == "build" || strings.HasPrefix(entry.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != "build.gradle" && entry.Name() != "build.gradle.kts" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range androidGradleVersionRegExp.FindAllStringSubmatch(string(content), -1) {
+			switch match[1] {
+			case "compileSdk", "compileSdkVersion":
+				if !slices.Contains(versions.Platforms, match[2]) {
+					versions.Platforms = append(versions.Platforms, match[2])
+				}
+			case "buildToolsVersion":
+				if !slices.Contains(versions.BuildTools, match[2]) {
+					versions.BuildTools = append(versions.BuildTools, match[2])
+				}
+			case "ndkVersion":
+				if !slices.Contains(versions.Ndks, match[2]) {
+					versions.Ndks = append(versions.Ndks, match[2])
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(versions.Platforms)
+	sort.Strings(versions.BuildTools)
+	sort.Strings(versions.Ndks)
+	return versions, nil
+}
+
+// GetAndroidPlatform returns the SDK platform of the given API level, as installed in the Android SDK.
+// A nil component is returned if the platform isn't installed.
+func GetAndroidPlatform(sdkRoot, apiLevel string) (*AndroidSdkComponent, error) {
+	return getAndroidSdkComponent(filepath.Join(sdkRoot, "platforms", "android-"+apiLevel), "platforms;android-"+apiLevel)
+}
+
+// GetAndroidBuildTools returns the build-tools of the given version, as installed in the Android SDK.
+// A nil component is returned if the build-tools aren't installed.
+func GetAndroidBuildTools(sdkRoot, version string) (*AndroidSdkComponent, error) {
+	return getAndroidSdkComponent(filepath.Join(sdkRoot, "build-tools", version), "build-tools;"+version)
+}
+
+// GetAndroidNdk returns the NDK of the given version, as installed side by side in the ndk directory of the Android SDK,
+// or in its legacy ndk-bundle directory. A nil component is returned if the NDK isn't installed.
+func GetAndroidNdk(sdkRoot, version string) (*AndroidSdkComponent, error) {
+	ndk, err := getAndroidSdkComponent(filepath.Join(sdkRoot, "ndk", version), "ndk;"+version)
+	if err != nil || ndk != nil {
+		return ndk, err
+	}
+	ndk, err = getAndroidSdkComponent(filepath.Join(sdkRoot, "ndk-bundle"), "ndk-bundle")
+	if err != nil || ndk == nil || ndk.Revision != version {
+		return nil, err
+	}
+	return ndk, nil
+}
+
+// GetAndroidNdkFromDir returns the NDK installed in the given directory, as set by the ndk.dir property in the local.properties file of a project.
+// A nil component is returned if the directory doesn't contain an NDK.
+func GetAndroidNdkFromDir(ndkDir string) (*AndroidSdkComponent, error) {
+	ndk, err := getAndroidSdkComponent(ndkDir, "")
+	if err != nil || ndk == nil {
+		return nil, err
+	}
+	ndk.SdkPath = "ndk;" + ndk.Revision
+	return ndk, nil
+}
+
+// GetPlatformJarPath returns the path of the android.jar file of an SDK platform, which the modules of the project are compiled against.
+func (asc *AndroidSdkComponent) GetPlatformJarPath() string {
+	return filepath.Join(asc.Dir, androidPlatformJarFileName)
+}
+
+func getAndroidSdkComponent(dir, sdkPath string) (*AndroidSdkComponent, error) {
+	sourceProperties, err := ReadJavaPropertiesFile(filepath.Join(dir, androidSourcePropertiesFileName))
+	if err != nil || len(sourceProperties) == 0 {
+		return nil, err
+	}
+	return &AndroidSdkComponent{SdkPath: sdkPath, Revision: sourceProperties["Pkg.Revision"], Dir: dir}, nil
+}
+
+// ReadJavaPropertiesFile reads the 'key=value' (or 'key:value') pairs of a Java properties file.
+// Backslashes escape the characters, which follow them, for example: 'sdk.dir=C\:\\Android\\sdk'. Line continuations aren't supported.
+// An empty map is returned if the file doesn't exist.
+func ReadJavaPropertiesFile(path string) (map[string]string, error) {
+	properties := make(map[string]string)
+	content, err := readFileIfExists(path)
+	if err != nil || content == nil {
+		return properties, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		separatorIndex := strings.IndexAny(line, "=:")
+		// Skip the escaped separators in the key.
+		for separatorIndex > 0 && line[separatorIndex-1] == '\\' {
+			nextIndex := strings.IndexAny(line[separatorIndex+1:], "=:")
+			if nextIndex < 0 {
+				separatorIndex = -1
+				break
+			}
+			separatorIndex += nextIndex + 1
+		}
+		if separatorIndex < 0 {
+			continue
+		}
+		key := unescapeJavaProperty(strings.TrimSpace(line[:separatorIndex]))
+		properties[key] = unescapeJavaProperty(strings.TrimSpace(line[separatorIndex+1:]))
+	}
+	return properties, scanner.Err()
+}
+
+func unescapeJavaProperty(value string) string {
+	var unescaped strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n':
+				unescaped.WriteByte('\n')
+			case 't':
+				unescaped.WriteByte('\t')
+			default:
+				unescaped.WriteByte(value[i])
+			}
+			continue
+		}
+		unescaped.WriteByte(value[i])
+	}
+	return unescaped.String()
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAndroidComponentsVersions(t *testing.T) {
+	versions, err := GetAndroidComponentsVersions(filepath.Join("..", "testdata", "android", "project"))
+	require.NoError(t, err)
+	assert.Equal(t, &AndroidComponentsVersions{Platforms: []string{"33", "34"}, BuildTools: []string{"34.0.0"}, Ndks: []string{"26.1.10909125"}}, versions)
+}
+
+func TestGetAndroidRootProjectName(t *testing.T) {
+	name, err := GetAndroidRootProjectName(filepath.Join("..", "testdata", "android", "project"))
+	require.NoError(t, err)
+	assert.Equal(t, "HelloApp", name)
+
+	name, err = GetAndroidRootProjectName(filepath.Join("..", "testdata", "android", "ndkproject"))
+	require.NoError(t, err)
+	assert.Empty(t, name)
+}
+
+func TestGetAndroidNdk(t *testing.T) {
+	sdkRoot := filepath.Join("..", "testdata", "android", "sdk")
+	ndk, err := GetAndroidNdk(sdkRoot, "26.1.10909125")
+	require.NoError(t, err)
+	assert.Equal(t, &AndroidSdkComponent{SdkPath: "ndk;26.1.10909125", Revision: "26.1.10909125", Dir: filepath.Join(sdkRoot, "ndk", "26.1.10909125")}, ndk)
+
+	// The legacy ndk-bundle directory is used, only if it contains the requested version.
+	ndk, err = GetAndroidNdk(sdkRoot, "21.4.7075529")
+	require.NoError(t, err)
+	assert.Equal(t, &AndroidSdkComponent{SdkPath: "ndk-bundle", Revision: "21.4.7075529", Dir: filepath.Join(sdkRoot, "ndk-bundle")}, ndk)
+
+	ndk, err = GetAndroidNdk(sdkRoot, "25.2.9519653")
+	require.NoError(t, err)
+	assert.Nil(t, ndk)
+}
+
+func TestReadJavaPropertiesFile(t *testing.T) {
+	properties, err := ReadJavaPropertiesFile(filepath.Join("..", "testdata", "android", "ndkproject", "local.properties"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"sdk.dir": `C:\Android\sdk`, "ndk.dir": "../sdk/ndk-bundle"}, properties)
+
+	// A missing file.
+	properties, err = ReadJavaPropertiesFile(filepath.Join("..", "testdata", "android", "project", "local.properties"))
+	require.NoError(t, err)
+	assert.Empty(t, properties)
+}
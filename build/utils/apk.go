@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The prefix of the checksums in the installed database, which are base64-encoded sha1 digests.
+	apkSha1ChecksumPrefix = "Q1"
+	// The version of an Alpine package starts with a digit, and ends with its release, for example: '3.1.2-r0'.
+	apkVersionSuffixPattern = `-\d[^-]*-r\d+$`
+)
+
+var (
+	// The paths of the installed database and the world file (which lists the explicitly installed packages), relative to the root directory of the system.
+	ApkInstalledDbPath = filepath.Join("lib", "apk", "db", "installed")
+	ApkWorldFilePath   = filepath.Join("etc", "apk", "world")
+
+	apkVersionSuffixRegExp = regexp.MustCompile(apkVersionSuffixPattern)
+	// The operators, which separate the name of a dependency from its version constraint, for example: 'musl>=1.2'.
+	apkConstraintRegExp = regexp.MustCompile(`[<>=~].*$`)
+)
+
+// ApkPackage represents an Alpine package installed on a system.
+type ApkPackage struct {
+	Name    string
+	Version string
+	Arch    string
+	// The source package, from which the package was built, and the commit of aports it was built from.
+	Origin  string
+	Commit  string
+	License string
+	// The sha1 checksum of the control section of the package, which identifies the package.
+	Sha1 string
+	// The names and provided names (such as 'so:libc.musl-x86_64.so.1') of the packages this package depends on.
+	Depends []string
+	// The names (such as 'so:libcrypto.so.3' or 'cmd:openssl'), which this package provides.
+	Provides []string
+}
+
+func (ap *ApkPackage) Id() string {
+	return ap.Name + ":" + ap.Version
+}
+
+// ReadApkInstalledDb returns the packages listed in the installed database of the system in the given root directory, sorted by their names.
+// The database consists of records, which are separated by empty lines. Each line in a record holds a field, identified by a single letter, for example: 'P:musl'.
+func ReadApkInstalledDb(rootDir string) ([]ApkPackage, error) {
+	content, err := os.ReadFile(filepath.Join(rootDir, ApkInstalledDbPath))
+	if err != nil {
+		return nil, err
+	}
+	var packages []ApkPackage
+	pkg := ApkPackage{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if pkg.Name != "" {
+				packages = append(packages, pkg)
+			}
+			pkg = ApkPackage{}
+			continue
+		}
+		field, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		switch field {
+		case "P":
+			pkg.Name = value
+		case "V":
+			pkg.Version = value
+		case "A":
+			pkg.Arch = value
+		case "o":
+			pkg.Origin = value
+		case "c":
+			pkg.Commit = value
+		case "L":
+			pkg.License = value
+		case "C":
+			if pkg.Sha1, err = apkChecksumToSha1(value); err != nil {
+				return nil, fmt.Errorf("failed parsing the checksum of the package %s: %s", pkg.Name, err.Error())
+			}
+		case "D":
+			pkg.Depends = strings.Fields(value)
+		case "p":
+			pkg.Provides = strings.Fields(value)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if pkg.Name != "" {
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+// Converts a checksum in the installed database, such as 'Q1Kt7pW6cIn9EAezQCAWamK/u17ew=', to a hex-encoded sha1 digest.
+// Checksums, which aren't sha1 digests (such as legacy md5 checksums), are ignored.
+func apkChecksumToSha1(checksum string) (string, error) {
+	if !strings.HasPrefix(checksum, apkSha1ChecksumPrefix) {
+		return "", nil
+	}
+	digest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(checksum, apkSha1ChecksumPrefix))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest), nil
+}
+
+// ReadApkWorld returns the names of the packages, which were explicitly installed on the system in the given root directory, as listed in its world file.
+// An empty list is returned if the system doesn't have a world file.
+func ReadApkWorld(rootDir string) ([]string, error) {
+	worldPath := filepath.Join(rootDir, ApkWorldFilePath)
+	exists, err := utils.IsFileExists(worldPath, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := os.ReadFile(worldPath)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, constraint := range strings.Fields(string(content)) {
+		// The constraints may pin a repository (for example: 'curl@edge') or a version (for example: 'curl>=8.0').
+		name, _, _ := strings.Cut(GetApkDependencyName(constraint), "@")
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// GetApkDependencyName returns the name (or provided name) of a dependency, without its version constraint, for example: 'musl' for 'musl>=1.2'.
+// An empty string is returned for conflicts, which are prefixed with '!'.
+func GetApkDependencyName(dependency string) string {
+	if strings.HasPrefix(dependency, "!") {
+		return ""
+	}
+	return apkConstraintRegExp.ReplaceAllString(dependency, "")
+}
+
+// RunApkInfo returns the packages installed on the system in the given root directory, as listed by 'apk info -v'.
+// The output only lists the names and versions of the packages, so it's used only if the installed database can't be read.
+func RunApkInfo(rootDir string) ([]ApkPackage, error) {
+	args := []string{"-v"}
+	if rootDir != "" && rootDir != "/" {
+		args = append(args, "--root", rootDir)
+	}
+	output, err := utils.NewCommand("apk", "info", args).RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed running 'apk info': %s", err.Error())
+	}
+	return ParseApkInfo(output), nil
+}
+
+// ParseApkInfo parses the output of 'apk info -v', in which each line holds a name and a version, for example: 'musl-1.2.4-r2'.
+func ParseApkInfo(output []byte) []ApkPackage {
+	var packages []ApkPackage
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		suffix := apkVersionSuffixRegExp.FindString(line)
+		if suffix == "" {
+			continue
+		}
+		packages = append(packages, ApkPackage{Name: strings.TrimSuffix(line, suffix), Version: strings.TrimPrefix(suffix, "-")})
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages
+}
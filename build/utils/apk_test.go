@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadApkInstalledDb(t *testing.T) {
+	packages, err := ReadApkInstalledDb(filepath.Join("..", "testdata", "apk", "root"))
+	require.NoError(t, err)
+	require.Len(t, packages, 7)
+	assert.Equal(t, "busybox", packages[0].Name)
+	assert.Equal(t, ApkPackage{
+		Name:     "libssl3",
+		Version:  "3.1.4-r1",
+		Arch:     "x86_64",
+		Origin:   "openssl",
+		Commit:   "b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0",
+		License:  "Apache-2.0",
+		Sha1:     "147e779ecc12fb06fc27a4fc318b3dafdb3fcc4a",
+		Depends:  []string{"so:libc.musl-x86_64.so.1", "so:libcrypto.so.3"},
+		Provides: []string{"so:libssl.so.3=3"},
+	}, packages[5])
+	assert.Equal(t, "musl:1.2.4-r2", packages[6].Id())
+}
+
+func TestReadApkWorld(t *testing.T) {
+	world, err := ReadApkWorld(filepath.Join("..", "testdata", "apk", "root"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"busybox", "curl"}, world)
+
+	// A system without a world file.
+	world, err = ReadApkWorld(filepath.Join("..", "testdata", "apk", "root-no-world"))
+	assert.NoError(t, err)
+	assert.Empty(t, world)
+}
+
+func TestGetApkDependencyName(t *testing.T) {
+	assert.Equal(t, "musl", GetApkDependencyName("musl>=1.2"))
+	assert.Equal(t, "so:libcrypto.so.3", GetApkDependencyName("so:libcrypto.so.3=3"))
+	assert.Equal(t, "curl", GetApkDependencyName("curl~8.4"))
+	assert.Empty(t, GetApkDependencyName("!curl-doc<8"))
+}
+
+func TestParseApkInfo(t *testing.T) {
+	output := []byte("WARNING: opening /var/cache/apk: No such file or directory\nbusybox-1.36.1-r5\nca-certificates-bundle-20230506-r0\nlibssl3-3.1.4-r1\n")
+	assert.Equal(t, []ApkPackage{
+		{Name: "busybox", Version: "1.36.1-r5"},
+		{Name: "ca-certificates-bundle", Version: "20230506-r0"},
+		{Name: "libssl3", Version: "3.1.4-r1"},
+	}, ParseApkInfo(output))
+}
+
+func TestGetOsModuleId(t *testing.T) {
+	id, err := GetOsModuleId(filepath.Join("..", "testdata", "apk", "root"), "default")
+	assert.NoError(t, err)
+	assert.Equal(t, "alpine:3.18.4", id)
+
+	fields, err := ReadOsRelease(filepath.Join("..", "testdata", "apk", "root"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Alpine Linux v3.18", fields["PRETTY_NAME"])
+
+	// A system without an os-release file.
+	id, err = GetOsModuleId(filepath.Join("..", "testdata", "apk", "root-no-world"), "default")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", id)
+}
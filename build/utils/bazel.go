@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	BazelModuleFileName = "MODULE.bazel"
+	// The WORKSPACE file names, in order of precedence.
+	BazelWorkspaceFileName       = "WORKSPACE.bazel"
+	BazelWorkspaceLegacyFileName = "WORKSPACE"
+	bazelIntegritySha256Prefix   = "sha256-"
+)
+
+var (
+	bazelModuleNameRegExp    = regexp.MustCompile(`(?s)module\s*\(.*?\bname\s*=\s*"([^"]+)"`)
+	bazelModuleVersionRegExp = regexp.MustCompile(`(?s)module\s*\(.*?\bversion\s*=\s*"([^"]+)"`)
+	bazelWorkspaceNameRegExp = regexp.MustCompile(`(?s)workspace\s*\(.*?\bname\s*=\s*"([^"]+)"`)
+	bazelRuleStartRegExp     = regexp.MustCompile(`^(\w+)\($`)
+	bazelAttributeRegExp     = regexp.MustCompile(`^\s+(\w+) = (.*?),?$`)
+	bazelStringRegExp        = regexp.MustCompile(`"([^"]*)"`)
+	// For example: '## rules_cc@0.0.9:'
+	bazelShowRepoHeaderRegExp = regexp.MustCompile(`^## (\S+):$`)
+)
+
+// BazelModule represents a node in the output of 'bazel mod graph --output json'.
+type BazelModule struct {
+	// The key of the module, for example: 'rules_cc@0.0.9' ('<root>' for the root module).
+	Key          string        `json:"key,omitempty"`
+	Name         string        `json:"name,omitempty"`
+	Version      string        `json:"version,omitempty"`
+	Dependencies []BazelModule `json:"dependencies,omitempty"`
+}
+
+func (bm *BazelModule) Id() string {
+	return bm.Name + ":" + bm.Version
+}
+
+// BazelRepository represents an external repository rule (such as http_archive), as printed by 'bazel query --output=build' or 'bazel mod show_repo'.
+type BazelRepository struct {
+	// The key of the Bazel module, which the repository was printed for by 'bazel mod show_repo'. Empty for WORKSPACE repositories.
+	ModuleKey string
+	Kind      string
+	Name      string
+	Urls      []string
+	// The sha256 checksum of the downloaded file, in hex.
+	Sha256 string
+}
+
+// GetBazelProjectName returns the name (and version, if defined) of the project, according to its MODULE.bazel or WORKSPACE file.
+// An empty string is returned if no name is defined.
+func GetBazelProjectName(srcPath string) (string, error) {
+	content, err := readFileIfExists(filepath.Join(srcPath, BazelModuleFileName))
+	if err != nil {
+		return "", err
+	}
+	if match := bazelModuleNameRegExp.FindSubmatch(content); match != nil {
+		name := string(match[1])
+		if versionMatch := bazelModuleVersionRegExp.FindSubmatch(content); versionMatch != nil {
+			name += ":" + string(versionMatch[1])
+		}
+		return name, nil
+	}
+	for _, workspaceFileName := range []string{BazelWorkspaceFileName, BazelWorkspaceLegacyFileName} {
+		content, err = readFileIfExists(filepath.Join(srcPath, workspaceFileName))
+		if err != nil {
+			return "", err
+		}
+		if match := bazelWorkspaceNameRegExp.FindSubmatch(content); match != nil {
+			return string(match[1]), nil
+		}
+	}
+	return "", nil
+}
+
+func readFileIfExists(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return content, err
+}
+
+// RunBazelModGraph runs 'bazel mod graph --output json' and returns the root module.
+func RunBazelModGraph(srcPath string) (*BazelModule, error) {
+	output, err := runBazelCommand(srcPath, "mod", "graph", "--output", "json")
+	if err != nil {
+		return nil, err
+	}
+	return ParseBazelModGraph(output)
+}
+
+// ParseBazelModGraph parses the output of 'bazel mod graph --output json'.
+func ParseBazelModGraph(output []byte) (*BazelModule, error) {
+	root := new(BazelModule)
+	return root, json.Unmarshal(output, root)
+}
+
+// RunBazelShowRepo runs 'bazel mod show_repo' for the given modules, and returns the definitions of their repositories.
+func RunBazelShowRepo(srcPath string, modulesKeys []string) ([]BazelRepository, error) {
+	output, err := runBazelCommand(srcPath, "mod", append([]string{"show_repo"}, modulesKeys...)...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBazelRepositories(output)
+}
+
+// RunBazelQueryExternalRepositories runs 'bazel query' to get the definitions of the http_archive, http_file and http_jar repositories defined in the WORKSPACE.
+func RunBazelQueryExternalRepositories(srcPath string) ([]BazelRepository, error) {
+	output, err := runBazelCommand(srcPath, "query", "kind('http_archive|http_file|http_jar', //external:*)", "--output=build")
+	if err != nil {
+		return nil, err
+	}
+	return ParseBazelRepositories(output)
+}
+
+// RunBazelCqueryFiles runs 'bazel cquery --output=files' and returns the paths of the output files of the given targets, relative to the workspace.
+func RunBazelCqueryFiles(srcPath string, targets []string) ([]string, error) {
+	output, err := runBazelCommand(srcPath, "cquery", append([]string{"--output=files"}, targets...)...)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+func runBazelCommand(srcPath, cmdName string, args ...string) ([]byte, error) {
+	command := utils.NewCommand("bazel", cmdName, args)
+	command.Dir = srcPath
+	return command.RunWithOutput()
+}
+
+// ParseBazelRepositories parses repository rules in the format printed by 'bazel query --output=build' and 'bazel mod show_repo'.
+func ParseBazelRepositories(output []byte) ([]BazelRepository, error) {
+	var repositories []BazelRepository
+	var current *BazelRepository
+	moduleKey := ""
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := bazelShowRepoHeaderRegExp.FindStringSubmatch(line); match != nil {
+			moduleKey = match[1]
+			continue
+		}
+		if current == nil {
+			if match := bazelRuleStartRegExp.FindStringSubmatch(line); match != nil {
+				current = &BazelRepository{ModuleKey: moduleKey, Kind: match[1]}
+			}
+			continue
+		}
+		if line == ")" {
+			repositories = append(repositories, *current)
+			current = nil
+			continue
+		}
+		match := bazelAttributeRegExp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		var values []string
+		for _, valueMatch := range bazelStringRegExp.FindAllStringSubmatch(match[2], -1) {
+			values = append(values, valueMatch[1])
+		}
+		if len(values) == 0 {
+			continue
+		}
+		switch match[1] {
+		case "name":
+			current.Name = values[0]
+		case "url", "urls":
+			current.Urls = append(current.Urls, values...)
+		case "sha256":
+			current.Sha256 = values[0]
+		case "integrity":
+			if current.Sha256 == "" {
+				current.Sha256 = integrityToSha256(values[0])
+			}
+		}
+	}
+	return repositories, scanner.Err()
+}
+
+// Converts a Subresource Integrity value (for example: 'sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=') to a hex sha256 checksum.
+// An empty string is returned for other algorithms.
+func integrityToSha256(integrity string) string {
+	if !strings.HasPrefix(integrity, bazelIntegritySha256Prefix) {
+		return ""
+	}
+	checksum, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(integrity, bazelIntegritySha256Prefix))
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(checksum)
+}
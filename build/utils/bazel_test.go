@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBazelRepositories(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("..", "testdata", "bazel", "query-output.txt"))
+	assert.NoError(t, err)
+	repositories, err := ParseBazelRepositories(output)
+	assert.NoError(t, err)
+	assert.Equal(t, []BazelRepository{
+		{
+			Kind:   "http_archive",
+			Name:   "com_google_googletest",
+			Urls:   []string{"https://github.com/google/googletest/archive/refs/tags/v1.14.0.tar.gz"},
+			Sha256: "8ad598c73ad796e0d8280b082cebd82a630d73e73cd3c70057938a6501bba5d7",
+		},
+		{
+			Kind:   "http_file",
+			Name:   "hello_data",
+			Urls:   []string{"https://example.com/data/hello.txt"},
+			Sha256: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+	}, repositories)
+}
+
+func TestIntegrityToSha256(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", integrityToSha256("sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="))
+	assert.Empty(t, integrityToSha256("sha512-z4PhNX7vuL3xVChQ1m2AB9Yg5AULVxXcg/SpIdNs6c5H0NE8XYXysP+DGNKHfuwvY7kxvUdBeoGlODJ6+SfaPg=="))
+}
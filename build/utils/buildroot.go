@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	BuildrootManifestFileName     = "manifest.csv"
+	BuildrootHostManifestFileName = "host-manifest.csv"
+	// The copy of the configuration, which the firmware image was built with.
+	buildrootConfigFileName = "buildroot.config"
+	buildrootLegalInfoDir   = "legal-info"
+	// The directory, to which 'make legal-info' saves the source archives of the packages, for example: 'sources/busybox-1.36.1/busybox-1.36.1.tar.bz2'.
+	buildrootSourcesDir = "sources"
+)
+
+// A package in the 'DEPENDENCIES WITH LICENSES' column of the manifest, followed by its license, for example: 'skeleton-init-common [GPL-2.0]'.
+var buildrootDependencyRegExp = regexp.MustCompile(`(\S+) \[[^\]]*\]`)
+
+// BuildrootPackage represents a package listed in a manifest, which 'make legal-info' creates.
+type BuildrootPackage struct {
+	Name          string
+	Version       string
+	License       string
+	LicenseFiles  string
+	SourceArchive string
+	SourceSite    string
+	// The names of the packages, which the package depends on.
+	Dependencies []string
+}
+
+func (bp *BuildrootPackage) Id() string {
+	if bp.Version == "" {
+		return bp.Name
+	}
+	return bp.Name + ":" + bp.Version
+}
+
+// GetSourceArchivePath returns the path of the source archive of the package, as saved to the given legal-info directory.
+func (bp *BuildrootPackage) GetSourceArchivePath(legalInfoDir string) string {
+	sourcesDir := bp.Name
+	if bp.Version != "" {
+		sourcesDir += "-" + bp.Version
+	}
+	return filepath.Join(legalInfoDir, buildrootSourcesDir, sourcesDir, bp.SourceArchive)
+}
+
+// FindBuildrootLegalInfoDir returns the legal-info directory, which 'make legal-info' creates in the given output directory of Buildroot.
+// The given directory is returned if it's the legal-info directory itself.
+func FindBuildrootLegalInfoDir(srcPath string) (string, error) {
+	for _, legalInfoDir := range []string{srcPath, filepath.Join(srcPath, buildrootLegalInfoDir), filepath.Join(srcPath, "output", buildrootLegalInfoDir)} {
+		exists, err := utils.IsFileExists(filepath.Join(legalInfoDir, BuildrootManifestFileName), true)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return legalInfoDir, nil
+		}
+	}
+	return "", fmt.Errorf("the %s file wasn't found in %s. Run 'make legal-info' to create it", BuildrootManifestFileName, srcPath)
+}
+
+// ReadBuildrootManifest returns the packages listed in a manifest (manifest.csv or host-manifest.csv) in the given legal-info directory.
+// The manifests are comma-separated files, whose first line is a header, for example: 'PACKAGE,VERSION,LICENSE,LICENSE FILES,SOURCE ARCHIVE,SOURCE SITE'.
+// A nil slice is returned if the manifest doesn't exist.
+func ReadBuildrootManifest(legalInfoDir, manifestFileName string) ([]BuildrootPackage, error) {
+	content, err := readFileIfExists(filepath.Join(legalInfoDir, manifestFileName))
+	if err != nil || content == nil {
+		return nil, err
+	}
+	reader := csv.NewReader(bytes.NewReader(content))
+	// The columns differ between the versions of Buildroot, so they're found by their names.
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	columns := make(map[string]int)
+	for i, column := range records[0] {
+		columns[strings.TrimSpace(column)] = i
+	}
+	getField := func(record []string, column string) string {
+		if i, exists := columns[column]; exists && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var packages []BuildrootPackage
+	for _, record := range records[1:] {
+		pkg := BuildrootPackage{
+			Name:          getField(record, "PACKAGE"),
+			Version:       getField(record, "VERSION"),
+			License:       getField(record, "LICENSE"),
+			LicenseFiles:  getField(record, "LICENSE FILES"),
+			SourceArchive: getField(record, "SOURCE ARCHIVE"),
+			SourceSite:    getField(record, "SOURCE SITE"),
+		}
+		if pkg.Name == "" {
+			continue
+		}
+		for _, match := range buildrootDependencyRegExp.FindAllStringSubmatch(getField(record, "DEPENDENCIES WITH LICENSES"), -1) {
+			pkg.Dependencies = append(pkg.Dependencies, match[1])
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// GetBuildrootDefconfigName returns the name of the defconfig, which the firmware image was configured with (for example: 'raspberrypi4_64'),
+// as set by the BR2_DEFCONFIG option in the copy of the configuration in the given legal-info directory.
+// An empty string is returned if the option isn't set.
+func GetBuildrootDefconfigName(legalInfoDir string) (string, error) {
+	content, err := readFileIfExists(filepath.Join(legalInfoDir, buildrootConfigFileName))
+	if err != nil || content == nil {
+		return "", err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found || key != "BR2_DEFCONFIG" {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		if value == "" {
+			return "", nil
+		}
+		return strings.TrimSuffix(filepath.Base(filepath.FromSlash(value)), "_defconfig"), nil
+	}
+	return "", scanner.Err()
+}
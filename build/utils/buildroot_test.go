@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBuildrootManifest(t *testing.T) {
+	legalInfoDir := filepath.Join("..", "testdata", "buildroot", "output", "legal-info")
+	packages, err := ReadBuildrootManifest(legalInfoDir, BuildrootManifestFileName)
+	require.NoError(t, err)
+	require.Len(t, packages, 5)
+	assert.Equal(t, BuildrootPackage{
+		Name:          "busybox",
+		Version:       "1.36.1",
+		License:       "GPL-2.0, bzip2-1.0.4",
+		LicenseFiles:  "LICENSE archival/libarchive/bz/LICENSE",
+		SourceArchive: "busybox-1.36.1.tar.bz2",
+		SourceSite:    "https://www.busybox.net/downloads",
+		Dependencies:  []string{"host-skeleton", "skeleton-init-sysv"},
+	}, packages[0])
+	assert.Equal(t, filepath.Join(legalInfoDir, "sources", "busybox-1.36.1", "busybox-1.36.1.tar.bz2"), packages[0].GetSourceArchivePath(legalInfoDir))
+	// A license, which contains commas.
+	assert.Equal(t, []string{"host-gcc-final"}, packages[2].Dependencies)
+	assert.Equal(t, "skeleton-init-sysv", packages[3].Id())
+
+	// A manifest of an older Buildroot version, without the dependencies column.
+	packages, err = ReadBuildrootManifest(filepath.Join("..", "testdata", "buildroot", "nodefconfig", "legal-info"), BuildrootManifestFileName)
+	require.NoError(t, err)
+	assert.Equal(t, []BuildrootPackage{{Name: "zlib", Version: "1.3", License: "Zlib", LicenseFiles: "LICENSE", SourceArchive: "zlib-1.3.tar.xz", SourceSite: "https://www.zlib.net"}}, packages)
+
+	// A missing manifest.
+	packages, err = ReadBuildrootManifest(filepath.Join("..", "testdata", "buildroot", "nodefconfig", "legal-info"), BuildrootHostManifestFileName)
+	require.NoError(t, err)
+	assert.Nil(t, packages)
+}
+
+func TestGetBuildrootDefconfigName(t *testing.T) {
+	name, err := GetBuildrootDefconfigName(filepath.Join("..", "testdata", "buildroot", "output", "legal-info"))
+	require.NoError(t, err)
+	assert.Equal(t, "qemu_x86_64", name)
+
+	name, err = GetBuildrootDefconfigName(filepath.Join("..", "testdata", "buildroot", "nodefconfig", "legal-info"))
+	require.NoError(t, err)
+	assert.Empty(t, name)
+}
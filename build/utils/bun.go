@@ -0,0 +1,318 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	BunLockFileName = "bun.lock"
+	// The binary lock file, which was created by Bun before version 1.2.
+	BunBinaryLockFileName = "bun.lockb"
+	bunWorkspacePrefix    = "workspace:"
+)
+
+type bunLock struct {
+	LockfileVersion int                        `json:"lockfileVersion"`
+	Workspaces      map[string]bunWorkspace    `json:"workspaces"`
+	Packages        map[string]json.RawMessage `json:"packages"`
+}
+
+// A workspace package in the bun.lock file. The workspaces are mapped by their paths relative to the root of the workspace, and the root package is mapped by an empty string.
+type bunWorkspace struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version,omitempty"`
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+}
+
+// A package in the bun.lock file.
+// Each package is written as an array, for example: ["ms@2.1.3", "", {"dependencies": {...}}, "sha512-..."], or ["util@workspace:packages/util"] for workspace packages.
+type bunPackage struct {
+	Name         string
+	Version      string
+	Integrity    string
+	Dependencies map[string]string
+}
+
+type bunPackageInfo struct {
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+}
+
+func (bp *bunPackage) id() string {
+	return bp.Name + ":" + bp.Version
+}
+
+func (bp *bunPackage) isWorkspace() bool {
+	return strings.HasPrefix(bp.Version, bunWorkspacePrefix)
+}
+
+func (bp *bunPackage) UnmarshalJSON(data []byte) error {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	if len(elements) == 0 {
+		return fmt.Errorf("unexpected empty package in the %s file", BunLockFileName)
+	}
+	var packageId string
+	if err := json.Unmarshal(elements[0], &packageId); err != nil {
+		return err
+	}
+	// Skip the '@' prefix of scoped packages.
+	i := strings.LastIndex(packageId, "@")
+	if i <= 0 {
+		return fmt.Errorf("unexpected package '%s' in the %s file", packageId, BunLockFileName)
+	}
+	bp.Name, bp.Version = packageId[:i], packageId[i+1:]
+	for _, element := range elements[1:] {
+		var info bunPackageInfo
+		if err := json.Unmarshal(element, &info); err == nil {
+			bp.Dependencies = mergeBunDependencies(info.Dependencies, info.OptionalDependencies)
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(element, &value); err == nil && isIntegrity(value) {
+			bp.Integrity = value
+		}
+	}
+	return nil
+}
+
+func isIntegrity(value string) bool {
+	hashAlgorithm, _, found := strings.Cut(value, "-")
+	return found && strings.HasPrefix(hashAlgorithm, "sha")
+}
+
+func mergeBunDependencies(dependencies, optionalDependencies map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for name, version := range dependencies {
+		merged[name] = version
+	}
+	for name, version := range optionalDependencies {
+		merged[name] = version
+	}
+	return merged
+}
+
+// FindBunLockDir returns the directory of the bun.lock (or bun.lockb) file of the project, which is either the project's directory, or the root of its workspace.
+// An empty string is returned if the project isn't managed by Bun, which is also the case if a lock file of another package manager (such as package-lock.json) is found first.
+func FindBunLockDir(srcPath string) (lockDir, lockFileName string) {
+	lockDir, lockFileName, err := findNpmProjectLockFile(srcPath)
+	if err != nil || (lockFileName != BunLockFileName && lockFileName != BunBinaryLockFileName) {
+		return "", ""
+	}
+	return lockDir, lockFileName
+}
+
+// CalculateBunDependenciesList gets the dependencies of a project managed by Bun, from its bun.lock file.
+// Bun doesn't keep the tarballs of the packages, so the checksums are taken from their integrities in the lock file, when their algorithm is sha1.
+// If the project is the root of a Bun workspace, the workspace packages are added as dependencies of the module, along with their own dependencies.
+func CalculateBunDependenciesList(srcPath, moduleId string, log utils.Log) ([]entities.Dependency, error) {
+	if log == nil {
+		log = &utils.NullLog{}
+	}
+	lockDir, lockFileName := FindBunLockDir(srcPath)
+	if lockDir == "" {
+		return nil, fmt.Errorf("could not find the %s file of the project", BunLockFileName)
+	}
+	if lockFileName == BunBinaryLockFileName {
+		return nil, fmt.Errorf("the binary %s file isn't supported. Run 'bun install --save-text-lockfile' to create a %s file", BunBinaryLockFileName, BunLockFileName)
+	}
+	workspacePath, err := filepath.Rel(lockDir, srcPath)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filepath.Join(lockDir, BunLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	dependenciesMap, err := parseBunLock(content, filepath.ToSlash(workspacePath), moduleId, log)
+	if err != nil {
+		return nil, err
+	}
+	var dependenciesList []entities.Dependency
+	for _, dep := range dependenciesMap {
+		dependenciesList = append(dependenciesList, dep.Dependency)
+	}
+	return dependenciesList, nil
+}
+
+// Parses the bun.lock file and returns a dependencies map of the given workspace, which looks like name:version -> entities.Dependency.
+func parseBunLock(content []byte, workspacePath, moduleId string, log utils.Log) (map[string]*dependencyInfo, error) {
+	var lock bunLock
+	if err := json.Unmarshal(removeTrailingCommas(content), &lock); err != nil {
+		return nil, fmt.Errorf("failed parsing the %s file: %s", BunLockFileName, err.Error())
+	}
+	if workspacePath == "." {
+		workspacePath = ""
+	}
+	workspace, ok := lock.Workspaces[workspacePath]
+	if !ok {
+		return nil, fmt.Errorf("the workspace '%s' isn't listed in the %s file", workspacePath, BunLockFileName)
+	}
+	packages := make(map[string]*bunPackage)
+	for key, data := range lock.Packages {
+		pkg := &bunPackage{}
+		if err := json.Unmarshal(data, pkg); err != nil {
+			return nil, err
+		}
+		packages[key] = pkg
+	}
+	parser := &bunLockParser{lock: &lock, packages: packages, dependenciesMap: make(map[string]*dependencyInfo), log: log}
+	pathToRoot := []string{moduleId}
+	if workspacePath == "" {
+		// The root of the workspace. The other workspace packages are added as dependencies of the module.
+		// They are all added before parsing their dependencies, so that the packages depending on them could be added to their requestedBy field.
+		for otherWorkspacePath, otherWorkspace := range lock.Workspaces {
+			if otherWorkspacePath != "" {
+				appendDependency(parser.dependenciesMap, &npmLsDependency{Name: otherWorkspace.Name, Version: otherWorkspace.Version}, pathToRoot, log)
+			}
+		}
+		for otherWorkspacePath, otherWorkspace := range lock.Workspaces {
+			if otherWorkspacePath != "" {
+				workspaceId := otherWorkspace.Name + ":" + otherWorkspace.Version
+				parser.parseWorkspace(otherWorkspace, append([]string{workspaceId}, pathToRoot...))
+			}
+		}
+	}
+	parser.parseWorkspace(workspace, pathToRoot)
+	for id, dep := range parser.dependenciesMap {
+		if dep.Integrity == "" {
+			continue
+		}
+		hashAlgorithm, hash, err := integrityToSha(dep.Integrity)
+		if err != nil {
+			return nil, err
+		}
+		if hashAlgorithm == "sha1" {
+			dep.Sha1 = hash
+		} else {
+			log.Debug(fmt.Sprintf("Couldn't set the checksums of %s, because its integrity uses the %s algorithm.", id, hashAlgorithm))
+		}
+	}
+	return parser.dependenciesMap, nil
+}
+
+type bunLockParser struct {
+	lock            *bunLock
+	packages        map[string]*bunPackage
+	dependenciesMap map[string]*dependencyInfo
+	log             utils.Log
+}
+
+func (blp *bunLockParser) parseWorkspace(workspace bunWorkspace, pathToRoot []string) {
+	// Packages which are overridden for a specific workspace are keyed by the workspace name.
+	blp.parseDependencies(mergeBunDependencies(workspace.Dependencies, workspace.OptionalDependencies), workspace.Name, false, pathToRoot)
+	blp.parseDependencies(workspace.DevDependencies, workspace.Name, true, pathToRoot)
+}
+
+// Adds the given dependencies of a package to the dependencies map, recursively.
+// parentKey is the key of the package in the lock file. Packages are resolved like in node_modules: the key of a nested package is its parent's key followed by its name (for example 'debug/ms'), and if it's not found, the package is searched in the parent's ancestors.
+func (blp *bunLockParser) parseDependencies(dependencies map[string]string, parentKey string, dev bool, pathToRoot []string) {
+	for name := range dependencies {
+		key, pkg := blp.resolvePackage(parentKey, name)
+		if pkg == nil {
+			blp.log.Debug(fmt.Sprintf("%s is missing in the %s file. This may be the result of an optional dependency.", name, BunLockFileName))
+			continue
+		}
+		if pkg.isWorkspace() {
+			blp.parseWorkspacePackage(pkg, dev, pathToRoot)
+			continue
+		}
+		dep := &npmLsDependency{Name: pkg.Name, Version: pkg.Version, Integrity: pkg.Integrity, Dev: dev}
+		// Skip circular dependencies.
+		if slices.Contains(pathToRoot, dep.id()) {
+			continue
+		}
+		appendDependency(blp.dependenciesMap, dep, pathToRoot, blp.log)
+		blp.parseDependencies(pkg.Dependencies, key, dev, append([]string{dep.id()}, pathToRoot...))
+	}
+}
+
+// Adds a workspace package, which is a dependency of another package, to the dependencies map.
+func (blp *bunLockParser) parseWorkspacePackage(pkg *bunPackage, dev bool, pathToRoot []string) {
+	workspace, ok := blp.lock.Workspaces[strings.TrimPrefix(pkg.Version, bunWorkspacePrefix)]
+	if !ok {
+		blp.log.Debug(fmt.Sprintf("Skipping the local package %s (%s).", pkg.Name, pkg.Version))
+		return
+	}
+	dep := &npmLsDependency{Name: workspace.Name, Version: workspace.Version, Dev: dev}
+	if slices.Contains(pathToRoot, dep.id()) {
+		return
+	}
+	isNew := blp.dependenciesMap[dep.id()] == nil
+	appendDependency(blp.dependenciesMap, dep, pathToRoot, blp.log)
+	if isNew {
+		blp.parseWorkspace(workspace, append([]string{dep.id()}, pathToRoot...))
+	}
+}
+
+func (blp *bunLockParser) resolvePackage(parentKey, name string) (string, *bunPackage) {
+	parents := splitBunPackageKey(parentKey)
+	for i := len(parents); i > 0; i-- {
+		key := strings.Join(append(parents[:i:i], name), "/")
+		if pkg, ok := blp.packages[key]; ok {
+			return key, pkg
+		}
+	}
+	return name, blp.packages[name]
+}
+
+// Splits the key of a package in the bun.lock file to the names of the packages in its path. For example: '@babel/core/debug' -> ['@babel/core', 'debug'].
+func splitBunPackageKey(key string) []string {
+	var names []string
+	segments := strings.Split(key, "/")
+	for i := 0; i < len(segments); i++ {
+		if strings.HasPrefix(segments[i], "@") && i+1 < len(segments) {
+			names = append(names, segments[i]+"/"+segments[i+1])
+			i++
+			continue
+		}
+		names = append(names, segments[i])
+	}
+	return names
+}
+
+// Removes the trailing commas from the given JSON, which are allowed in the bun.lock file.
+func removeTrailingCommas(content []byte) []byte {
+	result := make([]byte, 0, len(content))
+	inString, escaped := false, false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			result = append(result, c)
+			continue
+		}
+		if c == '"' {
+			inString = true
+		} else if c == ',' {
+			j := i + 1
+			for j < len(content) && strings.ContainsRune(" \t\r\n", rune(content[j])) {
+				j++
+			}
+			if j < len(content) && (content[j] == '}' || content[j] == ']') {
+				continue
+			}
+		}
+		result = append(result, c)
+	}
+	return result
+}
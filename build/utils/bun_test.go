@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindBunLockDir(t *testing.T) {
+	workspaceDir := filepath.Join("..", "testdata", "npm", "bun-workspace")
+	lockDir, lockFileName := FindBunLockDir(filepath.Join(workspaceDir, "packages", "app"))
+	assert.Equal(t, workspaceDir, lockDir)
+	assert.Equal(t, BunLockFileName, lockFileName)
+
+	lockDir, _ = FindBunLockDir(filepath.Join("..", "testdata", "npm", "project1"))
+	assert.Empty(t, lockDir)
+}
+
+func TestFindBunLockDirOfNestedProjects(t *testing.T) {
+	for _, bunLockFileName := range []string{BunLockFileName, BunBinaryLockFileName} {
+		t.Run(bunLockFileName, func(t *testing.T) {
+			parentDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(parentDir, bunLockFileName), []byte("{}"), 0644))
+
+			// The package-lock.json file of an npm project takes precedence over the Bun lock file of a parent directory.
+			npmProjectDir := filepath.Join(parentDir, "npm-project")
+			require.NoError(t, os.MkdirAll(npmProjectDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(npmProjectDir, NpmLockFileName), []byte("{}"), 0644))
+			lockDir, _ := FindBunLockDir(npmProjectDir)
+			assert.Empty(t, lockDir)
+
+			// Projects without a lock file of their own use the lock file of the parent directory.
+			lockDir, lockFileName := FindBunLockDir(filepath.Join(parentDir, "workspace-package"))
+			assert.Equal(t, parentDir, lockDir)
+			assert.Equal(t, bunLockFileName, lockFileName)
+		})
+	}
+}
+
+func TestSplitBunPackageKey(t *testing.T) {
+	assert.Equal(t, []string{"debug"}, splitBunPackageKey("debug"))
+	assert.Equal(t, []string{"@babel/core", "debug", "@types/ms"}, splitBunPackageKey("@babel/core/debug/@types/ms"))
+}
+
+func TestRemoveTrailingCommas(t *testing.T) {
+	content := "{\"a\": [1, 2,], \"b,}\": {\"c\": \"d\",\n},\n}"
+	assert.Equal(t, "{\"a\": [1, 2], \"b,}\": {\"c\": \"d\"\n}\n}", string(removeTrailingCommas([]byte(content))))
+}
+
+func TestCalculateBunDependenciesList(t *testing.T) {
+	workspaceDir := filepath.Join("..", "testdata", "npm", "bun-workspace")
+	rootId := "bun-workspace-root:1.0.0"
+	dependencies, err := CalculateBunDependenciesList(workspaceDir, rootId, nil)
+	require.NoError(t, err)
+	expected := []entities.Dependency{
+		{Id: "left-pad:1.3.0", Scopes: []string{"dev"}, RequestedBy: [][]string{{rootId}}, Checksum: entities.Checksum{Sha1: "5b8a3a7765dfe001261dde915589e782f8c94d1e"}},
+		{Id: "app:0.1.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{rootId}}},
+		{Id: "@jfrog/util:0.2.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{rootId}, {"app:0.1.0", rootId}}},
+		{Id: "debug:4.3.4", Scopes: []string{"prod"}, RequestedBy: [][]string{{"app:0.1.0", rootId}}},
+		{Id: "ms:2.1.2", Scopes: []string{"prod"}, RequestedBy: [][]string{{"debug:4.3.4", "app:0.1.0", rootId}}},
+		{Id: "ms:2.1.3", Scopes: []string{"prod"}, RequestedBy: [][]string{{"app:0.1.0", rootId}, {"@jfrog/util:0.2.0", rootId}}, Checksum: entities.Checksum{Sha1: "574c8138ce1d2b5861f214459cebe184144fa32a"}},
+	}
+	assert.Len(t, dependencies, len(expected))
+	for _, expectedDependency := range expected {
+		found := false
+		for _, dependency := range dependencies {
+			if dependency.Id == expectedDependency.Id {
+				found = true
+				assert.Equal(t, expectedDependency.Scopes, dependency.Scopes, dependency.Id)
+				assert.ElementsMatch(t, expectedDependency.RequestedBy, dependency.RequestedBy, dependency.Id)
+				assert.Equal(t, expectedDependency.Checksum, dependency.Checksum, dependency.Id)
+			}
+		}
+		assert.True(t, found, "dependency "+expectedDependency.Id+" is missing")
+	}
+
+	// A workspace package, which depends on another workspace package.
+	dependencies, err = CalculateBunDependenciesList(filepath.Join(workspaceDir, "packages", "app"), "app:0.1.0", nil)
+	require.NoError(t, err)
+	var ids []string
+	for _, dependency := range dependencies {
+		ids = append(ids, dependency.Id)
+	}
+	assert.ElementsMatch(t, []string{"@jfrog/util:0.2.0", "debug:4.3.4", "ms:2.1.2", "ms:2.1.3"}, ids)
+}
@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	CargoManifestFileName = "Cargo.toml"
+	CargoLockFileName     = "Cargo.lock"
+)
+
+// CargoPackage represents a package listed in a Cargo.lock file.
+type CargoPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	// The source of the package, for example: 'registry+https://github.com/rust-lang/crates.io-index'.
+	// Empty for packages of the workspace and path dependencies.
+	Source string `toml:"source"`
+	// The sha256 checksum of the .crate file, for packages downloaded from a registry.
+	Checksum string `toml:"checksum"`
+	// The references to the dependencies of the package, in one of the formats: 'name', 'name version' or 'name version (source)'.
+	Dependencies []string `toml:"dependencies"`
+}
+
+func (cp *CargoPackage) Id() string {
+	return cp.Name + ":" + cp.Version
+}
+
+type cargoLock struct {
+	Packages []CargoPackage `toml:"package"`
+}
+
+type cargoManifest struct {
+	Package struct {
+		Name string `toml:"name"`
+		// The version is either a string, or a table ('version.workspace = true') if the package inherits the version of its workspace.
+		Version interface{} `toml:"version"`
+	} `toml:"package"`
+	Workspace *struct {
+		Package struct {
+			Version string `toml:"version"`
+		} `toml:"package"`
+	} `toml:"workspace"`
+}
+
+// GetCargoPackageId returns the ID ('name:version') of the package, which is defined in the Cargo.toml file in the given directory.
+// An empty string is returned if the manifest defines a virtual workspace, which has no package of its own.
+func GetCargoPackageId(srcPath string) (string, error) {
+	var manifest cargoManifest
+	if _, err := toml.DecodeFile(filepath.Join(srcPath, CargoManifestFileName), &manifest); err != nil {
+		return "", err
+	}
+	if manifest.Package.Name == "" {
+		return "", nil
+	}
+	var version string
+	switch packageVersion := manifest.Package.Version.(type) {
+	case nil:
+	case string:
+		version = packageVersion
+	case map[string]interface{}:
+		if inherited, ok := packageVersion["workspace"].(bool); !ok || !inherited {
+			return "", fmt.Errorf("the version of the package '%s' must be a string or 'version.workspace = true'", manifest.Package.Name)
+		}
+		if manifest.Workspace != nil {
+			// The package is the root of its own workspace.
+			version = manifest.Workspace.Package.Version
+		} else {
+			var err error
+			if version, err = getCargoWorkspaceVersion(srcPath); err != nil {
+				return "", err
+			}
+		}
+		if version == "" {
+			return "", fmt.Errorf("the package '%s' inherits the version of its workspace, but no version is defined in [workspace.package]", manifest.Package.Name)
+		}
+	default:
+		return "", fmt.Errorf("the version of the package '%s' must be a string or 'version.workspace = true'", manifest.Package.Name)
+	}
+	return manifest.Package.Name + ":" + version, nil
+}
+
+// Returns the version defined in the [workspace.package] table of the root manifest of the workspace, which is the nearest
+// Cargo.toml file with a [workspace] table in the parent directories of the package.
+func getCargoWorkspaceVersion(srcPath string) (string, error) {
+	dir, err := filepath.Abs(srcPath)
+	if err != nil {
+		return "", err
+	}
+	for parent := filepath.Dir(dir); parent != dir; parent = filepath.Dir(dir) {
+		dir = parent
+		manifestPath := filepath.Join(dir, CargoManifestFileName)
+		if _, err = os.Stat(manifestPath); os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		var manifest cargoManifest
+		if _, err = toml.DecodeFile(manifestPath, &manifest); err != nil {
+			return "", err
+		}
+		if manifest.Workspace != nil {
+			return manifest.Workspace.Package.Version, nil
+		}
+	}
+	return "", fmt.Errorf("the package in %s inherits the version of its workspace, but no workspace root was found in its parent directories", srcPath)
+}
+
+// ReadCargoLock returns the packages listed in a Cargo.lock file.
+func ReadCargoLock(lockPath string) ([]CargoPackage, error) {
+	var lock cargoLock
+	if _, err := toml.DecodeFile(lockPath, &lock); err != nil {
+		return nil, err
+	}
+	return lock.Packages, nil
+}
+
+// GetCargoDependenciesGraph returns a map of the IDs of the packages to the IDs of their direct dependencies.
+func GetCargoDependenciesGraph(packages []CargoPackage) map[string][]string {
+	packagesByName := make(map[string][]CargoPackage)
+	for _, pkg := range packages {
+		packagesByName[pkg.Name] = append(packagesByName[pkg.Name], pkg)
+	}
+	graph := make(map[string][]string)
+	for _, pkg := range packages {
+		for _, dependencyRef := range pkg.Dependencies {
+			if dependency := resolveCargoDependency(dependencyRef, packagesByName); dependency != nil {
+				graph[pkg.Id()] = append(graph[pkg.Id()], dependency.Id())
+			}
+		}
+	}
+	return graph
+}
+
+// Returns the package, which a dependency reference from the Cargo.lock file points to.
+// The version is omitted from the reference when the lock file holds only one version of the package,
+// and the source is added to it only when the same version is available from several sources.
+func resolveCargoDependency(dependencyRef string, packagesByName map[string][]CargoPackage) *CargoPackage {
+	fields := strings.Fields(dependencyRef)
+	if len(fields) == 0 {
+		return nil
+	}
+	candidates := packagesByName[fields[0]]
+	if len(fields) == 1 {
+		if len(candidates) == 0 {
+			return nil
+		}
+		return &candidates[0]
+	}
+	source := ""
+	if len(fields) > 2 {
+		source = strings.TrimSuffix(strings.TrimPrefix(fields[2], "("), ")")
+	}
+	for i := range candidates {
+		if candidates[i].Version == fields[1] && (source == "" || candidates[i].Source == source) {
+			return &candidates[i]
+		}
+	}
+	return nil
+}
+
+// GetCargoRegistryCachePath returns the path of the directory, in which Cargo stores the .crate files downloaded from registries.
+func GetCargoRegistryCachePath() (string, error) {
+	cargoHome := os.Getenv("CARGO_HOME")
+	if cargoHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		cargoHome = filepath.Join(homeDir, ".cargo")
+	}
+	return filepath.Join(cargoHome, "registry", "cache"), nil
+}
+
+// FindCargoCrate returns the path of the .crate file of a package in the registry cache, or an empty string if it's not found.
+// The cache holds a directory per registry, so all of them are searched.
+func FindCargoCrate(cachePath string, pkg CargoPackage) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(cachePath, "*", pkg.Name+"-"+pkg.Version+".crate"))
+	if err != nil || len(matches) == 0 {
+		return "", err
+	}
+	return matches[0], nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCargoDependenciesGraph(t *testing.T) {
+	packages, err := ReadCargoLock(filepath.Join("..", "testdata", "cargo", "project", CargoLockFileName))
+	assert.NoError(t, err)
+	assert.Len(t, packages, 8)
+
+	graph := GetCargoDependenciesGraph(packages)
+	// Versioned references are used when the lock file holds several versions of the same package.
+	assert.ElementsMatch(t, []string{"rand:0.8.5", "serde:1.0.188"}, graph["cargo-project:0.1.0"])
+	assert.Equal(t, []string{"rand:0.7.3"}, graph["unused-member:0.1.0"])
+	assert.Equal(t, []string{"cfg-if:1.0.0"}, graph["getrandom:0.2.10"])
+	assert.Empty(t, graph["cfg-if:1.0.0"])
+}
+
+func TestResolveCargoDependency(t *testing.T) {
+	packagesByName := map[string][]CargoPackage{
+		"serde": {
+			{Name: "serde", Version: "1.0.188", Source: "registry+https://github.com/rust-lang/crates.io-index"},
+			{Name: "serde", Version: "1.0.188", Source: "git+https://github.com/serde-rs/serde"},
+		},
+	}
+	dependency := resolveCargoDependency("serde 1.0.188 (git+https://github.com/serde-rs/serde)", packagesByName)
+	if assert.NotNil(t, dependency) {
+		assert.Equal(t, "git+https://github.com/serde-rs/serde", dependency.Source)
+	}
+	assert.Nil(t, resolveCargoDependency("serde 1.0.0", packagesByName))
+	assert.Nil(t, resolveCargoDependency("missing", packagesByName))
+}
+
+func TestGetCargoPackageId(t *testing.T) {
+	packageId, err := GetCargoPackageId(filepath.Join("..", "testdata", "cargo", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "cargo-project:0.1.0", packageId)
+}
+
+func TestGetCargoPackageIdOfWorkspaceMember(t *testing.T) {
+	workspacePath := filepath.Join("..", "testdata", "cargo", "workspace")
+	// The member inherits the version from the [workspace.package] table of the workspace root.
+	packageId, err := GetCargoPackageId(filepath.Join(workspacePath, "member"))
+	assert.NoError(t, err)
+	assert.Equal(t, "workspace-member:0.2.0", packageId)
+
+	// A virtual workspace has no package of its own.
+	packageId, err = GetCargoPackageId(workspacePath)
+	assert.NoError(t, err)
+	assert.Empty(t, packageId)
+}
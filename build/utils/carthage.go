@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	CartfileFileName         = "Cartfile"
+	CartfilePrivateFileName  = "Cartfile.private"
+	CartfileResolvedFileName = "Cartfile.resolved"
+
+	CarthageGithubOrigin = "github"
+	CarthageGitOrigin    = "git"
+	CarthageBinaryOrigin = "binary"
+)
+
+var (
+	// The directory, to which Carthage checks out the repositories of the dependencies.
+	carthageCheckoutsDir = filepath.Join("Carthage", "Checkouts")
+	// The directory, to which Carthage builds (or downloads) the frameworks of the dependencies.
+	CarthageBuildDir = filepath.Join("Carthage", "Build")
+
+	// A dependency in a Cartfile, for example: 'github "Alamofire/Alamofire" ~> 5.8', or in a Cartfile.resolved file, for example: 'github "Alamofire/Alamofire" "5.8.1"'.
+	// Comments start with '#'.
+	cartfileLineRegExp = regexp.MustCompile(`^(github|git|binary)\s+"([^"]+)"(?:\s+([^#]*))?`)
+)
+
+// CarthageDependency represents a dependency listed in a Cartfile or in a Cartfile.resolved file.
+type CarthageDependency struct {
+	// The origin of the dependency: 'github', 'git' or 'binary'.
+	Origin string
+	// The 'owner/repository' name of a GitHub repository, or the URL of a git repository or of a binary project specification.
+	Identifier string
+	// The resolved version (or commit), or the version requirement, as listed in a Cartfile.
+	Version string
+}
+
+// Name returns the name of the dependency, as used by Carthage for its checkout directory and version file.
+// This is the name of the repository, or the name of the binary project specification file, without its extension.
+func (cd *CarthageDependency) Name() string {
+	name := path.Base(strings.TrimSuffix(cd.Identifier, "/"))
+	if cd.Origin == CarthageBinaryOrigin {
+		return strings.TrimSuffix(name, ".json")
+	}
+	return strings.TrimSuffix(name, ".git")
+}
+
+func (cd *CarthageDependency) Id() string {
+	return cd.Name() + ":" + cd.Version
+}
+
+// GetSourceUrl returns the URL of the git repository of the dependency, or of its binary project specification.
+func (cd *CarthageDependency) GetSourceUrl() string {
+	// GitHub Enterprise repositories are identified by their full URLs.
+	if cd.Origin == CarthageGithubOrigin && !strings.Contains(cd.Identifier, "://") {
+		return "https://github.com/" + cd.Identifier + ".git"
+	}
+	return cd.Identifier
+}
+
+// CarthageFramework represents a framework of a dependency, which Carthage built for a platform (or downloaded), as listed in the version file of the dependency.
+type CarthageFramework struct {
+	Name string `json:"name,omitempty"`
+	// The SHA-256 checksum of the binary of the framework.
+	Hash    string `json:"hash,omitempty"`
+	Linking string `json:"linking,omitempty"`
+	// The XCFramework, which contains the framework, and the identifier of the library in that XCFramework, for example: 'ios-arm64'.
+	// These are set only if the dependencies were built with '--use-xcframeworks'.
+	Container  string `json:"container,omitempty"`
+	Identifier string `json:"identifier,omitempty"`
+	// The platform, for which the framework was built, for example: 'iOS' or 'Mac'. It's set from the key of the framework in the version file.
+	Platform string `json:"-"`
+}
+
+// GetRelativePath returns the path of the framework, relative to the Carthage/Build directory, for example: 'iOS/Alamofire.framework' or 'Alamofire.xcframework/ios-arm64'.
+// The path is slash-separated on all operating systems.
+func (cf *CarthageFramework) GetRelativePath() string {
+	if cf.Container != "" {
+		return cf.Container + "/" + cf.Identifier
+	}
+	return cf.Platform + "/" + cf.Name + ".framework"
+}
+
+// GetBinaryPath returns the path of the binary of the framework in the given Carthage/Build directory.
+func (cf *CarthageFramework) GetBinaryPath(buildDir string) string {
+	frameworkPath := filepath.Join(buildDir, filepath.FromSlash(cf.GetRelativePath()))
+	if cf.Container != "" {
+		frameworkPath = filepath.Join(frameworkPath, cf.Name+".framework")
+	}
+	return filepath.Join(frameworkPath, cf.Name)
+}
+
+// ReadCartfileResolved returns the dependencies listed in the Cartfile.resolved file in the given directory.
+func ReadCartfileResolved(srcPath string) ([]CarthageDependency, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, CartfileResolvedFileName))
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := parseCartfile(content)
+	if err != nil {
+		return nil, err
+	}
+	for i := range dependencies {
+		dependencies[i].Version = strings.Trim(dependencies[i].Version, `"`)
+	}
+	return dependencies, nil
+}
+
+// GetCartfileDependenciesNames returns the names of the dependencies listed in the Cartfile in the given directory,
+// optionally including those listed in its Cartfile.private file. A nil slice is returned if there's no Cartfile.
+func GetCartfileDependenciesNames(srcPath string, includePrivate bool) ([]string, error) {
+	cartfiles := []string{CartfileFileName}
+	if includePrivate {
+		cartfiles = append(cartfiles, CartfilePrivateFileName)
+	}
+	var names []string
+	for _, cartfile := range cartfiles {
+		content, err := readFileIfExists(filepath.Join(srcPath, cartfile))
+		if err != nil {
+			return nil, err
+		}
+		dependencies, err := parseCartfile(content)
+		if err != nil {
+			return nil, err
+		}
+		for _, dependency := range dependencies {
+			names = append(names, dependency.Name())
+		}
+	}
+	return names, nil
+}
+
+// GetCarthageCheckoutDependenciesNames returns the names of the dependencies listed in the Cartfile of a dependency, as checked out to the Carthage/Checkouts directory.
+// The private dependencies of the dependency aren't resolved by Carthage, so they're not included.
+func GetCarthageCheckoutDependenciesNames(srcPath, name string) ([]string, error) {
+	return GetCartfileDependenciesNames(filepath.Join(srcPath, carthageCheckoutsDir, name), false)
+}
+
+// ReadCarthageVersionFile returns the frameworks listed in the version file of a dependency in the given Carthage/Build directory, sorted by their paths.
+// Carthage writes the version file (for example: '.Alamofire.version') after building (or downloading) the frameworks of the dependency.
+// A nil slice is returned if the dependency wasn't built.
+func ReadCarthageVersionFile(buildDir, name string) ([]CarthageFramework, error) {
+	content, err := readFileIfExists(filepath.Join(buildDir, "."+name+".version"))
+	if err != nil || content == nil {
+		return nil, err
+	}
+	var versionFile map[string]json.RawMessage
+	if err = json.Unmarshal(content, &versionFile); err != nil {
+		return nil, err
+	}
+	var frameworks []CarthageFramework
+	for platform, value := range versionFile {
+		// The other fields of the version file, such as the commitish, which the dependency was built from, aren't lists of frameworks.
+		if !bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+			continue
+		}
+		var platformFrameworks []CarthageFramework
+		if err = json.Unmarshal(value, &platformFrameworks); err != nil {
+			return nil, err
+		}
+		for _, framework := range platformFrameworks {
+			framework.Platform = platform
+			frameworks = append(frameworks, framework)
+		}
+	}
+	sort.Slice(frameworks, func(i, j int) bool {
+		return frameworks[i].GetRelativePath() < frameworks[j].GetRelativePath()
+	})
+	return frameworks, nil
+}
+
+func parseCartfile(content []byte) ([]CarthageDependency, error) {
+	var dependencies []CarthageDependency
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		match := cartfileLineRegExp.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+		dependencies = append(dependencies, CarthageDependency{Origin: match[1], Identifier: match[2], Version: strings.TrimSpace(match[3])})
+	}
+	return dependencies, scanner.Err()
+}
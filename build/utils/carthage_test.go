@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCartfileResolved(t *testing.T) {
+	dependencies, err := ReadCartfileResolved(filepath.Join("..", "testdata", "carthage", "project"))
+	require.NoError(t, err)
+	require.Len(t, dependencies, 5)
+	assert.Equal(t, CarthageDependency{Origin: CarthageBinaryOrigin, Identifier: "https://dl.google.com/dl/firebase/ios/carthage/FirebaseAnalyticsBinary.json", Version: "10.18.0"}, dependencies[0])
+	assert.Equal(t, "FirebaseAnalyticsBinary", dependencies[0].Name())
+	assert.Equal(t, "CwlPreconditionTesting", dependencies[1].Name())
+	assert.Equal(t, "https://github.com/mattgallagher/CwlPreconditionTesting.git", dependencies[1].GetSourceUrl())
+	assert.Equal(t, "Alamofire:5.8.1", dependencies[2].Id())
+	assert.Equal(t, "https://github.com/Alamofire/Alamofire.git", dependencies[2].GetSourceUrl())
+}
+
+func TestGetCartfileDependenciesNames(t *testing.T) {
+	srcPath := filepath.Join("..", "testdata", "carthage", "project")
+	names, err := GetCartfileDependenciesNames(srcPath, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alamofire", "RxSwift", "FirebaseAnalyticsBinary"}, names)
+
+	names, err = GetCartfileDependenciesNames(srcPath, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alamofire", "RxSwift", "FirebaseAnalyticsBinary", "Nimble"}, names)
+
+	// The private dependencies of checked out dependencies aren't included.
+	names, err = GetCarthageCheckoutDependenciesNames(srcPath, "Nimble")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CwlPreconditionTesting"}, names)
+
+	names, err = GetCarthageCheckoutDependenciesNames(srcPath, "Alamofire")
+	require.NoError(t, err)
+	assert.Nil(t, names)
+}
+
+func TestReadCarthageVersionFile(t *testing.T) {
+	buildDir := filepath.Join("..", "testdata", "carthage", "project", CarthageBuildDir)
+	frameworks, err := ReadCarthageVersionFile(buildDir, "RxSwift")
+	require.NoError(t, err)
+	require.Len(t, frameworks, 2)
+	assert.Equal(t, CarthageFramework{Name: "RxCocoa", Hash: "1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f809", Linking: "dynamic", Platform: "iOS"}, frameworks[0])
+	assert.Equal(t, "iOS/RxSwift.framework", frameworks[1].GetRelativePath())
+	assert.Equal(t, filepath.Join(buildDir, "iOS", "RxSwift.framework", "RxSwift"), frameworks[1].GetBinaryPath(buildDir))
+
+	frameworks, err = ReadCarthageVersionFile(buildDir, "Alamofire")
+	require.NoError(t, err)
+	require.Len(t, frameworks, 2)
+	assert.Equal(t, "Alamofire.xcframework/ios-arm64", frameworks[0].GetRelativePath())
+	assert.Equal(t, filepath.Join(buildDir, "Alamofire.xcframework", "ios-arm64", "Alamofire.framework", "Alamofire"), frameworks[0].GetBinaryPath(buildDir))
+
+	// A dependency, which wasn't built.
+	frameworks, err = ReadCarthageVersionFile(buildDir, "Nimble")
+	require.NoError(t, err)
+	assert.Nil(t, frameworks)
+}
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	ClojureDepsFileName      = "deps.edn"
+	LeiningenProjectFileName = "project.clj"
+)
+
+var (
+	// For example: '(defproject org.example/app "1.0.0-SNAPSHOT"'
+	leiningenDefprojectRegExp = regexp.MustCompile(`\(defproject\s+([^\s"]+)\s+"([^"]+)"`)
+	// For example: '   [com.fasterxml.jackson.core/jackson-core "2.15.2"]'
+	leiningenTreeLineRegExp = regexp.MustCompile(`^( +)\[([^\s\]"]+) "([^"]+)"`)
+	// For example: '  . org.clojure/spec.alpha 0.3.218' or '  X org.clojure/spec.alpha 0.3.214 :older-version'
+	clojureTreeLineRegExp = regexp.MustCompile(`^( *)(?:([.X]) )?(\S+/\S+) (\S+)`)
+)
+
+// ClojureDependency represents a node in the dependency tree of a Clojure project.
+type ClojureDependency struct {
+	// The ID of the dependency, in the format of 'groupId:artifactId:version'.
+	Id           string
+	Dependencies []*ClojureDependency
+}
+
+// GetClojureLibCoordinates returns the Maven group ID and artifact ID of a Clojure library, for example: 'cheshire' and 'cheshire' for 'cheshire'.
+// Libraries, whose names aren't qualified by a group, have the same group ID and artifact ID.
+func GetClojureLibCoordinates(lib string) (groupId, artifactId string) {
+	if groupId, artifactId, found := strings.Cut(lib, "/"); found {
+		return groupId, artifactId
+	}
+	return lib, lib
+}
+
+func getClojureDependencyId(lib, version string) string {
+	groupId, artifactId := GetClojureLibCoordinates(lib)
+	return groupId + ":" + artifactId + ":" + version
+}
+
+// IsLeiningenProject returns true if the project in the given directory is a Leiningen project, rather than a project using a deps.edn file.
+func IsLeiningenProject(srcPath string) (bool, error) {
+	depsFileExists, err := utils.IsFileExists(filepath.Join(srcPath, ClojureDepsFileName), true)
+	if err != nil || depsFileExists {
+		return false, err
+	}
+	return utils.IsFileExists(filepath.Join(srcPath, LeiningenProjectFileName), true)
+}
+
+// GetLeiningenProjectId returns the ID of the project defined in the project.clj file in the given directory, in the format of 'groupId:artifactId:version'.
+// An empty string is returned if the project isn't defined using 'defproject'.
+func GetLeiningenProjectId(srcPath string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, LeiningenProjectFileName))
+	if err != nil {
+		return "", err
+	}
+	match := leiningenDefprojectRegExp.FindSubmatch(content)
+	if match == nil {
+		return "", nil
+	}
+	return getClojureDependencyId(string(match[1]), string(match[2])), nil
+}
+
+// RunClojureTree runs 'clojure -Stree' and returns the direct dependencies of the project, with their dependencies.
+func RunClojureTree(srcPath string) ([]*ClojureDependency, error) {
+	command := utils.NewCommand("clojure", "-Stree", []string{})
+	command.Dir = srcPath
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	return ParseClojureTree(output)
+}
+
+// ParseClojureTree parses the output of 'clojure -Stree', in which each level of the tree is indented by two spaces.
+// The dependencies are prefixed with '.' if they were included, or with 'X' if they were excluded (for example, because a newer version of the library was selected). Excluded dependencies are skipped.
+func ParseClojureTree(output []byte) ([]*ClojureDependency, error) {
+	var roots []*ClojureDependency
+	// The last dependency in each depth of the tree.
+	var parents []*ClojureDependency
+	// The depth of the last excluded dependency, or -1 if not inside its subtree.
+	excludedDepth := -1
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		match := clojureTreeLineRegExp.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		depth := len(match[1]) / 2
+		if depth > len(parents) {
+			continue
+		}
+		if excludedDepth >= 0 {
+			if depth > excludedDepth {
+				continue
+			}
+			excludedDepth = -1
+		}
+		if match[2] == "X" {
+			excludedDepth = depth
+			continue
+		}
+		dependency := &ClojureDependency{Id: getClojureDependencyId(match[3], match[4])}
+		if depth == 0 {
+			roots = append(roots, dependency)
+		} else {
+			parent := parents[depth-1]
+			parent.Dependencies = append(parent.Dependencies, dependency)
+		}
+		parents = append(parents[:depth], dependency)
+	}
+	return roots, scanner.Err()
+}
+
+// RunLeiningenDepsTree runs 'lein deps :tree' and returns the direct dependencies of the project, with their dependencies.
+func RunLeiningenDepsTree(srcPath string) ([]*ClojureDependency, error) {
+	command := utils.NewCommand("lein", "deps", []string{":tree"})
+	command.Dir = srcPath
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	return ParseLeiningenDepsTree(output)
+}
+
+// ParseLeiningenDepsTree parses the output of 'lein deps :tree', in which each dependency is printed as a vector, for example: ' [cheshire "5.12.0"]'.
+// The direct dependencies are indented by one space, and each level of the tree by two more spaces.
+// Lines, which aren't indented, belong to the warnings Leiningen prints about conflicting versions, so they're skipped.
+func ParseLeiningenDepsTree(output []byte) ([]*ClojureDependency, error) {
+	var roots []*ClojureDependency
+	var parents []*ClojureDependency
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		match := leiningenTreeLineRegExp.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		depth := (len(match[1]) - 1) / 2
+		if depth > len(parents) {
+			continue
+		}
+		dependency := &ClojureDependency{Id: getClojureDependencyId(match[2], match[3])}
+		if depth == 0 {
+			roots = append(roots, dependency)
+		} else {
+			parent := parents[depth-1]
+			parent.Dependencies = append(parent.Dependencies, dependency)
+		}
+		parents = append(parents[:depth], dependency)
+	}
+	return roots, scanner.Err()
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClojureTree(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("..", "testdata", "clojure", "clojure-stree.txt"))
+	require.NoError(t, err)
+	roots, err := ParseClojureTree(output)
+	require.NoError(t, err)
+	require.Len(t, roots, 3)
+	assert.Equal(t, "org.clojure:clojure:1.11.1", roots[0].Id)
+	assert.Len(t, roots[0].Dependencies, 2)
+	assert.Equal(t, "cheshire:cheshire:5.12.0", roots[1].Id)
+	require.Len(t, roots[1].Dependencies, 3)
+	// The excluded dependency is skipped.
+	assert.Equal(t, "com.fasterxml.jackson.dataformat:jackson-dataformat-smile:2.15.2", roots[1].Dependencies[1].Id)
+	assert.Empty(t, roots[1].Dependencies[1].Dependencies)
+	// The subtree of the excluded dependency is skipped too.
+	require.Len(t, roots[2].Dependencies, 1)
+	assert.Equal(t, "org.clojure:tools.cli:1.0.206", roots[2].Dependencies[0].Id)
+}
+
+func TestParseLeiningenDepsTree(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("..", "testdata", "clojure", "lein-deps-tree.txt"))
+	require.NoError(t, err)
+	roots, err := ParseLeiningenDepsTree(output)
+	require.NoError(t, err)
+	// The warnings about conflicting versions are skipped.
+	require.Len(t, roots, 4)
+	assert.Equal(t, "cheshire:cheshire:5.12.0", roots[0].Id)
+	require.Len(t, roots[0].Dependencies, 2)
+	assert.Equal(t, "com.fasterxml.jackson.core:jackson-core:2.15.2", roots[0].Dependencies[0].Id)
+	assert.Equal(t, "tigris:tigris:0.1.2", roots[0].Dependencies[1].Id)
+	assert.Equal(t, "commons-io:commons-io:2.8.0", roots[1].Dependencies[0].Id)
+	assert.Equal(t, "nrepl:nrepl:1.0.0", roots[2].Id)
+	assert.Empty(t, roots[2].Dependencies)
+	assert.Len(t, roots[3].Dependencies, 2)
+}
+
+func TestGetLeiningenProjectId(t *testing.T) {
+	id, err := GetLeiningenProjectId(filepath.Join("..", "testdata", "clojure", "lein-project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "org.example:hello:0.1.0-SNAPSHOT", id)
+
+	leiningen, err := IsLeiningenProject(filepath.Join("..", "testdata", "clojure", "lein-project"))
+	assert.NoError(t, err)
+	assert.True(t, leiningen)
+	leiningen, err = IsLeiningenProject(filepath.Join("..", "testdata", "clojure", "deps-project"))
+	assert.NoError(t, err)
+	assert.False(t, leiningen)
+}
+
+func TestFindMavenLocalRepositoryJar(t *testing.T) {
+	repositoryPath := filepath.Join("..", "testdata", "clojure", "m2")
+	jarPath, err := FindMavenLocalRepositoryJar(repositoryPath, "org.clojure", "clojure", "1.11.1")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(repositoryPath, "org", "clojure", "clojure", "1.11.1", "clojure-1.11.1.jar"), jarPath)
+
+	jarPath, err = FindMavenLocalRepositoryJar(repositoryPath, "org.clojure", "spec.alpha", "0.3.218")
+	assert.NoError(t, err)
+	assert.Empty(t, jarPath)
+}
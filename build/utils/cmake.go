@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	CMakeListsFileName = "CMakeLists.txt"
+	CMakeCacheFileName = "CMakeCache.txt"
+	// The default directory, in which FetchContent populates the dependencies, relative to the build directory.
+	cmakeDefaultFetchContentDir = "_deps"
+	cmakeSubBuildSuffix         = "-subbuild"
+	cmakeSourceSuffix           = "-src"
+)
+
+var (
+	cmakeProjectRegExp     = regexp.MustCompile(`(?is)\bproject\s*\(\s*([\w.+-]+)([^)]*)\)`)
+	cmakeVersionArgRegExp  = regexp.MustCompile(`(?i)\bVERSION\s+"?([\w.]+)"?`)
+	cmakeCacheEntryRegExp  = regexp.MustCompile(`^([^#/:][^:]*):[A-Z]+=(.*)$`)
+	cmakeExternalArgRegExp = regexp.MustCompile(`\b(GIT_REPOSITORY|GIT_TAG|URL_HASH|URL_MD5|URL)"?\s+("[^"]*"|\S+)`)
+	cmakeVersionRegExp     = regexp.MustCompile(`\d+(?:\.\d+)+`)
+	gitCommitRegExp        = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// CMakeFetchedDependency represents a dependency, which was downloaded by FetchContent (or by CPM, which uses FetchContent) to the build directory.
+type CMakeFetchedDependency struct {
+	// The name of the dependency, in lowercase, as used by FetchContent.
+	Name          string
+	GitRepository string
+	GitTag        string
+	Url           string
+	// The expected hash of the downloaded archive, for example: 'SHA256=<hash>'.
+	UrlHash string
+	// The directory, to which the sources were populated.
+	SourceDir string
+	// The archive downloaded from the URL, if it's kept in the build directory.
+	ArchivePath string
+}
+
+// Version returns the version of the dependency, which is its git tag, or the version in the name of its archive.
+func (cfd *CMakeFetchedDependency) Version() string {
+	if cfd.GitTag != "" {
+		return strings.TrimPrefix(cfd.GitTag, "v")
+	}
+	return cmakeVersionRegExp.FindString(filepath.Base(cfd.Url))
+}
+
+func (cfd *CMakeFetchedDependency) Id() string {
+	if version := cfd.Version(); version != "" {
+		return cfd.Name + ":" + version
+	}
+	return cfd.Name
+}
+
+// GetCMakeProjectNameAndVersion returns the name and version of the project, as set by the project() command in the CMakeLists.txt file in the given directory.
+func GetCMakeProjectNameAndVersion(srcPath string) (name, version string, err error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, CMakeListsFileName))
+	if err != nil {
+		return
+	}
+	match := cmakeProjectRegExp.FindSubmatch(content)
+	if match == nil {
+		return
+	}
+	name = string(match[1])
+	if versionMatch := cmakeVersionArgRegExp.FindSubmatch(match[2]); versionMatch != nil {
+		version = string(versionMatch[1])
+	}
+	return
+}
+
+// ReadCMakeCache returns the entries of the CMakeCache.txt file in the given build directory.
+func ReadCMakeCache(buildDir string) (map[string]string, error) {
+	content, err := os.ReadFile(filepath.Join(buildDir, CMakeCacheFileName))
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		// For example: 'FETCHCONTENT_BASE_DIR:PATH=/home/user/project/build/_deps'.
+		if match := cmakeCacheEntryRegExp.FindStringSubmatch(scanner.Text()); match != nil {
+			entries[match[1]] = match[2]
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// GetCMakeFetchedDependencies returns the dependencies, which were downloaded by FetchContent to the given build directory.
+// The details of each dependency are taken from the sub-build, which FetchContent creates to download it.
+func GetCMakeFetchedDependencies(buildDir string) ([]CMakeFetchedDependency, error) {
+	cache, err := ReadCMakeCache(buildDir)
+	if err != nil {
+		return nil, err
+	}
+	depsDir := cache["FETCHCONTENT_BASE_DIR"]
+	if depsDir == "" {
+		depsDir = filepath.Join(buildDir, cmakeDefaultFetchContentDir)
+	}
+	exists, err := utils.IsDirExists(depsDir, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	entries, err := os.ReadDir(depsDir)
+	if err != nil {
+		return nil, err
+	}
+	dependenciesMap := make(map[string]*CMakeFetchedDependency)
+	getDependency := func(name string) *CMakeFetchedDependency {
+		if dependenciesMap[name] == nil {
+			dependenciesMap[name] = &CMakeFetchedDependency{Name: name}
+		}
+		return dependenciesMap[name]
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(entry.Name(), cmakeSubBuildSuffix):
+			dependency := getDependency(strings.TrimSuffix(entry.Name(), cmakeSubBuildSuffix))
+			if err = readCMakeSubBuild(filepath.Join(depsDir, entry.Name()), dependency); err != nil {
+				return nil, err
+			}
+		case strings.HasSuffix(entry.Name(), cmakeSourceSuffix):
+			getDependency(strings.TrimSuffix(entry.Name(), cmakeSourceSuffix)).SourceDir = filepath.Join(depsDir, entry.Name())
+		}
+	}
+	// Dependencies, whose sources were provided locally using FETCHCONTENT_SOURCE_DIR_<NAME>, weren't downloaded.
+	for key, value := range cache {
+		if name := strings.TrimPrefix(key, "FETCHCONTENT_SOURCE_DIR_"); name != key && value != "" {
+			delete(dependenciesMap, strings.ToLower(name))
+		}
+	}
+	var dependencies []CMakeFetchedDependency
+	for _, dependency := range dependenciesMap {
+		dependencies = append(dependencies, *dependency)
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		return dependencies[i].Name < dependencies[j].Name
+	})
+	return dependencies, nil
+}
+
+// Reads the download details of a dependency from the ExternalProject_Add() command in the CMakeLists.txt file of its sub-build.
+func readCMakeSubBuild(subBuildDir string, dependency *CMakeFetchedDependency) error {
+	content, err := os.ReadFile(filepath.Join(subBuildDir, CMakeListsFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, match := range cmakeExternalArgRegExp.FindAllStringSubmatch(string(content), -1) {
+		value := strings.Trim(match[2], `"`)
+		switch match[1] {
+		case "GIT_REPOSITORY":
+			dependency.GitRepository = value
+		case "GIT_TAG":
+			dependency.GitTag = value
+		case "URL":
+			dependency.Url = value
+		case "URL_HASH":
+			dependency.UrlHash = value
+		case "URL_MD5":
+			dependency.UrlHash = "MD5=" + value
+		}
+	}
+	if dependency.Url != "" {
+		// The downloaded archive is kept in the source directory of the sub-build's populate project.
+		archivePath := filepath.Join(subBuildDir, dependency.Name+"-populate-prefix", "src", filepath.Base(dependency.Url))
+		exists, err := utils.IsFileExists(archivePath, true)
+		if err != nil {
+			return err
+		}
+		if exists {
+			dependency.ArchivePath = archivePath
+		}
+	}
+	return nil
+}
+
+// GetGitHeadCommit returns the commit checked out in the given git repository, or an empty string if it isn't a git repository.
+func GetGitHeadCommit(repoDir string) (string, error) {
+	exists, err := utils.IsDirExists(filepath.Join(repoDir, ".git"), true)
+	if err != nil || !exists {
+		return "", err
+	}
+	command := utils.NewCommand("git", "rev-parse", []string{"HEAD"})
+	command.Dir = repoDir
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IsGitCommit returns true if the given git reference is a full commit hash.
+func IsGitCommit(reference string) bool {
+	return gitCommitRegExp.MatchString(reference)
+}
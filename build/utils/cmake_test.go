@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCMakeProjectNameAndVersion(t *testing.T) {
+	name, version, err := GetCMakeProjectNameAndVersion(filepath.Join("..", "testdata", "cmake", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", name)
+	assert.Equal(t, "1.0.0", version)
+}
+
+func TestReadCMakeCache(t *testing.T) {
+	cache, err := ReadCMakeCache(filepath.Join("..", "testdata", "cmake", "project", "build"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Release", cache["CMAKE_BUILD_TYPE"])
+	assert.Equal(t, "/home/user/local", cache["FETCHCONTENT_SOURCE_DIR_LOCAL"])
+	value, ok := cache["FETCHCONTENT_BASE_DIR"]
+	assert.True(t, ok)
+	assert.Empty(t, value)
+}
+
+func TestGetCMakeFetchedDependencies(t *testing.T) {
+	depsDir := filepath.Join("..", "testdata", "cmake", "project", "build", "_deps")
+	dependencies, err := GetCMakeFetchedDependencies(filepath.Join("..", "testdata", "cmake", "project", "build"))
+	assert.NoError(t, err)
+	assert.Equal(t, []CMakeFetchedDependency{
+		{
+			Name:          "fmt",
+			GitRepository: "https://github.com/fmtlib/fmt.git",
+			GitTag:        "10.1.1",
+			SourceDir:     filepath.Join(depsDir, "fmt-src"),
+		},
+		{
+			Name:        "json",
+			Url:         "https://github.com/nlohmann/json/releases/download/v3.11.2/json-3.11.2.tar.xz",
+			UrlHash:     "SHA256=8c4b26bf4b422252e13f332bc5e388ec0ab5c3443d24399acb675e68278d341f",
+			SourceDir:   filepath.Join(depsDir, "json-src"),
+			ArchivePath: filepath.Join(depsDir, "json-subbuild", "json-populate-prefix", "src", "json-3.11.2.tar.xz"),
+		},
+	}, dependencies)
+}
+
+func TestCMakeFetchedDependencyVersion(t *testing.T) {
+	testCases := []struct {
+		dependency      CMakeFetchedDependency
+		expectedVersion string
+	}{
+		{CMakeFetchedDependency{Name: "fmt", GitTag: "v10.1.1"}, "10.1.1"},
+		{CMakeFetchedDependency{Name: "json", Url: "https://github.com/nlohmann/json/archive/refs/tags/v3.11.2.tar.gz"}, "3.11.2"},
+		{CMakeFetchedDependency{Name: "cpm", Url: "https://example.com/archive.zip"}, ""},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectedVersion, testCase.dependency.Version())
+	}
+}
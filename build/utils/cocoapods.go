@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const PodfileLockFileName = "Podfile.lock"
+
+// Pod represents a pod (or a subspec of a pod) listed in a Podfile.lock file.
+type Pod struct {
+	// The name of the pod. Subspecs are named after their pod, for example: 'Firebase/Core'.
+	Name    string
+	Version string
+	// The names of the direct dependencies of the pod.
+	Dependencies []string
+	// The checksum of the podspec, as listed in the 'SPEC CHECKSUMS' section.
+	SpecChecksum string
+}
+
+func (p *Pod) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+type podfileLock struct {
+	// Each entry is either a string ('Name (version)') or a map of such a string to the list of the pod's dependencies.
+	Pods          []interface{}     `yaml:"PODS"`
+	Dependencies  []string          `yaml:"DEPENDENCIES"`
+	SpecChecksums map[string]string `yaml:"SPEC CHECKSUMS"`
+}
+
+// ReadPodfileLock parses the Podfile.lock file in the given directory.
+// Returns the pods listed in it, and the names of the direct dependencies of the Podfile.
+func ReadPodfileLock(srcPath string) (pods []Pod, directDependencies []string, err error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, PodfileLockFileName))
+	if err != nil {
+		return
+	}
+	var lock podfileLock
+	if err = yaml.Unmarshal(content, &lock); err != nil {
+		return
+	}
+	for _, entry := range lock.Pods {
+		var pod Pod
+		switch podEntry := entry.(type) {
+		case string:
+			pod.Name, pod.Version = parseNameAndVersionEntry(podEntry)
+		case map[string]interface{}:
+			for podWithVersion, podDependencies := range podEntry {
+				pod.Name, pod.Version = parseNameAndVersionEntry(podWithVersion)
+				dependenciesList, ok := podDependencies.([]interface{})
+				if !ok {
+					return nil, nil, fmt.Errorf("unexpected dependencies format of the %s pod in %s", pod.Name, PodfileLockFileName)
+				}
+				for _, dependency := range dependenciesList {
+					dependencyName, _ := parseNameAndVersionEntry(fmt.Sprint(dependency))
+					pod.Dependencies = append(pod.Dependencies, dependencyName)
+				}
+			}
+		default:
+			return nil, nil, fmt.Errorf("unexpected pod entry in %s: %v", PodfileLockFileName, entry)
+		}
+		// Subspecs share the podspec of their pod.
+		rootName, _, _ := strings.Cut(pod.Name, "/")
+		pod.SpecChecksum = lock.SpecChecksums[rootName]
+		pods = append(pods, pod)
+	}
+	for _, dependency := range lock.Dependencies {
+		dependencyName, _ := parseNameAndVersionEntry(dependency)
+		directDependencies = append(directDependencies, dependencyName)
+	}
+	return
+}
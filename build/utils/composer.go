@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	ComposerManifestFileName = "composer.json"
+	ComposerLockFileName     = "composer.lock"
+)
+
+var commitHashRegExp = regexp.MustCompile(`^[a-f0-9]{40}$`)
+
+// ComposerPackage represents a package listed in a composer.lock file.
+type ComposerPackage struct {
+	Name    string            `json:"name,omitempty"`
+	Version string            `json:"version,omitempty"`
+	Dist    ComposerDist      `json:"dist,omitempty"`
+	Require map[string]string `json:"require,omitempty"`
+}
+
+// ComposerDist holds the details of the archive, from which a package is installed.
+type ComposerDist struct {
+	Type      string `json:"type,omitempty"`
+	Url       string `json:"url,omitempty"`
+	Reference string `json:"reference,omitempty"`
+	// The sha1 checksum of the archive. Most repositories, including Packagist, leave it empty.
+	Shasum string `json:"shasum,omitempty"`
+}
+
+func (cp *ComposerPackage) Id() string {
+	return cp.Name + ":" + cp.Version
+}
+
+type ComposerLock struct {
+	Packages    []ComposerPackage `json:"packages,omitempty"`
+	PackagesDev []ComposerPackage `json:"packages-dev,omitempty"`
+}
+
+type ComposerManifest struct {
+	Name       string            `json:"name,omitempty"`
+	Version    string            `json:"version,omitempty"`
+	Require    map[string]string `json:"require,omitempty"`
+	RequireDev map[string]string `json:"require-dev,omitempty"`
+}
+
+// ReadComposerManifest reads the composer.json file in the given directory.
+func ReadComposerManifest(srcPath string) (*ComposerManifest, error) {
+	manifest := new(ComposerManifest)
+	return manifest, utils.Unmarshal(filepath.Join(srcPath, ComposerManifestFileName), manifest)
+}
+
+// ReadComposerLock reads the composer.lock file in the given directory.
+func ReadComposerLock(srcPath string) (*ComposerLock, error) {
+	lock := new(ComposerLock)
+	return lock, utils.Unmarshal(filepath.Join(srcPath, ComposerLockFileName), lock)
+}
+
+// GetComposerCachePath returns the path of the Composer cache directory.
+func GetComposerCachePath() (string, error) {
+	if cacheDir := os.Getenv("COMPOSER_CACHE_DIR"); cacheDir != "" {
+		return cacheDir, nil
+	}
+	if composerHome := os.Getenv("COMPOSER_HOME"); composerHome != "" {
+		return filepath.Join(composerHome, "cache"), nil
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userCacheDir, "composer"), nil
+}
+
+// GetComposerCachedArchivePath returns the path of the archive of a package in the Composer cache, or an empty string if it's not found.
+// Composer 2 names the cached archives by the sha1 of their dist URLs. Composer 1 named them by the dist references of the packages, if they are commit hashes.
+func GetComposerCachedArchivePath(cachePath string, pkg ComposerPackage) (string, error) {
+	if pkg.Dist.Type == "" {
+		return "", nil
+	}
+	var cacheKeys []string
+	if pkg.Dist.Url != "" {
+		hash := sha1.Sum([]byte(pkg.Dist.Url))
+		cacheKeys = append(cacheKeys, hex.EncodeToString(hash[:]))
+	}
+	if commitHashRegExp.MatchString(pkg.Dist.Reference) {
+		cacheKeys = append(cacheKeys, pkg.Dist.Reference)
+	}
+	for _, cacheKey := range cacheKeys {
+		archivePath := filepath.Join(cachePath, "files", filepath.FromSlash(pkg.Name), cacheKey+"."+pkg.Dist.Type)
+		exists, err := utils.IsFileExists(archivePath, true)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return archivePath, nil
+		}
+	}
+	return "", nil
+}
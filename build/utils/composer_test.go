@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetComposerCachedArchivePath(t *testing.T) {
+	cachePath := filepath.Join("..", "testdata", "composer", "cache")
+	// Composer 2 names the archive by the sha1 of the dist URL.
+	pkg := ComposerPackage{Name: "monolog/monolog", Version: "3.4.0", Dist: ComposerDist{
+		Type:      "zip",
+		Url:       "https://api.github.com/repos/Seldaek/monolog/zipball/e2392369686d420ca32df3803de28b5d6f76867d",
+		Reference: "e2392369686d420ca32df3803de28b5d6f76867d",
+	}}
+	archivePath, err := GetComposerCachedArchivePath(cachePath, pkg)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cachePath, "files", "monolog", "monolog", "344fb70b1da77d8fed4f9940d77789881e76c975.zip"), archivePath)
+
+	// Composer 1 named the archive by the dist reference, if it's a commit hash.
+	pkg = ComposerPackage{Name: "psr/log", Version: "3.0.0", Dist: ComposerDist{
+		Type:      "zip",
+		Url:       "https://api.github.com/repos/php-fig/log/zipball/fe5ea303b0887d5caefd3d431c3e61ad47037001",
+		Reference: "fe5ea303b0887d5caefd3d431c3e61ad47037001",
+	}}
+	archivePath, err = GetComposerCachedArchivePath(cachePath, pkg)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cachePath, "files", "psr", "log", "fe5ea303b0887d5caefd3d431c3e61ad47037001.zip"), archivePath)
+
+	// References, which aren't commit hashes, aren't used to name the archive.
+	pkg = ComposerPackage{Name: "phpunit/php-timer", Version: "6.0.0", Dist: ComposerDist{Type: "zip", Url: "https://example.com/php-timer-6.0.0.zip", Reference: "6.0.0"}}
+	archivePath, err = GetComposerCachedArchivePath(cachePath, pkg)
+	assert.NoError(t, err)
+	assert.Empty(t, archivePath)
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	ConanfilePyFileName  = "conanfile.py"
+	ConanfileTxtFileName = "conanfile.txt"
+	// The manifest, which Conan creates for each exported recipe, with the checksums of the recipe files.
+	ConanManifestFileName = "conanmanifest.txt"
+)
+
+var (
+	conanfileNameRegExp    = regexp.MustCompile(`(?m)^\s+name\s*=\s*["']([^"']+)["']`)
+	conanfileVersionRegExp = regexp.MustCompile(`(?m)^\s+version\s*=\s*["']([^"']+)["']`)
+)
+
+// ConanGraph represents the output of 'conan graph info --format json' (Conan 2).
+type ConanGraph struct {
+	Graph struct {
+		Nodes map[string]ConanNode `json:"nodes,omitempty"`
+		// The ID of the root node, mapped to its reference.
+		Root map[string]string `json:"root,omitempty"`
+	} `json:"graph,omitempty"`
+}
+
+// ConanNode represents a node (package) in the Conan dependencies graph.
+type ConanNode struct {
+	Id string `json:"id,omitempty"`
+	// The full reference of the package, for example: 'zlib/1.2.13#97d5730b529b4224045fe7090592d4c1'.
+	Ref     string `json:"ref,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	User    string `json:"user,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	// The recipe revision.
+	Rrev      string `json:"rrev,omitempty"`
+	PackageId string `json:"package_id,omitempty"`
+	// The package (binary) revision.
+	Prev string `json:"prev,omitempty"`
+	// The context of the node: 'host' or 'build' (for tool requirements).
+	Context      string `json:"context,omitempty"`
+	Test         bool   `json:"test,omitempty"`
+	RecipeFolder string `json:"recipe_folder,omitempty"`
+	// The direct dependencies of the node, mapped by their node IDs.
+	Dependencies map[string]ConanEdge `json:"dependencies,omitempty"`
+}
+
+// ConanEdge represents the requirement of a node on one of its dependencies.
+type ConanEdge struct {
+	Ref    string `json:"ref,omitempty"`
+	Direct bool   `json:"direct,omitempty"`
+	Build  bool   `json:"build,omitempty"`
+}
+
+// Reference returns the reference of the package without its revision, for example: 'zlib/1.2.13' or 'mylib/1.0@myuser/stable'.
+func (cn *ConanNode) Reference() string {
+	if cn.Name == "" {
+		ref, _, _ := strings.Cut(cn.Ref, "#")
+		return ref
+	}
+	ref := cn.Name + "/" + cn.Version
+	if cn.User != "" {
+		ref += "@" + cn.User + "/" + cn.Channel
+	}
+	return ref
+}
+
+// RunConanGraphInfo runs 'conan graph info --format json' on the project and returns its dependencies graph.
+func RunConanGraphInfo(srcPath string, extraArgs []string) (*ConanGraph, error) {
+	command := utils.NewCommand("conan", "graph", append([]string{"info", srcPath, "--format", "json"}, extraArgs...))
+	command.Dir = srcPath
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	return ParseConanGraph(output)
+}
+
+// ParseConanGraph parses the output of 'conan graph info --format json'.
+func ParseConanGraph(output []byte) (*ConanGraph, error) {
+	graph := new(ConanGraph)
+	return graph, json.Unmarshal(output, graph)
+}
+
+// GetConanfileNameAndVersion returns the name and version defined in the conanfile.py in the given directory.
+// Empty strings are returned if they aren't defined, or if the project has a conanfile.txt instead.
+func GetConanfileNameAndVersion(srcPath string) (name, version string, err error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, ConanfilePyFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	if match := conanfileNameRegExp.FindSubmatch(content); match != nil {
+		name = string(match[1])
+	}
+	if match := conanfileVersionRegExp.FindSubmatch(content); match != nil {
+		version = string(match[1])
+	}
+	return
+}
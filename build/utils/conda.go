@@ -0,0 +1,224 @@
+package utils
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CondaLockFileName = "conda-lock.yml"
+
+	CondaLockCondaManager = "conda"
+	CondaLockPipManager   = "pip"
+)
+
+var CondaEnvironmentFileNames = []string{"environment.yml", "environment.yaml"}
+
+// CondaPackage represents a package installed in a Conda environment, as described in the environment's conda-meta directory.
+type CondaPackage struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	Build   string `json:"build,omitempty"`
+	// The URL of the channel, for example: 'https://conda.anaconda.org/conda-forge/linux-64'.
+	Channel string `json:"channel,omitempty"`
+	// The file name of the package, for example: 'zlib-1.2.13-h5eee18b_1.conda'.
+	Fn     string `json:"fn,omitempty"`
+	Url    string `json:"url,omitempty"`
+	Md5    string `json:"md5,omitempty"`
+	Sha256 string `json:"sha256,omitempty"`
+	// The match specs of the dependencies of the package, for example: 'libgcc-ng >=11.2.0'.
+	Depends []string `json:"depends,omitempty"`
+	// The spec the package was explicitly requested with. Empty for packages which were installed as dependencies of other packages.
+	RequestedSpec string `json:"requested_spec,omitempty"`
+	// The path of the package file in the packages cache.
+	PackageTarballFullPath string `json:"package_tarball_full_path,omitempty"`
+}
+
+func (cp *CondaPackage) Id() string {
+	return cp.Name + ":" + cp.Version
+}
+
+// CondaEnvironmentFile represents an environment.yml file.
+type CondaEnvironmentFile struct {
+	Name     string   `yaml:"name,omitempty"`
+	Channels []string `yaml:"channels,omitempty"`
+	// Each dependency is either a match spec, or a map of 'pip' to the list of pip requirements.
+	Dependencies []interface{} `yaml:"dependencies,omitempty"`
+}
+
+// GetDependenciesNames returns the names of the Conda packages listed in the dependencies of the environment file.
+// Pip requirements are skipped.
+func (cef *CondaEnvironmentFile) GetDependenciesNames() []string {
+	var names []string
+	for _, dependency := range cef.Dependencies {
+		if spec, ok := dependency.(string); ok {
+			names = append(names, GetCondaMatchSpecName(spec))
+		}
+	}
+	return names
+}
+
+// ReadCondaEnvironmentFile reads the environment.yml (or environment.yaml) file in the given directory.
+// Returns nil if the file doesn't exist.
+func ReadCondaEnvironmentFile(srcPath string) (*CondaEnvironmentFile, error) {
+	for _, fileName := range CondaEnvironmentFileNames {
+		content, err := os.ReadFile(filepath.Join(srcPath, fileName))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		environmentFile := new(CondaEnvironmentFile)
+		return environmentFile, yaml.Unmarshal(content, environmentFile)
+	}
+	return nil, nil
+}
+
+// GetCondaMatchSpecName returns the package name of a match spec.
+// For example, the name of 'conda-forge::numpy>=1.24' is 'numpy'.
+func GetCondaMatchSpecName(spec string) string {
+	if _, afterChannel, found := strings.Cut(spec, "::"); found {
+		spec = afterChannel
+	}
+	if i := strings.IndexAny(spec, " =<>!~["); i >= 0 {
+		spec = spec[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(spec))
+}
+
+// ReadCondaMeta returns the packages installed in the Conda environment with the given prefix.
+func ReadCondaMeta(prefix string) ([]CondaPackage, error) {
+	metaFiles, err := filepath.Glob(filepath.Join(prefix, "conda-meta", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var packages []CondaPackage
+	for _, metaFile := range metaFiles {
+		var pkg CondaPackage
+		if err = utils.Unmarshal(metaFile, &pkg); err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// CondaLockFile represents a unified conda-lock.yml file, which conda-lock creates.
+type CondaLockFile struct {
+	Version  int `yaml:"version,omitempty"`
+	Metadata struct {
+		// The platforms (subdirs) the environment was locked for, for example: 'linux-64' or 'osx-arm64'.
+		Platforms []string `yaml:"platforms,omitempty"`
+	} `yaml:"metadata,omitempty"`
+	Package []CondaLockPackage `yaml:"package,omitempty"`
+}
+
+// CondaLockPackage represents a package locked for a platform in a conda-lock.yml file.
+type CondaLockPackage struct {
+	Name    string `yaml:"name,omitempty"`
+	Version string `yaml:"version,omitempty"`
+	// The package manager, which installs the package: 'conda' or 'pip'.
+	Manager  string `yaml:"manager,omitempty"`
+	Platform string `yaml:"platform,omitempty"`
+	// The match specs (or pip requirements) of the dependencies of the package, mapped by their names.
+	Dependencies map[string]string `yaml:"dependencies,omitempty"`
+	Url          string            `yaml:"url,omitempty"`
+	Hash         struct {
+		Md5    string `yaml:"md5,omitempty"`
+		Sha256 string `yaml:"sha256,omitempty"`
+	} `yaml:"hash,omitempty"`
+	// The category of the package, for example: 'main' or 'dev'.
+	Category string `yaml:"category,omitempty"`
+}
+
+func (clp *CondaLockPackage) Id() string {
+	return clp.Name + ":" + clp.Version
+}
+
+// GetFileName returns the file name of the package, for example: 'zlib-1.2.13-hd590300_5.conda'.
+func (clp *CondaLockPackage) GetFileName() string {
+	return path.Base(clp.Url)
+}
+
+// GetChannel returns the URL of the channel (and subdir) of a conda package, for example: 'https://conda.anaconda.org/conda-forge/linux-64'.
+func (clp *CondaLockPackage) GetChannel() string {
+	if i := strings.LastIndex(clp.Url, "/"); i >= 0 {
+		return clp.Url[:i]
+	}
+	return ""
+}
+
+// GetBuild returns the build string of a conda package, which is part of its file name, for example: 'hd590300_5'.
+// An empty string is returned if the file isn't a conda package, or if its name doesn't match the name and version of the package.
+func (clp *CondaLockPackage) GetBuild() string {
+	fileName := clp.GetFileName()
+	for _, extension := range []string{".conda", ".tar.bz2"} {
+		prefix := clp.Name + "-" + clp.Version + "-"
+		if strings.HasSuffix(fileName, extension) && strings.HasPrefix(fileName, prefix) {
+			return strings.TrimSuffix(fileName[len(prefix):], extension)
+		}
+	}
+	return ""
+}
+
+// GetDependenciesNames returns the sorted names of the dependencies of the package.
+func (clp *CondaLockPackage) GetDependenciesNames() []string {
+	var names []string
+	for name := range clp.Dependencies {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadCondaLockFile reads the conda-lock.yml file in the given path.
+func ReadCondaLockFile(lockFilePath string) (*CondaLockFile, error) {
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, err
+	}
+	lockFile := new(CondaLockFile)
+	return lockFile, yaml.Unmarshal(content, lockFile)
+}
+
+// GetPackages returns the packages locked for the given platform.
+func (clf *CondaLockFile) GetPackages(platform string) []CondaLockPackage {
+	var packages []CondaLockPackage
+	for _, pkg := range clf.Package {
+		if pkg.Platform == platform {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// GetCondaPlatform returns the Conda platform (subdir) of the current operating system and architecture, for example: 'linux-64' or 'osx-arm64'.
+func GetCondaPlatform() string {
+	operatingSystem := runtime.GOOS
+	switch operatingSystem {
+	case "darwin":
+		operatingSystem = "osx"
+	case "windows":
+		operatingSystem = "win"
+	}
+	architecture := runtime.GOARCH
+	switch architecture {
+	case "amd64":
+		architecture = "64"
+	case "386":
+		architecture = "32"
+	case "arm64":
+		if operatingSystem == "linux" {
+			architecture = "aarch64"
+		}
+	}
+	return operatingSystem + "-" + architecture
+}
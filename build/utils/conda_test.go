@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCondaMatchSpecName(t *testing.T) {
+	tests := map[string]string{
+		"python":                   "python",
+		"python=3.11":              "python",
+		"conda-forge::numpy>=1.24": "numpy",
+		"libgcc-ng >=11.2.0":       "libgcc-ng",
+		"zlib >=1.2.13,<1.3.0a0":   "zlib",
+		"PyYAML[version='>=6']":    "pyyaml",
+	}
+	for spec, expectedName := range tests {
+		assert.Equal(t, expectedName, GetCondaMatchSpecName(spec), spec)
+	}
+}
+
+func TestReadCondaEnvironmentFile(t *testing.T) {
+	environmentFile, err := ReadCondaEnvironmentFile(filepath.Join("..", "testdata", "conda", "project"))
+	assert.NoError(t, err)
+	if assert.NotNil(t, environmentFile) {
+		assert.Equal(t, "data-science", environmentFile.Name)
+		// Pip requirements are skipped.
+		assert.Equal(t, []string{"python", "numpy", "pip"}, environmentFile.GetDependenciesNames())
+	}
+
+	environmentFile, err = ReadCondaEnvironmentFile(filepath.Join("..", "testdata", "conda", "env"))
+	assert.NoError(t, err)
+	assert.Nil(t, environmentFile)
+}
+
+func TestReadCondaLockFile(t *testing.T) {
+	lockFile, err := ReadCondaLockFile(filepath.Join("..", "testdata", "conda", "lockproject", CondaLockFileName))
+	require.NoError(t, err)
+	assert.Equal(t, 1, lockFile.Version)
+	assert.Equal(t, []string{"linux-64", "osx-arm64"}, lockFile.Metadata.Platforms)
+	assert.Len(t, lockFile.Package, 6)
+
+	packages := lockFile.GetPackages("osx-arm64")
+	require.Len(t, packages, 1)
+	assert.Equal(t, "python:3.11.5", packages[0].Id())
+	assert.Equal(t, "https://conda.anaconda.org/conda-forge/osx-arm64", packages[0].GetChannel())
+	assert.Equal(t, "h47c9636_0_cpython", packages[0].GetBuild())
+
+	packages = lockFile.GetPackages("linux-64")
+	require.Len(t, packages, 5)
+	assert.Equal(t, []string{"libzlib", "python"}, packages[0].GetDependenciesNames())
+	assert.Equal(t, CondaLockPipManager, packages[3].Manager)
+	assert.Equal(t, "requests-2.31.0-py3-none-any.whl", packages[3].GetFileName())
+	// The file name of pip packages doesn't include a build string.
+	assert.Empty(t, packages[3].GetBuild())
+}
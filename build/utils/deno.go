@@ -0,0 +1,259 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	DenoLockFileName   = "deno.lock"
+	DenoConfigFileName = "deno.json"
+	DenoJsrKind        = "jsr"
+	DenoNpmKind        = "npm"
+)
+
+// DenoConfig represents the fields of a deno.json file, which are used by the build-info.
+type DenoConfig struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// DenoPackage represents a jsr or npm package listed in a deno.lock file.
+type DenoPackage struct {
+	// The kind of the package: jsr or npm.
+	Kind      string
+	Name      string
+	Version   string
+	Integrity string
+	// The IDs of the packages this package depends on.
+	Dependencies []string
+}
+
+func (dp *DenoPackage) Id() string {
+	return dp.Name + ":" + dp.Version
+}
+
+// GetChecksum returns the checksum of the package, according to its integrity.
+// The integrity of jsr packages is the sha256 checksum of their version manifest. The integrity of npm packages is used as their sha1 checksum, if it uses the sha1 algorithm.
+func (dp *DenoPackage) GetChecksum() (entities.Checksum, error) {
+	if dp.Integrity == "" {
+		return entities.Checksum{}, nil
+	}
+	if dp.Kind == DenoJsrKind {
+		return entities.Checksum{Sha256: dp.Integrity}, nil
+	}
+	hashAlgorithm, hash, err := integrityToSha(dp.Integrity)
+	if err != nil || hashAlgorithm != "sha1" {
+		return entities.Checksum{}, err
+	}
+	return entities.Checksum{Sha1: hash}, nil
+}
+
+// DenoRemoteModule represents a remote module imported by URL, as listed in a deno.lock file.
+type DenoRemoteModule struct {
+	Url string
+	// The sha256 checksum of the module's source.
+	Hash string
+}
+
+// DenoLock represents the packages and remote modules listed in a deno.lock file.
+type DenoLock struct {
+	Packages      []DenoPackage
+	RemoteModules []DenoRemoteModule
+	// The IDs of the packages, which are the direct dependencies of the project.
+	DirectDependencies []string
+}
+
+type denoLockFile struct {
+	Version string `json:"version"`
+	// Version 3 of the lock file, in which the packages are listed under 'packages'.
+	Packages *denoLockPackages `json:"packages,omitempty"`
+	denoLockPackages
+	Remote    map[string]string `json:"remote,omitempty"`
+	Workspace struct {
+		Dependencies []string `json:"dependencies,omitempty"`
+		PackageJson  struct {
+			Dependencies []string `json:"dependencies,omitempty"`
+		} `json:"packageJson,omitempty"`
+	} `json:"workspace,omitempty"`
+}
+
+type denoLockPackages struct {
+	// The specifiers used in the project, mapped to the resolved versions (since version 4), or to the resolved packages (in version 3), for example: 'jsr:@std/path@^1.0.0' -> '1.0.8'.
+	Specifiers map[string]string            `json:"specifiers,omitempty"`
+	Jsr        map[string]denoLockedPackage `json:"jsr,omitempty"`
+	Npm        map[string]denoLockedPackage `json:"npm,omitempty"`
+}
+
+type denoLockedPackage struct {
+	Integrity string `json:"integrity,omitempty"`
+	// A list of specifiers, or (for npm packages in version 3) a map of names to packages.
+	Dependencies json.RawMessage `json:"dependencies,omitempty"`
+}
+
+func (dlp *denoLockedPackage) getDependencies() ([]string, error) {
+	if len(dlp.Dependencies) == 0 {
+		return nil, nil
+	}
+	var dependencies []string
+	if err := json.Unmarshal(dlp.Dependencies, &dependencies); err == nil {
+		return dependencies, nil
+	}
+	var dependenciesMap map[string]string
+	if err := json.Unmarshal(dlp.Dependencies, &dependenciesMap); err != nil {
+		return nil, err
+	}
+	for _, dependency := range dependenciesMap {
+		dependencies = append(dependencies, dependency)
+	}
+	sort.Strings(dependencies)
+	return dependencies, nil
+}
+
+// ReadDenoConfig returns the configuration in the deno.json file in the given directory, or an empty configuration if the file doesn't exist.
+func ReadDenoConfig(srcPath string) (*DenoConfig, error) {
+	config := &DenoConfig{}
+	configPath := filepath.Join(srcPath, DenoConfigFileName)
+	exists, err := utils.IsFileExists(configPath, true)
+	if err != nil || !exists {
+		return config, err
+	}
+	return config, utils.Unmarshal(configPath, config)
+}
+
+// ReadDenoLock returns the packages and remote modules listed in the deno.lock file in the given directory.
+// Versions 3 and 4 of the lock file are supported.
+func ReadDenoLock(srcPath string) (*DenoLock, error) {
+	var lockFile denoLockFile
+	if err := utils.Unmarshal(filepath.Join(srcPath, DenoLockFileName), &lockFile); err != nil {
+		return nil, err
+	}
+	if version, err := strconv.Atoi(lockFile.Version); err != nil || version < 3 {
+		return nil, fmt.Errorf("version '%s' of the %s file isn't supported. Upgrade Deno and run 'deno install' to update it", lockFile.Version, DenoLockFileName)
+	}
+	packages := lockFile.denoLockPackages
+	if lockFile.Packages != nil {
+		packages = *lockFile.Packages
+	}
+	resolver := &denoPackagesResolver{packages: packages}
+	lock := &DenoLock{}
+	for _, kind := range []string{DenoJsrKind, DenoNpmKind} {
+		for _, key := range getSortedKeys(packages.getPackages(kind)) {
+			lockedPackage := packages.getPackages(kind)[key]
+			name, version := splitDenoPackageKey(key)
+			pkg := DenoPackage{Kind: kind, Name: name, Version: version, Integrity: lockedPackage.Integrity}
+			dependencies, err := lockedPackage.getDependencies()
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing the dependencies of %s in the %s file: %s", key, DenoLockFileName, err.Error())
+			}
+			for _, dependency := range dependencies {
+				// The dependencies of npm packages are written without the 'npm:' prefix.
+				if dependencyId := resolver.resolve(dependency, kind); dependencyId != "" {
+					pkg.Dependencies = append(pkg.Dependencies, dependencyId)
+				}
+			}
+			lock.Packages = append(lock.Packages, pkg)
+		}
+	}
+	directSpecifiers := append(lockFile.Workspace.Dependencies, lockFile.Workspace.PackageJson.Dependencies...)
+	if len(directSpecifiers) == 0 {
+		// Lock files without a workspace section don't specify the direct dependencies, so all the specifiers used in the project are considered as direct.
+		directSpecifiers = getSortedKeys(packages.Specifiers)
+	}
+	for _, specifier := range directSpecifiers {
+		if dependencyId := resolver.resolve(specifier, ""); dependencyId != "" {
+			lock.DirectDependencies = append(lock.DirectDependencies, dependencyId)
+		}
+	}
+	for _, url := range getSortedKeys(lockFile.Remote) {
+		lock.RemoteModules = append(lock.RemoteModules, DenoRemoteModule{Url: url, Hash: lockFile.Remote[url]})
+	}
+	return lock, nil
+}
+
+func (dlp *denoLockPackages) getPackages(kind string) map[string]denoLockedPackage {
+	if kind == DenoJsrKind {
+		return dlp.Jsr
+	}
+	return dlp.Npm
+}
+
+// Resolves the specifiers in a deno.lock file to the IDs of the locked packages.
+type denoPackagesResolver struct {
+	packages denoLockPackages
+}
+
+// Returns the ID of the package which the given specifier is resolved to, or an empty string if it's not found.
+// The specifier can be a full specifier (for example: 'jsr:@std/path@^1.0.0'), the key of a locked package ('@std/path@1.0.8'), or only the name of the package, if a single version of it is locked.
+// defaultKind is the kind of specifiers which don't have a 'jsr:' or 'npm:' prefix.
+func (dpr *denoPackagesResolver) resolve(specifier, defaultKind string) string {
+	kind, reference, found := strings.Cut(specifier, ":")
+	if !found || (kind != DenoJsrKind && kind != DenoNpmKind) {
+		if defaultKind == "" {
+			return ""
+		}
+		kind, reference = defaultKind, specifier
+	}
+	lockedPackages := dpr.packages.getPackages(kind)
+	if resolved, ok := dpr.packages.Specifiers[kind+":"+reference]; ok {
+		if strings.HasPrefix(resolved, kind+":") {
+			// Version 3: the specifier is mapped to the resolved package.
+			reference = strings.TrimPrefix(resolved, kind+":")
+		} else {
+			// Version 4: the specifier is mapped to the resolved version.
+			name, _ := splitDenoPackageKey(reference)
+			reference = name + "@" + resolved
+		}
+	}
+	if _, ok := lockedPackages[reference]; !ok {
+		name, _ := splitDenoPackageKey(reference)
+		reference = ""
+		for _, key := range getSortedKeys(lockedPackages) {
+			if keyName, _ := splitDenoPackageKey(key); keyName == name {
+				reference = key
+				break
+			}
+		}
+		if reference == "" {
+			return ""
+		}
+	}
+	name, version := splitDenoPackageKey(reference)
+	return name + ":" + version
+}
+
+// Splits the key of a package in the deno.lock file to its name and version. For example: '@std/path@1.0.8' -> '@std/path', '1.0.8'.
+// The resolved peer dependencies of npm packages are removed from the version, for example: 'preact-render-to-string@6.5.11_preact@10.24.3'.
+func splitDenoPackageKey(key string) (name, version string) {
+	start := 0
+	if strings.HasPrefix(key, "@") {
+		// Skip the '@' prefix of scoped packages.
+		start = 1
+	}
+	i := strings.Index(key[start:], "@")
+	if i < 0 {
+		return key, ""
+	}
+	i += start
+	name, version = key[:i], key[i+1:]
+	if j := strings.Index(version, "_"); j >= 0 {
+		version = version[:j]
+	}
+	return name, version
+}
+
+func getSortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDenoLockV3(t *testing.T) {
+	lock, err := ReadDenoLock(filepath.Join("..", "testdata", "deno", "project-v3"))
+	assert.NoError(t, err)
+	assert.Equal(t, &DenoLock{
+		Packages: []DenoPackage{
+			{Kind: DenoJsrKind, Name: "@std/assert", Version: "0.220.1", Integrity: "88710d54f3afdd7a5761e7805abba1f56cd14e4b212feffeb3e73a9f77482425"},
+			{Kind: DenoJsrKind, Name: "@std/path", Version: "0.220.1", Integrity: "21a2ae2ba3f1a4d774a2f0526f400bd8302d7ae8e14897e710ff00ea07d5193d", Dependencies: []string{"@std/assert:0.220.1"}},
+			{Kind: DenoNpmKind, Name: "debug", Version: "4.3.4", Integrity: "sha512-PRWFHuSU3eDtQJPvnNY7Jcket1j0t5OuOsFzPPzsekD52Zl8qUfFIPEiswXqIvHWGVHOgX+7G/vCNNhehwxfkQ==", Dependencies: []string{"ms:2.1.2"}},
+			{Kind: DenoNpmKind, Name: "ms", Version: "2.1.2", Integrity: "sha1-0J0fNXtEP0kzgqjrPM0YOHKuYAk="},
+		},
+		// Without a workspace section, all the specifiers are considered as direct dependencies.
+		DirectDependencies: []string{"@std/path:0.220.1", "debug:4.3.4"},
+	}, lock)
+}
+
+func TestSplitDenoPackageKey(t *testing.T) {
+	testCases := []struct {
+		key     string
+		name    string
+		version string
+	}{
+		{"@std/path@1.0.8", "@std/path", "1.0.8"},
+		{"chalk@5.3.0", "chalk", "5.3.0"},
+		{"preact-render-to-string@6.5.11_preact@10.24.3", "preact-render-to-string", "6.5.11"},
+		{"@std/assert", "@std/assert", ""},
+		{"ms", "ms", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.key, func(t *testing.T) {
+			name, version := splitDenoPackageKey(testCase.key)
+			assert.Equal(t, testCase.name, name)
+			assert.Equal(t, testCase.version, version)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The suffix of the files, which list the md5 checksums of the files installed by a package.
+	DpkgMd5sumsFileSuffix = ".md5sums"
+)
+
+var (
+	// The paths of the status database, and of the status directory (where distroless images list their packages, one file per package), relative to the root directory of the system.
+	DpkgStatusFilePath = filepath.Join("var", "lib", "dpkg", "status")
+	DpkgStatusDirPath  = filepath.Join("var", "lib", "dpkg", "status.d")
+	// The directory, which holds the md5sums files of the installed packages.
+	dpkgInfoDirPath = filepath.Join("var", "lib", "dpkg", "info")
+	// The file, in which apt marks the packages, which were installed automatically as dependencies of other packages.
+	AptExtendedStatesFilePath = filepath.Join("var", "lib", "apt", "extended_states")
+)
+
+// DpkgPackage represents a Debian package installed on a system.
+type DpkgPackage struct {
+	Name         string
+	Version      string
+	Architecture string
+	// The source package, from which the package was built.
+	Source string
+	// The names of the packages (or of the virtual packages), which this package depends on.
+	// Each element holds the alternatives of a single dependency, for example: ['libgcc-s1', 'libgcc1'].
+	Depends [][]string
+	// The names of the virtual packages, which this package provides.
+	Provides []string
+	// The path of the file, which lists the md5 checksums of the files installed by the package. Empty if the package didn't install any files.
+	Md5sumsPath string
+}
+
+func (dp *DpkgPackage) Id() string {
+	return dp.Name + ":" + dp.Version
+}
+
+// ReadDpkgPackages returns the packages installed on the system in the given root directory, sorted by their names.
+// The packages are read from the status database of dpkg, or (in distroless images) from the files in its status directory.
+func ReadDpkgPackages(rootDir string) ([]DpkgPackage, error) {
+	var packages []DpkgPackage
+	exists, err := utils.IsFileExists(filepath.Join(rootDir, DpkgStatusFilePath), true)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if packages, err = readDpkgStatusFile(filepath.Join(rootDir, DpkgStatusFilePath), filepath.Join(rootDir, dpkgInfoDirPath)); err != nil {
+			return nil, err
+		}
+	}
+	statusDirPath := filepath.Join(rootDir, DpkgStatusDirPath)
+	exists, err = utils.IsDirExists(statusDirPath, true)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		statusFiles, err := os.ReadDir(statusDirPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, statusFile := range statusFiles {
+			if statusFile.IsDir() || strings.HasSuffix(statusFile.Name(), DpkgMd5sumsFileSuffix) {
+				continue
+			}
+			statusDirPackages, err := readDpkgStatusFile(filepath.Join(statusDirPath, statusFile.Name()), statusDirPath)
+			if err != nil {
+				return nil, err
+			}
+			packages = append(packages, statusDirPackages...)
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+// Reads the installed packages from a status file, which consists of paragraphs separated by empty lines.
+// Each paragraph holds the fields of a package, for example: 'Package: libc6'. Lines, which start with a space, continue the value of the previous field.
+func readDpkgStatusFile(statusPath, md5sumsDir string) ([]DpkgPackage, error) {
+	content, err := os.ReadFile(statusPath)
+	if err != nil {
+		return nil, err
+	}
+	var packages []DpkgPackage
+	fields := make(map[string]string)
+	addPackage := func() error {
+		defer func() {
+			fields = make(map[string]string)
+		}()
+		// Packages, which were removed but whose configuration files remain, are listed with the 'deinstall ok config-files' status.
+		if fields["Package"] == "" || (fields["Status"] != "" && !strings.HasSuffix(fields["Status"], " installed")) {
+			return nil
+		}
+		pkg := DpkgPackage{
+			Name:         fields["Package"],
+			Version:      fields["Version"],
+			Architecture: fields["Architecture"],
+			Depends:      parseDpkgDependencies(fields["Pre-Depends"] + "," + fields["Depends"]),
+		}
+		// The source field may hold the version of the source package, for example: 'glibc (2.36-9)'.
+		pkg.Source = strings.TrimSpace(strings.Split(fields["Source"], "(")[0])
+		for _, provided := range parseDpkgDependencies(fields["Provides"]) {
+			pkg.Provides = append(pkg.Provides, provided...)
+		}
+		// The md5sums files of packages, which can be installed for several architectures, are qualified by the architecture.
+		for _, md5sumsName := range []string{pkg.Name + ":" + pkg.Architecture, pkg.Name} {
+			md5sumsPath := filepath.Join(md5sumsDir, md5sumsName+DpkgMd5sumsFileSuffix)
+			exists, err := utils.IsFileExists(md5sumsPath, true)
+			if err != nil {
+				return err
+			}
+			if exists {
+				pkg.Md5sumsPath = md5sumsPath
+				break
+			}
+		}
+		packages = append(packages, pkg)
+		return nil
+	}
+	lastField := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if err = addPackage(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if lastField != "" {
+				fields[lastField] += "\n" + strings.TrimSpace(line)
+			}
+			continue
+		}
+		field, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		lastField = field
+		fields[field] = strings.TrimSpace(value)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err = addPackage(); err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+// Parses a comma-separated list of dependencies, such as 'libc6 (>= 2.34), libgcc-s1 | libgcc1, python3:any'.
+// The version constraints and the architecture qualifiers are removed, and each element holds the alternatives of a single dependency.
+func parseDpkgDependencies(dependencies string) [][]string {
+	var parsed [][]string
+	for _, dependency := range strings.Split(dependencies, ",") {
+		var alternatives []string
+		for _, alternative := range strings.Split(dependency, "|") {
+			name := strings.TrimSpace(strings.Split(alternative, "(")[0])
+			name = strings.Split(name, ":")[0]
+			if name != "" {
+				alternatives = append(alternatives, name)
+			}
+		}
+		if len(alternatives) > 0 {
+			parsed = append(parsed, alternatives)
+		}
+	}
+	return parsed
+}
+
+// ReadAptAutoInstalled returns the names of the packages, which apt installed automatically as dependencies of other packages, on the system in the given root directory.
+// Nil is returned if the system doesn't have an extended_states file (for example, if the packages were installed by dpkg directly).
+func ReadAptAutoInstalled(rootDir string) (map[string]bool, error) {
+	extendedStatesPath := filepath.Join(rootDir, AptExtendedStatesFilePath)
+	exists, err := utils.IsFileExists(extendedStatesPath, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := os.ReadFile(extendedStatesPath)
+	if err != nil {
+		return nil, err
+	}
+	autoInstalled := make(map[string]bool)
+	name := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		field, value, _ := strings.Cut(line, ":")
+		switch strings.TrimSpace(field) {
+		case "Package":
+			name = strings.TrimSpace(value)
+		case "Auto-Installed":
+			if strings.TrimSpace(value) == "1" {
+				autoInstalled[name] = true
+			}
+		}
+	}
+	return autoInstalled, nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDpkgPackages(t *testing.T) {
+	rootDir := filepath.Join("..", "testdata", "dpkg", "root")
+	packages, err := ReadDpkgPackages(rootDir)
+	require.NoError(t, err)
+	// Removed packages are excluded.
+	require.Len(t, packages, 8)
+	assert.Equal(t, "curl", packages[0].Name)
+	assert.Equal(t, [][]string{{"libc6"}, {"libcurl4"}, {"zlib1g"}}, packages[0].Depends)
+	assert.Equal(t, DpkgPackage{
+		Name:         "libgcc-s1",
+		Version:      "12.2.0-14",
+		Architecture: "amd64",
+		Source:       "gcc-12",
+		Depends:      [][]string{{"gcc-12-base"}, {"libc6"}},
+		Provides:     []string{"libgcc1"},
+		Md5sumsPath:  filepath.Join(rootDir, "var", "lib", "dpkg", "info", "libgcc-s1.md5sums"),
+	}, packages[4])
+	// The pre-dependencies are included.
+	assert.Equal(t, "mawk", packages[6].Name)
+	assert.Equal(t, [][]string{{"libc6"}}, packages[6].Depends)
+	assert.Equal(t, []string{"awk"}, packages[6].Provides)
+
+	packages, err = ReadDpkgPackages(filepath.Join("..", "testdata", "dpkg", "distroless"))
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+	assert.Equal(t, "base-files", packages[0].Name)
+	assert.Equal(t, [][]string{{"debconf", "debconf-2.0"}}, packages[1].Depends)
+	assert.Empty(t, packages[1].Md5sumsPath)
+}
+
+func TestParseDpkgDependencies(t *testing.T) {
+	assert.Equal(t, [][]string{{"libc6"}, {"libgcc-s1", "libgcc1"}, {"python3"}}, parseDpkgDependencies("libc6 (>= 2.34), libgcc-s1 | libgcc1 (>= 1:3.0),\n python3:any"))
+	assert.Empty(t, parseDpkgDependencies(","))
+}
+
+func TestReadAptAutoInstalled(t *testing.T) {
+	autoInstalled, err := ReadAptAutoInstalled(filepath.Join("..", "testdata", "dpkg", "root"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"libcurl4": true, "libssl3": true, "mawk": true}, autoInstalled)
+
+	// A system without an extended_states file.
+	autoInstalled, err = ReadAptAutoInstalled(filepath.Join("..", "testdata", "dpkg", "distroless"))
+	assert.NoError(t, err)
+	assert.Nil(t, autoInstalled)
+}
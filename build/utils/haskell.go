@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	CabalFreezeFileName = "cabal.project.freeze"
+	StackLockFileName   = "stack.yaml.lock"
+	// The hpack package description, which is used by Stack projects instead of a .cabal file.
+	hpackFileName        = "package.yaml"
+	HaskellHackageSource = "hackage"
+	HaskellGitSource     = "git"
+	HaskellUrlSource     = "archive"
+	hackageServerName    = "hackage.haskell.org"
+)
+
+var (
+	cabalFieldRegExp            = regexp.MustCompile(`^([\w-]+)\s*:\s*(.*)$`)
+	cabalFreezeConstraintRegExp = regexp.MustCompile(`^(?:any\.)?([\w-]+)\s+(.+)$`)
+	stackHackagePackageRegExp   = regexp.MustCompile(`^(.+)-(\d[\d.]*)(?:@sha256:([0-9a-f]+))?`)
+)
+
+// HaskellPackage represents a package listed in a cabal.project.freeze file or in a stack.yaml.lock file.
+type HaskellPackage struct {
+	Name    string
+	Version string
+	// The source of the package: hackage, git or archive.
+	Source string
+	// The flags of the package, as set in the cabal.project.freeze file, for example: '-ordered-keymap'.
+	Flags []string
+	// The sha256 checksum of the package's .cabal file, and of its pantry tree, as listed in the stack.yaml.lock file.
+	CabalFileHash  string
+	PantryTreeHash string
+	// The URL of the package's git repository or archive.
+	Url       string
+	GitCommit string
+	// The sha256 checksum of the package's archive.
+	ArchiveHash string
+}
+
+func (hp *HaskellPackage) Id() string {
+	return hp.Name + ":" + hp.Version
+}
+
+type stackLockFile struct {
+	Packages []struct {
+		Completed stackLockPackage `yaml:"completed"`
+	} `yaml:"packages"`
+}
+
+type stackLockPackage struct {
+	Hackage    string `yaml:"hackage,omitempty"`
+	Git        string `yaml:"git,omitempty"`
+	Commit     string `yaml:"commit,omitempty"`
+	Url        string `yaml:"url,omitempty"`
+	Sha256     string `yaml:"sha256,omitempty"`
+	Name       string `yaml:"name,omitempty"`
+	Version    string `yaml:"version,omitempty"`
+	PantryTree struct {
+		Sha256 string `yaml:"sha256,omitempty"`
+	} `yaml:"pantry-tree,omitempty"`
+}
+
+type hpackPackage struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// GetHaskellPackageNameAndVersion returns the name and version of the Haskell package in the given directory, from its .cabal file, or from its package.yaml file, if there's no .cabal file.
+func GetHaskellPackageNameAndVersion(srcPath string) (name, version string, err error) {
+	cabalFiles, err := filepath.Glob(filepath.Join(srcPath, "*.cabal"))
+	if err != nil {
+		return
+	}
+	if len(cabalFiles) > 0 {
+		var content []byte
+		content, err = os.ReadFile(cabalFiles[0])
+		if err != nil {
+			return
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			// Only the top-level fields, which aren't indented, describe the package.
+			match := cabalFieldRegExp.FindStringSubmatch(scanner.Text())
+			if match == nil {
+				continue
+			}
+			switch strings.ToLower(match[1]) {
+			case "name":
+				name = strings.TrimSpace(match[2])
+			case "version":
+				version = strings.TrimSpace(match[2])
+			}
+		}
+		err = scanner.Err()
+		return
+	}
+	hpackPath := filepath.Join(srcPath, hpackFileName)
+	exists, err := utils.IsFileExists(hpackPath, true)
+	if err != nil || !exists {
+		return
+	}
+	var hpack hpackPackage
+	err = readYamlFile(hpackPath, &hpack)
+	return hpack.Name, hpack.Version, err
+}
+
+// ReadCabalFreeze returns the packages, whose versions are pinned in the cabal.project.freeze file in the given directory.
+func ReadCabalFreeze(srcPath string) ([]HaskellPackage, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, CabalFreezeFileName))
+	if err != nil {
+		return nil, err
+	}
+	// The constraints field may span several lines. Its continuation lines are indented.
+	var constraints []string
+	inConstraints := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := cabalFieldRegExp.FindStringSubmatch(line); match != nil {
+			inConstraints = match[1] == "constraints"
+			line = match[2]
+		} else if !inConstraints || strings.TrimSpace(line) == "" || !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			inConstraints = false
+			continue
+		}
+		if inConstraints {
+			constraints = append(constraints, strings.Split(line, ",")...)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	packagesMap := make(map[string]*HaskellPackage)
+	for _, constraint := range constraints {
+		// Qualified constraints, such as 'setup.Cabal ==3.8.1.0', apply to the setup scripts of packages, so they're skipped.
+		match := cabalFreezeConstraintRegExp.FindStringSubmatch(strings.TrimSpace(constraint))
+		if match == nil {
+			continue
+		}
+		pkg, ok := packagesMap[match[1]]
+		if !ok {
+			pkg = &HaskellPackage{Name: match[1], Source: HaskellHackageSource}
+			packagesMap[match[1]] = pkg
+		}
+		restriction := strings.TrimSpace(match[2])
+		switch {
+		case strings.HasPrefix(restriction, "=="):
+			pkg.Version = strings.TrimSpace(strings.TrimPrefix(restriction, "=="))
+		case strings.HasPrefix(restriction, "+") || strings.HasPrefix(restriction, "-"):
+			pkg.Flags = append(pkg.Flags, strings.Fields(restriction)...)
+		}
+	}
+	var packages []HaskellPackage
+	for _, pkg := range packagesMap {
+		// Packages without a pinned version (like packages constrained to the 'installed' version) are skipped.
+		if pkg.Version != "" {
+			packages = append(packages, *pkg)
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+// ReadStackLock returns the packages listed in the stack.yaml.lock file in the given directory.
+// The lock file lists the extra-deps of the project, which aren't included in its snapshot.
+func ReadStackLock(srcPath string) ([]HaskellPackage, error) {
+	var lockFile stackLockFile
+	if err := readYamlFile(filepath.Join(srcPath, StackLockFileName), &lockFile); err != nil {
+		return nil, err
+	}
+	var packages []HaskellPackage
+	for _, lockedPackage := range lockFile.Packages {
+		completed := lockedPackage.Completed
+		pkg := HaskellPackage{Name: completed.Name, Version: completed.Version, PantryTreeHash: completed.PantryTree.Sha256}
+		switch {
+		case completed.Hackage != "":
+			// For example: 'acme-missiles-0.3@sha256:2ba66a09...,613'.
+			match := stackHackagePackageRegExp.FindStringSubmatch(completed.Hackage)
+			if match == nil {
+				continue
+			}
+			pkg.Name, pkg.Version, pkg.CabalFileHash, pkg.Source = match[1], match[2], match[3], HaskellHackageSource
+		case completed.Git != "":
+			pkg.Source, pkg.Url, pkg.GitCommit = HaskellGitSource, completed.Git, completed.Commit
+		case completed.Url != "":
+			pkg.Source, pkg.Url, pkg.ArchiveHash = HaskellUrlSource, completed.Url, completed.Sha256
+		default:
+			continue
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// GetCabalPackagesCachePath returns the path of the directory, where cabal caches the tarballs of the downloaded packages.
+// The directory can be set using the CABAL_DIR environment variable. Otherwise, it's ~/.cabal/packages if it exists, or the XDG cache directory (used since cabal 3.10).
+func GetCabalPackagesCachePath() (string, error) {
+	if cabalDir := os.Getenv("CABAL_DIR"); cabalDir != "" {
+		return filepath.Join(cabalDir, "packages"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	cachePath := filepath.Join(homeDir, ".cabal", "packages")
+	exists, err := utils.IsDirExists(cachePath, true)
+	if err != nil || exists {
+		return cachePath, err
+	}
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		cacheHome = filepath.Join(homeDir, ".cache")
+	}
+	return filepath.Join(cacheHome, "cabal", "packages"), nil
+}
+
+// GetHackageTarballPath returns the path of the tarball of a Hackage package in the cabal packages cache.
+func GetHackageTarballPath(cachePath string, pkg HaskellPackage) string {
+	return filepath.Join(cachePath, hackageServerName, pkg.Name, pkg.Version, pkg.Name+"-"+pkg.Version+".tar.gz")
+}
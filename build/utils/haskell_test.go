@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHaskellPackageNameAndVersion(t *testing.T) {
+	name, version, err := GetHaskellPackageNameAndVersion(filepath.Join("..", "testdata", "haskell", "cabal-project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", name)
+	assert.Equal(t, "0.1.0.0", version)
+
+	// A Stack project, which is described by a package.yaml file.
+	name, version, err = GetHaskellPackageNameAndVersion(filepath.Join("..", "testdata", "haskell", "stack-project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "stack-hello", name)
+	assert.Equal(t, "1.2.0", version)
+}
+
+func TestReadCabalFreeze(t *testing.T) {
+	packages, err := ReadCabalFreeze(filepath.Join("..", "testdata", "haskell", "cabal-project"))
+	assert.NoError(t, err)
+	assert.Equal(t, []HaskellPackage{
+		{Name: "aeson", Version: "2.1.2.1", Source: HaskellHackageSource, Flags: []string{"-cffi", "+ordered-keymap"}},
+		{Name: "base", Version: "4.17.2.0", Source: HaskellHackageSource},
+		{Name: "ghc-prim", Version: "0.9.1", Source: HaskellHackageSource},
+		{Name: "text-short", Version: "0.1.5", Source: HaskellHackageSource, Flags: []string{"-asserts"}},
+	}, packages)
+}
+
+func TestReadStackLock(t *testing.T) {
+	packages, err := ReadStackLock(filepath.Join("..", "testdata", "haskell", "stack-project"))
+	assert.NoError(t, err)
+	assert.Equal(t, []HaskellPackage{
+		{
+			Name:           "acme-missiles",
+			Version:        "0.3",
+			Source:         HaskellHackageSource,
+			CabalFileHash:  "2ba66a092a32593880a87fb00f3213762d7bca65a687d45965778deb8694c5d1",
+			PantryTreeHash: "614bc0cca76937507ea0a5ccc17a504c997ce458d7f2f9e43b15a10c8eaeb033",
+		},
+		{
+			Name:           "text-short",
+			Version:        "0.1.5",
+			Source:         HaskellGitSource,
+			Url:            "https://github.com/haskell/text-short.git",
+			GitCommit:      "1d0b391b2aa0fa3e6e6cc31d7c0fbd3c1f8e0c0e",
+			PantryTreeHash: "6f0bd6e8bc2c9c8ac6e7f39b4e8da6e3db4b0c0f421c7d8e5c6fbd2f1e3a4b5c",
+		},
+		{
+			Name:           "split",
+			Version:        "0.2.4",
+			Source:         HaskellUrlSource,
+			Url:            "https://hackage.haskell.org/package/split-0.2.4/split-0.2.4.tar.gz",
+			ArchiveHash:    "9c0b8ce2b1fbd0b74d1ce6c8bc6b5d2e6f8c8b1a4f3e2d1c0b9a8f7e6d5c4b3a",
+			PantryTreeHash: "3ea8b8d36d7e1e5b7c3c2c0d0e1f4c0c0a3e1d0b6b5a4c2d9f8e7a6b5c4d3e2f",
+		},
+	}, packages)
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	HelmChartFileName     = "Chart.yaml"
+	HelmChartLockFileName = "Chart.lock"
+	// The lock file of charts with apiVersion v1.
+	HelmRequirementsLockFileName = "requirements.lock"
+	// The directory, where 'helm dependency build' downloads the dependencies of the chart to.
+	HelmChartsDirName = "charts"
+)
+
+// HelmChart represents a Chart.yaml file.
+type HelmChart struct {
+	ApiVersion string `yaml:"apiVersion,omitempty"`
+	Name       string `yaml:"name,omitempty"`
+	Version    string `yaml:"version,omitempty"`
+}
+
+// HelmChartDependency represents a dependency listed in a Chart.lock file.
+type HelmChartDependency struct {
+	Name       string `yaml:"name,omitempty"`
+	Version    string `yaml:"version,omitempty"`
+	Repository string `yaml:"repository,omitempty"`
+}
+
+func (hcd *HelmChartDependency) Id() string {
+	return hcd.Name + ":" + hcd.Version
+}
+
+// HelmChartLock represents a Chart.lock (or requirements.lock) file.
+type HelmChartLock struct {
+	Dependencies []HelmChartDependency `yaml:"dependencies,omitempty"`
+}
+
+// ReadHelmChart reads the Chart.yaml file in the given directory.
+func ReadHelmChart(srcPath string) (*HelmChart, error) {
+	chart := new(HelmChart)
+	return chart, readYamlFile(filepath.Join(srcPath, HelmChartFileName), chart)
+}
+
+// ReadHelmChartLock reads the Chart.lock file in the given directory, or the requirements.lock file if it doesn't exist.
+func ReadHelmChartLock(srcPath string) (*HelmChartLock, error) {
+	lockPath := filepath.Join(srcPath, HelmChartLockFileName)
+	if _, err := os.Stat(lockPath); os.IsNotExist(err) {
+		lockPath = filepath.Join(srcPath, HelmRequirementsLockFileName)
+	}
+	lock := new(HelmChartLock)
+	return lock, readYamlFile(lockPath, lock)
+}
+
+// GetHelmChartArchivePath returns the path of the archive of a dependency, as downloaded by 'helm dependency build' to the charts directory.
+func GetHelmChartArchivePath(srcPath string, dependency HelmChartDependency) string {
+	return filepath.Join(srcPath, HelmChartsDirName, GetHelmChartArchiveName(dependency.Name, dependency.Version))
+}
+
+// GetHelmChartArchiveName returns the file name of a packaged chart, for example: 'postgresql-12.5.8.tgz'.
+func GetHelmChartArchiveName(name, version string) string {
+	return name + "-" + version + ".tgz"
+}
+
+func readYamlFile(path string, v interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(content, v)
+}
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The suffix of the JSON files, which 'brew bottle --json' creates next to the bottles it builds.
+	HomebrewBottleJsonSuffix = ".bottle.json"
+	// The tag of bottles, which can be installed on all platforms.
+	homebrewAllBottleTag = "all"
+)
+
+// HomebrewBottle represents a bottle built by 'brew bottle', as described in its JSON file.
+type HomebrewBottle struct {
+	FormulaName string
+	// The version of the formula, including its revision, for example: '2.12.1_1'.
+	PkgVersion string
+	// The platform of the bottle, for example: 'arm64_sonoma'.
+	Tag string
+	// The name of the bottle file, as created in the local directory.
+	LocalFileName string
+	Sha256        string
+	// The tap of the formula, and its git revision.
+	TapGitRemote   string
+	TapGitRevision string
+}
+
+func (hb *HomebrewBottle) FormulaId() string {
+	return hb.FormulaName + ":" + hb.PkgVersion
+}
+
+type homebrewBottleJson map[string]struct {
+	Formula struct {
+		Name           string `json:"name"`
+		PkgVersion     string `json:"pkg_version"`
+		TapGitRemote   string `json:"tap_git_remote"`
+		TapGitRevision string `json:"tap_git_revision"`
+	} `json:"formula"`
+	Bottle struct {
+		Tags map[string]struct {
+			Filename      string `json:"filename"`
+			LocalFilename string `json:"local_filename"`
+			Sha256        string `json:"sha256"`
+		} `json:"tags"`
+	} `json:"bottle"`
+}
+
+// HomebrewFormula represents an installed formula, as described by 'brew info --json=v2 --installed'.
+type HomebrewFormula struct {
+	Name     string
+	FullName string
+	Tap      string
+	// The installed version of the formula, including its revision.
+	InstalledVersion string
+	// The names of the runtime dependencies, which the formula declares directly.
+	Dependencies []string
+	// The names of the dependencies, which are required only for building the formula from source.
+	BuildDependencies []string
+	// The versions of the runtime dependencies, which the formula was installed with, mapped by their names.
+	InstalledDependencies map[string]string
+	// Indicates that the formula was installed from a bottle, rather than built from source.
+	PouredFromBottle bool
+	// The version of the stable bottles, and the bottles (mapped by their tags), as listed in the tap.
+	BottleVersion string
+	Bottles       map[string]HomebrewBottleFile
+}
+
+func (hf *HomebrewFormula) Id() string {
+	return hf.Name + ":" + hf.InstalledVersion
+}
+
+// HomebrewBottleFile represents a bottle of a formula in a tap.
+type HomebrewBottleFile struct {
+	Url    string `json:"url"`
+	Sha256 string `json:"sha256"`
+}
+
+// GetInstalledBottle returns the bottle, from which the formula was installed on the platform with the given tag.
+// False is returned if the formula was built from source, or if its installed version isn't the version of the bottles in the tap.
+func (hf *HomebrewFormula) GetInstalledBottle(tag string) (HomebrewBottleFile, bool) {
+	if !hf.PouredFromBottle || hf.InstalledVersion != hf.BottleVersion {
+		return HomebrewBottleFile{}, false
+	}
+	if bottle, ok := hf.Bottles[tag]; ok {
+		return bottle, true
+	}
+	bottle, ok := hf.Bottles[homebrewAllBottleTag]
+	return bottle, ok
+}
+
+type homebrewInfoJson struct {
+	Formulae []struct {
+		Name     string `json:"name"`
+		FullName string `json:"full_name"`
+		Tap      string `json:"tap"`
+		Versions struct {
+			Stable string `json:"stable"`
+		} `json:"versions"`
+		Revision          int      `json:"revision"`
+		Dependencies      []string `json:"dependencies"`
+		BuildDependencies []string `json:"build_dependencies"`
+		Bottle            struct {
+			Stable struct {
+				Files map[string]HomebrewBottleFile `json:"files"`
+			} `json:"stable"`
+		} `json:"bottle"`
+		Installed []struct {
+			Version             string `json:"version"`
+			PouredFromBottle    bool   `json:"poured_from_bottle"`
+			RuntimeDependencies []struct {
+				FullName   string `json:"full_name"`
+				PkgVersion string `json:"pkg_version"`
+			} `json:"runtime_dependencies"`
+		} `json:"installed"`
+	} `json:"formulae"`
+}
+
+// ReadHomebrewBottles returns the bottles described by the bottle JSON files in the given directory, sorted by their formulae and tags.
+func ReadHomebrewBottles(srcPath string) ([]HomebrewBottle, error) {
+	jsonFiles, err := filepath.Glob(filepath.Join(srcPath, "*"+HomebrewBottleJsonSuffix))
+	if err != nil {
+		return nil, err
+	}
+	var bottles []HomebrewBottle
+	for _, jsonFile := range jsonFiles {
+		var bottleJson homebrewBottleJson
+		if err = utils.Unmarshal(jsonFile, &bottleJson); err != nil {
+			return nil, err
+		}
+		for _, formulaBottles := range bottleJson {
+			for tag, tagBottle := range formulaBottles.Bottle.Tags {
+				localFileName := tagBottle.LocalFilename
+				if localFileName == "" {
+					localFileName = tagBottle.Filename
+				}
+				bottles = append(bottles, HomebrewBottle{
+					FormulaName:    formulaBottles.Formula.Name,
+					PkgVersion:     formulaBottles.Formula.PkgVersion,
+					Tag:            tag,
+					LocalFileName:  localFileName,
+					Sha256:         tagBottle.Sha256,
+					TapGitRemote:   formulaBottles.Formula.TapGitRemote,
+					TapGitRevision: formulaBottles.Formula.TapGitRevision,
+				})
+			}
+		}
+	}
+	sort.Slice(bottles, func(i, j int) bool {
+		if bottles[i].FormulaName == bottles[j].FormulaName {
+			return bottles[i].Tag < bottles[j].Tag
+		}
+		return bottles[i].FormulaName < bottles[j].FormulaName
+	})
+	return bottles, nil
+}
+
+// RunBrewInfo returns the installed formulae, as described by 'brew info --json=v2 --installed'.
+func RunBrewInfo() (map[string]HomebrewFormula, error) {
+	command := utils.NewCommand("brew", "info", []string{"--json=v2", "--installed"})
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed running 'brew info': %s", err.Error())
+	}
+	return ParseBrewInfo(output)
+}
+
+// ParseBrewInfo parses the output of 'brew info --json=v2', and returns the installed formulae, mapped by their names.
+func ParseBrewInfo(output []byte) (map[string]HomebrewFormula, error) {
+	var info homebrewInfoJson
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, err
+	}
+	formulae := make(map[string]HomebrewFormula)
+	for _, formulaInfo := range info.Formulae {
+		if len(formulaInfo.Installed) == 0 {
+			continue
+		}
+		// The last installed version is the linked one.
+		installed := formulaInfo.Installed[len(formulaInfo.Installed)-1]
+		formula := HomebrewFormula{
+			Name:                  formulaInfo.Name,
+			FullName:              formulaInfo.FullName,
+			Tap:                   formulaInfo.Tap,
+			InstalledVersion:      installed.Version,
+			Dependencies:          getHomebrewFormulaeNames(formulaInfo.Dependencies),
+			BuildDependencies:     getHomebrewFormulaeNames(formulaInfo.BuildDependencies),
+			InstalledDependencies: make(map[string]string),
+			PouredFromBottle:      installed.PouredFromBottle,
+			BottleVersion:         formulaInfo.Versions.Stable,
+			Bottles:               formulaInfo.Bottle.Stable.Files,
+		}
+		if formulaInfo.Revision > 0 {
+			formula.BottleVersion += "_" + strconv.Itoa(formulaInfo.Revision)
+		}
+		for _, dependency := range installed.RuntimeDependencies {
+			formula.InstalledDependencies[getHomebrewFormulaName(dependency.FullName)] = dependency.PkgVersion
+		}
+		formulae[formula.Name] = formula
+	}
+	return formulae, nil
+}
+
+func getHomebrewFormulaeNames(fullNames []string) (names []string) {
+	for _, fullName := range fullNames {
+		names = append(names, getHomebrewFormulaName(fullName))
+	}
+	return
+}
+
+// Returns the name of a formula from its full name. The full names of formulae from taps other than homebrew/core are prefixed with the tap, for example: 'hashicorp/tap/terraform'.
+func getHomebrewFormulaName(fullName string) string {
+	return fullName[strings.LastIndex(fullName, "/")+1:]
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHomebrewBottles(t *testing.T) {
+	bottles, err := ReadHomebrewBottles(filepath.Join("..", "testdata", "homebrew", "bottles"))
+	require.NoError(t, err)
+	assert.Equal(t, []HomebrewBottle{{
+		FormulaName:    "hello",
+		PkgVersion:     "2.12.1",
+		Tag:            "arm64_sonoma",
+		LocalFileName:  "hello--2.12.1.arm64_sonoma.bottle.tar.gz",
+		Sha256:         "612e85ee25d7d1cc38fc13f5859663ea9f31b09d73ec4d6af5d1d34c9a38f949",
+		TapGitRemote:   "https://github.com/Homebrew/homebrew-core",
+		TapGitRevision: "8d5b2a5c27b5b91c0de3a066c7d7a9e5f1e28ee0",
+	}}, bottles)
+}
+
+func TestParseBrewInfo(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("..", "testdata", "homebrew", "brew-info.json"))
+	require.NoError(t, err)
+	formulae, err := ParseBrewInfo(output)
+	require.NoError(t, err)
+	assert.Len(t, formulae, 4)
+
+	gettext := formulae["gettext"]
+	assert.Equal(t, "gettext:0.22_1", gettext.Id())
+	assert.Equal(t, "0.22_1", gettext.BottleVersion)
+	assert.Equal(t, []string{"libunistring"}, gettext.Dependencies)
+	bottle, ok := gettext.GetInstalledBottle("x86_64_linux")
+	assert.True(t, ok)
+	assert.Equal(t, "6e2a1a8c0ae03bd5ad7b0d3dc2c1bd8a8ea2d29a9b6c5ee7b1b970b7ba2ebb55", bottle.Sha256)
+
+	hello := formulae["hello"]
+	assert.Equal(t, map[string]string{"gettext": "0.22_1", "libunistring": "1.1"}, hello.InstalledDependencies)
+	assert.Equal(t, []string{"pkg-config"}, hello.BuildDependencies)
+	// The formula was built from source.
+	_, ok = hello.GetInstalledBottle("arm64_sonoma")
+	assert.False(t, ok)
+}
+
+func TestGetHomebrewFormulaName(t *testing.T) {
+	assert.Equal(t, "terraform", getHomebrewFormulaName("hashicorp/tap/terraform"))
+	assert.Equal(t, "gettext", getHomebrewFormulaName("gettext"))
+}
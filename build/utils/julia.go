@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	JuliaProjectFileName  = "Project.toml"
+	JuliaManifestFileName = "Manifest.toml"
+	// The alternative names of the project and manifest files, which take precedence if they exist.
+	juliaAltProjectFileName  = "JuliaProject.toml"
+	juliaAltManifestFileName = "JuliaManifest.toml"
+)
+
+// JuliaProject represents the fields of a Project.toml file, which are used by the build-info.
+type JuliaProject struct {
+	Name    string `toml:"name"`
+	Uuid    string `toml:"uuid"`
+	Version string `toml:"version"`
+	// The direct dependencies of the project, mapped from their names to their UUIDs.
+	Deps map[string]string `toml:"deps"`
+}
+
+// JuliaPackage represents a package listed in a Manifest.toml file.
+type JuliaPackage struct {
+	Name    string
+	Uuid    string
+	Version string
+	// The git tree hash of the package's source, as recorded in its registry.
+	GitTreeSha1 string
+	// The repository and revision of a package added by URL, or the path of a developed package.
+	RepoUrl string
+	RepoRev string
+	Path    string
+	// The UUIDs of the packages this package depends on.
+	Dependencies []string
+}
+
+func (jp *JuliaPackage) Id() string {
+	if jp.Version == "" {
+		return jp.Name
+	}
+	return jp.Name + ":" + jp.Version
+}
+
+// IsStdlib returns true if the package is a standard library, which is shipped with Julia, rather than downloaded.
+func (jp *JuliaPackage) IsStdlib() bool {
+	return jp.GitTreeSha1 == "" && jp.Path == "" && jp.RepoUrl == ""
+}
+
+type juliaManifestEntry struct {
+	Uuid        string `toml:"uuid"`
+	Version     string `toml:"version"`
+	GitTreeSha1 string `toml:"git-tree-sha1"`
+	RepoUrl     string `toml:"repo-url"`
+	RepoRev     string `toml:"repo-rev"`
+	Path        string `toml:"path"`
+	// The dependencies are listed by their names, or (if several dependencies have the same name) mapped from their names to their UUIDs.
+	Deps interface{} `toml:"deps"`
+}
+
+type juliaManifestFile struct {
+	ManifestFormat string                          `toml:"manifest_format"`
+	Deps           map[string][]juliaManifestEntry `toml:"deps"`
+}
+
+// GetJuliaProjectFilePath returns the path of the project file of the Julia project in the given directory.
+func GetJuliaProjectFilePath(srcPath string) (string, error) {
+	return getJuliaFilePath(srcPath, juliaAltProjectFileName, JuliaProjectFileName)
+}
+
+// GetJuliaManifestFilePath returns the path of the manifest file of the Julia project in the given directory.
+func GetJuliaManifestFilePath(srcPath string) (string, error) {
+	return getJuliaFilePath(srcPath, juliaAltManifestFileName, JuliaManifestFileName)
+}
+
+func getJuliaFilePath(srcPath, altFileName, fileName string) (string, error) {
+	altPath := filepath.Join(srcPath, altFileName)
+	exists, err := utils.IsFileExists(altPath, true)
+	if err != nil || exists {
+		return altPath, err
+	}
+	return filepath.Join(srcPath, fileName), nil
+}
+
+// ReadJuliaProject returns the project defined in the project file in the given directory.
+func ReadJuliaProject(srcPath string) (*JuliaProject, error) {
+	projectPath, err := GetJuliaProjectFilePath(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	project := &JuliaProject{}
+	if _, err = toml.DecodeFile(projectPath, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// ReadJuliaManifest returns the packages listed in the manifest file in the given directory, sorted by their names.
+// Both the format of Julia 1.7 and above (in which the packages are listed under 'deps'), and the format of older versions, are supported.
+func ReadJuliaManifest(srcPath string) ([]JuliaPackage, error) {
+	manifestPath, err := GetJuliaManifestFilePath(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	var manifestFile juliaManifestFile
+	if _, err = toml.DecodeFile(manifestPath, &manifestFile); err != nil {
+		return nil, err
+	}
+	entries := manifestFile.Deps
+	if manifestFile.ManifestFormat == "" {
+		// In the old format, the packages are the top-level arrays of tables.
+		entries = nil
+		if _, err = toml.DecodeFile(manifestPath, &entries); err != nil {
+			return nil, err
+		}
+	}
+
+	// The UUIDs of the packages, which have a unique name, for resolving the dependencies listed by their names.
+	uuidsByName := make(map[string]string)
+	for name, nameEntries := range entries {
+		if len(nameEntries) == 1 {
+			uuidsByName[name] = nameEntries[0].Uuid
+		}
+	}
+	var packages []JuliaPackage
+	for name, nameEntries := range entries {
+		for _, entry := range nameEntries {
+			pkg := JuliaPackage{
+				Name:        name,
+				Uuid:        entry.Uuid,
+				Version:     entry.Version,
+				GitTreeSha1: entry.GitTreeSha1,
+				RepoUrl:     entry.RepoUrl,
+				RepoRev:     entry.RepoRev,
+				Path:        entry.Path,
+			}
+			switch deps := entry.Deps.(type) {
+			case []interface{}:
+				for _, dep := range deps {
+					depName, _ := dep.(string)
+					uuid, ok := uuidsByName[depName]
+					if !ok {
+						return nil, fmt.Errorf("the dependency %s of the package %s isn't listed in %s", depName, name, manifestPath)
+					}
+					pkg.Dependencies = append(pkg.Dependencies, uuid)
+				}
+			case map[string]interface{}:
+				for _, uuid := range deps {
+					if uuid, ok := uuid.(string); ok {
+						pkg.Dependencies = append(pkg.Dependencies, uuid)
+					}
+				}
+			}
+			sort.Strings(pkg.Dependencies)
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name == packages[j].Name {
+			return packages[i].Uuid < packages[j].Uuid
+		}
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJuliaProject(t *testing.T) {
+	project, err := ReadJuliaProject(filepath.Join("..", "testdata", "julia", "project"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", project.Name)
+	assert.Equal(t, "0.1.0", project.Version)
+	assert.Equal(t, "4f5a9c3e-1b2d-4e6f-8a7b-9c0d1e2f3a4b", project.Uuid)
+	assert.Len(t, project.Deps, 3)
+}
+
+func TestReadJuliaManifest(t *testing.T) {
+	packages, err := ReadJuliaManifest(filepath.Join("..", "testdata", "julia", "project"))
+	require.NoError(t, err)
+	assert.Len(t, packages, 14)
+	packagesByName := make(map[string]JuliaPackage)
+	for _, pkg := range packages {
+		packagesByName[pkg.Name] = pkg
+	}
+	assert.Equal(t, JuliaPackage{
+		Name:         "JSON",
+		Uuid:         "682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+		Version:      "0.21.4",
+		GitTreeSha1:  "31e996f0a15c7b280ba9f76636b3ff9e2ae58c9a",
+		Dependencies: []string{"4ec0a83e-493e-50e2-b9ac-8f72acf5a8f5", "69de0a69-1ddd-5017-9359-2bf0b02dc9f0", "a63ad114-7e13-5084-954f-fe012c677804", "ade2ca70-3891-5945-98fb-dc099432e05a"},
+	}, packagesByName["JSON"])
+	// The dependencies of the package are mapped from their names to their UUIDs.
+	assert.Equal(t, []string{"682c06a0-de6a-54ab-a142-c8b1cf79cde6"}, packagesByName["Utils"].Dependencies)
+	dates, utils, sha, mmap := packagesByName["Dates"], packagesByName["Utils"], packagesByName["SHA"], packagesByName["Mmap"]
+	assert.True(t, dates.IsStdlib())
+	assert.False(t, utils.IsStdlib())
+	assert.Equal(t, "SHA:0.7.0", sha.Id())
+	assert.Equal(t, "Mmap", mmap.Id())
+}
+
+func TestReadJuliaManifestWithOldFormat(t *testing.T) {
+	packages, err := ReadJuliaManifest(filepath.Join("..", "testdata", "julia", "project-v1"))
+	require.NoError(t, err)
+	var ids []string
+	for _, pkg := range packages {
+		ids = append(ids, pkg.Id())
+	}
+	assert.Equal(t, []string{"Dates", "JSON:0.21.0", "Mmap", "Parsers:0.3.12", "Printf", "Test", "Unicode"}, ids)
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The names of the files, which hold the license text of a package (for example, 'LICENSE', 'LICENSE.md' or 'COPYING.txt').
+var licenseFileNameRegex = regexp.MustCompile(`(?i)^(licen[cs]e|copying)(-[a-z0-9]+)?(\.(txt|md|rst))?$`)
+
+var whitespacesRegex = regexp.MustCompile(`\s+`)
+
+// Phrases, which identify the text of a license, and its SPDX identifier. The first matching license is detected, so licenses, whose texts mention other licenses, come first.
+var licenseTextPhrases = []struct {
+	phrases []string
+	license string
+}{
+	{[]string{"gnu affero general public license", "version 3"}, "AGPL-3.0-only"},
+	{[]string{"gnu lesser general public license", "version 3"}, "LGPL-3.0-only"},
+	{[]string{"gnu lesser general public license", "version 2.1"}, "LGPL-2.1-only"},
+	{[]string{"gnu library general public license", "version 2"}, "LGPL-2.0-only"},
+	{[]string{"gnu general public license", "version 3"}, "GPL-3.0-only"},
+	{[]string{"gnu general public license", "version 2"}, "GPL-2.0-only"},
+	{[]string{"mozilla public license", "version 2.0"}, "MPL-2.0"},
+	{[]string{"apache license", "version 2.0"}, "Apache-2.0"},
+	{[]string{"eclipse public license - v 2.0"}, "EPL-2.0"},
+	{[]string{"eclipse public license - v 1.0"}, "EPL-1.0"},
+	{[]string{"boost software license - version 1.0"}, "BSL-1.0"},
+	{[]string{"this is free and unencumbered software released into the public domain"}, "Unlicense"},
+	{[]string{"permission to use, copy, modify, and/or distribute this software for any purpose with or without fee is hereby granted"}, "ISC"},
+	{[]string{"permission is hereby granted, free of charge"}, "MIT"},
+	{[]string{"redistribution and use in source and binary forms", "neither the name"}, "BSD-3-Clause"},
+	{[]string{"redistribution and use in source and binary forms", "names of its contributors"}, "BSD-3-Clause"},
+	{[]string{"redistribution and use in source and binary forms"}, "BSD-2-Clause"},
+}
+
+// IsLicenseFileName returns true if the file, by its name, holds the license text of a package.
+func IsLicenseFileName(fileName string) bool {
+	return licenseFileNameRegex.MatchString(fileName)
+}
+
+// DetectLicenseFromText returns the SPDX identifier of the license, whose text is given, or an empty string if it isn't recognized.
+// The license is detected by phrases, which its text commonly includes, so modified licenses may be detected as their originals.
+func DetectLicenseFromText(text string) string {
+	text = whitespacesRegex.ReplaceAllString(strings.ToLower(text), " ")
+	for _, licenseText := range licenseTextPhrases {
+		matches := true
+		for _, phrase := range licenseText.phrases {
+			if !strings.Contains(text, phrase) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return licenseText.license
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLicenseFileName(t *testing.T) {
+	for _, fileName := range []string{"LICENSE", "LICENSE.md", "license.txt", "LICENCE", "COPYING", "LICENSE-MIT", "COPYING.rst"} {
+		assert.True(t, IsLicenseFileName(fileName), fileName)
+	}
+	for _, fileName := range []string{"README.md", "license.go", "LICENSES", "docs/LICENSE"} {
+		assert.False(t, IsLicenseFileName(fileName), fileName)
+	}
+}
+
+func TestDetectLicenseFromText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"mit", "MIT License\n\nCopyright (c) 2020\n\nPermission is hereby granted, free of charge, to any person obtaining a copy", "MIT"},
+		{"apache", "                                 Apache License\n                           Version 2.0, January 2004", "Apache-2.0"},
+		{"bsd-3", "Redistribution and use in source and binary forms, with or without\nmodification, are permitted. Neither the name of the copyright holder", "BSD-3-Clause"},
+		{"bsd-2", "Redistribution and use in source and binary forms, with or without modification, are permitted", "BSD-2-Clause"},
+		{"lgpl", "GNU LESSER GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007", "LGPL-3.0-only"},
+		{"gpl", "GNU GENERAL PUBLIC LICENSE\n Version 2, June 1991", "GPL-2.0-only"},
+		{"mpl", "Mozilla Public License Version 2.0\n==================================", "MPL-2.0"},
+		{"unknown", "All rights reserved.", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DetectLicenseFromText(test.text))
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	LuaRocksLockFileName = "luarocks.lock"
+	// The rocks tree, which 'luarocks init' creates in the project directory.
+	LuaRocksProjectTreeDir = "lua_modules"
+	rockspecFileSuffix     = ".rockspec"
+)
+
+// Rockspec holds the fields of a rockspec file, which are required for collecting build-info.
+type Rockspec struct {
+	Package string
+	Version string
+	// The URL of the source of the rock, and the md5 checksum of its archive (if listed in the rockspec).
+	SourceUrl string
+	SourceMd5 string
+	// The tag (or branch) of the source of rocks, which are fetched from version control systems.
+	SourceTag string
+	// The names of the rocks this rock depends on.
+	Dependencies []string
+}
+
+func (rs *Rockspec) Id() string {
+	return rs.Package + ":" + rs.Version
+}
+
+// FindRockspec returns the path of the rockspec file in the given directory, or an empty string if it's not found.
+// If the directory contains several rockspec files (of several versions of the rock), the last one by name is returned.
+func FindRockspec(srcPath string) (string, error) {
+	rockspecPaths, err := filepath.Glob(filepath.Join(srcPath, "*"+rockspecFileSuffix))
+	if err != nil || len(rockspecPaths) == 0 {
+		return "", err
+	}
+	sort.Strings(rockspecPaths)
+	return rockspecPaths[len(rockspecPaths)-1], nil
+}
+
+// ReadRockspec parses the rockspec file in the given path.
+// Rockspec files are Lua scripts, so only the assignments of literal values (and concatenations of strings) are evaluated.
+func ReadRockspec(rockspecPath string) (*Rockspec, error) {
+	content, err := os.ReadFile(rockspecPath)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := parseLuaAssignments(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing %s: %s", rockspecPath, err.Error())
+	}
+	rockspec := &Rockspec{Package: getLuaString(variables["package"]), Version: getLuaString(variables["version"])}
+	if source, ok := variables["source"].(*luaTable); ok {
+		rockspec.SourceUrl = getLuaString(source.fields["url"])
+		rockspec.SourceMd5 = getLuaString(source.fields["md5"])
+		rockspec.SourceTag = getLuaString(source.fields["tag"])
+		if rockspec.SourceTag == "" {
+			rockspec.SourceTag = getLuaString(source.fields["branch"])
+		}
+	}
+	if dependencies, ok := variables["dependencies"].(*luaTable); ok {
+		for _, item := range dependencies.items {
+			// For example: 'lpeg >= 1.0' or 'luasocket'.
+			if fields := strings.Fields(getLuaString(item)); len(fields) > 0 {
+				rockspec.Dependencies = append(rockspec.Dependencies, strings.ToLower(fields[0]))
+			}
+		}
+	}
+	return rockspec, nil
+}
+
+// ReadLuaRocksLock returns the versions of the rocks pinned in the luarocks.lock file in the given directory, mapped by their names.
+// A nil map is returned if the project has no luarocks.lock file.
+func ReadLuaRocksLock(srcPath string) (map[string]string, error) {
+	lockPath := filepath.Join(srcPath, LuaRocksLockFileName)
+	exists, err := utils.IsFileExists(lockPath, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	// For example: 'return { dependencies = { lpeg = "1.1.0-1" } }'
+	variables, err := parseLuaAssignments(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing %s: %s", lockPath, err.Error())
+	}
+	versions := make(map[string]string)
+	if lock, ok := variables["return"].(*luaTable); ok {
+		if dependencies, ok := lock.fields["dependencies"].(*luaTable); ok {
+			for name, version := range dependencies.fields {
+				versions[name] = getLuaString(version)
+			}
+		}
+	}
+	return versions, nil
+}
+
+// ReadRocksTree returns the rocks installed in the given rocks tree, sorted by their names and versions.
+// The rockspec of each rock is located in the tree under a directory per Lua version, rock name and version,
+// for example: 'lib/luarocks/rocks-5.4/lpeg/1.1.0-1/lpeg-1.1.0-1.rockspec'.
+func ReadRocksTree(treePath string) ([]*Rockspec, error) {
+	rockspecPaths, err := filepath.Glob(filepath.Join(treePath, "lib", "luarocks", "rocks-*", "*", "*", "*"+rockspecFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(rockspecPaths)
+	var rocks []*Rockspec
+	for _, rockspecPath := range rockspecPaths {
+		rockspec, err := ReadRockspec(rockspecPath)
+		if err != nil {
+			return nil, err
+		}
+		// The directories of the rock are named after its name and version, which are more reliable than the (possibly computed) values in the rockspec.
+		versionDir := filepath.Dir(rockspecPath)
+		rockspec.Package, rockspec.Version = filepath.Base(filepath.Dir(versionDir)), filepath.Base(versionDir)
+		rocks = append(rocks, rockspec)
+	}
+	return rocks, nil
+}
+
+// luaTable holds the fields (key = value) and the positional items of a Lua table constructor.
+type luaTable struct {
+	fields map[string]interface{}
+	items  []interface{}
+}
+
+func getLuaString(value interface{}) string {
+	str, _ := value.(string)
+	return str
+}
+
+type luaToken struct {
+	// 's' for strings, 'n' for names, 'd' for numbers and 'p' for punctuation.
+	kind  byte
+	value string
+}
+
+type luaParser struct {
+	tokens    []luaToken
+	position  int
+	variables map[string]interface{}
+}
+
+// Parses the top-level assignments of a Lua script, such as a rockspec, and returns the assigned values mapped by the names of the variables.
+// The value of a 'return' statement is mapped by 'return'. Values, which can't be evaluated without running the script, are nil.
+func parseLuaAssignments(script string) (map[string]interface{}, error) {
+	tokens, err := tokenizeLua(script)
+	if err != nil {
+		return nil, err
+	}
+	parser := &luaParser{tokens: tokens, variables: make(map[string]interface{})}
+	for !parser.done() {
+		token := parser.next()
+		switch {
+		case token.kind == 'n' && token.value == "return":
+			parser.variables["return"] = parser.parseExpression()
+		case token.kind == 'n' && parser.peekIs('p', "="):
+			parser.next()
+			parser.variables[token.value] = parser.parseExpression()
+		}
+	}
+	return parser.variables, nil
+}
+
+func (lp *luaParser) done() bool {
+	return lp.position >= len(lp.tokens)
+}
+
+func (lp *luaParser) next() luaToken {
+	if lp.done() {
+		return luaToken{}
+	}
+	lp.position++
+	return lp.tokens[lp.position-1]
+}
+
+func (lp *luaParser) peekIs(kind byte, value string) bool {
+	return !lp.done() && lp.tokens[lp.position].kind == kind && lp.tokens[lp.position].value == value
+}
+
+func (lp *luaParser) peekAt(offset int) luaToken {
+	if lp.position+offset >= len(lp.tokens) {
+		return luaToken{}
+	}
+	return lp.tokens[lp.position+offset]
+}
+
+// Parses a value, which may be a concatenation of strings, for example: '"v" .. version'.
+func (lp *luaParser) parseExpression() interface{} {
+	value := lp.parsePrimary()
+	for lp.peekIs('p', "..") {
+		lp.next()
+		next := lp.parsePrimary()
+		left, leftOk := value.(string)
+		right, rightOk := next.(string)
+		if leftOk && rightOk {
+			value = left + right
+		} else {
+			value = nil
+		}
+	}
+	return value
+}
+
+func (lp *luaParser) parsePrimary() interface{} {
+	token := lp.next()
+	switch token.kind {
+	case 's', 'd':
+		return token.value
+	case 'n':
+		switch token.value {
+		case "true":
+			return true
+		case "false", "nil":
+			return nil
+		}
+		// A reference to a variable assigned earlier in the script.
+		return lp.variables[token.value]
+	case 'p':
+		if token.value == "{" {
+			return lp.parseTable()
+		}
+	}
+	return nil
+}
+
+// Parses a table constructor, after its opening brace.
+func (lp *luaParser) parseTable() *luaTable {
+	table := &luaTable{fields: make(map[string]interface{})}
+	for !lp.done() && !lp.peekIs('p', "}") {
+		switch {
+		case lp.peekIs('p', "["):
+			// For example: '["1.1.0-1"] = {...}'
+			lp.next()
+			key := lp.parseExpression()
+			if lp.peekIs('p', "]") {
+				lp.next()
+			}
+			if lp.peekIs('p', "=") {
+				lp.next()
+			}
+			table.fields[fmt.Sprint(key)] = lp.parseExpression()
+		case lp.peekAt(0).kind == 'n' && lp.peekAt(1) == luaToken{kind: 'p', value: "="}:
+			key := lp.next().value
+			lp.next()
+			table.fields[key] = lp.parseExpression()
+		default:
+			table.items = append(table.items, lp.parseExpression())
+		}
+		if lp.peekIs('p', ",") || lp.peekIs('p', ";") {
+			lp.next()
+		} else if !lp.peekIs('p', "}") {
+			// Skip the rest of an expression, which can't be evaluated, such as a function call.
+			lp.next()
+		}
+	}
+	lp.next()
+	return table
+}
+
+func tokenizeLua(script string) ([]luaToken, error) {
+	var tokens []luaToken
+	for i := 0; i < len(script); {
+		c := script[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(script[i:], "--"):
+			// A long comment (for example: '--[[ ... ]]') or a comment until the end of the line.
+			if _, end, ok := readLuaLongBracket(script, i+2); ok {
+				i = end
+			} else if newLine := strings.IndexByte(script[i:], '\n'); newLine >= 0 {
+				i += newLine + 1
+			} else {
+				i = len(script)
+			}
+		case c == '"' || c == '\'':
+			value, end, err := readLuaQuotedString(script, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, luaToken{kind: 's', value: value})
+			i = end
+		case c == '[' && i+1 < len(script) && (script[i+1] == '[' || script[i+1] == '='):
+			value, end, ok := readLuaLongBracket(script, i)
+			if !ok {
+				return nil, errors.New("unfinished long string")
+			}
+			tokens = append(tokens, luaToken{kind: 's', value: value})
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(script) && (script[i] == '_' || unicode.IsLetter(rune(script[i])) || unicode.IsDigit(rune(script[i]))) {
+				i++
+			}
+			tokens = append(tokens, luaToken{kind: 'n', value: script[start:i]})
+		case unicode.IsDigit(rune(c)):
+			start := i
+			for i < len(script) && (unicode.IsDigit(rune(script[i])) || unicode.IsLetter(rune(script[i])) || script[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, luaToken{kind: 'd', value: script[start:i]})
+		case strings.HasPrefix(script[i:], ".."):
+			tokens = append(tokens, luaToken{kind: 'p', value: ".."})
+			i += 2
+		case strings.HasPrefix(script[i:], "=="), strings.HasPrefix(script[i:], "~="), strings.HasPrefix(script[i:], "<="), strings.HasPrefix(script[i:], ">="):
+			tokens = append(tokens, luaToken{kind: 'p', value: script[i : i+2]})
+			i += 2
+		default:
+			tokens = append(tokens, luaToken{kind: 'p', value: string(c)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// Reads a quoted string, starting at the given index, and returns its value and the index after its closing quote.
+func readLuaQuotedString(script string, start int) (string, int, error) {
+	quote := script[start]
+	var value strings.Builder
+	for i := start + 1; i < len(script); i++ {
+		switch script[i] {
+		case quote:
+			return value.String(), i + 1, nil
+		case '\n':
+			return "", 0, errors.New("unfinished string")
+		case '\\':
+			i++
+			if i >= len(script) {
+				return "", 0, errors.New("unfinished string")
+			}
+			switch script[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			default:
+				value.WriteByte(script[i])
+			}
+		default:
+			value.WriteByte(script[i])
+		}
+	}
+	return "", 0, errors.New("unfinished string")
+}
+
+// Reads a long bracket (for example: '[[...]]' or '[==[...]==]'), starting at the given index, and returns its content and the index after it.
+func readLuaLongBracket(script string, start int) (string, int, bool) {
+	if start >= len(script) || script[start] != '[' {
+		return "", 0, false
+	}
+	level := 0
+	for start+1+level < len(script) && script[start+1+level] == '=' {
+		level++
+	}
+	contentStart := start + 2 + level
+	if contentStart > len(script) || script[contentStart-1] != '[' {
+		return "", 0, false
+	}
+	closing := "]" + strings.Repeat("=", level) + "]"
+	end := strings.Index(script[contentStart:], closing)
+	if end < 0 {
+		return "", 0, false
+	}
+	// A new line directly after the opening bracket isn't part of the string.
+	content := strings.TrimPrefix(script[contentStart:contentStart+end], "\n")
+	return content, contentStart + end + len(closing), true
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRockspec(t *testing.T) {
+	rockspecPath, err := FindRockspec(filepath.Join("..", "testdata", "luarocks", "project"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello-1.0-1.rockspec", filepath.Base(rockspecPath))
+	rockspec, err := ReadRockspec(rockspecPath)
+	require.NoError(t, err)
+	assert.Equal(t, &Rockspec{
+		Package:      "hello",
+		Version:      "1.0-1",
+		SourceUrl:    "git+https://github.com/example/hello.git",
+		SourceTag:    "v1.0-1",
+		Dependencies: []string{"lua", "luafilesystem", "penlight"},
+	}, rockspec)
+}
+
+func TestReadRocksTree(t *testing.T) {
+	rocks, err := ReadRocksTree(filepath.Join("..", "testdata", "luarocks", "project", LuaRocksProjectTreeDir))
+	require.NoError(t, err)
+	require.Len(t, rocks, 3)
+	// The names of the rocks are taken from their directories in the tree, which are lowercase.
+	assert.Equal(t, "luafilesystem:1.8.0-1", rocks[1].Id())
+	assert.Equal(t, &Rockspec{
+		Package:      "penlight",
+		Version:      "1.13.1-1",
+		SourceUrl:    "https://github.com/lunarmodules/penlight/archive/1.13.1.tar.gz",
+		SourceMd5:    "a0c5d6b1a2e4c3f8b7d9e0f1a2b3c4d5",
+		Dependencies: []string{"lua", "luafilesystem"},
+	}, rocks[2])
+}
+
+func TestReadLuaRocksLock(t *testing.T) {
+	versions, err := ReadLuaRocksLock(filepath.Join("..", "testdata", "luarocks", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ansicolors": "1.0.2-3", "lua": "5.4-1", "luafilesystem": "1.8.0-1", "penlight": "1.13.1-1"}, versions)
+
+	// A project without a luarocks.lock file.
+	versions, err = ReadLuaRocksLock(filepath.Join("..", "testdata", "luarocks"))
+	assert.NoError(t, err)
+	assert.Nil(t, versions)
+}
+
+func TestParseLuaAssignments(t *testing.T) {
+	variables, err := parseLuaAssignments(`
+-- A comment with a "quote".
+local name = 'say'
+version = "2.0" .. '-1'
+description = { summary = [==[Says ]] things]==], ["homepage"] = "https://example.com"; "item\"1" }
+flag = true
+unknown = os.getenv("HOME")
+`)
+	require.NoError(t, err)
+	assert.Equal(t, "say", variables["name"])
+	assert.Equal(t, "2.0-1", variables["version"])
+	description := variables["description"].(*luaTable)
+	assert.Equal(t, "Says ]] things", description.fields["summary"])
+	assert.Equal(t, "https://example.com", description.fields["homepage"])
+	assert.Equal(t, []interface{}{"item\"1"}, description.items)
+	assert.Equal(t, true, variables["flag"])
+	assert.Nil(t, variables["unknown"])
+
+	_, err = parseLuaAssignments(`package = "unfinished`)
+	assert.Error(t, err)
+}
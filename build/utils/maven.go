@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// The maximal number of parent POMs, which are read when looking for the licenses of an artifact.
+const maxMavenParentPoms = 10
+
+// GetMavenLocalRepositoryPath returns the path of the default local Maven repository (~/.m2/repository), to which Maven and the tools that resolve Maven artifacts download the artifacts.
+func GetMavenLocalRepositoryPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".m2", "repository"), nil
+}
+
+// FindMavenLocalRepositoryJar returns the path of the jar of an artifact in the given local Maven repository, or an empty string if it's not found.
+func FindMavenLocalRepositoryJar(repositoryPath, groupId, artifactId, version string) (string, error) {
+	groupPath := filepath.Join(strings.Split(groupId, ".")...)
+	jarPath := filepath.Join(repositoryPath, groupPath, artifactId, version, artifactId+"-"+version+".jar")
+	exists, err := utils.IsFileExists(jarPath, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	return jarPath, nil
+}
+
+type mavenPom struct {
+	Parent *struct {
+		GroupId    string `xml:"groupId"`
+		ArtifactId string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+	Licenses []struct {
+		Name string `xml:"name"`
+	} `xml:"licenses>license"`
+}
+
+// GetMavenLocalRepositoryLicenses returns the licenses of an artifact, normalized into SPDX license expressions, from its POM in the given local Maven repository.
+// If the POM declares no licenses, they're inherited from its parent POMs. Returns nil if the POM isn't found.
+func GetMavenLocalRepositoryLicenses(repositoryPath, groupId, artifactId, version string) ([]string, error) {
+	for i := 0; i < maxMavenParentPoms; i++ {
+		groupPath := filepath.Join(strings.Split(groupId, ".")...)
+		content, err := os.ReadFile(filepath.Join(repositoryPath, groupPath, artifactId, version, artifactId+"-"+version+".pom"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		var pom mavenPom
+		if err = xml.Unmarshal(content, &pom); err != nil {
+			return nil, err
+		}
+		if len(pom.Licenses) > 0 {
+			var licenses []string
+			for _, license := range pom.Licenses {
+				// The name of a license isn't an expression, but it often includes commas (for example, 'The Apache Software License, Version 2.0').
+				licenses = append(licenses, strings.ReplaceAll(license.Name, ",", ""))
+			}
+			return entities.NormalizeSpdxLicenses(licenses), nil
+		}
+		if pom.Parent == nil {
+			return nil, nil
+		}
+		groupId, artifactId, version = pom.Parent.GroupId, pom.Parent.ArtifactId, pom.Parent.Version
+	}
+	return nil, nil
+}
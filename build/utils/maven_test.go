@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMavenLocalRepositoryLicenses(t *testing.T) {
+	repositoryPath := filepath.Join("..", "testdata", "maven", "licenses")
+	tests := []struct {
+		artifactId string
+		version    string
+		expected   []string
+	}{
+		// The licenses are inherited from the parent POM.
+		{"lib", "1.0", []string{"Apache-2.0"}},
+		{"dual", "1.0", []string{"EPL-1.0", "LGPL-2.0-or-later"}},
+		{"missing", "1.0", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.artifactId, func(t *testing.T) {
+			licenses, err := GetMavenLocalRepositoryLicenses(repositoryPath, "org.example", test.artifactId, test.version)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, licenses)
+		})
+	}
+}
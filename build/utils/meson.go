@@ -0,0 +1,173 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	MesonBuildFileName    = "meson.build"
+	MesonSubprojectsDir   = "subprojects"
+	MesonWrapFileSuffix   = ".wrap"
+	mesonWrapRedirectType = "redirect"
+)
+
+var (
+	// For example: "project('hello', 'c', version : '1.0.0')"
+	mesonProjectRegExp = regexp.MustCompile(`(?s)\bproject\s*\(\s*'([^']+)'(.*?)\)`)
+	mesonVersionRegExp = regexp.MustCompile(`\bversion\s*:\s*'([^']+)'`)
+)
+
+// MesonWrap represents a subproject, which Meson downloads according to its wrap file in the subprojects directory.
+type MesonWrap struct {
+	// The name of the wrap file, without its suffix.
+	Name string
+	// The type of the wrap, for example: 'file' or 'git'.
+	Type string
+	// The name of the directory in the subprojects directory, to which the subproject is extracted or cloned.
+	Directory string
+	// The URL of the source archive (in wraps of type 'file') or of the repository (in wraps of other types).
+	Url string
+	// The file name and the sha256 checksum of the source archive.
+	SourceFileName string
+	SourceHash     string
+	// The revision of the repository, for example: a branch, a tag or a commit.
+	Revision string
+	// The URL and the sha256 checksum of the archive, which is extracted over the source of the subproject.
+	PatchUrl  string
+	PatchHash string
+	// The version of the wrap in the Meson WrapDB, for example: '1.3-4'.
+	WrapDbVersion string
+}
+
+// Version returns the version of the subproject: its version in the Meson WrapDB, its revision, or the version suffix of its directory (for example: '1.3' for 'zlib-1.3').
+// An empty string is returned if none of them is known.
+func (mw *MesonWrap) Version() string {
+	if mw.WrapDbVersion != "" {
+		return mw.WrapDbVersion
+	}
+	if mw.Revision != "" {
+		return mw.Revision
+	}
+	if version := strings.TrimPrefix(mw.Directory, mw.Name+"-"); version != mw.Directory {
+		return version
+	}
+	return ""
+}
+
+func (mw *MesonWrap) Id() string {
+	if version := mw.Version(); version != "" {
+		return mw.Name + ":" + version
+	}
+	return mw.Name
+}
+
+// GetMesonProjectNameAndVersion returns the name and the version of the project defined in the meson.build file in the given directory.
+// Empty strings are returned if the file doesn't call 'project'.
+func GetMesonProjectNameAndVersion(srcPath string) (name, version string, err error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, MesonBuildFileName))
+	if err != nil {
+		return
+	}
+	match := mesonProjectRegExp.FindSubmatch(content)
+	if match == nil {
+		return
+	}
+	name = string(match[1])
+	if versionMatch := mesonVersionRegExp.FindSubmatch(match[2]); versionMatch != nil {
+		version = string(versionMatch[1])
+	}
+	return
+}
+
+// ReadMesonWraps returns the subprojects described by the wrap files in the subprojects directory of the project in the given directory, sorted by their names.
+// Wraps of type 'redirect' (which Meson creates for the subprojects of subprojects) are replaced by the wraps they redirect to, if these exist.
+func ReadMesonWraps(srcPath string) ([]MesonWrap, error) {
+	subprojectsPath := filepath.Join(srcPath, MesonSubprojectsDir)
+	wrapFiles, err := filepath.Glob(filepath.Join(subprojectsPath, "*"+MesonWrapFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	var wraps []MesonWrap
+	for _, wrapFile := range wrapFiles {
+		name := strings.TrimSuffix(filepath.Base(wrapFile), MesonWrapFileSuffix)
+		wrapType, fields, err := readMesonWrapFile(wrapFile)
+		if err != nil {
+			return nil, err
+		}
+		if wrapType == mesonWrapRedirectType {
+			// The path of the redirected wrap file is relative to the subprojects directory.
+			if wrapType, fields, err = readMesonWrapFile(filepath.Join(subprojectsPath, filepath.FromSlash(fields["filename"]))); err != nil {
+				return nil, fmt.Errorf("failed reading the wrap file, which %s redirects to: %s", wrapFile, err.Error())
+			}
+		}
+		wrap := MesonWrap{
+			Name:           name,
+			Type:           wrapType,
+			Directory:      fields["directory"],
+			Url:            fields["url"],
+			SourceFileName: fields["source_filename"],
+			SourceHash:     strings.ToLower(fields["source_hash"]),
+			Revision:       fields["revision"],
+			PatchUrl:       fields["patch_url"],
+			PatchHash:      strings.ToLower(fields["patch_hash"]),
+			WrapDbVersion:  fields["wrapdb_version"],
+		}
+		if wrap.Url == "" {
+			wrap.Url = fields["source_url"]
+		}
+		// Meson extracts or clones the subproject to a directory named after the wrap, unless the wrap sets another directory.
+		if wrap.Directory == "" {
+			wrap.Directory = name
+		}
+		wraps = append(wraps, wrap)
+	}
+	sort.Slice(wraps, func(i, j int) bool {
+		return wraps[i].Name < wraps[j].Name
+	})
+	return wraps, nil
+}
+
+// Reads a wrap file, which is an INI file with a section named after the type of the wrap (for example: '[wrap-file]'), followed by other sections, such as '[provide]'.
+// Returns the type of the wrap, and the fields in its section.
+func readMesonWrapFile(wrapPath string) (wrapType string, fields map[string]string, err error) {
+	content, err := os.ReadFile(wrapPath)
+	if err != nil {
+		return
+	}
+	fields = make(map[string]string)
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if wrapType == "" && strings.HasPrefix(section, "wrap-") {
+				wrapType = strings.TrimPrefix(section, "wrap-")
+			}
+			continue
+		}
+		if section != "wrap-"+wrapType {
+			continue
+		}
+		if key, value, found := strings.Cut(line, "="); found {
+			fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	if wrapType == "" {
+		err = fmt.Errorf("the wrap file %s has no wrap section", wrapPath)
+	}
+	return
+}
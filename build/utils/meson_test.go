@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadMesonWraps(t *testing.T) {
+	wraps, err := ReadMesonWraps(filepath.Join("..", "testdata", "meson", "project"))
+	require.NoError(t, err)
+	require.Len(t, wraps, 5)
+	assert.Equal(t, "fmt", wraps[0].Name)
+	assert.Equal(t, "10.1.1", wraps[0].Version())
+	// The wrap file redirects to the wrap file of a subproject.
+	assert.Equal(t, "gtest", wraps[1].Name)
+	assert.Equal(t, "googletest-1.14.0", wraps[1].Directory)
+	// The directory defaults to the name of the wrap.
+	assert.Equal(t, MesonWrap{
+		Name:      "libfoo",
+		Type:      "git",
+		Directory: "libfoo",
+		Url:       "https://github.com/example/libfoo.git",
+		Revision:  "v2.0.1",
+	}, wraps[3])
+	assert.Equal(t, "zlib", wraps[4].Name)
+	assert.Equal(t, "zlib-1.3.tar.xz", wraps[4].SourceFileName)
+	assert.Equal(t, "1.3-4", wraps[4].WrapDbVersion)
+}
+
+func TestGetMesonProjectNameAndVersion(t *testing.T) {
+	name, version, err := GetMesonProjectNameAndVersion(filepath.Join("..", "testdata", "meson", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", name)
+	assert.Equal(t, "1.2.0", version)
+}
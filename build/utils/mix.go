@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	MixExsFileName  = "mix.exs"
+	MixLockFileName = "mix.lock"
+
+	// The sources of packages in the mix.lock file.
+	MixHexSource = "hex"
+	MixGitSource = "git"
+
+	hexDefaultRepo = "hexpm"
+)
+
+var (
+	mixAppRegExp     = regexp.MustCompile(`\bapp:\s*:(\w+)`)
+	mixVersionRegExp = regexp.MustCompile(`\bversion:\s*"([^"]+)"`)
+	// The list returned by the deps function, for example: 'defp deps do [...] end'.
+	mixDepsFunctionRegExp = regexp.MustCompile(`(?s)defp?\s+deps\s*(?:\(\s*\))?\s*do\s*\[(.*?)\]\s*end`)
+	// A dependency tuple in the deps list, for example: '{:credo, "~> 1.7", only: [:dev, :test], runtime: false}'.
+	mixDepTupleRegExp = regexp.MustCompile(`\{\s*:(\w+)\s*,([^{}]*)\}`)
+	mixOnlyRegExp     = regexp.MustCompile(`\bonly:\s*(\[[^\]]*\]|:\w+)`)
+	mixAtomRegExp     = regexp.MustCompile(`:(\w+)`)
+	// For example: '"jason": {:hex, :jason, "1.4.1", "<inner checksum>", [:mix], [<dependencies>], "hexpm", "<outer checksum>"},'
+	// The repository and the outer checksum are missing in lock files created by older Hex versions.
+	mixLockHexEntryRegExp = regexp.MustCompile(`^\s*"([^"]+)":\s*\{:hex,\s*:"?([\w.\-]+)"?,\s*"([^"]+)",\s*"([0-9a-fA-F]*)",\s*\[[^\]]*\],\s*\[(.*)\](?:,\s*"([^"]+)",\s*"([0-9a-fA-F]*)")?\},?\s*$`)
+	// For example: '"plug": {:git, "https://github.com/elixir-plug/plug.git", "<commit>", [branch: "main"]},'
+	mixLockGitEntryRegExp = regexp.MustCompile(`^\s*"([^"]+)":\s*\{:git,\s*"([^"]+)",\s*"([0-9a-fA-F]+)"`)
+	// The name of a dependency in the dependencies list of a Hex package, for example: '{:decimal, "~> 1.0", [hex: :decimal, repo: "hexpm", optional: true]}'.
+	mixLockDependencyRegExp = regexp.MustCompile(`\{:(\w+),`)
+)
+
+// MixProject holds the details of a Mix project, as defined in its mix.exs file.
+type MixProject struct {
+	App          string
+	Version      string
+	Dependencies []MixRequirement
+}
+
+// MixRequirement represents a dependency listed in the deps of a mix.exs file.
+type MixRequirement struct {
+	Name string
+	// The environments the dependency is used in (for example: 'dev' and 'test'), or empty if it's used in all of them.
+	Only []string
+}
+
+// MixPackage represents a package listed in a mix.lock file.
+type MixPackage struct {
+	// The name of the application, which is the key of the package in the lock file.
+	Name   string
+	Source string
+	// The name of the package in the Hex repository. Usually identical to the name of the application.
+	HexName       string
+	Version       string
+	Repo          string
+	InnerChecksum string
+	// The sha256 checksum of the package tarball.
+	OuterChecksum string
+	GitUrl        string
+	GitCommit     string
+	// The names of the applications the package depends on.
+	Dependencies []string
+}
+
+// Id returns the ID of the package. The commit is used as the version of git packages.
+func (mp *MixPackage) Id() string {
+	if mp.Source == MixGitSource {
+		return mp.Name + ":" + mp.GitCommit
+	}
+	return mp.Name + ":" + mp.Version
+}
+
+// ReadMixProject reads the app name, version and dependencies from the mix.exs file in the given directory.
+func ReadMixProject(srcPath string) (*MixProject, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, MixExsFileName))
+	if err != nil {
+		return nil, err
+	}
+	project := new(MixProject)
+	if match := mixAppRegExp.FindSubmatch(content); match != nil {
+		project.App = string(match[1])
+	}
+	if match := mixVersionRegExp.FindSubmatch(content); match != nil {
+		project.Version = string(match[1])
+	}
+	depsMatch := mixDepsFunctionRegExp.FindSubmatch(content)
+	if depsMatch == nil {
+		return project, nil
+	}
+	for _, tupleMatch := range mixDepTupleRegExp.FindAllSubmatch(depsMatch[1], -1) {
+		requirement := MixRequirement{Name: string(tupleMatch[1])}
+		if onlyMatch := mixOnlyRegExp.FindSubmatch(tupleMatch[2]); onlyMatch != nil {
+			for _, atomMatch := range mixAtomRegExp.FindAllSubmatch(onlyMatch[1], -1) {
+				requirement.Only = append(requirement.Only, string(atomMatch[1]))
+			}
+		}
+		project.Dependencies = append(project.Dependencies, requirement)
+	}
+	return project, nil
+}
+
+// ReadMixLock returns the packages listed in the mix.lock file in the given directory, sorted by their names.
+// Packages of other sources (such as path dependencies) aren't listed in the lock file.
+func ReadMixLock(srcPath string) ([]MixPackage, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, MixLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	var packages []MixPackage
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	// The entries of packages with many dependencies may exceed the default buffer size.
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := mixLockHexEntryRegExp.FindStringSubmatch(line); match != nil {
+			pkg := MixPackage{Name: match[1], Source: MixHexSource, HexName: match[2], Version: match[3], InnerChecksum: match[4], Repo: match[6], OuterChecksum: match[7]}
+			if pkg.Repo == "" {
+				pkg.Repo = hexDefaultRepo
+			}
+			for _, dependencyMatch := range mixLockDependencyRegExp.FindAllStringSubmatch(match[5], -1) {
+				pkg.Dependencies = append(pkg.Dependencies, dependencyMatch[1])
+			}
+			packages = append(packages, pkg)
+			continue
+		}
+		if match := mixLockGitEntryRegExp.FindStringSubmatch(line); match != nil {
+			packages = append(packages, MixPackage{Name: match[1], Source: MixGitSource, GitUrl: match[2], GitCommit: match[3]})
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, scanner.Err()
+}
+
+// GetHexPackagesCachePath returns the path of the directory, where Hex caches the tarballs of the downloaded packages.
+func GetHexPackagesCachePath() (string, error) {
+	hexHome := os.Getenv("HEX_HOME")
+	if hexHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		hexHome = filepath.Join(homeDir, ".hex")
+	}
+	return filepath.Join(hexHome, "packages"), nil
+}
+
+// GetHexTarballPath returns the path of the tarball of a Hex package in the cache.
+// The packages of organizations are cached under their repository, for example: 'hexpm/acme' for the 'hexpm:acme' repository.
+func GetHexTarballPath(cachePath string, pkg MixPackage) string {
+	repoDir := filepath.FromSlash(strings.ReplaceAll(pkg.Repo, ":", "/"))
+	return filepath.Join(cachePath, repoDir, pkg.HexName+"-"+pkg.Version+".tar")
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMixProject(t *testing.T) {
+	project, err := ReadMixProject(filepath.Join("..", "testdata", "mix", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", project.App)
+	assert.Equal(t, "0.1.0", project.Version)
+	assert.Equal(t, []MixRequirement{
+		{Name: "plug_cowboy"},
+		{Name: "jason"},
+		{Name: "my_lib"},
+		{Name: "credo", Only: []string{"dev", "test"}},
+	}, project.Dependencies)
+}
+
+func TestReadMixLock(t *testing.T) {
+	packages, err := ReadMixLock(filepath.Join("..", "testdata", "mix", "project"))
+	assert.NoError(t, err)
+	if !assert.Len(t, packages, 8) {
+		return
+	}
+	cowboy := packages[1]
+	assert.Equal(t, MixPackage{
+		Name:          "cowboy",
+		Source:        MixHexSource,
+		HexName:       "cowboy",
+		Version:       "2.10.0",
+		Repo:          "hexpm",
+		InnerChecksum: "ff9ffeff91dae4ae270dd975642997afe2a1179d94b1887863e43f681a203e26",
+		OuterChecksum: "3afdccb7183cc6f143cb14d3cf51fa00e53db9ec80cdcd525482f5e99bc41d6b",
+		Dependencies:  []string{"cowlib", "ranch"},
+	}, cowboy)
+	assert.Equal(t, filepath.Join("cache", "hexpm", "cowboy-2.10.0.tar"), GetHexTarballPath("cache", cowboy))
+
+	myLib := packages[5]
+	assert.Equal(t, MixGitSource, myLib.Source)
+	assert.Equal(t, "https://github.com/example/my_lib.git", myLib.GitUrl)
+	assert.Equal(t, "my_lib:8a7b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b", myLib.Id())
+
+	// Lock entries created by older Hex versions have no repository and outer checksum.
+	ranch := packages[7]
+	assert.Equal(t, "ranch:1.8.0", ranch.Id())
+	assert.Equal(t, "hexpm", ranch.Repo)
+	assert.Empty(t, ranch.OuterChecksum)
+}
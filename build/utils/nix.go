@@ -0,0 +1,270 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	NixFlakeFileName     = "flake.nix"
+	NixFlakeLockFileName = "flake.lock"
+	// The alphabet of the base-32 encoding used by Nix for hashes.
+	nix32Alphabet = "0123456789abcdfghijklmnpqrsvwxyz"
+)
+
+var (
+	// The suffix added to the keys of flake.lock nodes, when several inputs have the same name, for example: 'nixpkgs_2'.
+	flakeNodeKeySuffixRegExp = regexp.MustCompile(`_\d+$`)
+	// A store path name is split to a name and a version in the first dash, which is followed by a digit (like builtins.parseDrvName).
+	nixStorePathVersionRegExp = regexp.MustCompile(`-\d`)
+)
+
+// FlakeInput represents a locked input of a flake, as listed in a flake.lock file.
+type FlakeInput struct {
+	// The key of the input's node in the flake.lock file.
+	Key     string
+	Type    string
+	Rev     string
+	NarHash string
+	// The locked flake reference of the input, for example: 'github:NixOS/nixpkgs/<rev>'.
+	Url string
+	// The keys of the nodes of the inputs of this input.
+	Inputs []string
+}
+
+func (fi *FlakeInput) Id() string {
+	name := flakeNodeKeySuffixRegExp.ReplaceAllString(fi.Key, "")
+	if fi.Rev == "" {
+		return name
+	}
+	return name + ":" + fi.Rev
+}
+
+// FlakeLock represents the inputs listed in a flake.lock file.
+type FlakeLock struct {
+	// The keys of the nodes of the inputs of the root flake.
+	RootInputs []string
+	// The inputs, mapped by the keys of their nodes.
+	Inputs map[string]*FlakeInput
+}
+
+type flakeLockFile struct {
+	Nodes   map[string]flakeLockNode `json:"nodes"`
+	Root    string                   `json:"root"`
+	Version int                      `json:"version"`
+}
+
+type flakeLockNode struct {
+	// Each input is mapped to the key of its node, or (if it follows another input) to a path of input names, starting at the root flake.
+	Inputs map[string]json.RawMessage `json:"inputs,omitempty"`
+	Locked map[string]interface{}     `json:"locked,omitempty"`
+}
+
+// NixStorePath represents a path in the closure of a derivation, as returned by 'nix path-info --json'.
+type NixStorePath struct {
+	Path       string   `json:"path"`
+	NarHash    string   `json:"narHash"`
+	References []string `json:"references"`
+}
+
+// Id returns the ID of the store path, which is built from its name and version. For example: '/nix/store/<hash>-hello-2.12.1' -> 'hello:2.12.1'.
+func (nsp *NixStorePath) Id() string {
+	name := path.Base(nsp.Path)
+	// Remove the hash of the store path.
+	if _, nameWithoutHash, found := strings.Cut(name, "-"); found {
+		name = nameWithoutHash
+	}
+	if location := nixStorePathVersionRegExp.FindStringIndex(name); location != nil {
+		return name[:location[0]] + ":" + name[location[0]+1:]
+	}
+	return name
+}
+
+// ReadFlakeLock returns the inputs listed in the flake.lock file in the given directory.
+func ReadFlakeLock(srcPath string) (*FlakeLock, error) {
+	var lockFile flakeLockFile
+	if err := utils.Unmarshal(filepath.Join(srcPath, NixFlakeLockFileName), &lockFile); err != nil {
+		return nil, err
+	}
+	if lockFile.Root == "" {
+		lockFile.Root = "root"
+	}
+	lock := &FlakeLock{Inputs: make(map[string]*FlakeInput)}
+	for key, node := range lockFile.Nodes {
+		inputs, err := lockFile.resolveInputs(node)
+		if err != nil {
+			return nil, err
+		}
+		if key == lockFile.Root {
+			lock.RootInputs = inputs
+			continue
+		}
+		input := &FlakeInput{Key: key, Inputs: inputs}
+		input.Type, _ = node.Locked["type"].(string)
+		input.Rev, _ = node.Locked["rev"].(string)
+		input.NarHash, _ = node.Locked["narHash"].(string)
+		input.Url = getFlakeLockedUrl(node.Locked)
+		lock.Inputs[key] = input
+	}
+	return lock, nil
+}
+
+// Returns the sorted keys of the nodes of the given node's inputs.
+func (flf *flakeLockFile) resolveInputs(node flakeLockNode) ([]string, error) {
+	var inputs []string
+	for name, value := range node.Inputs {
+		key, err := flf.resolveInput(value, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed resolving the input '%s' in the %s file: %s", name, NixFlakeLockFileName, err.Error())
+		}
+		if key != "" {
+			inputs = append(inputs, key)
+		}
+	}
+	sort.Strings(inputs)
+	return inputs, nil
+}
+
+func (flf *flakeLockFile) resolveInput(value json.RawMessage, depth int) (string, error) {
+	if depth > len(flf.Nodes) {
+		return "", errors.New("the inputs follow each other in a loop")
+	}
+	var key string
+	if err := json.Unmarshal(value, &key); err == nil {
+		return key, nil
+	}
+	var followsPath []string
+	if err := json.Unmarshal(value, &followsPath); err != nil {
+		return "", err
+	}
+	// An input which follows another input, by its path from the root flake. An empty path means that the input follows the root flake itself.
+	key = flf.Root
+	for _, name := range followsPath {
+		inputValue, ok := flf.Nodes[key].Inputs[name]
+		if !ok {
+			return "", fmt.Errorf("the input '%s' doesn't exist", strings.Join(followsPath, "/"))
+		}
+		var err error
+		if key, err = flf.resolveInput(inputValue, depth+1); err != nil {
+			return "", err
+		}
+	}
+	if key == flf.Root {
+		return "", nil
+	}
+	return key, nil
+}
+
+// Returns the locked flake reference of an input.
+func getFlakeLockedUrl(locked map[string]interface{}) string {
+	get := func(attribute string) string {
+		value, _ := locked[attribute].(string)
+		return value
+	}
+	switch inputType := get("type"); inputType {
+	case "github", "gitlab", "sourcehut":
+		return fmt.Sprintf("%s:%s/%s/%s", inputType, get("owner"), get("repo"), get("rev"))
+	case "git", "mercurial":
+		scheme := "git+"
+		if inputType == "mercurial" {
+			scheme = "hg+"
+		}
+		return scheme + get("url") + "?rev=" + get("rev")
+	case "tarball", "file":
+		return get("url")
+	case "path":
+		return "path:" + get("path")
+	case "indirect":
+		return "flake:" + get("id")
+	default:
+		return get("url")
+	}
+}
+
+// RunNixPathInfo returns the store paths in the closure of the given installables, using 'nix path-info --recursive --json'.
+func RunNixPathInfo(srcPath string, installables []string) ([]NixStorePath, error) {
+	args := append([]string{"--recursive", "--json", "--extra-experimental-features", "nix-command flakes"}, installables...)
+	command := utils.NewCommand("nix", "path-info", args)
+	command.Dir = srcPath
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed running 'nix path-info': %s", err.Error())
+	}
+	return ParseNixPathInfo(output)
+}
+
+// ParseNixPathInfo parses the output of 'nix path-info --json', which is a list of store paths (up to Nix 2.18), or an object, in which the store paths are the keys.
+func ParseNixPathInfo(output []byte) ([]NixStorePath, error) {
+	var storePaths []NixStorePath
+	if err := json.Unmarshal(output, &storePaths); err == nil {
+		return storePaths, nil
+	}
+	var storePathsMap map[string]NixStorePath
+	if err := json.Unmarshal(output, &storePathsMap); err != nil {
+		return nil, err
+	}
+	for storePath, info := range storePathsMap {
+		info.Path = storePath
+		storePaths = append(storePaths, info)
+	}
+	sort.Slice(storePaths, func(i, j int) bool {
+		return storePaths[i].Path < storePaths[j].Path
+	})
+	return storePaths, nil
+}
+
+// NarHashToSha256 converts a NAR hash to a hex-encoded sha256 checksum.
+// The hash can be written in the SRI format ('sha256-<base64>') or in the Nix format ('sha256:<base32 or hex>').
+func NarHashToSha256(narHash string) (string, error) {
+	if strings.HasPrefix(narHash, "sha256-") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(narHash, "sha256-"))
+		if err != nil {
+			return "", fmt.Errorf("failed decoding the NAR hash '%s': %s", narHash, err.Error())
+		}
+		return hex.EncodeToString(decoded), nil
+	}
+	if !strings.HasPrefix(narHash, "sha256:") {
+		return "", fmt.Errorf("the NAR hash '%s' has an unsupported format", narHash)
+	}
+	digest := strings.TrimPrefix(narHash, "sha256:")
+	if len(digest) == hex.EncodedLen(32) {
+		return digest, nil
+	}
+	decoded, err := decodeNix32(digest)
+	if err != nil {
+		return "", fmt.Errorf("failed decoding the NAR hash '%s': %s", narHash, err.Error())
+	}
+	return hex.EncodeToString(decoded), nil
+}
+
+// Decodes a string encoded in the base-32 encoding of Nix, in which the bytes are written from the last character to the first one.
+func decodeNix32(encoded string) ([]byte, error) {
+	decoded := make([]byte, len(encoded)*5/8)
+	for n := 0; n < len(encoded); n++ {
+		digit := strings.IndexByte(nix32Alphabet, encoded[len(encoded)-n-1])
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid character '%c'", encoded[len(encoded)-n-1])
+		}
+		b := n * 5
+		i, j := b/8, b%8
+		if i < len(decoded) {
+			decoded[i] |= byte(digit << j)
+		}
+		if carry := digit >> (8 - j); i+1 < len(decoded) {
+			decoded[i+1] |= byte(carry)
+		} else if carry != 0 {
+			return nil, errors.New("invalid encoding")
+		}
+	}
+	return decoded, nil
+}
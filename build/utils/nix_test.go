@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFlakeLock(t *testing.T) {
+	lock, err := ReadFlakeLock(filepath.Join("..", "testdata", "nix", "flake"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"flake-utils", "home-manager", "nixpkgs"}, lock.RootInputs)
+	assert.Len(t, lock.Inputs, 4)
+
+	nixpkgs := lock.Inputs["nixpkgs"]
+	assert.Equal(t, "nixpkgs:6df37dc6a77654682fe9f071c62b4242b5342e04", nixpkgs.Id())
+	assert.Equal(t, "github:NixOS/nixpkgs/6df37dc6a77654682fe9f071c62b4242b5342e04", nixpkgs.Url)
+	assert.Equal(t, "sha256-2T/H0Q3fWzComEgjP1J/SDoNiG+nS9bADAz0kXd1Kqo=", nixpkgs.NarHash)
+	assert.Empty(t, nixpkgs.Inputs)
+
+	// The nixpkgs input of home-manager follows the nixpkgs input of the root flake.
+	homeManager := lock.Inputs["home-manager"]
+	assert.Equal(t, "git+https://github.com/nix-community/home-manager?rev=d5824a76bc6bb93d1dce9ebbbcb09a9b6abcc224", homeManager.Url)
+	assert.Equal(t, []string{"nixpkgs"}, homeManager.Inputs)
+
+	assert.Equal(t, []string{"systems"}, lock.Inputs["flake-utils"].Inputs)
+}
+
+func TestFlakeInputId(t *testing.T) {
+	input := FlakeInput{Key: "nixpkgs_2", Rev: "abc"}
+	assert.Equal(t, "nixpkgs:abc", input.Id())
+	input = FlakeInput{Key: "source"}
+	assert.Equal(t, "source", input.Id())
+}
+
+func TestParseNixPathInfo(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("..", "testdata", "nix", "path-info.json"))
+	require.NoError(t, err)
+	storePaths, err := ParseNixPathInfo(output)
+	require.NoError(t, err)
+	require.Len(t, storePaths, 3)
+	assert.Equal(t, "/nix/store/1b9p07z77phvv2hf6gm9f28syp39f1ag-hello-2.12.1", storePaths[0].Path)
+	assert.Equal(t, "hello:2.12.1", storePaths[0].Id())
+	assert.Len(t, storePaths[0].References, 2)
+	assert.Equal(t, "glibc:2.38-44", storePaths[1].Id())
+
+	// The output of Nix 2.18 and earlier.
+	storePaths, err = ParseNixPathInfo([]byte(`[{"path": "/nix/store/rxganm4ibf31qngal3j3psp20mak37yy-xgcc-12.3.0-libgcc", "narHash": "sha256:0p93iivsyygikai1bjkd37x7nfi9nsgbsvwx5nx7hij26dypa48v", "references": []}]`))
+	require.NoError(t, err)
+	require.Len(t, storePaths, 1)
+	assert.Equal(t, "xgcc:12.3.0-libgcc", storePaths[0].Id())
+}
+
+func TestNarHashToSha256(t *testing.T) {
+	testCases := []struct {
+		narHash string
+		sha256  string
+	}{
+		{"sha256-2T/H0Q3fWzComEgjP1J/SDoNiG+nS9bADAz0kXd1Kqo=", "d93fc7d10ddf5b30a89848233f527f483a0d886fa74bd6c00c0cf49177752aaa"},
+		// The sha256 checksum of an empty string.
+		{"sha256:0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"sha256:094qif9n4cq4fdg459qzbhg1c6wywawwaaivx0k0x8xhbyx4vwic", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{"sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.narHash, func(t *testing.T) {
+			sha256, err := NarHashToSha256(testCase.narHash)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.sha256, sha256)
+		})
+	}
+	_, err := NarHashToSha256("md5:abc")
+	assert.Error(t, err)
+}
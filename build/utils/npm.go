@@ -16,6 +16,44 @@ import (
 	"github.com/jfrog/gofrog/version"
 )
 
+const (
+	NpmLockFileName       = "package-lock.json"
+	NpmShrinkwrapFileName = "npm-shrinkwrap.json"
+	YarnLockFileName      = "yarn.lock"
+)
+
+// The lock files of the package managers of npm projects, in their order of precedence in a directory.
+// The lock files of npm come first, so that a project with a package-lock.json file is treated as an npm project.
+var npmProjectLockFileNames = []string{NpmLockFileName, NpmShrinkwrapFileName, PnpmLockFileName, BunLockFileName, BunBinaryLockFileName, YarnLockFileName}
+
+// Returns the directory and the name of the nearest lock file of the project, which is either in the project's directory, or in the root of its workspace.
+// The search stops at the first lock file of any package manager, so that the lock file of an unrelated project in one of the parent directories is ignored.
+// It also stops at the root of a pnpm workspace, which has a pnpm-workspace.yaml file.
+// Empty strings are returned if no lock file is found.
+func findNpmProjectLockFile(srcPath string) (lockDir, lockFileName string, err error) {
+	// Store all paths visited, to avoid running in circles.
+	visitedPaths := make(map[string]bool)
+	currDir := srcPath
+	for !visitedPaths[currDir] {
+		for _, lockFileName = range npmProjectLockFileNames {
+			exists, err := utils.IsFileExists(filepath.Join(currDir, lockFileName), true)
+			if err != nil {
+				return "", "", err
+			}
+			if exists {
+				return currDir, lockFileName, nil
+			}
+		}
+		isPnpmWorkspaceRoot, err := utils.IsFileExists(filepath.Join(currDir, PnpmWorkspaceFileName), true)
+		if err != nil || isPnpmWorkspaceRoot {
+			return "", "", err
+		}
+		visitedPaths[currDir] = true
+		currDir = filepath.Dir(currDir)
+	}
+	return "", "", nil
+}
+
 // CalculateNpmDependenciesList gets an npm project's dependencies.
 func CalculateNpmDependenciesList(executablePath, srcPath, moduleId string, npmArgs []string, calculateChecksums bool, log utils.Log) ([]entities.Dependency, error) {
 	if log == nil {
@@ -464,3 +502,74 @@ func GetNpmConfigCache(srcPath, executablePath string, npmArgs []string, log uti
 func printMissingDependenciesWarning(dependencyType string, dependencies []string, log utils.Log) {
 	log.Debug("The following dependencies will not be included in the build-info, because the 'npm ls' command did not return their integrity.\nThe reason why the version wasn't returned may be because the package is a '" + dependencyType + "', which was not manually installed.\n It is therefore okay to skip this dependency: " + strings.Join(dependencies, ","))
 }
+
+// The license fields of a package.json file. The license may be an SPDX expression or an object with a type. The deprecated 'licenses' field is a list of such objects.
+type packageJsonLicenses struct {
+	Name     string            `json:"name,omitempty"`
+	Version  string            `json:"version,omitempty"`
+	License  json.RawMessage   `json:"license,omitempty"`
+	Licenses []json.RawMessage `json:"licenses,omitempty"`
+}
+
+// GetNodeModulesLicenses reads the licenses of the packages, which are installed in the node_modules directory of the project, from their package.json files.
+// Returns a map of the packages IDs ('name:version') to their licenses, normalized into SPDX license expressions.
+func GetNodeModulesLicenses(srcPath string) (map[string][]string, error) {
+	licenses := make(map[string][]string)
+	nodeModulesPath := filepath.Join(srcPath, "node_modules")
+	exists, err := utils.IsDirExists(nodeModulesPath, false)
+	if err != nil || !exists {
+		return licenses, err
+	}
+	err = filepath.WalkDir(nodeModulesPath, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || entry.Name() != "package.json" || !isNodeModulesPackageDir(filepath.Dir(path)) {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var packageJson packageJsonLicenses
+		// Package.json files, which can't be parsed, are skipped.
+		if json.Unmarshal(content, &packageJson) != nil || packageJson.Name == "" {
+			return nil
+		}
+		if packageLicenses := packageJson.getLicenses(); len(packageLicenses) > 0 {
+			licenses[packageJson.Name+":"+packageJson.Version] = packageLicenses
+		}
+		return nil
+	})
+	return licenses, err
+}
+
+// Returns true if the directory is the root of a package in a node_modules directory ('node_modules/<name>' or 'node_modules/@<scope>/<name>').
+func isNodeModulesPackageDir(dirPath string) bool {
+	parentPath := filepath.Dir(dirPath)
+	if strings.HasPrefix(filepath.Base(parentPath), "@") {
+		parentPath = filepath.Dir(parentPath)
+	}
+	return filepath.Base(parentPath) == "node_modules"
+}
+
+func (pjl *packageJsonLicenses) getLicenses() []string {
+	var licenses []string
+	for _, license := range append([]json.RawMessage{pjl.License}, pjl.Licenses...) {
+		if len(license) == 0 {
+			continue
+		}
+		var expression string
+		if json.Unmarshal(license, &expression) != nil {
+			var licenseObject struct {
+				Type string `json:"type,omitempty"`
+			}
+			if json.Unmarshal(license, &licenseObject) != nil {
+				continue
+			}
+			expression = licenseObject.Type
+		}
+		licenses = append(licenses, expression)
+	}
+	return entities.NormalizeSpdxLicenses(licenses)
+}
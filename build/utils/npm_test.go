@@ -325,3 +325,18 @@ func TestGetConfigCacheNpmIntegration(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, filepath.Join(cachePath, "_cacache"), configCache)
 }
+
+func TestGetNodeModulesLicenses(t *testing.T) {
+	licenses, err := GetNodeModulesLicenses(filepath.Join("..", "testdata", "npm", "licenses"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"is-number:7.0.0":     {"MIT"},
+		"kind-of:3.2.2":       {"(MIT OR Apache-2.0)"},
+		"@scope/legacy:0.1.0": {"BSD-3-Clause", "GPL-2.0-only"},
+	}, licenses)
+
+	// Projects, whose packages aren't installed, have no licenses.
+	licenses, err = GetNodeModulesLicenses(t.TempDir())
+	assert.NoError(t, err)
+	assert.Empty(t, licenses)
+}
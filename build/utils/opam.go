@@ -0,0 +1,350 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The directory of a local switch, which 'opam switch create .' creates in the project directory.
+	OpamLocalSwitchDir = "_opam"
+	opamFileSuffix     = ".opam"
+	opamFileName       = "opam"
+)
+
+// OpamPackage represents a package installed in an opam switch.
+type OpamPackage struct {
+	Name    string
+	Version string
+	// The URL of the source archive (or repository) of the package, and its checksums, mapped by the names of their algorithms, for example: 'sha256'.
+	SourceUrl string
+	Checksums map[string]string
+	// The names of the packages this package depends on, including optional dependencies, which are installed in the switch.
+	Dependencies []string
+	// True if the package was installed explicitly, rather than as a dependency of another package.
+	Root bool
+}
+
+func (op *OpamPackage) Id() string {
+	return op.Name + ":" + op.Version
+}
+
+// opamFile holds the fields of a file in the opam file format, such as an opam file or a switch-state file.
+// The value of each field is kept as a list of tokens. Sections (for example: 'url { src: "..." }') are mapped by their names too.
+type opamFile struct {
+	fields   map[string][]opamToken
+	sections map[string]*opamFile
+}
+
+type opamToken struct {
+	value    string
+	isString bool
+}
+
+// GetOpamSwitchPrefix returns the prefix of the opam switch of the project in the given directory.
+// This is the local switch of the project in its '_opam' directory, if it exists, or the current switch, as returned by 'opam var prefix'.
+func GetOpamSwitchPrefix(srcPath string) (string, error) {
+	localSwitch := filepath.Join(srcPath, OpamLocalSwitchDir)
+	exists, err := utils.IsDirExists(localSwitch, true)
+	if err != nil || exists {
+		return localSwitch, err
+	}
+	command := utils.NewCommand("opam", "var", []string{"prefix"})
+	command.Dir = srcPath
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed running 'opam var prefix': %s", err.Error())
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetOpamProjectPackages returns the packages defined by the opam files of the project in the given directory ('<name>.opam' or 'opam'), mapped by their names.
+func GetOpamProjectPackages(srcPath string) (map[string]*OpamPackage, error) {
+	opamPaths, err := filepath.Glob(filepath.Join(srcPath, "*"+opamFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	if exists, err := utils.IsFileExists(filepath.Join(srcPath, opamFileName), true); err != nil {
+		return nil, err
+	} else if exists {
+		opamPaths = append(opamPaths, filepath.Join(srcPath, opamFileName))
+	}
+	packages := make(map[string]*OpamPackage)
+	for _, opamPath := range opamPaths {
+		pkg, err := readOpamPackageFile(opamPath)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.Name == "" {
+			pkg.Name = strings.TrimSuffix(filepath.Base(opamPath), opamFileSuffix)
+		}
+		if pkg.Name == opamFileName {
+			// An 'opam' file without a name is named after the directory of the project.
+			pkg.Name = filepath.Base(srcPath)
+		}
+		packages[pkg.Name] = pkg
+	}
+	return packages, nil
+}
+
+// ReadOpamSwitch returns the packages installed in the opam switch with the given prefix, sorted by their names.
+// The installed packages are listed in the '.opam-switch/switch-state' file, and their opam files are copied to '.opam-switch/packages/<name>.<version>/opam'.
+func ReadOpamSwitch(switchPrefix string) ([]*OpamPackage, error) {
+	switchMetadataPath := filepath.Join(switchPrefix, ".opam-switch")
+	state, err := readOpamFile(filepath.Join(switchMetadataPath, "switch-state"))
+	if err != nil {
+		return nil, err
+	}
+	roots := make(map[string]bool)
+	for _, root := range getOpamStrings(state.fields["roots"]) {
+		roots[root] = true
+	}
+	var packages []*OpamPackage
+	for _, installed := range getOpamStrings(state.fields["installed"]) {
+		// For example: 'dune.3.11.1'. The names of packages can't contain dots.
+		name, version, _ := strings.Cut(installed, ".")
+		pkg := &OpamPackage{Name: name, Version: version}
+		opamPath := filepath.Join(switchMetadataPath, "packages", installed, opamFileName)
+		exists, err := utils.IsFileExists(opamPath, true)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			if pkg, err = readOpamPackageFile(opamPath); err != nil {
+				return nil, err
+			}
+			pkg.Name, pkg.Version = name, version
+		}
+		pkg.Root = roots[installed]
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+func readOpamPackageFile(opamPath string) (*OpamPackage, error) {
+	file, err := readOpamFile(opamPath)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &OpamPackage{Name: getOpamString(file.fields["name"]), Version: getOpamString(file.fields["version"]), Checksums: make(map[string]string)}
+	// Dependencies are listed with their version constraints and filters, for example: '"dune" {>= "3.0" & build}'.
+	// All the packages in the formulas are collected, including alternatives, since only the installed ones are resolved.
+	pkg.Dependencies = append(getOpamDependencyNames(file.fields["depends"]), getOpamDependencyNames(file.fields["depopts"])...)
+	if url, exists := file.sections["url"]; exists {
+		for _, field := range []string{"src", "archive", "http", "git"} {
+			if pkg.SourceUrl = getOpamString(url.fields[field]); pkg.SourceUrl != "" {
+				break
+			}
+		}
+		// The checksum may be a single string or a list of strings.
+		for _, checksum := range getOpamStrings(url.fields["checksum"]) {
+			// For example: 'sha256=7c4a...'. Checksums without an algorithm are md5 checksums.
+			algorithm, hash, found := strings.Cut(checksum, "=")
+			if !found {
+				algorithm, hash = "md5", checksum
+			}
+			pkg.Checksums[algorithm] = hash
+		}
+	}
+	return pkg, nil
+}
+
+// Returns the names of the packages in a dependency formula.
+// Dependencies with the 'post' filter are skipped, since they are installed after the package, for example: 'ocaml' in the opam file of 'ocaml-base-compiler'.
+func getOpamDependencyNames(tokens []opamToken) []string {
+	var names []string
+	for i := 0; i < len(tokens); i++ {
+		if !tokens[i].isString {
+			continue
+		}
+		name, isPost := tokens[i].value, false
+		if i+1 < len(tokens) && !tokens[i+1].isString && tokens[i+1].value == "{" {
+			end := skipOpamTerm(tokens, i+1)
+			for _, option := range tokens[i+2 : end] {
+				isPost = isPost || (!option.isString && option.value == "post")
+			}
+			// The option block contains versions, which aren't names of packages.
+			i = end - 1
+		}
+		if !isPost {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Returns the first string in the value of a field.
+func getOpamString(tokens []opamToken) string {
+	if values := getOpamStrings(tokens); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Returns the strings in the value of a field, excluding the strings in option blocks (for example: '{>= "3.0"}').
+func getOpamStrings(tokens []opamToken) []string {
+	var values []string
+	depth := 0
+	for _, token := range tokens {
+		switch {
+		case token.isString:
+			if depth == 0 {
+				values = append(values, token.value)
+			}
+		case token.value == "{":
+			depth++
+		case token.value == "}":
+			depth--
+		}
+	}
+	return values
+}
+
+func readOpamFile(path string) (*opamFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := tokenizeOpam(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing %s: %s", path, err.Error())
+	}
+	file, _ := parseOpamFields(tokens, 0)
+	return file, nil
+}
+
+// Parses the fields and sections, starting at the given index, until the end of the tokens or of the section.
+// Returns the index after the closing brace of the section.
+func parseOpamFields(tokens []opamToken, start int) (*opamFile, int) {
+	file := &opamFile{fields: make(map[string][]opamToken), sections: make(map[string]*opamFile)}
+	i := start
+	for i < len(tokens) {
+		token := tokens[i]
+		switch {
+		case !token.isString && token.value == "}":
+			return file, i + 1
+		case token.isString:
+			i++
+		case i+1 < len(tokens) && !tokens[i+1].isString && tokens[i+1].value == ":":
+			end := getOpamValueEnd(tokens, i+2)
+			file.fields[token.value] = tokens[i+2 : end]
+			i = end
+		case i+1 < len(tokens) && !tokens[i+1].isString && tokens[i+1].value == "{":
+			file.sections[token.value], i = parseOpamFields(tokens, i+2)
+		case i+2 < len(tokens) && tokens[i+1].isString && !tokens[i+2].isString && tokens[i+2].value == "{":
+			// A section with a label, for example: 'extra-source "fix.patch" {...}'.
+			file.sections[token.value], i = parseOpamFields(tokens, i+3)
+		default:
+			i++
+		}
+	}
+	return file, i
+}
+
+// Returns the index after the value of a field, which starts at the given index.
+// A value may be a list, a string or an identifier, optionally followed by an option block, or a formula of values, for example: '"a" | "b"'.
+func getOpamValueEnd(tokens []opamToken, start int) int {
+	i := start
+	for i < len(tokens) {
+		// Skip a unary operator, for example: '!'.
+		for i < len(tokens) && !tokens[i].isString && tokens[i].value == "!" {
+			i++
+		}
+		i = skipOpamTerm(tokens, i)
+		if i < len(tokens) && !tokens[i].isString && tokens[i].value == "{" {
+			i = skipOpamTerm(tokens, i)
+		}
+		if i >= len(tokens) || tokens[i].isString || (tokens[i].value != "&" && tokens[i].value != "|") {
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+// Returns the index after the term, which starts at the given index. A term is a single token, or a block enclosed in brackets, braces or parentheses.
+func skipOpamTerm(tokens []opamToken, start int) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		if !tokens[i].isString {
+			switch tokens[i].value {
+			case "[", "{", "(":
+				depth++
+			case "]", "}", ")":
+				depth--
+			}
+		}
+		if depth <= 0 {
+			if depth < 0 {
+				// A closing bracket of an enclosing block.
+				return i
+			}
+			return i + 1
+		}
+	}
+	return len(tokens)
+}
+
+func tokenizeOpam(content string) ([]opamToken, error) {
+	var tokens []opamToken
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#':
+			if newLine := strings.IndexByte(content[i:], '\n'); newLine >= 0 {
+				i += newLine + 1
+			} else {
+				i = len(content)
+			}
+		case strings.HasPrefix(content[i:], "(*"):
+			end := strings.Index(content[i+2:], "*)")
+			if end < 0 {
+				return nil, errors.New("unfinished comment")
+			}
+			i += 2 + end + 2
+		case strings.HasPrefix(content[i:], `"""`):
+			end := strings.Index(content[i+3:], `"""`)
+			if end < 0 {
+				return nil, errors.New("unfinished string")
+			}
+			tokens = append(tokens, opamToken{value: content[i+3 : i+3+end], isString: true})
+			i += 3 + end + 3
+		case c == '"':
+			var value strings.Builder
+			i++
+			for ; i < len(content) && content[i] != '"'; i++ {
+				if content[i] == '\\' && i+1 < len(content) {
+					i++
+				}
+				value.WriteByte(content[i])
+			}
+			if i >= len(content) {
+				return nil, errors.New("unfinished string")
+			}
+			tokens = append(tokens, opamToken{value: value.String(), isString: true})
+			i++
+		case strings.ContainsRune("[]{}():", rune(c)):
+			tokens = append(tokens, opamToken{value: string(c)})
+			i++
+		default:
+			// Identifiers, numbers and operators.
+			start := i
+			for i < len(content) && !strings.ContainsRune(" \t\r\n\"#[]{}():", rune(content[i])) {
+				i++
+			}
+			tokens = append(tokens, opamToken{value: content[start:i]})
+		}
+	}
+	return tokens, nil
+}
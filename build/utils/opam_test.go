@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOpamSwitch(t *testing.T) {
+	srcPath := filepath.Join("..", "testdata", "opam", "project")
+	switchPrefix, err := GetOpamSwitchPrefix(srcPath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(srcPath, OpamLocalSwitchDir), switchPrefix)
+
+	packages, err := ReadOpamSwitch(switchPrefix)
+	require.NoError(t, err)
+	require.Len(t, packages, 9)
+	assert.Equal(t, &OpamPackage{
+		Name:      "dune",
+		Version:   "3.11.1",
+		SourceUrl: "https://github.com/ocaml/dune/releases/download/3.11.1/dune-3.11.1.tbz",
+		Checksums: map[string]string{
+			"sha256": "866f2307adadaf7604f3bf9d98bb4098792baa046953a6726c96c40fc5ed3f71",
+			"md5":    "c0a5d7d2b5e7f9b1f7a43c5e4bb4cd7d",
+		},
+		// All the packages in the formula, including alternatives.
+		Dependencies: []string{"ocaml", "ocaml", "ocamlfind-secondary", "base-unix", "base-threads"},
+		Root:         true,
+	}, packages[4])
+	// The installed packages are sorted by their names.
+	assert.Equal(t, "ocaml-base-compiler:5.1.0", packages[7].Id())
+	// The 'post' dependency on ocaml is skipped.
+	assert.Empty(t, packages[7].Dependencies)
+	assert.Equal(t, []string{"ocaml-config", "ocaml-base-compiler", "ocaml-variants", "ocaml-system"}, packages[6].Dependencies)
+	assert.False(t, packages[6].Root)
+}
+
+func TestGetOpamProjectPackages(t *testing.T) {
+	packages, err := GetOpamProjectPackages(filepath.Join("..", "testdata", "opam", "project"))
+	require.NoError(t, err)
+	require.Contains(t, packages, "hello")
+	assert.Equal(t, "0.1.0", packages["hello"].Version)
+	assert.Equal(t, []string{"ocaml", "dune", "cmdliner", "alcotest", "odoc"}, packages["hello"].Dependencies)
+	assert.Len(t, packages, 1)
+}
+
+func TestParseOpamFile(t *testing.T) {
+	tokens, err := tokenizeOpam(`opam-version: "2.0" (* A comment. *)
+descr: """A "quoted" description"""
+available: !(os = "win32") & arch != "arm32"
+depends: "ocaml" {>= "4.08"} | "ocaml-system"
+# A comment.
+url { src: "https://example.com/a.tgz" checksum: "4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e" }
+`)
+	require.NoError(t, err)
+	file, _ := parseOpamFields(tokens, 0)
+	assert.Equal(t, "2.0", getOpamString(file.fields["opam-version"]))
+	assert.Equal(t, `A "quoted" description`, getOpamString(file.fields["descr"]))
+	assert.Equal(t, []string{"ocaml", "ocaml-system"}, getOpamDependencyNames(file.fields["depends"]))
+	require.Contains(t, file.sections, "url")
+	assert.Equal(t, "https://example.com/a.tgz", getOpamString(file.sections["url"].fields["src"]))
+	assert.Equal(t, "4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e", getOpamString(file.sections["url"].fields["checksum"]))
+
+	_, err = tokenizeOpam(`synopsis: "unfinished`)
+	assert.Error(t, err)
+}
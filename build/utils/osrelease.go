@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// The locations of the os-release file, relative to the root directory of the system. The first one takes precedence.
+var osReleaseFilePaths = []string{filepath.Join("etc", "os-release"), filepath.Join("usr", "lib", "os-release")}
+
+// ReadOsRelease returns the fields of the os-release file of the system in the given root directory, such as ID and VERSION_ID.
+// An empty map is returned if the system doesn't have an os-release file.
+func ReadOsRelease(rootDir string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for _, osReleasePath := range osReleaseFilePaths {
+		osReleasePath = filepath.Join(rootDir, osReleasePath)
+		exists, err := utils.IsFileExists(osReleasePath, true)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		content, err := os.ReadFile(osReleasePath)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			key, value, found := strings.Cut(line, "=")
+			if !found || strings.HasPrefix(line, "#") {
+				continue
+			}
+			// The values may be quoted, as in shell variable assignments.
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			} else {
+				value = strings.Trim(value, `'`)
+			}
+			fields[key] = value
+		}
+		return fields, scanner.Err()
+	}
+	return fields, nil
+}
+
+// GetOsModuleId returns the ID of the module, which represents the packages installed on the system in the given root directory, for example: 'alpine:3.18.4'.
+// The ID is taken from the os-release file of the system. The given default ID is returned if the system doesn't have an os-release file.
+func GetOsModuleId(rootDir, defaultId string) (string, error) {
+	fields, err := ReadOsRelease(rootDir)
+	if err != nil || fields["ID"] == "" {
+		return defaultId, err
+	}
+	if fields["VERSION_ID"] == "" {
+		return fields["ID"], nil
+	}
+	return fields["ID"] + ":" + fields["VERSION_ID"], nil
+}
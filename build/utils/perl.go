@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	CpanfileFileName         = "cpanfile"
+	CpanfileSnapshotFileName = "cpanfile.snapshot"
+	perlMetaFileName         = "META.json"
+)
+
+var (
+	// Matches the modules required by a cpanfile in any phase, for example: "requires 'Plack', '1.0';" or "test_requires 'Test::More';".
+	cpanfileRequiresRegExp = regexp.MustCompile(`(?m)^\s*(?:\w+_)?requires\s*\(?\s*['"]([^'"]+)['"]`)
+	// Matches the versions at the end of distribution names, for example: '1.008' in 'Class-Tiny-1.008' or 'v1.2.3' in 'Foo-v1.2.3'.
+	cpanDistributionVersionRegExp = regexp.MustCompile(`-(v?\d[\w.]*)$`)
+)
+
+// CpanDistribution represents a CPAN distribution listed in a cpanfile.snapshot file.
+type CpanDistribution struct {
+	// The name of the distribution in the snapshot, which includes its version, for example: 'Class-Tiny-1.008'.
+	Distribution string
+	// The path of the distribution's archive in CPAN, for example: 'D/DA/DAGOLDEN/Class-Tiny-1.008.tar.gz'.
+	Pathname string
+	// The modules the distribution provides, mapped to their versions.
+	Provides map[string]string
+	// The names of the modules the distribution requires.
+	Requirements []string
+}
+
+// NameAndVersion splits the name of the distribution into the distribution's name and its version, for example: 'Class-Tiny' and '1.008'.
+func (cd *CpanDistribution) NameAndVersion() (name, version string) {
+	if loc := cpanDistributionVersionRegExp.FindStringSubmatchIndex(cd.Distribution); loc != nil {
+		return cd.Distribution[:loc[0]], cd.Distribution[loc[2]:loc[3]]
+	}
+	return cd.Distribution, ""
+}
+
+func (cd *CpanDistribution) Id() string {
+	name, version := cd.NameAndVersion()
+	if version == "" {
+		return name
+	}
+	return name + ":" + version
+}
+
+// ReadCpanfileSnapshot returns the distributions listed in the cpanfile.snapshot file, which Carton creates in the given directory.
+// The file lists the distributions under its DISTRIBUTIONS section, for example:
+//
+//	DISTRIBUTIONS
+//	  Class-Tiny-1.008
+//	    pathname: D/DA/DAGOLDEN/Class-Tiny-1.008.tar.gz
+//	    provides:
+//	      Class::Tiny 1.008
+//	    requirements:
+//	      Carp 0
+func ReadCpanfileSnapshot(srcPath string) (distributions []CpanDistribution, err error) {
+	file, err := os.Open(filepath.Join(srcPath, CpanfileSnapshotFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		e := file.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	var section, field string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		content := strings.TrimSpace(line)
+		if content == "" || strings.HasPrefix(content, "#") {
+			continue
+		}
+		indentation := len(line) - len(strings.TrimLeft(line, " "))
+		if indentation == 0 {
+			section = content
+			continue
+		}
+		if section != "DISTRIBUTIONS" {
+			continue
+		}
+		switch {
+		case indentation == 2:
+			distributions = append(distributions, CpanDistribution{Distribution: content, Provides: make(map[string]string)})
+			field = ""
+		case len(distributions) == 0:
+			continue
+		case indentation == 4:
+			var value string
+			field, value, _ = strings.Cut(content, ":")
+			if field == "pathname" {
+				distributions[len(distributions)-1].Pathname = strings.TrimSpace(value)
+			}
+		case indentation == 6:
+			module, version, _ := strings.Cut(content, " ")
+			distribution := &distributions[len(distributions)-1]
+			switch field {
+			case "provides":
+				distribution.Provides[module] = strings.TrimSpace(version)
+			case "requirements":
+				distribution.Requirements = append(distribution.Requirements, module)
+			}
+		}
+	}
+	return distributions, scanner.Err()
+}
+
+// ReadCpanfileRequirements returns the names of the modules required by the cpanfile in the given directory, in all the phases.
+// A nil slice is returned if the project has no cpanfile.
+func ReadCpanfileRequirements(srcPath string) ([]string, error) {
+	cpanfilePath := filepath.Join(srcPath, CpanfileFileName)
+	exists, err := utils.IsFileExists(cpanfilePath, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	// The cpanfile is a Perl script, so only the 'requires' statements are extracted from it.
+	content, err := os.ReadFile(cpanfilePath)
+	if err != nil {
+		return nil, err
+	}
+	requirements := []string{}
+	for _, match := range cpanfileRequiresRegExp.FindAllSubmatch(content, -1) {
+		requirements = append(requirements, string(match[1]))
+	}
+	return requirements, nil
+}
+
+// GetPerlProjectNameAndVersion returns the name and the version of the Perl project in the given directory, as listed in its META.json file.
+// Empty strings are returned if the project has no META.json file.
+func GetPerlProjectNameAndVersion(srcPath string) (name, version string, err error) {
+	metaPath := filepath.Join(srcPath, perlMetaFileName)
+	exists, err := utils.IsFileExists(metaPath, true)
+	if err != nil || !exists {
+		return
+	}
+	var meta struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err = utils.Unmarshal(metaPath, &meta); err != nil {
+		return
+	}
+	return meta.Name, meta.Version, nil
+}
+
+// FindCpanDistributionArchive returns the path of the archive of a distribution, or an empty string if it's not found.
+// The archives are searched in the 'vendor/cache' directory of the project (created by 'carton bundle') and in its 'local/cache' directory,
+// in which they are located under the path of the distribution in CPAN, for example: 'authors/id/D/DA/DAGOLDEN/Class-Tiny-1.008.tar.gz'.
+func FindCpanDistributionArchive(srcPath string, distribution CpanDistribution) (string, error) {
+	if distribution.Pathname == "" {
+		return "", nil
+	}
+	for _, cachePath := range []string{filepath.Join(srcPath, "vendor", "cache"), filepath.Join(srcPath, "local", "cache")} {
+		archivePath := filepath.Join(cachePath, "authors", "id", filepath.FromSlash(distribution.Pathname))
+		exists, err := utils.IsFileExists(archivePath, false)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return archivePath, nil
+		}
+	}
+	return "", nil
+}
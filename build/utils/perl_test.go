@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCpanfileSnapshot(t *testing.T) {
+	distributions, err := ReadCpanfileSnapshot(filepath.Join("..", "testdata", "perl", "project"))
+	require.NoError(t, err)
+	require.Len(t, distributions, 6)
+	assert.Equal(t, CpanDistribution{
+		Distribution: "Sub-Quote-2.006008",
+		Pathname:     "H/HA/HAARG/Sub-Quote-2.006008.tar.gz",
+		Provides:     map[string]string{"Sub::Defer": "2.006008", "Sub::Quote": "2.006008"},
+		Requirements: []string{"ExtUtils::MakeMaker", "Scalar::Util", "perl"},
+	}, distributions[3])
+	assert.Equal(t, "Class-Method-Modifiers:2.15", distributions[0].Id())
+}
+
+func TestCpanDistributionNameAndVersion(t *testing.T) {
+	testCases := []struct {
+		distribution    string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"Class-Tiny-1.008", "Class-Tiny", "1.008"},
+		{"libwww-perl-6.72", "libwww-perl", "6.72"},
+		{"Data-Printer-v1.2.0", "Data-Printer", "v1.2.0"},
+		{"Foo-Bar-0.01_01", "Foo-Bar", "0.01_01"},
+		{"NoVersion", "NoVersion", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.distribution, func(t *testing.T) {
+			distribution := CpanDistribution{Distribution: testCase.distribution}
+			name, version := distribution.NameAndVersion()
+			assert.Equal(t, testCase.expectedName, name)
+			assert.Equal(t, testCase.expectedVersion, version)
+		})
+	}
+}
+
+func TestReadCpanfileRequirements(t *testing.T) {
+	requirements, err := ReadCpanfileRequirements(filepath.Join("..", "testdata", "perl", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"perl", "Moo", "Test::Needs"}, requirements)
+
+	// A project without a cpanfile.
+	requirements, err = ReadCpanfileRequirements(filepath.Join("..", "testdata", "perl"))
+	assert.NoError(t, err)
+	assert.Nil(t, requirements)
+}
+
+func TestFindCpanDistributionArchive(t *testing.T) {
+	srcPath := filepath.Join("..", "testdata", "perl", "project")
+	archivePath, err := FindCpanDistributionArchive(srcPath, CpanDistribution{Pathname: "H/HA/HAARG/Moo-2.005005.tar.gz"})
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(srcPath, "vendor", "cache", "authors", "id", "H", "HA", "HAARG", "Moo-2.005005.tar.gz"), archivePath)
+
+	archivePath, err = FindCpanDistributionArchive(srcPath, CpanDistribution{Pathname: "E/ET/ETHER/Try-Tiny-0.31.tar.gz"})
+	assert.NoError(t, err)
+	assert.Empty(t, archivePath)
+}
@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	PnpmLockFileName      = "pnpm-lock.yaml"
+	PnpmWorkspaceFileName = "pnpm-workspace.yaml"
+	// The prefix of the versions of workspace packages and other local packages in the output of 'pnpm list'.
+	pnpmLinkVersionPrefix = "link:"
+)
+
+// A project in the output of 'pnpm list --json'. When running in a workspace, each workspace package is a project.
+type pnpmLsProject struct {
+	Name                 string                      `json:"name,omitempty"`
+	Version              string                      `json:"version,omitempty"`
+	Path                 string                      `json:"path,omitempty"`
+	Dependencies         map[string]pnpmLsDependency `json:"dependencies,omitempty"`
+	DevDependencies      map[string]pnpmLsDependency `json:"devDependencies,omitempty"`
+	OptionalDependencies map[string]pnpmLsDependency `json:"optionalDependencies,omitempty"`
+}
+
+func (plp *pnpmLsProject) id() string {
+	return plp.Name + ":" + plp.Version
+}
+
+// A dependency in the output of 'pnpm list --json'.
+type pnpmLsDependency struct {
+	From    string `json:"from,omitempty"`
+	Version string `json:"version,omitempty"`
+	// The path of the package in the virtual store (or of the workspace package, for linked packages).
+	Path         string                      `json:"path,omitempty"`
+	Dependencies map[string]pnpmLsDependency `json:"dependencies,omitempty"`
+}
+
+type pnpmLock struct {
+	LockfileVersion interface{}                `yaml:"lockfileVersion"`
+	Packages        map[string]pnpmLockPackage `yaml:"packages"`
+}
+
+type pnpmLockPackage struct {
+	Resolution struct {
+		Integrity string `yaml:"integrity,omitempty"`
+	} `yaml:"resolution"`
+}
+
+// FindPnpmLockDir returns the directory of the pnpm-lock.yaml file of the project, which is either the project's directory, or the root of its workspace.
+// An empty string is returned if the project isn't managed by pnpm, which is also the case if a lock file of another package manager (such as package-lock.json) is found first.
+func FindPnpmLockDir(srcPath string) (string, error) {
+	lockDir, lockFileName, err := findNpmProjectLockFile(srcPath)
+	if err != nil || lockFileName != PnpmLockFileName {
+		return "", err
+	}
+	return lockDir, nil
+}
+
+func GetPnpmExecPath(log utils.Log) (string, error) {
+	pnpmExecPath, err := exec.LookPath("pnpm")
+	if err != nil {
+		return "", err
+	}
+	if pnpmExecPath == "" {
+		return "", errors.New("could not find the 'pnpm' executable in the system PATH")
+	}
+	log.Debug("Using pnpm executable:", pnpmExecPath)
+	return pnpmExecPath, nil
+}
+
+// CalculatePnpmDependenciesList gets the dependencies of a project managed by pnpm, using 'pnpm list'.
+// pnpm installs the packages in a content-addressable store, so it doesn't keep their tarballs. The checksums are therefore taken from the integrity of each package in the pnpm-lock.yaml file, when its algorithm is sha1.
+// If the project is the root of a pnpm workspace, the workspace packages are added as dependencies of the module, along with their own dependencies.
+func CalculatePnpmDependenciesList(executablePath, srcPath, moduleId string, pnpmArgs []string, log utils.Log) ([]entities.Dependency, error) {
+	if log == nil {
+		log = &utils.NullLog{}
+	}
+	lockDir, err := utils.FindFileInDirAndParents(srcPath, PnpmLockFileName)
+	if err != nil {
+		return nil, err
+	}
+	integrities, err := readPnpmLockIntegrities(filepath.Join(lockDir, PnpmLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	// These arguments must be added at the end of the command, to override their other values (if existed in pnpmArgs).
+	pnpmArgs = append(pnpmArgs, "--json", "--depth", "Infinity")
+	isWorkspaceRoot, err := utils.IsFileExists(filepath.Join(srcPath, PnpmWorkspaceFileName), false)
+	if err != nil {
+		return nil, err
+	}
+	if isWorkspaceRoot {
+		pnpmArgs = append(pnpmArgs, "--recursive")
+	}
+	command := utils.NewCommand(executablePath, "list", pnpmArgs)
+	command.Dir = srcPath
+	data, err := command.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	dependenciesMap, err := parsePnpmLsOutput(data, srcPath, moduleId, integrities, log)
+	if err != nil {
+		return nil, err
+	}
+	var dependenciesList []entities.Dependency
+	for _, dep := range dependenciesMap {
+		dependenciesList = append(dependenciesList, dep.Dependency)
+	}
+	return dependenciesList, nil
+}
+
+// Parses the output of 'pnpm list --json' and returns a dependencies map, which looks like name:version -> entities.Dependency.
+func parsePnpmLsOutput(data []byte, srcPath, moduleId string, integrities map[string]string, log utils.Log) (map[string]*dependencyInfo, error) {
+	var projects []pnpmLsProject
+	if err := json.Unmarshal(data, &projects); err != nil {
+		return nil, err
+	}
+	// The IDs of the workspace packages, mapped by their paths. Used to resolve the linked dependencies.
+	projectsIds := make(map[string]string)
+	for _, project := range projects {
+		projectsIds[filepath.Clean(project.Path)] = project.id()
+	}
+	dependenciesMap := make(map[string]*dependencyInfo)
+	// The workspace packages (other than the root project) are added first, so that the packages linking to them could be added to their requestedBy field.
+	for _, project := range projects {
+		if !isPnpmRootProject(project, srcPath) {
+			appendDependency(dependenciesMap, &npmLsDependency{Name: project.Name, Version: project.Version}, []string{moduleId}, log)
+		}
+	}
+	for _, project := range projects {
+		pathToRoot := []string{moduleId}
+		if !isPnpmRootProject(project, srcPath) {
+			pathToRoot = append([]string{project.id()}, pathToRoot...)
+		}
+		parsePnpmDependencies(project.Dependencies, false, pathToRoot, projectsIds, dependenciesMap, log)
+		parsePnpmDependencies(project.OptionalDependencies, false, pathToRoot, projectsIds, dependenciesMap, log)
+		parsePnpmDependencies(project.DevDependencies, true, pathToRoot, projectsIds, dependenciesMap, log)
+	}
+	for id, dep := range dependenciesMap {
+		dep.Integrity = integrities[id]
+		if dep.Integrity == "" {
+			continue
+		}
+		hashAlgorithm, hash, err := integrityToSha(dep.Integrity)
+		if err != nil {
+			return nil, err
+		}
+		if hashAlgorithm == "sha1" {
+			dep.Sha1 = hash
+		} else {
+			log.Debug(fmt.Sprintf("Couldn't set the checksums of %s, because its integrity uses the %s algorithm.", id, hashAlgorithm))
+		}
+	}
+	return dependenciesMap, nil
+}
+
+func isPnpmRootProject(project pnpmLsProject, srcPath string) bool {
+	return filepath.Clean(project.Path) == filepath.Clean(srcPath)
+}
+
+func parsePnpmDependencies(dependencies map[string]pnpmLsDependency, dev bool, pathToRoot []string, projectsIds map[string]string, dependenciesMap map[string]*dependencyInfo, log utils.Log) {
+	for name, dependency := range dependencies {
+		if strings.HasPrefix(dependency.Version, pnpmLinkVersionPrefix) {
+			// Workspace packages are added as dependencies of the module separately.
+			projectId := projectsIds[filepath.Clean(dependency.Path)]
+			if workspacePackage, ok := dependenciesMap[projectId]; ok && !slices.Contains(pathToRoot, projectId) {
+				workspacePackage.RequestedBy = append(workspacePackage.RequestedBy, pathToRoot)
+			} else {
+				log.Debug(fmt.Sprintf("Skipping the local package %s (%s).", name, dependency.Version))
+			}
+			continue
+		}
+		npmLsDependency := &npmLsDependency{Name: name, Version: dependency.Version, Dev: dev}
+		// Skip circular dependencies.
+		if slices.Contains(pathToRoot, npmLsDependency.id()) {
+			continue
+		}
+		appendDependency(dependenciesMap, npmLsDependency, pathToRoot, log)
+		parsePnpmDependencies(dependency.Dependencies, dev, append([]string{npmLsDependency.id()}, pathToRoot...), projectsIds, dependenciesMap, log)
+	}
+}
+
+// Reads the integrity of each package in the pnpm-lock.yaml file, mapped by the package's ID (name:version).
+func readPnpmLockIntegrities(lockPath string) (map[string]string, error) {
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	var lock pnpmLock
+	if err = yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	// In lock files version 5, the packages are keyed by '/name/version'. In later versions, by '/name@version' (and by 'name@version' since version 9).
+	slashSeparated := strings.HasPrefix(fmt.Sprint(lock.LockfileVersion), "5")
+	integrities := make(map[string]string)
+	for key, pkg := range lock.Packages {
+		name, version := parsePnpmPackageKey(key, slashSeparated)
+		if name == "" || pkg.Resolution.Integrity == "" {
+			continue
+		}
+		integrities[name+":"+version] = pkg.Resolution.Integrity
+	}
+	return integrities, nil
+}
+
+// Returns the name and version of a package from its key in the pnpm-lock.yaml file, for example: '/@babel/core@7.22.0(supports-color@8.1.1)'.
+func parsePnpmPackageKey(key string, slashSeparated bool) (name, version string) {
+	key = strings.TrimPrefix(key, "/")
+	// Remove the resolved peer dependencies.
+	if i := strings.Index(key, "("); i >= 0 {
+		key = key[:i]
+	}
+	separator := "@"
+	if slashSeparated {
+		separator = "/"
+		if i := strings.Index(key, "_"); i >= 0 {
+			key = key[:i]
+		}
+	}
+	i := strings.LastIndex(key, separator)
+	if i <= 0 {
+		return "", ""
+	}
+	return key[:i], key[i+1:]
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPnpmLockDir(t *testing.T) {
+	workspaceDir := filepath.Join("..", "testdata", "npm", "pnpm-workspace")
+	lockDir, err := FindPnpmLockDir(filepath.Join(workspaceDir, "packages", "app"))
+	assert.NoError(t, err)
+	assert.Equal(t, workspaceDir, lockDir)
+
+	lockDir, err = FindPnpmLockDir(filepath.Join("..", "testdata", "npm", "project1"))
+	assert.NoError(t, err)
+	assert.Empty(t, lockDir)
+}
+
+func TestFindPnpmLockDirOfNestedProjects(t *testing.T) {
+	parentDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(parentDir, PnpmLockFileName), []byte("lockfileVersion: '6.0'\n"), 0644))
+
+	// The package-lock.json file of an npm project takes precedence over the pnpm-lock.yaml file of a parent directory.
+	npmProjectDir := filepath.Join(parentDir, "npm-project")
+	require.NoError(t, os.MkdirAll(npmProjectDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(npmProjectDir, NpmLockFileName), []byte("{}"), 0644))
+	lockDir, err := FindPnpmLockDir(npmProjectDir)
+	assert.NoError(t, err)
+	assert.Empty(t, lockDir)
+
+	// The search stops at the root of a pnpm workspace, which has no lock file yet.
+	workspaceDir := filepath.Join(parentDir, "workspace")
+	require.NoError(t, os.MkdirAll(filepath.Join(workspaceDir, "app"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(workspaceDir, PnpmWorkspaceFileName), []byte("packages:\n  - 'app'\n"), 0644))
+	lockDir, err = FindPnpmLockDir(filepath.Join(workspaceDir, "app"))
+	assert.NoError(t, err)
+	assert.Empty(t, lockDir)
+
+	// Projects without a lock file of their own use the lock file of the parent directory.
+	lockDir, err = FindPnpmLockDir(filepath.Join(parentDir, "workspace-less"))
+	assert.NoError(t, err)
+	assert.Equal(t, parentDir, lockDir)
+}
+
+func TestParsePnpmPackageKey(t *testing.T) {
+	testCases := []struct {
+		key            string
+		slashSeparated bool
+		name           string
+		version        string
+	}{
+		{"/ms/2.1.3", true, "ms", "2.1.3"},
+		{"/@babel/core/7.22.0_supports-color@8.1.1", true, "@babel/core", "7.22.0"},
+		{"/ms@2.1.3", false, "ms", "2.1.3"},
+		{"/@babel/core@7.22.0(supports-color@8.1.1)", false, "@babel/core", "7.22.0"},
+		{"@babel/core@7.22.0", false, "@babel/core", "7.22.0"},
+		{"ms", false, "", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.key, func(t *testing.T) {
+			name, version := parsePnpmPackageKey(testCase.key, testCase.slashSeparated)
+			assert.Equal(t, testCase.name, name)
+			assert.Equal(t, testCase.version, version)
+		})
+	}
+}
+
+func TestParsePnpmLsOutput(t *testing.T) {
+	workspaceDir := filepath.Join("..", "testdata", "npm", "pnpm-workspace")
+	integrities, err := readPnpmLockIntegrities(filepath.Join(workspaceDir, PnpmLockFileName))
+	require.NoError(t, err)
+	assert.Len(t, integrities, 4)
+
+	data, err := os.ReadFile(filepath.Join(workspaceDir, "pnpm-list.json"))
+	require.NoError(t, err)
+	// The paths in the output of 'pnpm list' are absolute.
+	dependenciesMap, err := parsePnpmLsOutput(data, "/tmp/pnpm-workspace", "pnpm-workspace-root:1.0.0", integrities, &utils.NullLog{})
+	require.NoError(t, err)
+
+	expected := map[string]struct {
+		scopes      []string
+		requestedBy [][]string
+		sha1        string
+	}{
+		"left-pad:1.3.0":    {[]string{"dev"}, [][]string{{"pnpm-workspace-root:1.0.0"}}, "5b8a3a7765dfe001261dde915589e782f8c94d1e"},
+		"app:0.1.0":         {[]string{"prod"}, [][]string{{"pnpm-workspace-root:1.0.0"}}, ""},
+		"@jfrog/util:0.2.0": {[]string{"prod"}, [][]string{{"pnpm-workspace-root:1.0.0"}, {"app:0.1.0", "pnpm-workspace-root:1.0.0"}}, ""},
+		"debug:4.3.4":       {[]string{"prod"}, [][]string{{"app:0.1.0", "pnpm-workspace-root:1.0.0"}}, ""},
+		"ms:2.1.2":          {[]string{"prod"}, [][]string{{"debug:4.3.4", "app:0.1.0", "pnpm-workspace-root:1.0.0"}}, ""},
+		"ms:2.1.3":          {[]string{"prod"}, [][]string{{"app:0.1.0", "pnpm-workspace-root:1.0.0"}, {"@jfrog/util:0.2.0", "pnpm-workspace-root:1.0.0"}}, "574c8138ce1d2b5861f214459cebe184144fa32a"},
+	}
+	assert.Len(t, dependenciesMap, len(expected))
+	for id, expectedDependency := range expected {
+		dependency, ok := dependenciesMap[id]
+		if !assert.True(t, ok, "dependency "+id+" is missing") {
+			continue
+		}
+		assert.Equal(t, expectedDependency.scopes, dependency.Scopes, id)
+		assert.ElementsMatch(t, expectedDependency.requestedBy, dependency.RequestedBy, id)
+		assert.Equal(t, expectedDependency.sha1, dependency.Sha1, id)
+	}
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadPropertiesFile reads the properties from a YAML file (with the '.yaml' or '.yml' extension), or from a properties file (with any other extension, such as '.props').
+// In a properties file, each line holds a property, as 'key=value' or 'key: value', and lines, which start with '#' or '!', are comments.
+// In a YAML file, the keys of nested mappings are joined by dots (for example, 'ci.job.id'), and lists are joined by commas.
+func ReadPropertiesFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return parseYamlProperties(content)
+	default:
+		return parsePropertiesFile(content)
+	}
+}
+
+func parsePropertiesFile(content []byte) (map[string]string, error) {
+	properties := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		separator := strings.IndexAny(line, "=:")
+		if separator <= 0 {
+			return nil, fmt.Errorf("line %d of the properties file isn't a 'key=value' property", lineNumber)
+		}
+		properties[strings.TrimSpace(line[:separator])] = strings.TrimSpace(line[separator+1:])
+	}
+	return properties, scanner.Err()
+}
+
+func parseYamlProperties(content []byte) (map[string]string, error) {
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, err
+	}
+	properties := make(map[string]string)
+	flattenYamlProperties("", values, properties)
+	return properties, nil
+}
+
+func flattenYamlProperties(prefix string, values map[string]interface{}, properties map[string]string) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch typedValue := value.(type) {
+		case map[string]interface{}:
+			flattenYamlProperties(key, typedValue, properties)
+		case []interface{}:
+			var items []string
+			for _, item := range typedValue {
+				items = append(items, fmt.Sprint(item))
+			}
+			properties[key] = strings.Join(items, ",")
+		case nil:
+			properties[key] = ""
+		default:
+			properties[key] = fmt.Sprint(typedValue)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPropertiesFile(t *testing.T) {
+	dir := t.TempDir()
+	propsPath := filepath.Join(dir, "build.props")
+	assert.NoError(t, os.WriteFile(propsPath, []byte("# The CI job\nci.job = release\n! A comment\n\nci.url: https://ci.example.com/job/1\nempty=\n"), 0600))
+	properties, err := ReadPropertiesFile(propsPath)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ci.job": "release", "ci.url": "https://ci.example.com/job/1", "empty": ""}, properties)
+
+	yamlPath := filepath.Join(dir, "build.yaml")
+	assert.NoError(t, os.WriteFile(yamlPath, []byte("ci:\n  job: release\n  attempt: 2\nteams: [platform, api]\n"), 0600))
+	properties, err = ReadPropertiesFile(yamlPath)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ci.job": "release", "ci.attempt": "2", "teams": "platform,api"}, properties)
+
+	invalidPath := filepath.Join(dir, "invalid.props")
+	assert.NoError(t, os.WriteFile(invalidPath, []byte("ci.job=release\nnot a property\n"), 0600))
+	_, err = ReadPropertiesFile(invalidPath)
+	assert.EqualError(t, err, "line 2 of the properties file isn't a 'key=value' property")
+}
@@ -0,0 +1,173 @@
+package utils
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	PubspecFileName     = "pubspec.yaml"
+	PubspecLockFileName = "pubspec.lock"
+
+	// The values of the 'dependency' field of packages in the pubspec.lock file.
+	PubDirectMainDependency = "direct main"
+	PubDirectDevDependency  = "direct dev"
+	// The sources of packages.
+	PubHostedSource = "hosted"
+	PubGitSource    = "git"
+	PubPathSource   = "path"
+)
+
+// Pubspec represents a pubspec.yaml file.
+type Pubspec struct {
+	Name    string `yaml:"name,omitempty"`
+	Version string `yaml:"version,omitempty"`
+	// The constraints of the dependencies, mapped by their names. Only the names are used.
+	Dependencies map[string]interface{} `yaml:"dependencies,omitempty"`
+}
+
+// GetDependenciesNames returns the sorted names of the dependencies of the pubspec.
+func (ps *Pubspec) GetDependenciesNames() []string {
+	names := make([]string, 0, len(ps.Dependencies))
+	for name := range ps.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PubPackage represents a package listed in a pubspec.lock file.
+type PubPackage struct {
+	// The name is set from the key of the package in the lock file.
+	Name        string                `yaml:"-"`
+	Dependency  string                `yaml:"dependency,omitempty"`
+	Description PubPackageDescription `yaml:"description,omitempty"`
+	Source      string                `yaml:"source,omitempty"`
+	Version     string                `yaml:"version,omitempty"`
+}
+
+func (pp *PubPackage) Id() string {
+	return pp.Name + ":" + pp.Version
+}
+
+// PubPackageDescription holds the fields of the description of a package, which depend on its source.
+type PubPackageDescription struct {
+	Name   string `yaml:"name,omitempty"`
+	Sha256 string `yaml:"sha256,omitempty"`
+	Url    string `yaml:"url,omitempty"`
+	// The path of the package, relative to the root of the git repository, or to the project for path packages.
+	Path        string `yaml:"path,omitempty"`
+	Ref         string `yaml:"ref,omitempty"`
+	ResolvedRef string `yaml:"resolved-ref,omitempty"`
+}
+
+// UnmarshalYAML decodes the description of a package. The description of sdk packages is just the name of the SDK, for example: 'flutter'.
+func (pd *PubPackageDescription) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		pd.Name = value.Value
+		return nil
+	}
+	type plainDescription PubPackageDescription
+	return value.Decode((*plainDescription)(pd))
+}
+
+type pubspecLock struct {
+	Packages map[string]PubPackage `yaml:"packages,omitempty"`
+}
+
+// ReadPubspec reads the pubspec.yaml file in the given directory.
+func ReadPubspec(srcPath string) (*Pubspec, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, PubspecFileName))
+	if err != nil {
+		return nil, err
+	}
+	pubspec := new(Pubspec)
+	return pubspec, yaml.Unmarshal(content, pubspec)
+}
+
+// ReadPubspecLock returns the packages listed in the pubspec.lock file in the given directory, sorted by their names.
+func ReadPubspecLock(srcPath string) ([]PubPackage, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, PubspecLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	var lock pubspecLock
+	if err = yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	packages := make([]PubPackage, 0, len(lock.Packages))
+	for name, pkg := range lock.Packages {
+		pkg.Name = name
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+// GetPubCachePath returns the path of the pub cache, where the hosted and git packages are downloaded to.
+func GetPubCachePath() (string, error) {
+	if cachePath := os.Getenv("PUB_CACHE"); cachePath != "" {
+		return cachePath, nil
+	}
+	if runtime.GOOS == "windows" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(userCacheDir, "Pub", "Cache"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".pub-cache"), nil
+}
+
+// GetPubPackageDir returns the directory of the package in the pub cache (or in the file system, for path packages).
+// An empty string is returned for sdk packages.
+func GetPubPackageDir(cachePath, srcPath string, pkg PubPackage) string {
+	description := pkg.Description
+	switch pkg.Source {
+	case PubHostedSource:
+		return filepath.Join(cachePath, PubHostedSource, getPubHostedDirName(description.Url), pkg.Name+"-"+pkg.Version)
+	case PubGitSource:
+		repositoryName := strings.TrimSuffix(path.Base(description.Url), ".git")
+		return filepath.Join(cachePath, PubGitSource, repositoryName+"-"+description.ResolvedRef, filepath.FromSlash(description.Path))
+	case PubPathSource:
+		if filepath.IsAbs(description.Path) {
+			return description.Path
+		}
+		return filepath.Join(srcPath, filepath.FromSlash(description.Path))
+	}
+	return ""
+}
+
+// Returns the name of the directory of the packages of a hosted repository in the pub cache, for example: 'pub.dev'.
+// Pub escapes the ':' and '/' characters in the URLs of other repositories.
+func getPubHostedDirName(repositoryUrl string) string {
+	parsedUrl, err := url.Parse(repositoryUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return "pub.dev"
+	}
+	dirName := parsedUrl.Host + strings.TrimSuffix(parsedUrl.Path, "/")
+	return strings.NewReplacer(":", "%58", "/", "%47").Replace(dirName)
+}
+
+// GetPubHostedHash returns the sha256 checksum of the archive of a hosted package, as stored in the pub cache, or an empty string if it's not found.
+func GetPubHostedHash(cachePath string, pkg PubPackage) (string, error) {
+	hashPath := filepath.Join(cachePath, "hosted-hashes", getPubHostedDirName(pkg.Description.Url), pkg.Name+"-"+pkg.Version+".sha256")
+	content, err := os.ReadFile(hashPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(content)), err
+}
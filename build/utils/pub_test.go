@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPubspecLock(t *testing.T) {
+	packages, err := ReadPubspecLock(filepath.Join("..", "testdata", "pub", "project"))
+	assert.NoError(t, err)
+	if !assert.Len(t, packages, 6) {
+		return
+	}
+	// The packages are sorted by their names.
+	assert.Equal(t, "async", packages[0].Name)
+	// The description of sdk packages is the name of the SDK.
+	flutter := packages[1]
+	assert.Equal(t, "sdk", flutter.Source)
+	assert.Equal(t, "flutter", flutter.Description.Name)
+	myUtils := packages[5]
+	assert.Equal(t, PubGitSource, myUtils.Source)
+	assert.Equal(t, "4f2a6b0c2d1e9f8a7b6c5d4e3f2a1b0c9d8e7f6a", myUtils.Description.ResolvedRef)
+	assert.Equal(t, filepath.Join("cache", "git", "my_utils-4f2a6b0c2d1e9f8a7b6c5d4e3f2a1b0c9d8e7f6a"), GetPubPackageDir("cache", "project", myUtils))
+}
+
+func TestGetPubHostedDirName(t *testing.T) {
+	testCases := map[string]string{
+		"https://pub.dev":                          "pub.dev",
+		"https://pub.dartlang.org":                 "pub.dartlang.org",
+		"https://my.host:8080/api/pub/pub-remote/": "my.host%588080%47api%47pub%47pub-remote",
+	}
+	for repositoryUrl, expected := range testCases {
+		assert.Equal(t, expected, getPubHostedDirName(repositoryUrl), repositoryUrl)
+	}
+}
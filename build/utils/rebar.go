@@ -0,0 +1,413 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	RebarConfigFileName = "rebar.config"
+	RebarLockFileName   = "rebar.lock"
+
+	// The sources of packages in the rebar.lock file.
+	RebarPkgSource = "pkg"
+	RebarGitSource = "git"
+)
+
+// RebarPackage represents a package listed in a rebar.lock file.
+type RebarPackage struct {
+	// The name of the application, which is the key of the package in the lock file.
+	Name   string
+	Source string
+	// The name of the package in the Hex repository, and its version.
+	PkgName string
+	Version string
+	// The sha256 checksum of the package tarball (the outer checksum), as listed under 'pkg_hash_ext' in the lock file.
+	OuterChecksum string
+	GitUrl        string
+	// The commit of git packages, which is listed as their 'ref'.
+	GitCommit string
+	// The depth of the package in the dependency tree. The dependencies listed in the rebar.config file of the project have depth 0.
+	Level int
+}
+
+// Id returns the ID of the package. The commit is used as the version of git packages.
+func (rp *RebarPackage) Id() string {
+	if rp.Source == RebarGitSource {
+		return rp.Name + ":" + rp.GitCommit
+	}
+	return rp.Name + ":" + rp.Version
+}
+
+// Erlang terms, as parsed from rebar3 files. Strings and binaries are parsed as Go strings.
+type erlangAtom string
+type erlangTuple []interface{}
+type erlangList []interface{}
+
+// ReadRebarLock returns the packages listed in the rebar.lock file in the given directory, sorted by their names.
+// The file consists of a tuple of the lock version and the packages, followed by a list of their checksums, for example:
+//
+//	{"1.2.0",
+//	[{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.10.0">>},0}]}.
+//	[
+//	{pkg_hash,[{<<"cowboy">>, <<"F3DC62E...">>}]},
+//	{pkg_hash_ext,[{<<"cowboy">>, <<"04FD8C6...">>}]}
+//	].
+//
+// Lock files created by old versions of rebar3 consist of the list of packages only.
+func ReadRebarLock(srcPath string) ([]RebarPackage, error) {
+	terms, err := readErlangTerms(filepath.Join(srcPath, RebarLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	lockedPackages, _ := terms[0].(erlangList)
+	if lock, ok := terms[0].(erlangTuple); ok && len(lock) == 2 {
+		lockedPackages, _ = lock[1].(erlangList)
+	}
+	outerChecksums := make(map[string]string)
+	if len(terms) > 1 {
+		for _, hashes := range getErlangProplist(terms[1]) {
+			if hashes.key == "pkg_hash_ext" {
+				for _, hash := range getErlangProplist(hashes.value) {
+					outerChecksums[hash.key] = strings.ToLower(getErlangString(hash.value))
+				}
+			}
+		}
+	}
+	var packages []RebarPackage
+	for _, lockedPackage := range lockedPackages {
+		// For example: '{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.10.0">>},0}'
+		entry, ok := lockedPackage.(erlangTuple)
+		if !ok || len(entry) != 3 {
+			continue
+		}
+		source, ok := entry[1].(erlangTuple)
+		if !ok || len(source) < 3 {
+			continue
+		}
+		pkg := RebarPackage{Name: getErlangString(entry[0]), Source: getErlangString(source[0])}
+		level, _ := entry[2].(int)
+		pkg.Level = level
+		switch pkg.Source {
+		case RebarPkgSource:
+			pkg.PkgName, pkg.Version = getErlangString(source[1]), getErlangString(source[2])
+			pkg.OuterChecksum = outerChecksums[pkg.Name]
+		case RebarGitSource:
+			// For example: '{git,"https://github.com/org/repo.git",{ref,"<commit>"}}'
+			pkg.GitUrl = getErlangString(source[1])
+			if ref, ok := source[2].(erlangTuple); ok && len(ref) == 2 {
+				pkg.GitCommit = getErlangString(ref[1])
+			}
+		default:
+			continue
+		}
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+// GetRebarApplicationNameAndVersion returns the name and the version of the application defined by the '.app.src' file in the 'src' directory of the project.
+// Empty strings are returned if the project has no '.app.src' file, such as in umbrella projects.
+func GetRebarApplicationNameAndVersion(srcPath string) (name, version string, err error) {
+	appSrcPaths, err := filepath.Glob(filepath.Join(srcPath, "src", "*.app.src"))
+	if err != nil || len(appSrcPaths) == 0 {
+		return
+	}
+	terms, err := readErlangTerms(appSrcPaths[0])
+	if err != nil || len(terms) == 0 {
+		return
+	}
+	// For example: '{application, myapp, [{vsn, "0.1.0"}, {applications, [kernel, stdlib]}]}.'
+	application, ok := terms[0].(erlangTuple)
+	if !ok || len(application) != 3 {
+		return
+	}
+	name = getErlangString(application[1])
+	for _, property := range getErlangProplist(application[2]) {
+		// The version may be an atom, such as 'git', which rebar3 replaces with the version from git when building the application.
+		if _, isString := property.value.(string); property.key == "vsn" && isString {
+			version = getErlangString(property.value)
+		}
+	}
+	return
+}
+
+// GetRebarPackageDependencies returns the names of the applications, which the package built in the given directory depends on.
+// The names are read from the 'hex_metadata.config' file, which rebar3 creates for Hex packages, or from the 'rebar.config' file of the package.
+func GetRebarPackageDependencies(packagePath string) ([]string, error) {
+	metadataPath := filepath.Join(packagePath, "hex_metadata.config")
+	exists, err := utils.IsFileExists(metadataPath, true)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		terms, err := readErlangTerms(metadataPath)
+		if err != nil {
+			return nil, err
+		}
+		var dependencies []string
+		for _, term := range terms {
+			// For example: '{<<"requirements">>,[{<<"cowlib">>,[{<<"app">>,<<"cowlib">>},{<<"requirement">>,<<"2.12.1">>}]}]}.'
+			if property, ok := term.(erlangTuple); ok && len(property) == 2 && getErlangString(property[0]) == "requirements" {
+				for _, requirement := range getErlangProplist(property[1]) {
+					name := requirement.key
+					for _, field := range getErlangProplist(requirement.value) {
+						if field.key == "app" {
+							name = getErlangString(field.value)
+						}
+					}
+					dependencies = append(dependencies, name)
+				}
+			}
+		}
+		return dependencies, nil
+	}
+	return GetRebarConfigDependencies(packagePath)
+}
+
+// GetRebarConfigDependencies returns the names of the dependencies listed under 'deps' in the rebar.config file in the given directory,
+// for example: 'cowboy' in '{deps, [{cowboy, "2.10.0"}]}.'. A nil slice is returned if the directory has no rebar.config file.
+func GetRebarConfigDependencies(srcPath string) ([]string, error) {
+	configPath := filepath.Join(srcPath, RebarConfigFileName)
+	exists, err := utils.IsFileExists(configPath, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	terms, err := readErlangTerms(configPath)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []string
+	for _, term := range terms {
+		property, ok := term.(erlangTuple)
+		if !ok || len(property) != 2 || getErlangString(property[0]) != "deps" {
+			continue
+		}
+		deps, _ := property[1].(erlangList)
+		for _, dep := range deps {
+			// A dependency may be an atom (for example: 'cowboy') or a tuple starting with its name.
+			if depTuple, ok := dep.(erlangTuple); ok && len(depTuple) > 0 {
+				dep = depTuple[0]
+			}
+			if name := getErlangString(dep); name != "" {
+				dependencies = append(dependencies, name)
+			}
+		}
+	}
+	return dependencies, nil
+}
+
+// GetRebarHexCachePath returns the path of the directory, where rebar3 caches the tarballs of the packages downloaded from the given Hex repository.
+// The cache directory can be set by the REBAR_CACHE_DIR environment variable.
+func GetRebarHexCachePath(repo string) (string, error) {
+	cacheDir := os.Getenv("REBAR_CACHE_DIR")
+	if cacheDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		cacheDir = filepath.Join(homeDir, ".cache", "rebar3")
+	}
+	return filepath.Join(cacheDir, "hex", repo, "packages"), nil
+}
+
+type erlangProperty struct {
+	key   string
+	value interface{}
+}
+
+// Returns the 2-tuples in a list (such as '[{vsn, "0.1.0"}]'), as pairs of their keys and values.
+func getErlangProplist(term interface{}) []erlangProperty {
+	list, _ := term.(erlangList)
+	var properties []erlangProperty
+	for _, element := range list {
+		if tuple, ok := element.(erlangTuple); ok && len(tuple) == 2 {
+			properties = append(properties, erlangProperty{key: getErlangString(tuple[0]), value: tuple[1]})
+		}
+	}
+	return properties
+}
+
+// Returns the value of a string, a binary or an atom.
+func getErlangString(term interface{}) string {
+	switch value := term.(type) {
+	case string:
+		return value
+	case erlangAtom:
+		return string(value)
+	}
+	return ""
+}
+
+// Reads the terms in a file of Erlang terms, such as rebar.config or rebar.lock. Each term ends with a dot.
+func readErlangTerms(path string) ([]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parser := &erlangParser{content: string(content)}
+	var terms []interface{}
+	for {
+		parser.skipWhitespaceAndComments()
+		if parser.done() {
+			return terms, nil
+		}
+		term, err := parser.parseTerm()
+		if err == nil {
+			err = parser.expect('.')
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %s: %s", path, err.Error())
+		}
+		terms = append(terms, term)
+	}
+}
+
+type erlangParser struct {
+	content  string
+	position int
+}
+
+func (ep *erlangParser) done() bool {
+	return ep.position >= len(ep.content)
+}
+
+func (ep *erlangParser) skipWhitespaceAndComments() {
+	for !ep.done() {
+		c := ep.content[ep.position]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			ep.position++
+		case c == '%':
+			if newLine := strings.IndexByte(ep.content[ep.position:], '\n'); newLine >= 0 {
+				ep.position += newLine + 1
+			} else {
+				ep.position = len(ep.content)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (ep *erlangParser) expect(c byte) error {
+	ep.skipWhitespaceAndComments()
+	if ep.done() || ep.content[ep.position] != c {
+		return fmt.Errorf("expected '%c' at offset %d", c, ep.position)
+	}
+	ep.position++
+	return nil
+}
+
+func (ep *erlangParser) parseTerm() (interface{}, error) {
+	ep.skipWhitespaceAndComments()
+	if ep.done() {
+		return nil, errors.New("unexpected end of file")
+	}
+	c := ep.content[ep.position]
+	switch {
+	case c == '{':
+		ep.position++
+		elements, err := ep.parseElements('}')
+		return erlangTuple(elements), err
+	case c == '[':
+		ep.position++
+		elements, err := ep.parseElements(']')
+		return erlangList(elements), err
+	case strings.HasPrefix(ep.content[ep.position:], "<<"):
+		// A binary, for example: '<<"cowboy">>'.
+		ep.position += 2
+		ep.skipWhitespaceAndComments()
+		value := ""
+		if !ep.done() && ep.content[ep.position] == '"' {
+			var err error
+			if value, err = ep.parseQuoted('"'); err != nil {
+				return nil, err
+			}
+		}
+		ep.skipWhitespaceAndComments()
+		if !strings.HasPrefix(ep.content[ep.position:], ">>") {
+			return nil, fmt.Errorf("unfinished binary at offset %d", ep.position)
+		}
+		ep.position += 2
+		return value, nil
+	case c == '"':
+		return ep.parseQuoted('"')
+	case c == '\'':
+		atom, err := ep.parseQuoted('\'')
+		return erlangAtom(atom), err
+	case c == '-' || unicode.IsDigit(rune(c)):
+		start := ep.position
+		ep.position++
+		for !ep.done() && (unicode.IsDigit(rune(ep.content[ep.position])) || ep.content[ep.position] == '.' && ep.position+1 < len(ep.content) && unicode.IsDigit(rune(ep.content[ep.position+1]))) {
+			ep.position++
+		}
+		// Floats are kept as strings.
+		if number, err := strconv.Atoi(ep.content[start:ep.position]); err == nil {
+			return number, nil
+		}
+		return ep.content[start:ep.position], nil
+	case unicode.IsLetter(rune(c)):
+		start := ep.position
+		for !ep.done() && (ep.content[ep.position] == '_' || ep.content[ep.position] == '@' || unicode.IsLetter(rune(ep.content[ep.position])) || unicode.IsDigit(rune(ep.content[ep.position]))) {
+			ep.position++
+		}
+		return erlangAtom(ep.content[start:ep.position]), nil
+	}
+	return nil, fmt.Errorf("unexpected character '%c' at offset %d", c, ep.position)
+}
+
+// Parses the comma-separated elements of a tuple or a list, after its opening bracket.
+func (ep *erlangParser) parseElements(closing byte) ([]interface{}, error) {
+	elements := []interface{}{}
+	ep.skipWhitespaceAndComments()
+	if !ep.done() && ep.content[ep.position] == closing {
+		ep.position++
+		return elements, nil
+	}
+	for {
+		element, err := ep.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+		ep.skipWhitespaceAndComments()
+		if !ep.done() && ep.content[ep.position] == ',' {
+			ep.position++
+			continue
+		}
+		return elements, ep.expect(closing)
+	}
+}
+
+// Parses a string or a quoted atom, starting at its opening quote.
+func (ep *erlangParser) parseQuoted(quote byte) (string, error) {
+	var value strings.Builder
+	for ep.position++; !ep.done(); ep.position++ {
+		c := ep.content[ep.position]
+		if c == quote {
+			ep.position++
+			return value.String(), nil
+		}
+		if c == '\\' && ep.position+1 < len(ep.content) {
+			ep.position++
+			c = ep.content[ep.position]
+		}
+		value.WriteByte(c)
+	}
+	return "", errors.New("unfinished string")
+}
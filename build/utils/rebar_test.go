@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRebarLock(t *testing.T) {
+	packages, err := ReadRebarLock(filepath.Join("..", "testdata", "rebar", "project"))
+	require.NoError(t, err)
+	require.Len(t, packages, 4)
+	assert.Equal(t, RebarPackage{Name: "cowboy", Source: RebarPkgSource, PkgName: "cowboy", Version: "2.10.0", OuterChecksum: "3afdccb7183cc6f143cb14d3cf51fa00e53db9ec80cdcd525482f5e99bc41d6b"}, packages[0])
+	assert.Equal(t, 1, packages[1].Level)
+	assert.Equal(t, RebarPackage{Name: "mylib", Source: RebarGitSource, GitUrl: "https://github.com/example/mylib.git", GitCommit: "0123456789abcdef0123456789abcdef01234567"}, packages[2])
+	assert.Equal(t, "mylib:0123456789abcdef0123456789abcdef01234567", packages[2].Id())
+}
+
+func TestReadRebarLockLegacyFormat(t *testing.T) {
+	// Lock files created by old versions of rebar3 list the packages only.
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, RebarLockFileName), []byte(`[{<<"jsx">>,{pkg,<<"jsx">>,<<"3.1.0">>},0}].`+"\n"), 0644))
+	packages, err := ReadRebarLock(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, []RebarPackage{{Name: "jsx", Source: RebarPkgSource, PkgName: "jsx", Version: "3.1.0"}}, packages)
+}
+
+func TestGetRebarApplicationNameAndVersion(t *testing.T) {
+	name, version, err := GetRebarApplicationNameAndVersion(filepath.Join("..", "testdata", "rebar", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", name)
+	assert.Equal(t, "0.1.0", version)
+}
+
+func TestGetRebarPackageDependencies(t *testing.T) {
+	libPath := filepath.Join("..", "testdata", "rebar", "project", "_build", "default", "lib")
+	// Read from the hex_metadata.config file.
+	dependencies, err := GetRebarPackageDependencies(filepath.Join(libPath, "cowboy"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cowlib", "ranch"}, dependencies)
+	// Read from the rebar.config file.
+	dependencies, err = GetRebarPackageDependencies(filepath.Join(libPath, "mylib"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cowlib"}, dependencies)
+	// The deps of the project, including the git dependency.
+	dependencies, err = GetRebarConfigDependencies(filepath.Join("..", "testdata", "rebar", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cowboy", "mylib"}, dependencies)
+}
+
+func TestReadErlangTerms(t *testing.T) {
+	tempDir := t.TempDir()
+	termsPath := filepath.Join(tempDir, "terms.config")
+	require.NoError(t, os.WriteFile(termsPath, []byte(`% A comment.
+{key, 'quoted atom', "a \"string\"", <<>>, [], -12}.
+[1.5, node@host].
+`), 0644))
+	terms, err := readErlangTerms(termsPath)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		erlangTuple{erlangAtom("key"), erlangAtom("quoted atom"), `a "string"`, "", erlangList{}, -12},
+		erlangList{"1.5", erlangAtom("node@host")},
+	}, terms)
+
+	require.NoError(t, os.WriteFile(termsPath, []byte(`{unfinished, [1, 2}.`), 0644))
+	_, err = readErlangTerms(termsPath)
+	assert.Error(t, err)
+}
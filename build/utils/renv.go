@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	RenvLockFileName = "renv.lock"
+	// The description file of an R package, which defines its name and version.
+	RDescriptionFileName = "DESCRIPTION"
+)
+
+// RenvPackage represents a package listed in a renv.lock file.
+type RenvPackage struct {
+	Name    string
+	Version string
+	// The source of the package, for example: 'Repository', 'Bioconductor' or 'GitHub'.
+	Source string
+	// The name of the repository, from which the package was installed (for example: 'CRAN'), and its URL.
+	Repository    string
+	RepositoryUrl string
+	// The hash, which renv calculates from the DESCRIPTION file of the package.
+	Hash string
+	// The git repository and commit of a package installed from Bioconductor or from a remote (such as GitHub).
+	GitUrl    string
+	GitCommit string
+	// The names of the packages this package requires. Packages, which aren't listed in the lock file (such as 'R' or base packages), are excluded.
+	Requirements []string
+}
+
+func (rp *RenvPackage) Id() string {
+	return rp.Name + ":" + rp.Version
+}
+
+type renvLockFile struct {
+	R struct {
+		Repositories []struct {
+			Name string `json:"Name"`
+			Url  string `json:"URL"`
+		} `json:"Repositories"`
+	} `json:"R"`
+	Packages map[string]renvLockedPackage `json:"Packages"`
+}
+
+type renvLockedPackage struct {
+	Version        string   `json:"Version"`
+	Source         string   `json:"Source"`
+	Repository     string   `json:"Repository"`
+	Hash           string   `json:"Hash"`
+	Requirements   []string `json:"Requirements"`
+	GitUrl         string   `json:"git_url"`
+	GitLastCommit  string   `json:"git_last_commit"`
+	RemoteType     string   `json:"RemoteType"`
+	RemoteHost     string   `json:"RemoteHost"`
+	RemoteUsername string   `json:"RemoteUsername"`
+	RemoteRepo     string   `json:"RemoteRepo"`
+	RemoteUrl      string   `json:"RemoteUrl"`
+	RemoteSha      string   `json:"RemoteSha"`
+}
+
+// Returns the URL of the git repository of a package installed from a remote.
+func (rlp *renvLockedPackage) getRemoteGitUrl() string {
+	switch strings.ToLower(rlp.RemoteType) {
+	case "github", "gitlab", "bitbucket":
+		host := rlp.RemoteHost
+		switch host {
+		case "", "api.github.com":
+			host = "github.com"
+		case "api.bitbucket.org":
+			host = "bitbucket.org"
+		}
+		return "https://" + host + "/" + rlp.RemoteUsername + "/" + rlp.RemoteRepo
+	case "git", "git2r":
+		return rlp.RemoteUrl
+	}
+	return ""
+}
+
+// ReadRenvLock returns the packages listed in the renv.lock file in the given directory, sorted by their names.
+func ReadRenvLock(srcPath string) ([]RenvPackage, error) {
+	var lockFile renvLockFile
+	if err := utils.Unmarshal(filepath.Join(srcPath, RenvLockFileName), &lockFile); err != nil {
+		return nil, err
+	}
+	repositoryUrls := make(map[string]string)
+	for _, repository := range lockFile.R.Repositories {
+		repositoryUrls[repository.Name] = repository.Url
+	}
+	var packages []RenvPackage
+	for name, lockedPackage := range lockFile.Packages {
+		pkg := RenvPackage{
+			Name:          name,
+			Version:       lockedPackage.Version,
+			Source:        lockedPackage.Source,
+			Repository:    lockedPackage.Repository,
+			RepositoryUrl: repositoryUrls[lockedPackage.Repository],
+			Hash:          lockedPackage.Hash,
+			GitUrl:        lockedPackage.GitUrl,
+			GitCommit:     lockedPackage.GitLastCommit,
+		}
+		if remoteGitUrl := lockedPackage.getRemoteGitUrl(); remoteGitUrl != "" {
+			pkg.GitUrl, pkg.GitCommit = remoteGitUrl, lockedPackage.RemoteSha
+		}
+		for _, requirement := range lockedPackage.Requirements {
+			if _, ok := lockFile.Packages[requirement]; ok {
+				pkg.Requirements = append(pkg.Requirements, requirement)
+			}
+		}
+		sort.Strings(pkg.Requirements)
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+// GetRPackageNameAndVersion returns the name and version of the R package, which is defined by the DESCRIPTION file in the given directory.
+// Empty strings are returned if the directory doesn't contain a DESCRIPTION file, as in projects, which aren't packages.
+func GetRPackageNameAndVersion(srcPath string) (name, version string, err error) {
+	descriptionPath := filepath.Join(srcPath, RDescriptionFileName)
+	exists, err := utils.IsFileExists(descriptionPath, true)
+	if err != nil || !exists {
+		return
+	}
+	content, err := os.ReadFile(descriptionPath)
+	if err != nil {
+		return
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		field, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		switch field {
+		case "Package":
+			name = strings.TrimSpace(value)
+		case "Version":
+			version = strings.TrimSpace(value)
+		}
+	}
+	err = scanner.Err()
+	return
+}
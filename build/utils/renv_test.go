@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRenvLock(t *testing.T) {
+	packages, err := ReadRenvLock(filepath.Join("..", "testdata", "r", "project"))
+	require.NoError(t, err)
+	require.Len(t, packages, 6)
+	assert.Equal(t, "BiocGenerics", packages[0].Name)
+	// The base packages, which aren't listed in the lock file, are excluded from the requirements.
+	assert.Empty(t, packages[0].Requirements)
+	assert.Equal(t, RenvPackage{
+		Name:          "lifecycle",
+		Version:       "1.0.3",
+		Source:        "Repository",
+		Repository:    "CRAN",
+		RepositoryUrl: "https://cloud.r-project.org",
+		Hash:          "001cecbeac1cff9301bdc3775ee46a86",
+		Requirements:  []string{"cli", "glue", "rlang"},
+	}, packages[3])
+	assert.Equal(t, "https://github.com/r-lib/rlang", packages[5].GitUrl)
+	assert.Equal(t, "1a6c5bf8bd5796fc1e0e8be8e1e04a2c0a5a8a86", packages[5].GitCommit)
+}
+
+func TestGetRPackageNameAndVersion(t *testing.T) {
+	name, version, err := GetRPackageNameAndVersion(filepath.Join("..", "testdata", "r", "package"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", name)
+	assert.Equal(t, "0.1.0", version)
+
+	// A project without a DESCRIPTION file.
+	name, version, err = GetRPackageNameAndVersion(filepath.Join("..", "testdata", "r", "project"))
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+	assert.Empty(t, version)
+}
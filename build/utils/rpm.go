@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The value rpm prints for tags, which the header of a package doesn't hold.
+	rpmNoneValue = "(none)"
+	// The fields of each package are separated by tabs, and the elements of the requires and provides lists by commas.
+	rpmQueryFormat      = `%{NAME}\t%{EPOCH}\t%{VERSION}\t%{RELEASE}\t%{ARCH}\t%{SHA256HEADER}\t%{SIGMD5}\t%{SOURCERPM}\t%{LICENSE}\t%{VENDOR}\t[%{REQUIRENAME},]\t[%{PROVIDENAME},]\n`
+	rpmQueryFieldsCount = 12
+)
+
+// RpmPackage represents an RPM package installed on a system, as listed in its rpmdb.
+type RpmPackage struct {
+	Name    string
+	Epoch   string
+	Version string
+	Release string
+	Arch    string
+	// The sha256 digest of the header of the package, and the md5 digest of its header and payload, as recorded when the package was installed.
+	Sha256Header string
+	SigMd5       string
+	// The source RPM, from which the package was built, for example: 'openssl-3.0.7-24.el9.src.rpm'.
+	SourceRpm string
+	License   string
+	Vendor    string
+	// The capabilities (such as package names, libraries or files), which the package requires and provides.
+	Requires []string
+	Provides []string
+}
+
+// Id returns the ID of the package, in which the version is prefixed with the epoch of the package (if it has one), for example: 'openssl-libs:1:3.0.7-24.el9'.
+func (rp *RpmPackage) Id() string {
+	version := rp.Version + "-" + rp.Release
+	if rp.Epoch != "" {
+		version = rp.Epoch + ":" + version
+	}
+	return rp.Name + ":" + version
+}
+
+// RunRpmQuery returns the packages installed on the system in the given root directory, as listed in its rpmdb by 'rpm -qa'.
+func RunRpmQuery(rootDir string) ([]RpmPackage, error) {
+	args := []string{"-a", "--queryformat", rpmQueryFormat}
+	if rootDir != "" && rootDir != "/" {
+		args = append(args, "--root", rootDir)
+	}
+	output, err := utils.NewCommand("rpm", "-q", args).RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed running 'rpm -qa': %s", err.Error())
+	}
+	return ParseRpmQuery(output)
+}
+
+// ParseRpmQuery parses the output of 'rpm -qa' with the query format of RunRpmQuery, and returns the packages sorted by their names.
+// Public keys imported into the rpmdb are listed as gpg-pubkey packages, which aren't installed packages, so they're excluded.
+func ParseRpmQuery(output []byte) ([]RpmPackage, error) {
+	var packages []RpmPackage
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != rpmQueryFieldsCount {
+			return nil, fmt.Errorf("unexpected output of 'rpm -qa': %s", line)
+		}
+		for i, field := range fields {
+			if field == rpmNoneValue {
+				fields[i] = ""
+			}
+		}
+		if fields[0] == "gpg-pubkey" {
+			continue
+		}
+		packages = append(packages, RpmPackage{
+			Name:         fields[0],
+			Epoch:        fields[1],
+			Version:      fields[2],
+			Release:      fields[3],
+			Arch:         fields[4],
+			Sha256Header: fields[5],
+			SigMd5:       fields[6],
+			SourceRpm:    fields[7],
+			License:      fields[8],
+			Vendor:       fields[9],
+			Requires:     parseRpmCapabilities(fields[10]),
+			Provides:     parseRpmCapabilities(fields[11]),
+		})
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+// Parses a comma-separated list of capabilities.
+// The capabilities of rpm itself (such as 'rpmlib(PayloadIsZstd)') and rich dependencies (such as '(glibc-langpack-en or glibc-all-langpacks)') can't be resolved to a single package, so they're excluded.
+func parseRpmCapabilities(capabilities string) []string {
+	var parsed []string
+	for _, capability := range strings.Split(capabilities, ",") {
+		if capability == "" || strings.HasPrefix(capability, "(") || strings.HasPrefix(capability, "rpmlib(") {
+			continue
+		}
+		parsed = append(parsed, capability)
+	}
+	return parsed
+}
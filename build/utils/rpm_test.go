@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRpmQuery(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("..", "testdata", "rpm", "rpm-qa.txt"))
+	require.NoError(t, err)
+	packages, err := ParseRpmQuery(output)
+	require.NoError(t, err)
+	// The imported public key is excluded.
+	require.Len(t, packages, 8)
+	assert.Equal(t, "bash:5.1.8-6.el9_1", packages[1].Id())
+	assert.Equal(t, []string{"/bin/sh", "config(bash)", "filesystem", "libc.so.6()(64bit)", "libtinfo.so.6()(64bit)"}, packages[1].Requires)
+	// The rich dependency is excluded.
+	assert.Equal(t, "glibc", packages[3].Name)
+	assert.Equal(t, []string{"/bin/sh", "basesystem", "glibc-common", "libc.so.6()(64bit)"}, packages[3].Requires)
+	assert.Equal(t, RpmPackage{
+		Name:         "openssl-libs",
+		Epoch:        "1",
+		Version:      "3.0.7",
+		Release:      "24.el9",
+		Arch:         "x86_64",
+		Sha256Header: "6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d",
+		SigMd5:       "1a2b3c4d5e6f70718293a4b5c6d7e8f9",
+		SourceRpm:    "openssl-3.0.7-24.el9.src.rpm",
+		License:      "ASL 2.0",
+		Vendor:       "Red Hat, Inc.",
+		Requires:     []string{"libc.so.6()(64bit)"},
+		Provides:     []string{"libcrypto.so.3()(64bit)", "libssl.so.3()(64bit)", "openssl-libs"},
+	}, packages[6])
+	assert.Equal(t, "openssl-libs:1:3.0.7-24.el9", packages[6].Id())
+
+	_, err = ParseRpmQuery([]byte("bash\t5.1.8\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const GemfileLockFileName = "Gemfile.lock"
+
+// GemSpec represents a gem listed under the 'specs' of a source section (GEM, GIT or PATH) in a Gemfile.lock file.
+type GemSpec struct {
+	Name string
+	// The version of the gem, which may be suffixed with a platform, for example: '1.15.4-x86_64-linux'.
+	Version string
+	// The type of the source section, in which the gem is listed.
+	SourceType string
+	// The 'remote' of the source section.
+	Remote string
+	// The names of the direct dependencies of the gem.
+	Dependencies []string
+}
+
+func (gs *GemSpec) Id() string {
+	return gs.Name + ":" + gs.Version
+}
+
+// GemfileLock holds the content of a Gemfile.lock file.
+type GemfileLock struct {
+	Specs []GemSpec
+	// The names of the gems listed in the DEPENDENCIES section of the Gemfile.lock file, which are the direct dependencies of the project.
+	Dependencies []string
+	// The sha256 checksums of the gems, as listed in the CHECKSUMS section (added in Bundler 2.5), mapped by their IDs.
+	Checksums map[string]string
+}
+
+// ReadGemfileLock parses the Gemfile.lock file in the given directory.
+func ReadGemfileLock(srcPath string) (lock *GemfileLock, err error) {
+	file, err := os.Open(filepath.Join(srcPath, GemfileLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		e := file.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	lock = &GemfileLock{Checksums: make(map[string]string)}
+	var section, remote string
+	// The index of the last spec read, to which its dependencies are added.
+	currentSpec := -1
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indentation := len(line) - len(strings.TrimLeft(line, " "))
+		content := strings.TrimSpace(line)
+		if indentation == 0 {
+			section, remote = content, ""
+			currentSpec = -1
+			continue
+		}
+		switch section {
+		case "GEM", "GIT", "PATH":
+			switch {
+			case indentation == 2 && strings.HasPrefix(content, "remote:"):
+				remote = strings.TrimSpace(strings.TrimPrefix(content, "remote:"))
+			case indentation == 4:
+				name, version := parseNameAndVersionEntry(content)
+				lock.Specs = append(lock.Specs, GemSpec{Name: name, Version: version, SourceType: section, Remote: remote})
+				currentSpec = len(lock.Specs) - 1
+			case indentation == 6 && currentSpec >= 0:
+				name, _ := parseNameAndVersionEntry(content)
+				lock.Specs[currentSpec].Dependencies = append(lock.Specs[currentSpec].Dependencies, name)
+			}
+		case "DEPENDENCIES":
+			name, _ := parseNameAndVersionEntry(content)
+			lock.Dependencies = append(lock.Dependencies, strings.TrimSuffix(name, "!"))
+		case "CHECKSUMS":
+			// For example: 'rack (3.0.8) sha256=b9a4b0...'
+			entry, checksum, found := strings.Cut(content, " sha256=")
+			if found {
+				name, version := parseNameAndVersionEntry(entry)
+				lock.Checksums[name+":"+version] = checksum
+			}
+		}
+	}
+	return lock, scanner.Err()
+}
+
+// Parses an entry in the 'name (version)' format. The version (or the version constraints) is optional.
+func parseNameAndVersionEntry(entry string) (name, version string) {
+	name, version, _ = strings.Cut(entry, " ")
+	version = strings.TrimSuffix(strings.TrimPrefix(version, "("), ")")
+	return
+}
+
+// GetGemCachePaths returns the directories, in which the gems installed by Bundler are cached.
+// These are the 'vendor/cache' directory of the project (created by 'bundle cache'), and the 'cache' directories of the gem paths.
+// The gem paths are the project's 'vendor/bundle' directory, and the paths taken from the GEM_HOME and GEM_PATH environment variables,
+// or from 'gem env gempath' if they aren't set.
+func GetGemCachePaths(srcPath string, log utils.Log) []string {
+	cachePaths := []string{filepath.Join(srcPath, "vendor", "cache")}
+	// Gems installed with 'bundle config set path vendor/bundle' are located under a directory per Ruby version.
+	gemPaths, err := filepath.Glob(filepath.Join(srcPath, "vendor", "bundle", "ruby", "*"))
+	if err != nil {
+		log.Debug("Couldn't search the vendor/bundle directory:", err.Error())
+	}
+	vendoredPathsCount := len(gemPaths)
+	if gemHome := os.Getenv("GEM_HOME"); gemHome != "" {
+		gemPaths = append(gemPaths, gemHome)
+	}
+	if gemPath := os.Getenv("GEM_PATH"); gemPath != "" {
+		gemPaths = append(gemPaths, filepath.SplitList(gemPath)...)
+	}
+	if len(gemPaths) == vendoredPathsCount {
+		output, err := exec.Command("gem", "env", "gempath").Output()
+		if err != nil {
+			log.Debug("Couldn't get the gem paths using 'gem env gempath':", err.Error())
+		} else {
+			gemPaths = append(gemPaths, filepath.SplitList(strings.TrimSpace(string(output)))...)
+		}
+	}
+	for _, gemPath := range gemPaths {
+		cachePaths = append(cachePaths, filepath.Join(gemPath, "cache"))
+	}
+	return cachePaths
+}
+
+// FindCachedGem returns the path of the .gem file of a gem in one of the cache directories, or an empty string if it's not found.
+func FindCachedGem(cachePaths []string, spec GemSpec) (string, error) {
+	for _, cachePath := range cachePaths {
+		gemPath := filepath.Join(cachePath, spec.Name+"-"+spec.Version+".gem")
+		exists, err := utils.IsFileExists(gemPath, true)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return gemPath, nil
+		}
+	}
+	return "", nil
+}
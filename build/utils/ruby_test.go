@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadGemfileLock(t *testing.T) {
+	lock, err := ReadGemfileLock(filepath.Join("..", "testdata", "ruby", "project"))
+	assert.NoError(t, err)
+	assert.Len(t, lock.Specs, 6)
+	assert.Equal(t, GemSpec{Name: "ruby-project", Version: "1.0.0", SourceType: "PATH", Remote: ".", Dependencies: []string{"nokogiri"}}, lock.Specs[0])
+	assert.Equal(t, GemSpec{Name: "nokogiri", Version: "1.15.4-x86_64-linux", SourceType: "GEM", Remote: "https://rubygems.org/", Dependencies: []string{"racc"}}, lock.Specs[3])
+	assert.Equal(t, []string{"minitest", "rack", "ruby-project"}, lock.Dependencies)
+	assert.Len(t, lock.Checksums, 5)
+	assert.Equal(t, "af64124836fdd3c00e830703d7f873ea5deabde923f37006a39f5a5e0da16387", lock.Checksums["racc:1.7.1"])
+}
+
+func TestGetGemCachePathsWithVendoredGems(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The fake gem command is a shell script.")
+	}
+	srcPath := t.TempDir()
+	vendoredPath := filepath.Join(srcPath, "vendor", "bundle", "ruby", "3.2.0")
+	assert.NoError(t, os.MkdirAll(vendoredPath, 0755))
+
+	// Without GEM_HOME and GEM_PATH, the paths returned by 'gem env gempath' are added to the vendored paths.
+	gemHome, err := filepath.Abs(filepath.Join("..", "testdata", "ruby", "gemhome"))
+	assert.NoError(t, err)
+	binDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(binDir, "gem"), []byte("#!/bin/sh\necho "+gemHome+"\n"), 0755))
+	t.Setenv("PATH", binDir)
+	t.Setenv("GEM_HOME", "")
+	t.Setenv("GEM_PATH", "")
+
+	cachePaths := GetGemCachePaths(srcPath, logger)
+	assert.Equal(t, []string{filepath.Join(srcPath, "vendor", "cache"), filepath.Join(vendoredPath, "cache"), filepath.Join(gemHome, "cache")}, cachePaths)
+}
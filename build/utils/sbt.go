@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	SbtBuildFileName = "build.sbt"
+	// The maximum number of directories in the repository part of the paths in the Coursier cache, for example: 'https/repo1.maven.org/maven2'.
+	coursierMaxRepositoryPathDepth = 6
+)
+
+var (
+	sbtOrganizationRegExp = regexp.MustCompile(`\borganization\s*:=\s*"([^"]+)"`)
+	sbtNameRegExp         = regexp.MustCompile(`\bname\s*:=\s*"([^"]+)"`)
+	sbtVersionRegExp      = regexp.MustCompile(`\bversion\s*:=\s*"([^"]+)"`)
+	// For example: 'org.typelevel:cats-core_2.13:2.9.0 [S]'
+	sbtDependencyRegExp = regexp.MustCompile(`^([\w.\-]+:[\w.\-]+:[\w.\-+]+)(.*)$`)
+	sbtLogPrefixRegExp  = regexp.MustCompile(`^\[\w+\] ?`)
+)
+
+// SbtDependency represents a node in the output of 'sbt dependencyTree'.
+type SbtDependency struct {
+	// The ID of the dependency, in the format of 'organization:name:revision'.
+	Id           string
+	Dependencies []*SbtDependency
+}
+
+// GetSbtProjectId returns the ID of the project, according to the organization, name and version settings in its build.sbt file.
+// The ID is in the format of 'organization:name:version'. The organization and version are omitted if they aren't set. An empty string is returned if no name is set.
+func GetSbtProjectId(srcPath string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, SbtBuildFileName))
+	if err != nil {
+		return "", err
+	}
+	match := sbtNameRegExp.FindSubmatch(content)
+	if match == nil {
+		return "", nil
+	}
+	id := string(match[1])
+	if organizationMatch := sbtOrganizationRegExp.FindSubmatch(content); organizationMatch != nil {
+		id = string(organizationMatch[1]) + ":" + id
+	}
+	if versionMatch := sbtVersionRegExp.FindSubmatch(content); versionMatch != nil {
+		id += ":" + string(versionMatch[1])
+	}
+	return id, nil
+}
+
+// RunSbtDependencyTree runs 'sbt dependencyTree' and returns the root of the tree of each project.
+// If project is not empty, only the tree of that project is returned.
+func RunSbtDependencyTree(srcPath, project string) ([]*SbtDependency, error) {
+	task := "dependencyTree"
+	if project != "" {
+		task = project + "/" + task
+	}
+	command := utils.NewCommand("sbt", "-batch", []string{"-no-colors", task})
+	command.Dir = srcPath
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	return ParseSbtDependencyTree(output)
+}
+
+// ParseSbtDependencyTree parses the output of 'sbt dependencyTree'.
+// Evicted dependencies are skipped, because they were replaced by other versions of the same modules.
+func ParseSbtDependencyTree(output []byte) ([]*SbtDependency, error) {
+	var roots []*SbtDependency
+	// The last dependency in each depth of the tree.
+	var parents []*SbtDependency
+	// The depth of the last evicted dependency, or -1 if not inside its subtree.
+	evictedDepth := -1
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := sbtLogPrefixRegExp.ReplaceAllString(scanner.Text(), "")
+		depth := 0
+		if i := strings.Index(line, "+-"); i >= 0 {
+			depth = i / 2
+			line = line[i+len("+-"):]
+		}
+		match := sbtDependencyRegExp.FindStringSubmatch(line)
+		if match == nil || (depth > 0 && len(parents) < depth) {
+			continue
+		}
+		if evictedDepth >= 0 {
+			if depth > evictedDepth {
+				continue
+			}
+			evictedDepth = -1
+		}
+		if strings.Contains(match[2], "(evicted by") {
+			evictedDepth = depth
+			continue
+		}
+		dependency := &SbtDependency{Id: match[1]}
+		if depth == 0 {
+			roots = append(roots, dependency)
+		} else {
+			parent := parents[depth-1]
+			parent.Dependencies = append(parent.Dependencies, dependency)
+		}
+		parents = append(parents[:depth], dependency)
+	}
+	return roots, scanner.Err()
+}
+
+// GetCoursierCachePath returns the path of the Coursier cache, which is used by sbt to download the dependencies.
+func GetCoursierCachePath() (string, error) {
+	if cachePath := os.Getenv("COURSIER_CACHE"); cachePath != "" {
+		return cachePath, nil
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(userCacheDir, "Coursier", "v1"), nil
+	case "windows":
+		return filepath.Join(userCacheDir, "Coursier", "Cache", "v1"), nil
+	default:
+		return filepath.Join(userCacheDir, "coursier", "v1"), nil
+	}
+}
+
+// FindCoursierJar returns the path of the jar of a dependency in the Coursier cache, or an empty string if it's not found.
+// The cache holds the files in their repositories' layout, under a directory per repository URL, so all of them are searched.
+func FindCoursierJar(cachePath, organization, name, revision string) (string, error) {
+	jarPath := path.Join(strings.ReplaceAll(organization, ".", "/"), name, revision, name+"-"+revision+".jar")
+	repositoryPattern := ""
+	for i := 0; i < coursierMaxRepositoryPathDepth; i++ {
+		repositoryPattern = path.Join(repositoryPattern, "*")
+		matches, err := filepath.Glob(filepath.Join(cachePath, filepath.FromSlash(repositoryPattern), filepath.FromSlash(jarPath)))
+		if err != nil {
+			return "", err
+		}
+		if len(matches) > 0 {
+			return matches[0], nil
+		}
+	}
+	return "", nil
+}
+
+// FindIvyJar returns the path of the jar of a dependency in the Ivy cache (~/.ivy2/cache), which is used by older sbt versions, or an empty string if it's not found.
+func FindIvyJar(organization, name, revision string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	jarPath := filepath.Join(homeDir, ".ivy2", "cache", organization, name, "jars", name+"-"+revision+".jar")
+	exists, err := utils.IsFileExists(jarPath, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	return jarPath, nil
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSbtDependencyTree(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join("..", "testdata", "sbt", "dependency-tree.txt"))
+	assert.NoError(t, err)
+	roots, err := ParseSbtDependencyTree(output)
+	assert.NoError(t, err)
+	if !assert.Len(t, roots, 1) {
+		return
+	}
+	root := roots[0]
+	assert.Equal(t, "com.example:hello_2.13:0.1.0", root.Id)
+	var ids []string
+	for _, dependency := range root.Dependencies {
+		ids = append(ids, dependency.Id)
+	}
+	assert.Equal(t, []string{"com.typesafe:config:1.4.2", "org.typelevel:cats-core_2.13:2.9.0", "org.scala-lang:scala-library:2.13.12"}, ids)
+
+	// The evicted version of scala-library is skipped.
+	catsCore := root.Dependencies[1]
+	if assert.Len(t, catsCore.Dependencies, 2) {
+		assert.Equal(t, "org.scala-lang:scala-library:2.13.12", catsCore.Dependencies[0].Id)
+		assert.Equal(t, "org.typelevel:cats-kernel_2.13:2.9.0", catsCore.Dependencies[1].Id)
+		assert.Len(t, catsCore.Dependencies[1].Dependencies, 1)
+	}
+}
+
+func TestFindCoursierJar(t *testing.T) {
+	cachePath := filepath.Join("..", "testdata", "sbt", "coursier")
+	jarPath, err := FindCoursierJar(cachePath, "com.typesafe", "config", "1.4.2")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cachePath, "https", "repo1.maven.org", "maven2", "com", "typesafe", "config", "1.4.2", "config-1.4.2.jar"), jarPath)
+
+	jarPath, err = FindCoursierJar(cachePath, "org.typelevel", "cats-core_2.13", "2.9.0")
+	assert.NoError(t, err)
+	assert.Empty(t, jarPath)
+}
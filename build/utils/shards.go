@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ShardFileName     = "shard.yml"
+	ShardLockFileName = "shard.lock"
+	// The directory, to which shards installs the dependencies of the project.
+	shardsLibDir = "lib"
+)
+
+// The commit in the versions of shards, which are locked to commits rather than to releases, for example: '0.3.1+git.commit.<commit>'.
+var shardCommitVersionRegExp = regexp.MustCompile(`\+git\.commit\.([0-9a-f]+)$`)
+
+// Shard holds the details of a shard, as listed in a shard.yml file.
+type Shard struct {
+	Name    string `yaml:"name,omitempty"`
+	Version string `yaml:"version,omitempty"`
+	// The requirements of the dependencies, mapped by their names. Only the names are used.
+	Dependencies            map[string]interface{} `yaml:"dependencies,omitempty"`
+	DevelopmentDependencies map[string]interface{} `yaml:"development_dependencies,omitempty"`
+}
+
+// LockedShard represents a shard listed in a shard.lock file.
+type LockedShard struct {
+	// The name is set from the key of the shard in the lock file.
+	Name string `yaml:"-"`
+	// The source of the shard. Only one of them is set.
+	Git       string `yaml:"git,omitempty"`
+	Github    string `yaml:"github,omitempty"`
+	Gitlab    string `yaml:"gitlab,omitempty"`
+	Bitbucket string `yaml:"bitbucket,omitempty"`
+	Hg        string `yaml:"hg,omitempty"`
+	Fossil    string `yaml:"fossil,omitempty"`
+	Path      string `yaml:"path,omitempty"`
+	Version   string `yaml:"version,omitempty"`
+	// The commit of the shard, which is listed in lock files of version 1.0 only.
+	Commit string `yaml:"commit,omitempty"`
+}
+
+// Id returns the ID of the shard. The commit is used as the version, if the shard is locked to a commit rather than to a release.
+func (ls *LockedShard) Id() string {
+	version := ls.Version
+	if version == "" {
+		version = ls.Commit
+	}
+	return ls.Name + ":" + version
+}
+
+// GetSourceUrl returns the URL of the repository of the shard (or its path, for path shards).
+// The URLs of shards hosted on GitHub, GitLab and Bitbucket are built from their 'owner/repository' names.
+func (ls *LockedShard) GetSourceUrl() string {
+	switch {
+	case ls.Github != "":
+		return "https://github.com/" + ls.Github + ".git"
+	case ls.Gitlab != "":
+		return "https://gitlab.com/" + ls.Gitlab + ".git"
+	case ls.Bitbucket != "":
+		return "https://bitbucket.org/" + ls.Bitbucket + ".git"
+	case ls.Git != "":
+		return ls.Git
+	case ls.Hg != "":
+		return ls.Hg
+	case ls.Fossil != "":
+		return ls.Fossil
+	}
+	return ls.Path
+}
+
+// IsGitShard returns true if the shard is fetched from a git repository.
+func (ls *LockedShard) IsGitShard() bool {
+	return ls.Git != "" || ls.Github != "" || ls.Gitlab != "" || ls.Bitbucket != ""
+}
+
+// GetCommit returns the commit the shard is locked to, or an empty string if it's locked to a release.
+func (ls *LockedShard) GetCommit() string {
+	if ls.Commit != "" {
+		return ls.Commit
+	}
+	if match := shardCommitVersionRegExp.FindStringSubmatch(ls.Version); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+type shardLock struct {
+	Shards map[string]LockedShard `yaml:"shards,omitempty"`
+}
+
+// ReadShard reads the shard.yml file in the given directory.
+func ReadShard(srcPath string) (*Shard, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, ShardFileName))
+	if err != nil {
+		return nil, err
+	}
+	shard := new(Shard)
+	return shard, yaml.Unmarshal(content, shard)
+}
+
+// GetInstalledShard reads the shard.yml file of a shard installed in the lib directory of the project.
+// A nil shard is returned if the shard isn't installed.
+func GetInstalledShard(srcPath, name string) (*Shard, error) {
+	shardPath := filepath.Join(srcPath, shardsLibDir, name)
+	exists, err := utils.IsFileExists(filepath.Join(shardPath, ShardFileName), true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return ReadShard(shardPath)
+}
+
+// ReadShardLock returns the shards listed in the shard.lock file in the given directory, sorted by their names.
+func ReadShardLock(srcPath string) ([]LockedShard, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, ShardLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	var lock shardLock
+	if err = yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	shards := make([]LockedShard, 0, len(lock.Shards))
+	for name, shard := range lock.Shards {
+		shard.Name = name
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].Name < shards[j].Name
+	})
+	return shards, nil
+}
+
+// GetShardsCachePath returns the path of the directory, where shards caches the repositories of the shards.
+// The cache directory can be set by the SHARDS_CACHE_PATH environment variable.
+func GetShardsCachePath() (string, error) {
+	if cachePath := os.Getenv("SHARDS_CACHE_PATH"); cachePath != "" {
+		return cachePath, nil
+	}
+	if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
+		return filepath.Join(cacheHome, "shards"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".cache", "shards"), nil
+}
+
+// ResolveShardCommit returns the commit of the release a git shard is locked to, as tagged in its repository in the shards cache.
+// The repositories are cloned to the cache as bare repositories, under their host and path, for example: 'github.com/kemalcr/kemal.git'.
+// An empty string is returned if the repository isn't cached.
+func ResolveShardCommit(cachePath string, shard LockedShard) (string, error) {
+	sourceUrl, err := url.Parse(shard.GetSourceUrl())
+	if err != nil || sourceUrl.Host == "" {
+		return "", nil
+	}
+	repositoryPath := strings.TrimSuffix(sourceUrl.Path, ".git") + ".git"
+	repositoryDir := filepath.Join(cachePath, sourceUrl.Host, filepath.FromSlash(repositoryPath))
+	exists, err := utils.IsDirExists(repositoryDir, true)
+	if err != nil || !exists {
+		return "", err
+	}
+	// The releases of shards are tagged with their versions, prefixed by 'v'.
+	command := utils.NewCommand("git", "rev-parse", []string{"v" + shard.Version + "^{commit}"})
+	command.Dir = repositoryDir
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetDependenciesNames returns the sorted names of the dependencies of the shard, optionally including its development dependencies.
+func (s *Shard) GetDependenciesNames(includeDevelopment bool) []string {
+	names := getSortedKeys(s.Dependencies)
+	if includeDevelopment {
+		names = append(names, getSortedKeys(s.DevelopmentDependencies)...)
+	}
+	return names
+}
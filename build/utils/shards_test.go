@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/build-info-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadShardLock(t *testing.T) {
+	shards, err := ReadShardLock(filepath.Join("..", "testdata", "shards", "project"))
+	require.NoError(t, err)
+	require.Len(t, shards, 6)
+	assert.Equal(t, LockedShard{Name: "exception_page", Git: "https://github.com/crystal-loot/exception_page.git", Version: "0.3.1+git.commit.1d8528a1d6ad1ef5a3b9a5a2bb7d0c9d4c59d6f7"}, shards[2])
+	assert.Equal(t, "1d8528a1d6ad1ef5a3b9a5a2bb7d0c9d4c59d6f7", shards[2].GetCommit())
+	assert.Empty(t, shards[3].GetCommit())
+	assert.False(t, shards[4].IsGitShard())
+	assert.Equal(t, "../mylib", shards[4].GetSourceUrl())
+}
+
+func TestLockedShardLegacyFormat(t *testing.T) {
+	// Lock files of version 1.0 list the hosts of the shards, and their commits.
+	shard := LockedShard{Name: "kemal", Github: "kemalcr/kemal", Commit: "0123456789abcdef0123456789abcdef01234567"}
+	assert.True(t, shard.IsGitShard())
+	assert.Equal(t, "https://github.com/kemalcr/kemal.git", shard.GetSourceUrl())
+	assert.Equal(t, "kemal:0123456789abcdef0123456789abcdef01234567", shard.Id())
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", shard.GetCommit())
+}
+
+func TestReadShard(t *testing.T) {
+	srcPath := filepath.Join("..", "testdata", "shards", "project")
+	shard, err := ReadShard(srcPath)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", shard.Name)
+	assert.Equal(t, []string{"kemal", "mylib", "ameba"}, shard.GetDependenciesNames(true))
+	assert.Equal(t, []string{"kemal", "mylib"}, shard.GetDependenciesNames(false))
+
+	installedShard, err := GetInstalledShard(srcPath, "kemal")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backtracer", "exception_page", "radix"}, installedShard.GetDependenciesNames(false))
+	// A shard, which isn't installed.
+	installedShard, err = GetInstalledShard(srcPath, "ameba")
+	assert.NoError(t, err)
+	assert.Nil(t, installedShard)
+}
+
+func TestResolveShardCommit(t *testing.T) {
+	cachePath := t.TempDir()
+	shard := LockedShard{Name: "radix", Git: "https://github.com/luislavena/radix.git", Version: "0.4.1"}
+	// The repository isn't cached.
+	commit, err := ResolveShardCommit(cachePath, shard)
+	assert.NoError(t, err)
+	assert.Empty(t, commit)
+
+	// Create a repository with a tagged release, in the cache.
+	repositoryDir := filepath.Join(cachePath, "github.com", "luislavena", "radix.git")
+	require.NoError(t, os.MkdirAll(repositoryDir, 0755))
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "--message", "Release 0.4.1"},
+		{"tag", "v0.4.1"},
+	} {
+		command := utils.NewCommand("git", args[0], args[1:])
+		command.Dir = repositoryDir
+		_, err = command.RunWithOutput()
+		require.NoError(t, err)
+	}
+	command := utils.NewCommand("git", "rev-parse", []string{"HEAD"})
+	command.Dir = repositoryDir
+	head, err := command.RunWithOutput()
+	require.NoError(t, err)
+
+	commit, err = ResolveShardCommit(cachePath, shard)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(head)), commit)
+}
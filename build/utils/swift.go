@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	SwiftManifestFileName = "Package.swift"
+	SwiftResolvedFileName = "Package.resolved"
+)
+
+var swiftPackageNameRegExp = regexp.MustCompile(`Package\s*\(\s*name\s*:\s*"([^"]+)"`)
+
+// SwiftPin represents a package pinned in a Package.resolved file.
+type SwiftPin struct {
+	// The identity of the package, which is the last path component of its location, in lowercase.
+	Identity string
+	Location string
+	Version  string
+	Branch   string
+	// The Git commit of the package.
+	Revision string
+}
+
+func (sp *SwiftPin) Id() string {
+	version := sp.Version
+	if version == "" {
+		// Packages pinned to branches or revisions have no version.
+		version = sp.Revision
+	}
+	return sp.Identity + ":" + version
+}
+
+type swiftPinState struct {
+	Branch   string `json:"branch,omitempty"`
+	Revision string `json:"revision,omitempty"`
+	Version  string `json:"version,omitempty"`
+}
+
+type swiftResolved struct {
+	Version int `json:"version,omitempty"`
+	// The pins of version 2 and above of the format.
+	Pins []struct {
+		Identity string        `json:"identity,omitempty"`
+		Location string        `json:"location,omitempty"`
+		State    swiftPinState `json:"state,omitempty"`
+	} `json:"pins,omitempty"`
+	// The pins of version 1 of the format.
+	Object struct {
+		Pins []struct {
+			Package       string        `json:"package,omitempty"`
+			RepositoryURL string        `json:"repositoryURL,omitempty"`
+			State         swiftPinState `json:"state,omitempty"`
+		} `json:"pins,omitempty"`
+	} `json:"object,omitempty"`
+}
+
+// ReadSwiftResolved returns the pins listed in the Package.resolved file in the given directory.
+func ReadSwiftResolved(srcPath string) ([]SwiftPin, error) {
+	var resolved swiftResolved
+	if err := utils.Unmarshal(filepath.Join(srcPath, SwiftResolvedFileName), &resolved); err != nil {
+		return nil, err
+	}
+	var pins []SwiftPin
+	for _, pin := range resolved.Pins {
+		pins = append(pins, SwiftPin{Identity: pin.Identity, Location: pin.Location, Version: pin.State.Version, Branch: pin.State.Branch, Revision: pin.State.Revision})
+	}
+	for _, pin := range resolved.Object.Pins {
+		pins = append(pins, SwiftPin{Identity: GetSwiftPackageIdentity(pin.RepositoryURL), Location: pin.RepositoryURL, Version: pin.State.Version, Branch: pin.State.Branch, Revision: pin.State.Revision})
+	}
+	return pins, nil
+}
+
+// GetSwiftPackageIdentity returns the identity of a package by its location, the same way SwiftPM does.
+// For example, the identity of 'https://github.com/apple/swift-argument-parser.git' is 'swift-argument-parser'.
+func GetSwiftPackageIdentity(location string) string {
+	identity := strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+	if i := strings.LastIndexAny(identity, "/:"); i >= 0 {
+		identity = identity[i+1:]
+	}
+	return strings.ToLower(identity)
+}
+
+// GetSwiftPackageName returns the name of the package, as defined in the Package.swift file in the given directory.
+// An empty string is returned if the name couldn't be found.
+func GetSwiftPackageName(srcPath string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, SwiftManifestFileName))
+	if err != nil {
+		return "", err
+	}
+	if match := swiftPackageNameRegExp.FindSubmatch(content); match != nil {
+		return string(match[1]), nil
+	}
+	return "", nil
+}
+
+// SwiftDependency represents a node in the output of 'swift package show-dependencies --format json'.
+type SwiftDependency struct {
+	Identity     string            `json:"identity,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	Url          string            `json:"url,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	Dependencies []SwiftDependency `json:"dependencies,omitempty"`
+}
+
+// RunSwiftShowDependencies runs 'swift package show-dependencies --format json' and returns the root of the dependencies tree.
+func RunSwiftShowDependencies(srcPath string) (*SwiftDependency, error) {
+	command := utils.NewCommand("swift", "package", []string{"show-dependencies", "--format", "json"})
+	command.Dir = srcPath
+	output, err := command.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	return ParseSwiftShowDependencies(output)
+}
+
+// ParseSwiftShowDependencies parses the output of 'swift package show-dependencies --format json'.
+func ParseSwiftShowDependencies(output []byte) (*SwiftDependency, error) {
+	// SwiftPM may print messages about fetching and resolving packages before the JSON.
+	if start := strings.Index(string(output), "{"); start > 0 {
+		output = output[start:]
+	}
+	root := new(SwiftDependency)
+	return root, json.Unmarshal(output, root)
+}
+
+// IsSwiftInstalled returns true if the swift executable is found in the PATH.
+func IsSwiftInstalled() bool {
+	_, err := exec.LookPath("swift")
+	return err == nil
+}
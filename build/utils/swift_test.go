@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSwiftResolved(t *testing.T) {
+	// Version 2 of the format.
+	pins, err := ReadSwiftResolved(filepath.Join("..", "testdata", "swift", "project"))
+	assert.NoError(t, err)
+	assert.Len(t, pins, 3)
+	assert.Equal(t, SwiftPin{Identity: "vapor", Location: "https://github.com/vapor/vapor.git", Branch: "main", Revision: "4e4ff7f3e6c3a4b5e1c4d6e89db6b9e3f2c1a0b9"}, pins[2])
+
+	// Version 1 of the format, in which the identities of the packages aren't listed.
+	pins, err = ReadSwiftResolved(filepath.Join("..", "testdata", "swift", "v1"))
+	assert.NoError(t, err)
+	if assert.Len(t, pins, 1) {
+		assert.Equal(t, "swift-argument-parser:1.2.3", pins[0].Id())
+	}
+}
+
+func TestGetSwiftPackageIdentity(t *testing.T) {
+	assert.Equal(t, "swift-argument-parser", GetSwiftPackageIdentity("https://github.com/apple/swift-argument-parser.git"))
+	assert.Equal(t, "swift-log", GetSwiftPackageIdentity("https://github.com/apple/Swift-Log/"))
+	assert.Equal(t, "vapor", GetSwiftPackageIdentity("git@github.com:vapor/vapor.git"))
+}
+
+func TestGetSwiftPackageName(t *testing.T) {
+	name, err := GetSwiftPackageName(filepath.Join("..", "testdata", "swift", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "SwiftProject", name)
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	TerraformLockFileName = ".terraform.lock.hcl"
+	// The default data directory of the working directory, where Terraform installs the providers and modules.
+	terraformDefaultDataDirName = ".terraform"
+	// The manifest of the modules installed by 'terraform init', relative to the data directory.
+	terraformModulesManifestPath = "modules/modules.json"
+)
+
+var (
+	terraformProviderBlockRegExp = regexp.MustCompile(`^\s*provider\s+"([^"]+)"\s*\{`)
+	terraformAttributeRegExp     = regexp.MustCompile(`^\s*(\w+)\s*=\s*"([^"]*)"`)
+	terraformHashesStartRegExp   = regexp.MustCompile(`^\s*hashes\s*=\s*\[`)
+	terraformStringRegExp        = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// TerraformProvider represents a provider listed in a .terraform.lock.hcl file.
+type TerraformProvider struct {
+	// The source address of the provider, for example: 'registry.terraform.io/hashicorp/aws'.
+	Address     string
+	Version     string
+	Constraints string
+	// The 'h1:' (content) and 'zh:' (zip package) hashes of the provider's packages.
+	Hashes []string
+}
+
+func (tp *TerraformProvider) Id() string {
+	return tp.Address + ":" + tp.Version
+}
+
+// TerraformModuleRecord represents a module installed by 'terraform init', as listed in the modules manifest.
+type TerraformModuleRecord struct {
+	// The path of the module in the configuration, for example: 'vpc' or 'vpc.subnets'. The key of the root module is empty.
+	Key     string `json:"Key"`
+	Source  string `json:"Source"`
+	Version string `json:"Version,omitempty"`
+	Dir     string `json:"Dir"`
+}
+
+// IsLocal returns true if the module is loaded from a local path, and therefore isn't a remote module.
+func (tmr *TerraformModuleRecord) IsLocal() bool {
+	return tmr.Source == "" || strings.HasPrefix(tmr.Source, "./") || strings.HasPrefix(tmr.Source, "../")
+}
+
+// Id returns the ID of the module. Modules without a version (such as git modules) are identified by their source address.
+func (tmr *TerraformModuleRecord) Id() string {
+	if tmr.Version == "" {
+		return tmr.Source
+	}
+	return tmr.Source + ":" + tmr.Version
+}
+
+// GetParentKey returns the key of the module which calls this module, or an empty string if it's called by the root module.
+func (tmr *TerraformModuleRecord) GetParentKey() string {
+	if i := strings.LastIndex(tmr.Key, "."); i >= 0 {
+		return tmr.Key[:i]
+	}
+	return ""
+}
+
+type terraformModulesManifest struct {
+	Modules []TerraformModuleRecord `json:"Modules"`
+}
+
+// GetTerraformDataDir returns the data directory of the given working directory, which can be changed using the TF_DATA_DIR environment variable.
+func GetTerraformDataDir(srcPath string) string {
+	dataDir := os.Getenv("TF_DATA_DIR")
+	if dataDir == "" {
+		return filepath.Join(srcPath, terraformDefaultDataDirName)
+	}
+	if filepath.IsAbs(dataDir) {
+		return dataDir
+	}
+	return filepath.Join(srcPath, dataDir)
+}
+
+// ReadTerraformLockFile returns the providers listed in the .terraform.lock.hcl file in the given directory.
+func ReadTerraformLockFile(srcPath string) ([]TerraformProvider, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, TerraformLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	var providers []TerraformProvider
+	var current *TerraformProvider
+	inHashes := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if current == nil {
+			if match := terraformProviderBlockRegExp.FindStringSubmatch(line); match != nil {
+				current = &TerraformProvider{Address: match[1]}
+			}
+			continue
+		}
+		if inHashes || terraformHashesStartRegExp.MatchString(line) {
+			for _, match := range terraformStringRegExp.FindAllStringSubmatch(line, -1) {
+				current.Hashes = append(current.Hashes, match[1])
+			}
+			inHashes = !strings.Contains(line, "]")
+			continue
+		}
+		if strings.TrimSpace(line) == "}" {
+			providers = append(providers, *current)
+			current = nil
+			continue
+		}
+		if match := terraformAttributeRegExp.FindStringSubmatch(line); match != nil {
+			switch match[1] {
+			case "version":
+				current.Version = match[2]
+			case "constraints":
+				current.Constraints = match[2]
+			}
+		}
+	}
+	return providers, scanner.Err()
+}
+
+// ReadTerraformModulesManifest returns the modules installed by 'terraform init' in the given data directory.
+// Returns nil if no modules were installed.
+func ReadTerraformModulesManifest(dataDir string) ([]TerraformModuleRecord, error) {
+	manifestPath := filepath.Join(dataDir, filepath.FromSlash(terraformModulesManifestPath))
+	exists, err := utils.IsFileExists(manifestPath, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	var manifest terraformModulesManifest
+	if err = utils.Unmarshal(manifestPath, &manifest); err != nil {
+		return nil, err
+	}
+	return manifest.Modules, nil
+}
+
+// FindTerraformProviderExecutable returns the path of the executable of the provider for the current platform, or an empty string if it's not found.
+// The provider is searched in the data directory, and then in the plugin cache directory (TF_PLUGIN_CACHE_DIR), if it's set.
+func FindTerraformProviderExecutable(dataDir string, provider TerraformProvider) (string, error) {
+	providerDir := filepath.Join(filepath.FromSlash(provider.Address), provider.Version, runtime.GOOS+"_"+runtime.GOARCH)
+	searchDirs := []string{filepath.Join(dataDir, "providers", providerDir)}
+	if pluginCacheDir := os.Getenv("TF_PLUGIN_CACHE_DIR"); pluginCacheDir != "" {
+		searchDirs = append(searchDirs, filepath.Join(pluginCacheDir, providerDir))
+	}
+	for _, searchDir := range searchDirs {
+		matches, err := filepath.Glob(filepath.Join(searchDir, "terraform-provider-*"))
+		if err != nil {
+			return "", err
+		}
+		if len(matches) > 0 {
+			return matches[0], nil
+		}
+	}
+	return "", nil
+}
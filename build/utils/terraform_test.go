@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTerraformLockFile(t *testing.T) {
+	providers, err := ReadTerraformLockFile(filepath.Join("..", "testdata", "terraform", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, []TerraformProvider{
+		{
+			Address:     "registry.terraform.io/hashicorp/aws",
+			Version:     "5.17.0",
+			Constraints: "~> 5.0",
+			Hashes: []string{
+				"h1:rplvK7UGP7FuzM44t2eRX+QYYPC0aUIoKdi5XayRI8M=",
+				"zh:0087b9dd2c9c638fd63e527e5b9b70988008e263d480a199f180ad2a0c0e7c2a",
+				"zh:0fd6b7d5f0e6f1a1e4c2b0e0a7a0e8b7d6e3f2b8c1f4a3e0d2c7b6a5f4e3d2c1",
+			},
+		},
+		{
+			Address:     "registry.terraform.io/hashicorp/random",
+			Version:     "3.5.1",
+			Constraints: "3.5.1",
+			Hashes:      []string{"h1:IL9mSatmwov+e0+++YX2V6uel+dV6bn+fC/cnGDK3Ls="},
+		},
+	}, providers)
+}
+
+func TestTerraformModuleRecord(t *testing.T) {
+	module := TerraformModuleRecord{Key: "network.labels", Source: "git::https://github.com/cloudposse/terraform-null-label.git?ref=0.25.0"}
+	assert.False(t, module.IsLocal())
+	assert.Equal(t, "network", module.GetParentKey())
+	assert.Equal(t, module.Source, module.Id())
+
+	module = TerraformModuleRecord{Key: "network", Source: "./modules/network"}
+	assert.True(t, module.IsLocal())
+	assert.Empty(t, module.GetParentKey())
+}
@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	UnityPackagesDir          = "Packages"
+	UnityPackagesLockFileName = "packages-lock.json"
+	unityPackageFileName      = "package.json"
+	// The prefix of the versions of packages, which are installed from the local file system, for example: 'file:../MyPackage'.
+	unityFileVersionPrefix = "file:"
+
+	UnityRegistrySource     = "registry"
+	UnityGitSource          = "git"
+	UnityEmbeddedSource     = "embedded"
+	UnityLocalSource        = "local"
+	UnityLocalTarballSource = "local-tarball"
+)
+
+var (
+	unityProductNameRegExp   = regexp.MustCompile(`(?m)^\s*productName:\s*(.+?)\s*$`)
+	unityBundleVersionRegExp = regexp.MustCompile(`(?m)^\s*bundleVersion:\s*(.+?)\s*$`)
+)
+
+// UnityPackage represents a package listed in the packages-lock.json file of a Unity project.
+type UnityPackage struct {
+	Name string
+	// The version of the package, as resolved by the Unity Package Manager.
+	// The versions of packages installed from git repositories or from the local file system are their URLs or paths, for example: 'file:../MyPackage'.
+	Version string
+	// The source of the package, for example: 'registry', 'builtin', 'embedded', 'local', 'local-tarball' or 'git'.
+	Source string
+	// The depth of the package in the dependency tree. The packages listed in the manifest of the project have depth 0.
+	Depth int
+	// The URL of the registry the package was installed from.
+	Url string
+	// The commit of the git repository the package was installed from.
+	Hash string
+	// The names of the packages this package depends on.
+	Dependencies []string
+}
+
+type unityPackagesLock struct {
+	Dependencies map[string]struct {
+		Version      string            `json:"version"`
+		Depth        int               `json:"depth"`
+		Source       string            `json:"source"`
+		Dependencies map[string]string `json:"dependencies"`
+		Url          string            `json:"url"`
+		Hash         string            `json:"hash"`
+	} `json:"dependencies"`
+}
+
+// GetUnityPackagesLockPath returns the path of the packages-lock.json file of the Unity project in the given directory.
+func GetUnityPackagesLockPath(srcPath string) string {
+	return filepath.Join(srcPath, UnityPackagesDir, UnityPackagesLockFileName)
+}
+
+// GetUnityProductNameAndVersion returns the product name and the version of the Unity project in the given directory, as set in its player settings.
+// Empty strings are returned if the project has no player settings.
+func GetUnityProductNameAndVersion(srcPath string) (name, version string, err error) {
+	settingsPath := filepath.Join(srcPath, "ProjectSettings", "ProjectSettings.asset")
+	exists, err := utils.IsFileExists(settingsPath, true)
+	if err != nil || !exists {
+		return
+	}
+	// The settings are serialized as YAML with Unity-specific tags, so only the required fields are extracted.
+	content, err := os.ReadFile(settingsPath)
+	if err != nil {
+		return
+	}
+	if match := unityProductNameRegExp.FindSubmatch(content); match != nil {
+		name = string(match[1])
+	}
+	if match := unityBundleVersionRegExp.FindSubmatch(content); match != nil {
+		version = string(match[1])
+	}
+	return
+}
+
+// ReadUnityPackagesLock returns the packages listed in the packages-lock.json file of the Unity project in the given directory, sorted by their names.
+func ReadUnityPackagesLock(srcPath string) ([]UnityPackage, error) {
+	var lock unityPackagesLock
+	if err := utils.Unmarshal(GetUnityPackagesLockPath(srcPath), &lock); err != nil {
+		return nil, err
+	}
+	var packages []UnityPackage
+	for _, name := range getSortedKeys(lock.Dependencies) {
+		lockedPackage := lock.Dependencies[name]
+		packages = append(packages, UnityPackage{
+			Name:         name,
+			Version:      lockedPackage.Version,
+			Source:       lockedPackage.Source,
+			Depth:        lockedPackage.Depth,
+			Url:          lockedPackage.Url,
+			Hash:         lockedPackage.Hash,
+			Dependencies: getSortedKeys(lockedPackage.Dependencies),
+		})
+	}
+	return packages, nil
+}
+
+// GetLocalPath returns the path of a package, which is embedded in the project or installed from the local file system.
+// Paths in the versions of packages installed from the local file system are relative to the Packages directory of the project.
+// An empty string is returned for packages from other sources.
+func (up *UnityPackage) GetLocalPath(srcPath string) string {
+	packagesPath := filepath.Join(srcPath, UnityPackagesDir)
+	switch up.Source {
+	case UnityEmbeddedSource:
+		return filepath.Join(packagesPath, up.Name)
+	case UnityLocalSource, UnityLocalTarballSource:
+		localPath := filepath.FromSlash(strings.TrimPrefix(up.Version, unityFileVersionPrefix))
+		if filepath.IsAbs(localPath) {
+			return localPath
+		}
+		return filepath.Join(packagesPath, localPath)
+	}
+	return ""
+}
+
+// GetUnityPackageVersion returns the version in the package.json file of the package in the given directory, or an empty string if the file doesn't exist.
+func GetUnityPackageVersion(packagePath string) (string, error) {
+	packageJsonPath := filepath.Join(packagePath, unityPackageFileName)
+	exists, err := utils.IsFileExists(packageJsonPath, true)
+	if err != nil || !exists {
+		return "", err
+	}
+	var packageJson struct {
+		Version string `json:"version"`
+	}
+	if err = utils.Unmarshal(packageJsonPath, &packageJson); err != nil {
+		return "", err
+	}
+	return packageJson.Version, nil
+}
+
+// GetUnityCacheRoot returns the root of the global cache of the Unity Package Manager, which can be set by the UPM_CACHE_ROOT environment variable.
+func GetUnityCacheRoot() (string, error) {
+	if cacheRoot := os.Getenv("UPM_CACHE_ROOT"); cacheRoot != "" {
+		return cacheRoot, nil
+	}
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(os.Getenv("LOCALAPPDATA"), "Unity", "cache"), nil
+	case "darwin":
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(homeDir, "Library", "Unity", "cache"), nil
+	default:
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(configDir, "unity3d", "cache"), nil
+	}
+}
+
+// FindUnityRegistryTarball returns the path of the tarball of a registry package in the global cache of the Unity Package Manager, or an empty string if it's not found.
+// The tarballs are cached under a directory per registry host, for example: 'npm/packages.unity.com/com.unity.timeline/1.7.5/package.tgz'.
+func FindUnityRegistryTarball(cacheRoot string, pkg UnityPackage) (string, error) {
+	registryHost := pkg.Url
+	if i := strings.Index(registryHost, "://"); i >= 0 {
+		registryHost = registryHost[i+len("://"):]
+	}
+	registryHost = strings.Split(registryHost, "/")[0]
+	tarballPath := filepath.Join(cacheRoot, "npm", registryHost, pkg.Name, pkg.Version, "package.tgz")
+	exists, err := utils.IsFileExists(tarballPath, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	return tarballPath, nil
+}
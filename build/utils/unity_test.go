@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUnityPackagesLock(t *testing.T) {
+	srcPath := filepath.Join("..", "testdata", "unity", "project")
+	packages, err := ReadUnityPackagesLock(srcPath)
+	require.NoError(t, err)
+	require.Len(t, packages, 7)
+	assert.Equal(t, filepath.Join(srcPath, "Packages", "com.company.embedded"), packages[0].GetLocalPath(srcPath))
+	assert.Equal(t, filepath.Join("..", "testdata", "unity", "LocalPackage"), packages[1].GetLocalPath(srcPath))
+	assert.Equal(t, UnityPackage{
+		Name:         "com.unity.test-framework",
+		Version:      "1.1.33",
+		Source:       UnityRegistrySource,
+		Url:          "https://packages.unity.com",
+		Dependencies: []string{"com.unity.ext.nunit", "com.unity.modules.ui"},
+	}, packages[6])
+	assert.Empty(t, packages[6].GetLocalPath(srcPath))
+}
+
+func TestGetUnityProductNameAndVersion(t *testing.T) {
+	name, version, err := GetUnityProductNameAndVersion(filepath.Join("..", "testdata", "unity", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello Game", name)
+	assert.Equal(t, "0.3.1", version)
+
+	// A project without player settings.
+	name, version, err = GetUnityProductNameAndVersion(filepath.Join("..", "testdata", "unity"))
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+	assert.Empty(t, version)
+}
+
+func TestFindUnityRegistryTarball(t *testing.T) {
+	cacheRoot := filepath.Join("..", "testdata", "unity", "cache")
+	tarballPath, err := FindUnityRegistryTarball(cacheRoot, UnityPackage{Name: "com.unity.ext.nunit", Version: "1.0.6", Url: "https://packages.unity.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheRoot, "npm", "packages.unity.com", "com.unity.ext.nunit", "1.0.6", "package.tgz"), tarballPath)
+
+	tarballPath, err = FindUnityRegistryTarball(cacheRoot, UnityPackage{Name: "com.unity.ext.nunit", Version: "2.0.0", Url: "https://packages.unity.com"})
+	assert.NoError(t, err)
+	assert.Empty(t, tarballPath)
+}
@@ -0,0 +1,323 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	VcpkgManifestFileName      = "vcpkg.json"
+	VcpkgLockFileName          = "vcpkg-lock.json"
+	VcpkgConfigurationFileName = "vcpkg-configuration.json"
+	// The directory, to which the ports are installed in manifest mode, relative to the project's directory.
+	VcpkgInstalledDirName = "vcpkg_installed"
+	// The repository of the builtin registry, which is used when no other registry is configured for a port.
+	vcpkgBuiltinRegistryRepository = "https://github.com/microsoft/vcpkg"
+	vcpkgSpdxFileName              = "vcpkg.spdx.json"
+	vcpkgInstalledStatus           = "install ok installed"
+)
+
+// VcpkgManifest represents the fields of a vcpkg.json file, which are used by the build-info.
+type VcpkgManifest struct {
+	Name    string
+	Version string
+	// The names of the ports, which are the direct dependencies of the project.
+	Dependencies []string
+	// The registries configuration, embedded in the manifest or read from the vcpkg-configuration.json file.
+	Configuration *VcpkgConfiguration
+}
+
+type vcpkgManifestFile struct {
+	Name          string                    `json:"name,omitempty"`
+	Version       string                    `json:"version,omitempty"`
+	VersionSemver string                    `json:"version-semver,omitempty"`
+	VersionDate   string                    `json:"version-date,omitempty"`
+	VersionString string                    `json:"version-string,omitempty"`
+	PortVersion   int                       `json:"port-version,omitempty"`
+	Dependencies  []vcpkgManifestDependency `json:"dependencies,omitempty"`
+	Configuration *VcpkgConfiguration       `json:"vcpkg-configuration,omitempty"`
+}
+
+// A dependency in a vcpkg.json file, which is either the name of a port or an object, such as: {"name": "fmt", "features": [...]}.
+type vcpkgManifestDependency struct {
+	Name string `json:"name"`
+}
+
+func (vmd *vcpkgManifestDependency) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		return json.Unmarshal(data, &vmd.Name)
+	}
+	type dependency vcpkgManifestDependency
+	return json.Unmarshal(data, (*dependency)(vmd))
+}
+
+// VcpkgConfiguration represents the registries configuration of a vcpkg project.
+type VcpkgConfiguration struct {
+	DefaultRegistry *VcpkgRegistry  `json:"default-registry,omitempty"`
+	Registries      []VcpkgRegistry `json:"registries,omitempty"`
+}
+
+type VcpkgRegistry struct {
+	// The kind of the registry: builtin, git or filesystem.
+	Kind       string `json:"kind,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Reference  string `json:"reference,omitempty"`
+	Baseline   string `json:"baseline,omitempty"`
+	// The ports provided by the registry. Patterns, such as 'beicode-*', are allowed.
+	Packages []string `json:"packages,omitempty"`
+}
+
+// GetPortRegistry returns the registry, from which the given port is installed.
+// Ports, which aren't provided by any of the configured registries, are installed from the default registry.
+func (vc *VcpkgConfiguration) GetPortRegistry(port string) VcpkgRegistry {
+	if vc != nil {
+		for _, registry := range vc.Registries {
+			for _, pattern := range registry.Packages {
+				if matched, _ := path.Match(pattern, port); matched {
+					return registry
+				}
+			}
+		}
+		if vc.DefaultRegistry != nil {
+			return *vc.DefaultRegistry
+		}
+	}
+	return VcpkgRegistry{Kind: "builtin", Repository: vcpkgBuiltinRegistryRepository}
+}
+
+// VcpkgLock represents a vcpkg-lock.json file, which maps the repositories of git registries and their references to the locked commits.
+type VcpkgLock map[string]map[string]string
+
+// GetRegistryCommit returns the commit, to which the given git registry is locked, or an empty string if it isn't locked.
+func (vl VcpkgLock) GetRegistryCommit(registry VcpkgRegistry) string {
+	reference := registry.Reference
+	if reference == "" {
+		reference = "HEAD"
+	}
+	return vl[registry.Repository][reference]
+}
+
+// VcpkgPort represents a port installed for a specific triplet, as listed in the status file of the installed tree.
+type VcpkgPort struct {
+	Name        string
+	Version     string
+	PortVersion string
+	Triplet     string
+	// The ABI hash of the port, which identifies the binary package.
+	Abi string
+	// The installed features of the port.
+	Features []string
+	// The names of the ports this port depends on.
+	Depends []string
+}
+
+// Id returns the ID of the port, in which the port version (if it's not 0) is appended to the version, as in vcpkg: 'zlib:1.3#1'.
+func (vp *VcpkgPort) Id() string {
+	if vp.PortVersion != "" && vp.PortVersion != "0" {
+		return vp.Name + ":" + vp.Version + "#" + vp.PortVersion
+	}
+	return vp.Name + ":" + vp.Version
+}
+
+// VcpkgPortSource represents a source (such as a GitHub archive), which was downloaded to build a port, as listed in its SPDX file.
+type VcpkgPortSource struct {
+	Url    string
+	Sha512 string
+}
+
+type vcpkgSpdxFile struct {
+	Packages []struct {
+		SpdxId           string `json:"SPDXID"`
+		DownloadLocation string `json:"downloadLocation"`
+		Checksums        []struct {
+			Algorithm     string `json:"algorithm"`
+			ChecksumValue string `json:"checksumValue"`
+		} `json:"checksums,omitempty"`
+	} `json:"packages"`
+}
+
+// ReadVcpkgManifest returns the manifest in the vcpkg.json file in the given directory.
+// If the manifest doesn't embed the registries configuration, it's read from the vcpkg-configuration.json file, if it exists.
+func ReadVcpkgManifest(srcPath string) (*VcpkgManifest, error) {
+	var manifestFile vcpkgManifestFile
+	if err := utils.Unmarshal(filepath.Join(srcPath, VcpkgManifestFileName), &manifestFile); err != nil {
+		return nil, err
+	}
+	manifest := &VcpkgManifest{Name: manifestFile.Name, Configuration: manifestFile.Configuration}
+	for _, version := range []string{manifestFile.Version, manifestFile.VersionSemver, manifestFile.VersionDate, manifestFile.VersionString} {
+		if version != "" {
+			manifest.Version = version
+			break
+		}
+	}
+	if manifest.Version != "" && manifestFile.PortVersion > 0 {
+		manifest.Version += "#" + strconv.Itoa(manifestFile.PortVersion)
+	}
+	for _, dependency := range manifestFile.Dependencies {
+		manifest.Dependencies = append(manifest.Dependencies, dependency.Name)
+	}
+	if manifest.Configuration == nil {
+		configurationPath := filepath.Join(srcPath, VcpkgConfigurationFileName)
+		exists, err := utils.IsFileExists(configurationPath, true)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			manifest.Configuration = &VcpkgConfiguration{}
+			if err = utils.Unmarshal(configurationPath, manifest.Configuration); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return manifest, nil
+}
+
+// ReadVcpkgLock returns the locked registries in the vcpkg-lock.json file in the given directory, or an empty lock if the file doesn't exist.
+func ReadVcpkgLock(srcPath string) (VcpkgLock, error) {
+	lock := VcpkgLock{}
+	lockPath := filepath.Join(srcPath, VcpkgLockFileName)
+	exists, err := utils.IsFileExists(lockPath, true)
+	if err != nil || !exists {
+		return lock, err
+	}
+	return lock, utils.Unmarshal(lockPath, &lock)
+}
+
+// ReadVcpkgInstalledPorts returns the ports installed in the given installed tree, as listed in its 'vcpkg/status' file, sorted by their names and triplets.
+// The status file consists of paragraphs of 'Field: value' lines. The features of a port are listed in separate paragraphs, which add their dependencies to the port.
+func ReadVcpkgInstalledPorts(installedDir string) ([]VcpkgPort, error) {
+	content, err := os.ReadFile(filepath.Join(installedDir, "vcpkg", "status"))
+	if err != nil {
+		return nil, err
+	}
+	var paragraphs []map[string]string
+	paragraph := make(map[string]string)
+	lastField := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			if len(paragraph) > 0 {
+				paragraphs = append(paragraphs, paragraph)
+				paragraph = make(map[string]string)
+			}
+		case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"):
+			// A continuation line of the previous field.
+			if lastField != "" {
+				paragraph[lastField] += "\n" + strings.TrimSpace(line)
+			}
+		default:
+			field, value, _ := strings.Cut(line, ":")
+			lastField = strings.TrimSpace(field)
+			paragraph[lastField] = strings.TrimSpace(value)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(paragraphs) > 0 || len(paragraph) > 0 {
+		paragraphs = append(paragraphs, paragraph)
+	}
+
+	portsMap := make(map[string]*VcpkgPort)
+	var features []map[string]string
+	for _, paragraph := range paragraphs {
+		// Removed ports remain in the status file, with a 'purge' or 'deinstall' status.
+		if paragraph["Status"] != vcpkgInstalledStatus {
+			continue
+		}
+		if paragraph["Feature"] != "" {
+			features = append(features, paragraph)
+			continue
+		}
+		port := &VcpkgPort{
+			Name:        paragraph["Package"],
+			Version:     paragraph["Version"],
+			PortVersion: paragraph["Port-Version"],
+			Triplet:     paragraph["Architecture"],
+			Abi:         paragraph["Abi"],
+			Depends:     parseVcpkgDepends(paragraph["Depends"]),
+		}
+		portsMap[port.Name+":"+port.Triplet] = port
+	}
+	for _, feature := range features {
+		port, ok := portsMap[feature["Package"]+":"+feature["Architecture"]]
+		if !ok {
+			continue
+		}
+		port.Features = append(port.Features, feature["Feature"])
+		for _, dependency := range parseVcpkgDepends(feature["Depends"]) {
+			// A feature may depend on another feature of its own port.
+			if dependency != port.Name && !slices.Contains(port.Depends, dependency) {
+				port.Depends = append(port.Depends, dependency)
+			}
+		}
+	}
+	var ports []VcpkgPort
+	for _, port := range portsMap {
+		sort.Strings(port.Features)
+		ports = append(ports, *port)
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Name == ports[j].Name {
+			return ports[i].Triplet < ports[j].Triplet
+		}
+		return ports[i].Name < ports[j].Name
+	})
+	return ports, nil
+}
+
+// Parses the 'Depends' field of the status file, for example: 'vcpkg-cmake:x64-linux, fmt, boost-asio[ssl]'.
+// The triplets and features of the dependencies are removed, so only their names are returned.
+func parseVcpkgDepends(depends string) (names []string) {
+	for _, dependency := range strings.Split(depends, ",") {
+		dependency = strings.TrimSpace(dependency)
+		if i := strings.IndexAny(dependency, ":[ "); i >= 0 {
+			dependency = dependency[:i]
+		}
+		if dependency != "" && !slices.Contains(names, dependency) {
+			names = append(names, dependency)
+		}
+	}
+	return
+}
+
+// ReadVcpkgPortSources returns the sources, which were downloaded to build the given port, as listed in the SPDX file installed with it.
+// An empty list is returned if the port doesn't have an SPDX file, which is generated by vcpkg since version 2023.04.15.
+func ReadVcpkgPortSources(installedDir string, port VcpkgPort) ([]VcpkgPortSource, error) {
+	spdxPath := filepath.Join(installedDir, port.Triplet, "share", port.Name, vcpkgSpdxFileName)
+	exists, err := utils.IsFileExists(spdxPath, true)
+	if err != nil || !exists {
+		return nil, err
+	}
+	var spdxFile vcpkgSpdxFile
+	if err = utils.Unmarshal(spdxPath, &spdxFile); err != nil {
+		return nil, err
+	}
+	var sources []VcpkgPortSource
+	for _, pkg := range spdxFile.Packages {
+		// The port itself and the built binary are also listed in the file. The downloaded sources are listed as resources.
+		if !strings.HasPrefix(pkg.SpdxId, "SPDXRef-resource-") {
+			continue
+		}
+		source := VcpkgPortSource{Url: pkg.DownloadLocation}
+		for _, checksum := range pkg.Checksums {
+			if strings.EqualFold(checksum.Algorithm, "SHA512") {
+				source.Sha512 = strings.ToLower(checksum.ChecksumValue)
+			}
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadVcpkgManifest(t *testing.T) {
+	manifest, err := ReadVcpkgManifest(filepath.Join("..", "testdata", "vcpkg", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", manifest.Name)
+	assert.Equal(t, "1.0.0#1", manifest.Version)
+	assert.Equal(t, []string{"fmt", "curl", "beicode"}, manifest.Dependencies)
+	// The configuration is read from the vcpkg-configuration.json file.
+	if assert.NotNil(t, manifest.Configuration) {
+		assert.Equal(t, "https://github.com/northwindtraders/vcpkg-registry", manifest.Configuration.GetPortRegistry("beison-json").Repository)
+		assert.Equal(t, "https://github.com/microsoft/vcpkg", manifest.Configuration.GetPortRegistry("fmt").Repository)
+	}
+}
+
+func TestGetVcpkgPortRegistryWithoutConfiguration(t *testing.T) {
+	var configuration *VcpkgConfiguration
+	registry := configuration.GetPortRegistry("fmt")
+	assert.Equal(t, VcpkgRegistry{Kind: "builtin", Repository: "https://github.com/microsoft/vcpkg"}, registry)
+	assert.Empty(t, VcpkgLock{}.GetRegistryCommit(registry))
+}
+
+func TestReadVcpkgInstalledPorts(t *testing.T) {
+	ports, err := ReadVcpkgInstalledPorts(filepath.Join("..", "testdata", "vcpkg", "project", "vcpkg_installed"))
+	assert.NoError(t, err)
+	var ids []string
+	for _, port := range ports {
+		ids = append(ids, port.Id())
+	}
+	assert.Equal(t, []string{"beicode:1.0.0", "curl:8.2.1", "fmt:10.1.1", "openssl:3.1.2", "vcpkg-cmake:2023-05-04", "zlib:1.3#1"}, ids)
+	curl := ports[1]
+	assert.Equal(t, "x64-linux", curl.Triplet)
+	assert.Equal(t, []string{"openssl", "ssl"}, curl.Features)
+	assert.Equal(t, []string{"vcpkg-cmake", "zlib", "openssl"}, curl.Depends)
+}
+
+func TestParseVcpkgDepends(t *testing.T) {
+	assert.Equal(t, []string{"vcpkg-cmake", "fmt", "boost-asio"}, parseVcpkgDepends("vcpkg-cmake:x64-linux, fmt, boost-asio[ssl], fmt:x64-linux"))
+	assert.Empty(t, parseVcpkgDepends(""))
+}
+
+func TestReadVcpkgPortSources(t *testing.T) {
+	installedDir := filepath.Join("..", "testdata", "vcpkg", "project", "vcpkg_installed")
+	sources, err := ReadVcpkgPortSources(installedDir, VcpkgPort{Name: "fmt", Triplet: "x64-linux"})
+	assert.NoError(t, err)
+	assert.Equal(t, []VcpkgPortSource{{
+		Url:    "git+https://github.com/fmtlib/fmt@10.1.1",
+		Sha512: "7a5024a35d1370b2bf7448e8c8dcac5704618b5c902ea40326e09ea05be636bb2dacd72cb4d2140169c596a52689be7171aa467b455db005daaaa1d6fce7ad32",
+	}}, sources)
+
+	// A port without an SPDX file.
+	sources, err = ReadVcpkgPortSources(installedDir, VcpkgPort{Name: "zlib", Triplet: "x64-linux"})
+	assert.NoError(t, err)
+	assert.Empty(t, sources)
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	YoctoLicenseManifestFileName = "license.manifest"
+	// The prefix of the SPDX documents, which the create-spdx class creates for the recipes, for example: 'recipe-busybox.spdx.json'.
+	yoctoSpdxRecipeDocumentPrefix = "recipe-"
+	yoctoSpdxRecipeIdPrefix       = "SPDXRef-Recipe-"
+	yoctoSpdxDocumentSuffix       = ".spdx.json"
+)
+
+// YoctoRecipe represents a recipe, whose packages were installed in an image built by BitBake.
+type YoctoRecipe struct {
+	Name    string
+	Version string
+	License string
+	// The names of the packages, which the recipe built and which were installed in the image.
+	Packages []string
+	// The location, from which the source of the recipe was fetched, and its CPE identifier, as listed in its SPDX document.
+	DownloadLocation string
+	Cpe              string
+}
+
+func (yr *YoctoRecipe) Id() string {
+	return yr.Name + ":" + yr.Version
+}
+
+type yoctoSpdxDocument struct {
+	Packages []struct {
+		SpdxId           string `json:"SPDXID"`
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		DownloadLocation string `json:"downloadLocation"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+		ExternalRefs     []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// ReadYoctoLicenseManifest returns the recipes listed in the license.manifest file in the given directory, sorted by their names.
+// The file consists of paragraphs separated by empty lines, each describing a package installed in the image, for example: 'PACKAGE NAME: busybox-syslog'.
+// The packages are grouped by the recipes, which built them.
+func ReadYoctoLicenseManifest(srcPath string) ([]YoctoRecipe, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, YoctoLicenseManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	recipesMap := make(map[string]*YoctoRecipe)
+	fields := make(map[string]string)
+	addPackage := func() {
+		defer func() {
+			fields = make(map[string]string)
+		}()
+		if fields["PACKAGE NAME"] == "" {
+			return
+		}
+		recipeName := fields["RECIPE NAME"]
+		if recipeName == "" {
+			recipeName = fields["PACKAGE NAME"]
+		}
+		recipe, exists := recipesMap[recipeName]
+		if !exists {
+			recipe = &YoctoRecipe{Name: recipeName, Version: fields["PACKAGE VERSION"], License: fields["LICENSE"]}
+			recipesMap[recipeName] = recipe
+		}
+		recipe.Packages = append(recipe.Packages, fields["PACKAGE NAME"])
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			addPackage()
+			continue
+		}
+		if field, value, found := strings.Cut(line, ":"); found {
+			fields[strings.TrimSpace(field)] = strings.TrimSpace(value)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	addPackage()
+	return getSortedYoctoRecipes(recipesMap), nil
+}
+
+// ReadYoctoSpdxRecipes returns the recipes described by the SPDX documents of recipes (recipe-*.spdx.json) in the given directory, sorted by their names.
+// These documents are created by the create-spdx class, in the 'recipes' directory of the SPDX deploy directory of the machine.
+func ReadYoctoSpdxRecipes(srcPath string) ([]YoctoRecipe, error) {
+	documentPaths, err := filepath.Glob(filepath.Join(srcPath, yoctoSpdxRecipeDocumentPrefix+"*"+yoctoSpdxDocumentSuffix))
+	if err != nil {
+		return nil, err
+	}
+	recipesMap := make(map[string]*YoctoRecipe)
+	for _, documentPath := range documentPaths {
+		var document yoctoSpdxDocument
+		if err = utils.Unmarshal(documentPath, &document); err != nil {
+			return nil, err
+		}
+		for _, pkg := range document.Packages {
+			// The documents also describe the source files of the recipes.
+			if !strings.HasPrefix(pkg.SpdxId, yoctoSpdxRecipeIdPrefix) {
+				continue
+			}
+			recipe := &YoctoRecipe{Name: pkg.Name, Version: pkg.VersionInfo, License: pkg.LicenseDeclared}
+			if pkg.DownloadLocation != "NOASSERTION" && pkg.DownloadLocation != "NONE" {
+				recipe.DownloadLocation = pkg.DownloadLocation
+			}
+			for _, ref := range pkg.ExternalRefs {
+				if ref.ReferenceType == "cpe23Type" {
+					recipe.Cpe = ref.ReferenceLocator
+					break
+				}
+			}
+			recipesMap[recipe.Name] = recipe
+		}
+	}
+	return getSortedYoctoRecipes(recipesMap), nil
+}
+
+func getSortedYoctoRecipes(recipesMap map[string]*YoctoRecipe) []YoctoRecipe {
+	var recipes []YoctoRecipe
+	for _, recipe := range recipesMap {
+		recipes = append(recipes, *recipe)
+	}
+	sort.Slice(recipes, func(i, j int) bool {
+		return recipes[i].Name < recipes[j].Name
+	})
+	return recipes
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadYoctoLicenseManifest(t *testing.T) {
+	recipes, err := ReadYoctoLicenseManifest(filepath.Join("..", "testdata", "yocto", "core-image-minimal-qemux86-64"))
+	require.NoError(t, err)
+	require.Len(t, recipes, 4)
+	assert.Equal(t, "base-files:3.0.14", recipes[0].Id())
+	assert.Equal(t, YoctoRecipe{
+		Name:     "glibc",
+		Version:  "2.38+git0+36f2487f13",
+		License:  "GPL-2.0-only & LGPL-2.1-or-later",
+		Packages: []string{"libc6", "ldconfig"},
+	}, recipes[2])
+	// The last paragraph isn't followed by an empty line.
+	assert.Equal(t, []string{"update-alternatives-opkg"}, recipes[3].Packages)
+}
+
+func TestReadYoctoSpdxRecipes(t *testing.T) {
+	recipes, err := ReadYoctoSpdxRecipes(filepath.Join("..", "testdata", "yocto", "spdx", "recipes"))
+	require.NoError(t, err)
+	// The source files described by the documents are excluded.
+	require.Len(t, recipes, 2)
+	assert.Equal(t, "base-files", recipes[0].Name)
+	assert.Empty(t, recipes[0].DownloadLocation)
+	assert.Equal(t, "busybox:1.36.1", recipes[1].Id())
+	assert.Equal(t, "cpe:2.3:*:*:busybox:1.36.1:*:*:*:*:*:*:*", recipes[1].Cpe)
+}
@@ -0,0 +1,288 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+const (
+	ZigManifestFileName = "build.zig.zon"
+	// The prefix of a multihash, which holds a sha256 digest: the sha2-256 function code (0x12) followed by the digest length (0x20).
+	zigSha256MultihashPrefix = "1220"
+)
+
+// ZigPackage represents the fields of a build.zig.zon file, which are used by the build-info.
+type ZigPackage struct {
+	Name    string
+	Version string
+	// The dependencies of the package, sorted by their names.
+	Dependencies []ZigDependency
+}
+
+// ZigDependency represents a dependency declared in a build.zig.zon file.
+type ZigDependency struct {
+	// The name, by which the dependency is declared.
+	Name string
+	Url  string
+	// The hash of the package, which is a multihash (in Zig 0.11-0.13), or a '<name>-<version>-<hash>' string (since Zig 0.14).
+	Hash string
+	// The path of a local dependency, relative to the directory of the build.zig.zon file.
+	Path string
+	// Lazy dependencies are fetched only if the build uses them.
+	Lazy bool
+}
+
+// GetSha256 returns the sha256 digest in the hash of the dependency, if it's a sha256 multihash.
+func (zd *ZigDependency) GetSha256() string {
+	if len(zd.Hash) == len(zigSha256MultihashPrefix)+64 && strings.HasPrefix(zd.Hash, zigSha256MultihashPrefix) {
+		return strings.TrimPrefix(zd.Hash, zigSha256MultihashPrefix)
+	}
+	return ""
+}
+
+// ReadZigPackage returns the package described by the build.zig.zon file in the given directory.
+func ReadZigPackage(srcPath string) (*ZigPackage, error) {
+	content, err := os.ReadFile(filepath.Join(srcPath, ZigManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	value, err := ParseZon(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing the %s file in %s: %s", ZigManifestFileName, srcPath, err.Error())
+	}
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the %s file in %s doesn't contain a struct literal", ZigManifestFileName, srcPath)
+	}
+	pkg := &ZigPackage{Name: getZonString(fields, "name"), Version: getZonString(fields, "version")}
+	dependencies, _ := fields["dependencies"].(map[string]interface{})
+	for name, value := range dependencies {
+		dependencyFields, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pkg.Dependencies = append(pkg.Dependencies, ZigDependency{
+			Name: name,
+			Url:  getZonString(dependencyFields, "url"),
+			Hash: getZonString(dependencyFields, "hash"),
+			Path: getZonString(dependencyFields, "path"),
+			Lazy: getZonString(dependencyFields, "lazy") == "true",
+		})
+	}
+	sort.Slice(pkg.Dependencies, func(i, j int) bool {
+		return pkg.Dependencies[i].Name < pkg.Dependencies[j].Name
+	})
+	return pkg, nil
+}
+
+func getZonString(fields map[string]interface{}, name string) string {
+	value, _ := fields[name].(string)
+	return value
+}
+
+// GetZigGlobalCacheDir returns the global cache directory of Zig, to which the packages are fetched.
+// The directory can be set using the ZIG_GLOBAL_CACHE_DIR environment variable. Otherwise, it's %LOCALAPPDATA%\zig on Windows, or $XDG_CACHE_HOME/zig (~/.cache/zig by default) on other platforms.
+func GetZigGlobalCacheDir() (string, error) {
+	if cacheDir := os.Getenv("ZIG_GLOBAL_CACHE_DIR"); cacheDir != "" {
+		return cacheDir, nil
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("LOCALAPPDATA"), "zig"), nil
+	}
+	if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
+		return filepath.Join(cacheHome, "zig"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".cache", "zig"), nil
+}
+
+// GetZigPackageDir returns the directory, to which the package with the given hash is fetched in the global cache.
+func GetZigPackageDir(cacheDir, hash string) string {
+	return filepath.Join(cacheDir, "p", hash)
+}
+
+// ParseZon parses a ZON (Zig Object Notation) document.
+// Struct literals are returned as maps, tuples as slices, and strings, enum literals (such as '.hello') and other values (such as numbers and booleans) as strings.
+func ParseZon(content string) (interface{}, error) {
+	parser := &zonParser{content: content}
+	value, err := parser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if parser.skipWhitespace(); parser.pos < len(parser.content) {
+		return nil, parser.errorf("unexpected content after the top-level value")
+	}
+	return value, nil
+}
+
+type zonParser struct {
+	content string
+	pos     int
+}
+
+func (zp *zonParser) errorf(format string, args ...interface{}) error {
+	line := strings.Count(zp.content[:zp.pos], "\n") + 1
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// Skips whitespaces and comments.
+func (zp *zonParser) skipWhitespace() {
+	for zp.pos < len(zp.content) {
+		switch {
+		case strings.HasPrefix(zp.content[zp.pos:], "//"):
+			if end := strings.IndexByte(zp.content[zp.pos:], '\n'); end >= 0 {
+				zp.pos += end
+			} else {
+				zp.pos = len(zp.content)
+			}
+		case strings.ContainsRune(" \t\r\n", rune(zp.content[zp.pos])):
+			zp.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (zp *zonParser) parseValue() (interface{}, error) {
+	zp.skipWhitespace()
+	switch {
+	case zp.pos >= len(zp.content):
+		return nil, zp.errorf("unexpected end of content")
+	case strings.HasPrefix(zp.content[zp.pos:], ".{"):
+		zp.pos += 2
+		return zp.parseInitializer()
+	case zp.content[zp.pos] == '.':
+		zp.pos++
+		return zp.parseIdentifier()
+	case zp.content[zp.pos] == '"':
+		return zp.parseString()
+	}
+	start := zp.pos
+	for zp.pos < len(zp.content) && !strings.ContainsRune(" \t\r\n,}", rune(zp.content[zp.pos])) {
+		zp.pos++
+	}
+	if start == zp.pos {
+		return nil, zp.errorf("unexpected character '%c'", zp.content[zp.pos])
+	}
+	return zp.content[start:zp.pos], nil
+}
+
+// Parses the content of an anonymous struct literal (.{ .name = value, ... }) or tuple (.{ value, ... }), after its opening '.{'.
+func (zp *zonParser) parseInitializer() (interface{}, error) {
+	var fields map[string]interface{}
+	var elements []interface{}
+	for {
+		zp.skipWhitespace()
+		if zp.pos < len(zp.content) && zp.content[zp.pos] == '}' {
+			zp.pos++
+			break
+		}
+		name, isField, err := zp.parseFieldName()
+		if err != nil {
+			return nil, err
+		}
+		value, err := zp.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if isField {
+			if fields == nil {
+				fields = make(map[string]interface{})
+			}
+			fields[name] = value
+		} else {
+			elements = append(elements, value)
+		}
+		zp.skipWhitespace()
+		if zp.pos < len(zp.content) && zp.content[zp.pos] == ',' {
+			zp.pos++
+		} else if zp.pos >= len(zp.content) || zp.content[zp.pos] != '}' {
+			return nil, zp.errorf("expected ',' or '}'")
+		}
+	}
+	if elements != nil {
+		return elements, nil
+	}
+	if fields == nil {
+		// An empty initializer.
+		return map[string]interface{}{}, nil
+	}
+	return fields, nil
+}
+
+// Parses the '.name =' prefix of a struct field, if the next element is a field. Otherwise, the position isn't changed.
+func (zp *zonParser) parseFieldName() (name string, isField bool, err error) {
+	start := zp.pos
+	if zp.pos >= len(zp.content) || zp.content[zp.pos] != '.' || strings.HasPrefix(zp.content[zp.pos:], ".{") {
+		return
+	}
+	zp.pos++
+	if name, err = zp.parseIdentifier(); err != nil {
+		return
+	}
+	zp.skipWhitespace()
+	if zp.pos < len(zp.content) && zp.content[zp.pos] == '=' {
+		zp.pos++
+		isField = true
+		return
+	}
+	// An enum literal in a tuple.
+	zp.pos = start
+	return "", false, nil
+}
+
+// Parses an identifier, which is either a bare identifier or a quoted identifier, such as @"zig-clap".
+func (zp *zonParser) parseIdentifier() (string, error) {
+	if strings.HasPrefix(zp.content[zp.pos:], `@"`) {
+		zp.pos++
+		return zp.parseString()
+	}
+	start := zp.pos
+	for zp.pos < len(zp.content) && (zp.content[zp.pos] == '_' || zp.content[zp.pos] >= 'a' && zp.content[zp.pos] <= 'z' ||
+		zp.content[zp.pos] >= 'A' && zp.content[zp.pos] <= 'Z' || zp.content[zp.pos] >= '0' && zp.content[zp.pos] <= '9') {
+		zp.pos++
+	}
+	if start == zp.pos {
+		return "", zp.errorf("expected an identifier")
+	}
+	return zp.content[start:zp.pos], nil
+}
+
+func (zp *zonParser) parseString() (string, error) {
+	// Skip the opening quote.
+	zp.pos++
+	var value strings.Builder
+	for zp.pos < len(zp.content) {
+		c := zp.content[zp.pos]
+		switch {
+		case c == '"':
+			zp.pos++
+			return value.String(), nil
+		case c == '\n':
+			return "", zp.errorf("unterminated string")
+		case c == '\\' && zp.pos+1 < len(zp.content):
+			zp.pos++
+			switch escaped := zp.content[zp.pos]; escaped {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			case 'r':
+				value.WriteByte('\r')
+			default:
+				value.WriteByte(escaped)
+			}
+		default:
+			value.WriteByte(c)
+		}
+		zp.pos++
+	}
+	return "", zp.errorf("unterminated string")
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadZigPackage(t *testing.T) {
+	pkg, err := ReadZigPackage(filepath.Join("..", "testdata", "zig", "project"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", pkg.Name)
+	assert.Equal(t, "0.1.0", pkg.Version)
+	assert.Equal(t, []ZigDependency{
+		{Name: "util", Path: "libs/util"},
+		{Name: "zap", Url: "https://github.com/zigzap/zap/archive/refs/tags/v0.8.0.tar.gz", Hash: "1220002d24d73672fe8b1e39717c0671598acc8ec27b8af2e1caf623a4fd0ce0d1bd"},
+		{
+			Name: "zig-network",
+			Url:  "git+https://github.com/ikskuh/zig-network#8db1aa2f5efdf1e2ff6dd5f5f8efe1b4f44ff978",
+			Hash: "122040d5e6b5b1a0c6b7b2a2c2d9e2ba1f8d8c6da7a7d4d5a934a4fcd2d1f0c3a4b5",
+			Lazy: true,
+		},
+	}, pkg.Dependencies)
+}
+
+func TestParseZon(t *testing.T) {
+	value, err := ParseZon(`.{
+    // A comment.
+    .name = "a \"quoted\" name",
+    .@"quoted-field" = .enum_literal,
+    .tuple = .{ "a", .b, 1 },
+    .empty = .{},
+    .number = 0x10,
+}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":         `a "quoted" name`,
+		"quoted-field": "enum_literal",
+		"tuple":        []interface{}{"a", "b", "1"},
+		"empty":        map[string]interface{}{},
+		"number":       "0x10",
+	}, value)
+
+	_, err = ParseZon(`.{ .name = "unterminated }`)
+	assert.Error(t, err)
+	_, err = ParseZon(`.{ .name = "name" .version = "1.0.0" }`)
+	assert.Error(t, err)
+}
+
+func TestGetZigDependencySha256(t *testing.T) {
+	dependency := ZigDependency{Hash: "1220002d24d73672fe8b1e39717c0671598acc8ec27b8af2e1caf623a4fd0ce0d1bd"}
+	assert.Equal(t, "002d24d73672fe8b1e39717c0671598acc8ec27b8af2e1caf623a4fd0ce0d1bd", dependency.GetSha256())
+	dependency = ZigDependency{Hash: "clap-0.10.0-oBajB434AQBDh-Ei3YtoKIRxZacVPF1iSwp3IX_ZB8f0"}
+	assert.Empty(t, dependency.GetSha256())
+}
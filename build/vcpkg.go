@@ -0,0 +1,202 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency properties, which hold the triplets, ABI hash and features of an installed port, and the URLs and SHA512 checksums of the sources it was built from.
+	VcpkgTripletProperty  = "vcpkg.triplet"
+	VcpkgAbiProperty      = "vcpkg.abi"
+	VcpkgFeaturesProperty = "vcpkg.features"
+	VcpkgUrlProperty      = "vcpkg.url"
+	VcpkgSha512Property   = "vcpkg.sha512"
+	// The dependency properties, which hold the repository of the registry a port is installed from, and the commit it's locked to in the vcpkg-lock.json file.
+	VcpkgRegistryProperty       = "vcpkg.registry"
+	VcpkgRegistryCommitProperty = "vcpkg.registryCommit"
+)
+
+type VcpkgModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+	// The installed tree, which contains the 'vcpkg/status' file.
+	installedDir string
+}
+
+// Pass an empty string for srcPath to find the vcpkg.json file in the working directory or in its parents.
+func newVcpkgModule(srcPath string, containingBuild *Build) (*VcpkgModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.VcpkgManifestFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	manifest, err := buildutils.ReadVcpkgManifest(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := manifest.Name
+	if name == "" {
+		name = filepath.Base(srcPath)
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the %s file. Using the directory name: %s as module name.", buildutils.VcpkgManifestFileName, name))
+	} else if manifest.Version != "" {
+		name += ":" + manifest.Version
+	}
+
+	return &VcpkgModule{name: name, srcPath: srcPath, installedDir: filepath.Join(srcPath, buildutils.VcpkgInstalledDirName), containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the ports installed in the installed tree of the project.
+func (vm *VcpkgModule) CalcDependencies() error {
+	started := time.Now()
+	if !vm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := vm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: vm.name, Type: entities.Vcpkg, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(vm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return vm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (vm *VcpkgModule) SetName(name string) {
+	vm.name = name
+}
+
+func (vm *VcpkgModule) SetProperties(properties map[string]string) {
+	vm.properties = properties
+}
+
+// SetInstalledDir sets the installed tree of the project. By default, it's the 'vcpkg_installed' directory of the project, in which vcpkg installs the ports in manifest mode.
+func (vm *VcpkgModule) SetInstalledDir(installedDir string) {
+	vm.installedDir = installedDir
+}
+
+func (vm *VcpkgModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !vm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: vm.name, ModuleType: entities.Vcpkg, Artifacts: artifacts}
+	return vm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (vm *VcpkgModule) loadDependencies() ([]entities.Dependency, error) {
+	manifest, err := buildutils.ReadVcpkgManifest(vm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := buildutils.ReadVcpkgLock(vm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	ports, err := buildutils.ReadVcpkgInstalledPorts(vm.installedDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the installed tree %s: %s. Run 'vcpkg install' to install the project's dependencies", vm.installedDir, err.Error())
+	}
+
+	// A port, which is installed for several triplets (such as the target and host triplets), is added as a single dependency.
+	portsByName := make(map[string][]buildutils.VcpkgPort)
+	var names []string
+	for _, port := range ports {
+		if _, ok := portsByName[port.Name]; !ok {
+			names = append(names, port.Name)
+		}
+		portsByName[port.Name] = append(portsByName[port.Name], port)
+	}
+	dependenciesMap := make(map[string]entities.Dependency)
+	dependenciesGraph := make(map[string][]string)
+	requested := make(map[string]bool)
+	for _, name := range names {
+		dependency, err := vm.createVcpkgDependency(portsByName[name], manifest.Configuration, lock)
+		if err != nil {
+			return nil, err
+		}
+		dependenciesMap[dependency.Id] = dependency
+		for _, port := range portsByName[name] {
+			for _, dependencyName := range port.Depends {
+				if dependencyPorts, ok := portsByName[dependencyName]; ok && !slices.Contains(dependenciesGraph[dependency.Id], dependencyPorts[0].Id()) {
+					dependenciesGraph[dependency.Id] = append(dependenciesGraph[dependency.Id], dependencyPorts[0].Id())
+					requested[dependencyName] = true
+				}
+			}
+		}
+	}
+
+	directDependencies := manifest.Dependencies
+	if len(directDependencies) == 0 {
+		// Without dependencies in the manifest (for example, in classic mode), the ports, which no other port depends on, are considered as direct dependencies.
+		for _, name := range names {
+			if !requested[name] {
+				directDependencies = append(directDependencies, name)
+			}
+		}
+	}
+	for _, name := range directDependencies {
+		dependencyPorts, ok := portsByName[name]
+		if !ok {
+			vm.containingBuild.logger.Debug(fmt.Sprintf("The port %s isn't installed in %s and is skipped.", name, vm.installedDir))
+			continue
+		}
+		dependenciesGraph[vm.name] = append(dependenciesGraph[vm.name], dependencyPorts[0].Id())
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(vm.name, emptyRequestedBy, dependenciesMap, dependenciesGraph, vm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(dependenciesMap), nil
+}
+
+// Creates the build-info dependency of a port, from its installations for one or more triplets.
+// The SHA512 checksums of the port's sources (which vcpkg verifies when downloading them) are read from the SPDX file installed with it.
+func (vm *VcpkgModule) createVcpkgDependency(ports []buildutils.VcpkgPort, configuration *buildutils.VcpkgConfiguration, lock buildutils.VcpkgLock) (entities.Dependency, error) {
+	port := ports[0]
+	dependency := entities.Dependency{Id: port.Id(), Type: "vcpkg"}
+	var triplets, urls, sha512s []string
+	for _, tripletPort := range ports {
+		triplets = append(triplets, tripletPort.Triplet)
+	}
+	sources, err := buildutils.ReadVcpkgPortSources(vm.installedDir, port)
+	if err != nil {
+		return dependency, err
+	}
+	for _, source := range sources {
+		urls = append(urls, source.Url)
+		if source.Sha512 != "" {
+			sha512s = append(sha512s, source.Sha512)
+		}
+	}
+	registry := configuration.GetPortRegistry(port.Name)
+	setDependencyProperties(&dependency, map[string]string{
+		VcpkgTripletProperty:        strings.Join(triplets, ","),
+		VcpkgAbiProperty:            port.Abi,
+		VcpkgFeaturesProperty:       strings.Join(port.Features, ","),
+		VcpkgUrlProperty:            strings.Join(urls, ","),
+		VcpkgSha512Property:         strings.Join(sha512s, ","),
+		VcpkgRegistryProperty:       registry.Repository,
+		VcpkgRegistryCommitProperty: lock.GetRegistryCommit(registry),
+	})
+	return dependency, nil
+}
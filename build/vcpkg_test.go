@@ -0,0 +1,60 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForVcpkgProject(t *testing.T) {
+	service := NewBuildInfoService()
+	vcpkgBuild, err := service.GetOrCreateBuild("build-info-go-test-vcpkg", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, vcpkgBuild.Clean())
+	}()
+	vcpkgModule, err := vcpkgBuild.AddVcpkgModule(filepath.Join("testdata", "vcpkg", "project"))
+	if assert.NoError(t, err) {
+		err = vcpkgModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := vcpkgBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Vcpkg, module.Type)
+		assert.Equal(t, "hello:1.0.0#1", module.Id)
+
+		// The boost-asio port was removed, so it's not included.
+		assert.Len(t, module.Dependencies, 6)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "vcpkg", dependency.Type)
+			assert.Equal(t, "x64-linux", dependency.Properties[VcpkgTripletProperty])
+			assert.NotEmpty(t, dependency.Properties[VcpkgAbiProperty])
+			switch dependency.Id {
+			case "fmt:10.1.1":
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+				assert.Equal(t, "git+https://github.com/fmtlib/fmt@10.1.1", dependency.Properties[VcpkgUrlProperty])
+				assert.Equal(t, "7a5024a35d1370b2bf7448e8c8dcac5704618b5c902ea40326e09ea05be636bb2dacd72cb4d2140169c596a52689be7171aa467b455db005daaaa1d6fce7ad32", dependency.Properties[VcpkgSha512Property])
+				assert.Equal(t, "https://github.com/microsoft/vcpkg", dependency.Properties[VcpkgRegistryProperty])
+				assert.Equal(t, "3426db05b996481ca31e95fff3734cf23e0f51bc", dependency.Properties[VcpkgRegistryCommitProperty])
+			case "curl:8.2.1":
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+				assert.Equal(t, "openssl,ssl", dependency.Properties[VcpkgFeaturesProperty])
+			case "beicode:1.0.0":
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+				assert.Equal(t, "https://github.com/northwindtraders/vcpkg-registry", dependency.Properties[VcpkgRegistryProperty])
+				assert.Equal(t, "dacf4de488094a384ca2c202b923ccc097956e0c", dependency.Properties[VcpkgRegistryCommitProperty])
+			case "zlib:1.3#1":
+				assert.Equal(t, [][]string{{"curl:8.2.1", module.Id}}, dependency.RequestedBy)
+			case "openssl:3.1.2":
+				assert.Equal(t, [][]string{{"curl:8.2.1", module.Id}}, dependency.RequestedBy)
+			case "vcpkg-cmake:2023-05-04":
+				assert.Len(t, dependency.RequestedBy, 4)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
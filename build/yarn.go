@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 )
 
 const minSupportedYarnVersion = "2.4.0"
@@ -19,6 +20,7 @@ const minSupportedYarnVersion = "2.4.0"
 type YarnModule struct {
 	containingBuild          *Build
 	name                     string
+	properties               map[string]string
 	srcPath                  string
 	executablePath           string
 	yarnArgs                 []string
@@ -62,6 +64,7 @@ func newYarnModule(srcPath string, containingBuild *Build) (*YarnModule, error)
 
 // Build builds the project, collects its dependencies and saves them in the build-info module.
 func (ym *YarnModule) Build() error {
+	started := time.Now()
 	err := runYarnCommand(ym.executablePath, ym.srcPath, ym.yarnArgs...)
 	if err != nil {
 		return err
@@ -78,7 +81,9 @@ func (ym *YarnModule) Build() error {
 		return err
 	}
 	buildInfoModule := entities.Module{Id: ym.name, Type: entities.Npm, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(ym.properties)
 	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
 	return ym.containingBuild.SaveBuildInfo(buildInfo)
 }
 
@@ -133,6 +138,10 @@ func (ym *YarnModule) SetName(name string) {
 	ym.name = name
 }
 
+func (ym *YarnModule) SetProperties(properties map[string]string) {
+	ym.properties = properties
+}
+
 func (ym *YarnModule) SetArgs(yarnArgs []string) {
 	ym.yarnArgs = yarnArgs
 }
@@ -0,0 +1,117 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the license of a recipe, the packages it built that were installed in the image, the location its source was fetched from, and its CPE identifier.
+	YoctoLicenseProperty          = "yocto.license"
+	YoctoPackagesProperty         = "yocto.packages"
+	YoctoDownloadLocationProperty = "yocto.downloadLocation"
+	YoctoCpeProperty              = "yocto.cpe"
+)
+
+type YoctoModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath if the license.manifest file of the image (or the SPDX documents of its recipes) is in the working directory.
+// The license.manifest file is in the license deploy directory of the image, for example: 'tmp/deploy/licenses/core-image-minimal-qemux86-64'.
+func newYoctoModule(srcPath string, containingBuild *Build) (*YoctoModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The name of the directory is the name of the image, for example: 'core-image-minimal-qemux86-64'.
+	return &YoctoModule{name: filepath.Base(srcPath), srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the recipes, whose packages were installed in the image.
+func (ym *YoctoModule) CalcDependencies() error {
+	started := time.Now()
+	if !ym.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := ym.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: ym.name, Type: entities.Yocto, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(ym.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return ym.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (ym *YoctoModule) SetName(name string) {
+	ym.name = name
+}
+
+func (ym *YoctoModule) SetProperties(properties map[string]string) {
+	ym.properties = properties
+}
+
+func (ym *YoctoModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !ym.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: ym.name, ModuleType: entities.Yocto, Artifacts: artifacts}
+	return ym.containingBuild.SavePartialBuildInfo(partial)
+}
+
+// Returns the recipes from the license.manifest file, or (if it doesn't exist) from the SPDX documents of the recipes.
+func (ym *YoctoModule) loadRecipes() ([]buildutils.YoctoRecipe, error) {
+	exists, err := utils.IsFileExists(filepath.Join(ym.srcPath, buildutils.YoctoLicenseManifestFileName), true)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return buildutils.ReadYoctoLicenseManifest(ym.srcPath)
+	}
+	recipes, err := buildutils.ReadYoctoSpdxRecipes(ym.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return nil, fmt.Errorf("neither a %s file nor SPDX documents of recipes were found in %s", buildutils.YoctoLicenseManifestFileName, ym.srcPath)
+	}
+	return recipes, nil
+}
+
+func (ym *YoctoModule) loadDependencies() ([]entities.Dependency, error) {
+	recipes, err := ym.loadRecipes()
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []entities.Dependency
+	for _, recipe := range recipes {
+		dependency := entities.Dependency{Id: recipe.Id(), Type: "recipe"}
+		setDependencyProperties(&dependency, map[string]string{
+			YoctoLicenseProperty:          recipe.License,
+			YoctoPackagesProperty:         strings.Join(recipe.Packages, ","),
+			YoctoDownloadLocationProperty: recipe.DownloadLocation,
+			YoctoCpeProperty:              recipe.Cpe,
+		})
+		// The manifests don't describe which recipes depend on other recipes, so all of them are considered as direct dependencies of the image.
+		dependency.RequestedBy = [][]string{{ym.name}}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
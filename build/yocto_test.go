@@ -0,0 +1,84 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForYoctoImage(t *testing.T) {
+	service := NewBuildInfoService()
+	yoctoBuild, err := service.GetOrCreateBuild("build-info-go-test-yocto", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, yoctoBuild.Clean())
+	}()
+	yoctoModule, err := yoctoBuild.AddYoctoModule(filepath.Join("testdata", "yocto", "core-image-minimal-qemux86-64"))
+	if assert.NoError(t, err) {
+		err = yoctoModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := yoctoBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Yocto, module.Type)
+		assert.Equal(t, "core-image-minimal-qemux86-64", module.Id)
+
+		// The packages are grouped by their recipes.
+		assert.Len(t, module.Dependencies, 4)
+		for _, dependency := range module.Dependencies {
+			assert.Equal(t, "recipe", dependency.Type)
+			assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			switch dependency.Id {
+			case "busybox:1.36.1":
+				assert.Equal(t, map[string]string{
+					YoctoLicenseProperty:  "GPL-2.0-only & bzip2-1.0.4",
+					YoctoPackagesProperty: "busybox,busybox-syslog",
+				}, dependency.Properties)
+			case "glibc:2.38+git0+36f2487f13":
+				assert.Equal(t, "libc6,ldconfig", dependency.Properties[YoctoPackagesProperty])
+			case "base-files:3.0.14", "opkg-utils:0.6.1":
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
+
+func TestGenerateBuildInfoForYoctoSpdxRecipes(t *testing.T) {
+	service := NewBuildInfoService()
+	yoctoBuild, err := service.GetOrCreateBuild("build-info-go-test-yocto-spdx", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, yoctoBuild.Clean())
+	}()
+	yoctoModule, err := yoctoBuild.AddYoctoModule(filepath.Join("testdata", "yocto", "spdx", "recipes"))
+	if assert.NoError(t, err) {
+		yoctoModule.SetName("core-image-minimal")
+		err = yoctoModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := yoctoBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, "core-image-minimal", module.Id)
+		if assert.Len(t, module.Dependencies, 2) {
+			assert.Equal(t, "base-files:3.0.14", module.Dependencies[0].Id)
+			// The download location is unknown.
+			assert.Equal(t, map[string]string{YoctoLicenseProperty: "GPL-2.0-only"}, module.Dependencies[0].Properties)
+			assert.Equal(t, "busybox:1.36.1", module.Dependencies[1].Id)
+			assert.Equal(t, map[string]string{
+				YoctoLicenseProperty:          "GPL-2.0-only AND bzip2-1.0.4",
+				YoctoDownloadLocationProperty: "https://busybox.net/downloads/busybox-1.36.1.tar.bz2",
+				YoctoCpeProperty:              "cpe:2.3:*:*:busybox:1.36.1:*:*:*:*:*:*:*",
+			}, module.Dependencies[1].Properties)
+		}
+	}
+
+	// A directory without a license.manifest file or SPDX documents.
+	yoctoModule, err = yoctoBuild.AddYoctoModule(filepath.Join("testdata", "yocto"))
+	if assert.NoError(t, err) {
+		assert.Error(t, yoctoModule.CalcDependencies())
+	}
+}
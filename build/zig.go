@@ -0,0 +1,167 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+const (
+	// The dependency properties, which hold the URL of a package and its hash, as declared in the build.zig.zon file.
+	ZigUrlProperty  = "zig.url"
+	ZigHashProperty = "zig.hash"
+)
+
+type ZigModule struct {
+	containingBuild *Build
+	name            string
+	properties      map[string]string
+	srcPath         string
+}
+
+// Pass an empty string for srcPath to find the build.zig.zon file in the working directory or in its parents.
+func newZigModule(srcPath string, containingBuild *Build) (*ZigModule, error) {
+	var err error
+	if srcPath == "" {
+		srcPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		srcPath, err = utils.FindFileInDirAndParents(srcPath, buildutils.ZigManifestFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read module name
+	pkg, err := buildutils.ReadZigPackage(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	name := getZigPackageId(pkg, filepath.Base(srcPath))
+	if pkg.Name == "" {
+		containingBuild.logger.Debug(fmt.Sprintf("No name is defined in the %s file. Using the directory name: %s as module name.", buildutils.ZigManifestFileName, name))
+	}
+
+	return &ZigModule{name: name, srcPath: srcPath, containingBuild: containingBuild}, nil
+}
+
+// CalcDependencies collects the dependencies declared in the build.zig.zon file, and (recursively) the dependencies of the packages fetched to the global cache.
+func (zm *ZigModule) CalcDependencies() error {
+	started := time.Now()
+	if !zm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to collect the project's dependencies")
+	}
+	buildInfoDependencies, err := zm.loadDependencies()
+	if err != nil {
+		return err
+	}
+	buildInfoModule := entities.Module{Id: zm.name, Type: entities.Zig, Dependencies: buildInfoDependencies}
+	buildInfoModule.AddProperties(zm.properties)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}}
+	setModulesTiming(buildInfo, started)
+
+	return zm.containingBuild.SaveBuildInfo(buildInfo)
+}
+
+func (zm *ZigModule) SetName(name string) {
+	zm.name = name
+}
+
+func (zm *ZigModule) SetProperties(properties map[string]string) {
+	zm.properties = properties
+}
+
+func (zm *ZigModule) AddArtifacts(artifacts ...entities.Artifact) error {
+	if !zm.containingBuild.buildNameAndNumberProvided() {
+		return errors.New("a build name must be provided in order to add artifacts")
+	}
+	partial := &entities.Partial{ModuleId: zm.name, ModuleType: entities.Zig, Artifacts: artifacts}
+	return zm.containingBuild.SavePartialBuildInfo(partial)
+}
+
+func (zm *ZigModule) loadDependencies() ([]entities.Dependency, error) {
+	pkg, err := buildutils.ReadZigPackage(zm.srcPath)
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := buildutils.GetZigGlobalCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	collector := &zigDependenciesCollector{
+		containingBuild:   zm.containingBuild,
+		cacheDir:          cacheDir,
+		dependenciesMap:   make(map[string]entities.Dependency),
+		dependenciesGraph: make(map[string][]string),
+	}
+	if err = collector.collect(zm.name, zm.srcPath, pkg); err != nil {
+		return nil, err
+	}
+
+	emptyRequestedBy := [][]string{{}}
+	populateRequestedByField(zm.name, emptyRequestedBy, collector.dependenciesMap, collector.dependenciesGraph, zm.containingBuild.getRequestedByLimits())
+	return dependenciesMapToList(collector.dependenciesMap), nil
+}
+
+type zigDependenciesCollector struct {
+	containingBuild   *Build
+	cacheDir          string
+	dependenciesMap   map[string]entities.Dependency
+	dependenciesGraph map[string][]string
+}
+
+// Adds the dependencies of the package in the given directory to the dependencies map and graph, and collects their own dependencies.
+// Packages, which weren't fetched to the global cache (such as lazy dependencies, which the build doesn't use), are added without their dependencies.
+func (zdc *zigDependenciesCollector) collect(pkgId, pkgDir string, pkg *buildutils.ZigPackage) error {
+	for _, zigDependency := range pkg.Dependencies {
+		dependencyDir := filepath.Join(pkgDir, zigDependency.Path)
+		dependency := entities.Dependency{Type: "path"}
+		if zigDependency.Path == "" {
+			dependencyDir = buildutils.GetZigPackageDir(zdc.cacheDir, zigDependency.Hash)
+			// The hash of the package (but not of its archive) is a multihash of its files, which is usually a sha256 digest.
+			dependency = entities.Dependency{Type: "zig", Checksum: entities.Checksum{Sha256: zigDependency.GetSha256()}}
+			setDependencyProperties(&dependency, map[string]string{ZigUrlProperty: zigDependency.Url, ZigHashProperty: zigDependency.Hash})
+		}
+		dependencyPkg := &buildutils.ZigPackage{}
+		exists, err := utils.IsFileExists(filepath.Join(dependencyDir, buildutils.ZigManifestFileName), true)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if dependencyPkg, err = buildutils.ReadZigPackage(dependencyDir); err != nil {
+				return err
+			}
+		} else if zigDependency.Path == "" {
+			zdc.containingBuild.logger.Debug(fmt.Sprintf("The package %s wasn't found in %s. Run 'zig build --fetch' to fetch it.", zigDependency.Name, dependencyDir))
+		}
+		dependency.Id = getZigPackageId(dependencyPkg, zigDependency.Name)
+		zdc.dependenciesGraph[pkgId] = append(zdc.dependenciesGraph[pkgId], dependency.Id)
+		if _, ok := zdc.dependenciesMap[dependency.Id]; ok {
+			continue
+		}
+		zdc.dependenciesMap[dependency.Id] = dependency
+		if err = zdc.collect(dependency.Id, dependencyDir, dependencyPkg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the ID of a package, from its name and version. The default name is used if the package doesn't define a name.
+func getZigPackageId(pkg *buildutils.ZigPackage, defaultName string) string {
+	name := pkg.Name
+	if name == "" {
+		return defaultName
+	}
+	if pkg.Version != "" {
+		name += ":" + pkg.Version
+	}
+	return name
+}
@@ -0,0 +1,56 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBuildInfoForZigProject(t *testing.T) {
+	t.Setenv("ZIG_GLOBAL_CACHE_DIR", filepath.Join("testdata", "zig", "cache"))
+	service := NewBuildInfoService()
+	zigBuild, err := service.GetOrCreateBuild("build-info-go-test-zig", "1")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, zigBuild.Clean())
+	}()
+	zigModule, err := zigBuild.AddZigModule(filepath.Join("testdata", "zig", "project"))
+	if assert.NoError(t, err) {
+		err = zigModule.CalcDependencies()
+		assert.NoError(t, err)
+		buildInfo, err := zigBuild.ToBuildInfo()
+		assert.NoError(t, err)
+		assert.Len(t, buildInfo.Modules, 1)
+		module := buildInfo.Modules[0]
+		assert.Equal(t, entities.Zig, module.Type)
+		assert.Equal(t, "hello:0.1.0", module.Id)
+
+		assert.Len(t, module.Dependencies, 4)
+		for _, dependency := range module.Dependencies {
+			switch dependency.Id {
+			case "zap:0.8.0":
+				assert.Equal(t, "zig", dependency.Type)
+				assert.Equal(t, entities.Checksum{Sha256: "002d24d73672fe8b1e39717c0671598acc8ec27b8af2e1caf623a4fd0ce0d1bd"}, dependency.Checksum)
+				assert.Equal(t, "https://github.com/zigzap/zap/archive/refs/tags/v0.8.0.tar.gz", dependency.Properties[ZigUrlProperty])
+				assert.ElementsMatch(t, [][]string{{module.Id}, {"clap:0.10.0", "util:0.0.1", module.Id}}, dependency.RequestedBy)
+			case "util:0.0.1":
+				assert.Equal(t, "path", dependency.Type)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			case "clap:0.10.0":
+				assert.Equal(t, "zig", dependency.Type)
+				// The hash of the package isn't a multihash.
+				assert.True(t, dependency.Checksum.IsEmpty())
+				assert.Equal(t, "clap-0.10.0-oBajB434AQBDh-Ei3YtoKIRxZacVPF1iSwp3IX_ZB8f0", dependency.Properties[ZigHashProperty])
+				assert.Equal(t, [][]string{{"util:0.0.1", module.Id}}, dependency.RequestedBy)
+			case "zig-network":
+				// A lazy dependency, which wasn't fetched.
+				assert.Equal(t, entities.Checksum{Sha256: "40d5e6b5b1a0c6b7b2a2c2d9e2ba1f8d8c6da7a7d4d5a934a4fcd2d1f0c3a4b5"}, dependency.Checksum)
+				assert.Equal(t, [][]string{{module.Id}}, dependency.RequestedBy)
+			default:
+				assert.Fail(t, "Unexpected dependency "+dependency.Id)
+			}
+		}
+	}
+}
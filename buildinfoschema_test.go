@@ -7,6 +7,7 @@ import (
 
 	"github.com/jfrog/build-info-go/build/testdata"
 	buildutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/build-info-go/utils"
 
 	"github.com/stretchr/testify/assert"
@@ -39,9 +40,7 @@ func TestYarnSchema(t *testing.T) {
 // install        - Install the project, if needed
 func validateBuildInfoSchema(t *testing.T, commandName, pathInTestData string, install func()) {
 	// Load build-info schema
-	schema, err := os.ReadFile("buildinfo-schema.json")
-	assert.NoError(t, err)
-	schemaLoader := gojsonschema.NewBytesLoader(schema)
+	schemaLoader := gojsonschema.NewBytesLoader(entities.GetBuildInfoSchema())
 
 	// Prepare test project
 	cleanUp := prepareProject(t, pathInTestData, install)
@@ -6,27 +6,63 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/jfrog/build-info-go/build"
+	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/build-info-go/utils"
 	"github.com/jfrog/build-info-go/utils/pythonutils"
+	"github.com/jfrog/build-info-go/utils/signutils"
 	"github.com/pkg/errors"
 	clitool "github.com/urfave/cli/v2"
 )
 
 const (
-	formatFlag    = "format"
-	cycloneDxXml  = "cyclonedx/xml"
-	cycloneDxJson = "cyclonedx/json"
+	formatFlag      = "format"
+	cycloneDxXml    = "cyclonedx/xml"
+	cycloneDxJson   = "cyclonedx/json"
+	cycloneDx15Json = "cyclonedx-1.5/json"
+	spdxJson        = "spdx/json"
+	spdxTagValue    = "spdx/tag-value"
+	csvFormat       = "csv"
+	tsvFormat       = "tsv"
+	osvJson         = "osv/json"
+	previousFlag    = "previous"
+	htmlReport      = "html"
+	markdownReport  = "markdown"
+	entityFlag      = "entity"
+	regIdFlag       = "regid"
+	outputDirFlag   = "output-dir"
+	validateFlag    = "validate"
+	toFlag          = "to"
+	typeFlag        = "type"
+	keyFlag         = "key"
+	detachedFlag    = "detached"
+	signatureFlag   = "signature"
+	identityFlag    = "certificate-identity"
+	oidcIssuerFlag  = "certificate-oidc-issuer"
+	moduleFlag      = "module"
+	modeFlag        = "mode"
+	envFlag         = "env"
+	usersFlag       = "users"
+	hostnameFlag    = "hostname"
+	vendorFlag      = "vendor"
+	tagsFlag        = "tags"
+	goosFlag        = "goos"
+	goarchFlag      = "goarch"
+	testDepsFlag    = "test-dependencies"
+	verifyGoSumFlag = "verify-go-sum"
+	cgoBinaryFlag   = "cgo-binary"
+	pkgConfigFlag   = "pkg-config"
 )
 
 func GetCommands(logger utils.Log) []*clitool.Command {
 	flags := []clitool.Flag{
 		&clitool.StringFlag{
 			Name:  formatFlag,
-			Usage: fmt.Sprintf("[Optional] Set to convert the build-info to a different format. Supported values are '%s' and '%s'.` `", cycloneDxXml, cycloneDxJson),
+			Usage: fmt.Sprintf("[Optional] Set to convert the build-info to a different format. Supported values are '%s', '%s', '%s', '%s', '%s', '%s', '%s' and '%s'.` `", cycloneDxXml, cycloneDxJson, cycloneDx15Json, spdxJson, spdxTagValue, csvFormat, tsvFormat, osvJson),
 		},
 	}
 
@@ -34,8 +70,41 @@ func GetCommands(logger utils.Log) []*clitool.Command {
 		{
 			Name:      "go",
 			Usage:     "Generate build-info for a Go project",
-			UsageText: "bi go",
-			Flags:     flags,
+			UsageText: "bi go [--vendor] [--tags <tag>,...] [--goos <GOOS>] [--goarch <GOARCH>] [--test-dependencies <include|exclude>] [--verify-go-sum <warn|fail>] [--cgo-binary <path>] [--pkg-config]",
+			Flags: append([]clitool.Flag{
+				&clitool.BoolFlag{
+					Name:  vendorFlag,
+					Usage: "[Default: false] Set to true to collect the dependencies from the vendor directory, instead of from the local Go cache.` `",
+				},
+				&clitool.StringSliceFlag{
+					Name:  tagsFlag,
+					Usage: "[Optional] The build tags, which the dependencies are collected for.` `",
+				},
+				&clitool.StringFlag{
+					Name:  goosFlag,
+					Usage: "[Default: the current OS] The target OS, which the dependencies are collected for.` `",
+				},
+				&clitool.StringFlag{
+					Name:  goarchFlag,
+					Usage: "[Default: the current architecture] The target architecture, which the dependencies are collected for.` `",
+				},
+				&clitool.StringFlag{
+					Name:  testDepsFlag,
+					Usage: fmt.Sprintf("[Optional] Set to '%s' to collect the dependencies, which only the tests use, with the 'test' scope, or to '%s' to exclude them.` `", build.GoTestDependenciesInclude, build.GoTestDependenciesExclude),
+				},
+				&clitool.StringFlag{
+					Name:  cgoBinaryFlag,
+					Usage: "[Optional] The path of a binary of the project, which was built with cgo. The system libraries, which it links dynamically, are collected as dependencies.` `",
+				},
+				&clitool.BoolFlag{
+					Name:  pkgConfigFlag,
+					Usage: "[Default: false] Set to true to collect the pkg-config packages, which the cgo directives use, as dependencies.` `",
+				},
+				&clitool.StringFlag{
+					Name:  verifyGoSumFlag,
+					Usage: fmt.Sprintf("[Optional] Set to '%s' or '%s' to warn or fail if the zip of a dependency in the local Go cache doesn't match its hash in go.sum.` `", build.GoSumVerificationWarn, build.GoSumVerificationFail),
+				},
+			}, flags...),
 			Action: func(context *clitool.Context) (err error) {
 				service := build.NewBuildInfoService()
 				service.SetLogger(logger)
@@ -49,11 +118,45 @@ func GetCommands(logger utils.Log) []*clitool.Command {
 						err = e
 					}
 				}()
-				goModule, err := bld.AddGoModule("")
+				// In the dir of a go.work file, each module of the workspace is collected separately.
+				isWorkspace, err := utils.IsFileExists("go.work", false)
 				if err != nil {
 					return
 				}
-				err = goModule.CalcDependencies()
+				testDependenciesMode := build.GoTestDependenciesMode(context.String(testDepsFlag))
+				if testDependenciesMode != build.GoTestDependenciesDefault && testDependenciesMode != build.GoTestDependenciesInclude && testDependenciesMode != build.GoTestDependenciesExclude {
+					return fmt.Errorf("'%s' is not a valid value for the --%s option", testDependenciesMode, testDepsFlag)
+				}
+				goSumVerification := build.GoSumVerificationMode(context.String(verifyGoSumFlag))
+				if goSumVerification != build.GoSumVerificationOff && goSumVerification != build.GoSumVerificationWarn && goSumVerification != build.GoSumVerificationFail {
+					return fmt.Errorf("'%s' is not a valid value for the --%s option", goSumVerification, verifyGoSumFlag)
+				}
+				configureGoModule := func(goModule *build.GoModule) {
+					goModule.SetBuildTags(context.StringSlice(tagsFlag)...)
+					goModule.SetTargetPlatform(context.String(goosFlag), context.String(goarchFlag))
+					goModule.SetTestDependenciesMode(testDependenciesMode)
+					goModule.SetGoSumVerification(goSumVerification)
+					goModule.SetCgoBinary(context.String(cgoBinaryFlag))
+					goModule.SetCollectPkgConfigDependencies(context.Bool(pkgConfigFlag))
+				}
+				if isWorkspace {
+					var goWorkspace *build.GoWorkspace
+					if goWorkspace, err = bld.AddGoWorkspace(""); err != nil {
+						return
+					}
+					for _, goModule := range goWorkspace.Modules() {
+						configureGoModule(goModule)
+					}
+					err = goWorkspace.CalcDependencies()
+				} else {
+					var goModule *build.GoModule
+					if goModule, err = bld.AddGoModule(""); err != nil {
+						return
+					}
+					goModule.SetVendorMode(context.Bool(vendorFlag))
+					configureGoModule(goModule)
+					err = goModule.CalcDependencies()
+				}
 				if err != nil {
 					return
 				}
@@ -342,52 +445,1639 @@ func GetCommands(logger utils.Log) []*clitool.Command {
 				}
 			},
 		},
-	}
-}
-
-func printBuild(bld *build.Build, format string) error {
-	buildInfo, err := bld.ToBuildInfo()
-	if err != nil {
-		return err
-	}
-
-	switch format {
-	case cycloneDxXml:
-		cdxBom, err := buildInfo.ToCycloneDxBom()
-		if err != nil {
-			return err
-		}
-		encoder := cdx.NewBOMEncoder(os.Stdout, cdx.BOMFileFormatXML)
-		encoder.SetPretty(true)
-		if err = encoder.Encode(cdxBom); err != nil {
-			return err
-		}
-	case cycloneDxJson:
-		cdxBom, err := buildInfo.ToCycloneDxBom()
-		if err != nil {
-			return err
-		}
-		encoder := cdx.NewBOMEncoder(os.Stdout, cdx.BOMFileFormatJSON)
-		encoder.SetPretty(true)
-		if err = encoder.Encode(cdxBom); err != nil {
-			return err
-		}
-	case "":
-		b, err := json.Marshal(buildInfo)
-		if err != nil {
-			return err
-		}
-		var content bytes.Buffer
-		err = json.Indent(&content, b, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(content.String())
-	default:
-		return fmt.Errorf("'%s' is not a valid value for '%s'", format, formatFlag)
-	}
-
-	return nil
+		{
+			Name:      "cargo",
+			Usage:     "Generate build-info for a Cargo project",
+			UsageText: "bi cargo",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("cargo-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				cargoModule, err := bld.AddCargoModule("")
+				if err != nil {
+					return
+				}
+				err = cargoModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:            "composer",
+			Usage:           "Generate build-info for a Composer project. If a Composer command is provided, it's run before generating the build-info",
+			UsageText:       "bi composer [composer command] [command options]",
+			Flags:           flags,
+			SkipFlagParsing: true,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("composer-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				composerModule, err := bld.AddComposerModule("")
+				if err != nil {
+					return
+				}
+				formatValue, filteredArgs, err := extractStringFlag(context.Args().Slice(), formatFlag)
+				if err != nil {
+					return
+				}
+				if len(filteredArgs) > 0 {
+					composerModule.SetArgs(filteredArgs)
+					err = composerModule.Build()
+				} else {
+					err = composerModule.CalcDependencies()
+				}
+				if err != nil {
+					return
+				}
+				return printBuild(bld, formatValue)
+			},
+		},
+		{
+			Name:            "bundle",
+			Usage:           "Generate build-info for a Ruby project. If a Bundler command is provided, it's run before generating the build-info",
+			UsageText:       "bi bundle [bundle command] [command options]",
+			Flags:           flags,
+			SkipFlagParsing: true,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("bundle-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				rubyModule, err := bld.AddRubyModule("")
+				if err != nil {
+					return
+				}
+				formatValue, filteredArgs, err := extractStringFlag(context.Args().Slice(), formatFlag)
+				if err != nil {
+					return
+				}
+				if len(filteredArgs) > 0 {
+					rubyModule.SetArgs(filteredArgs)
+					err = rubyModule.Build()
+				} else {
+					err = rubyModule.CalcDependencies()
+				}
+				if err != nil {
+					return
+				}
+				return printBuild(bld, formatValue)
+			},
+		},
+		{
+			Name:      "pod",
+			Usage:     "Generate build-info for a CocoaPods project",
+			UsageText: "bi pod",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("pod-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				cocoapodsModule, err := bld.AddCocoapodsModule("")
+				if err != nil {
+					return
+				}
+				err = cocoapodsModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "swift",
+			Usage:     "Generate build-info for a Swift Package Manager project",
+			UsageText: "bi swift",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("swift-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				swiftModule, err := bld.AddSwiftModule("")
+				if err != nil {
+					return
+				}
+				err = swiftModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "conan",
+			Usage:     "Generate build-info for a Conan project",
+			UsageText: "bi conan",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("conan-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				conanModule, err := bld.AddConanModule("")
+				if err != nil {
+					return
+				}
+				err = conanModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "conda",
+			Usage:     "Generate build-info for a Conda project",
+			UsageText: "bi conda",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("conda-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				condaModule, err := bld.AddCondaModule("")
+				if err != nil {
+					return
+				}
+				err = condaModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "bazel",
+			Usage:     "Generate build-info for a Bazel project",
+			UsageText: "bi bazel",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("bazel-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				bazelModule, err := bld.AddBazelModule("")
+				if err != nil {
+					return
+				}
+				err = bazelModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "sbt",
+			Usage:     "Generate build-info for an sbt project",
+			UsageText: "bi sbt",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("sbt-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				sbtModule, err := bld.AddSbtModule("")
+				if err != nil {
+					return
+				}
+				err = sbtModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "pub",
+			Usage:     "Generate build-info for a Dart pub project",
+			UsageText: "bi pub",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("pub-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				pubModule, err := bld.AddPubModule("")
+				if err != nil {
+					return
+				}
+				err = pubModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "mix",
+			Usage:     "Generate build-info for a Mix project",
+			UsageText: "bi mix",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("mix-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				mixModule, err := bld.AddMixModule("")
+				if err != nil {
+					return
+				}
+				err = mixModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "helm",
+			Usage:     "Generate build-info for a Helm chart",
+			UsageText: "bi helm",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("helm-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				helmModule, err := bld.AddHelmModule("")
+				if err != nil {
+					return
+				}
+				err = helmModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "terraform",
+			Usage:     "Generate build-info for a Terraform project",
+			UsageText: "bi terraform",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("terraform-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				terraformModule, err := bld.AddTerraformModule("")
+				if err != nil {
+					return
+				}
+				err = terraformModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "deno",
+			Usage:     "Generate build-info for a Deno project",
+			UsageText: "bi deno",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("deno-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				denoModule, err := bld.AddDenoModule("")
+				if err != nil {
+					return
+				}
+				err = denoModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "nix",
+			Usage:     "Generate build-info for a Nix flake. If installables are provided, their closures are added as dependencies",
+			UsageText: "bi nix [installables]",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("nix-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				nixModule, err := bld.AddNixModule("")
+				if err != nil {
+					return
+				}
+				nixModule.SetInstallables(context.Args().Slice()...)
+				err = nixModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "haskell",
+			Usage:     "Generate build-info for a Haskell project",
+			UsageText: "bi haskell",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("haskell-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				haskellModule, err := bld.AddHaskellModule("")
+				if err != nil {
+					return
+				}
+				err = haskellModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "cmake",
+			Usage:     "Generate build-info for a CMake project",
+			UsageText: "bi cmake",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("cmake-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				cmakeModule, err := bld.AddCMakeModule("")
+				if err != nil {
+					return
+				}
+				err = cmakeModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "vcpkg",
+			Usage:     "Generate build-info for a vcpkg project",
+			UsageText: "bi vcpkg",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("vcpkg-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				vcpkgModule, err := bld.AddVcpkgModule("")
+				if err != nil {
+					return
+				}
+				err = vcpkgModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "zig",
+			Usage:     "Generate build-info for a Zig project",
+			UsageText: "bi zig",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("zig-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				zigModule, err := bld.AddZigModule("")
+				if err != nil {
+					return
+				}
+				err = zigModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "julia",
+			Usage:     "Generate build-info for a Julia project",
+			UsageText: "bi julia",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("julia-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				juliaModule, err := bld.AddJuliaModule("")
+				if err != nil {
+					return
+				}
+				err = juliaModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "r",
+			Usage:     "Generate build-info for a R project",
+			UsageText: "bi r",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("r-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				rModule, err := bld.AddRModule("")
+				if err != nil {
+					return
+				}
+				err = rModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "brew",
+			Usage:     "Generate build-info for the bottles of a Homebrew formula",
+			UsageText: "bi brew",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("brew-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				brewModule, err := bld.AddHomebrewModule("")
+				if err != nil {
+					return
+				}
+				err = brewModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "apk",
+			Usage:     "Generate build-info for the Alpine packages installed on the system",
+			UsageText: "bi apk",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("apk-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				apkModule, err := bld.AddApkModule("")
+				if err != nil {
+					return
+				}
+				err = apkModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "dpkg",
+			Usage:     "Generate build-info for the Debian packages installed on the system",
+			UsageText: "bi dpkg",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("dpkg-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				dpkgModule, err := bld.AddDpkgModule("")
+				if err != nil {
+					return
+				}
+				err = dpkgModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "rpm",
+			Usage:     "Generate build-info for the RPM packages installed on the system",
+			UsageText: "bi rpm",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("rpm-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				rpmModule, err := bld.AddRpmModule("")
+				if err != nil {
+					return
+				}
+				err = rpmModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "clojure",
+			Usage:     "Generate build-info for a Clojure project",
+			UsageText: "bi clojure",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("clojure-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				clojureModule, err := bld.AddClojureModule("")
+				if err != nil {
+					return
+				}
+				err = clojureModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "meson",
+			Usage:     "Generate build-info for a Meson project",
+			UsageText: "bi meson",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("meson-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				mesonModule, err := bld.AddMesonModule("")
+				if err != nil {
+					return
+				}
+				err = mesonModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "yocto",
+			Usage:     "Generate build-info for the recipes installed in a Yocto image",
+			UsageText: "bi yocto",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("yocto-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				yoctoModule, err := bld.AddYoctoModule("")
+				if err != nil {
+					return
+				}
+				err = yoctoModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "unity",
+			Usage:     "Generate build-info for a Unity project",
+			UsageText: "bi unity",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("unity-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				unityModule, err := bld.AddUnityModule("")
+				if err != nil {
+					return
+				}
+				err = unityModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "perl",
+			Usage:     "Generate build-info for a Perl project",
+			UsageText: "bi perl",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("perl-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				perlModule, err := bld.AddPerlModule("")
+				if err != nil {
+					return
+				}
+				err = perlModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "luarocks",
+			Usage:     "Generate build-info for a LuaRocks project",
+			UsageText: "bi luarocks",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("luarocks-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				luaRocksModule, err := bld.AddLuaRocksModule("")
+				if err != nil {
+					return
+				}
+				err = luaRocksModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "opam",
+			Usage:     "Generate build-info for an OCaml (opam) project",
+			UsageText: "bi opam",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("opam-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				opamModule, err := bld.AddOpamModule("")
+				if err != nil {
+					return
+				}
+				err = opamModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "rebar",
+			Usage:     "Generate build-info for an Erlang (rebar3) project",
+			UsageText: "bi rebar",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("rebar-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				rebarModule, err := bld.AddRebarModule("")
+				if err != nil {
+					return
+				}
+				err = rebarModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "shards",
+			Usage:     "Generate build-info for a Crystal (shards) project",
+			UsageText: "bi shards",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("shards-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				shardsModule, err := bld.AddShardsModule("")
+				if err != nil {
+					return
+				}
+				err = shardsModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "android",
+			Usage:     "Generate build-info for the Android SDK components used by an Android project",
+			UsageText: "bi android",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("android-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				androidModule, err := bld.AddAndroidModule("")
+				if err != nil {
+					return
+				}
+				err = androidModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "carthage",
+			Usage:     "Generate build-info for a Carthage project",
+			UsageText: "bi carthage",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("carthage-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				carthageModule, err := bld.AddCarthageModule("")
+				if err != nil {
+					return
+				}
+				err = carthageModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "buildroot",
+			Usage:     "Generate build-info for a Buildroot firmware image",
+			UsageText: "bi buildroot",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("buildroot-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				buildrootModule, err := bld.AddBuildrootModule("")
+				if err != nil {
+					return
+				}
+				err = buildrootModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "sbom",
+			Usage:     "Generate build-info from a CycloneDX or an SPDX SBOM",
+			UsageText: "bi sbom <path to SBOM>",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of a CycloneDX or an SPDX JSON SBOM must be provided")
+				}
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("sbom-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				sbomModule, err := bld.AddSbomModule(context.Args().First())
+				if err != nil {
+					return
+				}
+				err = sbomModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "syft",
+			Usage:     "Generate build-info from the JSON output of Syft",
+			UsageText: "bi syft <path to Syft JSON output>",
+			Flags:     flags,
+			Action: func(context *clitool.Context) (err error) {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of the Syft JSON output must be provided")
+				}
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("syft-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				syftModule, err := bld.AddSyftModules(context.Args().First())
+				if err != nil {
+					return
+				}
+				err = syftModule.CalcDependencies()
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "artifacts",
+			Usage:     "Generate build-info with the files, which match glob patterns, as the artifacts of a generic module",
+			UsageText: "bi artifacts [--module <module ID>] <glob pattern>...",
+			Flags: append([]clitool.Flag{
+				&clitool.StringFlag{
+					Name:  moduleFlag,
+					Usage: "[Default: artifacts] The ID of the module, to which the artifacts are added.` `",
+					Value: "artifacts",
+				},
+			}, flags...),
+			Action: func(context *clitool.Context) (err error) {
+				if context.Args().Len() == 0 {
+					return errors.New("at least one glob pattern (for example, 'dist/**/*.zip') must be provided")
+				}
+				service := build.NewBuildInfoService()
+				service.SetLogger(logger)
+				bld, err := service.GetOrCreateBuild("artifacts-build", "1")
+				if err != nil {
+					return
+				}
+				defer func() {
+					e := bld.Clean()
+					if err == nil {
+						err = e
+					}
+				}()
+				err = bld.AddArtifactsByPattern(context.String(moduleFlag), context.Args().Slice()...)
+				if err != nil {
+					return
+				}
+				return printBuild(bld, context.String(formatFlag))
+			},
+		},
+		{
+			Name:      "graph",
+			Usage:     "Render the dependency graphs of the modules of a build-info",
+			UsageText: "bi graph [--format dot|mermaid|graphml] <path to build-info JSON>",
+			Flags: []clitool.Flag{
+				&clitool.StringFlag{
+					Name:  formatFlag,
+					Usage: fmt.Sprintf("[Default: %s] Set the format of the graph. Supported values are '%s', '%s' and '%s'.` `", entities.GraphDot, entities.GraphDot, entities.GraphMermaid, entities.GraphMl),
+					Value: string(entities.GraphDot),
+				},
+			},
+			Action: func(context *clitool.Context) error {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of a build-info JSON file must be provided")
+				}
+				buildInfo, err := readBuildInfo(context.Args().First())
+				if err != nil {
+					return err
+				}
+				return buildInfo.WriteDependencyGraph(os.Stdout, entities.GraphFormat(context.String(formatFlag)))
+			},
+		},
+		{
+			Name:      "report",
+			Usage:     "Generate a self-contained HTML report, or a Markdown summary of the dependency changes, of a build-info",
+			UsageText: "bi report [--format html|markdown] [--previous <path to previous build-info JSON>] <path to build-info JSON>",
+			Flags: []clitool.Flag{
+				&clitool.StringFlag{
+					Name:  formatFlag,
+					Usage: fmt.Sprintf("[Default: %s] Set the format of the report. Supported values are '%s' and '%s'.` `", htmlReport, htmlReport, markdownReport),
+					Value: htmlReport,
+				},
+				&clitool.StringFlag{
+					Name:  previousFlag,
+					Usage: fmt.Sprintf("[Optional] Set to the path of the build-info JSON of a previous build, to compare the build to it. Mandatory for the '%s' format.` `", markdownReport),
+				},
+			},
+			Action: func(context *clitool.Context) error {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of a build-info JSON file must be provided")
+				}
+				buildInfo, err := readBuildInfo(context.Args().First())
+				if err != nil {
+					return err
+				}
+				var previous *entities.BuildInfo
+				if previousPath := context.String(previousFlag); previousPath != "" {
+					if previous, err = readBuildInfo(previousPath); err != nil {
+						return err
+					}
+				}
+				switch context.String(formatFlag) {
+				case htmlReport:
+					return buildInfo.WriteHtmlReport(os.Stdout, previous)
+				case markdownReport:
+					if previous == nil {
+						return fmt.Errorf("the '%s' flag is mandatory for the '%s' format", previousFlag, markdownReport)
+					}
+					return buildInfo.WriteMarkdownDependencySummary(os.Stdout, previous)
+				default:
+					return fmt.Errorf("'%s' is not a valid value for '%s'", context.String(formatFlag), formatFlag)
+				}
+			},
+		},
+		{
+			Name:      "diff",
+			Usage:     "Print the dependencies, artifacts and environment variables, which were added, removed or changed between two build-infos",
+			UsageText: "bi diff <path to previous build-info JSON> <path to build-info JSON>",
+			Action: func(context *clitool.Context) error {
+				if context.Args().Len() != 2 {
+					return errors.New("the paths of the previous and the current build-info JSON files must be provided")
+				}
+				previous, err := readBuildInfo(context.Args().Get(0))
+				if err != nil {
+					return err
+				}
+				buildInfo, err := readBuildInfo(context.Args().Get(1))
+				if err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(entities.Diff(previous, buildInfo))
+			},
+		},
+		{
+			Name:      "swid",
+			Usage:     "Generate a SWID tag for each artifact of a build-info",
+			UsageText: "bi swid --entity <name> --regid <domain> [--output-dir <path>] <path to build-info JSON>",
+			Flags: []clitool.Flag{
+				&clitool.StringFlag{
+					Name:     entityFlag,
+					Usage:    "[Mandatory] The name of the organization, which created the software and the tags.` `",
+					Required: true,
+				},
+				&clitool.StringFlag{
+					Name:     regIdFlag,
+					Usage:    "[Mandatory] The registration ID of the organization, which is a domain name it owns, such as 'example.com'.` `",
+					Required: true,
+				},
+				&clitool.StringFlag{
+					Name:  outputDirFlag,
+					Usage: "[Default: current directory] The directory, in which the '.swidtag' files are created.` `",
+				},
+			},
+			Action: func(context *clitool.Context) error {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of a build-info JSON file must be provided")
+				}
+				buildInfo, err := readBuildInfo(context.Args().First())
+				if err != nil {
+					return err
+				}
+				for _, tag := range buildInfo.ToSwidTags(context.String(entityFlag), context.String(regIdFlag)) {
+					if err = writeSwidTag(filepath.Join(context.String(outputDirFlag), tag.FileName()), tag); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "schema",
+			Usage:     "Print the build-info JSON schema, or validate a build-info against it and check its consistency",
+			UsageText: "bi schema [--validate <path to build-info JSON>]",
+			Flags: []clitool.Flag{
+				&clitool.StringFlag{
+					Name:  validateFlag,
+					Usage: "[Optional] Set to the path of a build-info JSON file, to validate it against the schema instead of printing the schema.` `",
+				},
+			},
+			Action: func(context *clitool.Context) error {
+				buildInfoPath := context.String(validateFlag)
+				if buildInfoPath == "" {
+					_, err := os.Stdout.Write(entities.GetBuildInfoSchema())
+					return err
+				}
+				content, err := utils.ReadFileDecompressed(buildInfoPath)
+				if err != nil {
+					return err
+				}
+				if err = entities.ValidateBuildInfoJson(content); err != nil {
+					return err
+				}
+				buildInfo := &entities.BuildInfo{}
+				if err = json.Unmarshal(content, buildInfo); err != nil {
+					return err
+				}
+				if violations := entities.Validate(buildInfo); len(violations) > 0 {
+					messages := make([]string, 0, len(violations))
+					for _, violation := range violations {
+						messages = append(messages, violation.String())
+					}
+					return errors.New("the build-info is invalid:\n" + strings.Join(messages, "\n"))
+				}
+				logger.Info(buildInfoPath + " is a valid build-info.")
+				return nil
+			},
+		},
+		{
+			Name:      "sign",
+			Usage:     "Sign a build-info with GPG or Sigstore, and print it with the embedded signature, or print a detached signature",
+			UsageText: "bi sign [--type gpg|sigstore] [--key <GPG key>] [--detached] <path to build-info JSON>",
+			Flags: []clitool.Flag{
+				&clitool.StringFlag{
+					Name:  typeFlag,
+					Usage: fmt.Sprintf("[Default: %s] The type of the signature. Supported values are '%s' and '%s'.` `", entities.GpgSignature, entities.GpgSignature, entities.SigstoreSignature),
+					Value: string(entities.GpgSignature),
+				},
+				&clitool.StringFlag{
+					Name:  keyFlag,
+					Usage: "[Optional] The GPG key to sign with. If it isn't set, the default key of GPG is used.` `",
+				},
+				&clitool.BoolFlag{
+					Name:  detachedFlag,
+					Usage: "[Default: false] Set to true to print a detached signature, instead of the build-info with the embedded signature.` `",
+				},
+			},
+			Action: func(context *clitool.Context) error {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of a build-info JSON file must be provided")
+				}
+				buildInfo, err := readBuildInfo(context.Args().First())
+				if err != nil {
+					return err
+				}
+				var signer entities.BuildInfoSigner
+				switch entities.BuildInfoSignatureType(context.String(typeFlag)) {
+				case entities.GpgSignature:
+					signer = signutils.NewGpgSigner(context.String(keyFlag))
+				case entities.SigstoreSignature:
+					signer = signutils.NewSigstoreSigner()
+				default:
+					return fmt.Errorf("'%s' is not a valid signature type", context.String(typeFlag))
+				}
+				var output interface{} = buildInfo
+				if context.Bool(detachedFlag) {
+					if output, err = buildInfo.SignDetached(signer); err != nil {
+						return err
+					}
+				} else if err = buildInfo.Sign(signer); err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(output)
+			},
+		},
+		{
+			Name:      "verify",
+			Usage:     "Verify the embedded or detached GPG or Sigstore signature of a build-info",
+			UsageText: "bi verify [--type gpg|sigstore] [--signature <path to signature JSON>] [--certificate-identity <identity> --certificate-oidc-issuer <issuer>] <path to build-info JSON>",
+			Flags: []clitool.Flag{
+				&clitool.StringFlag{
+					Name:  typeFlag,
+					Usage: fmt.Sprintf("[Default: %s] The type of the signature. Supported values are '%s' and '%s'.` `", entities.GpgSignature, entities.GpgSignature, entities.SigstoreSignature),
+					Value: string(entities.GpgSignature),
+				},
+				&clitool.StringFlag{
+					Name:  signatureFlag,
+					Usage: "[Optional] The path of a detached signature JSON file, as printed by 'bi sign --detached'. If it isn't set, the embedded signatures are verified.` `",
+				},
+				&clitool.StringFlag{
+					Name:  identityFlag,
+					Usage: "[Mandatory for Sigstore] The expected identity of the signer, such as an email or the URL of a CI workflow.` `",
+				},
+				&clitool.StringFlag{
+					Name:  oidcIssuerFlag,
+					Usage: "[Mandatory for Sigstore] The expected OIDC issuer of the identity of the signer.` `",
+				},
+			},
+			Action: func(context *clitool.Context) error {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of a build-info JSON file must be provided")
+				}
+				buildInfo, err := readBuildInfo(context.Args().First())
+				if err != nil {
+					return err
+				}
+				var verifier entities.BuildInfoVerifier
+				switch entities.BuildInfoSignatureType(context.String(typeFlag)) {
+				case entities.GpgSignature:
+					verifier = signutils.NewGpgVerifier()
+				case entities.SigstoreSignature:
+					verifier = signutils.NewSigstoreVerifier(context.String(identityFlag), context.String(oidcIssuerFlag))
+				default:
+					return fmt.Errorf("'%s' is not a valid signature type", context.String(typeFlag))
+				}
+				if signaturePath := context.String(signatureFlag); signaturePath != "" {
+					signature := &entities.BuildInfoSignature{}
+					if err = utils.Unmarshal(signaturePath, signature); err != nil {
+						return err
+					}
+					err = buildInfo.VerifyDetached(verifier, signature)
+				} else {
+					err = buildInfo.Verify(verifier)
+				}
+				if err != nil {
+					return err
+				}
+				logger.Info("The signature of " + context.Args().First() + " is valid.")
+				return nil
+			},
+		},
+		{
+			Name:      "redact",
+			Usage:     "Strip or hash the environment variables, user names and internal hostnames of a build-info, before exporting it",
+			UsageText: "bi redact [--mode remove|hash] [--env <pattern>]... [--users] [--hostname <pattern>]... <path to build-info JSON>",
+			Flags: []clitool.Flag{
+				&clitool.StringFlag{
+					Name:  modeFlag,
+					Usage: fmt.Sprintf("[Default: %s] How the redacted values are replaced. Supported values are '%s' and '%s'.` `", entities.RemoveValues, entities.RemoveValues, entities.HashValues),
+					Value: string(entities.RemoveValues),
+				},
+				&clitool.StringSliceFlag{
+					Name:  envFlag,
+					Usage: "[Optional] A wildcard pattern of the names of the environment variables, whose values are redacted. If it isn't set, the values of all the environment variables are redacted.` `",
+				},
+				&clitool.BoolFlag{
+					Name:  usersFlag,
+					Usage: "[Default: false] Set to true to redact the user names.` `",
+				},
+				&clitool.StringSliceFlag{
+					Name:  hostnameFlag,
+					Usage: "[Optional] A wildcard pattern of internal hostnames (for example, '*.corp.example.com'), which are redacted.` `",
+				},
+			},
+			Action: func(context *clitool.Context) error {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of a build-info JSON file must be provided")
+				}
+				buildInfo, err := readBuildInfo(context.Args().First())
+				if err != nil {
+					return err
+				}
+				err = buildInfo.Redact(entities.RedactionOptions{
+					Mode:      entities.RedactionMode(context.String(modeFlag)),
+					EnvVars:   context.StringSlice(envFlag),
+					Users:     context.Bool(usersFlag),
+					Hostnames: context.StringSlice(hostnameFlag),
+				})
+				if err != nil {
+					return err
+				}
+				content, err := json.MarshalIndent(buildInfo, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(content))
+				return nil
+			},
+		},
+		{
+			Name:      "convert",
+			Usage:     "Convert a build-info JSON between the legacy 1.x format and the current format",
+			UsageText: "bi convert --to legacy|current <path to build-info JSON>",
+			Flags: []clitool.Flag{
+				&clitool.StringFlag{
+					Name:     toFlag,
+					Usage:    fmt.Sprintf("[Mandatory] The format to convert the build-info to. Supported values are '%s' and '%s'.` `", entities.LegacyBuildInfoFormat, entities.CurrentBuildInfoFormat),
+					Required: true,
+				},
+			},
+			Action: func(context *clitool.Context) error {
+				if context.Args().Len() != 1 {
+					return errors.New("the path of a build-info JSON file must be provided")
+				}
+				content, err := utils.ReadFileDecompressed(context.Args().First())
+				if err != nil {
+					return err
+				}
+				converted, err := entities.ConvertBuildInfoJson(content, entities.BuildInfoFormat(context.String(toFlag)))
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(converted))
+				return nil
+			},
+		},
+	}
+}
+
+func printBuild(bld *build.Build, format string) error {
+	buildInfo, err := bld.ToBuildInfo()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case cycloneDxXml:
+		cdxBom, err := buildInfo.ToCycloneDxBom()
+		if err != nil {
+			return err
+		}
+		encoder := cdx.NewBOMEncoder(os.Stdout, cdx.BOMFileFormatXML)
+		encoder.SetPretty(true)
+		if err = encoder.Encode(cdxBom); err != nil {
+			return err
+		}
+	case cycloneDxJson:
+		cdxBom, err := buildInfo.ToCycloneDxBom()
+		if err != nil {
+			return err
+		}
+		encoder := cdx.NewBOMEncoder(os.Stdout, cdx.BOMFileFormatJSON)
+		encoder.SetPretty(true)
+		if err = encoder.Encode(cdxBom); err != nil {
+			return err
+		}
+	case cycloneDx15Json:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(buildInfo.ToCycloneDx15Bom()); err != nil {
+			return err
+		}
+	case spdxJson:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(buildInfo.ToSpdx23Document()); err != nil {
+			return err
+		}
+	case spdxTagValue:
+		if err = buildInfo.ToSpdx23Document().WriteTagValue(os.Stdout); err != nil {
+			return err
+		}
+	case osvJson:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(buildInfo.ToOsvBatchQuery()); err != nil {
+			return err
+		}
+	case csvFormat:
+		if err = buildInfo.WriteDependenciesCsv(os.Stdout); err != nil {
+			return err
+		}
+	case tsvFormat:
+		if err = buildInfo.WriteDependenciesTsv(os.Stdout); err != nil {
+			return err
+		}
+	case "":
+		b, err := json.Marshal(buildInfo)
+		if err != nil {
+			return err
+		}
+		var content bytes.Buffer
+		err = json.Indent(&content, b, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(content.String())
+	default:
+		return fmt.Errorf("'%s' is not a valid value for '%s'", format, formatFlag)
+	}
+
+	return nil
+}
+
+func readBuildInfo(path string) (*entities.BuildInfo, error) {
+	content, err := utils.ReadFileDecompressed(path)
+	if err != nil {
+		return nil, err
+	}
+	buildInfo := &entities.BuildInfo{}
+	if err = json.Unmarshal(content, buildInfo); err != nil {
+		return nil, fmt.Errorf("failed to parse the build-info %s: %w", path, err)
+	}
+	return buildInfo, nil
+}
+
+func writeSwidTag(path string, tag entities.SwidTag) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := file.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	return tag.Write(file)
 }
 
 func extractStringFlag(args []string, flagName string) (flagValue string, filteredArgs []string, err error) {
@@ -1,13 +1,17 @@
 package entities
 
 import (
-	"github.com/jfrog/build-info-go/utils/compareutils"
-	"golang.org/x/exp/maps"
-	"golang.org/x/exp/slices"
+	"encoding/json"
+	"fmt"
+	"path"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/jfrog/build-info-go/utils/compareutils"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
 	"github.com/pkg/errors"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
@@ -23,6 +27,8 @@ const (
 
 	// Build type
 	Build ModuleType = "build"
+	// The type of the module, which holds the dependencies shared by several modules. See DeduplicateDependencies().
+	Shared ModuleType = "shared"
 
 	// Package managers types
 	Generic   ModuleType = "generic"
@@ -34,21 +40,61 @@ const (
 	Go        ModuleType = "go"
 	Python    ModuleType = "python"
 	Terraform ModuleType = "terraform"
+	Cargo     ModuleType = "cargo"
+	Composer  ModuleType = "composer"
+	Ruby      ModuleType = "ruby"
+	Cocoapods ModuleType = "cocoapods"
+	Swift     ModuleType = "swift"
+	Conan     ModuleType = "conan"
+	Conda     ModuleType = "conda"
+	Bazel     ModuleType = "bazel"
+	Sbt       ModuleType = "sbt"
+	Pub       ModuleType = "pub"
+	Mix       ModuleType = "mix"
+	Helm      ModuleType = "helm"
+	Deno      ModuleType = "deno"
+	Nix       ModuleType = "nix"
+	Haskell   ModuleType = "haskell"
+	CMake     ModuleType = "cmake"
+	Vcpkg     ModuleType = "vcpkg"
+	Zig       ModuleType = "zig"
+	Julia     ModuleType = "julia"
+	R         ModuleType = "r"
+	Homebrew  ModuleType = "homebrew"
+	Apk       ModuleType = "apk"
+	Dpkg      ModuleType = "dpkg"
+	Rpm       ModuleType = "rpm"
+	Clojure   ModuleType = "clojure"
+	Meson     ModuleType = "meson"
+	Yocto     ModuleType = "yocto"
+	Unity     ModuleType = "unity"
+	Perl      ModuleType = "perl"
+	LuaRocks  ModuleType = "luarocks"
+	Opam      ModuleType = "opam"
+	Rebar     ModuleType = "rebar"
+	Shards    ModuleType = "shards"
+	Android   ModuleType = "android"
+	Carthage  ModuleType = "carthage"
+	Buildroot ModuleType = "buildroot"
 )
 
 type BuildInfo struct {
-	Name          string   `json:"name,omitempty"`
-	Number        string   `json:"number,omitempty"`
-	Agent         *Agent   `json:"agent,omitempty"`
-	BuildAgent    *Agent   `json:"buildAgent,omitempty"`
-	Modules       []Module `json:"modules,omitempty"`
-	Started       string   `json:"started,omitempty"`
-	Properties    Env      `json:"properties,omitempty"`
-	Principal     string   `json:"artifactoryPrincipal,omitempty"`
-	BuildUrl      string   `json:"url,omitempty"`
-	Issues        *Issues  `json:"issues,omitempty"`
-	PluginVersion string   `json:"artifactoryPluginVersion,omitempty"`
-	VcsList       []Vcs    `json:"vcs,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Number         string   `json:"number,omitempty"`
+	Agent          *Agent   `json:"agent,omitempty"`
+	BuildAgent     *Agent   `json:"buildAgent,omitempty"`
+	Modules        []Module `json:"modules,omitempty"`
+	Started        string   `json:"started,omitempty"`
+	Finished       string   `json:"finished,omitempty"`
+	DurationMillis int64    `json:"durationMillis,omitempty"`
+	Properties     Env      `json:"properties,omitempty"`
+	Principal      string   `json:"artifactoryPrincipal,omitempty"`
+	BuildUrl       string   `json:"url,omitempty"`
+	Issues         *Issues  `json:"issues,omitempty"`
+	PluginVersion  string   `json:"artifactoryPluginVersion,omitempty"`
+	VcsList        []Vcs    `json:"vcs,omitempty"`
+	// The signatures, which are embedded in the build-info. See Sign().
+	Signatures []BuildInfoSignature `json:"signatures,omitempty"`
 }
 
 func New() *BuildInfo {
@@ -78,19 +124,23 @@ func (targetBuildInfo *BuildInfo) SetPluginVersion(pluginVersion string) {
 
 // Append the modules of the received build info to this build info.
 // If the two build info instances contain modules with identical names, these modules are merged.
-// When merging the modules, the artifacts and dependencies remain unique according to their checksum.
+// When merging the modules, the artifacts and dependencies remain unique according to their checksum. Artifacts, which were deployed to different paths, are kept.
 func (targetBuildInfo *BuildInfo) Append(buildInfo *BuildInfo) {
-	for i, newModule := range buildInfo.Modules {
+	appendModules(buildInfo.Modules, &targetBuildInfo.Modules)
+}
+
+func appendModules(modules []Module, intoModules *[]Module) {
+	for i, newModule := range modules {
 		exists := false
-		for j := range targetBuildInfo.Modules {
-			if newModule.Id == targetBuildInfo.Modules[j].Id {
-				mergeModules(&buildInfo.Modules[i], &targetBuildInfo.Modules[j])
+		for j := range *intoModules {
+			if newModule.Id == (*intoModules)[j].Id {
+				mergeModules(&modules[i], &(*intoModules)[j])
 				exists = true
 				break
 			}
 		}
 		if !exists {
-			targetBuildInfo.Modules = append(targetBuildInfo.Modules, newModule)
+			*intoModules = append(*intoModules, newModule)
 		}
 	}
 }
@@ -146,7 +196,7 @@ func (targetBuildInfo *BuildInfo) ToCycloneDxBom() (*cdx.BOM, error) {
 	var biDependencies []Dependency
 	moduleIds := make(map[string]bool)
 
-	for _, module := range targetBuildInfo.Modules {
+	for _, module := range targetBuildInfo.GetAllModules() {
 		// Aggregated builds are not supported
 		if module.Type == Build {
 			continue
@@ -235,6 +285,9 @@ func packageIdToCycloneDxComponent(packageId string) (*cdx.Component, error) {
 
 // Merge the first module into the second module.
 func mergeModules(merge *Module, into *Module) {
+	into.Properties = mergeModuleProperties(into.Properties, merge.Properties)
+	mergeModulesTiming(merge, into)
+	appendModules(merge.Modules, &into.Modules)
 	mergeArtifacts(&merge.Artifacts, &into.Artifacts)
 	mergeArtifacts(&merge.ExcludedArtifacts, &into.ExcludedArtifacts)
 	mergeDependenciesLists(&merge.Dependencies, &into.Dependencies)
@@ -244,7 +297,8 @@ func mergeArtifacts(mergeArtifacts *[]Artifact, intoArtifacts *[]Artifact) {
 	for _, mergeArtifact := range *mergeArtifacts {
 		exists := false
 		for _, artifact := range *intoArtifacts {
-			if mergeArtifact.Sha1 == artifact.Sha1 {
+			// The same file may be deployed to several repositories or paths.
+			if mergeArtifact.Sha1 == artifact.Sha1 && mergeArtifact.Path == artifact.Path && mergeArtifact.OriginalDeploymentRepo == artifact.OriginalDeploymentRepo {
 				exists = true
 				break
 			}
@@ -256,12 +310,12 @@ func mergeArtifacts(mergeArtifacts *[]Artifact, intoArtifacts *[]Artifact) {
 }
 
 func mergeDependenciesLists(dependenciesToAdd, intoDependencies *[]Dependency) {
-	for i, dependencyToAdd := range *dependenciesToAdd {
+	for _, dependencyToAdd := range *dependenciesToAdd {
 		exists := false
-		for _, dependency := range *intoDependencies {
+		for j, dependency := range *intoDependencies {
 			if dependencyToAdd.Sha1 == dependency.Sha1 && dependencyToAdd.Id == dependency.Id {
 				exists = true
-				(*dependenciesToAdd)[i] = mergeDependencies(dependency, dependencyToAdd)
+				(*intoDependencies)[j] = mergeDependencies(dependency, dependencyToAdd)
 				break
 			}
 		}
@@ -272,13 +326,40 @@ func mergeDependenciesLists(dependenciesToAdd, intoDependencies *[]Dependency) {
 }
 
 func mergeDependencies(dep1, dep2 Dependency) Dependency {
+	licenses := dep1.Licenses
+	if len(licenses) == 0 {
+		// The licenses may have been collected for only one of the occurrences of the dependency.
+		licenses = dep2.Licenses
+	}
 	return Dependency{
-		Id:          dep1.Id,
-		Type:        dep1.Type,
-		Scopes:      mergeStringSlices(dep1.Scopes, dep2.Scopes),
-		RequestedBy: mergeRequestedBySlices(dep1.RequestedBy, dep2.RequestedBy),
-		Checksum:    dep1.Checksum,
+		Id:             dep1.Id,
+		Type:           dep1.Type,
+		Scopes:         mergeStringSlices(dep1.Scopes, dep2.Scopes),
+		StandardScopes: mergeStandardScopes(dep1.StandardScopes, dep2.StandardScopes),
+		RequestedBy:    mergeRequestedBySlices(dep1.RequestedBy, dep2.RequestedBy),
+		Purl:           dep1.Purl,
+		Properties:     mergeProperties(dep1.Properties, dep2.Properties),
+		Licenses:       licenses,
+		Checksum:       dep1.Checksum,
+
+		Retracted:           dep1.Retracted,
+		RetractionRationale: dep1.RetractionRationale,
+	}
+}
+
+// mergeProperties merges two properties maps. If a key exists in both maps, the value from the first map is kept.
+func mergeProperties(properties1, properties2 map[string]string) map[string]string {
+	if len(properties1) == 0 && len(properties2) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(properties1)+len(properties2))
+	for key, value := range properties2 {
+		merged[key] = value
+	}
+	for key, value := range properties1 {
+		merged[key] = value
 	}
+	return merged
 }
 
 func mergeStringSlices(slice1, slice2 []string) []string {
@@ -335,19 +416,112 @@ type PublishedBuildInfo struct {
 type Agent struct {
 	Name    string `json:"name,omitempty"`
 	Version string `json:"version,omitempty"`
+	// The details of the machine, which ran the build. They're recorded on the build agent.
+	GoVersion string `json:"goVersion,omitempty"`
+	Os        string `json:"os,omitempty"`
+	Arch      string `json:"arch,omitempty"`
+	Hostname  string `json:"hostname,omitempty"`
 }
 
 type Module struct {
-	Type              ModuleType   `json:"type,omitempty"`
+	Type ModuleType `json:"type,omitempty"`
+	// Arbitrary metadata of the module, such as its team, component or compliance tags.
+	// Use GetProperties() and AddProperties() to read and set them as strings.
 	Properties        interface{}  `json:"properties,omitempty"`
 	Id                string       `json:"id,omitempty"`
 	Artifacts         []Artifact   `json:"artifacts,omitempty"`
 	ExcludedArtifacts []Artifact   `json:"excludedArtifacts,omitempty"`
 	Dependencies      []Dependency `json:"dependencies,omitempty"`
+	// The times, at which the collection of the module started and finished, and its duration.
+	Started        string `json:"started,omitempty"`
+	Finished       string `json:"finished,omitempty"`
+	DurationMillis int64  `json:"durationMillis,omitempty"`
+	// The child modules of the module, such as the modules of a Maven reactor or the members of a Go workspace.
+	Modules []Module `json:"modules,omitempty"`
 	// Used in aggregated builds - this field stores the checksums of the referenced build-info JSON.
 	Checksum
 }
 
+// GetProperties returns a copy of the properties of the module, or nil if it has none.
+// Build-infos, which were created by other tools, may have property values, which aren't strings (for example, '{"replicas": 3, "tags": ["a", "b"]}').
+// Such values are converted to their JSON text ('3' and '["a","b"]'). Properties, which aren't a JSON object, are ignored.
+func (m *Module) GetProperties() map[string]string {
+	properties := modulePropertiesToMap(m.Properties)
+	if len(properties) == 0 {
+		return nil
+	}
+	stringProperties := make(map[string]string, len(properties))
+	for key, value := range properties {
+		stringProperties[key] = modulePropertyToString(value)
+	}
+	return stringProperties
+}
+
+// AddProperties adds the properties to the module. The added properties override the properties, which the module already has.
+// The properties are copied to a new map, so the map of the module may be shared by several modules.
+func (m *Module) AddProperties(properties map[string]string) {
+	if len(properties) == 0 {
+		return
+	}
+	m.Properties = mergeModuleProperties(properties, m.Properties)
+}
+
+// Merges the properties of two modules. If a key exists in both, the value from the first properties is kept.
+// The merged properties are strings, unless one of the values isn't a string, in which case the values keep their types.
+func mergeModuleProperties(properties1, properties2 interface{}) interface{} {
+	map1, map2 := modulePropertiesToMap(properties1), modulePropertiesToMap(properties2)
+	if len(map1) == 0 && len(map2) == 0 {
+		return nil
+	}
+	merged := make(map[string]interface{}, len(map1)+len(map2))
+	for key, value := range map2 {
+		merged[key] = value
+	}
+	for key, value := range map1 {
+		merged[key] = value
+	}
+	stringProperties := make(map[string]string, len(merged))
+	for key, value := range merged {
+		stringValue, isString := value.(string)
+		if !isString {
+			return merged
+		}
+		stringProperties[key] = stringValue
+	}
+	return stringProperties
+}
+
+// Returns the properties of a module as a map. The properties are a map of strings if they were set by this library, or a map of any JSON values if they were read from a build-info file.
+func modulePropertiesToMap(properties interface{}) map[string]interface{} {
+	switch typedProperties := properties.(type) {
+	case map[string]interface{}:
+		return typedProperties
+	case map[string]string:
+		propertiesMap := make(map[string]interface{}, len(typedProperties))
+		for key, value := range typedProperties {
+			propertiesMap[key] = value
+		}
+		return propertiesMap
+	default:
+		return nil
+	}
+}
+
+func modulePropertyToString(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	default:
+		content, err := json.Marshal(typedValue)
+		if err != nil {
+			return fmt.Sprint(typedValue)
+		}
+		return string(content)
+	}
+}
+
 // If the 'other' Module matches the current one, return true.
 // 'other' Module may contain regex values for Id, Artifacts, ExcludedArtifacts, Dependencies and Checksum.
 func (m *Module) isEqual(other Module) (bool, error) {
@@ -408,10 +582,20 @@ func isEqualModuleSlices(actual, other []Module) (bool, error) {
 type Artifact struct {
 	Name string `json:"name,omitempty"`
 	Type string `json:"type,omitempty"`
-	Path string `json:"path,omitempty"`
+	// The path of the artifact in the repository it was deployed to, including its name.
+	Path                   string `json:"path,omitempty"`
+	OriginalDeploymentRepo string `json:"originalDeploymentRepo,omitempty"`
+	// Properties of the artifact, for example, the platform, which a binary was built for.
+	Properties map[string]string `json:"properties,omitempty"`
 	Checksum
 }
 
+// SetDeploymentPath sets the repository, to which the artifact was deployed, and its path in the repository, which is the directory joined with the name of the artifact.
+func (a *Artifact) SetDeploymentPath(repo, directory string) {
+	a.OriginalDeploymentRepo = repo
+	a.Path = path.Join(directory, a.Name)
+}
+
 // If the 'other' Artifact matches the current one, return true.
 // 'other' Artifacts may contain regex values for Name, Path, and Checksum.
 func (a *Artifact) isEqual(other Artifact) (bool, error) {
@@ -463,6 +647,17 @@ type Dependency struct {
 	Type        string     `json:"type,omitempty"`
 	Scopes      []string   `json:"scopes,omitempty"`
 	RequestedBy [][]string `json:"requestedBy,omitempty"`
+	// The scopes of the dependency, which are common to all the module types, as mapped from its Scopes. See GetStandardScopes().
+	StandardScopes []DependencyScope `json:"standardScopes,omitempty"`
+	// The package URL of the dependency, which identifies it for SBOM and vulnerability tools.
+	Purl string `json:"purl,omitempty"`
+	// Additional information about the dependency, such as its change status relative to a base build.
+	Properties map[string]string `json:"properties,omitempty"`
+	// Indicates that the authors of the dependency withdrew its version (for example, using a Go 'retract' directive).
+	Retracted           bool   `json:"retracted,omitempty"`
+	RetractionRationale string `json:"retractionRationale,omitempty"`
+	// The SPDX license expressions of the licenses, which the dependency declares.
+	Licenses []string `json:"licenses,omitempty"`
 	Checksum
 }
 
@@ -526,6 +721,52 @@ func (d *Dependency) UpdateRequestedBy(parentId string, parentRequestedBy [][]st
 	d.RequestedBy = filteredChildRequestedBy
 }
 
+// RequestedByLimits bounds the RequestedBy paths of the dependencies, so the build-infos of projects with deep or dense dependency graphs are bounded in size.
+type RequestedByLimits struct {
+	// The maximum number of IDs in a path. Longer paths are cut, so they keep the nearest parents and don't end with the ID of the module. Zero means unlimited.
+	MaxDepth int
+	// The maximum number of paths of a dependency. Zero means unlimited.
+	MaxPaths int
+}
+
+// The limits, which are used unless other limits are set. The depth of the paths isn't limited.
+var DefaultRequestedByLimits = RequestedByLimits{MaxPaths: RequestedByMaxLength}
+
+// IsFull returns true if no more paths can be added to the RequestedBy field of the dependency.
+func (limits RequestedByLimits) IsFull(dependency *Dependency) bool {
+	return limits.MaxPaths > 0 && len(dependency.RequestedBy) >= limits.MaxPaths
+}
+
+// LimitRequestedBy cuts the paths of the dependency, which are longer than the maximum depth, and drops the paths beyond the maximum number of paths.
+// Paths, which become identical after they're cut, are kept once.
+func (d *Dependency) LimitRequestedBy(limits RequestedByLimits) {
+	if limits.MaxDepth <= 0 && (limits.MaxPaths <= 0 || len(d.RequestedBy) <= limits.MaxPaths) {
+		return
+	}
+	var limited [][]string
+	for _, path := range d.RequestedBy {
+		if limits.MaxPaths > 0 && len(limited) >= limits.MaxPaths {
+			break
+		}
+		if limits.MaxDepth > 0 && len(path) > limits.MaxDepth {
+			path = path[:limits.MaxDepth]
+		}
+		if !containsPath(limited, path) {
+			limited = append(limited, path)
+		}
+	}
+	d.RequestedBy = limited
+}
+
+func containsPath(paths [][]string, path []string) bool {
+	for _, existingPath := range paths {
+		if slices.Equal(existingPath, path) {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *Dependency) NodeHasLoop() bool {
 	for _, requestedBy := range d.RequestedBy {
 		if slices.Contains(requestedBy, d.Id) {
@@ -558,14 +799,16 @@ type Checksum struct {
 	Sha1   string `json:"sha1,omitempty"`
 	Md5    string `json:"md5,omitempty"`
 	Sha256 string `json:"sha256,omitempty"`
+	// Calculated only by the builds, which are configured to calculate it.
+	Sha512 string `json:"sha512,omitempty"`
 }
 
 func (c *Checksum) IsEmpty() bool {
-	return c.Md5 == "" && c.Sha1 == "" && c.Sha256 == ""
+	return c.Md5 == "" && c.Sha1 == "" && c.Sha256 == "" && c.Sha512 == ""
 }
 
 // If the 'other' checksum matches the current one, return true.
-// 'other' checksum may contain regex values for sha1, sha256 and md5.
+// 'other' checksum may contain regex values for sha1, sha256, sha512 and md5.
 func (c *Checksum) IsEqual(other Checksum) (bool, error) {
 	match, err := regexp.MatchString(other.Md5, c.Md5)
 	if !match || err != nil {
@@ -579,6 +822,10 @@ func (c *Checksum) IsEqual(other Checksum) (bool, error) {
 	if !match || err != nil {
 		return false, err
 	}
+	match, err = regexp.MatchString(other.Sha512, c.Sha512)
+	if !match || err != nil {
+		return false, err
+	}
 
 	return true, nil
 }
@@ -603,6 +850,8 @@ type Partial struct {
 	ModuleId     string       `json:"ModuleId,omitempty"`
 	Issues       *Issues      `json:"Issues,omitempty"`
 	VcsList      []Vcs        `json:"vcs,omitempty"`
+	// Properties, which are added to the properties of the module, whose ID is ModuleId.
+	ModuleProperties map[string]string `json:"ModuleProperties,omitempty"`
 	Checksum
 }
 
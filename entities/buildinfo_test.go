@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -201,6 +202,18 @@ func TestMergeDependenciesLists(t *testing.T) {
 	reflect.DeepEqual(expectedMergedDependencies, intoDependencies)
 }
 
+func TestLimitRequestedBy(t *testing.T) {
+	dependency := Dependency{Id: "d", RequestedBy: [][]string{{"c", "b", "a", "module"}, {"c", "b", "x", "module"}, {"e", "module"}, {"f", "b", "module"}}}
+	dependency.LimitRequestedBy(RequestedByLimits{MaxDepth: 2})
+	// The first two paths are identical after they're cut.
+	assert.Equal(t, [][]string{{"c", "b"}, {"e", "module"}, {"f", "b"}}, dependency.RequestedBy)
+
+	dependency.LimitRequestedBy(RequestedByLimits{MaxPaths: 2})
+	assert.Equal(t, [][]string{{"c", "b"}, {"e", "module"}}, dependency.RequestedBy)
+	assert.True(t, RequestedByLimits{MaxPaths: 2}.IsFull(&dependency))
+	assert.False(t, RequestedByLimits{}.IsFull(&dependency))
+}
+
 func TestAppend(t *testing.T) {
 	artifactA := Artifact{Name: "artifact-a", Checksum: Checksum{Sha1: "artifact-a-sha"}}
 	artifactB := Artifact{Name: "artifact-b", Checksum: Checksum{Sha1: "artifact-b-sha"}}
@@ -239,3 +252,50 @@ func TestAppend(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, results)
 }
+
+func TestModuleProperties(t *testing.T) {
+	// Build-infos, which were created by other tools, may have property values, which aren't strings.
+	content := `{"id": "module-id", "properties": {"team": "platform", "replicas": 3, "enabled": true, "tags": ["a", "b"], "owner": {"name": "x"}, "empty": null}}`
+	var module Module
+	assert.NoError(t, json.Unmarshal([]byte(content), &module))
+	assert.Equal(t, map[string]string{"team": "platform", "replicas": "3", "enabled": "true", "tags": `["a","b"]`, "owner": `{"name":"x"}`, "empty": ""}, module.GetProperties())
+
+	// The added properties override the existing ones, and the values, which aren't strings, keep their types.
+	module.AddProperties(map[string]string{"team": "backend", "component": "api"})
+	assert.Equal(t, "backend", module.GetProperties()["team"])
+	assert.Equal(t, "api", module.GetProperties()["component"])
+	marshaledProperties, err := json.Marshal(module.Properties)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"team": "backend", "component": "api", "replicas": 3, "enabled": true, "tags": ["a", "b"], "owner": {"name": "x"}, "empty": null}`, string(marshaledProperties))
+
+	// The properties, which are set by this library, are strings.
+	module = Module{}
+	assert.Nil(t, module.GetProperties())
+	module.AddProperties(nil)
+	assert.Nil(t, module.Properties)
+	properties := map[string]string{"team": "platform"}
+	module.AddProperties(properties)
+	assert.Equal(t, properties, module.Properties)
+	// The properties are copied.
+	module.AddProperties(map[string]string{"component": "api"})
+	assert.Equal(t, map[string]string{"team": "platform"}, properties)
+
+	var legacyModule LegacyModule
+	assert.NoError(t, json.Unmarshal([]byte(`{"id": "module-id", "properties": ["a"]}`), &legacyModule))
+	module = Module{Properties: legacyModule.Properties}
+	assert.Nil(t, module.GetProperties())
+}
+
+func TestAppendModuleProperties(t *testing.T) {
+	buildInfo1 := BuildInfo{Modules: []Module{{Id: "module-id", Properties: map[string]string{"team": "platform", "component": "api"}}}}
+	buildInfo2 := BuildInfo{Modules: []Module{
+		{Id: "module-id", Properties: map[string]string{"team": "frontend", "compliance": "pci"}},
+		{Id: "other-module-id"},
+	}}
+
+	buildInfo1.Append(&buildInfo2)
+	assert.Len(t, buildInfo1.Modules, 2)
+	// The properties of the module, which is appended to, take precedence.
+	assert.Equal(t, map[string]string{"team": "platform", "component": "api", "compliance": "pci"}, buildInfo1.Modules[0].Properties)
+	assert.Nil(t, buildInfo1.Modules[1].Properties)
+}
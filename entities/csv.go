@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// The values of the relation column of the flattened dependencies.
+const (
+	DirectDependency   = "direct"
+	IndirectDependency = "indirect"
+)
+
+var dependenciesCsvHeader = []string{"module", "id", "type", "sha256", "scopes", "relation"}
+
+// WriteDependenciesCsv writes the dependencies of all the modules as a CSV table, with a row for each dependency of each module.
+// The columns are the module ID, the dependency ID, type, SHA-256 checksum and scopes (separated by commas), and whether the dependency is direct or indirect.
+func (targetBuildInfo *BuildInfo) WriteDependenciesCsv(writer io.Writer) error {
+	return targetBuildInfo.writeDependenciesTable(writer, ',')
+}
+
+// WriteDependenciesTsv writes the dependencies of all the modules as a TSV table, with the columns of WriteDependenciesCsv().
+func (targetBuildInfo *BuildInfo) WriteDependenciesTsv(writer io.Writer) error {
+	return targetBuildInfo.writeDependenciesTable(writer, '\t')
+}
+
+func (targetBuildInfo *BuildInfo) writeDependenciesTable(writer io.Writer, separator rune) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = separator
+	if err := csvWriter.Write(dependenciesCsvHeader); err != nil {
+		return err
+	}
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		for _, dependency := range module.Dependencies {
+			row := []string{module.Id, dependency.Id, dependency.Type, dependency.Sha256, strings.Join(dependency.Scopes, ","), getDependencyRelation(module.Id, dependency)}
+			if err := csvWriter.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// A dependency is direct if the module requested it, or if nothing is known about who requested it.
+func getDependencyRelation(moduleId string, dependency Dependency) string {
+	if len(dependency.RequestedBy) == 0 {
+		return DirectDependency
+	}
+	for _, path := range dependency.RequestedBy {
+		if len(path) > 0 && path[0] == moduleId {
+			return DirectDependency
+		}
+	}
+	return IndirectDependency
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getCsvTestBuildInfo() *BuildInfo {
+	return &BuildInfo{Modules: []Module{
+		{Id: "my-app", Type: Npm, Dependencies: []Dependency{
+			{Id: "express:4.18.2", Type: "tgz", Checksum: Checksum{Sha256: "e256"}, Scopes: []string{"prod", "dev"}, RequestedBy: [][]string{{"my-app"}}},
+			{Id: "body-parser:1.20.1", Type: "tgz", RequestedBy: [][]string{{"express:4.18.2", "my-app"}}},
+		}},
+		{Id: "my-lib", Type: Go, Dependencies: []Dependency{{Id: "github.com/pkg/errors:v0.9.1"}}},
+		{Id: "aggregated-build/1", Type: Build, Dependencies: []Dependency{{Id: "ignored"}}},
+	}}
+}
+
+func TestWriteDependenciesCsv(t *testing.T) {
+	var content strings.Builder
+	assert.NoError(t, getCsvTestBuildInfo().WriteDependenciesCsv(&content))
+	assert.Equal(t, `module,id,type,sha256,scopes,relation
+my-app,express:4.18.2,tgz,e256,"prod,dev",direct
+my-app,body-parser:1.20.1,tgz,,,indirect
+my-lib,github.com/pkg/errors:v0.9.1,,,,direct
+`, content.String())
+}
+
+func TestWriteDependenciesTsv(t *testing.T) {
+	var content strings.Builder
+	assert.NoError(t, getCsvTestBuildInfo().WriteDependenciesTsv(&content))
+	assert.Equal(t, "module\tid\ttype\tsha256\tscopes\trelation\n"+
+		"my-app\texpress:4.18.2\ttgz\te256\tprod,dev\tdirect\n"+
+		"my-app\tbody-parser:1.20.1\ttgz\t\t\tindirect\n"+
+		"my-lib\tgithub.com/pkg/errors:v0.9.1\t\t\t\tdirect\n", content.String())
+}
@@ -0,0 +1,232 @@
+package entities
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+)
+
+const (
+	CycloneDxBomFormat   = "CycloneDX"
+	CycloneDxSpecVersion = "1.5"
+	cycloneDxSchema      = "http://cyclonedx.org/schema/bom-1.5.schema.json"
+
+	// The names of the properties of the components, which hold the types (and scopes) of the modules, the dependencies and the artifacts.
+	CycloneDxTypeProperty   = "buildinfo:type"
+	CycloneDxScopesProperty = "buildinfo:scopes"
+
+	cycloneDxToolName = "build-info-go"
+)
+
+// CycloneDxBom is a CycloneDX 1.5 JSON SBOM, converted from a build-info.
+type CycloneDxBom struct {
+	Schema       string                `json:"$schema"`
+	BomFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber,omitempty"`
+	Version      int                   `json:"version"`
+	Metadata     *CycloneDxMetadata    `json:"metadata,omitempty"`
+	Components   []CycloneDxComponent  `json:"components,omitempty"`
+	Dependencies []CycloneDxDependency `json:"dependencies,omitempty"`
+}
+
+type CycloneDxMetadata struct {
+	Timestamp string          `json:"timestamp,omitempty"`
+	Tools     *CycloneDxTools `json:"tools,omitempty"`
+	// The component, which the BOM describes. This is the build itself.
+	Component *CycloneDxComponent `json:"component,omitempty"`
+}
+
+type CycloneDxTools struct {
+	Components []CycloneDxComponent `json:"components,omitempty"`
+}
+
+type CycloneDxComponent struct {
+	Type       string              `json:"type"`
+	BomRef     string              `json:"bom-ref,omitempty"`
+	Group      string              `json:"group,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	Hashes     []CycloneDxHash     `json:"hashes,omitempty"`
+	Licenses   []CycloneDxLicense  `json:"licenses,omitempty"`
+	Purl       string              `json:"purl,omitempty"`
+	Properties []CycloneDxProperty `json:"properties,omitempty"`
+	// The artifacts of a module are nested in its component.
+	Components []CycloneDxComponent `json:"components,omitempty"`
+}
+
+type CycloneDxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// CycloneDxLicense holds either a license or an SPDX license expression.
+type CycloneDxLicense struct {
+	License    *CycloneDxLicenseDetails `json:"license,omitempty"`
+	Expression string                   `json:"expression,omitempty"`
+}
+
+type CycloneDxLicenseDetails struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type CycloneDxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CycloneDxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// ToCycloneDx15Bom converts the build-info to a CycloneDX 1.5 SBOM.
+// The build is the component described by the metadata of the BOM, and it depends on its modules.
+// The modules and their dependencies are the components of the BOM, identified by their IDs, and the dependency graph is built from the RequestedBy fields of the dependencies.
+// Dependencies, which appear in multiple modules, are merged.
+func (targetBuildInfo *BuildInfo) ToCycloneDx15Bom() *CycloneDxBom {
+	buildRef := targetBuildInfo.Name + ":" + targetBuildInfo.Number
+	bom := &CycloneDxBom{
+		Schema:       cycloneDxSchema,
+		BomFormat:    CycloneDxBomFormat,
+		SpecVersion:  CycloneDxSpecVersion,
+		SerialNumber: getCycloneDxSerialNumber(targetBuildInfo),
+		Version:      1,
+		Metadata: &CycloneDxMetadata{
+			Timestamp: getRfc3339Timestamp(targetBuildInfo.Started),
+			Tools:     &CycloneDxTools{Components: []CycloneDxComponent{{Type: "application", Name: cycloneDxToolName}}},
+			Component: &CycloneDxComponent{Type: "application", BomRef: buildRef, Name: targetBuildInfo.Name, Version: targetBuildInfo.Number},
+		},
+	}
+
+	dependsOn := map[string]map[string]bool{buildRef: {}}
+	addDependsOn := func(ref, childRef string) {
+		if dependsOn[ref] == nil {
+			dependsOn[ref] = make(map[string]bool)
+		}
+		if childRef != "" {
+			dependsOn[ref][childRef] = true
+		}
+	}
+	var dependencies []Dependency
+	dependencyIndexes := make(map[string]int)
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		moduleComponent := newCycloneDxComponent("application", module.Id, string(module.Type), nil, Checksum{})
+		for _, artifact := range module.Artifacts {
+			artifactComponent := newCycloneDxComponent("file", artifact.Name, artifact.Type, nil, artifact.Checksum)
+			artifactComponent.BomRef = module.Id + "/" + artifact.Name
+			moduleComponent.Components = append(moduleComponent.Components, artifactComponent)
+		}
+		bom.Components = append(bom.Components, moduleComponent)
+		addDependsOn(buildRef, module.Id)
+		addDependsOn(module.Id, "")
+		for _, dependency := range module.Dependencies {
+			if dependency.Purl == "" {
+				dependency.Purl = GetPurl(module.Type, dependency.Id)
+			}
+			if len(dependency.RequestedBy) == 0 {
+				// A direct dependency of the module.
+				addDependsOn(module.Id, dependency.Id)
+			}
+			if index, exists := dependencyIndexes[dependency.Id]; exists {
+				dependencies[index] = mergeDependencies(dependencies[index], dependency)
+				continue
+			}
+			dependencyIndexes[dependency.Id] = len(dependencies)
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	for _, dependency := range dependencies {
+		if _, exists := dependsOn[dependency.Id]; exists {
+			// A module, which is a dependency of another module.
+			continue
+		}
+		dependencyComponent := newCycloneDxComponent("library", dependency.Id, dependency.Type, dependency.Scopes, dependency.Checksum)
+		dependencyComponent.Purl = dependency.Purl
+		if len(dependency.Licenses) > 0 {
+			// A component may have a single license expression.
+			dependencyComponent.Licenses = []CycloneDxLicense{{Expression: joinSpdxLicenses(dependency.Licenses)}}
+		}
+		bom.Components = append(bom.Components, dependencyComponent)
+		addDependsOn(dependency.Id, "")
+	}
+	for _, dependency := range dependencies {
+		for _, requestedByPath := range dependency.RequestedBy {
+			if len(requestedByPath) == 0 {
+				continue
+			}
+			// Parents, which aren't components of the BOM, are skipped, to keep the dependency graph valid.
+			if _, exists := dependsOn[requestedByPath[0]]; exists {
+				addDependsOn(requestedByPath[0], dependency.Id)
+			}
+		}
+	}
+
+	refs := maps.Keys(dependsOn)
+	sort.Strings(refs)
+	for _, ref := range refs {
+		childRefs := maps.Keys(dependsOn[ref])
+		sort.Strings(childRefs)
+		bom.Dependencies = append(bom.Dependencies, CycloneDxDependency{Ref: ref, DependsOn: childRefs})
+	}
+	return bom
+}
+
+func newCycloneDxComponent(componentType, id, buildInfoType string, scopes []string, checksum Checksum) CycloneDxComponent {
+	component := CycloneDxComponent{Type: componentType, BomRef: id}
+	if packageComponent, err := packageIdToCycloneDxComponent(id); err == nil {
+		component.Group, component.Name, component.Version = packageComponent.Group, packageComponent.Name, packageComponent.Version
+	} else {
+		// IDs, which consist of more than three parts, can't be split to the group, name and version of the component.
+		component.Name = id
+	}
+	for _, hash := range []CycloneDxHash{{Alg: "SHA-512", Content: checksum.Sha512}, {Alg: "SHA-256", Content: checksum.Sha256}, {Alg: "SHA-1", Content: checksum.Sha1}, {Alg: "MD5", Content: checksum.Md5}} {
+		if hash.Content != "" {
+			component.Hashes = append(component.Hashes, hash)
+		}
+	}
+	if buildInfoType != "" {
+		component.Properties = append(component.Properties, CycloneDxProperty{Name: CycloneDxTypeProperty, Value: buildInfoType})
+	}
+	if len(scopes) > 0 {
+		component.Properties = append(component.Properties, CycloneDxProperty{Name: CycloneDxScopesProperty, Value: strings.Join(scopes, ",")})
+	}
+	return component
+}
+
+// Returns the time the build started at, in the format required by CycloneDX and SPDX (RFC 3339), or an empty string if it's not set.
+func getRfc3339Timestamp(started string) string {
+	startedTime, err := time.Parse(TimeFormat, started)
+	if err != nil {
+		return ""
+	}
+	return startedTime.UTC().Format(time.RFC3339)
+}
+
+// Returns a name-based (version 5) UUID URN, which identifies the BOM of the build.
+// Converting the same build-info always produces the same serial number.
+func getCycloneDxSerialNumber(buildInfo *BuildInfo) string {
+	return "urn:uuid:" + getBuildUuid(buildInfo)
+}
+
+// Returns a name-based (version 5) UUID, which identifies the build by its name, number and start time.
+func getBuildUuid(buildInfo *BuildInfo) string {
+	return getNameBasedUuid(buildInfo.Name + "\n" + buildInfo.Number + "\n" + buildInfo.Started)
+}
+
+// Returns a name-based (version 5) UUID of the given name.
+func getNameBasedUuid(name string) string {
+	hash := sha1.Sum([]byte(name))
+	hash[6] = (hash[6] & 0x0f) | 0x50
+	hash[8] = (hash[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", hash[0:4], hash[4:6], hash[6:8], hash[8:10], hash[10:16])
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToCycloneDx15Bom(t *testing.T) {
+	buildInfo := &BuildInfo{
+		Name:    "my-build",
+		Number:  "1",
+		Started: "2023-01-01T10:00:00.000+0000",
+		Modules: []Module{
+			{
+				Id:        "my-module",
+				Type:      Go,
+				Artifacts: []Artifact{{Name: "my-module.zip", Type: "zip", Checksum: Checksum{Sha1: "a1", Md5: "m1"}}},
+				Dependencies: []Dependency{
+					{Id: "github.com/dep1:v1.0.0", Type: "zip", Checksum: Checksum{Sha256: "s1"}, RequestedBy: [][]string{{"my-module"}}},
+					{Id: "github.com/dep2:v2.0.0", Scopes: []string{"test"}, RequestedBy: [][]string{{"github.com/dep1:v1.0.0", "my-module"}}},
+				},
+			},
+			{
+				Id:   "other-module",
+				Type: Go,
+				Dependencies: []Dependency{
+					{Id: "github.com/dep1:v1.0.0", Type: "zip", Checksum: Checksum{Sha256: "s1"}},
+					{Id: "github.com/dep2:v2.0.0", Scopes: []string{"compile"}, RequestedBy: [][]string{{"other-module"}}},
+				},
+			},
+		},
+	}
+
+	bom := buildInfo.ToCycloneDx15Bom()
+	assert.Equal(t, CycloneDxBomFormat, bom.BomFormat)
+	assert.Equal(t, CycloneDxSpecVersion, bom.SpecVersion)
+	assert.Equal(t, "2023-01-01T10:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "my-build:1", bom.Metadata.Component.BomRef)
+	assert.Regexp(t, "^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$", bom.SerialNumber)
+	assert.Equal(t, bom.SerialNumber, buildInfo.ToCycloneDx15Bom().SerialNumber)
+
+	assert.Len(t, bom.Components, 4)
+	module := bom.Components[0]
+	assert.Equal(t, "application", module.Type)
+	assert.Equal(t, "my-module", module.Name)
+	assert.Equal(t, []CycloneDxProperty{{Name: CycloneDxTypeProperty, Value: "go"}}, module.Properties)
+	assert.Equal(t, []CycloneDxComponent{{
+		Type:       "file",
+		BomRef:     "my-module/my-module.zip",
+		Name:       "my-module.zip",
+		Hashes:     []CycloneDxHash{{Alg: "SHA-1", Content: "a1"}, {Alg: "MD5", Content: "m1"}},
+		Properties: []CycloneDxProperty{{Name: CycloneDxTypeProperty, Value: "zip"}},
+	}}, module.Components)
+
+	dep1 := bom.Components[2]
+	assert.Equal(t, "library", dep1.Type)
+	assert.Empty(t, dep1.Group)
+	assert.Equal(t, "github.com/dep1", dep1.Name)
+	assert.Equal(t, "v1.0.0", dep1.Version)
+	assert.Equal(t, []CycloneDxHash{{Alg: "SHA-256", Content: "s1"}}, dep1.Hashes)
+	assert.Equal(t, "pkg:golang/github.com/dep1@v1.0.0", dep1.Purl)
+	// The scopes of dependencies, which appear in multiple modules, are merged.
+	assert.Equal(t, []CycloneDxProperty{{Name: CycloneDxScopesProperty, Value: "test,compile"}}, bom.Components[3].Properties)
+
+	assert.Equal(t, []CycloneDxDependency{
+		{Ref: "github.com/dep1:v1.0.0", DependsOn: []string{"github.com/dep2:v2.0.0"}},
+		{Ref: "github.com/dep2:v2.0.0", DependsOn: []string{}},
+		{Ref: "my-build:1", DependsOn: []string{"my-module", "other-module"}},
+		{Ref: "my-module", DependsOn: []string{"github.com/dep1:v1.0.0"}},
+		{Ref: "other-module", DependsOn: []string{"github.com/dep1:v1.0.0", "github.com/dep2:v2.0.0"}},
+	}, bom.Dependencies)
+
+	content, err := json.Marshal(bom)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"$schema":"http://cyclonedx.org/schema/bom-1.5.schema.json"`)
+}
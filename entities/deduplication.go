@@ -0,0 +1,131 @@
+package entities
+
+import "fmt"
+
+// The policies for dependencies, which several modules of the same build have.
+const (
+	// Each module keeps the full details of its dependencies. This is the default.
+	KeepPerModule DependencyDeduplicationPolicy = "keep-per-module"
+	// The dependencies, which several modules have, are moved to a shared module, whose RequestedBy paths end with the IDs of the modules, which requested them.
+	HoistToShared DependencyDeduplicationPolicy = "hoist-to-shared"
+	// The first module, which has a dependency, keeps its full details, and the other modules keep only its ID, type, sha1 checksum, scopes and RequestedBy paths.
+	ReferenceById DependencyDeduplicationPolicy = "reference-by-id"
+
+	// The ID of the module, to which the shared dependencies are hoisted.
+	SharedDependenciesModuleId = "shared-dependencies"
+)
+
+type DependencyDeduplicationPolicy string
+
+// DeduplicateDependencies applies the policy to the dependencies, which several modules (including sub-modules) of the build-info have.
+// Dependencies are identical if they have the same ID and sha1 checksum.
+func (targetBuildInfo *BuildInfo) DeduplicateDependencies(policy DependencyDeduplicationPolicy) error {
+	switch policy {
+	case KeepPerModule, "":
+		return nil
+	case HoistToShared:
+		targetBuildInfo.hoistSharedDependencies()
+		return nil
+	case ReferenceById:
+		targetBuildInfo.referenceDependenciesById()
+		return nil
+	default:
+		return fmt.Errorf("'%s' is not a valid dependency deduplication policy", policy)
+	}
+}
+
+// ResolveDependencyReferences restores the full details of the dependencies, which were deduplicated by the reference-by-id policy, from the modules, which kept them.
+func (targetBuildInfo *BuildInfo) ResolveDependencyReferences() {
+	details := make(map[string]Dependency)
+	forEachModule(targetBuildInfo.Modules, func(module *Module) {
+		for _, dependency := range module.Dependencies {
+			key := dependency.getDeduplicationKey()
+			if _, exists := details[key]; !exists && !dependency.isReference() {
+				details[key] = dependency
+			}
+		}
+	})
+	forEachModule(targetBuildInfo.Modules, func(module *Module) {
+		for i, dependency := range module.Dependencies {
+			if full, exists := details[dependency.getDeduplicationKey()]; exists && dependency.isReference() {
+				full.Scopes, full.StandardScopes, full.RequestedBy = dependency.Scopes, dependency.StandardScopes, dependency.RequestedBy
+				module.Dependencies[i] = full
+			}
+		}
+	})
+}
+
+func (targetBuildInfo *BuildInfo) hoistSharedDependencies() {
+	modulesCount := countDependencyModules(targetBuildInfo.Modules)
+	shared := Module{Id: SharedDependenciesModuleId, Type: Shared}
+	forEachModule(targetBuildInfo.Modules, func(module *Module) {
+		// Aggregated builds are not supported
+		if module.Type == Build || module.Type == Shared {
+			return
+		}
+		var dependencies []Dependency
+		for _, dependency := range module.Dependencies {
+			if modulesCount[dependency.getDeduplicationKey()] > 1 {
+				mergeDependenciesLists(&[]Dependency{dependency}, &shared.Dependencies)
+			} else {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		module.Dependencies = dependencies
+	})
+	if len(shared.Dependencies) > 0 {
+		appendModules([]Module{shared}, &targetBuildInfo.Modules)
+	}
+}
+
+func (targetBuildInfo *BuildInfo) referenceDependenciesById() {
+	referenced := make(map[string]bool)
+	forEachModule(targetBuildInfo.Modules, func(module *Module) {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			return
+		}
+		for i, dependency := range module.Dependencies {
+			key := dependency.getDeduplicationKey()
+			if !referenced[key] {
+				referenced[key] = true
+				continue
+			}
+			module.Dependencies[i] = Dependency{
+				Id:             dependency.Id,
+				Type:           dependency.Type,
+				Checksum:       Checksum{Sha1: dependency.Sha1},
+				Scopes:         dependency.Scopes,
+				StandardScopes: dependency.StandardScopes,
+				RequestedBy:    dependency.RequestedBy,
+			}
+		}
+	})
+}
+
+// Returns the number of modules, which have each dependency, by its deduplication key.
+func countDependencyModules(modules []Module) map[string]int {
+	counts := make(map[string]int)
+	forEachModule(modules, func(module *Module) {
+		if module.Type == Build {
+			return
+		}
+		counted := make(map[string]bool)
+		for _, dependency := range module.Dependencies {
+			if key := dependency.getDeduplicationKey(); !counted[key] {
+				counted[key] = true
+				counts[key]++
+			}
+		}
+	})
+	return counts
+}
+
+func (d *Dependency) getDeduplicationKey() string {
+	return d.Id + ":" + d.Sha1
+}
+
+// Returns true if the dependency has only the details, which the reference-by-id policy keeps.
+func (d *Dependency) isReference() bool {
+	return d.Md5 == "" && d.Sha256 == "" && d.Sha512 == "" && d.Purl == "" && len(d.Licenses) == 0 && len(d.Properties) == 0
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeduplicationBuildInfo() *BuildInfo {
+	return &BuildInfo{Name: "monorepo", Number: "1", Modules: []Module{
+		{Id: "web", Type: Npm, Dependencies: []Dependency{
+			{Id: "react:18.2.0", Type: "npm", Scopes: []string{"prod"}, Checksum: Checksum{Sha1: "r1", Md5: "r2"}, RequestedBy: [][]string{{"web"}}},
+			{Id: "loose-envify:1.4.0", Type: "npm", Checksum: Checksum{Sha1: "l1"}, Purl: "pkg:npm/loose-envify@1.4.0", RequestedBy: [][]string{{"react:18.2.0", "web"}}},
+		}},
+		{Id: "admin", Type: Npm, Dependencies: []Dependency{
+			{Id: "react:18.2.0", Type: "npm", Scopes: []string{"dev"}, Checksum: Checksum{Sha1: "r1", Md5: "r2"}, RequestedBy: [][]string{{"admin"}}},
+			{Id: "lodash:4.17.21", Type: "npm", Checksum: Checksum{Sha1: "o1"}, RequestedBy: [][]string{{"react:18.2.0", "admin"}}},
+		}},
+		{Id: "other-build/1", Type: Build},
+	}}
+}
+
+func TestDeduplicateDependenciesHoistToShared(t *testing.T) {
+	buildInfo := newDeduplicationBuildInfo()
+	assert.NoError(t, buildInfo.DeduplicateDependencies(HoistToShared))
+	assert.Len(t, buildInfo.Modules, 4)
+	assert.Equal(t, []string{"loose-envify:1.4.0"}, getDependencyIds(buildInfo.Modules[0]))
+	assert.Equal(t, []string{"lodash:4.17.21"}, getDependencyIds(buildInfo.Modules[1]))
+	shared := buildInfo.Modules[3]
+	assert.Equal(t, SharedDependenciesModuleId, shared.Id)
+	assert.Equal(t, Shared, shared.Type)
+	assert.Equal(t, []Dependency{{Id: "react:18.2.0", Type: "npm", Scopes: []string{"prod", "dev"}, Checksum: Checksum{Sha1: "r1", Md5: "r2"}, RequestedBy: [][]string{{"web"}, {"admin"}}}}, shared.Dependencies)
+	// The hoisted dependencies may be referenced by the dependencies of all the modules.
+	for _, violation := range Validate(buildInfo) {
+		assert.NotEqual(t, DanglingRequestedByViolation, violation.Rule, violation.String())
+	}
+}
+
+func TestDeduplicateDependenciesReferenceById(t *testing.T) {
+	buildInfo := newDeduplicationBuildInfo()
+	assert.NoError(t, buildInfo.DeduplicateDependencies(ReferenceById))
+	assert.Equal(t, Checksum{Sha1: "r1", Md5: "r2"}, buildInfo.Modules[0].Dependencies[0].Checksum)
+	assert.Equal(t, Dependency{Id: "react:18.2.0", Type: "npm", Scopes: []string{"dev"}, Checksum: Checksum{Sha1: "r1"}, RequestedBy: [][]string{{"admin"}}}, buildInfo.Modules[1].Dependencies[0])
+
+	buildInfo.ResolveDependencyReferences()
+	assert.Equal(t, newDeduplicationBuildInfo(), buildInfo)
+}
+
+func TestDeduplicateDependenciesPolicies(t *testing.T) {
+	buildInfo := newDeduplicationBuildInfo()
+	assert.NoError(t, buildInfo.DeduplicateDependencies(KeepPerModule))
+	assert.Equal(t, newDeduplicationBuildInfo(), buildInfo)
+	assert.Error(t, buildInfo.DeduplicateDependencies("hoist"))
+}
+
+func getDependencyIds(module Module) []string {
+	var ids []string
+	for _, dependency := range module.Dependencies {
+		ids = append(ids, dependency.Id)
+	}
+	return ids
+}
@@ -0,0 +1,102 @@
+package entities
+
+import "sort"
+
+// The changes of artifacts, in relation to a previous build.
+const (
+	ArtifactAdded   = "added"
+	ArtifactRemoved = "removed"
+	ArtifactChanged = "changed"
+)
+
+// BuildInfoDiff holds the changes of a build, in relation to a previous build.
+type BuildInfoDiff struct {
+	Dependencies []DependencyDiff `json:"dependencies,omitempty"`
+	Artifacts    []ArtifactDiff   `json:"artifacts,omitempty"`
+	Env          []EnvDiff        `json:"env,omitempty"`
+}
+
+// ArtifactDiff is a change of an artifact of a module, in relation to a previous build. An artifact changed if its checksums differ.
+type ArtifactDiff struct {
+	ModuleId         string   `json:"moduleId"`
+	Name             string   `json:"name"`
+	PreviousChecksum Checksum `json:"previousChecksum"`
+	Checksum         Checksum `json:"checksum"`
+	Change           string   `json:"change"`
+}
+
+// EnvDiff is a change of an environment variable, in relation to a previous build. Its change is one of EnvAdded, EnvRemoved and EnvChanged.
+type EnvDiff struct {
+	Name          string `json:"name"`
+	PreviousValue string `json:"previousValue,omitempty"`
+	Value         string `json:"value,omitempty"`
+	Change        string `json:"change"`
+}
+
+// IsEmpty returns true if nothing changed between the builds.
+func (diff *BuildInfoDiff) IsEmpty() bool {
+	return len(diff.Dependencies) == 0 && len(diff.Artifacts) == 0 && len(diff.Env) == 0
+}
+
+// Diff returns the dependencies, artifacts and environment variables, which were added, removed or changed in the current build, in relation to the previous build.
+// The dependencies are compared as in DiffDependencies(). The artifacts are matched by the IDs of their modules and their names. If the previous build is nil, everything was added.
+func Diff(previous, current *BuildInfo) *BuildInfoDiff {
+	if previous == nil {
+		previous = &BuildInfo{}
+	}
+	diff := &BuildInfoDiff{Dependencies: current.DiffDependencies(previous), Artifacts: diffArtifacts(previous, current)}
+	for _, variable := range diffEnv(previous, current) {
+		if variable.Status != EnvUnchanged {
+			diff.Env = append(diff.Env, EnvDiff{Name: variable.Name, PreviousValue: variable.PreviousValue, Value: variable.Value, Change: variable.Status})
+		}
+	}
+	return diff
+}
+
+func diffArtifacts(previous, current *BuildInfo) []ArtifactDiff {
+	previousChecksums := getArtifactChecksums(previous)
+	currentChecksums := getArtifactChecksums(current)
+	var diffs []ArtifactDiff
+	for moduleId, checksums := range currentChecksums {
+		for name, checksum := range checksums {
+			previousChecksum, exists := previousChecksums[moduleId][name]
+			switch {
+			case !exists:
+				diffs = append(diffs, ArtifactDiff{ModuleId: moduleId, Name: name, Checksum: checksum, Change: ArtifactAdded})
+			case previousChecksum != checksum:
+				diffs = append(diffs, ArtifactDiff{ModuleId: moduleId, Name: name, PreviousChecksum: previousChecksum, Checksum: checksum, Change: ArtifactChanged})
+			}
+		}
+	}
+	for moduleId, checksums := range previousChecksums {
+		for name, previousChecksum := range checksums {
+			if _, exists := currentChecksums[moduleId][name]; !exists {
+				diffs = append(diffs, ArtifactDiff{ModuleId: moduleId, Name: name, PreviousChecksum: previousChecksum, Change: ArtifactRemoved})
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].ModuleId != diffs[j].ModuleId {
+			return diffs[i].ModuleId < diffs[j].ModuleId
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// Returns the checksums of the artifacts of each module, by the names of the artifacts.
+func getArtifactChecksums(buildInfo *BuildInfo) map[string]map[string]Checksum {
+	moduleChecksums := make(map[string]map[string]Checksum)
+	for _, module := range buildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		checksums := make(map[string]Checksum)
+		for _, artifact := range module.Artifacts {
+			checksums[artifact.Name] = artifact.Checksum
+		}
+		moduleChecksums[module.Id] = checksums
+	}
+	return moduleChecksums
+}
@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	previous := &BuildInfo{
+		Properties: Env{BuildInfoEnvPrefix + "GOOS": "linux", BuildInfoEnvPrefix + "CI": "true", BuildInfoEnvPrefix + "OLD": "1"},
+		Modules: []Module{{Id: "my-app", Type: Npm,
+			Artifacts:    []Artifact{{Name: "app.tgz", Checksum: Checksum{Sha1: "1"}}, {Name: "docs.tgz", Checksum: Checksum{Sha1: "2"}}},
+			Dependencies: []Dependency{{Id: "express:4.18.1"}},
+		}},
+	}
+	current := &BuildInfo{
+		Properties: Env{BuildInfoEnvPrefix + "GOOS": "darwin", BuildInfoEnvPrefix + "CI": "true", BuildInfoEnvPrefix + "NEW": "2", "buildInfo.other": "ignored"},
+		Modules: []Module{{Id: "my-app", Type: Npm,
+			Artifacts:    []Artifact{{Name: "app.tgz", Checksum: Checksum{Sha1: "3"}}, {Name: "types.tgz", Checksum: Checksum{Sha1: "4"}}},
+			Dependencies: []Dependency{{Id: "express:4.18.2"}},
+		}},
+	}
+
+	diff := Diff(previous, current)
+	assert.Equal(t, []DependencyDiff{{ModuleId: "my-app", Name: "express", PreviousVersion: "4.18.1", Version: "4.18.2", Change: DependencyUpdated}}, diff.Dependencies)
+	assert.Equal(t, []ArtifactDiff{
+		{ModuleId: "my-app", Name: "app.tgz", PreviousChecksum: Checksum{Sha1: "1"}, Checksum: Checksum{Sha1: "3"}, Change: ArtifactChanged},
+		{ModuleId: "my-app", Name: "docs.tgz", PreviousChecksum: Checksum{Sha1: "2"}, Change: ArtifactRemoved},
+		{ModuleId: "my-app", Name: "types.tgz", Checksum: Checksum{Sha1: "4"}, Change: ArtifactAdded},
+	}, diff.Artifacts)
+	assert.Equal(t, []EnvDiff{
+		{Name: "GOOS", PreviousValue: "linux", Value: "darwin", Change: EnvChanged},
+		{Name: "NEW", Value: "2", Change: EnvAdded},
+		{Name: "OLD", PreviousValue: "1", Change: EnvRemoved},
+	}, diff.Env)
+	assert.False(t, diff.IsEmpty())
+
+	assert.True(t, Diff(current, current).IsEmpty())
+	// Without a previous build, everything was added.
+	diff = Diff(nil, current)
+	assert.Len(t, diff.Dependencies, 1)
+	assert.Len(t, diff.Artifacts, 2)
+	assert.Len(t, diff.Env, 3)
+}
@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"strings"
+
+	"github.com/jfrog/gofrog/stringutils"
+)
+
+// DependencyFilter selects dependencies by wildcard patterns (for example, 'com.example.internal:*' or 'pkg:npm/%40example/*'), which are matched case-insensitively.
+// A dependency matches the filter if it matches at least one of its patterns.
+type DependencyFilter struct {
+	// Matched against the IDs of the dependencies.
+	Ids []string `json:"ids,omitempty"`
+	// Matched against the purls of the dependencies. Dependencies without purls don't match these patterns.
+	Purls []string `json:"purls,omitempty"`
+	// Matched against the scopes, which the package managers set, and the standard scopes of the dependencies (for example, 'test').
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// IsEmpty returns true if the filter has no patterns, so no dependency matches it.
+func (filter *DependencyFilter) IsEmpty() bool {
+	return len(filter.Ids) == 0 && len(filter.Purls) == 0 && len(filter.Scopes) == 0
+}
+
+// Matches returns true if the dependency matches at least one of the patterns of the filter.
+func (filter *DependencyFilter) Matches(dependency *Dependency) (bool, error) {
+	if match, err := matchAnyWildcardPattern(filter.Ids, dependency.Id); match || err != nil {
+		return match, err
+	}
+	if dependency.Purl != "" {
+		if match, err := matchAnyWildcardPattern(filter.Purls, dependency.Purl); match || err != nil {
+			return match, err
+		}
+	}
+	if len(filter.Scopes) == 0 {
+		return false, nil
+	}
+	scopes := append([]string{}, dependency.Scopes...)
+	standardScopes := dependency.StandardScopes
+	if len(standardScopes) == 0 {
+		// The standard scopes are set when the build-info is created, so they may not be set yet.
+		standardScopes = GetStandardScopes(dependency.Scopes)
+	}
+	for _, scope := range standardScopes {
+		scopes = append(scopes, string(scope))
+	}
+	for _, scope := range scopes {
+		if match, err := matchAnyWildcardPattern(filter.Scopes, scope); match || err != nil {
+			return match, err
+		}
+	}
+	return false, nil
+}
+
+// IncludeDependencies keeps only the dependencies of the modules (and sub-modules), which match the filter.
+// The IDs of the removed dependencies are removed from the RequestedBy paths of the remaining ones.
+func (targetBuildInfo *BuildInfo) IncludeDependencies(filter DependencyFilter) error {
+	return targetBuildInfo.filterDependencies(filter, true)
+}
+
+// ExcludeDependencies removes the dependencies of the modules (and sub-modules), which match the filter.
+// The IDs of the removed dependencies are removed from the RequestedBy paths of the remaining ones.
+func (targetBuildInfo *BuildInfo) ExcludeDependencies(filter DependencyFilter) error {
+	return targetBuildInfo.filterDependencies(filter, false)
+}
+
+func (targetBuildInfo *BuildInfo) filterDependencies(filter DependencyFilter, include bool) (err error) {
+	forEachModule(targetBuildInfo.Modules, func(module *Module) {
+		// Aggregated builds are not supported
+		if err != nil || module.Type == Build {
+			return
+		}
+		var kept []Dependency
+		removedIds := make(map[string]bool)
+		for i := range module.Dependencies {
+			var match bool
+			if match, err = filter.Matches(&module.Dependencies[i]); err != nil {
+				return
+			}
+			if match == include {
+				kept = append(kept, module.Dependencies[i])
+			} else {
+				removedIds[module.Dependencies[i].Id] = true
+			}
+		}
+		if len(removedIds) == 0 {
+			return
+		}
+		for i := range kept {
+			kept[i].RequestedBy = removeIdsFromRequestedBy(kept[i].RequestedBy, removedIds)
+		}
+		module.Dependencies = kept
+	})
+	return
+}
+
+// Removes the IDs from the paths. Paths, which become identical, are kept once, and paths, which become empty, are removed.
+func removeIdsFromRequestedBy(requestedBy [][]string, ids map[string]bool) [][]string {
+	var filtered [][]string
+	for _, path := range requestedBy {
+		var filteredPath []string
+		for _, id := range path {
+			if !ids[id] {
+				filteredPath = append(filteredPath, id)
+			}
+		}
+		if len(filteredPath) > 0 && !containsPath(filtered, filteredPath) {
+			filtered = append(filtered, filteredPath)
+		}
+	}
+	return filtered
+}
+
+func matchAnyWildcardPattern(patterns []string, value string) (bool, error) {
+	for _, pattern := range patterns {
+		match, err := stringutils.MatchWildcardPattern(strings.ToLower(pattern), strings.ToLower(value))
+		if match || err != nil {
+			return match, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyFilterMatches(t *testing.T) {
+	dependency := &Dependency{Id: "com.example.internal:utils:1.0", Purl: "pkg:maven/com.example.internal/utils@1.0", Scopes: []string{"testImplementation"}}
+	tests := []struct {
+		name     string
+		filter   DependencyFilter
+		expected bool
+	}{
+		{"empty", DependencyFilter{}, false},
+		{"id", DependencyFilter{Ids: []string{"com.example.internal:*"}}, true},
+		{"id case", DependencyFilter{Ids: []string{"COM.EXAMPLE.*"}}, true},
+		{"other id", DependencyFilter{Ids: []string{"org.example:*"}}, false},
+		{"purl", DependencyFilter{Purls: []string{"pkg:maven/com.example.internal/*"}}, true},
+		{"scope", DependencyFilter{Scopes: []string{"testImplementation"}}, true},
+		{"standard scope", DependencyFilter{Scopes: []string{"test"}}, true},
+		{"other scope", DependencyFilter{Scopes: []string{"compile"}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			match, err := test.filter.Matches(dependency)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, match)
+		})
+	}
+	// A dependency without a purl doesn't match the purl patterns.
+	match, err := (&DependencyFilter{Purls: []string{"*"}}).Matches(&Dependency{Id: "a:1"})
+	assert.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestExcludeDependencies(t *testing.T) {
+	buildInfo := &BuildInfo{Modules: []Module{
+		{Id: "module", Type: Maven, Dependencies: []Dependency{
+			{Id: "a:1", RequestedBy: [][]string{{"module"}}},
+			{Id: "internal:1", RequestedBy: [][]string{{"a:1", "module"}}},
+			{Id: "b:1", RequestedBy: [][]string{{"internal:1", "a:1", "module"}, {"a:1", "module"}}},
+			{Id: "junit:4", Scopes: []string{"test"}, RequestedBy: [][]string{{"module"}}},
+		}, Modules: []Module{
+			{Id: "sub", Type: Maven, Dependencies: []Dependency{{Id: "internal:1"}}},
+		}},
+		{Id: "build/1", Type: Build, Dependencies: []Dependency{{Id: "internal:1"}}},
+	}}
+	assert.NoError(t, buildInfo.ExcludeDependencies(DependencyFilter{Ids: []string{"internal:*"}, Scopes: []string{"test"}}))
+	assert.Equal(t, []Dependency{
+		{Id: "a:1", RequestedBy: [][]string{{"module"}}},
+		{Id: "b:1", RequestedBy: [][]string{{"a:1", "module"}}},
+	}, buildInfo.Modules[0].Dependencies)
+	assert.Empty(t, buildInfo.Modules[0].Modules[0].Dependencies)
+	// Aggregated builds aren't filtered.
+	assert.Len(t, buildInfo.Modules[1].Dependencies, 1)
+}
+
+func TestIncludeDependencies(t *testing.T) {
+	buildInfo := &BuildInfo{Modules: []Module{
+		{Id: "module", Type: Npm, Dependencies: []Dependency{
+			{Id: "a:1", Purl: "pkg:npm/a@1", RequestedBy: [][]string{{"module"}}},
+			{Id: "eslint:8", Purl: "pkg:npm/eslint@8", RequestedBy: [][]string{{"module"}}},
+		}},
+	}}
+	assert.NoError(t, buildInfo.IncludeDependencies(DependencyFilter{Purls: []string{"pkg:npm/a@*"}}))
+	assert.Equal(t, []Dependency{{Id: "a:1", Purl: "pkg:npm/a@1", RequestedBy: [][]string{{"module"}}}}, buildInfo.Modules[0].Dependencies)
+}
@@ -0,0 +1,202 @@
+package entities
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The formats of the dependency graph.
+const (
+	GraphDot     GraphFormat = "dot"
+	GraphMermaid GraphFormat = "mermaid"
+	GraphMl      GraphFormat = "graphml"
+)
+
+type GraphFormat string
+
+// moduleGraph is the dependency graph of a module, built from the RequestedBy fields of its dependencies.
+// The module is the root of the graph, and the dependencies are the other nodes, in the order they appear in the module.
+type moduleGraph struct {
+	moduleId      string
+	dependencyIds []string
+	// The same dependency may appear in several modules, so the nodes of all the graphs are named by the indexes of their modules and dependencies.
+	nodeNames map[string]string
+	// The edges of the graph, from a parent (the module or a dependency) to the dependency it requested, sorted.
+	edges [][2]string
+}
+
+// WriteDependencyGraph writes the dependency graphs of the modules in the given format: Graphviz DOT, Mermaid or GraphML. Each module is a separate graph, whose root is the module.
+// The edges are taken from the first items of the RequestedBy paths of the dependencies. Dependencies without RequestedBy paths are requested by the module itself.
+func (targetBuildInfo *BuildInfo) WriteDependencyGraph(writer io.Writer, format GraphFormat) error {
+	graphs := targetBuildInfo.getModuleGraphs()
+	switch format {
+	case GraphDot:
+		return writeDotGraph(writer, targetBuildInfo.Name+"/"+targetBuildInfo.Number, graphs)
+	case GraphMermaid:
+		return writeMermaidGraph(writer, graphs)
+	case GraphMl:
+		return writeGraphMl(writer, targetBuildInfo.Name+"/"+targetBuildInfo.Number, graphs)
+	default:
+		return fmt.Errorf("'%s' is not a supported dependency graph format", format)
+	}
+}
+
+func (targetBuildInfo *BuildInfo) getModuleGraphs() []moduleGraph {
+	var graphs []moduleGraph
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		graph := moduleGraph{moduleId: module.Id, nodeNames: map[string]string{module.Id: fmt.Sprintf("m%d", len(graphs))}}
+		for _, dependency := range module.Dependencies {
+			if _, exists := graph.nodeNames[dependency.Id]; !exists {
+				graph.nodeNames[dependency.Id] = fmt.Sprintf("m%d_d%d", len(graphs), len(graph.dependencyIds))
+				graph.dependencyIds = append(graph.dependencyIds, dependency.Id)
+			}
+		}
+		edges := make(map[[2]string]bool)
+		for _, dependency := range module.Dependencies {
+			if len(dependency.RequestedBy) == 0 {
+				edges[[2]string{module.Id, dependency.Id}] = true
+			}
+			for _, path := range dependency.RequestedBy {
+				// Parents, which aren't dependencies of the module, were truncated from the graph.
+				if len(path) > 0 && graph.nodeNames[path[0]] != "" && path[0] != dependency.Id {
+					edges[[2]string{path[0], dependency.Id}] = true
+				}
+			}
+		}
+		for edge := range edges {
+			graph.edges = append(graph.edges, edge)
+		}
+		sort.Slice(graph.edges, func(i, j int) bool {
+			if graph.edges[i][0] != graph.edges[j][0] {
+				return graph.edges[i][0] < graph.edges[j][0]
+			}
+			return graph.edges[i][1] < graph.edges[j][1]
+		})
+		graphs = append(graphs, graph)
+	}
+	return graphs
+}
+
+// Writes the graphs as a Graphviz DOT digraph, with a cluster for each module. The nodes are labeled by the IDs of the modules and dependencies.
+func writeDotGraph(writer io.Writer, name string, graphs []moduleGraph) error {
+	var dot strings.Builder
+	dot.WriteString("digraph " + quoteDotId(name) + " {\n")
+	dot.WriteString("  rankdir=LR;\n")
+	dot.WriteString("  node [shape=box];\n")
+	for i, graph := range graphs {
+		fmt.Fprintf(&dot, "  subgraph %s {\n", quoteDotId(fmt.Sprintf("cluster_%d", i)))
+		fmt.Fprintf(&dot, "    label=%s;\n", quoteDotId(graph.moduleId))
+		fmt.Fprintf(&dot, "    %s [label=%s, style=bold];\n", quoteDotId(graph.nodeNames[graph.moduleId]), quoteDotId(graph.moduleId))
+		for _, dependencyId := range graph.dependencyIds {
+			fmt.Fprintf(&dot, "    %s [label=%s];\n", quoteDotId(graph.nodeNames[dependencyId]), quoteDotId(dependencyId))
+		}
+		for _, edge := range graph.edges {
+			fmt.Fprintf(&dot, "    %s -> %s;\n", quoteDotId(graph.nodeNames[edge[0]]), quoteDotId(graph.nodeNames[edge[1]]))
+		}
+		dot.WriteString("  }\n")
+	}
+	dot.WriteString("}\n")
+	_, err := io.WriteString(writer, dot.String())
+	return err
+}
+
+func quoteDotId(id string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(id) + `"`
+}
+
+// Writes the graphs as a Mermaid flowchart, with a subgraph for each module. The module nodes are drawn as subroutines, to tell them apart from the dependencies.
+func writeMermaidGraph(writer io.Writer, graphs []moduleGraph) error {
+	var mermaid strings.Builder
+	mermaid.WriteString("flowchart LR\n")
+	for i, graph := range graphs {
+		fmt.Fprintf(&mermaid, "  subgraph c%d[%s]\n", i, quoteMermaidLabel(graph.moduleId))
+		fmt.Fprintf(&mermaid, "    %s[[%s]]\n", graph.nodeNames[graph.moduleId], quoteMermaidLabel(graph.moduleId))
+		for _, dependencyId := range graph.dependencyIds {
+			fmt.Fprintf(&mermaid, "    %s[%s]\n", graph.nodeNames[dependencyId], quoteMermaidLabel(dependencyId))
+		}
+		for _, edge := range graph.edges {
+			fmt.Fprintf(&mermaid, "    %s --> %s\n", graph.nodeNames[edge[0]], graph.nodeNames[edge[1]])
+		}
+		mermaid.WriteString("  end\n")
+	}
+	_, err := io.WriteString(writer, mermaid.String())
+	return err
+}
+
+// Mermaid labels are quoted, and the quotes inside them are written as entity codes.
+func quoteMermaidLabel(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "#quot;") + `"`
+}
+
+type graphMlDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMlKey `xml:"key"`
+	Graph   graphMlGraph `xml:"graph"`
+}
+
+type graphMlKey struct {
+	Id       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMlGraph struct {
+	Id          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMlNode `xml:"node"`
+	Edges       []graphMlEdge `xml:"edge"`
+}
+
+type graphMlNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMlData `xml:"data"`
+}
+
+type graphMlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMlEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+// Writes the graphs as a single directed GraphML graph. Each node has the ID of the module or the dependency as its label, and the ID of its module.
+func writeGraphMl(writer io.Writer, name string, graphs []moduleGraph) error {
+	document := graphMlDocument{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMlKey{
+			{Id: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{Id: "module", For: "node", AttrName: "module", AttrType: "string"},
+		},
+		Graph: graphMlGraph{Id: name, EdgeDefault: "directed"},
+	}
+	for _, graph := range graphs {
+		for _, id := range append([]string{graph.moduleId}, graph.dependencyIds...) {
+			document.Graph.Nodes = append(document.Graph.Nodes, graphMlNode{Id: graph.nodeNames[id], Data: []graphMlData{{Key: "label", Value: id}, {Key: "module", Value: graph.moduleId}}})
+		}
+		for _, edge := range graph.edges {
+			document.Graph.Edges = append(document.Graph.Edges, graphMlEdge{Source: graph.nodeNames[edge[0]], Target: graph.nodeNames[edge[1]]})
+		}
+	}
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
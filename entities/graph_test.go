@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getGraphTestBuildInfo() *BuildInfo {
+	return &BuildInfo{Name: "my-build", Number: "1", Modules: []Module{
+		{Id: "my-app", Type: Npm, Dependencies: []Dependency{
+			{Id: "express:4.18.2", RequestedBy: [][]string{{"my-app"}}},
+			{Id: "body-parser:1.20.1", RequestedBy: [][]string{{"express:4.18.2", "my-app"}, {"my-app"}}},
+			// The parent was truncated from the graph.
+			{Id: "bytes:3.1.2", RequestedBy: [][]string{{"missing:1.0.0", "my-app"}}},
+		}},
+		{Id: "my-lib", Type: Go, Dependencies: []Dependency{
+			{Id: `github.com/"quoted":v1.0.0`},
+		}},
+		{Id: "aggregated-build/1", Type: Build},
+	}}
+}
+
+func TestWriteDotDependencyGraph(t *testing.T) {
+	var dot strings.Builder
+	assert.NoError(t, getGraphTestBuildInfo().WriteDependencyGraph(&dot, GraphDot))
+	assert.Equal(t, `digraph "my-build/1" {
+  rankdir=LR;
+  node [shape=box];
+  subgraph "cluster_0" {
+    label="my-app";
+    "m0" [label="my-app", style=bold];
+    "m0_d0" [label="express:4.18.2"];
+    "m0_d1" [label="body-parser:1.20.1"];
+    "m0_d2" [label="bytes:3.1.2"];
+    "m0_d0" -> "m0_d1";
+    "m0" -> "m0_d1";
+    "m0" -> "m0_d0";
+  }
+  subgraph "cluster_1" {
+    label="my-lib";
+    "m1" [label="my-lib", style=bold];
+    "m1_d0" [label="github.com/\"quoted\":v1.0.0"];
+    "m1" -> "m1_d0";
+  }
+}
+`, dot.String())
+
+	assert.Error(t, getGraphTestBuildInfo().WriteDependencyGraph(&dot, "png"))
+}
+
+func TestWriteMermaidDependencyGraph(t *testing.T) {
+	var mermaid strings.Builder
+	assert.NoError(t, getGraphTestBuildInfo().WriteDependencyGraph(&mermaid, GraphMermaid))
+	assert.Equal(t, `flowchart LR
+  subgraph c0["my-app"]
+    m0[["my-app"]]
+    m0_d0["express:4.18.2"]
+    m0_d1["body-parser:1.20.1"]
+    m0_d2["bytes:3.1.2"]
+    m0_d0 --> m0_d1
+    m0 --> m0_d1
+    m0 --> m0_d0
+  end
+  subgraph c1["my-lib"]
+    m1[["my-lib"]]
+    m1_d0["github.com/#quot;quoted#quot;:v1.0.0"]
+    m1 --> m1_d0
+  end
+`, mermaid.String())
+}
+
+func TestWriteGraphMlDependencyGraph(t *testing.T) {
+	var graphMl strings.Builder
+	assert.NoError(t, getGraphTestBuildInfo().WriteDependencyGraph(&graphMl, GraphMl))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="label" for="node" attr.name="label" attr.type="string"></key>
+  <key id="module" for="node" attr.name="module" attr.type="string"></key>
+  <graph id="my-build/1" edgedefault="directed">
+    <node id="m0">
+      <data key="label">my-app</data>
+      <data key="module">my-app</data>
+    </node>
+    <node id="m0_d0">
+      <data key="label">express:4.18.2</data>
+      <data key="module">my-app</data>
+    </node>
+    <node id="m0_d1">
+      <data key="label">body-parser:1.20.1</data>
+      <data key="module">my-app</data>
+    </node>
+    <node id="m0_d2">
+      <data key="label">bytes:3.1.2</data>
+      <data key="module">my-app</data>
+    </node>
+    <node id="m1">
+      <data key="label">my-lib</data>
+      <data key="module">my-lib</data>
+    </node>
+    <node id="m1_d0">
+      <data key="label">github.com/&#34;quoted&#34;:v1.0.0</data>
+      <data key="module">my-lib</data>
+    </node>
+    <edge source="m0_d0" target="m0_d1"></edge>
+    <edge source="m0" target="m0_d1"></edge>
+    <edge source="m0" target="m0_d0"></edge>
+    <edge source="m1" target="m1_d0"></edge>
+  </graph>
+</graphml>
+`, graphMl.String())
+}
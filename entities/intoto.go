@@ -0,0 +1,303 @@
+package entities
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	InTotoStatementType = "https://in-toto.io/Statement/v1"
+	// The type of the payloads of DSSE envelopes, which hold in-toto statements.
+	InTotoPayloadType = "application/vnd.in-toto+json"
+
+	// The predicate types of the statements, which hold a build-info or an SBOM derived from it.
+	BuildInfoPredicateType = "https://github.com/jfrog/build-info-go/build-info/v1"
+	CycloneDxPredicateType = "https://cyclonedx.org/bom"
+	SpdxPredicateType      = "https://spdx.dev/Document"
+)
+
+// InTotoStatement is an in-toto attestation statement, which binds a predicate to the artifacts it describes (its subjects).
+type InTotoStatement struct {
+	Type          string                     `json:"_type"`
+	Subject       []InTotoResourceDescriptor `json:"subject"`
+	PredicateType string                     `json:"predicateType"`
+	Predicate     interface{}                `json:"predicate"`
+}
+
+// InTotoResourceDescriptor describes an artifact or a dependency by its name, location and digests.
+type InTotoResourceDescriptor struct {
+	Name        string            `json:"name,omitempty"`
+	Uri         string            `json:"uri,omitempty"`
+	Digest      map[string]string `json:"digest,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DsseEnvelope is a Dead Simple Signing Envelope, which holds a signed payload (usually an in-toto statement).
+type DsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	// The payload, encoded in base64.
+	Payload    string          `json:"payload"`
+	Signatures []DsseSignature `json:"signatures"`
+}
+
+type DsseSignature struct {
+	KeyId string `json:"keyid,omitempty"`
+	// The signature, encoded in base64.
+	Sig string `json:"sig"`
+}
+
+// DsseSigner signs DSSE envelopes with an ECDSA, Ed25519 or RSA private key.
+type DsseSigner struct {
+	privateKey crypto.Signer
+	keyId      string
+}
+
+// NewInTotoStatement creates an in-toto statement, which binds the predicate to the artifacts of the build.
+// For example, pass BuildInfoPredicateType and the build-info itself, or CycloneDxPredicateType and the BOM returned by ToCycloneDx15Bom().
+func (targetBuildInfo *BuildInfo) NewInTotoStatement(predicateType string, predicate interface{}) *InTotoStatement {
+	return &InTotoStatement{Type: InTotoStatementType, Subject: getInTotoSubjects(targetBuildInfo), PredicateType: predicateType, Predicate: predicate}
+}
+
+// Returns the artifacts of the build, which have checksums, as subjects of in-toto statements.
+func getInTotoSubjects(buildInfo *BuildInfo) []InTotoResourceDescriptor {
+	subjects := []InTotoResourceDescriptor{}
+	for _, module := range buildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		for _, artifact := range module.Artifacts {
+			if digest := toInTotoDigest(artifact.Checksum); len(digest) > 0 {
+				subjects = append(subjects, InTotoResourceDescriptor{Name: artifact.Name, Digest: digest})
+			}
+		}
+	}
+	return subjects
+}
+
+// Sign wraps the statement in a DSSE envelope, signed by all the signers.
+func (statement *InTotoStatement) Sign(signers ...*DsseSigner) (*DsseEnvelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+	return NewDsseEnvelope(InTotoPayloadType, payload, signers...)
+}
+
+// NewDsseEnvelope creates a DSSE envelope, which holds the payload, signed by all the signers.
+func NewDsseEnvelope(payloadType string, payload []byte, signers ...*DsseSigner) (*DsseEnvelope, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("at least one signer must be provided in order to create a DSSE envelope")
+	}
+	envelope := &DsseEnvelope{PayloadType: payloadType, Payload: base64.StdEncoding.EncodeToString(payload), Signatures: []DsseSignature{}}
+	for _, signer := range signers {
+		signature, err := signer.sign(getDssePreAuthEncoding(payloadType, payload))
+		if err != nil {
+			return nil, err
+		}
+		envelope.Signatures = append(envelope.Signatures, DsseSignature{KeyId: signer.keyId, Sig: base64.StdEncoding.EncodeToString(signature)})
+	}
+	return envelope, nil
+}
+
+// Verify checks that the envelope is signed by the private key of the public key.
+// If keyId isn't empty, only the signatures without a key ID, or with the same key ID, are checked.
+func (envelope *DsseEnvelope) Verify(publicKey crypto.PublicKey, keyId string) error {
+	payload, err := envelope.DecodePayload()
+	if err != nil {
+		return err
+	}
+	message := getDssePreAuthEncoding(envelope.PayloadType, payload)
+	for _, signature := range envelope.Signatures {
+		if keyId != "" && signature.KeyId != "" && signature.KeyId != keyId {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+		if verifyDsseSignature(publicKey, message, sig) == nil {
+			return nil
+		}
+	}
+	return errors.New("none of the signatures of the DSSE envelope could be verified with the provided public key")
+}
+
+// DecodePayload returns the payload of the envelope, decoded from base64.
+func (envelope *DsseEnvelope) DecodePayload() ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, errors.New("failed to decode the payload of the DSSE envelope: " + err.Error())
+	}
+	return payload, nil
+}
+
+// GetInTotoStatement returns the in-toto statement in the payload of the envelope.
+// The predicate of the statement is unmarshalled to a generic map. Call Verify() before trusting the statement.
+func (envelope *DsseEnvelope) GetInTotoStatement() (*InTotoStatement, error) {
+	if envelope.PayloadType != InTotoPayloadType {
+		return nil, fmt.Errorf("the payload type of the DSSE envelope is '%s' rather than '%s'", envelope.PayloadType, InTotoPayloadType)
+	}
+	payload, err := envelope.DecodePayload()
+	if err != nil {
+		return nil, err
+	}
+	statement := &InTotoStatement{}
+	if err = json.Unmarshal(payload, statement); err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// Returns the pre-authentication encoding of the payload, which is the message that's actually signed, as defined by the DSSE protocol.
+func getDssePreAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// NewDsseSigner creates a signer from an ECDSA, Ed25519 or RSA private key.
+// keyId is optional. If it's set, it's added to the signatures, to help verifiers pick the public key.
+func NewDsseSigner(privateKey crypto.PrivateKey, keyId string) (*DsseSigner, error) {
+	switch key := privateKey.(type) {
+	case *ecdsa.PrivateKey, *rsa.PrivateKey:
+		return &DsseSigner{privateKey: key.(crypto.Signer), keyId: keyId}, nil
+	case ed25519.PrivateKey:
+		return &DsseSigner{privateKey: key, keyId: keyId}, nil
+	case *ed25519.PrivateKey:
+		return &DsseSigner{privateKey: *key, keyId: keyId}, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type: %T", privateKey)
+	}
+}
+
+// LoadDsseSigner creates a signer from a PEM file, which holds an ECDSA, Ed25519 or RSA private key.
+// Encrypted private keys aren't supported.
+func LoadDsseSigner(privateKeyPath, keyId string) (*DsseSigner, error) {
+	privateKey, err := LoadPrivateKey(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewDsseSigner(privateKey, keyId)
+}
+
+func (signer *DsseSigner) sign(message []byte) ([]byte, error) {
+	switch key := signer.privateKey.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, message), nil
+	case *ecdsa.PrivateKey:
+		hash := getEcdsaHash(key.Curve)
+		return key.Sign(rand.Reader, getDigest(hash, message), hash)
+	default:
+		return signer.privateKey.Sign(rand.Reader, getDigest(crypto.SHA256, message), crypto.SHA256)
+	}
+}
+
+func verifyDsseSignature(publicKey crypto.PublicKey, message, signature []byte) error {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, message, signature) {
+			return errors.New("invalid Ed25519 signature")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, getDigest(getEcdsaHash(key.Curve), message), signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, getDigest(crypto.SHA256, message), signature)
+	default:
+		return fmt.Errorf("unsupported public key type: %T", publicKey)
+	}
+}
+
+// Returns the hash function, which matches the size of the curve of an ECDSA key.
+func getEcdsaHash(curve elliptic.Curve) crypto.Hash {
+	switch curve.Params().BitSize {
+	case 384:
+		return crypto.SHA384
+	case 521:
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+func getDigest(hash crypto.Hash, message []byte) []byte {
+	switch hash {
+	case crypto.SHA384:
+		digest := sha512.Sum384(message)
+		return digest[:]
+	case crypto.SHA512:
+		digest := sha512.Sum512(message)
+		return digest[:]
+	default:
+		digest := sha256.Sum256(message)
+		return digest[:]
+	}
+}
+
+// LoadPrivateKey reads a private key from a PEM file, in the PKCS #8, SEC 1 (EC) or PKCS #1 (RSA) format.
+func LoadPrivateKey(privateKeyPath string) (crypto.PrivateKey, error) {
+	block, err := readPemBlock(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type '%s' in %s", block.Type, privateKeyPath)
+	}
+}
+
+// LoadPublicKey reads a public key from a PEM file, in the PKIX or PKCS #1 (RSA) format, or from the certificate in the file.
+func LoadPublicKey(publicKeyPath string) (crypto.PublicKey, error) {
+	block, err := readPemBlock(publicKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return certificate.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type '%s' in %s", block.Type, publicKeyPath)
+	}
+}
+
+func readPemBlock(pemPath string) (*pem.Block, error) {
+	content, err := os.ReadFile(pemPath)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM data was found in " + pemPath)
+	}
+	return block, nil
+}
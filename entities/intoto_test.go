@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDssePreAuthEncoding(t *testing.T) {
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world", string(getDssePreAuthEncoding("http://example.com/HelloWorld", []byte("hello world"))))
+}
+
+func TestSignAndVerifyInTotoStatement(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	buildInfo := &BuildInfo{Name: "my-build", Number: "1", Modules: []Module{{Id: "my-module", Artifacts: []Artifact{{Name: "a.zip", Checksum: Checksum{Sha256: "a256"}}}}}}
+	statement := buildInfo.NewInTotoStatement(BuildInfoPredicateType, buildInfo)
+	assert.Equal(t, []InTotoResourceDescriptor{{Name: "a.zip", Digest: map[string]string{"sha256": "a256"}}}, statement.Subject)
+
+	tests := []struct {
+		keyType    string
+		privateKey crypto.Signer
+	}{
+		{"ecdsa", ecdsaKey},
+		{"ed25519", ed25519Key},
+		{"rsa", rsaKey},
+	}
+	for _, test := range tests {
+		t.Run(test.keyType, func(t *testing.T) {
+			privateKeyPath, publicKeyPath := writeTestKeys(t, test.privateKey)
+			signer, err := LoadDsseSigner(privateKeyPath, "my-key")
+			require.NoError(t, err)
+			publicKey, err := LoadPublicKey(publicKeyPath)
+			require.NoError(t, err)
+
+			envelope, err := statement.Sign(signer)
+			require.NoError(t, err)
+			assert.Equal(t, InTotoPayloadType, envelope.PayloadType)
+			assert.Equal(t, "my-key", envelope.Signatures[0].KeyId)
+			assert.NoError(t, envelope.Verify(publicKey, "my-key"))
+			// Signatures with other key IDs aren't checked.
+			assert.Error(t, envelope.Verify(publicKey, "other-key"))
+
+			decodedStatement, err := envelope.GetInTotoStatement()
+			assert.NoError(t, err)
+			assert.Equal(t, BuildInfoPredicateType, decodedStatement.PredicateType)
+			assert.Equal(t, statement.Subject, decodedStatement.Subject)
+
+			// A tampered payload fails the verification.
+			envelope.PayloadType = "text/plain"
+			assert.Error(t, envelope.Verify(publicKey, ""))
+		})
+	}
+
+	// A signature can't be verified with another key.
+	ecdsaSigner, err := NewDsseSigner(ecdsaKey, "")
+	require.NoError(t, err)
+	envelope, err := NewDsseEnvelope("text/plain", []byte("hello world"), ecdsaSigner)
+	require.NoError(t, err)
+	assert.NoError(t, envelope.Verify(ecdsaKey.Public(), ""))
+	assert.Error(t, envelope.Verify(rsaKey.Public(), ""))
+	_, err = envelope.GetInTotoStatement()
+	assert.Error(t, err)
+}
+
+func TestLoadKeysErrors(t *testing.T) {
+	_, err := NewDsseEnvelope(InTotoPayloadType, []byte("{}"))
+	assert.Error(t, err)
+
+	notPemPath := filepath.Join(t.TempDir(), "key.txt")
+	require.NoError(t, os.WriteFile(notPemPath, []byte("not a key"), 0600))
+	_, err = LoadPrivateKey(notPemPath)
+	assert.ErrorContains(t, err, "no PEM data")
+	_, err = LoadPublicKey(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+}
+
+func writeTestKeys(t *testing.T, privateKey crypto.Signer) (privateKeyPath, publicKeyPath string) {
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+	tempDir := t.TempDir()
+	privateKeyPath, publicKeyPath = filepath.Join(tempDir, "key.pem"), filepath.Join(tempDir, "key.pub")
+	require.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes}), 0600))
+	require.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0600))
+	return
+}
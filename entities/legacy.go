@@ -0,0 +1,167 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The formats of the build-info JSON.
+const (
+	// The format, which is written by this library.
+	CurrentBuildInfoFormat BuildInfoFormat = "current"
+	// The 1.x format, which is consumed by older Artifactory versions.
+	LegacyBuildInfoFormat BuildInfoFormat = "legacy"
+
+	LegacyBuildInfoVersion = "1.0.1"
+)
+
+type BuildInfoFormat string
+
+// LegacyBuildInfo is a build-info in the 1.x format. Its VCS details are top-level fields, and the builds it aggregates are build dependencies rather than modules.
+type LegacyBuildInfo struct {
+	Version           string                 `json:"version"`
+	Name              string                 `json:"name,omitempty"`
+	Number            string                 `json:"number,omitempty"`
+	Agent             *Agent                 `json:"agent,omitempty"`
+	BuildAgent        *Agent                 `json:"buildAgent,omitempty"`
+	Started           string                 `json:"started,omitempty"`
+	Properties        Env                    `json:"properties,omitempty"`
+	Principal         string                 `json:"artifactoryPrincipal,omitempty"`
+	PluginVersion     string                 `json:"artifactoryPluginVersion,omitempty"`
+	BuildUrl          string                 `json:"url,omitempty"`
+	VcsRevision       string                 `json:"vcsRevision,omitempty"`
+	VcsUrl            string                 `json:"vcsUrl,omitempty"`
+	Modules           []LegacyModule         `json:"modules,omitempty"`
+	BuildDependencies []LegacyBuildReference `json:"buildDependencies,omitempty"`
+	Issues            *Issues                `json:"issues,omitempty"`
+}
+
+type LegacyModule struct {
+	Id           string             `json:"id,omitempty"`
+	Properties   interface{}        `json:"properties,omitempty"`
+	Artifacts    []LegacyArtifact   `json:"artifacts,omitempty"`
+	Dependencies []LegacyDependency `json:"dependencies,omitempty"`
+}
+
+type LegacyArtifact struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+	Sha1 string `json:"sha1,omitempty"`
+	Md5  string `json:"md5,omitempty"`
+}
+
+type LegacyDependency struct {
+	Type   string   `json:"type,omitempty"`
+	Id     string   `json:"id,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+	Sha1   string   `json:"sha1,omitempty"`
+	Md5    string   `json:"md5,omitempty"`
+}
+
+type LegacyBuildReference struct {
+	Name    string `json:"name,omitempty"`
+	Number  string `json:"number,omitempty"`
+	Started string `json:"started,omitempty"`
+}
+
+// ToLegacy converts the build-info to the legacy 1.x format.
+// The first VCS entry becomes the VCS details of the build, the aggregated builds become build dependencies, and the sub-modules become modules.
+// The fields, which the legacy format doesn't have, are dropped. These are the signatures, the finish time and the duration of the build, the types and the timing of the modules, the excluded artifacts, the SHA-256 and SHA-512 checksums, the paths and deployment repositories of the artifacts, and the RequestedBy graphs, purls, properties, licenses and standard scopes of the dependencies.
+func (targetBuildInfo *BuildInfo) ToLegacy() *LegacyBuildInfo {
+	legacy := &LegacyBuildInfo{
+		Version:       LegacyBuildInfoVersion,
+		Name:          targetBuildInfo.Name,
+		Number:        targetBuildInfo.Number,
+		Agent:         targetBuildInfo.Agent,
+		BuildAgent:    targetBuildInfo.BuildAgent,
+		Started:       targetBuildInfo.Started,
+		Properties:    targetBuildInfo.Properties,
+		Principal:     targetBuildInfo.Principal,
+		PluginVersion: targetBuildInfo.PluginVersion,
+		BuildUrl:      targetBuildInfo.BuildUrl,
+		Issues:        targetBuildInfo.Issues,
+	}
+	if len(targetBuildInfo.VcsList) > 0 {
+		legacy.VcsUrl, legacy.VcsRevision = targetBuildInfo.VcsList[0].Url, targetBuildInfo.VcsList[0].Revision
+	}
+	for _, module := range targetBuildInfo.GetAllModules() {
+		if module.Type == Build {
+			// The ID of an aggregated build is its name and number.
+			name, number, _ := strings.Cut(module.Id, "/")
+			legacy.BuildDependencies = append(legacy.BuildDependencies, LegacyBuildReference{Name: name, Number: number})
+			continue
+		}
+		legacyModule := LegacyModule{Id: module.Id, Properties: module.Properties}
+		for _, artifact := range module.Artifacts {
+			legacyModule.Artifacts = append(legacyModule.Artifacts, LegacyArtifact{Type: artifact.Type, Name: artifact.Name, Sha1: artifact.Sha1, Md5: artifact.Md5})
+		}
+		for _, dependency := range module.Dependencies {
+			legacyModule.Dependencies = append(legacyModule.Dependencies, LegacyDependency{Type: dependency.Type, Id: dependency.Id, Scopes: dependency.Scopes, Sha1: dependency.Sha1, Md5: dependency.Md5})
+		}
+		legacy.Modules = append(legacy.Modules, legacyModule)
+	}
+	return legacy
+}
+
+// ToBuildInfo converts the legacy build-info to the current format.
+// The VCS details of the build become its VCS entry, and the build dependencies become aggregated build modules. The types of the modules are unknown, so they're left empty.
+func (legacy *LegacyBuildInfo) ToBuildInfo() *BuildInfo {
+	buildInfo := &BuildInfo{
+		Name:          legacy.Name,
+		Number:        legacy.Number,
+		Agent:         legacy.Agent,
+		BuildAgent:    legacy.BuildAgent,
+		Started:       legacy.Started,
+		Properties:    legacy.Properties,
+		Principal:     legacy.Principal,
+		PluginVersion: legacy.PluginVersion,
+		BuildUrl:      legacy.BuildUrl,
+		Issues:        legacy.Issues,
+	}
+	if legacy.VcsUrl != "" || legacy.VcsRevision != "" {
+		buildInfo.VcsList = []Vcs{{Url: legacy.VcsUrl, Revision: legacy.VcsRevision}}
+	}
+	for _, legacyModule := range legacy.Modules {
+		module := Module{Id: legacyModule.Id, Properties: legacyModule.Properties}
+		for _, artifact := range legacyModule.Artifacts {
+			module.Artifacts = append(module.Artifacts, Artifact{Type: artifact.Type, Name: artifact.Name, Checksum: Checksum{Sha1: artifact.Sha1, Md5: artifact.Md5}})
+		}
+		for _, dependency := range legacyModule.Dependencies {
+			module.Dependencies = append(module.Dependencies, Dependency{Type: dependency.Type, Id: dependency.Id, Scopes: dependency.Scopes, Checksum: Checksum{Sha1: dependency.Sha1, Md5: dependency.Md5}})
+		}
+		buildInfo.Modules = append(buildInfo.Modules, module)
+	}
+	for _, buildDependency := range legacy.BuildDependencies {
+		buildInfo.Modules = append(buildInfo.Modules, Module{Id: buildDependency.Name + "/" + buildDependency.Number, Type: Build})
+	}
+	return buildInfo
+}
+
+// ConvertBuildInfoJson converts a build-info JSON to the given format. The format of the build-info is detected by its version field, which only the legacy format has.
+func ConvertBuildInfoJson(content []byte, format BuildInfoFormat) ([]byte, error) {
+	var header struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		return nil, err
+	}
+	buildInfo := &BuildInfo{}
+	if strings.HasPrefix(header.Version, "1.") {
+		legacy := &LegacyBuildInfo{}
+		if err := json.Unmarshal(content, legacy); err != nil {
+			return nil, err
+		}
+		buildInfo = legacy.ToBuildInfo()
+	} else if err := json.Unmarshal(content, buildInfo); err != nil {
+		return nil, err
+	}
+	switch format {
+	case CurrentBuildInfoFormat:
+		return json.MarshalIndent(buildInfo, "", "  ")
+	case LegacyBuildInfoFormat:
+		return json.MarshalIndent(buildInfo.ToLegacy(), "", "  ")
+	default:
+		return nil, fmt.Errorf("'%s' is not a valid build-info format", format)
+	}
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToLegacyAndBack(t *testing.T) {
+	buildInfo := &BuildInfo{
+		Name:    "my-build",
+		Number:  "1",
+		Started: "2023-01-01T10:00:00.000+0000",
+		VcsList: []Vcs{{Url: "https://github.com/jfrog/build-info-go.git", Revision: "abc123", Branch: "main"}, {Url: "https://github.com/jfrog/other.git"}},
+		Modules: []Module{
+			{Id: "my-app", Type: Npm,
+				Artifacts:    []Artifact{{Name: "my-app-1.0.0.tgz", Type: "tgz", Path: "dist/my-app-1.0.0.tgz", Checksum: Checksum{Sha1: "a1", Md5: "m1", Sha256: "a256"}}},
+				Dependencies: []Dependency{{Id: "express:4.18.2", Scopes: []string{"prod"}, RequestedBy: [][]string{{"my-app"}}, Purl: "pkg:npm/express@4.18.2", Checksum: Checksum{Sha1: "e1", Md5: "em1"}}},
+			},
+			{Id: "other-build/5", Type: Build, Checksum: Checksum{Sha1: "b1"}},
+		},
+	}
+	legacy := buildInfo.ToLegacy()
+	assert.Equal(t, &LegacyBuildInfo{
+		Version:           LegacyBuildInfoVersion,
+		Name:              "my-build",
+		Number:            "1",
+		Started:           "2023-01-01T10:00:00.000+0000",
+		VcsUrl:            "https://github.com/jfrog/build-info-go.git",
+		VcsRevision:       "abc123",
+		Modules:           []LegacyModule{{Id: "my-app", Artifacts: []LegacyArtifact{{Type: "tgz", Name: "my-app-1.0.0.tgz", Sha1: "a1", Md5: "m1"}}, Dependencies: []LegacyDependency{{Id: "express:4.18.2", Scopes: []string{"prod"}, Sha1: "e1", Md5: "em1"}}}},
+		BuildDependencies: []LegacyBuildReference{{Name: "other-build", Number: "5"}},
+	}, legacy)
+
+	assert.Equal(t, &BuildInfo{
+		Name:    "my-build",
+		Number:  "1",
+		Started: "2023-01-01T10:00:00.000+0000",
+		VcsList: []Vcs{{Url: "https://github.com/jfrog/build-info-go.git", Revision: "abc123"}},
+		Modules: []Module{
+			{Id: "my-app",
+				Artifacts:    []Artifact{{Name: "my-app-1.0.0.tgz", Type: "tgz", Checksum: Checksum{Sha1: "a1", Md5: "m1"}}},
+				Dependencies: []Dependency{{Id: "express:4.18.2", Scopes: []string{"prod"}, Checksum: Checksum{Sha1: "e1", Md5: "em1"}}},
+			},
+			{Id: "other-build/5", Type: Build},
+		},
+	}, legacy.ToBuildInfo())
+}
+
+func TestConvertBuildInfoJson(t *testing.T) {
+	current := []byte(`{"name": "my-build", "number": "1", "vcs": [{"url": "https://example.com/repo.git", "revision": "abc123"}], "modules": [{"id": "my-app", "type": "npm"}]}`)
+	content, err := ConvertBuildInfoJson(current, LegacyBuildInfoFormat)
+	assert.NoError(t, err)
+	var legacy map[string]interface{}
+	assert.NoError(t, json.Unmarshal(content, &legacy))
+	assert.Equal(t, LegacyBuildInfoVersion, legacy["version"])
+	assert.Equal(t, "abc123", legacy["vcsRevision"])
+	assert.NotContains(t, legacy, "vcs")
+
+	// The legacy format is detected by its version.
+	content, err = ConvertBuildInfoJson(content, CurrentBuildInfoFormat)
+	assert.NoError(t, err)
+	buildInfo := &BuildInfo{}
+	assert.NoError(t, json.Unmarshal(content, buildInfo))
+	assert.Equal(t, []Vcs{{Url: "https://example.com/repo.git", Revision: "abc123"}}, buildInfo.VcsList)
+	assert.Equal(t, "my-app", buildInfo.Modules[0].Id)
+
+	_, err = ConvertBuildInfoJson(current, "2.0")
+	assert.Error(t, err)
+	_, err = ConvertBuildInfoJson([]byte("not json"), CurrentBuildInfoFormat)
+	assert.Error(t, err)
+}
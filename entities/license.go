@@ -0,0 +1,221 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+const SpdxNoneLicense = "NONE"
+
+// The identifiers of common licenses from the SPDX license list. Licenses, which aren't in this list, can't be normalized.
+var spdxLicenseIds = []string{
+	"0BSD", "AFL-2.1", "AFL-3.0", "AGPL-1.0-only", "AGPL-1.0-or-later", "AGPL-3.0-only", "AGPL-3.0-or-later", "Apache-1.0", "Apache-1.1", "Apache-2.0",
+	"APSL-2.0", "Artistic-1.0", "Artistic-1.0-Perl", "Artistic-2.0", "BlueOak-1.0.0", "BSD-1-Clause", "BSD-2-Clause", "BSD-2-Clause-Patent", "BSD-3-Clause",
+	"BSD-3-Clause-Clear", "BSD-4-Clause", "BSL-1.0", "bzip2-1.0.6", "bzip2-1.0.4", "CC-BY-3.0", "CC-BY-4.0", "CC-BY-SA-3.0", "CC-BY-SA-4.0", "CC0-1.0",
+	"CDDL-1.0", "CDDL-1.1", "CPL-1.0", "curl", "ECL-2.0", "EPL-1.0", "EPL-2.0", "EUPL-1.1", "EUPL-1.2", "FSFAP", "FTL", "GFDL-1.2-only",
+	"GFDL-1.2-or-later", "GFDL-1.3-only", "GFDL-1.3-or-later", "GPL-1.0-only", "GPL-1.0-or-later", "GPL-2.0-only", "GPL-2.0-or-later", "GPL-3.0-only",
+	"GPL-3.0-or-later", "HPND", "ICU", "IJG", "ImageMagick", "Info-ZIP", "IPL-1.0", "ISC", "LGPL-2.0-only", "LGPL-2.0-or-later", "LGPL-2.1-only",
+	"LGPL-2.1-or-later", "LGPL-3.0-only", "LGPL-3.0-or-later", "libpng-2.0", "Libpng", "LPPL-1.3c", "MirOS", "MIT", "MIT-0", "MIT-CMU", "MPL-1.0",
+	"MPL-1.1", "MPL-2.0", "MPL-2.0-no-copyleft-exception", "MS-PL", "MS-RL", "NCSA", "ODbL-1.0", "OFL-1.1", "OpenSSL", "OSL-3.0", "PHP-3.0", "PHP-3.01",
+	"PostgreSQL", "PSF-2.0", "Python-2.0", "Ruby", "SSPL-1.0", "Unicode-DFS-2016", "Unicode-3.0", "Unlicense", "UPL-1.0", "Vim", "W3C", "WTFPL", "X11",
+	"Zlib", "zlib-acknowledgement", "ZPL-2.0", "ZPL-2.1",
+}
+
+// The identifiers of common license exceptions from the SPDX license exceptions list, which may follow the WITH operator.
+var spdxLicenseExceptionIds = []string{
+	"Autoconf-exception-2.0", "Autoconf-exception-3.0", "Bison-exception-2.2", "Classpath-exception-2.0", "Font-exception-2.0", "GCC-exception-2.0",
+	"GCC-exception-3.1", "LLVM-exception", "OCaml-LGPL-linking-exception", "OpenJDK-assembly-exception-1.0", "Qt-LGPL-exception-1.1", "Universal-FOSS-exception-1.0",
+}
+
+// Names of licenses, which package managers commonly use, and their SPDX identifiers.
+var spdxLicenseAliases = map[string]string{
+	"ASL 2.0":                    "Apache-2.0",
+	"ASL":                        "Apache-2.0",
+	"Apache":                     "Apache-2.0",
+	"Apache Software License":    "Apache-2.0",
+	"BSD":                        "BSD-3-Clause",
+	"New BSD":                    "BSD-3-Clause",
+	"Modified BSD":               "BSD-3-Clause",
+	"Simplified BSD":             "BSD-2-Clause",
+	"FreeBSD":                    "BSD-2-Clause",
+	"Expat":                      "MIT",
+	"MIT/X11":                    "MIT",
+	"GPL":                        "GPL-1.0-or-later",
+	"GPL+":                       "GPL-1.0-or-later",
+	"LGPL":                       "LGPL-2.0-or-later",
+	"LGPL+":                      "LGPL-2.0-or-later",
+	"Boost":                      "BSL-1.0",
+	"Boost Software License 1.0": "BSL-1.0",
+	"Eclipse Public License 1.0": "EPL-1.0",
+	"Eclipse Public License 2.0": "EPL-2.0",
+	"Mozilla Public License 2.0": "MPL-2.0",
+	"Python Software Foundation": "PSF-2.0",
+	"PSF":                        "PSF-2.0",
+	"ZPL":                        "ZPL-2.1",
+}
+
+var (
+	// Splits license expressions to terms, parentheses and operators. Package managers write AND as '&', '&&' or ',' and OR as '|', '||' or '/'.
+	spdxExpressionTokenRegex = regexp.MustCompile(`\(|\)|&&?|\|\|?|,|;|\s/\s|\s+(?i:and|or|with)\s+`)
+	licenseKeyIgnoredWords   = regexp.MustCompile(`\b(the|gnu|license|licence|version|software|public)\b`)
+	licenseKeyInvalidChars   = regexp.MustCompile(`[^a-z0-9+.]`)
+	licenseKeyVersionPrefix  = regexp.MustCompile(`v(\d)`)
+	licenseKeyZeroMinor      = regexp.MustCompile(`(\d)\.0($|[^\d])`)
+	licenseRefRegex          = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+)
+
+var spdxLicensesByKey, spdxExceptionsByKey = func() (licenses, exceptions map[string]string) {
+	licenses, exceptions = make(map[string]string), make(map[string]string)
+	for _, id := range spdxLicenseIds {
+		licenses[getLicenseKey(id)] = id
+		// The license of a deprecated ID, without '-only', is the same.
+		if strings.HasSuffix(id, "-only") {
+			licenses[getLicenseKey(strings.TrimSuffix(id, "-only"))] = id
+		}
+	}
+	for alias, id := range spdxLicenseAliases {
+		licenses[getLicenseKey(alias)] = id
+	}
+	for _, id := range spdxLicenseExceptionIds {
+		exceptions[getLicenseKey(id)] = id
+	}
+	return
+}()
+
+// Returns a key, by which different spellings of the same license match. For example: 'GPLv2', 'GPL-2.0' and 'GNU General Public License v2.0' all have the key 'gpl2'.
+func getLicenseKey(license string) string {
+	key := strings.ToLower(license)
+	key = strings.ReplaceAll(key, "gnu general public", "gpl")
+	key = strings.ReplaceAll(key, "gnu lesser general public", "lgpl")
+	key = strings.ReplaceAll(key, "gnu library general public", "lgpl")
+	key = strings.ReplaceAll(key, "gnu affero general public", "agpl")
+	key = licenseKeyIgnoredWords.ReplaceAllString(key, "")
+	key = licenseKeyInvalidChars.ReplaceAllString(key, "")
+	key = licenseKeyVersionPrefix.ReplaceAllString(key, "$1")
+	return licenseKeyZeroMinor.ReplaceAllString(key, "$1$2")
+}
+
+// NormalizeSpdxLicense normalizes a license, as found by a package manager (for example: 'GPLv2+ and ASL 2.0'), into a valid SPDX license expression (for example: 'GPL-2.0-or-later AND Apache-2.0').
+// Licenses and exceptions are matched against common identifiers of the SPDX lists, and the commonly used names of licenses. LicenseRef- identifiers are kept as they are.
+// If a license isn't recognized, or the expression is malformed, NOASSERTION is returned. An empty license is NOASSERTION, and NONE is kept.
+func NormalizeSpdxLicense(license string) string {
+	license = strings.TrimSpace(license)
+	switch strings.ToUpper(license) {
+	case "", SpdxNoAssertion, "UNKNOWN":
+		return SpdxNoAssertion
+	case SpdxNoneLicense:
+		return SpdxNoneLicense
+	}
+	var tokens []string
+	expectTerm, depth := true, 0
+	afterWith := false
+	addTerm := func(term string) bool {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			return true
+		}
+		if !expectTerm {
+			return false
+		}
+		normalized := normalizeSpdxLicenseTerm(term, afterWith)
+		if normalized == "" {
+			return false
+		}
+		tokens = append(tokens, normalized)
+		expectTerm, afterWith = false, false
+		return true
+	}
+	position := 0
+	for _, match := range spdxExpressionTokenRegex.FindAllStringIndex(license, -1) {
+		if !addTerm(license[position:match[0]]) {
+			return SpdxNoAssertion
+		}
+		position = match[1]
+		switch operator := strings.ToUpper(strings.TrimSpace(license[match[0]:match[1]])); operator {
+		case "(":
+			if !expectTerm || afterWith {
+				return SpdxNoAssertion
+			}
+			depth++
+			tokens = append(tokens, "(")
+		case ")":
+			if expectTerm || depth == 0 {
+				return SpdxNoAssertion
+			}
+			depth--
+			tokens = append(tokens, ")")
+		default:
+			if expectTerm {
+				return SpdxNoAssertion
+			}
+			switch operator {
+			case "AND", "&", "&&", ",", ";":
+				operator = "AND"
+			case "OR", "|", "||", "/":
+				operator = "OR"
+			case "WITH":
+				afterWith = true
+			}
+			tokens = append(tokens, operator)
+			expectTerm = true
+		}
+	}
+	if !addTerm(license[position:]) || expectTerm || depth != 0 {
+		return SpdxNoAssertion
+	}
+	return strings.NewReplacer("( ", "(", " )", ")").Replace(strings.Join(tokens, " "))
+}
+
+// Returns the SPDX identifier of a license (or an exception, if it follows the WITH operator), or an empty string if it isn't recognized.
+func normalizeSpdxLicenseTerm(term string, isException bool) string {
+	if licenseRefRegex.MatchString(term) {
+		return term
+	}
+	key := getLicenseKey(term)
+	if isException {
+		return spdxExceptionsByKey[key]
+	}
+	if id, exists := spdxLicensesByKey[key]; exists {
+		return id
+	}
+	// The '+' suffix means 'or any later version'.
+	if baseKey := strings.TrimSuffix(key, "+"); baseKey != key {
+		if id, exists := spdxLicensesByKey[baseKey]; exists {
+			if strings.HasSuffix(id, "-only") {
+				return strings.TrimSuffix(id, "-only") + "-or-later"
+			}
+			return id + "+"
+		}
+	}
+	return ""
+}
+
+// NormalizeSpdxLicenses normalizes the licenses, which were found for a dependency, into SPDX license expressions. See NormalizeSpdxLicense().
+// Licenses, which aren't recognized, and duplicates are dropped.
+func NormalizeSpdxLicenses(licenses []string) []string {
+	var normalized []string
+	for _, license := range licenses {
+		expression := NormalizeSpdxLicense(license)
+		if expression != SpdxNoAssertion && !slices.Contains(normalized, expression) {
+			normalized = append(normalized, expression)
+		}
+	}
+	return normalized
+}
+
+// Joins the license expressions of a dependency into a single expression, which requires all of them.
+func joinSpdxLicenses(licenses []string) string {
+	if len(licenses) == 1 {
+		return licenses[0]
+	}
+	terms := make([]string, len(licenses))
+	for i, license := range licenses {
+		terms[i] = license
+		if strings.Contains(license, " ") {
+			terms[i] = "(" + license + ")"
+		}
+	}
+	return strings.Join(terms, " AND ")
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSpdxLicense(t *testing.T) {
+	tests := []struct {
+		license  string
+		expected string
+	}{
+		{"MIT", "MIT"},
+		{"mit", "MIT"},
+		{"Apache License 2.0", "Apache-2.0"},
+		{"ASL 2.0", "Apache-2.0"},
+		{"GPLv2", "GPL-2.0-only"},
+		{"GPL-2.0", "GPL-2.0-only"},
+		{"GPLv2+", "GPL-2.0-or-later"},
+		{"GNU General Public License v3.0", "GPL-3.0-only"},
+		{"LGPLv2.1+", "LGPL-2.1-or-later"},
+		{"MPL-1.1+", "MPL-1.1+"},
+		{"GPLv2+ and ASL 2.0", "GPL-2.0-or-later AND Apache-2.0"},
+		{"GPL-2.0-only & bzip2-1.0.4", "GPL-2.0-only AND bzip2-1.0.4"},
+		{"GPL-2.0, bzip2-1.0.4", "GPL-2.0-only AND bzip2-1.0.4"},
+		{"MIT | Apache-2.0", "MIT OR Apache-2.0"},
+		{"(MIT or BSD-3-Clause) and ISC", "(MIT OR BSD-3-Clause) AND ISC"},
+		{"GPL-2.0 with Classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{"LicenseRef-Proprietary", "LicenseRef-Proprietary"},
+		{"NONE", SpdxNoneLicense},
+		{"", SpdxNoAssertion},
+		{"Unknown", SpdxNoAssertion},
+		{"My Custom License", SpdxNoAssertion},
+		{"MIT and My Custom License", SpdxNoAssertion},
+		{"MIT with MIT", SpdxNoAssertion},
+		{"(MIT", SpdxNoAssertion},
+		{"MIT)", SpdxNoAssertion},
+		{"MIT and", SpdxNoAssertion},
+		{"and MIT", SpdxNoAssertion},
+		{"MIT (BSD)", SpdxNoAssertion},
+	}
+	for _, test := range tests {
+		t.Run(test.license, func(t *testing.T) {
+			assert.Equal(t, test.expected, NormalizeSpdxLicense(test.license))
+		})
+	}
+}
+
+func TestNormalizeSpdxLicenses(t *testing.T) {
+	assert.Equal(t, []string{"MIT", "Apache-2.0"}, NormalizeSpdxLicenses([]string{"MIT", "Unknown", "Apache License 2.0", "The MIT License"}))
+	assert.Empty(t, NormalizeSpdxLicenses([]string{"My Custom License"}))
+}
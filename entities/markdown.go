@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The changes of dependencies, in relation to a previous build.
+const (
+	DependencyAdded   = "added"
+	DependencyRemoved = "removed"
+	DependencyUpdated = "updated"
+)
+
+// DependencyDiff is a change of a dependency of a module, in relation to a previous build.
+type DependencyDiff struct {
+	ModuleId string `json:"moduleId"`
+	// The name of the dependency, which is its ID without the version.
+	Name string `json:"name"`
+	// The versions of the dependency in the previous and the current builds. A module may depend on several versions of the same dependency, so they're separated by commas.
+	PreviousVersion string `json:"previousVersion,omitempty"`
+	Version         string `json:"version,omitempty"`
+	Change          string `json:"change"`
+}
+
+// DiffDependencies returns the dependencies, which were added, removed or updated in the modules of the build, in relation to the previous build.
+// The modules are matched by their IDs, and the dependencies by their names, which are parsed from their IDs according to the types of their modules.
+// The changes are sorted by the IDs of the modules and the names of the dependencies. If the previous build is nil, all the dependencies were added.
+func (targetBuildInfo *BuildInfo) DiffDependencies(previous *BuildInfo) []DependencyDiff {
+	if previous == nil {
+		previous = &BuildInfo{}
+	}
+	previousVersions := getDependencyVersions(previous)
+	currentVersions := getDependencyVersions(targetBuildInfo)
+	var diffs []DependencyDiff
+	for moduleId, versions := range currentVersions {
+		for name, version := range versions {
+			previousVersion, exists := previousVersions[moduleId][name]
+			switch {
+			case !exists:
+				diffs = append(diffs, DependencyDiff{ModuleId: moduleId, Name: name, Version: version, Change: DependencyAdded})
+			case previousVersion != version:
+				diffs = append(diffs, DependencyDiff{ModuleId: moduleId, Name: name, PreviousVersion: previousVersion, Version: version, Change: DependencyUpdated})
+			}
+		}
+	}
+	for moduleId, versions := range previousVersions {
+		for name, previousVersion := range versions {
+			if _, exists := currentVersions[moduleId][name]; !exists {
+				diffs = append(diffs, DependencyDiff{ModuleId: moduleId, Name: name, PreviousVersion: previousVersion, Change: DependencyRemoved})
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].ModuleId != diffs[j].ModuleId {
+			return diffs[i].ModuleId < diffs[j].ModuleId
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// Returns the sorted versions of the dependencies of each module, by the names of the dependencies.
+func getDependencyVersions(buildInfo *BuildInfo) map[string]map[string]string {
+	moduleVersions := make(map[string]map[string]string)
+	for _, module := range buildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		versionsLists := make(map[string][]string)
+		for _, dependency := range module.Dependencies {
+			name, version := getDependencyNameAndVersion(module.Type, dependency.Id)
+			versionsLists[name] = append(versionsLists[name], version)
+		}
+		versions := make(map[string]string)
+		for name, versionsList := range versionsLists {
+			sort.Strings(versionsList)
+			versions[name] = strings.Join(versionsList, ", ")
+		}
+		moduleVersions[module.Id] = versions
+	}
+	return moduleVersions
+}
+
+// Splits the ID of a dependency to its name and version, according to the type of its module. See GetPurl().
+func getDependencyNameAndVersion(moduleType ModuleType, dependencyId string) (name, version string) {
+	purl, ok := parsePurl(purlTypes[moduleType], dependencyId)
+	if !ok {
+		return splitDependencyId(dependencyId)
+	}
+	name = purl.Name
+	if purl.Namespace != "" {
+		name = purl.Namespace + "/" + name
+	}
+	if purl.Subpath != "" {
+		name += "/" + purl.Subpath
+	}
+	return name, purl.Version
+}
+
+// WriteMarkdownDependencySummary writes a Markdown summary of the dependencies, which were added, removed or updated in relation to the previous build, suitable for a pull request comment.
+func (targetBuildInfo *BuildInfo) WriteMarkdownDependencySummary(writer io.Writer, previous *BuildInfo) error {
+	if previous == nil {
+		return errors.New("a previous build must be provided in order to summarize the dependency changes")
+	}
+	diffs := targetBuildInfo.DiffDependencies(previous)
+	var markdown strings.Builder
+	fmt.Fprintf(&markdown, "## Dependency changes in %s #%s\n\n", escapeMarkdown(targetBuildInfo.Name), escapeMarkdown(targetBuildInfo.Number))
+	if len(diffs) == 0 {
+		fmt.Fprintf(&markdown, "No dependencies were added, removed or updated since %s #%s.\n", escapeMarkdown(previous.Name), escapeMarkdown(previous.Number))
+		_, err := io.WriteString(writer, markdown.String())
+		return err
+	}
+	counts := make(map[string]int)
+	for _, diff := range diffs {
+		counts[diff.Change]++
+	}
+	fmt.Fprintf(&markdown, "Compared to %s #%s: %d added, %d removed, %d updated.\n\n", escapeMarkdown(previous.Name), escapeMarkdown(previous.Number), counts[DependencyAdded], counts[DependencyRemoved], counts[DependencyUpdated])
+	markdown.WriteString("| Module | Dependency | Change | Previous version | Version |\n")
+	markdown.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, diff := range diffs {
+		fmt.Fprintf(&markdown, "| %s | %s | %s | %s | %s |\n", escapeMarkdown(diff.ModuleId), escapeMarkdown(diff.Name), diff.Change, escapeMarkdown(diff.PreviousVersion), escapeMarkdown(diff.Version))
+	}
+	_, err := io.WriteString(writer, markdown.String())
+	return err
+}
+
+// Escapes the characters, which have a special meaning in Markdown tables and inline text.
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "<", "&lt;", ">", "&gt;", "\n", " ").Replace(text)
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getMarkdownTestBuilds() (current, previous *BuildInfo) {
+	previous = &BuildInfo{Name: "my-build", Number: "1", Modules: []Module{
+		{Id: "my-app", Type: Npm, Dependencies: []Dependency{{Id: "express:4.18.1"}, {Id: "@types/node:20.0.0"}, {Id: "lodash:4.17.20"}}},
+		{Id: "my-lib", Type: Maven, Dependencies: []Dependency{{Id: "org.slf4j:slf4j-api:2.0.7"}}},
+	}}
+	current = &BuildInfo{Name: "my-build", Number: "2", Modules: []Module{
+		{Id: "my-app", Type: Npm, Dependencies: []Dependency{{Id: "express:4.18.2"}, {Id: "@types/node:20.0.0"}, {Id: "body-parser:1.20.1"}}},
+		{Id: "my-service", Type: Go, Dependencies: []Dependency{{Id: "github.com/pkg/errors:v0.9.1"}}},
+	}}
+	return
+}
+
+func TestDiffDependencies(t *testing.T) {
+	current, previous := getMarkdownTestBuilds()
+	assert.Equal(t, []DependencyDiff{
+		{ModuleId: "my-app", Name: "body-parser", Version: "1.20.1", Change: DependencyAdded},
+		{ModuleId: "my-app", Name: "express", PreviousVersion: "4.18.1", Version: "4.18.2", Change: DependencyUpdated},
+		{ModuleId: "my-app", Name: "lodash", PreviousVersion: "4.17.20", Change: DependencyRemoved},
+		{ModuleId: "my-lib", Name: "org.slf4j/slf4j-api", PreviousVersion: "2.0.7", Change: DependencyRemoved},
+		{ModuleId: "my-service", Name: "github.com/pkg/errors", Version: "v0.9.1", Change: DependencyAdded},
+	}, current.DiffDependencies(previous))
+	assert.Empty(t, current.DiffDependencies(current))
+	assert.Len(t, current.DiffDependencies(nil), 4)
+}
+
+func TestWriteMarkdownDependencySummary(t *testing.T) {
+	current, previous := getMarkdownTestBuilds()
+	var markdown strings.Builder
+	assert.NoError(t, current.WriteMarkdownDependencySummary(&markdown, previous))
+	assert.Equal(t, `## Dependency changes in my-build #2
+
+Compared to my-build #1: 2 added, 2 removed, 1 updated.
+
+| Module | Dependency | Change | Previous version | Version |
+| --- | --- | --- | --- | --- |
+| my-app | body-parser | added |  | 1.20.1 |
+| my-app | express | updated | 4.18.1 | 4.18.2 |
+| my-app | lodash | removed | 4.17.20 |  |
+| my-lib | org.slf4j/slf4j-api | removed | 2.0.7 |  |
+| my-service | github.com/pkg/errors | added |  | v0.9.1 |
+`, markdown.String())
+
+	markdown.Reset()
+	assert.NoError(t, current.WriteMarkdownDependencySummary(&markdown, current))
+	assert.Equal(t, "## Dependency changes in my-build #2\n\nNo dependencies were added, removed or updated since my-build #2.\n", markdown.String())
+	assert.Error(t, current.WriteMarkdownDependencySummary(&markdown, nil))
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	assert.Equal(t, `a\|b \*c\* &lt;d&gt; e\_f`, escapeMarkdown("a|b *c* <d> e_f"))
+}
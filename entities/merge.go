@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// Merge combines several build-infos, such as the build-infos created by the parallel CI jobs of a build, into a new build-info. The build-infos themselves aren't changed.
+// The details of the build (such as its name, number, agents and issues) are taken from the first build-info, which has them. The build started when the earliest build-info started, and finished when the latest one finished.
+// The environment variables and the VCS entries of all the build-infos are combined. If the same variable has different values, the value of the earlier build-info is kept.
+// Modules with identical IDs are merged, as in Append(). The modules are sorted by their IDs, and the artifacts and the dependencies of each module by their names and IDs,
+// so the result doesn't depend on the order, in which the jobs completed.
+func Merge(buildInfos ...*BuildInfo) (*BuildInfo, error) {
+	merged := &BuildInfo{}
+	var started, finished time.Time
+	for _, buildInfo := range buildInfos {
+		if buildInfo == nil {
+			continue
+		}
+		// The modules are merged into the build-info, which changes the merged modules, so they're copied first.
+		copied, err := copyBuildInfo(buildInfo)
+		if err != nil {
+			return nil, err
+		}
+		mergeBuildDetails(copied, merged)
+		if buildStarted, err := time.Parse(TimeFormat, copied.Started); err == nil && (started.IsZero() || buildStarted.Before(started)) {
+			started = buildStarted
+		}
+		if buildFinished, err := time.Parse(TimeFormat, copied.Finished); err == nil && buildFinished.After(finished) {
+			finished = buildFinished
+		}
+		if len(copied.Properties) > 0 {
+			merged.Properties = mergeProperties(merged.Properties, copied.Properties)
+		}
+		for _, vcs := range copied.VcsList {
+			if !slices.Contains(merged.VcsList, vcs) {
+				merged.VcsList = append(merged.VcsList, vcs)
+			}
+		}
+		merged.Append(copied)
+	}
+	if !started.IsZero() {
+		merged.Started = started.Format(TimeFormat)
+	}
+	if !finished.IsZero() {
+		merged.SetFinished(finished)
+	}
+	sortModules(merged.Modules)
+	return merged, nil
+}
+
+// Sets the details of the build, which aren't set in the merged build-info yet.
+func mergeBuildDetails(buildInfo, merged *BuildInfo) {
+	if merged.Name == "" {
+		merged.Name, merged.Number = buildInfo.Name, buildInfo.Number
+	}
+	if merged.Agent == nil {
+		merged.Agent = buildInfo.Agent
+	}
+	if merged.BuildAgent == nil {
+		merged.BuildAgent = buildInfo.BuildAgent
+	}
+	if merged.Principal == "" {
+		merged.Principal = buildInfo.Principal
+	}
+	if merged.PluginVersion == "" {
+		merged.PluginVersion = buildInfo.PluginVersion
+	}
+	if merged.BuildUrl == "" {
+		merged.BuildUrl = buildInfo.BuildUrl
+	}
+	if merged.Issues == nil {
+		merged.Issues = buildInfo.Issues
+	}
+}
+
+func copyBuildInfo(buildInfo *BuildInfo) (*BuildInfo, error) {
+	content, err := json.Marshal(buildInfo)
+	if err != nil {
+		return nil, err
+	}
+	copied := &BuildInfo{}
+	return copied, json.Unmarshal(content, copied)
+}
+
+func sortModules(modules []Module) {
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Id < modules[j].Id
+	})
+	for i := range modules {
+		module := &modules[i]
+		// Artifacts and dependencies with identical names and IDs, but different checksums, are sorted by their checksums.
+		sort.Slice(module.Artifacts, func(i, j int) bool {
+			if module.Artifacts[i].Name != module.Artifacts[j].Name {
+				return module.Artifacts[i].Name < module.Artifacts[j].Name
+			}
+			return module.Artifacts[i].Sha1 < module.Artifacts[j].Sha1
+		})
+		sort.Slice(module.Dependencies, func(i, j int) bool {
+			if module.Dependencies[i].Id != module.Dependencies[j].Id {
+				return module.Dependencies[i].Id < module.Dependencies[j].Id
+			}
+			return module.Dependencies[i].Sha1 < module.Dependencies[j].Sha1
+		})
+		sortModules(module.Modules)
+	}
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	job1 := &BuildInfo{
+		Name: "my-build", Number: "7", Started: "2024-03-01T10:00:05.000+0000", Finished: "2024-03-01T10:01:00.000+0000",
+		Properties: Env{"buildInfo.env.JOB": "1", "buildInfo.env.CI": "true"},
+		VcsList:    []Vcs{{Url: "https://github.com/org/repo.git", Revision: "abc"}},
+		Modules: []Module{
+			{Id: "web", Type: Npm, Artifacts: []Artifact{{Name: "web.tgz", Checksum: Checksum{Sha1: "w"}}}, Dependencies: []Dependency{{Id: "react:18.2.0", Scopes: []string{"prod"}}}},
+			{Id: "api", Type: Go, Artifacts: []Artifact{{Name: "api-linux", Checksum: Checksum{Sha1: "l"}}}},
+		},
+	}
+	job2 := &BuildInfo{
+		Name: "my-build", Number: "7", Started: "2024-03-01T10:00:00.000+0000", Finished: "2024-03-01T10:02:00.000+0000",
+		Properties: Env{"buildInfo.env.JOB": "2"},
+		VcsList:    []Vcs{{Url: "https://github.com/org/repo.git", Revision: "abc"}},
+		Modules: []Module{
+			{Id: "api", Type: Go, Artifacts: []Artifact{{Name: "api-darwin", Checksum: Checksum{Sha1: "d"}}}},
+			{Id: "web", Type: Npm, Dependencies: []Dependency{{Id: "react:18.2.0", Scopes: []string{"dev"}}, {Id: "jest:29.0.0"}}},
+		},
+	}
+
+	merged, err := Merge(job1, nil, job2)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-build", merged.Name)
+	assert.Equal(t, "7", merged.Number)
+	assert.Equal(t, "2024-03-01T10:00:00.000+0000", merged.Started)
+	assert.Equal(t, "2024-03-01T10:02:00.000+0000", merged.Finished)
+	assert.Equal(t, int64(120000), merged.DurationMillis)
+	assert.Equal(t, Env{"buildInfo.env.JOB": "1", "buildInfo.env.CI": "true"}, merged.Properties)
+	assert.Len(t, merged.VcsList, 1)
+	assert.Equal(t, []Module{
+		{Id: "api", Type: Go, Artifacts: []Artifact{{Name: "api-darwin", Checksum: Checksum{Sha1: "d"}}, {Name: "api-linux", Checksum: Checksum{Sha1: "l"}}}},
+		{Id: "web", Type: Npm, Artifacts: []Artifact{{Name: "web.tgz", Checksum: Checksum{Sha1: "w"}}}, Dependencies: []Dependency{{Id: "jest:29.0.0"}, {Id: "react:18.2.0", Scopes: []string{"prod", "dev"}}}},
+	}, merged.Modules)
+
+	// The merged build-infos aren't changed, and the order of the modules and artifacts doesn't depend on the order of the build-infos.
+	assert.Len(t, job1.Modules[0].Dependencies[0].Scopes, 1)
+	reversed, err := Merge(job2, job1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api-darwin", "api-linux"}, []string{reversed.Modules[0].Artifacts[0].Name, reversed.Modules[0].Artifacts[1].Name})
+	assert.Equal(t, "2", reversed.Properties["buildInfo.env.JOB"])
+}
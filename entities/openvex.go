@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	OpenVexContext = "https://openvex.dev/ns/v0.2.0"
+
+	// The statuses of products in relation to vulnerabilities.
+	VexNotAffected        VexStatus = "not_affected"
+	VexAffected           VexStatus = "affected"
+	VexFixed              VexStatus = "fixed"
+	VexUnderInvestigation VexStatus = "under_investigation"
+
+	// The justifications of not_affected statuses.
+	VexComponentNotPresent                         VexJustification = "component_not_present"
+	VexVulnerableCodeNotPresent                    VexJustification = "vulnerable_code_not_present"
+	VexVulnerableCodeNotInExecutePath              VexJustification = "vulnerable_code_not_in_execute_path"
+	VexVulnerableCodeCannotBeControlledByAdversary VexJustification = "vulnerable_code_cannot_be_controlled_by_adversary"
+	VexInlineMitigationsAlreadyExist               VexJustification = "inline_mitigations_already_exist"
+)
+
+type VexStatus string
+
+type VexJustification string
+
+// VexDocument is an OpenVEX document, which records the impact of vulnerabilities on the dependencies of a build.
+type VexDocument struct {
+	Context    string         `json:"@context"`
+	Id         string         `json:"@id"`
+	Author     string         `json:"author"`
+	Timestamp  string         `json:"timestamp"`
+	Version    int            `json:"version"`
+	Tooling    string         `json:"tooling,omitempty"`
+	Statements []VexStatement `json:"statements"`
+}
+
+type VexStatement struct {
+	Vulnerability   VexVulnerability `json:"vulnerability"`
+	Products        []VexProduct     `json:"products"`
+	Status          VexStatus        `json:"status"`
+	Justification   VexJustification `json:"justification,omitempty"`
+	ImpactStatement string           `json:"impact_statement,omitempty"`
+	ActionStatement string           `json:"action_statement,omitempty"`
+	StatusNotes     string           `json:"status_notes,omitempty"`
+}
+
+type VexVulnerability struct {
+	// The ID of the vulnerability, for example: 'CVE-2023-1234'.
+	Name string `json:"name"`
+}
+
+type VexProduct struct {
+	// The package URL of the product.
+	Id string `json:"@id"`
+}
+
+// NewVexDocument creates an empty OpenVEX document for the build. The author is the person or organization, which is responsible for the statements.
+// The ID of the document is derived from the name, number and start time of the build.
+func (targetBuildInfo *BuildInfo) NewVexDocument(author string) *VexDocument {
+	return &VexDocument{
+		Context:    OpenVexContext,
+		Id:         "urn:uuid:" + getBuildUuid(targetBuildInfo),
+		Author:     author,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Version:    1,
+		Tooling:    cycloneDxToolName,
+		Statements: []VexStatement{},
+	}
+}
+
+// NewVexStatement creates a statement about the impact of a vulnerability on dependencies of the build.
+// The dependencies are identified by their IDs, and referenced in the statement by their package URLs. Dependencies without purls get purls according to the types of their modules.
+// Set the justification (or the impact statement) of not_affected statuses, and the action statement of affected statuses, before adding the statement to a document.
+func (targetBuildInfo *BuildInfo) NewVexStatement(vulnerabilityId string, status VexStatus, dependencyIds ...string) (VexStatement, error) {
+	statement := VexStatement{Vulnerability: VexVulnerability{Name: vulnerabilityId}, Status: status}
+	for _, dependencyId := range dependencyIds {
+		purl := targetBuildInfo.getDependencyPurl(dependencyId)
+		if purl == "" {
+			return statement, fmt.Errorf("the dependency '%s' was not found in the build-info", dependencyId)
+		}
+		statement.Products = append(statement.Products, VexProduct{Id: purl})
+	}
+	return statement, nil
+}
+
+// Returns the purl of the dependency with the given ID, or an empty string if the build has no such dependency.
+func (targetBuildInfo *BuildInfo) getDependencyPurl(dependencyId string) string {
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		for _, dependency := range module.Dependencies {
+			if dependency.Id != dependencyId {
+				continue
+			}
+			if dependency.Purl != "" {
+				return dependency.Purl
+			}
+			return GetPurl(module.Type, dependency.Id)
+		}
+	}
+	return ""
+}
+
+// AddStatement validates the statement, according to the OpenVEX specification, and adds it to the document.
+func (document *VexDocument) AddStatement(statement VexStatement) error {
+	if statement.Vulnerability.Name == "" {
+		return errors.New("the vulnerability of a VEX statement must be set")
+	}
+	if len(statement.Products) == 0 {
+		return errors.New("a VEX statement must reference at least one product")
+	}
+	switch statement.Status {
+	case VexNotAffected:
+		if statement.Justification == "" && statement.ImpactStatement == "" {
+			return errors.New("a VEX statement with the not_affected status must have a justification or an impact statement")
+		}
+	case VexAffected:
+		if statement.ActionStatement == "" {
+			return errors.New("a VEX statement with the affected status must have an action statement")
+		}
+	case VexFixed, VexUnderInvestigation:
+	default:
+		return fmt.Errorf("'%s' is not a valid VEX status", statement.Status)
+	}
+	document.Statements = append(document.Statements, statement)
+	return nil
+}
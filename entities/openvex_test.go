@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVexDocument(t *testing.T) {
+	buildInfo := &BuildInfo{Name: "my-build", Number: "1", Modules: []Module{
+		{Id: "my-module", Type: Maven, Dependencies: []Dependency{{Id: "org.yaml:snakeyaml:1.33"}, {Id: "custom:1.0", Purl: "pkg:github/org/custom@1.0"}}},
+	}}
+	document := buildInfo.NewVexDocument("Security Team")
+	assert.Equal(t, OpenVexContext, document.Context)
+	assert.Equal(t, "urn:uuid:"+getBuildUuid(buildInfo), document.Id)
+	assert.NotEmpty(t, document.Timestamp)
+
+	statement, err := buildInfo.NewVexStatement("CVE-2022-1471", VexNotAffected, "org.yaml:snakeyaml:1.33", "custom:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, []VexProduct{{Id: "pkg:maven/org.yaml/snakeyaml@1.33"}, {Id: "pkg:github/org/custom@1.0"}}, statement.Products)
+	// A not_affected status must be justified.
+	assert.Error(t, document.AddStatement(statement))
+	statement.Justification = VexVulnerableCodeNotInExecutePath
+	assert.NoError(t, document.AddStatement(statement))
+
+	_, err = buildInfo.NewVexStatement("CVE-2022-1471", VexAffected, "missing:1.0")
+	assert.ErrorContains(t, err, "missing:1.0")
+	statement, err = buildInfo.NewVexStatement("CVE-2023-0001", VexAffected, "custom:1.0")
+	require.NoError(t, err)
+	assert.Error(t, document.AddStatement(statement))
+	statement.ActionStatement = "Upgrade to 1.1"
+	assert.NoError(t, document.AddStatement(statement))
+	assert.Error(t, document.AddStatement(VexStatement{Vulnerability: VexVulnerability{Name: "CVE-2023-0002"}, Products: statement.Products, Status: "unknown"}))
+
+	content, err := json.Marshal(document)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"justification":"vulnerable_code_not_in_execute_path"`)
+	assert.Contains(t, string(content), `"products":[{"@id":"pkg:github/org/custom@1.0"}]`)
+	assert.Len(t, document.Statements, 2)
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"net/url"
+	"strings"
+)
+
+// OsvBatchQuery is a batch query of the OSV API (https://api.osv.dev/v1/querybatch), which looks up the vulnerabilities of packages.
+type OsvBatchQuery struct {
+	Queries []OsvQuery `json:"queries"`
+}
+
+type OsvQuery struct {
+	Package OsvPackage `json:"package"`
+	Version string     `json:"version,omitempty"`
+}
+
+type OsvPackage struct {
+	// The package URL of the package, without its version.
+	Purl string `json:"purl"`
+}
+
+// ToOsvBatchQuery creates an OSV batch query, with a query for each unique dependency of the build, which identifies the dependency by its purl and version.
+// Dependencies without purls get purls according to the types of their modules. Dependencies without a version, and dependencies with generic purls, aren't included, since OSV can't look them up.
+func (targetBuildInfo *BuildInfo) ToOsvBatchQuery() *OsvBatchQuery {
+	batchQuery := &OsvBatchQuery{Queries: []OsvQuery{}}
+	added := make(map[OsvQuery]bool)
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		for _, dependency := range module.Dependencies {
+			purl := dependency.Purl
+			if purl == "" {
+				purl = GetPurl(module.Type, dependency.Id)
+			}
+			if strings.HasPrefix(purl, "pkg:generic/") {
+				continue
+			}
+			purlWithoutVersion, version := splitPurlVersion(purl)
+			query := OsvQuery{Package: OsvPackage{Purl: purlWithoutVersion}, Version: version}
+			if version == "" || added[query] {
+				continue
+			}
+			added[query] = true
+			batchQuery.Queries = append(batchQuery.Queries, query)
+		}
+	}
+	return batchQuery
+}
+
+// Splits a purl to the purl without its version, and the unescaped version. The qualifiers and subpath of the purl are kept.
+func splitPurlVersion(purl string) (purlWithoutVersion, version string) {
+	withoutSuffix, suffix := purl, ""
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		withoutSuffix, suffix = purl[:i], purl[i:]
+	}
+	at := strings.LastIndex(withoutSuffix, "@")
+	if at < 0 || at < strings.LastIndex(withoutSuffix, "/") {
+		return purl, ""
+	}
+	version, err := url.PathUnescape(withoutSuffix[at+1:])
+	if err != nil {
+		version = withoutSuffix[at+1:]
+	}
+	return withoutSuffix[:at] + suffix, version
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToOsvBatchQuery(t *testing.T) {
+	buildInfo := &BuildInfo{Modules: []Module{
+		{Id: "my-app", Type: Npm, Dependencies: []Dependency{
+			{Id: "express:4.18.2"},
+			{Id: "@types/node:20.0.0"},
+			// A dependency without a version can't be looked up.
+			{Id: "local-package"},
+		}},
+		{Id: "my-other-app", Type: Npm, Dependencies: []Dependency{{Id: "express:4.18.2"}}},
+		{Id: "my-image", Type: Generic, Dependencies: []Dependency{
+			{Id: "openssl:3.0.8"},
+			{Id: "musl:1.2.4-r1", Purl: "pkg:apk/alpine/musl@1.2.4-r1?arch=x86_64"},
+		}},
+		{Id: "aggregated-build/1", Type: Build, Dependencies: []Dependency{{Id: "ignored:1.0.0"}}},
+	}}
+	assert.Equal(t, &OsvBatchQuery{Queries: []OsvQuery{
+		{Package: OsvPackage{Purl: "pkg:npm/express"}, Version: "4.18.2"},
+		{Package: OsvPackage{Purl: "pkg:npm/%40types/node"}, Version: "20.0.0"},
+		{Package: OsvPackage{Purl: "pkg:apk/alpine/musl?arch=x86_64"}, Version: "1.2.4-r1"},
+	}}, buildInfo.ToOsvBatchQuery())
+}
+
+func TestSplitPurlVersion(t *testing.T) {
+	tests := []struct {
+		purl               string
+		purlWithoutVersion string
+		version            string
+	}{
+		{"pkg:golang/github.com/pkg/errors@v0.9.1", "pkg:golang/github.com/pkg/errors", "v0.9.1"},
+		{"pkg:npm/%40types/node@20.0.0", "pkg:npm/%40types/node", "20.0.0"},
+		{"pkg:rpm/openssl@1.1.1k-7?epoch=1#sub/path", "pkg:rpm/openssl?epoch=1#sub/path", "1.1.1k-7"},
+		{"pkg:maven/org.slf4j/slf4j-api@2.0.7%2Bbuild", "pkg:maven/org.slf4j/slf4j-api", "2.0.7+build"},
+		{"pkg:npm/express", "pkg:npm/express", ""},
+	}
+	for _, test := range tests {
+		purlWithoutVersion, version := splitPurlVersion(test.purl)
+		assert.Equal(t, test.purlWithoutVersion, purlWithoutVersion)
+		assert.Equal(t, test.version, version)
+	}
+}
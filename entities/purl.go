@@ -0,0 +1,198 @@
+package entities
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// The purl types of the module types, whose dependencies are packages of a known ecosystem.
+// The dependencies of the other module types get 'generic' purls.
+var purlTypes = map[ModuleType]string{
+	Go:        "golang",
+	Maven:     "maven",
+	Gradle:    "maven",
+	Sbt:       "maven",
+	Clojure:   "maven",
+	Npm:       "npm",
+	Python:    "pypi",
+	Nuget:     "nuget",
+	Cargo:     "cargo",
+	Composer:  "composer",
+	Ruby:      "gem",
+	Cocoapods: "cocoapods",
+	Conan:     "conan",
+	Conda:     "conda",
+	Pub:       "pub",
+	Mix:       "hex",
+	Rebar:     "hex",
+	Haskell:   "hackage",
+	R:         "cran",
+	Apk:       "apk",
+	Dpkg:      "deb",
+	Rpm:       "rpm",
+	LuaRocks:  "luarocks",
+	Perl:      "cpan",
+}
+
+// Matches the file names of Python wheels and source distributions, which are the IDs of Python dependencies.
+// For example: 'requests-2.28.1-py3-none-any.whl' or 'PyYAML-6.0.tar.gz'.
+var pythonFileNameRegex = regexp.MustCompile(`^(.+?)-(\d[^-]*)(?:-.+)?\.(?:whl|tar\.gz|tar\.bz2|zip|egg)$`)
+
+// Purl is a package URL, which identifies a package across ecosystems and tools: pkg:type/namespace/name@version?qualifiers#subpath
+type Purl struct {
+	Type      string
+	Namespace string
+	Name      string
+	Version   string
+	// The qualifiers, in the order they're written.
+	Qualifiers [][2]string
+	Subpath    string
+}
+
+// String returns the canonical form of the purl, with its components percent-encoded.
+func (purl Purl) String() string {
+	purlString := "pkg:" + purl.Type + "/"
+	if purl.Namespace != "" {
+		for _, segment := range strings.Split(purl.Namespace, "/") {
+			purlString += escapePurlComponent(segment) + "/"
+		}
+	}
+	purlString += escapePurlComponent(purl.Name)
+	if purl.Version != "" {
+		purlString += "@" + escapePurlComponent(purl.Version)
+	}
+	for i, qualifier := range purl.Qualifiers {
+		separator := "&"
+		if i == 0 {
+			separator = "?"
+		}
+		purlString += separator + qualifier[0] + "=" + escapePurlComponent(qualifier[1])
+	}
+	if purl.Subpath != "" {
+		purlString += "#" + purl.Subpath
+	}
+	return purlString
+}
+
+// GetPurl returns the package URL of a dependency of a module of the given type, according to the format of the IDs of its dependencies.
+// The dependencies of module types without a matching purl type, and dependencies whose IDs can't be parsed, get 'generic' purls.
+func GetPurl(moduleType ModuleType, dependencyId string) string {
+	purl, ok := parsePurl(purlTypes[moduleType], dependencyId)
+	if !ok {
+		purl = newGenericPurl(dependencyId)
+	}
+	return purl.String()
+}
+
+func parsePurl(purlType, dependencyId string) (purl Purl, ok bool) {
+	purl.Type = purlType
+	switch purlType {
+	case "maven":
+		// groupId:artifactId:version
+		parts := strings.Split(dependencyId, ":")
+		if len(parts) != 3 {
+			return purl, false
+		}
+		purl.Namespace, purl.Name, purl.Version = parts[0], parts[1], parts[2]
+	case "pypi":
+		match := pythonFileNameRegex.FindStringSubmatch(dependencyId)
+		if match == nil {
+			purl.Name, purl.Version = splitDependencyId(dependencyId)
+		} else {
+			purl.Name, purl.Version = match[1], match[2]
+		}
+		// Python package names are case-insensitive, and underscores are equivalent to dashes.
+		purl.Name = strings.ReplaceAll(strings.ToLower(purl.Name), "_", "-")
+	case "rpm":
+		// name:[epoch:]version-release
+		name, version, _ := strings.Cut(dependencyId, ":")
+		purl.Name = name
+		if epoch, versionWithoutEpoch, found := strings.Cut(version, ":"); found {
+			purl.Qualifiers = append(purl.Qualifiers, [2]string{"epoch", epoch})
+			version = versionWithoutEpoch
+		}
+		purl.Version = version
+	case "conan":
+		// name/version[@user/channel]
+		reference, userAndChannel, _ := strings.Cut(dependencyId, "@")
+		name, version, found := strings.Cut(reference, "/")
+		if !found {
+			return purl, false
+		}
+		purl.Name, purl.Version = name, version
+		if user, channel, found := strings.Cut(userAndChannel, "/"); found {
+			purl.Qualifiers = append(purl.Qualifiers, [2]string{"channel", channel}, [2]string{"user", user})
+		}
+	case "":
+		return purl, false
+	default:
+		purl.Name, purl.Version = splitDependencyId(dependencyId)
+		switch purlType {
+		case "golang", "composer", "npm":
+			// The namespace is the part of the name before its last slash, for example: the scope of npm packages.
+			if slash := strings.LastIndex(purl.Name, "/"); slash > 0 {
+				purl.Namespace, purl.Name = purl.Name[:slash], purl.Name[slash+1:]
+			}
+		case "cocoapods":
+			// The name of a subspec is the subpath of its pod.
+			purl.Name, purl.Subpath, _ = strings.Cut(purl.Name, "/")
+		case "apk":
+			purl.Namespace = "alpine"
+		case "deb":
+			purl.Namespace = "debian"
+		case "hex", "cargo":
+			purl.Name = strings.ToLower(purl.Name)
+		}
+	}
+	return purl, purl.Name != ""
+}
+
+func newGenericPurl(dependencyId string) Purl {
+	name, version := splitDependencyId(dependencyId)
+	// Generic names may be paths, so only their last part is the name.
+	namespace := ""
+	if strings.Contains(name, "/") {
+		namespace, name = path.Dir(name), path.Base(name)
+	}
+	return Purl{Type: "generic", Namespace: strings.Trim(namespace, "/"), Name: name, Version: version}
+}
+
+// Splits a dependency ID in the 'name:version' format. The version is empty if the ID has no colon.
+func splitDependencyId(dependencyId string) (name, version string) {
+	colon := strings.LastIndex(dependencyId, ":")
+	if colon < 0 {
+		return dependencyId, ""
+	}
+	return dependencyId[:colon], dependencyId[colon+1:]
+}
+
+// Percent-encodes all the characters of a purl component, except for the unreserved characters and colons.
+func escapePurlComponent(component string) string {
+	var escaped strings.Builder
+	for _, b := range []byte(component) {
+		if b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || strings.IndexByte("-._~:", b) >= 0 {
+			escaped.WriteByte(b)
+		} else {
+			escaped.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return escaped.String()
+}
+
+// SetPurls sets the package URLs of the dependencies of all the modules and their sub-modules, according to the types of the modules.
+// Dependencies, which already have purls, aren't changed.
+func (targetBuildInfo *BuildInfo) SetPurls() {
+	forEachModule(targetBuildInfo.Modules, func(module *Module) {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			return
+		}
+		for j := range module.Dependencies {
+			if module.Dependencies[j].Purl == "" {
+				module.Dependencies[j].Purl = GetPurl(module.Type, module.Dependencies[j].Id)
+			}
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPurl(t *testing.T) {
+	tests := []struct {
+		moduleType   ModuleType
+		dependencyId string
+		expectedPurl string
+	}{
+		{Go, "github.com/jfrog/gofrog:v1.2.4", "pkg:golang/github.com/jfrog/gofrog@v1.2.4"},
+		{Go, "rsc.io/quote:v1.5.2", "pkg:golang/rsc.io/quote@v1.5.2"},
+		{Maven, "org.apache.commons:commons-lang3:3.12.0", "pkg:maven/org.apache.commons/commons-lang3@3.12.0"},
+		{Gradle, "junit:junit", "pkg:generic/junit@junit"},
+		{Npm, "lodash:4.17.21", "pkg:npm/lodash@4.17.21"},
+		{Npm, "@types/node:20.1.0", "pkg:npm/%40types/node@20.1.0"},
+		{Python, "PyYAML-6.0.tar.gz", "pkg:pypi/pyyaml@6.0"},
+		{Python, "typing_extensions-4.5.0-py3-none-any.whl", "pkg:pypi/typing-extensions@4.5.0"},
+		{Python, "requests:2.28.1", "pkg:pypi/requests@2.28.1"},
+		{Nuget, "newtonsoft.json:13.0.1", "pkg:nuget/newtonsoft.json@13.0.1"},
+		{Composer, "symfony/console:v6.2.0", "pkg:composer/symfony/console@v6.2.0"},
+		{Cocoapods, "Firebase/Core:10.0.0", "pkg:cocoapods/Firebase@10.0.0#Core"},
+		{Conan, "zlib/1.2.13", "pkg:conan/zlib@1.2.13"},
+		{Conan, "poco/1.12.4@conan/stable", "pkg:conan/poco@1.12.4?channel=stable&user=conan"},
+		{Mix, "Jason:1.4.0", "pkg:hex/jason@1.4.0"},
+		{Apk, "musl:1.2.3-r4", "pkg:apk/alpine/musl@1.2.3-r4"},
+		{Dpkg, "libc6:2.36-9+deb12u1", "pkg:deb/debian/libc6@2.36-9%2Bdeb12u1"},
+		{Rpm, "bash:5.1.8-6.el9", "pkg:rpm/bash@5.1.8-6.el9"},
+		{Rpm, "openssl:1:3.0.7-16.el9", "pkg:rpm/openssl@3.0.7-16.el9?epoch=1"},
+		{Vcpkg, "fmt:10.0.0", "pkg:generic/fmt@10.0.0"},
+		{Terraform, "registry.terraform.io/hashicorp/aws:5.0.0", "pkg:generic/registry.terraform.io/hashicorp/aws@5.0.0"},
+		{Generic, "my-file", "pkg:generic/my-file"},
+	}
+	for _, test := range tests {
+		t.Run(test.dependencyId, func(t *testing.T) {
+			assert.Equal(t, test.expectedPurl, GetPurl(test.moduleType, test.dependencyId))
+		})
+	}
+}
+
+func TestSetPurls(t *testing.T) {
+	buildInfo := &BuildInfo{Modules: []Module{
+		{Id: "my-module", Type: Npm, Dependencies: []Dependency{{Id: "lodash:4.17.21"}, {Id: "custom:1.0", Purl: "pkg:github/org/custom@1.0"}}},
+		{Id: "other-build", Type: Build, Dependencies: []Dependency{{Id: "lodash:4.17.21"}}},
+	}}
+	buildInfo.SetPurls()
+	assert.Equal(t, "pkg:npm/lodash@4.17.21", buildInfo.Modules[0].Dependencies[0].Purl)
+	assert.Equal(t, "pkg:github/org/custom@1.0", buildInfo.Modules[0].Dependencies[1].Purl)
+	assert.Empty(t, buildInfo.Modules[1].Dependencies[0].Purl)
+}
@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// BuildInfoReader reads a build-info JSON module by module, so huge build-infos can be processed without loading them into memory.
+// Only the current module, and the fields of the build-info other than its modules, are kept in memory.
+type BuildInfoReader struct {
+	decoder *json.Decoder
+	// The fields of the build-info, other than its modules, which were read so far.
+	fields map[string]json.RawMessage
+	// The state of the reader: whether it read the opening brace of the build-info, whether it's reading the modules array, and whether it read the whole build-info.
+	started   bool
+	inModules bool
+	finished  bool
+}
+
+func NewBuildInfoReader(reader io.Reader) *BuildInfoReader {
+	return &BuildInfoReader{decoder: json.NewDecoder(reader), fields: make(map[string]json.RawMessage)}
+}
+
+// NextModule returns the next module of the build-info, or io.EOF after the last module. The sub-modules of the modules are read with them.
+func (bir *BuildInfoReader) NextModule() (*Module, error) {
+	if bir.finished {
+		return nil, io.EOF
+	}
+	if !bir.started {
+		if err := bir.expectDelim('{'); err != nil {
+			return nil, err
+		}
+		bir.started = true
+	}
+	for {
+		if bir.inModules {
+			if bir.decoder.More() {
+				module := &Module{}
+				if err := bir.decoder.Decode(module); err != nil {
+					return nil, fmt.Errorf("failed to read a module of the build-info: %w", err)
+				}
+				return module, nil
+			}
+			if err := bir.expectDelim(']'); err != nil {
+				return nil, err
+			}
+			bir.inModules = false
+		}
+		if !bir.decoder.More() {
+			if err := bir.expectDelim('}'); err != nil {
+				return nil, err
+			}
+			bir.finished = true
+			return nil, io.EOF
+		}
+		token, err := bir.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %v in the build-info JSON", token)
+		}
+		if key == "modules" {
+			if bir.inModules, err = bir.startModules(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var value json.RawMessage
+		if err = bir.decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		bir.fields[key] = value
+	}
+}
+
+// BuildInfo returns the build-info without its modules. It includes only the fields, which were read so far, so the fields, which follow the modules in the JSON, are included only after NextModule() returns io.EOF.
+func (bir *BuildInfoReader) BuildInfo() (*BuildInfo, error) {
+	content, err := json.Marshal(bir.fields)
+	if err != nil {
+		return nil, err
+	}
+	buildInfo := &BuildInfo{}
+	if err = json.Unmarshal(content, buildInfo); err != nil {
+		return nil, err
+	}
+	return buildInfo, nil
+}
+
+// Reads the start of the modules array. Returns false if the modules are null.
+func (bir *BuildInfoReader) startModules() (bool, error) {
+	token, err := bir.decoder.Token()
+	if err != nil {
+		return false, err
+	}
+	if token == nil {
+		return false, nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return false, fmt.Errorf("expected the modules of the build-info to be an array, but found %v", token)
+	}
+	return true, nil
+}
+
+func (bir *BuildInfoReader) expectDelim(expected json.Delim) error {
+	token, err := bir.decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected '%v' in the build-info JSON, but found %v", expected, token)
+	}
+	return nil
+}
+
+// ForEachModuleInJson reads a build-info JSON module by module (see BuildInfoReader), and runs handler on each module, until it returns an error.
+// Returns the build-info without its modules.
+func ForEachModuleInJson(reader io.Reader, handler func(module *Module) error) (*BuildInfo, error) {
+	buildInfoReader := NewBuildInfoReader(reader)
+	for {
+		module, err := buildInfoReader.NextModule()
+		if err == io.EOF {
+			return buildInfoReader.BuildInfo()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = handler(module); err != nil {
+			return nil, err
+		}
+	}
+}
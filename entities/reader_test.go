@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildInfoReader(t *testing.T) {
+	content := `{
+  "name": "my-build",
+  "modules": [
+    {"id": "first", "type": "go", "dependencies": [{"id": "a:1"}]},
+    {"id": "second", "type": "npm", "modules": [{"id": "sub", "type": "npm"}]}
+  ],
+  "number": "7",
+  "properties": {"buildInfo.env.KEY": "value"}
+}`
+	reader := NewBuildInfoReader(strings.NewReader(content))
+	module, err := reader.NextModule()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", module.Id)
+	assert.Equal(t, []Dependency{{Id: "a:1"}}, module.Dependencies)
+	// Only the fields, which precede the modules, were read.
+	buildInfo, err := reader.BuildInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-build", buildInfo.Name)
+	assert.Empty(t, buildInfo.Number)
+
+	module, err = reader.NextModule()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", module.Id)
+	assert.Equal(t, "sub", module.Modules[0].Id)
+	_, err = reader.NextModule()
+	assert.Equal(t, io.EOF, err)
+	_, err = reader.NextModule()
+	assert.Equal(t, io.EOF, err)
+
+	buildInfo, err = reader.BuildInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, &BuildInfo{Name: "my-build", Number: "7", Properties: Env{"buildInfo.env.KEY": "value"}}, buildInfo)
+}
+
+func TestForEachModuleInJson(t *testing.T) {
+	var moduleIds []string
+	buildInfo, err := ForEachModuleInJson(strings.NewReader(`{"name": "my-build", "modules": [{"id": "first"}, {"id": "second"}]}`), func(module *Module) error {
+		moduleIds = append(moduleIds, module.Id)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-build", buildInfo.Name)
+	assert.Equal(t, []string{"first", "second"}, moduleIds)
+
+	// Build-infos without modules.
+	buildInfo, err = ForEachModuleInJson(strings.NewReader(`{"name": "my-build", "modules": null}`), func(*Module) error {
+		return errors.New("unexpected module")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-build", buildInfo.Name)
+
+	expectedErr := errors.New("stop")
+	_, err = ForEachModuleInJson(strings.NewReader(`{"modules": [{"id": "first"}, {"id": "second"}]}`), func(*Module) error {
+		return expectedErr
+	})
+	assert.Equal(t, expectedErr, err)
+
+	for _, invalid := range []string{`[]`, `{"modules": {}}`, `{"name": "my-build"`, `{"modules": [{"id": 1}]}`} {
+		_, err = ForEachModuleInJson(strings.NewReader(invalid), func(*Module) error { return nil })
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,175 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// How the redacted values are replaced.
+const (
+	// The redacted values are replaced by RedactedValue, and the user names in URLs are removed.
+	RemoveValues RedactionMode = "remove"
+	// The redacted values are replaced by their hex-encoded SHA-256 hashes, so equal values can still be correlated (for example, the builds of the same user).
+	HashValues RedactionMode = "hash"
+
+	RedactedValue = "***"
+)
+
+type RedactionMode string
+
+// The words in texts (such as the values of the environment variables), which may be hostnames.
+var hostnameCandidateRegex = regexp.MustCompile(`[A-Za-z0-9](?:[A-Za-z0-9.-]*[A-Za-z0-9])?`)
+
+// RedactionOptions sets what Redact() redacts from a build-info.
+type RedactionOptions struct {
+	Mode RedactionMode `json:"mode,omitempty"`
+	// Wildcard patterns of the names of the environment variables (without the 'buildInfo.env.' prefix, for example, '*TOKEN*'), whose values are redacted.
+	// If it's empty, the values of all the environment variables are redacted.
+	EnvVars []string `json:"envVars,omitempty"`
+	// If true, the user names are redacted. These are the Artifactory principal, and the user names in the URLs of the build, the VCS and the issues.
+	Users bool `json:"users,omitempty"`
+	// Wildcard patterns of internal hostnames (for example, '*.corp.example.com'), which are redacted from the hostname of the build agent, the URLs,
+	// and the values of the properties of the build and the modules.
+	Hostnames []string `json:"hostnames,omitempty"`
+}
+
+// Redact strips or hashes the values of the environment variables, the user names and the internal hostnames from the build-info, before it's exported outside the organization.
+// Redacting changes the signed content of the build-info, so the signatures, which are embedded in it, are removed. Sign the build-info after it's redacted.
+func (targetBuildInfo *BuildInfo) Redact(options RedactionOptions) error {
+	if options.Mode != RemoveValues && options.Mode != HashValues {
+		return fmt.Errorf("'%s' is not a valid redaction mode", options.Mode)
+	}
+	redactor := &redactor{options: options}
+	for key, value := range targetBuildInfo.Properties {
+		if strings.HasPrefix(key, BuildInfoEnvPrefix) {
+			match, err := matchAnyWildcardPattern(options.EnvVars, strings.TrimPrefix(key, BuildInfoEnvPrefix))
+			if err != nil {
+				return err
+			}
+			if match || len(options.EnvVars) == 0 {
+				targetBuildInfo.Properties[key] = redactor.redactValue(value)
+				continue
+			}
+		}
+		redacted, err := redactor.redactHostnames(value)
+		if err != nil {
+			return err
+		}
+		targetBuildInfo.Properties[key] = redacted
+	}
+	if options.Users && targetBuildInfo.Principal != "" {
+		targetBuildInfo.Principal = redactor.redactValue(targetBuildInfo.Principal)
+	}
+	if targetBuildInfo.BuildAgent != nil && targetBuildInfo.BuildAgent.Hostname != "" {
+		match, err := matchAnyWildcardPattern(options.Hostnames, targetBuildInfo.BuildAgent.Hostname)
+		if err != nil {
+			return err
+		}
+		if match {
+			targetBuildInfo.BuildAgent.Hostname = redactor.redactValue(targetBuildInfo.BuildAgent.Hostname)
+		}
+	}
+	var err error
+	if targetBuildInfo.BuildUrl, err = redactor.redactUrl(targetBuildInfo.BuildUrl); err != nil {
+		return err
+	}
+	for i := range targetBuildInfo.VcsList {
+		if targetBuildInfo.VcsList[i].Url, err = redactor.redactUrl(targetBuildInfo.VcsList[i].Url); err != nil {
+			return err
+		}
+	}
+	if targetBuildInfo.Issues != nil {
+		for i := range targetBuildInfo.Issues.AffectedIssues {
+			if targetBuildInfo.Issues.AffectedIssues[i].Url, err = redactor.redactUrl(targetBuildInfo.Issues.AffectedIssues[i].Url); err != nil {
+				return err
+			}
+		}
+	}
+	forEachModule(targetBuildInfo.Modules, func(module *Module) {
+		if err != nil {
+			return
+		}
+		// The properties may be shared by several modules, so they're copied. Values, which aren't strings, are redacted in their JSON text.
+		properties := module.GetProperties()
+		if len(properties) == 0 {
+			return
+		}
+		for key, value := range properties {
+			if properties[key], err = redactor.redactHostnames(value); err != nil {
+				return
+			}
+		}
+		module.Properties = properties
+	})
+	targetBuildInfo.Signatures = nil
+	return err
+}
+
+type redactor struct {
+	options RedactionOptions
+}
+
+func (r *redactor) redactValue(value string) string {
+	if r.options.Mode == HashValues {
+		hash := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(hash[:])
+	}
+	return RedactedValue
+}
+
+// Redacts the words in the text, which match the internal hostnames.
+func (r *redactor) redactHostnames(text string) (redacted string, err error) {
+	if len(r.options.Hostnames) == 0 {
+		return text, nil
+	}
+	redacted = hostnameCandidateRegex.ReplaceAllStringFunc(text, func(word string) string {
+		match, e := matchAnyWildcardPattern(r.options.Hostnames, word)
+		if e != nil {
+			err = e
+		}
+		if !match {
+			return word
+		}
+		return r.redactValue(word)
+	})
+	return
+}
+
+// Redacts the user name and the internal hostname of the URL. The URL is returned as is, if it can't be parsed.
+func (r *redactor) redactUrl(rawUrl string) (string, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if rawUrl == "" || err != nil {
+		return rawUrl, nil
+	}
+	changed := false
+	if r.options.Users && parsedUrl.User != nil {
+		if r.options.Mode == HashValues {
+			parsedUrl.User = url.User(r.redactValue(parsedUrl.User.Username()))
+		} else {
+			parsedUrl.User = nil
+		}
+		changed = true
+	}
+	if hostname := parsedUrl.Hostname(); hostname != "" {
+		match, err := matchAnyWildcardPattern(r.options.Hostnames, hostname)
+		if err != nil {
+			return "", err
+		}
+		if match {
+			host := r.redactValue(hostname)
+			if port := parsedUrl.Port(); port != "" {
+				host += ":" + port
+			}
+			parsedUrl.Host = host
+			changed = true
+		}
+	}
+	if !changed {
+		return rawUrl, nil
+	}
+	return parsedUrl.String(), nil
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBuildInfoToRedact() *BuildInfo {
+	return &BuildInfo{
+		Principal:  "jdoe",
+		BuildAgent: &Agent{Name: "GENERIC", Hostname: "build-07.corp.example.com"},
+		BuildUrl:   "https://ci.corp.example.com:8443/job/1",
+		Properties: Env{
+			"buildInfo.env.API_TOKEN": "secret",
+			"buildInfo.env.HOME":      "/home/jdoe",
+			"ci.node":                 "runs on build-07.corp.example.com and example.com",
+		},
+		VcsList:    []Vcs{{Url: "https://jdoe@github.com/example/repo.git", Revision: "abc"}},
+		Issues:     &Issues{AffectedIssues: []AffectedIssue{{Key: "JIRA-1", Url: "https://jira.corp.example.com/browse/JIRA-1"}}},
+		Modules:    []Module{{Id: "module", Properties: map[string]string{"host": "db.corp.example.com"}}},
+		Signatures: []BuildInfoSignature{{Type: GpgSignature, Signature: "signature"}},
+	}
+}
+
+func TestRedactRemoveValues(t *testing.T) {
+	buildInfo := newBuildInfoToRedact()
+	assert.NoError(t, buildInfo.Redact(RedactionOptions{Mode: RemoveValues, EnvVars: []string{"*token*"}, Users: true, Hostnames: []string{"*.corp.example.com"}}))
+	assert.Equal(t, RedactedValue, buildInfo.Principal)
+	assert.Equal(t, RedactedValue, buildInfo.BuildAgent.Hostname)
+	assert.Equal(t, "https://***:8443/job/1", buildInfo.BuildUrl)
+	assert.Equal(t, Env{
+		"buildInfo.env.API_TOKEN": RedactedValue,
+		"buildInfo.env.HOME":      "/home/jdoe",
+		"ci.node":                 "runs on *** and example.com",
+	}, buildInfo.Properties)
+	assert.Equal(t, "https://github.com/example/repo.git", buildInfo.VcsList[0].Url)
+	assert.Equal(t, "https://***/browse/JIRA-1", buildInfo.Issues.AffectedIssues[0].Url)
+	assert.Equal(t, map[string]string{"host": RedactedValue}, buildInfo.Modules[0].Properties)
+	assert.Empty(t, buildInfo.Signatures)
+}
+
+func TestRedactHashValues(t *testing.T) {
+	hash := func(value string) string {
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])
+	}
+	buildInfo := newBuildInfoToRedact()
+	// The values of all the environment variables are redacted, but the user names and hostnames aren't.
+	assert.NoError(t, buildInfo.Redact(RedactionOptions{Mode: HashValues}))
+	assert.Equal(t, "jdoe", buildInfo.Principal)
+	assert.Equal(t, hash("secret"), buildInfo.Properties["buildInfo.env.API_TOKEN"])
+	assert.Equal(t, hash("/home/jdoe"), buildInfo.Properties["buildInfo.env.HOME"])
+	assert.Equal(t, "https://ci.corp.example.com:8443/job/1", buildInfo.BuildUrl)
+
+	buildInfo = newBuildInfoToRedact()
+	assert.NoError(t, buildInfo.Redact(RedactionOptions{Mode: HashValues, Users: true}))
+	assert.Equal(t, hash("jdoe"), buildInfo.Principal)
+	assert.Equal(t, "https://"+hash("jdoe")+"@github.com/example/repo.git", buildInfo.VcsList[0].Url)
+
+	assert.Error(t, buildInfo.Redact(RedactionOptions{}))
+}
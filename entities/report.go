@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The statuses of the environment variables, in relation to a previous build.
+const (
+	EnvAdded     = "added"
+	EnvRemoved   = "removed"
+	EnvChanged   = "changed"
+	EnvUnchanged = "unchanged"
+)
+
+type htmlReport struct {
+	BuildInfo   *BuildInfo
+	Modules     []htmlReportModule
+	Env         []htmlReportEnv
+	HasPrevious bool
+	Previous    string
+}
+
+type htmlReportModule struct {
+	Module
+	DirectDependencies   int
+	IndirectDependencies int
+}
+
+type htmlReportEnv struct {
+	Name          string
+	Value         string
+	PreviousValue string
+	Status        string
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Build {{.BuildInfo.Name}} #{{.BuildInfo.Number}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { font-family: SFMono-Regular, Consolas, monospace; font-size: 0.9em; }
+.added { background: #dafbe1; }
+.removed { background: #ffebe9; }
+.changed { background: #fff8c5; }
+</style>
+</head>
+<body>
+<h1>Build {{.BuildInfo.Name}} #{{.BuildInfo.Number}}</h1>
+<p>Started: {{.BuildInfo.Started}}{{if .BuildInfo.BuildUrl}} | <a href="{{.BuildInfo.BuildUrl}}">CI run</a>{{end}}</p>
+<h2>Modules</h2>
+<table>
+<tr><th>Module</th><th>Type</th><th>Artifacts</th><th>Direct dependencies</th><th>Indirect dependencies</th></tr>
+{{- range .Modules}}
+<tr><td>{{.Id}}</td><td>{{.Type}}</td><td>{{len .Artifacts}}</td><td>{{.DirectDependencies}}</td><td>{{.IndirectDependencies}}</td></tr>
+{{- end}}
+</table>
+<h2>Artifacts</h2>
+<table>
+<tr><th>Module</th><th>Name</th><th>SHA-256</th><th>SHA-1</th><th>MD5</th></tr>
+{{- range $module := .Modules}}{{range .Artifacts}}
+<tr><td>{{$module.Id}}</td><td>{{.Name}}</td><td><code>{{.Sha256}}</code></td><td><code>{{.Sha1}}</code></td><td><code>{{.Md5}}</code></td></tr>
+{{- end}}{{end}}
+</table>
+<h2>Environment</h2>
+{{- if .HasPrevious}}
+<p>Compared to build {{.Previous}}.</p>
+<table>
+<tr><th>Name</th><th>Previous value</th><th>Value</th><th>Status</th></tr>
+{{- range .Env}}
+<tr class="{{.Status}}"><td>{{.Name}}</td><td>{{.PreviousValue}}</td><td>{{.Value}}</td><td>{{.Status}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<table>
+<tr><th>Name</th><th>Value</th></tr>
+{{- range .Env}}
+<tr><td>{{.Name}}</td><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHtmlReport writes a self-contained static HTML page, which reports the modules of the build, the counts of their direct and indirect dependencies, the checksums of their artifacts and the environment variables.
+// If a previous build is provided, the environment variables are compared to its environment variables. Otherwise, pass nil.
+func (targetBuildInfo *BuildInfo) WriteHtmlReport(writer io.Writer, previous *BuildInfo) error {
+	report := htmlReport{BuildInfo: targetBuildInfo, Env: diffEnv(previous, targetBuildInfo)}
+	if previous != nil {
+		report.HasPrevious = true
+		report.Previous = previous.Name + " #" + previous.Number
+	}
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		reportModule := htmlReportModule{Module: module}
+		for _, dependency := range module.Dependencies {
+			if getDependencyRelation(module.Id, dependency) == DirectDependency {
+				reportModule.DirectDependencies++
+			} else {
+				reportModule.IndirectDependencies++
+			}
+		}
+		report.Modules = append(report.Modules, reportModule)
+	}
+	return htmlReportTemplate.Execute(writer, report)
+}
+
+// Returns the environment variables of the current build, sorted by their names. If the previous build isn't nil, the removed variables are included, and the status of each variable is set.
+func diffEnv(previous, current *BuildInfo) []htmlReportEnv {
+	var env []htmlReportEnv
+	previousEnv := make(map[string]string)
+	if previous != nil {
+		for key, value := range previous.Properties {
+			if strings.HasPrefix(key, BuildInfoEnvPrefix) {
+				previousEnv[strings.TrimPrefix(key, BuildInfoEnvPrefix)] = value
+			}
+		}
+	}
+	for key, value := range current.Properties {
+		if !strings.HasPrefix(key, BuildInfoEnvPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, BuildInfoEnvPrefix)
+		variable := htmlReportEnv{Name: name, Value: value}
+		if previous != nil {
+			previousValue, exists := previousEnv[name]
+			switch {
+			case !exists:
+				variable.Status = EnvAdded
+			case previousValue != value:
+				variable.Status, variable.PreviousValue = EnvChanged, previousValue
+			default:
+				variable.Status, variable.PreviousValue = EnvUnchanged, previousValue
+			}
+			delete(previousEnv, name)
+		}
+		env = append(env, variable)
+	}
+	for name, previousValue := range previousEnv {
+		env = append(env, htmlReportEnv{Name: name, PreviousValue: previousValue, Status: EnvRemoved})
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	return env
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHtmlReport(t *testing.T) {
+	buildInfo := &BuildInfo{
+		Name:   "my-build",
+		Number: "2",
+		Modules: []Module{{
+			Id:        "my-app",
+			Type:      Npm,
+			Artifacts: []Artifact{{Name: "my-app-1.0.0.tgz", Checksum: Checksum{Sha256: "a256", Sha1: "a1"}}},
+			Dependencies: []Dependency{
+				{Id: "express:4.18.2", RequestedBy: [][]string{{"my-app"}}},
+				{Id: "body-parser:1.20.1", RequestedBy: [][]string{{"express:4.18.2", "my-app"}}},
+			},
+		}},
+		Properties: Env{BuildInfoEnvPrefix + "NODE_ENV": "production", BuildInfoEnvPrefix + "CI": "true", "other": "<not env>"},
+	}
+	previous := &BuildInfo{Name: "my-build", Number: "1", Properties: Env{BuildInfoEnvPrefix + "NODE_ENV": "development", BuildInfoEnvPrefix + "CI": "true", BuildInfoEnvPrefix + "DEBUG": "1"}}
+
+	var report strings.Builder
+	assert.NoError(t, buildInfo.WriteHtmlReport(&report, previous))
+	html := report.String()
+	assert.Contains(t, html, "<title>Build my-build #2</title>")
+	assert.Contains(t, html, "<tr><td>my-app</td><td>npm</td><td>1</td><td>1</td><td>1</td></tr>")
+	assert.Contains(t, html, "<tr><td>my-app</td><td>my-app-1.0.0.tgz</td><td><code>a256</code></td><td><code>a1</code></td><td><code></code></td></tr>")
+	assert.Contains(t, html, "Compared to build my-build #1.")
+	assert.Contains(t, html, `<tr class="unchanged"><td>CI</td><td>true</td><td>true</td><td>unchanged</td></tr>`)
+	assert.Contains(t, html, `<tr class="removed"><td>DEBUG</td><td>1</td><td></td><td>removed</td></tr>`)
+	assert.Contains(t, html, `<tr class="changed"><td>NODE_ENV</td><td>development</td><td>production</td><td>changed</td></tr>`)
+	assert.NotContains(t, html, "not env")
+
+	// Without a previous build, the environment variables are listed as they are.
+	report.Reset()
+	assert.NoError(t, buildInfo.WriteHtmlReport(&report, nil))
+	assert.Contains(t, report.String(), "<tr><td>NODE_ENV</td><td>production</td></tr>")
+	assert.NotContains(t, report.String(), "Compared to build")
+}
+
+func TestDiffEnv(t *testing.T) {
+	current := &BuildInfo{Properties: Env{BuildInfoEnvPrefix + "B": "2", BuildInfoEnvPrefix + "A": "1"}}
+	assert.Equal(t, []htmlReportEnv{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}, diffEnv(nil, current))
+	previous := &BuildInfo{Properties: Env{BuildInfoEnvPrefix + "A": "1"}}
+	assert.Equal(t, []htmlReportEnv{{Name: "A", Value: "1", PreviousValue: "1", Status: EnvUnchanged}, {Name: "B", Value: "2", Status: EnvAdded}}, diffEnv(previous, current))
+}
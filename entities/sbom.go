@@ -0,0 +1,261 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// The dependency properties, which hold the licenses of the components and the packages imported from SBOMs.
+	CycloneDxLicenseProperty = "cyclonedx.license"
+	SpdxLicenseProperty      = "spdx.license"
+)
+
+// SbomToModule converts a CycloneDX or an SPDX SBOM, in the JSON format, to a module, whose dependencies are the components (or packages) of the SBOM.
+// The format is detected from the content. See CycloneDxBom.ToModule() and SpdxDocument.ToModule() for the details of the conversion.
+func SbomToModule(content []byte, moduleId string, moduleType ModuleType) (*Module, error) {
+	var header struct {
+		BomFormat   string `json:"bomFormat"`
+		SpdxVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		return nil, errors.New("failed to parse the SBOM, which must be a CycloneDX or an SPDX JSON document: " + err.Error())
+	}
+	switch {
+	case header.BomFormat == CycloneDxBomFormat:
+		bom := &CycloneDxBom{}
+		if err := json.Unmarshal(content, bom); err != nil {
+			return nil, err
+		}
+		return bom.ToModule(moduleId, moduleType), nil
+	case strings.HasPrefix(header.SpdxVersion, "SPDX-"):
+		document := &SpdxDocument{}
+		if err := json.Unmarshal(content, document); err != nil {
+			return nil, err
+		}
+		return document.ToModule(moduleId, moduleType), nil
+	default:
+		return nil, errors.New("the SBOM is neither a CycloneDX nor an SPDX JSON document")
+	}
+}
+
+// ToModule converts the BOM to a module, whose dependencies are the components of the BOM (including nested components).
+// The component described by the metadata of the BOM is the module itself. If moduleId is empty, the ID of the module is taken from this component.
+// The RequestedBy fields of the dependencies are built from the dependencies section of the BOM. Components, which no other component depends on, are direct dependencies of the module.
+func (bom *CycloneDxBom) ToModule(moduleId string, moduleType ModuleType) *Module {
+	rootRef := ""
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		rootRef = bom.Metadata.Component.BomRef
+		if moduleId == "" {
+			moduleId = getSbomPackageId(bom.Metadata.Component.Group, bom.Metadata.Component.Name, bom.Metadata.Component.Version)
+		}
+	}
+
+	graph := newSbomDependencyGraph(rootRef)
+	var addComponents func(components []CycloneDxComponent)
+	addComponents = func(components []CycloneDxComponent) {
+		for _, component := range components {
+			if rootRef == "" || component.BomRef != rootRef {
+				graph.addDependency(component.BomRef, cycloneDxComponentToDependency(component))
+			}
+			addComponents(component.Components)
+		}
+	}
+	addComponents(bom.Components)
+	for _, dependency := range bom.Dependencies {
+		for _, childRef := range dependency.DependsOn {
+			graph.addEdge(dependency.Ref, childRef)
+		}
+	}
+	return &Module{Id: moduleId, Type: moduleType, Dependencies: graph.toDependencies(moduleId)}
+}
+
+func cycloneDxComponentToDependency(component CycloneDxComponent) Dependency {
+	dependency := Dependency{Id: getSbomPackageId(component.Group, component.Name, component.Version), Purl: component.Purl}
+	for _, hash := range component.Hashes {
+		switch hash.Alg {
+		case "SHA-1":
+			dependency.Sha1 = hash.Content
+		case "SHA-256":
+			dependency.Sha256 = hash.Content
+		case "SHA-512":
+			dependency.Sha512 = hash.Content
+		case "MD5":
+			dependency.Md5 = hash.Content
+		}
+	}
+	for _, property := range component.Properties {
+		switch property.Name {
+		case CycloneDxTypeProperty:
+			dependency.Type = property.Value
+		case CycloneDxScopesProperty:
+			dependency.Scopes = strings.Split(property.Value, ",")
+		}
+	}
+	var licenses []string
+	for _, license := range component.Licenses {
+		switch {
+		case license.Expression != "":
+			licenses = append(licenses, license.Expression)
+		case license.License != nil && license.License.Id != "":
+			licenses = append(licenses, license.License.Id)
+		case license.License != nil && license.License.Name != "":
+			licenses = append(licenses, license.License.Name)
+		}
+	}
+	if len(licenses) > 0 {
+		dependency.Properties = map[string]string{CycloneDxLicenseProperty: strings.Join(licenses, " AND ")}
+	}
+	return dependency
+}
+
+// ToModule converts the document to a module, whose dependencies are the packages of the document.
+// The packages described by the document are the module itself. If moduleId is empty, the ID of the module is taken from the first of them.
+// The RequestedBy fields of the dependencies are built from the DEPENDS_ON and DEPENDENCY_OF relationships. Packages, which no other package depends on, are direct dependencies of the module.
+func (document *SpdxDocument) ToModule(moduleId string, moduleType ModuleType) *Module {
+	describedIds := append([]string{}, document.DocumentDescribes...)
+	for _, relationship := range document.Relationships {
+		if relationship.Element == document.SpdxId && relationship.Type == SpdxRelationshipDescribes {
+			describedIds = append(describedIds, relationship.RelatedElement)
+		}
+	}
+
+	graph := newSbomDependencyGraph(describedIds...)
+	for _, spdxPackage := range document.Packages {
+		if slices.Contains(describedIds, spdxPackage.SpdxId) {
+			if moduleId == "" {
+				moduleId = getSbomPackageId("", spdxPackage.Name, spdxPackage.VersionInfo)
+			}
+			continue
+		}
+		graph.addDependency(spdxPackage.SpdxId, spdxPackageToDependency(spdxPackage))
+	}
+	for _, relationship := range document.Relationships {
+		switch relationship.Type {
+		case SpdxRelationshipDependsOn:
+			graph.addEdge(relationship.Element, relationship.RelatedElement)
+		case SpdxRelationshipDependencyOf:
+			graph.addEdge(relationship.RelatedElement, relationship.Element)
+		}
+	}
+	return &Module{Id: moduleId, Type: moduleType, Dependencies: graph.toDependencies(moduleId)}
+}
+
+func spdxPackageToDependency(spdxPackage SpdxPackage) Dependency {
+	dependency := Dependency{Id: getSbomPackageId("", spdxPackage.Name, spdxPackage.VersionInfo)}
+	for _, checksum := range spdxPackage.Checksums {
+		switch checksum.Algorithm {
+		case "SHA1":
+			dependency.Sha1 = checksum.Value
+		case "SHA256":
+			dependency.Sha256 = checksum.Value
+		case "SHA512":
+			dependency.Sha512 = checksum.Value
+		case "MD5":
+			dependency.Md5 = checksum.Value
+		}
+	}
+	for _, externalRef := range spdxPackage.ExternalRefs {
+		if externalRef.Type == "purl" {
+			dependency.Purl = externalRef.Locator
+			break
+		}
+	}
+	license := spdxPackage.LicenseConcluded
+	if license == "" || license == SpdxNoAssertion || license == "NONE" {
+		license = spdxPackage.LicenseDeclared
+	}
+	if license != "" && license != SpdxNoAssertion && license != "NONE" {
+		dependency.Properties = map[string]string{SpdxLicenseProperty: license}
+	}
+	return dependency
+}
+
+// Returns the ID of a dependency in the 'group:name:version' format, omitting its empty parts.
+func getSbomPackageId(group, name, version string) string {
+	var parts []string
+	for _, part := range []string{group, name, version} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// sbomDependencyGraph holds the dependencies of an SBOM by their references (bom-refs or SPDX identifiers), and the dependency relations between them.
+type sbomDependencyGraph struct {
+	rootRefs     []string
+	refs         []string
+	dependencies map[string]Dependency
+	children     map[string][]string
+	hasParent    map[string]bool
+}
+
+func newSbomDependencyGraph(rootRefs ...string) *sbomDependencyGraph {
+	return &sbomDependencyGraph{rootRefs: rootRefs, dependencies: make(map[string]Dependency), children: make(map[string][]string), hasParent: make(map[string]bool)}
+}
+
+func (graph *sbomDependencyGraph) addDependency(ref string, dependency Dependency) {
+	if ref == "" {
+		// Components without a reference can't be part of the graph, so each of them gets a reference of its own.
+		ref = "\n" + dependency.Id + "\n" + dependency.Sha1
+	}
+	if _, exists := graph.dependencies[ref]; exists {
+		return
+	}
+	graph.refs = append(graph.refs, ref)
+	graph.dependencies[ref] = dependency
+}
+
+func (graph *sbomDependencyGraph) addEdge(parentRef, childRef string) {
+	if slices.Contains(graph.rootRefs, parentRef) || slices.Contains(graph.children[parentRef], childRef) {
+		return
+	}
+	graph.children[parentRef] = append(graph.children[parentRef], childRef)
+	graph.hasParent[childRef] = true
+}
+
+// Returns the dependencies of the module, in the order they appear in the SBOM, with their RequestedBy fields populated.
+func (graph *sbomDependencyGraph) toDependencies(moduleId string) []Dependency {
+	for _, ref := range graph.refs {
+		if !graph.hasParent[ref] {
+			graph.populateRequestedBy(ref, moduleId, [][]string{{}})
+		}
+	}
+	dependencies := []Dependency{}
+	for _, ref := range graph.refs {
+		dependencies = append(dependencies, graph.dependencies[ref])
+	}
+	return dependencies
+}
+
+// Adds the paths of the parent to the RequestedBy field of the dependency, and then does the same for the children of the dependency recursively.
+// Paths, which contain the dependency itself, are skipped, and the recursion stops when the RequestedBy field doesn't change.
+func (graph *sbomDependencyGraph) populateRequestedBy(ref, parentId string, parentRequestedBy [][]string) {
+	dependency := graph.dependencies[ref]
+	if len(dependency.RequestedBy) >= RequestedByMaxLength {
+		return
+	}
+	previousRequestedBy := dependency.RequestedBy
+	dependency.UpdateRequestedBy(parentId, parentRequestedBy)
+	var requestedBy [][]string
+	for _, path := range dependency.RequestedBy {
+		if !slices.Contains(path, dependency.Id) {
+			requestedBy = append(requestedBy, path)
+		}
+	}
+	dependency.RequestedBy = requestedBy
+	if reflect.DeepEqual(previousRequestedBy, dependency.RequestedBy) {
+		return
+	}
+	graph.dependencies[ref] = dependency
+	for _, childRef := range graph.children[ref] {
+		if _, exists := graph.dependencies[childRef]; exists {
+			graph.populateRequestedBy(childRef, dependency.Id, dependency.RequestedBy)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCycloneDxBomToModule(t *testing.T) {
+	bom := &CycloneDxBom{
+		BomFormat: CycloneDxBomFormat,
+		Components: []CycloneDxComponent{
+			{BomRef: "a", Name: "a", Version: "1", Licenses: []CycloneDxLicense{{License: &CycloneDxLicenseDetails{Name: "Custom"}}, {Expression: "MIT OR Apache-2.0"}}},
+			{BomRef: "b", Group: "org", Name: "b", Version: "2", Hashes: []CycloneDxHash{{Alg: "MD5", Content: "m1"}}, Components: []CycloneDxComponent{{BomRef: "b/c", Name: "c"}}},
+		},
+		// A cycle between a and b.
+		Dependencies: []CycloneDxDependency{{Ref: "a", DependsOn: []string{"b"}}, {Ref: "b", DependsOn: []string{"a", "b/c"}}},
+	}
+	module := bom.ToModule("my-module", Generic)
+	assert.Equal(t, "my-module", module.Id)
+	assert.Equal(t, Generic, module.Type)
+	// All the components are part of the cycle, or depend on it, so none of them is a direct dependency of the module.
+	assert.Equal(t, []Dependency{
+		{Id: "a:1", Properties: map[string]string{CycloneDxLicenseProperty: "Custom AND MIT OR Apache-2.0"}},
+		{Id: "org:b:2", Checksum: Checksum{Md5: "m1"}},
+		{Id: "c"},
+	}, module.Dependencies)
+
+	// Without the cycle, a is a direct dependency of the module.
+	bom.Dependencies[1].DependsOn = []string{"b/c"}
+	module = bom.ToModule("my-module", Generic)
+	assert.Equal(t, [][]string{{"my-module"}}, module.Dependencies[0].RequestedBy)
+	assert.Equal(t, [][]string{{"a:1", "my-module"}}, module.Dependencies[1].RequestedBy)
+	assert.Equal(t, [][]string{{"org:b:2", "a:1", "my-module"}}, module.Dependencies[2].RequestedBy)
+}
+
+func TestSbomToModuleRoundTrip(t *testing.T) {
+	buildInfo := &BuildInfo{
+		Name:   "my-build",
+		Number: "1",
+		Modules: []Module{{
+			Id:   "my-module",
+			Type: Npm,
+			Dependencies: []Dependency{
+				{Id: "a:1", Type: "tgz", Scopes: []string{"prod"}, Checksum: Checksum{Sha1: "a1"}, RequestedBy: [][]string{{"my-module"}}},
+				{Id: "b:2", Properties: map[string]string{"rpm.license": "MIT"}, RequestedBy: [][]string{{"a:1", "my-module"}, {"my-module"}}},
+			},
+		}},
+	}
+
+	cycloneDxContent, err := json.Marshal(buildInfo.ToCycloneDx15Bom())
+	require.NoError(t, err)
+	module, err := SbomToModule(cycloneDxContent, "imported", Npm)
+	require.NoError(t, err)
+	// The module of the build-info is a component of the BOM, so it's imported as a dependency too.
+	assert.Equal(t, []Dependency{
+		{Id: "my-module", Type: "npm", RequestedBy: [][]string{{"imported"}}},
+		{Id: "a:1", Type: "tgz", Scopes: []string{"prod"}, Checksum: Checksum{Sha1: "a1"}, Purl: "pkg:npm/a@1", RequestedBy: [][]string{{"my-module", "imported"}}},
+		{Id: "b:2", Purl: "pkg:npm/b@2", RequestedBy: [][]string{{"a:1", "my-module", "imported"}, {"my-module", "imported"}}},
+	}, module.Dependencies)
+
+	spdxContent, err := json.Marshal(buildInfo.ToSpdx23Document())
+	require.NoError(t, err)
+	module, err = SbomToModule(spdxContent, "", Npm)
+	require.NoError(t, err)
+	// The name of the module is taken from the package the document describes.
+	assert.Equal(t, "my-build:1", module.Id)
+	assert.Equal(t, []Dependency{
+		{Id: "my-module", RequestedBy: [][]string{{"my-build:1"}}},
+		{Id: "a:1", Checksum: Checksum{Sha1: "a1"}, Purl: "pkg:npm/a@1", RequestedBy: [][]string{{"my-module", "my-build:1"}}},
+		{Id: "b:2", Properties: map[string]string{SpdxLicenseProperty: "MIT"}, Purl: "pkg:npm/b@2", RequestedBy: [][]string{{"a:1", "my-module", "my-build:1"}, {"my-module", "my-build:1"}}},
+	}, module.Dependencies)
+
+	_, err = SbomToModule([]byte(`{"name": "not an SBOM"}`), "", Npm)
+	assert.Error(t, err)
+	_, err = SbomToModule([]byte(`not JSON`), "", Npm)
+	assert.Error(t, err)
+}
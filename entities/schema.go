@@ -0,0 +1,33 @@
+package entities
+
+import (
+	_ "embed"
+	"errors"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//go:embed buildinfo-schema.json
+var buildInfoSchema []byte
+
+// GetBuildInfoSchema returns the canonical JSON schema of the build-info.
+func GetBuildInfoSchema() []byte {
+	return append([]byte{}, buildInfoSchema...)
+}
+
+// ValidateBuildInfoJson validates the build-info JSON against the build-info schema. The returned error lists all the violations of the schema.
+func ValidateBuildInfoJson(content []byte) error {
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(buildInfoSchema), gojsonschema.NewBytesLoader(content))
+	if err != nil {
+		return err
+	}
+	if result.Valid() {
+		return nil
+	}
+	violations := make([]string, 0, len(result.Errors()))
+	for _, violation := range result.Errors() {
+		violations = append(violations, violation.String())
+	}
+	return errors.New("the build-info doesn't match the build-info schema:\n" + strings.Join(violations, "\n"))
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBuildInfoSchema(t *testing.T) {
+	var schema map[string]interface{}
+	assert.NoError(t, json.Unmarshal(GetBuildInfoSchema(), &schema))
+	assert.Equal(t, "build-info", schema["title"])
+	// The embedded schema can't be changed by the callers.
+	GetBuildInfoSchema()[0] = 'x'
+	assert.Equal(t, byte('{'), GetBuildInfoSchema()[0])
+}
+
+func TestValidateBuildInfoJson(t *testing.T) {
+	buildInfo := &BuildInfo{Name: "my-build", Number: "1", Started: "2023-01-01T10:00:00.000+0000", Modules: []Module{{Id: "my-module", Type: Npm, Dependencies: []Dependency{{Id: "express:4.18.2", Checksum: Checksum{Sha1: "a1", Md5: "m1"}}}}}}
+	content, err := json.Marshal(buildInfo)
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateBuildInfoJson(content))
+
+	err = ValidateBuildInfoJson([]byte(`{"name": 1}`))
+	assert.ErrorContains(t, err, "name")
+	assert.Error(t, ValidateBuildInfoJson([]byte(`not json`)))
+}
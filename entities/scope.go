@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// The standard scopes of dependencies, which are common to all the module types.
+const (
+	// Required to compile the module, and at runtime.
+	CompileScope DependencyScope = "compile"
+	// Required only at runtime.
+	RuntimeScope DependencyScope = "runtime"
+	// Required only to compile or run the tests.
+	TestScope DependencyScope = "test"
+	// Required only during development, such as build tools and linters.
+	DevScope DependencyScope = "dev"
+	// Not required, but used if present.
+	OptionalScope DependencyScope = "optional"
+	// Required to compile the module, but provided by the environment at runtime.
+	ProvidedScope DependencyScope = "provided"
+)
+
+type DependencyScope string
+
+// The standard scopes, in the order they're listed in the dependencies.
+var standardScopes = []DependencyScope{CompileScope, RuntimeScope, TestScope, DevScope, OptionalScope, ProvidedScope}
+
+// The scopes, which the package managers use, by their lowercase names, and their standard scopes.
+var standardScopesByName = map[string]DependencyScope{
+	"compile":              CompileScope,
+	"implementation":       CompileScope,
+	"api":                  CompileScope,
+	"compileclasspath":     CompileScope,
+	"prod":                 CompileScope,
+	"production":           CompileScope,
+	"default":              CompileScope,
+	"main":                 CompileScope,
+	"runtime":              RuntimeScope,
+	"runtimeonly":          RuntimeScope,
+	"runtimeclasspath":     RuntimeScope,
+	"test":                 TestScope,
+	"dev":                  DevScope,
+	"development":          DevScope,
+	"devdependencies":      DevScope,
+	"require-dev":          DevScope,
+	"build":                DevScope,
+	"optional":             OptionalScope,
+	"optionaldependencies": OptionalScope,
+	"recommended":          OptionalScope,
+	"provided":             ProvidedScope,
+	"system":               ProvidedScope,
+	"compileonly":          ProvidedScope,
+	"peer":                 ProvidedScope,
+	"peerdependencies":     ProvidedScope,
+}
+
+// GetStandardScopes maps the scopes of a dependency, as set by its package manager (for example, Maven's 'provided', Gradle's 'testImplementation' or npm's 'dev'), to the standard scopes.
+// Scopes, whose names include 'test', are test scopes. Scopes, which aren't recognized, are ignored, and a dependency without recognized scopes has the compile scope.
+func GetStandardScopes(scopes []string) []DependencyScope {
+	found := make(map[DependencyScope]bool)
+	for _, scope := range scopes {
+		// Elixir writes the scopes as atoms (for example, ':dev').
+		name := strings.TrimPrefix(strings.ToLower(scope), ":")
+		if standardScope, exists := standardScopesByName[name]; exists {
+			found[standardScope] = true
+		} else if strings.Contains(name, "test") {
+			found[TestScope] = true
+		}
+	}
+	if len(found) == 0 {
+		return []DependencyScope{CompileScope}
+	}
+	var dependencyScopes []DependencyScope
+	for _, standardScope := range standardScopes {
+		if found[standardScope] {
+			dependencyScopes = append(dependencyScopes, standardScope)
+		}
+	}
+	return dependencyScopes
+}
+
+// SetStandardScopes sets the standard scopes of the dependencies of all the modules and their sub-modules, from the scopes set by their package managers.
+// Dependencies, which already have standard scopes, aren't changed.
+func (targetBuildInfo *BuildInfo) SetStandardScopes() {
+	forEachModule(targetBuildInfo.Modules, func(module *Module) {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			return
+		}
+		for j := range module.Dependencies {
+			if len(module.Dependencies[j].StandardScopes) == 0 {
+				module.Dependencies[j].StandardScopes = GetStandardScopes(module.Dependencies[j].Scopes)
+			}
+		}
+	})
+}
+
+// IsTestOnly returns true if the dependency is required only by tests, according to its standard scopes.
+func (dependency *Dependency) IsTestOnly() bool {
+	for _, scope := range dependency.StandardScopes {
+		if scope != TestScope {
+			return false
+		}
+	}
+	return len(dependency.StandardScopes) > 0
+}
+
+// Returns the standard scopes of both dependencies, in the standard order.
+func mergeStandardScopes(scopes1, scopes2 []DependencyScope) []DependencyScope {
+	if len(scopes1) == 0 || len(scopes2) == 0 {
+		return append(scopes1, scopes2...)
+	}
+	var merged []DependencyScope
+	for _, standardScope := range standardScopes {
+		if slices.Contains(scopes1, standardScope) || slices.Contains(scopes2, standardScope) {
+			merged = append(merged, standardScope)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStandardScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []string
+		expected []DependencyScope
+	}{
+		{"no scopes", nil, []DependencyScope{CompileScope}},
+		{"maven", []string{"provided"}, []DependencyScope{ProvidedScope}},
+		{"gradle", []string{"testImplementation", "androidTestRuntimeClasspath"}, []DependencyScope{TestScope}},
+		{"gradle runtime", []string{"runtimeClasspath", "compileClasspath"}, []DependencyScope{CompileScope, RuntimeScope}},
+		{"npm", []string{"prod", "@types"}, []DependencyScope{CompileScope}},
+		{"npm dev", []string{"dev"}, []DependencyScope{DevScope}},
+		{"mix", []string{":dev", ":test"}, []DependencyScope{TestScope, DevScope}},
+		{"conan", []string{"build"}, []DependencyScope{DevScope}},
+		{"homebrew", []string{"recommended"}, []DependencyScope{OptionalScope}},
+		{"unknown", []string{"custom"}, []DependencyScope{CompileScope}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GetStandardScopes(test.scopes))
+		})
+	}
+}
+
+func TestSetStandardScopes(t *testing.T) {
+	buildInfo := &BuildInfo{Modules: []Module{
+		{Id: "module", Type: Go, Dependencies: []Dependency{
+			{Id: "a:1", Scopes: []string{"test"}},
+			{Id: "b:1"},
+			{Id: "c:1", Scopes: []string{"test"}, StandardScopes: []DependencyScope{RuntimeScope}},
+		}},
+		{Id: "build/1", Type: Build, Dependencies: []Dependency{{Id: "d:1"}}},
+	}}
+	buildInfo.SetStandardScopes()
+	dependencies := buildInfo.Modules[0].Dependencies
+	assert.Equal(t, []DependencyScope{TestScope}, dependencies[0].StandardScopes)
+	assert.True(t, dependencies[0].IsTestOnly())
+	assert.Equal(t, []DependencyScope{CompileScope}, dependencies[1].StandardScopes)
+	assert.False(t, dependencies[1].IsTestOnly())
+	// Standard scopes, which were set by the collector, aren't changed.
+	assert.Equal(t, []DependencyScope{RuntimeScope}, dependencies[2].StandardScopes)
+	assert.Empty(t, buildInfo.Modules[1].Dependencies[0].StandardScopes)
+}
+
+func TestMergeStandardScopes(t *testing.T) {
+	assert.Equal(t, []DependencyScope{CompileScope, TestScope}, mergeStandardScopes([]DependencyScope{TestScope}, []DependencyScope{CompileScope, TestScope}))
+	assert.Equal(t, []DependencyScope{DevScope}, mergeStandardScopes(nil, []DependencyScope{DevScope}))
+}
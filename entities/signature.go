@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The types of the signatures of build-infos.
+const (
+	// An ASCII-armored OpenPGP signature, created by GPG.
+	GpgSignature BuildInfoSignatureType = "gpg"
+	// A Sigstore signature, created by keyless signing with a short-lived certificate, issued by Fulcio to an OIDC identity.
+	SigstoreSignature BuildInfoSignatureType = "sigstore"
+)
+
+type BuildInfoSignatureType string
+
+// BuildInfoSignature is a signature of a build-info. It's either embedded in the build-info, or detached from it.
+type BuildInfoSignature struct {
+	Type BuildInfoSignatureType `json:"type"`
+	// The ASCII-armored GPG signature, or the base64-encoded Sigstore signature.
+	Signature string `json:"signature"`
+	// The PEM-encoded certificate of a Sigstore signature, which binds the signing key to the identity of the signer.
+	Certificate string `json:"certificate,omitempty"`
+}
+
+// BuildInfoSigner signs the content of build-infos.
+type BuildInfoSigner interface {
+	Sign(content []byte) (*BuildInfoSignature, error)
+}
+
+// BuildInfoVerifier verifies signatures of a single type.
+type BuildInfoVerifier interface {
+	Type() BuildInfoSignatureType
+	Verify(content []byte, signature *BuildInfoSignature) error
+}
+
+// GetSignedContent returns the content of the build-info, which its signatures sign. This is its JSON, without the embedded signatures.
+// Fields, which this library doesn't know, aren't included, so a build-info, which has such fields, should be signed and verified as a file, with detached signatures.
+func (targetBuildInfo *BuildInfo) GetSignedContent() ([]byte, error) {
+	unsigned := *targetBuildInfo
+	unsigned.Signatures = nil
+	return json.Marshal(&unsigned)
+}
+
+// SignDetached returns a signature of the build-info, which isn't embedded in it.
+func (targetBuildInfo *BuildInfo) SignDetached(signer BuildInfoSigner) (*BuildInfoSignature, error) {
+	content, err := targetBuildInfo.GetSignedContent()
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(content)
+}
+
+// Sign embeds a signature of each signer in the build-info. The signatures, which are already embedded, are kept, and don't affect the new signatures.
+func (targetBuildInfo *BuildInfo) Sign(signers ...BuildInfoSigner) error {
+	if len(signers) == 0 {
+		return errors.New("at least one signer must be provided in order to sign the build-info")
+	}
+	for _, signer := range signers {
+		signature, err := targetBuildInfo.SignDetached(signer)
+		if err != nil {
+			return err
+		}
+		targetBuildInfo.Signatures = append(targetBuildInfo.Signatures, *signature)
+	}
+	return nil
+}
+
+// VerifyDetached verifies a signature of the build-info, which isn't embedded in it.
+func (targetBuildInfo *BuildInfo) VerifyDetached(verifier BuildInfoVerifier, signature *BuildInfoSignature) error {
+	if signature.Type != verifier.Type() {
+		return fmt.Errorf("a %s signature can't be verified by a %s verifier", signature.Type, verifier.Type())
+	}
+	content, err := targetBuildInfo.GetSignedContent()
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(content, signature)
+}
+
+// Verify checks that at least one of the signatures, which are embedded in the build-info and have the type of the verifier, is valid.
+func (targetBuildInfo *BuildInfo) Verify(verifier BuildInfoVerifier) error {
+	var failures []string
+	for i := range targetBuildInfo.Signatures {
+		if targetBuildInfo.Signatures[i].Type != verifier.Type() {
+			continue
+		}
+		err := targetBuildInfo.VerifyDetached(verifier, &targetBuildInfo.Signatures[i])
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, err.Error())
+	}
+	if len(failures) == 0 {
+		return fmt.Errorf("the build-info has no %s signatures", verifier.Type())
+	}
+	return errors.New("none of the signatures of the build-info could be verified:\n" + strings.Join(failures, "\n"))
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Signs the content by prefixing it with its key, so a signature is valid only for the same key and content.
+type prefixSigner struct {
+	key string
+}
+
+func (ps *prefixSigner) Sign(content []byte) (*BuildInfoSignature, error) {
+	return &BuildInfoSignature{Type: GpgSignature, Signature: ps.key + string(content)}, nil
+}
+
+func (ps *prefixSigner) Type() BuildInfoSignatureType {
+	return GpgSignature
+}
+
+func (ps *prefixSigner) Verify(content []byte, signature *BuildInfoSignature) error {
+	if signature.Signature != ps.key+string(content) {
+		return errors.New("invalid signature of " + ps.key)
+	}
+	return nil
+}
+
+func TestSignAndVerify(t *testing.T) {
+	buildInfo := &BuildInfo{Name: "my-build", Number: "7", Modules: []Module{{Id: "web", Type: Npm}}}
+	assert.NoError(t, buildInfo.Sign(&prefixSigner{key: "alice"}, &prefixSigner{key: "bob"}))
+	assert.Len(t, buildInfo.Signatures, 2)
+	// The embedded signatures don't affect the signed content, so both signatures sign the same content.
+	assert.NoError(t, buildInfo.Verify(&prefixSigner{key: "bob"}))
+	assert.ErrorContains(t, buildInfo.Verify(&prefixSigner{key: "eve"}), "none of the signatures")
+
+	buildInfo.Modules[0].Id = "api"
+	assert.Error(t, buildInfo.Verify(&prefixSigner{key: "alice"}))
+
+	unsigned := &BuildInfo{Name: "my-build"}
+	assert.ErrorContains(t, unsigned.Verify(&prefixSigner{key: "alice"}), "has no gpg signatures")
+	assert.Error(t, unsigned.Sign())
+}
+
+func TestSignDetached(t *testing.T) {
+	buildInfo := &BuildInfo{Name: "my-build", Number: "7"}
+	signature, err := buildInfo.SignDetached(&prefixSigner{key: "alice"})
+	assert.NoError(t, err)
+	assert.Empty(t, buildInfo.Signatures)
+	assert.NoError(t, buildInfo.VerifyDetached(&prefixSigner{key: "alice"}, signature))
+
+	signature.Type = SigstoreSignature
+	assert.ErrorContains(t, buildInfo.VerifyDetached(&prefixSigner{key: "alice"}, signature), "can't be verified by a gpg verifier")
+}
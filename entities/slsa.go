@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"time"
+)
+
+const (
+	SlsaProvenancePredicateType = "https://slsa.dev/provenance/v1"
+	// The build type of the provenance, which is generated from build-info. It determines the meaning of the external and internal parameters.
+	SlsaBuildType = "https://github.com/jfrog/build-info-go/slsa/build-info/v1"
+)
+
+// SlsaProvenance is a SLSA v1 build provenance predicate.
+type SlsaProvenance struct {
+	BuildDefinition SlsaBuildDefinition `json:"buildDefinition"`
+	RunDetails      SlsaRunDetails      `json:"runDetails"`
+}
+
+type SlsaBuildDefinition struct {
+	BuildType            string                     `json:"buildType"`
+	ExternalParameters   map[string]interface{}     `json:"externalParameters"`
+	InternalParameters   map[string]interface{}     `json:"internalParameters,omitempty"`
+	ResolvedDependencies []InTotoResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type SlsaRunDetails struct {
+	Builder  SlsaBuilder        `json:"builder"`
+	Metadata *SlsaBuildMetadata `json:"metadata,omitempty"`
+}
+
+type SlsaBuilder struct {
+	Id      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type SlsaBuildMetadata struct {
+	InvocationId string `json:"invocationId,omitempty"`
+	StartedOn    string `json:"startedOn,omitempty"`
+	FinishedOn   string `json:"finishedOn,omitempty"`
+}
+
+// ToSlsaProvenance converts the build-info to an in-toto statement with a SLSA v1 build provenance predicate.
+// The artifacts of the modules are the subjects of the statement (artifacts without checksums are skipped), and the dependencies and the VCS revisions are its resolved dependencies.
+// builderId identifies the platform, which ran the build. Pass a zero finishedOn if the time the build finished at is unknown.
+func (targetBuildInfo *BuildInfo) ToSlsaProvenance(builderId string, finishedOn time.Time) *InTotoStatement {
+	provenance := &SlsaProvenance{
+		BuildDefinition: SlsaBuildDefinition{
+			BuildType:          SlsaBuildType,
+			ExternalParameters: map[string]interface{}{"buildName": targetBuildInfo.Name, "buildNumber": targetBuildInfo.Number},
+		},
+		RunDetails: SlsaRunDetails{Builder: SlsaBuilder{Id: builderId}},
+	}
+	if len(targetBuildInfo.VcsList) > 0 {
+		provenance.BuildDefinition.ExternalParameters["vcs"] = targetBuildInfo.VcsList
+	}
+	internalParameters := make(map[string]interface{})
+	if targetBuildInfo.Principal != "" {
+		internalParameters["principal"] = targetBuildInfo.Principal
+	}
+	if targetBuildInfo.Agent != nil && targetBuildInfo.Agent.Name != "" {
+		internalParameters["agent"] = targetBuildInfo.Agent
+	}
+	if len(internalParameters) > 0 {
+		provenance.BuildDefinition.InternalParameters = internalParameters
+	}
+	if buildAgent := targetBuildInfo.BuildAgent; buildAgent != nil && buildAgent.Name != "" && buildAgent.Version != "" {
+		provenance.RunDetails.Builder.Version = map[string]string{buildAgent.Name: buildAgent.Version}
+	}
+
+	metadata := &SlsaBuildMetadata{InvocationId: targetBuildInfo.BuildUrl, StartedOn: getRfc3339Timestamp(targetBuildInfo.Started)}
+	if !finishedOn.IsZero() {
+		metadata.FinishedOn = finishedOn.UTC().Format(time.RFC3339)
+	}
+	if *metadata != (SlsaBuildMetadata{}) {
+		provenance.RunDetails.Metadata = metadata
+	}
+
+	for _, vcs := range targetBuildInfo.VcsList {
+		if vcs.Url == "" || vcs.Revision == "" {
+			continue
+		}
+		resolvedDependency := InTotoResourceDescriptor{Uri: "git+" + vcs.Url, Digest: map[string]string{"gitCommit": vcs.Revision}}
+		if vcs.Branch != "" {
+			resolvedDependency.Uri += "@refs/heads/" + vcs.Branch
+		}
+		provenance.BuildDefinition.ResolvedDependencies = append(provenance.BuildDefinition.ResolvedDependencies, resolvedDependency)
+	}
+
+	statement := targetBuildInfo.NewInTotoStatement(SlsaProvenancePredicateType, provenance)
+	addedDependencies := make(map[string]bool)
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		for _, dependency := range module.Dependencies {
+			key := dependency.Id + "\n" + dependency.Sha1
+			if addedDependencies[key] {
+				continue
+			}
+			addedDependencies[key] = true
+			provenance.BuildDefinition.ResolvedDependencies = append(provenance.BuildDefinition.ResolvedDependencies, InTotoResourceDescriptor{Name: dependency.Id, Digest: toInTotoDigest(dependency.Checksum)})
+		}
+	}
+	return statement
+}
+
+// Returns the digests of the checksum, by the names of their algorithms, as defined by in-toto.
+func toInTotoDigest(checksum Checksum) map[string]string {
+	digest := make(map[string]string)
+	for algorithm, value := range map[string]string{"sha512": checksum.Sha512, "sha256": checksum.Sha256, "sha1": checksum.Sha1, "md5": checksum.Md5} {
+		if value != "" {
+			digest[algorithm] = value
+		}
+	}
+	if len(digest) == 0 {
+		return nil
+	}
+	return digest
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSlsaProvenance(t *testing.T) {
+	buildInfo := &BuildInfo{
+		Name:       "my-build",
+		Number:     "1",
+		Started:    "2023-01-01T10:00:00.000+0000",
+		BuildUrl:   "https://ci.example.com/jobs/1",
+		Principal:  "admin",
+		BuildAgent: &Agent{Name: "GENERIC", Version: "2.0"},
+		VcsList:    []Vcs{{Url: "https://github.com/org/repo.git", Revision: "abc123", Branch: "main"}, {Url: "https://github.com/org/no-revision.git"}},
+		Modules: []Module{
+			{
+				Id:        "my-module",
+				Artifacts: []Artifact{{Name: "a.zip", Checksum: Checksum{Sha1: "a1", Sha256: "a256"}}, {Name: "no-checksum.zip"}},
+				Dependencies: []Dependency{
+					{Id: "dep1:1.0", Checksum: Checksum{Sha1: "d1"}},
+					{Id: "dep2:1.0"},
+				},
+			},
+			{
+				Id:           "other-module",
+				Artifacts:    []Artifact{{Name: "b.zip", Checksum: Checksum{Md5: "b5"}}},
+				Dependencies: []Dependency{{Id: "dep1:1.0", Checksum: Checksum{Sha1: "d1"}}},
+			},
+		},
+	}
+
+	statement := buildInfo.ToSlsaProvenance("https://ci.example.com/builder", time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC))
+	assert.Equal(t, InTotoStatementType, statement.Type)
+	assert.Equal(t, SlsaProvenancePredicateType, statement.PredicateType)
+	assert.Equal(t, []InTotoResourceDescriptor{
+		{Name: "a.zip", Digest: map[string]string{"sha1": "a1", "sha256": "a256"}},
+		{Name: "b.zip", Digest: map[string]string{"md5": "b5"}},
+	}, statement.Subject)
+
+	provenance := statement.Predicate.(*SlsaProvenance)
+	assert.Equal(t, SlsaBuildType, provenance.BuildDefinition.BuildType)
+	assert.Equal(t, "my-build", provenance.BuildDefinition.ExternalParameters["buildName"])
+	assert.Equal(t, map[string]interface{}{"principal": "admin"}, provenance.BuildDefinition.InternalParameters)
+	assert.Equal(t, []InTotoResourceDescriptor{
+		{Uri: "git+https://github.com/org/repo.git@refs/heads/main", Digest: map[string]string{"gitCommit": "abc123"}},
+		{Name: "dep1:1.0", Digest: map[string]string{"sha1": "d1"}},
+		{Name: "dep2:1.0"},
+	}, provenance.BuildDefinition.ResolvedDependencies)
+	assert.Equal(t, SlsaBuilder{Id: "https://ci.example.com/builder", Version: map[string]string{"GENERIC": "2.0"}}, provenance.RunDetails.Builder)
+	assert.Equal(t, &SlsaBuildMetadata{InvocationId: "https://ci.example.com/jobs/1", StartedOn: "2023-01-01T10:00:00Z", FinishedOn: "2023-01-01T11:00:00Z"}, provenance.RunDetails.Metadata)
+
+	content, err := json.Marshal(statement)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"_type":"https://in-toto.io/Statement/v1"`)
+}
@@ -0,0 +1,373 @@
+package entities
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	SpdxVersion         = "SPDX-2.3"
+	SpdxDataLicense     = "CC0-1.0"
+	SpdxDocumentId      = "SPDXRef-DOCUMENT"
+	SpdxNoAssertion     = "NOASSERTION"
+	spdxNamespacePrefix = "https://spdx.org/spdxdocs/"
+
+	// The suffix of the dependency properties, which hold the licenses of the dependencies (for example, 'rpm.license').
+	SpdxLicensePropertySuffix = ".license"
+
+	SpdxRelationshipDescribes    = "DESCRIBES"
+	SpdxRelationshipContains     = "CONTAINS"
+	SpdxRelationshipDependsOn    = "DEPENDS_ON"
+	SpdxRelationshipDependencyOf = "DEPENDENCY_OF"
+)
+
+// The characters, which aren't allowed in SPDX identifiers.
+var spdxIdInvalidCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// SpdxDocument is an SPDX 2.3 document, converted from a build-info.
+type SpdxDocument struct {
+	SpdxVersion       string           `json:"spdxVersion"`
+	DataLicense       string           `json:"dataLicense"`
+	SpdxId            string           `json:"SPDXID"`
+	Name              string           `json:"name"`
+	DocumentNamespace string           `json:"documentNamespace"`
+	CreationInfo      SpdxCreationInfo `json:"creationInfo"`
+	// The elements, which the document describes. Documents may list them here, or use DESCRIBES relationships.
+	DocumentDescribes []string           `json:"documentDescribes,omitempty"`
+	Packages          []SpdxPackage      `json:"packages,omitempty"`
+	Files             []SpdxFile         `json:"files,omitempty"`
+	Relationships     []SpdxRelationship `json:"relationships,omitempty"`
+}
+
+type SpdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SpdxPackage struct {
+	SpdxId                string                `json:"SPDXID"`
+	Name                  string                `json:"name"`
+	VersionInfo           string                `json:"versionInfo,omitempty"`
+	DownloadLocation      string                `json:"downloadLocation"`
+	FilesAnalyzed         bool                  `json:"filesAnalyzed"`
+	VerificationCode      *SpdxVerificationCode `json:"packageVerificationCode,omitempty"`
+	Checksums             []SpdxChecksum        `json:"checksums,omitempty"`
+	LicenseConcluded      string                `json:"licenseConcluded"`
+	LicenseDeclared       string                `json:"licenseDeclared"`
+	CopyrightText         string                `json:"copyrightText"`
+	PrimaryPackagePurpose string                `json:"primaryPackagePurpose,omitempty"`
+	ExternalRefs          []SpdxExternalRef     `json:"externalRefs,omitempty"`
+	HasFiles              []string              `json:"hasFiles,omitempty"`
+}
+
+// SpdxExternalRef references a package in an external system, for example, by its package URL.
+type SpdxExternalRef struct {
+	Category string `json:"referenceCategory"`
+	Type     string `json:"referenceType"`
+	Locator  string `json:"referenceLocator"`
+}
+
+type SpdxVerificationCode struct {
+	Value string `json:"packageVerificationCodeValue"`
+}
+
+type SpdxFile struct {
+	SpdxId           string         `json:"SPDXID"`
+	FileName         string         `json:"fileName"`
+	Checksums        []SpdxChecksum `json:"checksums"`
+	LicenseConcluded string         `json:"licenseConcluded"`
+	CopyrightText    string         `json:"copyrightText"`
+}
+
+type SpdxChecksum struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"checksumValue"`
+}
+
+type SpdxRelationship struct {
+	Element        string `json:"spdxElementId"`
+	Type           string `json:"relationshipType"`
+	RelatedElement string `json:"relatedSpdxElement"`
+}
+
+// ToSpdx23Document converts the build-info to an SPDX 2.3 document.
+// The document describes a package, which represents the build, and contains a package for each module.
+// The artifacts of a module are the files of its package, and its package verification code is calculated from their SHA-1 checksums.
+// The dependencies are packages too, and the relationships between them are built from their RequestedBy fields.
+// The license of a dependency is taken from its Licenses field, if collected. Otherwise, it's taken from its properties, which end with '.license', if present, and normalized into an SPDX license expression.
+func (targetBuildInfo *BuildInfo) ToSpdx23Document() *SpdxDocument {
+	created := getRfc3339Timestamp(targetBuildInfo.Started)
+	if created == "" {
+		created = time.Now().UTC().Format(time.RFC3339)
+	}
+	document := &SpdxDocument{
+		SpdxVersion:       SpdxVersion,
+		DataLicense:       SpdxDataLicense,
+		SpdxId:            SpdxDocumentId,
+		Name:              targetBuildInfo.Name + "-" + targetBuildInfo.Number,
+		DocumentNamespace: spdxNamespacePrefix + spdxIdInvalidCharsRegex.ReplaceAllString(targetBuildInfo.Name, "-") + "-" + getBuildUuid(targetBuildInfo),
+		CreationInfo:      SpdxCreationInfo{Created: created, Creators: []string{"Tool: " + cycloneDxToolName}},
+	}
+
+	ids := newSpdxIdGenerator()
+	buildId := ids.get("Build", targetBuildInfo.Name+"-"+targetBuildInfo.Number)
+	document.Packages = append(document.Packages, SpdxPackage{
+		SpdxId:                buildId,
+		Name:                  targetBuildInfo.Name,
+		VersionInfo:           targetBuildInfo.Number,
+		DownloadLocation:      SpdxNoAssertion,
+		LicenseConcluded:      SpdxNoAssertion,
+		LicenseDeclared:       SpdxNoAssertion,
+		CopyrightText:         SpdxNoAssertion,
+		PrimaryPackagePurpose: "APPLICATION",
+	})
+	document.addRelationship(SpdxDocumentId, SpdxRelationshipDescribes, buildId)
+
+	// The SPDX identifiers of the modules and the dependencies, by their IDs.
+	packageIds := make(map[string]string)
+	var dependencies []Dependency
+	dependencyIndexes := make(map[string]int)
+	var directDependencies [][2]string
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		moduleId := ids.get("Module", module.Id)
+		packageIds[module.Id] = moduleId
+		modulePackage := newSpdxPackage(moduleId, module.Id, Checksum{}, nil, nil)
+		modulePackage.PrimaryPackagePurpose = "APPLICATION"
+		// SPDX requires the SHA-1 checksums of the files, so the artifacts are added only if all of their SHA-1 checksums are known.
+		if len(module.Artifacts) > 0 && slices.IndexFunc(module.Artifacts, func(artifact Artifact) bool { return artifact.Sha1 == "" }) < 0 {
+			var fileSha1s []string
+			for _, artifact := range module.Artifacts {
+				file := SpdxFile{
+					SpdxId:           ids.get("File", module.Id+"-"+artifact.Name),
+					FileName:         "./" + artifact.Name,
+					Checksums:        toSpdxChecksums(artifact.Checksum),
+					LicenseConcluded: SpdxNoAssertion,
+					CopyrightText:    SpdxNoAssertion,
+				}
+				document.Files = append(document.Files, file)
+				modulePackage.HasFiles = append(modulePackage.HasFiles, file.SpdxId)
+				fileSha1s = append(fileSha1s, artifact.Sha1)
+			}
+			modulePackage.FilesAnalyzed = true
+			modulePackage.VerificationCode = &SpdxVerificationCode{Value: getSpdxVerificationCode(fileSha1s)}
+		}
+		document.Packages = append(document.Packages, modulePackage)
+		document.addRelationship(buildId, SpdxRelationshipContains, moduleId)
+
+		for _, dependency := range module.Dependencies {
+			if dependency.Purl == "" {
+				dependency.Purl = GetPurl(module.Type, dependency.Id)
+			}
+			if len(dependency.RequestedBy) == 0 {
+				directDependencies = append(directDependencies, [2]string{module.Id, dependency.Id})
+			}
+			if index, exists := dependencyIndexes[dependency.Id]; exists {
+				dependencies[index] = mergeDependencies(dependencies[index], dependency)
+				continue
+			}
+			dependencyIndexes[dependency.Id] = len(dependencies)
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	for _, dependency := range dependencies {
+		if _, exists := packageIds[dependency.Id]; exists {
+			// A module, which is a dependency of another module.
+			continue
+		}
+		dependencyId := ids.get("Package", dependency.Id)
+		packageIds[dependency.Id] = dependencyId
+		dependencyPackage := newSpdxPackage(dependencyId, dependency.Id, dependency.Checksum, dependency.Properties, dependency.Licenses)
+		if dependency.Purl != "" {
+			dependencyPackage.ExternalRefs = []SpdxExternalRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: dependency.Purl}}
+		}
+		document.Packages = append(document.Packages, dependencyPackage)
+	}
+
+	dependsOn := make(map[string]map[string]bool)
+	addDependsOn := func(parentId, childId string) {
+		parentSpdxId, childSpdxId := packageIds[parentId], packageIds[childId]
+		// Parents, which aren't packages of the document, are skipped.
+		if parentSpdxId == "" || childSpdxId == "" {
+			return
+		}
+		if dependsOn[parentSpdxId] == nil {
+			dependsOn[parentSpdxId] = make(map[string]bool)
+		}
+		dependsOn[parentSpdxId][childSpdxId] = true
+	}
+	for _, directDependency := range directDependencies {
+		addDependsOn(directDependency[0], directDependency[1])
+	}
+	for _, dependency := range dependencies {
+		for _, requestedByPath := range dependency.RequestedBy {
+			if len(requestedByPath) > 0 {
+				addDependsOn(requestedByPath[0], dependency.Id)
+			}
+		}
+	}
+	parentIds := maps.Keys(dependsOn)
+	sort.Strings(parentIds)
+	for _, parentId := range parentIds {
+		childIds := maps.Keys(dependsOn[parentId])
+		sort.Strings(childIds)
+		for _, childId := range childIds {
+			document.addRelationship(parentId, SpdxRelationshipDependsOn, childId)
+		}
+	}
+	return document
+}
+
+// WriteTagValue writes the document in the SPDX tag-value format.
+func (document *SpdxDocument) WriteTagValue(writer io.Writer) error {
+	var content strings.Builder
+	writeTag := func(tag, value string) {
+		if value != "" {
+			content.WriteString(tag + ": " + value + "\n")
+		}
+	}
+	writeTag("SPDXVersion", document.SpdxVersion)
+	writeTag("DataLicense", document.DataLicense)
+	writeTag("SPDXID", document.SpdxId)
+	writeTag("DocumentName", document.Name)
+	writeTag("DocumentNamespace", document.DocumentNamespace)
+	for _, creator := range document.CreationInfo.Creators {
+		writeTag("Creator", creator)
+	}
+	writeTag("Created", document.CreationInfo.Created)
+
+	for _, spdxPackage := range document.Packages {
+		content.WriteString("\n")
+		writeTag("PackageName", spdxPackage.Name)
+		writeTag("SPDXID", spdxPackage.SpdxId)
+		writeTag("PackageVersion", spdxPackage.VersionInfo)
+		writeTag("PackageDownloadLocation", spdxPackage.DownloadLocation)
+		writeTag("FilesAnalyzed", fmt.Sprint(spdxPackage.FilesAnalyzed))
+		if spdxPackage.VerificationCode != nil {
+			writeTag("PackageVerificationCode", spdxPackage.VerificationCode.Value)
+		}
+		for _, checksum := range spdxPackage.Checksums {
+			writeTag("PackageChecksum", checksum.Algorithm+": "+checksum.Value)
+		}
+		writeTag("PackageLicenseConcluded", spdxPackage.LicenseConcluded)
+		writeTag("PackageLicenseDeclared", spdxPackage.LicenseDeclared)
+		writeTag("PackageCopyrightText", spdxPackage.CopyrightText)
+		writeTag("PrimaryPackagePurpose", spdxPackage.PrimaryPackagePurpose)
+		for _, externalRef := range spdxPackage.ExternalRefs {
+			writeTag("ExternalRef", externalRef.Category+" "+externalRef.Type+" "+externalRef.Locator)
+		}
+	}
+
+	for _, file := range document.Files {
+		content.WriteString("\n")
+		writeTag("FileName", file.FileName)
+		writeTag("SPDXID", file.SpdxId)
+		for _, checksum := range file.Checksums {
+			writeTag("FileChecksum", checksum.Algorithm+": "+checksum.Value)
+		}
+		writeTag("LicenseConcluded", file.LicenseConcluded)
+		writeTag("FileCopyrightText", file.CopyrightText)
+	}
+
+	if len(document.Relationships) > 0 {
+		content.WriteString("\n")
+	}
+	for _, relationship := range document.Relationships {
+		writeTag("Relationship", relationship.Element+" "+relationship.Type+" "+relationship.RelatedElement)
+	}
+	_, err := io.WriteString(writer, content.String())
+	return err
+}
+
+func (document *SpdxDocument) addRelationship(element, relationshipType, relatedElement string) {
+	document.Relationships = append(document.Relationships, SpdxRelationship{Element: element, Type: relationshipType, RelatedElement: relatedElement})
+}
+
+func newSpdxPackage(spdxId, id string, checksum Checksum, properties map[string]string, licenses []string) SpdxPackage {
+	spdxPackage := SpdxPackage{
+		SpdxId:           spdxId,
+		Name:             id,
+		DownloadLocation: SpdxNoAssertion,
+		Checksums:        toSpdxChecksums(checksum),
+		LicenseConcluded: SpdxNoAssertion,
+		LicenseDeclared:  SpdxNoAssertion,
+		CopyrightText:    SpdxNoAssertion,
+	}
+	if component, err := packageIdToCycloneDxComponent(id); err == nil {
+		spdxPackage.Name, spdxPackage.VersionInfo = component.Name, component.Version
+		if component.Group != "" {
+			spdxPackage.Name = component.Group + ":" + component.Name
+		}
+	}
+	if len(licenses) > 0 {
+		spdxPackage.LicenseDeclared = joinSpdxLicenses(licenses)
+	} else if license := getSpdxLicense(properties); license != "" {
+		spdxPackage.LicenseDeclared = NormalizeSpdxLicense(license)
+	}
+	return spdxPackage
+}
+
+// Returns the license of a dependency from its properties, or an empty string if it has none.
+// If several properties hold licenses, the first one (by name) is returned.
+func getSpdxLicense(properties map[string]string) string {
+	keys := maps.Keys(properties)
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.HasSuffix(key, SpdxLicensePropertySuffix) && properties[key] != "" {
+			return properties[key]
+		}
+	}
+	return ""
+}
+
+func toSpdxChecksums(checksum Checksum) (checksums []SpdxChecksum) {
+	for _, spdxChecksum := range []SpdxChecksum{{Algorithm: "SHA1", Value: checksum.Sha1}, {Algorithm: "SHA256", Value: checksum.Sha256}, {Algorithm: "SHA512", Value: checksum.Sha512}, {Algorithm: "MD5", Value: checksum.Md5}} {
+		if spdxChecksum.Value != "" {
+			checksums = append(checksums, spdxChecksum)
+		}
+	}
+	return
+}
+
+// Returns the package verification code of a package, as defined by the SPDX specification:
+// the SHA-1 of the sorted SHA-1 checksums of its files, concatenated.
+func getSpdxVerificationCode(fileSha1s []string) string {
+	sortedSha1s := append([]string{}, fileSha1s...)
+	for i := range sortedSha1s {
+		sortedSha1s[i] = strings.ToLower(sortedSha1s[i])
+	}
+	sort.Strings(sortedSha1s)
+	return fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(sortedSha1s, ""))))
+}
+
+// spdxIdGenerator generates unique SPDX identifiers from the IDs of the elements.
+type spdxIdGenerator struct {
+	used map[string]bool
+}
+
+func newSpdxIdGenerator() *spdxIdGenerator {
+	return &spdxIdGenerator{used: make(map[string]bool)}
+}
+
+// Returns an identifier in the 'SPDXRef-<kind>-<id>' format, in which the characters of the ID, which aren't allowed, are replaced.
+// A numeric suffix is added to identifiers, which were already returned.
+func (generator *spdxIdGenerator) get(kind, id string) string {
+	spdxId := "SPDXRef-" + kind + "-" + strings.Trim(spdxIdInvalidCharsRegex.ReplaceAllString(id, "-"), "-")
+	uniqueId := spdxId
+	for i := 2; generator.used[uniqueId]; i++ {
+		uniqueId = fmt.Sprintf("%s-%d", spdxId, i)
+	}
+	generator.used[uniqueId] = true
+	return uniqueId
+}
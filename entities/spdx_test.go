@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpdx23Document(t *testing.T) {
+	buildInfo := &BuildInfo{
+		Name:    "my-build",
+		Number:  "1",
+		Started: "2023-01-01T10:00:00.000+0000",
+		Modules: []Module{
+			{
+				Id:   "org:my-module:1.0",
+				Type: Maven,
+				Artifacts: []Artifact{
+					{Name: "my-module.jar", Checksum: Checksum{Sha1: "b2", Md5: "m1"}},
+					{Name: "my-module.pom", Checksum: Checksum{Sha1: "A1"}},
+				},
+				Dependencies: []Dependency{
+					{Id: "org:dep1:1.0", Checksum: Checksum{Sha1: "d1"}, Properties: map[string]string{"rpm.license": "MIT"}},
+					{Id: "org:dep2:2.0", RequestedBy: [][]string{{"org:dep1:1.0", "org:my-module:1.0"}}, Licenses: []string{"Apache-2.0", "MIT OR ISC"}},
+				},
+			},
+			{
+				Id:           "other-module",
+				Type:         Generic,
+				Artifacts:    []Artifact{{Name: "no-sha1.zip"}},
+				Dependencies: []Dependency{{Id: "org:my-module:1.0"}},
+			},
+		},
+	}
+
+	document := buildInfo.ToSpdx23Document()
+	assert.Equal(t, SpdxVersion, document.SpdxVersion)
+	assert.Equal(t, "2023-01-01T10:00:00Z", document.CreationInfo.Created)
+	assert.Equal(t, "https://spdx.org/spdxdocs/my-build-"+getBuildUuid(buildInfo), document.DocumentNamespace)
+
+	assert.Len(t, document.Packages, 5)
+	module := document.Packages[1]
+	assert.Equal(t, "SPDXRef-Module-org-my-module-1.0", module.SpdxId)
+	assert.Equal(t, "org:my-module", module.Name)
+	assert.Equal(t, "1.0", module.VersionInfo)
+	assert.True(t, module.FilesAnalyzed)
+	// SHA-1 of "a1b2"
+	assert.Equal(t, &SpdxVerificationCode{Value: "87f7ff9d1e933e7b40a6b46cc6a3c6663247d915"}, module.VerificationCode)
+	assert.Len(t, module.HasFiles, 2)
+	assert.Len(t, document.Files, 2)
+
+	// The artifacts of modules, some of whose SHA-1 checksums are unknown, aren't added.
+	assert.False(t, document.Packages[2].FilesAnalyzed)
+	assert.Empty(t, document.Packages[2].HasFiles)
+
+	dep1 := document.Packages[3]
+	assert.Equal(t, "SPDXRef-Package-org-dep1-1.0", dep1.SpdxId)
+	assert.Equal(t, "MIT", dep1.LicenseDeclared)
+	assert.Equal(t, SpdxNoAssertion, dep1.LicenseConcluded)
+	assert.Equal(t, []SpdxChecksum{{Algorithm: "SHA1", Value: "d1"}}, dep1.Checksums)
+	assert.Equal(t, "Apache-2.0 AND (MIT OR ISC)", document.Packages[4].LicenseDeclared)
+
+	assert.Equal(t, []SpdxRelationship{
+		{Element: SpdxDocumentId, Type: SpdxRelationshipDescribes, RelatedElement: "SPDXRef-Build-my-build-1"},
+		{Element: "SPDXRef-Build-my-build-1", Type: SpdxRelationshipContains, RelatedElement: "SPDXRef-Module-org-my-module-1.0"},
+		{Element: "SPDXRef-Build-my-build-1", Type: SpdxRelationshipContains, RelatedElement: "SPDXRef-Module-other-module"},
+		{Element: "SPDXRef-Module-org-my-module-1.0", Type: SpdxRelationshipDependsOn, RelatedElement: "SPDXRef-Package-org-dep1-1.0"},
+		{Element: "SPDXRef-Module-other-module", Type: SpdxRelationshipDependsOn, RelatedElement: "SPDXRef-Module-org-my-module-1.0"},
+		{Element: "SPDXRef-Package-org-dep1-1.0", Type: SpdxRelationshipDependsOn, RelatedElement: "SPDXRef-Package-org-dep2-2.0"},
+	}, document.Relationships)
+
+	var content bytes.Buffer
+	assert.NoError(t, document.WriteTagValue(&content))
+	assert.Contains(t, content.String(), "SPDXVersion: SPDX-2.3\n")
+	assert.Contains(t, content.String(), "PackageVerificationCode: 87f7ff9d1e933e7b40a6b46cc6a3c6663247d915\n")
+	assert.Contains(t, content.String(), "PackageLicenseDeclared: MIT\n")
+	assert.Contains(t, content.String(), "Relationship: SPDXRef-Package-org-dep1-1.0 DEPENDS_ON SPDXRef-Package-org-dep2-2.0\n")
+}
+
+func TestSpdxIdGenerator(t *testing.T) {
+	ids := newSpdxIdGenerator()
+	assert.Equal(t, "SPDXRef-Package-github.com-dep-v1.0.0", ids.get("Package", "github.com/dep:v1.0.0"))
+	assert.Equal(t, "SPDXRef-Package-github.com-dep-v1.0.0-2", ids.get("Package", "github.com/dep@v1.0.0"))
+}
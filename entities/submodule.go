@@ -0,0 +1,70 @@
+package entities
+
+// AddSubModules adds child modules to the module. A sub-module, whose ID the module already has, is merged into the existing sub-module.
+func (m *Module) AddSubModules(subModules ...Module) {
+	appendModules(subModules, &m.Modules)
+}
+
+// GetAllDependencies returns the dependencies of the module and of all its sub-modules, recursively.
+// A dependency of several modules is returned once, with the scopes and the RequestedBy paths of all its occurrences.
+func (m *Module) GetAllDependencies() []Dependency {
+	var dependencies []Dependency
+	for _, module := range flattenModules([]Module{*m}) {
+		for _, dependency := range module.Dependencies {
+			exists := false
+			for i := range dependencies {
+				if dependency.Sha1 == dependencies[i].Sha1 && dependency.Id == dependencies[i].Id {
+					dependencies[i] = mergeDependencies(dependencies[i], dependency)
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+	return dependencies
+}
+
+// GetAllArtifacts returns the artifacts of the module and of all its sub-modules, recursively. The artifacts remain unique according to their checksum.
+func (m *Module) GetAllArtifacts() []Artifact {
+	var artifacts []Artifact
+	for _, module := range flattenModules([]Module{*m}) {
+		mergeArtifacts(&module.Artifacts, &artifacts)
+	}
+	return artifacts
+}
+
+// GetAllModules returns the modules of the build-info and all their sub-modules, recursively, in a flat list, in which each module is followed by its sub-modules.
+func (targetBuildInfo *BuildInfo) GetAllModules() []Module {
+	return flattenModules(targetBuildInfo.Modules)
+}
+
+// FlattenModules moves the sub-modules of all the modules to the top level of the build-info, for consumers which don't support nested modules.
+// Modules with identical IDs are merged.
+func (targetBuildInfo *BuildInfo) FlattenModules() {
+	var modules []Module
+	for _, module := range targetBuildInfo.GetAllModules() {
+		module.Modules = nil
+		appendModules([]Module{module}, &modules)
+	}
+	targetBuildInfo.Modules = modules
+}
+
+func flattenModules(modules []Module) []Module {
+	var flattened []Module
+	for _, module := range modules {
+		flattened = append(flattened, module)
+		flattened = append(flattened, flattenModules(module.Modules)...)
+	}
+	return flattened
+}
+
+// Calls the function with each of the modules and their sub-modules, recursively.
+func forEachModule(modules []Module, apply func(module *Module)) {
+	for i := range modules {
+		apply(&modules[i])
+		forEachModule(modules[i].Modules, apply)
+	}
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubModules(t *testing.T) {
+	artifactA := Artifact{Name: "a.jar", Checksum: Checksum{Sha1: "a-sha"}}
+	artifactB := Artifact{Name: "b.jar", Checksum: Checksum{Sha1: "b-sha"}}
+	parent := Module{Id: "parent", Type: Maven, Dependencies: []Dependency{{Id: "org:common:1.0", Scopes: []string{"compile"}}}}
+	parent.AddSubModules(
+		Module{Id: "child-a", Type: Maven, Artifacts: []Artifact{artifactA}, Dependencies: []Dependency{{Id: "org:common:1.0", Scopes: []string{"test"}}}},
+		Module{Id: "child-b", Type: Maven, Artifacts: []Artifact{artifactB}, Dependencies: []Dependency{{Id: "org:other:2.0"}}},
+	)
+	// A sub-module with an existing ID is merged.
+	parent.AddSubModules(Module{Id: "child-a", Type: Maven, Artifacts: []Artifact{artifactA}})
+	assert.Len(t, parent.Modules, 2)
+	assert.Len(t, parent.Modules[0].Artifacts, 1)
+
+	assert.Equal(t, []Dependency{
+		{Id: "org:common:1.0", Scopes: []string{"compile", "test"}},
+		{Id: "org:other:2.0"},
+	}, parent.GetAllDependencies())
+	assert.Equal(t, []Artifact{artifactA, artifactB}, parent.GetAllArtifacts())
+
+	buildInfo := &BuildInfo{Modules: []Module{parent, {Id: "child-b", Type: Maven, Dependencies: []Dependency{{Id: "org:third:3.0"}}}}}
+	var moduleIds []string
+	for _, module := range buildInfo.GetAllModules() {
+		moduleIds = append(moduleIds, module.Id)
+	}
+	assert.Equal(t, []string{"parent", "child-a", "child-b", "child-b"}, moduleIds)
+
+	buildInfo.SetPurls()
+	assert.Equal(t, "pkg:maven/org/other@2.0", buildInfo.Modules[0].Modules[1].Dependencies[0].Purl)
+
+	buildInfo.FlattenModules()
+	assert.Len(t, buildInfo.Modules, 3)
+	for _, module := range buildInfo.Modules {
+		assert.Empty(t, module.Modules)
+	}
+	// The top-level module with the ID of a sub-module is merged into it.
+	assert.Equal(t, "child-b", buildInfo.Modules[2].Id)
+	assert.Len(t, buildInfo.Modules[2].Dependencies, 2)
+}
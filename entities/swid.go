@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"encoding/xml"
+	"io"
+	"path"
+)
+
+const (
+	SwidNamespace        = "http://standards.iso.org/iso/19770/-2/2015/schema.xsd"
+	SwidTagFileExtension = ".swidtag"
+
+	// The namespaces of the hash algorithms of the files in the payload, from the IANA Named Information Hash Algorithm Registry.
+	swidSha256Namespace = "http://www.w3.org/2001/04/xmlenc#sha256"
+	swidSha1Namespace   = "http://www.w3.org/2000/09/xmldsig#sha1"
+)
+
+// SwidTag is an ISO/IEC 19770-2:2015 software identification tag, which identifies an artifact of the build.
+type SwidTag struct {
+	XMLName       xml.Name     `xml:"SoftwareIdentity"`
+	Xmlns         string       `xml:"xmlns,attr"`
+	XmlnsSha256   string       `xml:"xmlns:SHA256,attr,omitempty"`
+	XmlnsSha1     string       `xml:"xmlns:SHA1,attr,omitempty"`
+	Lang          string       `xml:"xml:lang,attr"`
+	Name          string       `xml:"name,attr"`
+	TagId         string       `xml:"tagId,attr"`
+	TagVersion    int          `xml:"tagVersion,attr"`
+	Version       string       `xml:"version,attr"`
+	VersionScheme string       `xml:"versionScheme,attr"`
+	Entities      []SwidEntity `xml:"Entity"`
+	Links         []SwidLink   `xml:"Link,omitempty"`
+	Meta          *SwidMeta    `xml:"Meta,omitempty"`
+	Payload       *SwidPayload `xml:"Payload,omitempty"`
+}
+
+type SwidEntity struct {
+	Name  string `xml:"name,attr"`
+	RegId string `xml:"regid,attr"`
+	Role  string `xml:"role,attr"`
+}
+
+type SwidLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type SwidMeta struct {
+	// The ID of the module, which produced the artifact.
+	Product  string `xml:"product,attr,omitempty"`
+	Revision string `xml:"revision,attr,omitempty"`
+}
+
+type SwidPayload struct {
+	Files []SwidFile `xml:"File"`
+}
+
+type SwidFile struct {
+	Name     string `xml:"name,attr"`
+	Location string `xml:"location,attr,omitempty"`
+	Sha256   string `xml:"SHA256:hash,attr,omitempty"`
+	Sha1     string `xml:"SHA1:hash,attr,omitempty"`
+}
+
+// ToSwidTags creates a SWID tag for each artifact of the build. The entity, which created the tags and the software, is identified by its name and its registration ID (a domain name, such as 'example.com').
+// The name of each tag is the name of its artifact, its version is the number of the build, and its tag ID is derived from the build and the artifact, so converting the same build-info always produces the same tag IDs.
+func (targetBuildInfo *BuildInfo) ToSwidTags(entityName, regId string) []SwidTag {
+	buildUuid := getBuildUuid(targetBuildInfo)
+	var tags []SwidTag
+	for _, module := range targetBuildInfo.GetAllModules() {
+		// Aggregated builds are not supported
+		if module.Type == Build {
+			continue
+		}
+		for _, artifact := range module.Artifacts {
+			tag := SwidTag{
+				Xmlns:         SwidNamespace,
+				Lang:          "en-US",
+				Name:          artifact.Name,
+				TagId:         getNameBasedUuid(buildUuid + "\n" + module.Id + "\n" + artifact.Name),
+				Version:       targetBuildInfo.Number,
+				VersionScheme: "unknown",
+				Entities:      []SwidEntity{{Name: entityName, RegId: regId, Role: "tagCreator softwareCreator"}},
+				Meta:          &SwidMeta{Product: module.Id, Revision: targetBuildInfo.Number},
+			}
+			if targetBuildInfo.BuildUrl != "" {
+				tag.Links = []SwidLink{{Href: targetBuildInfo.BuildUrl, Rel: "see-also"}}
+			}
+			file := SwidFile{Name: artifact.Name, Sha256: artifact.Sha256, Sha1: artifact.Sha1}
+			if artifact.Path != "" && path.Dir(artifact.Path) != "." {
+				file.Location = path.Dir(artifact.Path)
+			}
+			if file.Sha256 != "" {
+				tag.XmlnsSha256 = swidSha256Namespace
+			}
+			if file.Sha1 != "" {
+				tag.XmlnsSha1 = swidSha1Namespace
+			}
+			tag.Payload = &SwidPayload{Files: []SwidFile{file}}
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Write writes the tag as an XML document.
+func (tag *SwidTag) Write(writer io.Writer) error {
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(tag); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+// FileName returns the name of the file of the tag, which is its tag ID with the '.swidtag' extension.
+func (tag *SwidTag) FileName() string {
+	return tag.TagId + SwidTagFileExtension
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSwidTags(t *testing.T) {
+	buildInfo := &BuildInfo{
+		Name:     "my-build",
+		Number:   "7",
+		Started:  "2023-01-01T10:00:00.000+0000",
+		BuildUrl: "https://ci.example.com/my-build/7",
+		Modules: []Module{
+			{Id: "my-app", Type: Npm, Artifacts: []Artifact{
+				{Name: "my-app-1.0.0.tgz", Path: "dist/my-app-1.0.0.tgz", Checksum: Checksum{Sha256: "a256", Sha1: "a1"}},
+				{Name: "README.md"},
+			}},
+			{Id: "aggregated-build/1", Type: Build, Artifacts: []Artifact{{Name: "ignored"}}},
+		},
+	}
+	tags := buildInfo.ToSwidTags("Example Inc.", "example.com")
+	if !assert.Len(t, tags, 2) {
+		return
+	}
+	// The same build-info always produces the same tag IDs, which are unique for each artifact.
+	assert.Equal(t, tags[0].TagId, buildInfo.ToSwidTags("Example Inc.", "example.com")[0].TagId)
+	assert.NotEqual(t, tags[0].TagId, tags[1].TagId)
+	assert.Equal(t, tags[0].TagId+".swidtag", tags[0].FileName())
+
+	var content strings.Builder
+	assert.NoError(t, tags[0].Write(&content))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<SoftwareIdentity xmlns="http://standards.iso.org/iso/19770/-2/2015/schema.xsd" xmlns:SHA256="http://www.w3.org/2001/04/xmlenc#sha256" xmlns:SHA1="http://www.w3.org/2000/09/xmldsig#sha1" xml:lang="en-US" name="my-app-1.0.0.tgz" tagId="`+tags[0].TagId+`" tagVersion="0" version="7" versionScheme="unknown">
+  <Entity name="Example Inc." regid="example.com" role="tagCreator softwareCreator"></Entity>
+  <Link href="https://ci.example.com/my-build/7" rel="see-also"></Link>
+  <Meta product="my-app" revision="7"></Meta>
+  <Payload>
+    <File name="my-app-1.0.0.tgz" location="dist" SHA256:hash="a256" SHA1:hash="a1"></File>
+  </Payload>
+</SoftwareIdentity>
+`, content.String())
+
+	content.Reset()
+	assert.NoError(t, tags[1].Write(&content))
+	assert.Contains(t, content.String(), `<File name="README.md"></File>`)
+	assert.NotContains(t, content.String(), "xmlns:SHA256")
+}
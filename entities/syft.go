@@ -0,0 +1,196 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// The dependency properties, which hold the license of a package found by Syft, and the path of the file it was found in.
+	SyftLicenseProperty  = "syft.license"
+	SyftLocationProperty = "syft.location"
+
+	syftDependencyOfRelationship = "dependency-of"
+)
+
+// The module types of the package types of Syft. Packages of other types are added to generic modules.
+var syftModuleTypes = map[string]ModuleType{
+	"go-module":    Go,
+	"java-archive": Maven,
+	"npm":          Npm,
+	"python":       Python,
+	"dotnet":       Nuget,
+	"rust-crate":   Cargo,
+	"php-composer": Composer,
+	"gem":          Ruby,
+	"pod":          Cocoapods,
+	"swift":        Swift,
+	"conan":        Conan,
+	"dart-pub":     Pub,
+	"hex":          Mix,
+	"hackage":      Haskell,
+	"R-package":    R,
+	"apk":          Apk,
+	"deb":          Dpkg,
+	"rpm":          Rpm,
+	"lua-rock":     LuaRocks,
+	"binary":       Generic,
+}
+
+// SyftOutput is the output of Syft in its own JSON format ('syft -o json').
+type SyftOutput struct {
+	Artifacts             []SyftArtifact     `json:"artifacts"`
+	ArtifactRelationships []SyftRelationship `json:"artifactRelationships"`
+	Source                SyftSource         `json:"source"`
+}
+
+type SyftArtifact struct {
+	Id        string          `json:"id"`
+	Name      string          `json:"name"`
+	Version   string          `json:"version"`
+	Type      string          `json:"type"`
+	Purl      string          `json:"purl"`
+	Locations []SyftLocation  `json:"locations"`
+	Licenses  json.RawMessage `json:"licenses"`
+	Metadata  struct {
+		// The digests of Java archives.
+		Digest []struct {
+			Algorithm string `json:"algorithm"`
+			Value     string `json:"value"`
+		} `json:"digest"`
+	} `json:"metadata"`
+}
+
+type SyftLocation struct {
+	Path string `json:"path"`
+}
+
+type SyftRelationship struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+	Type   string `json:"type"`
+}
+
+type SyftSource struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// The type of the scanned source, for example: 'directory' or 'image'.
+	Type string `json:"type"`
+}
+
+// SyftJsonToModules converts the JSON output of Syft, for a directory or an image, to build-info modules.
+// The packages are grouped by their types, to a module per type, whose ID is '<name>/<Syft package type>'. Pass an empty name to use the name of the scanned source.
+// The RequestedBy fields of the dependencies are built from the 'dependency-of' relationships. Packages, which no other package depends on, are direct dependencies of their modules.
+func SyftJsonToModules(content []byte, name string) ([]Module, error) {
+	output := &SyftOutput{}
+	if err := json.Unmarshal(content, output); err != nil {
+		return nil, errors.New("failed to parse the Syft JSON output: " + err.Error())
+	}
+	if name == "" {
+		name = output.Source.Name
+	}
+	if name == "" {
+		return nil, errors.New("the Syft JSON output has no source name, so a name must be provided")
+	}
+
+	graphs := make(map[string]*sbomDependencyGraph)
+	artifactTypes := make(map[string]string)
+	for _, artifact := range output.Artifacts {
+		graph := graphs[artifact.Type]
+		if graph == nil {
+			graph = newSbomDependencyGraph()
+			graphs[artifact.Type] = graph
+		}
+		graph.addDependency(artifact.Id, syftArtifactToDependency(artifact))
+		artifactTypes[artifact.Id] = artifact.Type
+	}
+	for _, relationship := range output.ArtifactRelationships {
+		// The parent of a 'dependency-of' relationship is the dependency of its child.
+		if relationship.Type != syftDependencyOfRelationship || artifactTypes[relationship.Parent] != artifactTypes[relationship.Child] {
+			continue
+		}
+		if graph := graphs[artifactTypes[relationship.Child]]; graph != nil {
+			graph.addEdge(relationship.Child, relationship.Parent)
+		}
+	}
+
+	artifactTypesList := make([]string, 0, len(graphs))
+	for artifactType := range graphs {
+		artifactTypesList = append(artifactTypesList, artifactType)
+	}
+	sort.Strings(artifactTypesList)
+	var modules []Module
+	for _, artifactType := range artifactTypesList {
+		moduleType, ok := syftModuleTypes[artifactType]
+		if !ok {
+			moduleType = Generic
+		}
+		moduleId := name + "/" + artifactType
+		modules = append(modules, Module{Id: moduleId, Type: moduleType, Dependencies: graphs[artifactType].toDependencies(moduleId)})
+	}
+	return modules, nil
+}
+
+func syftArtifactToDependency(artifact SyftArtifact) Dependency {
+	dependency := Dependency{Id: getSbomPackageId("", artifact.Name, artifact.Version), Type: artifact.Type, Purl: artifact.Purl}
+	// Java archives are identified by their Maven coordinates, which are taken from their purls.
+	if strings.HasPrefix(artifact.Purl, "pkg:maven/") {
+		group, _, found := strings.Cut(strings.TrimPrefix(artifact.Purl, "pkg:maven/"), "/")
+		if unescapedGroup, err := url.PathUnescape(group); found && err == nil {
+			dependency.Id = getSbomPackageId(unescapedGroup, artifact.Name, artifact.Version)
+		}
+	}
+	for _, digest := range artifact.Metadata.Digest {
+		switch strings.ToLower(strings.ReplaceAll(digest.Algorithm, "-", "")) {
+		case "sha1":
+			dependency.Sha1 = digest.Value
+		case "sha256":
+			dependency.Sha256 = digest.Value
+		case "sha512":
+			dependency.Sha512 = digest.Value
+		case "md5":
+			dependency.Md5 = digest.Value
+		}
+	}
+	properties := make(map[string]string)
+	if license := getSyftLicense(artifact.Licenses); license != "" {
+		properties[SyftLicenseProperty] = license
+	}
+	if len(artifact.Locations) > 0 {
+		properties[SyftLocationProperty] = artifact.Locations[0].Path
+	}
+	if len(properties) > 0 {
+		dependency.Properties = properties
+	}
+	return dependency
+}
+
+// Returns the licenses of a package, joined by 'AND'.
+// Older versions of Syft list the licenses as strings, and newer versions as objects, which may include SPDX expressions.
+func getSyftLicense(rawLicenses json.RawMessage) string {
+	if len(rawLicenses) == 0 {
+		return ""
+	}
+	var licenses []string
+	if err := json.Unmarshal(rawLicenses, &licenses); err != nil {
+		var licenseObjects []struct {
+			Value          string `json:"value"`
+			SpdxExpression string `json:"spdxExpression"`
+		}
+		if err = json.Unmarshal(rawLicenses, &licenseObjects); err != nil {
+			return ""
+		}
+		licenses = nil
+		for _, license := range licenseObjects {
+			if license.SpdxExpression != "" {
+				licenses = append(licenses, license.SpdxExpression)
+			} else if license.Value != "" {
+				licenses = append(licenses, license.Value)
+			}
+		}
+	}
+	return strings.Join(licenses, " AND ")
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyftJsonToModules(t *testing.T) {
+	content := []byte(`{
+  "artifacts": [
+    {"id": "p1", "name": "github.com/pkg/errors", "version": "v0.9.1", "type": "go-module", "purl": "pkg:golang/github.com/pkg/errors@v0.9.1"},
+    {"id": "p2", "name": "musl", "version": "1.2.4-r1", "type": "apk", "licenses": ["MIT"]},
+    {"id": "p3", "name": "busybox", "version": "1.36.1-r2", "type": "apk"}
+  ],
+  "artifactRelationships": [
+    {"parent": "p2", "child": "p3", "type": "dependency-of"},
+    {"parent": "p1", "child": "p3", "type": "dependency-of"}
+  ],
+  "source": {"name": "alpine:3.18", "type": "image"}
+}`)
+	modules, err := SyftJsonToModules(content, "my-image")
+	assert.NoError(t, err)
+	assert.Equal(t, []Module{
+		{Id: "my-image/apk", Type: Apk, Dependencies: []Dependency{
+			{Id: "musl:1.2.4-r1", Type: "apk", Properties: map[string]string{SyftLicenseProperty: "MIT"}, RequestedBy: [][]string{{"busybox:1.36.1-r2", "my-image/apk"}}},
+			{Id: "busybox:1.36.1-r2", Type: "apk", RequestedBy: [][]string{{"my-image/apk"}}},
+		}},
+		// Relationships between packages of different types are ignored.
+		{Id: "my-image/go-module", Type: Go, Dependencies: []Dependency{
+			{Id: "github.com/pkg/errors:v0.9.1", Type: "go-module", Purl: "pkg:golang/github.com/pkg/errors@v0.9.1", RequestedBy: [][]string{{"my-image/go-module"}}},
+		}},
+	}, modules)
+
+	// The name of the scanned source is used when no name is provided.
+	modules, err = SyftJsonToModules(content, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "alpine:3.18/apk", modules[0].Id)
+
+	_, err = SyftJsonToModules([]byte(`{"artifacts": []}`), "")
+	assert.Error(t, err)
+	_, err = SyftJsonToModules([]byte(`not json`), "my-image")
+	assert.Error(t, err)
+}
+
+func TestGetSyftLicense(t *testing.T) {
+	tests := []struct {
+		licenses string
+		expected string
+	}{
+		{``, ""},
+		{`["MIT", "Apache-2.0"]`, "MIT AND Apache-2.0"},
+		{`[{"value": "GPL-2.0-only", "spdxExpression": "GPL-2.0-only"}, {"value": "Custom License"}]`, "GPL-2.0-only AND Custom License"},
+		{`"unexpected"`, ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getSyftLicense(json.RawMessage(test.licenses)))
+	}
+}
@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// SetTiming sets the times, at which the collection of the module started and finished, and its duration.
+func (m *Module) SetTiming(started, finished time.Time) {
+	m.Started = started.Format(TimeFormat)
+	m.Finished = finished.Format(TimeFormat)
+	m.DurationMillis = finished.Sub(started).Milliseconds()
+}
+
+// SetFinished sets the time, at which the build finished, and its duration since it started.
+// If the start time of the build is missing or malformed, the duration isn't set.
+func (targetBuildInfo *BuildInfo) SetFinished(finished time.Time) {
+	targetBuildInfo.Finished = finished.Format(TimeFormat)
+	if started, err := time.Parse(TimeFormat, targetBuildInfo.Started); err == nil {
+		targetBuildInfo.DurationMillis = finished.Sub(started).Milliseconds()
+	}
+}
+
+// A module, which was collected more than once (for example, by several commands), started when the first collection started, and finished when the last one finished.
+func mergeModulesTiming(merge *Module, into *Module) {
+	if merge.Started == "" {
+		return
+	}
+	if into.Started == "" {
+		into.Started, into.Finished, into.DurationMillis = merge.Started, merge.Finished, merge.DurationMillis
+		return
+	}
+	started, err := time.Parse(TimeFormat, into.Started)
+	if err != nil {
+		return
+	}
+	finished, err := time.Parse(TimeFormat, into.Finished)
+	if err != nil {
+		return
+	}
+	if mergeStarted, err := time.Parse(TimeFormat, merge.Started); err == nil && mergeStarted.Before(started) {
+		started = mergeStarted
+	}
+	if mergeFinished, err := time.Parse(TimeFormat, merge.Finished); err == nil && mergeFinished.After(finished) {
+		finished = mergeFinished
+	}
+	into.SetTiming(started, finished)
+}
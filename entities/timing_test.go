@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFinished(t *testing.T) {
+	started := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	buildInfo := &BuildInfo{Started: started.Format(TimeFormat)}
+	buildInfo.SetFinished(started.Add(90 * time.Second))
+	assert.Equal(t, "2024-03-01T10:01:30.000+0000", buildInfo.Finished)
+	assert.Equal(t, int64(90000), buildInfo.DurationMillis)
+
+	// Without a start time, the duration is unknown.
+	buildInfo = &BuildInfo{}
+	buildInfo.SetFinished(started)
+	assert.Equal(t, "2024-03-01T10:00:00.000+0000", buildInfo.Finished)
+	assert.Zero(t, buildInfo.DurationMillis)
+}
+
+func TestMergeModulesTiming(t *testing.T) {
+	started := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	into := Module{Id: "module-id"}
+	into.SetTiming(started.Add(time.Second), started.Add(3*time.Second))
+	merge := Module{Id: "module-id"}
+	merge.SetTiming(started, started.Add(2*time.Second))
+
+	mergeModules(&merge, &into)
+	assert.Equal(t, started.Format(TimeFormat), into.Started)
+	assert.Equal(t, started.Add(3*time.Second).Format(TimeFormat), into.Finished)
+	assert.Equal(t, int64(3000), into.DurationMillis)
+
+	// A module without timing takes the timing of the merged module.
+	into = Module{Id: "module-id"}
+	mergeModules(&merge, &into)
+	assert.Equal(t, merge.Started, into.Started)
+	assert.Equal(t, int64(2000), into.DurationMillis)
+}
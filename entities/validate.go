@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// The rules, which a build-info may violate.
+const (
+	MissingFieldViolation        ViolationRule = "missing-field"
+	InvalidTimeViolation         ViolationRule = "invalid-time"
+	InvalidChecksumViolation     ViolationRule = "invalid-checksum"
+	DuplicateModuleIdViolation   ViolationRule = "duplicate-module-id"
+	DanglingRequestedByViolation ViolationRule = "dangling-requested-by"
+)
+
+type ViolationRule string
+
+// Violation is a problem in a build-info, which would fail its publishing or mislead its consumers.
+type Violation struct {
+	Rule ViolationRule `json:"rule"`
+	// The path of the field in the build-info JSON, such as 'modules[0].dependencies[2].sha1'.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (violation Violation) String() string {
+	return violation.Path + ": " + violation.Message
+}
+
+var (
+	sha1Regex   = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+	md5Regex    = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+	sha256Regex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+	sha512Regex = regexp.MustCompile(`^[0-9a-fA-F]{128}$`)
+)
+
+// Validate checks the build-info for missing required fields (the name and number of the build, the IDs of the modules and dependencies, and the names of the artifacts and the paths of the deployed ones),
+// malformed times and checksums, modules with duplicate IDs (including sub-modules), and RequestedBy paths which reference neither the module nor one of its dependencies.
+// The dependencies of shared modules (see DeduplicateDependencies()) may be referenced by the RequestedBy paths of all the modules, and may be requested by all the modules.
+// It returns the violations it found, or nil if the build-info is valid.
+func Validate(buildInfo *BuildInfo) []Violation {
+	validator := &buildInfoValidator{moduleIdPaths: make(map[string]string), moduleIds: make(map[string]bool), sharedIds: make(map[string]bool)}
+	// The dependencies, which were hoisted to a shared module, are referenced by the RequestedBy paths of the dependencies of the modules they were hoisted from,
+	// and their own RequestedBy paths end with the IDs of these modules.
+	for _, module := range buildInfo.GetAllModules() {
+		validator.moduleIds[module.Id] = true
+		if module.Type == Shared {
+			for _, dependency := range module.Dependencies {
+				validator.sharedIds[dependency.Id] = true
+			}
+		}
+	}
+	if buildInfo.Name == "" {
+		validator.add(MissingFieldViolation, "name", "the build name is missing")
+	}
+	if buildInfo.Number == "" {
+		validator.add(MissingFieldViolation, "number", "the build number is missing")
+	}
+	validator.validateTime("started", buildInfo.Started)
+	validator.validateTime("finished", buildInfo.Finished)
+	validator.validateModules("modules", buildInfo.Modules)
+	return validator.violations
+}
+
+type buildInfoValidator struct {
+	violations []Violation
+	// The paths of the modules, by their IDs.
+	moduleIdPaths map[string]string
+	// The IDs of all the modules, and of the dependencies of the shared modules.
+	moduleIds map[string]bool
+	sharedIds map[string]bool
+}
+
+func (validator *buildInfoValidator) add(rule ViolationRule, path, message string) {
+	validator.violations = append(validator.violations, Violation{Rule: rule, Path: path, Message: message})
+}
+
+func (validator *buildInfoValidator) validateTime(path, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := time.Parse(TimeFormat, value); err != nil {
+		validator.add(InvalidTimeViolation, path, fmt.Sprintf("'%s' doesn't match the time format %s", value, TimeFormat))
+	}
+}
+
+func (validator *buildInfoValidator) validateModules(path string, modules []Module) {
+	for i, module := range modules {
+		modulePath := fmt.Sprintf("%s[%d]", path, i)
+		switch existingPath, exists := validator.moduleIdPaths[module.Id]; {
+		case module.Id == "":
+			validator.add(MissingFieldViolation, modulePath+".id", "the module ID is missing")
+		case exists:
+			validator.add(DuplicateModuleIdViolation, modulePath+".id", fmt.Sprintf("the module ID '%s' is also the ID of %s", module.Id, existingPath))
+		default:
+			validator.moduleIdPaths[module.Id] = modulePath
+		}
+		validator.validateTime(modulePath+".started", module.Started)
+		validator.validateTime(modulePath+".finished", module.Finished)
+		validator.validateChecksum(modulePath, module.Checksum)
+		validator.validateArtifacts(modulePath+".artifacts", module.Artifacts)
+		validator.validateArtifacts(modulePath+".excludedArtifacts", module.ExcludedArtifacts)
+		validator.validateDependencies(modulePath+".dependencies", module)
+		validator.validateModules(modulePath+".modules", module.Modules)
+	}
+}
+
+func (validator *buildInfoValidator) validateArtifacts(path string, artifacts []Artifact) {
+	for i, artifact := range artifacts {
+		artifactPath := fmt.Sprintf("%s[%d]", path, i)
+		if artifact.Name == "" {
+			validator.add(MissingFieldViolation, artifactPath+".name", "the artifact name is missing")
+		}
+		if artifact.OriginalDeploymentRepo != "" && artifact.Path == "" {
+			validator.add(MissingFieldViolation, artifactPath+".path", "the artifact path is missing, though its deployment repository is set")
+		}
+		validator.validateChecksum(artifactPath, artifact.Checksum)
+	}
+}
+
+func (validator *buildInfoValidator) validateDependencies(path string, module Module) {
+	// The elements of the RequestedBy paths are the IDs of the dependencies, and the ID of the module, which they end with.
+	ids := map[string]bool{module.Id: true}
+	for _, dependency := range module.Dependencies {
+		ids[dependency.Id] = true
+	}
+	for id := range validator.sharedIds {
+		ids[id] = true
+	}
+	if module.Type == Shared {
+		for id := range validator.moduleIds {
+			ids[id] = true
+		}
+	}
+	for i, dependency := range module.Dependencies {
+		dependencyPath := fmt.Sprintf("%s[%d]", path, i)
+		if dependency.Id == "" {
+			validator.add(MissingFieldViolation, dependencyPath+".id", "the dependency ID is missing")
+		}
+		validator.validateChecksum(dependencyPath, dependency.Checksum)
+		for j, requestedBy := range dependency.RequestedBy {
+			for k, id := range requestedBy {
+				if !ids[id] {
+					validator.add(DanglingRequestedByViolation, fmt.Sprintf("%s.requestedBy[%d][%d]", dependencyPath, j, k), fmt.Sprintf("'%s' is neither the module nor one of its dependencies", id))
+				}
+			}
+		}
+	}
+}
+
+func (validator *buildInfoValidator) validateChecksum(path string, checksum Checksum) {
+	for _, field := range []struct {
+		name  string
+		value string
+		regex *regexp.Regexp
+	}{{"sha1", checksum.Sha1, sha1Regex}, {"md5", checksum.Md5, md5Regex}, {"sha256", checksum.Sha256, sha256Regex}, {"sha512", checksum.Sha512, sha512Regex}} {
+		if field.value != "" && !field.regex.MatchString(field.value) {
+			validator.add(InvalidChecksumViolation, path+"."+field.name, fmt.Sprintf("'%s' isn't a valid %s checksum", field.value, field.name))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	sha1 := strings.Repeat("a", 40)
+	buildInfo := &BuildInfo{Name: "my-build", Number: "1", Started: "2024-03-01T10:00:00.000+0000", Modules: []Module{{
+		Id:        "my-app",
+		Type:      Npm,
+		Artifacts: []Artifact{{Name: "my-app.tgz", Checksum: Checksum{Sha1: sha1, Md5: strings.Repeat("b", 32), Sha256: strings.Repeat("c", 64)}}},
+		Dependencies: []Dependency{
+			{Id: "express:4.18.2", RequestedBy: [][]string{{"my-app"}}},
+			{Id: "body-parser:1.20.1", RequestedBy: [][]string{{"express:4.18.2", "my-app"}}},
+		},
+		Modules: []Module{{Id: "my-lib", Type: Npm}},
+	}}}
+	assert.Nil(t, Validate(buildInfo))
+
+	invalid := &BuildInfo{Number: "1", Started: "yesterday", Modules: []Module{
+		{
+			Id:           "my-app",
+			Artifacts:    []Artifact{{Checksum: Checksum{Sha1: "abc"}}, {Name: "my-app.tgz", OriginalDeploymentRepo: "npm-local"}},
+			Dependencies: []Dependency{{Id: "body-parser:1.20.1", RequestedBy: [][]string{{"express:4.18.2", "my-app"}}}, {Checksum: Checksum{Md5: sha1}}},
+		},
+		{Modules: []Module{{Id: "my-app"}}},
+	}}
+	assert.Equal(t, []Violation{
+		{Rule: MissingFieldViolation, Path: "name", Message: "the build name is missing"},
+		{Rule: InvalidTimeViolation, Path: "started", Message: "'yesterday' doesn't match the time format " + TimeFormat},
+		{Rule: MissingFieldViolation, Path: "modules[0].artifacts[0].name", Message: "the artifact name is missing"},
+		{Rule: InvalidChecksumViolation, Path: "modules[0].artifacts[0].sha1", Message: "'abc' isn't a valid sha1 checksum"},
+		{Rule: MissingFieldViolation, Path: "modules[0].artifacts[1].path", Message: "the artifact path is missing, though its deployment repository is set"},
+		{Rule: DanglingRequestedByViolation, Path: "modules[0].dependencies[0].requestedBy[0][0]", Message: "'express:4.18.2' is neither the module nor one of its dependencies"},
+		{Rule: MissingFieldViolation, Path: "modules[0].dependencies[1].id", Message: "the dependency ID is missing"},
+		{Rule: InvalidChecksumViolation, Path: "modules[0].dependencies[1].md5", Message: "'" + sha1 + "' isn't a valid md5 checksum"},
+		{Rule: MissingFieldViolation, Path: "modules[1].id", Message: "the module ID is missing"},
+		{Rule: DuplicateModuleIdViolation, Path: "modules[1].modules[0].id", Message: "the module ID 'my-app' is also the ID of modules[0]"},
+	}, Validate(invalid))
+}
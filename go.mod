@@ -13,6 +13,8 @@ require (
 	github.com/urfave/cli/v2 v2.11.2
 	github.com/xeipuuv/gojsonschema v1.2.0
 	golang.org/x/exp v0.0.0-20220827204233-334a2380cb91
+	golang.org/x/mod v0.8.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -24,5 +26,4 @@ require (
 	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
 	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
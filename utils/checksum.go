@@ -2,15 +2,20 @@ package utils
 
 import (
 	"bufio"
+	"bytes"
 	//#nosec G501 -- md5 is supported by Artifactory.
 	"crypto/md5"
 	//#nosec G505 -- sha1 is supported by Artifactory.
 	"crypto/sha1"
+	"crypto/sha512"
 	"fmt"
 	"hash"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 
+	"github.com/jfrog/build-info-go/entities"
 	"github.com/minio/sha256-simd"
 )
 
@@ -20,6 +25,7 @@ const (
 	MD5 Algorithm = iota
 	SHA1
 	SHA256
+	SHA512
 )
 
 var algorithmFunc = map[Algorithm]func() hash.Hash{
@@ -28,9 +34,25 @@ var algorithmFunc = map[Algorithm]func() hash.Hash{
 	SHA1: sha1.New,
 	// sha256-simd algorithm:
 	SHA256: sha256.New,
+	// Go native crypto algorithm, calculated only when requested:
+	SHA512: sha512.New,
 }
 
+// The algorithms, which are calculated when no algorithms are requested.
+var defaultAlgorithms = []Algorithm{MD5, SHA1, SHA256}
+
+// GetFileChecksums returns the MD5, SHA-1 and SHA-256 checksums of the file.
 func GetFileChecksums(filePath string) (md5, sha1, sha2 string, err error) {
+	checksum, err := GetFileChecksum(filePath, false)
+	if err != nil {
+		return
+	}
+	md5, sha1, sha2 = checksum.Md5, checksum.Sha1, checksum.Sha256
+	return
+}
+
+// GetFileChecksum returns the MD5, SHA-1 and SHA-256 checksums of the file, and its SHA-512 checksum if includeSha512 is true.
+func GetFileChecksum(filePath string, includeSha512 bool) (checksum entities.Checksum, err error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return
@@ -41,15 +63,75 @@ func GetFileChecksums(filePath string) (md5, sha1, sha2 string, err error) {
 			err = e
 		}
 	}()
-	checksumInfo, err := CalcChecksums(file)
+	checksumInfo, err := CalcChecksums(file, getChecksumAlgorithms(includeSha512)...)
 	if err != nil {
 		return
 	}
-	md5, sha1, sha2 = checksumInfo[MD5], checksumInfo[SHA1], checksumInfo[SHA256]
+	checksum = entities.Checksum{Md5: checksumInfo[MD5], Sha1: checksumInfo[SHA1], Sha256: checksumInfo[SHA256], Sha512: checksumInfo[SHA512]}
 	return
 }
 
+// GetDirChecksum returns the checksums of the content of the dir, which are calculated from a manifest of the files in the dir and its sub-dirs.
+// The manifest lists the SHA-256 checksum and the slash-separated relative path of each file, sorted by their paths, similarly to the 'h1:' hashes in go.sum files.
+// Hence, the checksums don't depend on the modification times, permissions or location of the files. The '.git' dirs and the symbolic links are skipped.
+func GetDirChecksum(dirPath string, includeSha512 bool) (entities.Checksum, error) {
+	filesPaths := make(map[string]string)
+	err := filepath.WalkDir(dirPath, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return err
+		}
+		filesPaths[filepath.ToSlash(relativePath)] = path
+		return nil
+	})
+	if err != nil {
+		return entities.Checksum{}, err
+	}
+	return calcManifestChecksum(filesPaths, includeSha512)
+}
+
+// Calculates the checksums of a manifest of the files. See GetDirChecksum().
+// filesPaths - Map of the relative paths of the files, which are listed in the manifest, to their actual paths.
+func calcManifestChecksum(filesPaths map[string]string, includeSha512 bool) (entities.Checksum, error) {
+	relativePaths := make([]string, 0, len(filesPaths))
+	for relativePath := range filesPaths {
+		relativePaths = append(relativePaths, relativePath)
+	}
+	sort.Strings(relativePaths)
+	var manifest bytes.Buffer
+	for _, relativePath := range relativePaths {
+		fileChecksum, err := GetFileChecksum(filesPaths[relativePath], false)
+		if err != nil {
+			return entities.Checksum{}, err
+		}
+		fmt.Fprintf(&manifest, "%s  %s\n", fileChecksum.Sha256, relativePath)
+	}
+	checksumInfo, err := CalcChecksums(&manifest, getChecksumAlgorithms(includeSha512)...)
+	if err != nil {
+		return entities.Checksum{}, err
+	}
+	return entities.Checksum{Md5: checksumInfo[MD5], Sha1: checksumInfo[SHA1], Sha256: checksumInfo[SHA256], Sha512: checksumInfo[SHA512]}, nil
+}
+
+// Returns the default algorithms, and SHA-512 if includeSha512 is true.
+func getChecksumAlgorithms(includeSha512 bool) []Algorithm {
+	if includeSha512 {
+		return append([]Algorithm{SHA512}, defaultAlgorithms...)
+	}
+	return defaultAlgorithms
+}
+
 // CalcChecksums calculates all hashes at once using AsyncMultiWriter. The file is therefore read only once.
+// If no algorithms are requested, the MD5, SHA-1 and SHA-256 checksums are calculated.
 func CalcChecksums(reader io.Reader, checksumType ...Algorithm) (map[Algorithm]string, error) {
 	hashes := getChecksumByAlgorithm(checksumType...)
 	var multiWriter io.Writer
@@ -79,10 +161,7 @@ func sumResults(hashes map[Algorithm]hash.Hash) map[Algorithm]string {
 func getChecksumByAlgorithm(checksumType ...Algorithm) map[Algorithm]hash.Hash {
 	hashes := map[Algorithm]hash.Hash{}
 	if len(checksumType) == 0 {
-		for k, v := range algorithmFunc {
-			hashes[k] = v()
-		}
-		return hashes
+		checksumType = defaultAlgorithms
 	}
 
 	for _, v := range checksumType {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"strings"
 )
@@ -13,8 +14,10 @@ type Command struct {
 	CmdName    string
 	CmdArgs    []string
 	Dir        string
-	StrWriter  io.WriteCloser
-	ErrWriter  io.WriteCloser
+	// Environment variables, which are added to the environment of the command, without changing the environment of the current process.
+	Env       map[string]string
+	StrWriter io.WriteCloser
+	ErrWriter io.WriteCloser
 }
 
 func NewCommand(executable, cmdName string, cmdArgs []string) *Command {
@@ -49,6 +52,12 @@ func (config *Command) GetCmd() (cmd *exec.Cmd) {
 	}
 	cmd = exec.Command(config.Executable, cmdStr...)
 	cmd.Dir = config.Dir
+	if len(config.Env) > 0 {
+		cmd.Env = os.Environ()
+		for key, value := range config.Env {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+	}
 	return
 }
 
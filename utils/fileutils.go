@@ -2,14 +2,18 @@ package utils
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"golang.org/x/exp/slices"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -227,6 +231,141 @@ func ListFilesByFilterFunc(path string, filterFunc func(filePath string) (bool,
 	return fileList, nil
 }
 
+// ListFilesByGlob returns the files, which match the glob pattern, sorted by their paths. Besides the patterns of filepath.Match, a '**' path element matches any number of directories (for example, 'dist/**/*.zip').
+// The directories, which the pattern matches, aren't returned.
+func ListFilesByGlob(pattern string) ([]string, error) {
+	elements := strings.Split(filepath.ToSlash(filepath.Clean(pattern)), "/")
+	// Validate the pattern, before walking the directories.
+	for _, element := range elements {
+		if _, err := filepath.Match(element, ""); err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid glob pattern: %w", pattern, err)
+		}
+	}
+	// Only the directory, in which the pattern starts to have wildcards, is walked.
+	baseElements := 0
+	for baseElements < len(elements)-1 && !hasGlobMeta(elements[baseElements]) {
+		baseElements++
+	}
+	baseDir := strings.Join(elements[:baseElements], "/")
+	if baseDir == "" && baseElements > 0 {
+		// An absolute pattern.
+		baseDir = "/"
+	} else if baseDir == "" {
+		baseDir = "."
+	}
+	baseDir = filepath.FromSlash(baseDir)
+	if !IsPathExists(baseDir) {
+		return nil, nil
+	}
+	var files []string
+	err := filepath.WalkDir(baseDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(baseDir, filePath)
+		if err != nil {
+			return err
+		}
+		if matchGlobElements(elements[baseElements:], strings.Split(filepath.ToSlash(relativePath), "/")) {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func hasGlobMeta(element string) bool {
+	return strings.ContainsAny(element, `*?[\`)
+}
+
+// Matches the elements of a path to the elements of a glob pattern, where '**' matches any number of path elements.
+func matchGlobElements(patternElements, pathElements []string) bool {
+	if len(patternElements) == 0 {
+		return len(pathElements) == 0
+	}
+	if patternElements[0] == "**" {
+		for i := 0; i <= len(pathElements); i++ {
+			if matchGlobElements(patternElements[1:], pathElements[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(pathElements) == 0 {
+		return false
+	}
+	// The pattern was validated, so there's no error.
+	match, _ := filepath.Match(patternElements[0], pathElements[0])
+	return match && matchGlobElements(patternElements[1:], pathElements[1:])
+}
+
+// The first bytes of gzip-compressed content.
+var gzipMagicBytes = []byte{0x1f, 0x8b}
+
+// ReadFileDecompressed returns the content of the file. If the file is compressed by gzip, its decompressed content is returned.
+func ReadFileDecompressed(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil || !bytes.HasPrefix(content, gzipMagicBytes) {
+		return content, err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// Reading the content fully verifies its checksum, so closing the reader can't fail.
+		_ = reader.Close()
+	}()
+	return io.ReadAll(reader)
+}
+
+// OpenFileDecompressed opens the file for reading. If the file is compressed by gzip, its decompressed content is read.
+func OpenFileDecompressed(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(file)
+	if header, err := reader.Peek(len(gzipMagicBytes)); err != nil || !bytes.Equal(header, gzipMagicBytes) {
+		// Files, which are shorter than the header, aren't compressed.
+		return &readCloser{Reader: reader, closer: file}, nil
+	}
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		// The error of reading the file is more relevant than the error of closing it.
+		_ = file.Close()
+		return nil, err
+	}
+	return &readCloser{Reader: gzipReader, closer: file}, nil
+}
+
+// Reads from a reader, which wraps a file, and closes the file.
+type readCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (rc *readCloser) Close() error {
+	return rc.closer.Close()
+}
+
+// CompressGzip returns the content, compressed by gzip.
+func CompressGzip(content []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(content); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
 func DownloadFile(downloadTo string, fromUrl string) (err error) {
 	// Get the data
 	resp, err := http.Get(fromUrl)
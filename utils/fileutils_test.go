@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -54,3 +55,63 @@ func TestReadNLines(t *testing.T) {
 	assert.True(t, strings.HasPrefix(lines[1], "781"))
 	assert.True(t, strings.HasSuffix(lines[1], ":true}}}"))
 }
+
+func TestListFilesByGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"dist/app.zip", "dist/a/b/lib.zip", "dist/a/notes.txt", "other/app.zip"} {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(file), 0600))
+	}
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"dist/*.zip", []string{"dist/app.zip"}},
+		{"dist/**/*.zip", []string{"dist/a/b/lib.zip", "dist/app.zip"}},
+		{"**/app.zip", []string{"dist/app.zip", "other/app.zip"}},
+		{"dist/a/**", []string{"dist/a/b/lib.zip", "dist/a/notes.txt"}},
+		{"dist/app.zip", []string{"dist/app.zip"}},
+		{"missing/**/*.zip", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			files, err := ListFilesByGlob(filepath.Join(dir, test.pattern))
+			assert.NoError(t, err)
+			var expected []string
+			for _, file := range test.expected {
+				expected = append(expected, filepath.Join(dir, filepath.FromSlash(file)))
+			}
+			assert.Equal(t, expected, files)
+		})
+	}
+	_, err := ListFilesByGlob(filepath.Join(dir, "dist/[.zip"))
+	assert.Error(t, err)
+}
+
+func TestReadFileDecompressed(t *testing.T) {
+	content := []byte(`{"name":"build-info"}`)
+	plainPath := filepath.Join(t.TempDir(), "build-info.json")
+	assert.NoError(t, os.WriteFile(plainPath, content, 0600))
+	read, err := ReadFileDecompressed(plainPath)
+	assert.NoError(t, err)
+	assert.Equal(t, content, read)
+
+	compressed, err := CompressGzip(content)
+	assert.NoError(t, err)
+	assert.NotEqual(t, content, compressed)
+	compressedPath := filepath.Join(t.TempDir(), "build-info.json.gz")
+	assert.NoError(t, os.WriteFile(compressedPath, compressed, 0600))
+	read, err = ReadFileDecompressed(compressedPath)
+	assert.NoError(t, err)
+	assert.Equal(t, content, read)
+
+	for _, path := range []string{plainPath, compressedPath} {
+		reader, err := OpenFileDecompressed(path)
+		assert.NoError(t, err)
+		read, err = io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, content, read)
+		assert.NoError(t, reader.Close())
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GetBinaryImportedLibraries returns the names of the shared libraries, which the binary links dynamically (for example, 'libc.so.6'), sorted.
+// ELF, Mach-O and PE binaries are supported. A statically linked binary has no imported libraries.
+func GetBinaryImportedLibraries(binaryPath string) ([]string, error) {
+	var libraries []string
+	if elfFile, err := elf.Open(binaryPath); err == nil {
+		defer elfFile.Close()
+		if libraries, err = elfFile.ImportedLibraries(); err != nil {
+			return nil, err
+		}
+	} else if machoFile, err := macho.Open(binaryPath); err == nil {
+		defer machoFile.Close()
+		if libraries, err = machoFile.ImportedLibraries(); err != nil {
+			return nil, err
+		}
+	} else if peFile, err := pe.Open(binaryPath); err == nil {
+		defer peFile.Close()
+		if libraries, err = peFile.ImportedLibraries(); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("%s is not an ELF, Mach-O or PE binary", binaryPath)
+	}
+	// The names of the Windows DLLs are case-insensitive, and may be imported with different cases.
+	found := make(map[string]bool)
+	var importedLibraries []string
+	for _, library := range libraries {
+		if !found[strings.ToLower(library)] {
+			found[strings.ToLower(library)] = true
+			importedLibraries = append(importedLibraries, library)
+		}
+	}
+	sort.Strings(importedLibraries)
+	return importedLibraries, nil
+}
+
+// GetCgoPkgConfigPackages returns the pkg-config packages, which the cgo directives of the packages of the project and their dependencies use (for example, '#cgo pkg-config: openssl'), sorted.
+// No packages are returned if cgo is disabled, since the cgo files aren't compiled.
+func GetCgoPkgConfigPackages(projectDir string, constraints GoBuildConstraints, log Log) ([]string, error) {
+	cmdArgs, err := getListCmdArgs()
+	if err != nil {
+		return nil, err
+	}
+	cmdArgs = append(cmdArgs, "-deps")
+	cmdArgs = append(cmdArgs, constraints.listArgs()...)
+	output, err := runDependenciesCmdWithEnv(projectDir, append(cmdArgs, "-f", `{{join .CgoPkgConfig "\n"}}`, "./..."), constraints.env(), log)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	var packages []string
+	for _, line := range strings.Split(output, "\n") {
+		// The flags of pkg-config (such as '--static') may be listed with the packages.
+		pkg := strings.TrimSpace(line)
+		if pkg != "" && !strings.HasPrefix(pkg, "-") && !found[pkg] {
+			found[pkg] = true
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Strings(packages)
+	return packages, nil
+}
+
+// GetPkgConfigVersion returns the version of the pkg-config package, which is installed on the machine, by running 'pkg-config --modversion'.
+func GetPkgConfigVersion(pkg string) (string, error) {
+	output, err := NewCommand("pkg-config", "--modversion", []string{pkg}).RunWithOutput()
+	if err != nil {
+		return "", err
+	}
+	version := strings.TrimSpace(string(output))
+	if version == "" {
+		return "", errors.New("pkg-config returned no version for " + pkg)
+	}
+	return version, nil
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// GoPrivateSettings are the patterns of the module paths, which Go treats as private, as set by the GOPRIVATE, GONOPROXY and GONOSUMDB environment variables.
+// The patterns are comma-separated globs of path prefixes. GONOPROXY and GONOSUMDB default to GOPRIVATE.
+type GoPrivateSettings struct {
+	Private string
+	NoProxy string
+	NoSumDB string
+}
+
+// GetGoPrivateSettings returns the private modules settings of Go in the given dir, with the given environment variables added to the environment of the process.
+func GetGoPrivateSettings(projectDir string, env map[string]string) (GoPrivateSettings, error) {
+	goEnv, err := GetGoEnv(projectDir, env, "GOPRIVATE", "GONOPROXY", "GONOSUMDB")
+	if err != nil {
+		return GoPrivateSettings{}, err
+	}
+	return GoPrivateSettings{Private: goEnv["GOPRIVATE"], NoProxy: goEnv["GONOPROXY"], NoSumDB: goEnv["GONOSUMDB"]}, nil
+}
+
+// IsPrivate returns true if the module is private, which means that Go downloads it directly from its repository, or from a private proxy, instead of from the public proxy.
+func (gps GoPrivateSettings) IsPrivate(modulePath string) bool {
+	return module.MatchPrefixPatterns(gps.Private, modulePath) || module.MatchPrefixPatterns(gps.NoProxy, modulePath)
+}
+
+// IsNoSumDB returns true if Go doesn't verify the module against the checksum database, so the go.sum file is the only record of its hashes.
+func (gps GoPrivateSettings) IsNoSumDB(modulePath string) bool {
+	return module.MatchPrefixPatterns(gps.Private, modulePath) || module.MatchPrefixPatterns(gps.NoSumDB, modulePath)
+}
+
+// NetrcMachine is an entry of a .netrc file, which holds the credentials, which Go uses to authenticate to a host, such as a module proxy or the server of a private repository.
+type NetrcMachine struct {
+	Machine  string
+	Login    string
+	Password string
+}
+
+// CreateGoNetrcFile creates a .netrc file in the dir, and returns its path. The file lists the machines, followed by the content of the .netrc file,
+// which Go reads by default (the file set by NETRC in the given environment or in the environment of the process, or the .netrc file in the home dir), if it exists.
+// Since Go uses the first entry of a host, the given machines take precedence, and the credentials of other hosts keep working when NETRC is set to the created file.
+func CreateGoNetrcFile(dir string, machines []NetrcMachine, env map[string]string) (string, error) {
+	var content strings.Builder
+	for _, machine := range machines {
+		// The .netrc format doesn't support quoting, so the fields are separated by whitespace.
+		for _, field := range []string{machine.Machine, machine.Login, machine.Password} {
+			if field == "" || strings.ContainsAny(field, " \t\r\n") {
+				return "", fmt.Errorf("the .netrc credentials of '%s' must be non-empty and can't include whitespace", machine.Machine)
+			}
+		}
+		content.WriteString(fmt.Sprintf("machine %s login %s password %s\n", machine.Machine, machine.Login, machine.Password))
+	}
+	defaultNetrcPath, err := getDefaultNetrcPath(env)
+	if err != nil {
+		return "", err
+	}
+	if defaultNetrcPath != "" {
+		defaultContent, err := os.ReadFile(defaultNetrcPath)
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		content.Write(defaultContent)
+	}
+	netrcPath := filepath.Join(dir, ".netrc")
+	return netrcPath, os.WriteFile(netrcPath, []byte(content.String()), 0600)
+}
+
+// Returns the path of the .netrc file, which Go reads by default, as described in CreateGoNetrcFile().
+func getDefaultNetrcPath(env map[string]string) (string, error) {
+	if netrcPath, exists := env["NETRC"]; exists {
+		return netrcPath, nil
+	}
+	if netrcPath := os.Getenv("NETRC"); netrcPath != "" {
+		return netrcPath, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		// Go doesn't read a .netrc file without a home dir.
+		return "", nil
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(homeDir, "_netrc"), nil
+	}
+	return filepath.Join(homeDir, ".netrc"), nil
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoPrivateSettings(t *testing.T) {
+	settings := GoPrivateSettings{Private: "github.com/my-org/*,*.corp.example.com", NoProxy: "example.com/noproxy", NoSumDB: "example.com/nosumdb"}
+	assert.True(t, settings.IsPrivate("github.com/my-org/repo"))
+	assert.True(t, settings.IsPrivate("github.com/my-org/repo/v2"))
+	assert.True(t, settings.IsPrivate("git.corp.example.com/repo"))
+	assert.True(t, settings.IsPrivate("example.com/noproxy"))
+	assert.False(t, settings.IsPrivate("example.com/nosumdb"))
+	assert.False(t, settings.IsPrivate("github.com/other-org/repo"))
+
+	assert.True(t, settings.IsNoSumDB("github.com/my-org/repo"))
+	assert.True(t, settings.IsNoSumDB("example.com/nosumdb"))
+	assert.False(t, settings.IsNoSumDB("example.com/noproxy"))
+}
+
+func TestGetGoPrivateSettings(t *testing.T) {
+	// GONOPROXY and GONOSUMDB default to GOPRIVATE.
+	settings, err := GetGoPrivateSettings("", map[string]string{"GOPRIVATE": "github.com/my-org", "GONOPROXY": "", "GONOSUMDB": ""})
+	assert.NoError(t, err)
+	assert.Equal(t, GoPrivateSettings{Private: "github.com/my-org", NoProxy: "github.com/my-org", NoSumDB: "github.com/my-org"}, settings)
+}
+
+func TestCreateGoNetrcFile(t *testing.T) {
+	defaultNetrcPath := filepath.Join(t.TempDir(), ".netrc")
+	assert.NoError(t, os.WriteFile(defaultNetrcPath, []byte("machine github.com login user password token\n"), 0600))
+	machines := []NetrcMachine{{Machine: "proxy.example.com", Login: "admin", Password: "secret"}}
+	netrcPath, err := CreateGoNetrcFile(t.TempDir(), machines, map[string]string{"NETRC": defaultNetrcPath})
+	assert.NoError(t, err)
+	content, err := os.ReadFile(netrcPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "machine proxy.example.com login admin password secret\nmachine github.com login user password token\n", string(content))
+
+	// A missing default file is ignored.
+	netrcPath, err = CreateGoNetrcFile(t.TempDir(), machines, map[string]string{"NETRC": filepath.Join(t.TempDir(), ".netrc")})
+	assert.NoError(t, err)
+	content, err = os.ReadFile(netrcPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "machine proxy.example.com login admin password secret\n", string(content))
+
+	_, err = CreateGoNetrcFile(t.TempDir(), []NetrcMachine{{Machine: "proxy.example.com", Login: "admin", Password: "with space"}}, nil)
+	assert.Error(t, err)
+}
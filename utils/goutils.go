@@ -1,12 +1,18 @@
 package utils
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"runtime"
+	"strconv"
 
 	"github.com/jfrog/gofrog/version"
+	"golang.org/x/exp/slices"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
 
 	"os"
 	"path/filepath"
@@ -93,17 +99,69 @@ func getListCmdArgs() (cmdArgs []string, err error) {
 	return []string{"list", "-mod=mod"}, nil
 }
 
+// GoBuildConstraints are the build tags and the target platform, which 'go list' lists the packages for.
+// The empty fields keep the defaults of the Go command, which are the current OS and architecture, and no custom build tags.
+type GoBuildConstraints struct {
+	Tags   []string
+	GOOS   string
+	GOARCH string
+	// Environment variables (such as GOFLAGS or GOMODCACHE), which are added to the environment of the go commands. GOOS and GOARCH override the ones set here.
+	Env map[string]string
+}
+
+// Returns the 'go list' flags of the build tags.
+func (gbc *GoBuildConstraints) listArgs() []string {
+	if len(gbc.Tags) == 0 {
+		return nil
+	}
+	return []string{"-tags", strings.Join(gbc.Tags, ",")}
+}
+
+// Returns the environment variables of the go commands, including the ones of the target platform.
+func (gbc *GoBuildConstraints) env() map[string]string {
+	env := make(map[string]string)
+	for key, value := range gbc.Env {
+		env[key] = value
+	}
+	if gbc.GOOS != "" {
+		env["GOOS"] = gbc.GOOS
+	}
+	if gbc.GOARCH != "" {
+		env["GOARCH"] = gbc.GOARCH
+	}
+	return env
+}
+
+// Runs 'go build' for the package in the project dir, with the given build tags and for the given target platform, and writes the binary to outputPath.
+// buildArgs are added to the flags of the command, for example, '-trimpath'.
+func BuildGoBinary(projectDir, pkg, outputPath string, constraints GoBuildConstraints, buildArgs []string, log Log) error {
+	cmdArgs := append([]string{"-o", outputPath}, constraints.listArgs()...)
+	cmdArgs = append(append(cmdArgs, buildArgs...), pkg)
+	goCmd := NewCommand("go", "build", cmdArgs)
+	goCmd.Dir = projectDir
+	goCmd.Env = constraints.env()
+	log.Info(fmt.Sprintf("Running 'go build %s' in %s", strings.Join(cmdArgs, " "), projectDir))
+	_, err := goCmd.RunWithOutput()
+	return err
+}
+
 // Runs go list -f {{with .Module}}{{.Path}}:{{.Version}}{{end}} all command and returns map of the dependencies
 func GetDependenciesList(projectDir string, log Log) (map[string]bool, error) {
+	return GetConstrainedDependenciesList(projectDir, GoBuildConstraints{}, log)
+}
+
+// Like GetDependenciesList, but lists only the dependencies, which are compiled with the given build tags and for the given target platform.
+func GetConstrainedDependenciesList(projectDir string, constraints GoBuildConstraints, log Log) (map[string]bool, error) {
 	cmdArgs, err := getListCmdArgs()
 	if err != nil {
 		return nil, err
 	}
-	output, err := runDependenciesCmd(projectDir, append(cmdArgs, "-f", "{{with .Module}}{{.Path}}:{{.Version}}{{end}}", "all"), log)
+	cmdArgs = append(cmdArgs, constraints.listArgs()...)
+	output, err := runDependenciesCmdWithEnv(projectDir, append(cmdArgs, "-f", "{{with .Module}}{{.Path}}:{{.Version}}{{end}}", "all"), constraints.env(), log)
 	if err != nil {
 		// Errors occurred while running "go list". Run again and this time ignore errors (with '-e')
 		log.Warn("Errors occurred while building the Go dependency tree. The dependency tree may be incomplete:" + err.Error())
-		output, err = runDependenciesCmd(projectDir, append(cmdArgs, "-e", "-f", "{{with .Module}}{{.Path}}:{{.Version}}{{end}}", "all"), log)
+		output, err = runDependenciesCmdWithEnv(projectDir, append(cmdArgs, "-e", "-f", "{{with .Module}}{{.Path}}:{{.Version}}{{end}}", "all"), constraints.env(), log)
 		if err != nil {
 			return nil, err
 		}
@@ -111,17 +169,116 @@ func GetDependenciesList(projectDir string, log Log) (map[string]bool, error) {
 	return listToMap(output), err
 }
 
+// Runs 'go list -deps' command for the given packages and returns a map of the modules, which provide the packages and their dependencies.
+// Pass includeTests as true to add the '-test' flag, so that the dependencies of the packages' test binaries are included.
+func GetPackagesDependenciesList(projectDir string, packages []string, includeTests bool, log Log) (map[string]bool, error) {
+	return GetConstrainedPackagesDependenciesList(projectDir, packages, includeTests, GoBuildConstraints{}, log)
+}
+
+// Like GetPackagesDependenciesList, but lists only the dependencies, which are compiled with the given build tags and for the given target platform.
+func GetConstrainedPackagesDependenciesList(projectDir string, packages []string, includeTests bool, constraints GoBuildConstraints, log Log) (map[string]bool, error) {
+	cmdArgs, err := getListCmdArgs()
+	if err != nil {
+		return nil, err
+	}
+	cmdArgs = append(cmdArgs, "-deps")
+	if includeTests {
+		cmdArgs = append(cmdArgs, "-test")
+	}
+	cmdArgs = append(cmdArgs, constraints.listArgs()...)
+	cmdArgs = append(cmdArgs, "-f", "{{with .Module}}{{.Path}}:{{.Version}}{{end}}")
+	output, err := runDependenciesCmdWithEnv(projectDir, append(cmdArgs, packages...), constraints.env(), log)
+	if err != nil {
+		return nil, err
+	}
+	return listToMap(output), nil
+}
+
 // Runs 'go mod graph' command and returns map that maps dependencies to their child dependencies slice
 func GetDependenciesGraph(projectDir string, log Log) (map[string][]string, error) {
-	output, err := runDependenciesCmd(projectDir, []string{"mod", "graph"}, log)
+	return GetDependenciesGraphWithEnv(projectDir, nil, log)
+}
+
+// Like GetDependenciesGraph, but adds the given environment variables to the environment of the command.
+func GetDependenciesGraphWithEnv(projectDir string, env map[string]string, log Log) (map[string][]string, error) {
+	output, err := runDependenciesCmdWithEnv(projectDir, []string{"mod", "graph"}, env, log)
 	if err != nil {
 		return nil, err
 	}
 	return graphToMap(output), err
 }
 
+// Parses the go.work file in the given dir and returns the absolute dirs of the workspace modules, which are listed by its 'use' directives.
+func GetWorkspaceModulesDirs(workspaceDir string) ([]string, error) {
+	goWorkPath := filepath.Join(workspaceDir, "go.work")
+	content, err := os.ReadFile(goWorkPath)
+	if err != nil {
+		return nil, err
+	}
+	goWork, err := modfile.ParseWork(goWorkPath, content, nil)
+	if err != nil {
+		return nil, err
+	}
+	modulesDirs := make([]string, 0, len(goWork.Use))
+	for _, use := range goWork.Use {
+		moduleDir := use.Path
+		if !filepath.IsAbs(moduleDir) {
+			moduleDir = filepath.Join(workspaceDir, moduleDir)
+		}
+		if moduleDir, err = filepath.Abs(moduleDir); err != nil {
+			return nil, err
+		}
+		modulesDirs = append(modulesDirs, moduleDir)
+	}
+	return modulesDirs, nil
+}
+
+// Returns the module path, which is declared in the go.mod file in the given dir.
+// Unlike GetModuleNameByDir, it doesn't run 'go list', which lists all the modules of the workspace, when the dir belongs to one.
+func GetModulePathFromGoMod(moduleDir string) (string, error) {
+	goModPath := filepath.Join(moduleDir, "go.mod")
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return "", err
+	}
+	modulePath := modfile.ModulePath(content)
+	if modulePath == "" {
+		return "", fmt.Errorf("no module path was found in %s", goModPath)
+	}
+	return modulePath, nil
+}
+
+// Runs 'go list -deps' command on the packages of the workspace module in the given dir, and returns a map of the modules, which provide the packages and their dependencies.
+// Unlike 'go list all', which lists the dependencies of all the modules of the workspace, only the dependencies of the module's own packages are listed.
+// The other workspace modules, which the module uses, are listed without versions.
+// Pass includeTests as true to include the dependencies of the packages' tests. The dependencies are listed for the given build tags and target platform.
+func GetWorkspaceModuleDependenciesList(moduleDir string, includeTests bool, constraints GoBuildConstraints, log Log) (map[string]bool, error) {
+	// Workspaces don't allow '-mod=mod', so '-mod=readonly' overrides it, in case it's set by GOFLAGS.
+	cmdArgs := []string{"list", "-mod=readonly", "-deps"}
+	if includeTests {
+		cmdArgs = append(cmdArgs, "-test")
+	}
+	cmdArgs = append(cmdArgs, constraints.listArgs()...)
+	cmdArgs = append(cmdArgs, "-f", "{{with .Module}}{{.Path}}:{{.Version}}{{end}}")
+	output, err := runDependenciesCmdWithEnv(moduleDir, append(cmdArgs, "./..."), constraints.env(), log)
+	if err != nil {
+		// Errors occurred while running "go list". Run again and this time ignore errors (with '-e')
+		log.Warn("Errors occurred while building the Go dependency tree. The dependency tree may be incomplete:" + err.Error())
+		output, err = runDependenciesCmdWithEnv(moduleDir, append(cmdArgs, "-e", "./..."), constraints.env(), log)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return listToMap(output), nil
+}
+
 // Common function to run dependencies command for list or graph commands
 func runDependenciesCmd(projectDir string, commandArgs []string, log Log) (output string, err error) {
+	return runDependenciesCmdWithEnv(projectDir, commandArgs, nil, log)
+}
+
+// Like runDependenciesCmd, but adds the given environment variables to the environment of the command.
+func runDependenciesCmdWithEnv(projectDir string, commandArgs []string, env map[string]string, log Log) (output string, err error) {
 	log.Info(fmt.Sprintf("Running 'go %s' in %s", strings.Join(commandArgs, " "), projectDir))
 	if projectDir == "" {
 		projectDir, err = GetProjectRoot()
@@ -150,6 +307,7 @@ func runDependenciesCmd(projectDir string, commandArgs []string, log Log) (outpu
 	}
 	goCmd := NewCommand("go", "", commandArgs)
 	goCmd.Dir = projectDir
+	goCmd.Env = env
 
 	err = prepareGlobalRegExp()
 	if err != nil {
@@ -235,6 +393,24 @@ func getGoVersion() (string, error) {
 	return output, err
 }
 
+// GetGoEnv runs 'go env' in the given dir, and returns the values of the given Go environment variables.
+// The values are of the toolchain, which Go selects for the dir, which may differ from the installed toolchain, if the go.mod file in the dir requires a newer one.
+// env - Environment variables, which are added to the environment of the command, and may affect the returned values.
+func GetGoEnv(projectDir string, env map[string]string, names ...string) (map[string]string, error) {
+	goCmd := NewCommand("go", "env", append([]string{"-json"}, names...))
+	goCmd.Dir = projectDir
+	goCmd.Env = env
+	output, err := goCmd.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string)
+	if err = json.Unmarshal(output, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 // Compiles all the regex once
 func prepareGlobalRegExp() error {
 	var err error
@@ -342,3 +518,252 @@ func graphToMap(output string) map[string][]string {
 	}
 	return mapOfDeps
 }
+
+// Parses the go.mod file in the given path and returns a map of the required modules to their versions.
+func GetGoModRequirements(goModPath string) (map[string]string, error) {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	goMod, err := modfile.ParseLax(goModPath, content, nil)
+	if err != nil {
+		return nil, err
+	}
+	requirements := make(map[string]string, len(goMod.Require))
+	for _, require := range goMod.Require {
+		requirements[require.Mod.Path] = require.Mod.Version
+	}
+	return requirements, nil
+}
+
+// Parses the go.mod file in the given path and returns the paths of the modules, which it requires directly, without the '// indirect' comment.
+// These are the modules, which 'go list -m -json all' doesn't report as indirect, out of the requirements of the main module.
+func GetGoModDirectRequirements(goModPath string) (map[string]bool, error) {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	goMod, err := modfile.ParseLax(goModPath, content, nil)
+	if err != nil {
+		return nil, err
+	}
+	directRequirements := make(map[string]bool)
+	for _, require := range goMod.Require {
+		if !require.Indirect {
+			directRequirements[require.Mod.Path] = true
+		}
+	}
+	return directRequirements, nil
+}
+
+// Parses the go.mod file in the given path and returns the Go version, which its 'go' directive requires, and the toolchain, which its 'toolchain' directive suggests.
+// Each of them is empty if the file doesn't have its directive.
+func GetGoModToolchain(goModPath string) (goVersion, toolchain string, err error) {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return
+	}
+	goMod, err := modfile.ParseLax(goModPath, content, nil)
+	if err != nil {
+		return
+	}
+	if goMod.Go != nil && goMod.Go.Syntax != nil {
+		// The version is read from the original line, since the lax parsing truncates versions, such as '1.21.3', to their language versions.
+		goLine := strings.Fields(string(content[goMod.Go.Syntax.Start.Byte:goMod.Go.Syntax.End.Byte]))
+		if len(goLine) > 1 {
+			goVersion = goLine[1]
+		}
+	}
+	if toolchainLines := getGoModDirectiveLines(goMod, "toolchain"); len(toolchainLines) > 0 && len(toolchainLines[0]) > 0 {
+		toolchain = toolchainLines[0][0]
+	}
+	return
+}
+
+// GoReplacement is a 'replace' directive of a go.mod file.
+type GoReplacement struct {
+	OldPath string
+	// Empty if all the versions of the module are replaced.
+	OldVersion string
+	NewPath    string
+	// Empty if the module is replaced by a local dir, in which case NewPath is the path of the dir, relative to the dir of the go.mod file, or absolute.
+	NewVersion string
+}
+
+// IsLocal returns true if the module is replaced by a local dir.
+func (gr *GoReplacement) IsLocal() bool {
+	return gr.NewVersion == ""
+}
+
+// Parses the go.mod file in the given path and returns its 'replace' directives.
+func GetGoModReplacements(goModPath string) ([]GoReplacement, error) {
+	goMod, err := parseGoModLax(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	var replacements []GoReplacement
+	for _, tokens := range getGoModDirectiveLines(goMod, "replace") {
+		// The expected syntax : old/path [v1.2.3] => new/path [v1.2.4]
+		arrowIndex := slices.Index(tokens, "=>")
+		if arrowIndex < 1 || arrowIndex > 2 || len(tokens)-arrowIndex < 2 || len(tokens)-arrowIndex > 3 {
+			return nil, fmt.Errorf("%s: unexpected 'replace' directive: %s", goModPath, strings.Join(tokens, " "))
+		}
+		replacement := GoReplacement{OldPath: tokens[0], NewPath: tokens[arrowIndex+1]}
+		if arrowIndex == 2 {
+			replacement.OldVersion = tokens[1]
+		}
+		if len(tokens)-arrowIndex == 3 {
+			replacement.NewVersion = tokens[arrowIndex+2]
+		}
+		replacements = append(replacements, replacement)
+	}
+	return replacements, nil
+}
+
+func parseGoModLax(goModPath string) (*modfile.File, error) {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	return modfile.ParseLax(goModPath, content, nil)
+}
+
+// Returns the unquoted arguments of each line of the directive in the go.mod file, including the lines in its blocks.
+// The lax parsing ignores some directives (such as 'replace'), while the strict parsing fails on directives, which are newer than golang.org/x/mod (such as 'toolchain'),
+// so these directives are read from the syntax tree.
+func getGoModDirectiveLines(goMod *modfile.File, directive string) [][]string {
+	var lines [][]string
+	addLine := func(tokens []string) {
+		arguments := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			if unquoted, err := strconv.Unquote(token); err == nil {
+				token = unquoted
+			}
+			arguments = append(arguments, token)
+		}
+		lines = append(lines, arguments)
+	}
+	for _, statement := range goMod.Syntax.Stmt {
+		switch statement := statement.(type) {
+		case *modfile.Line:
+			if len(statement.Token) > 0 && statement.Token[0] == directive {
+				addLine(statement.Token[1:])
+			}
+		case *modfile.LineBlock:
+			if len(statement.Token) > 0 && statement.Token[0] == directive {
+				for _, line := range statement.Line {
+					addLine(line.Token)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// FindGoReplacement returns the replacement of the given version of the module, or nil if it isn't replaced.
+// Similarly to Go, a replacement of the specific version takes precedence over a replacement of all the versions of the module.
+func FindGoReplacement(replacements []GoReplacement, modulePath, version string) *GoReplacement {
+	var allVersionsReplacement *GoReplacement
+	for i := range replacements {
+		if replacements[i].OldPath != modulePath {
+			continue
+		}
+		if replacements[i].OldVersion == version {
+			return &replacements[i]
+		}
+		if replacements[i].OldVersion == "" {
+			allVersionsReplacement = &replacements[i]
+		}
+	}
+	return allVersionsReplacement
+}
+
+// Parses the go.sum file in the given path and returns a map of the modules to their versions.
+// Only the module zip entries are taken into account. If a module appears with more than one version, the highest version is returned.
+func GetGoSumVersions(goSumPath string) (map[string]string, error) {
+	versions := make(map[string]string)
+	err := parseGoSum(goSumPath, func(modulePath, version, _ string) {
+		if currentVersion, exists := versions[modulePath]; !exists || semver.Compare(version, currentVersion) > 0 {
+			versions[modulePath] = version
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// Parses the go.sum file in the given path and returns a map of the modules, in the 'path@version' format, to the hashes of their zips (for example, 'h1:hash=').
+// The hashes of the go.mod files are not included.
+func GetGoSumHashes(goSumPath string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	err := parseGoSum(goSumPath, func(modulePath, version, hash string) {
+		hashes[modulePath+"@"+version] = hash
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// Parses the go.sum file in the given path and calls handleModule with each of the module zips, which it lists. The lines of the go.mod files are skipped.
+func parseGoSum(goSumPath string, handleModule func(modulePath, version, hash string)) error {
+	content, err := os.ReadFile(goSumPath)
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		// The expected syntax : github.com/name v1.2.3 h1:hash=
+		fields := strings.Fields(line)
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		handleModule(fields[0], fields[1], fields[2])
+	}
+	return nil
+}
+
+// Parses the go.mod file in the given path and checks whether the given version falls under one of its 'retract' directives.
+// Returns the rationale of the matching directive, which may be empty.
+func GetRetraction(goModPath, version string) (retracted bool, rationale string, err error) {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return
+	}
+	goMod, err := modfile.ParseLax(goModPath, content, nil)
+	if err != nil {
+		return
+	}
+	for _, retract := range goMod.Retract {
+		if semver.Compare(retract.Low, version) <= 0 && semver.Compare(version, retract.High) <= 0 {
+			return true, retract.Rationale, nil
+		}
+	}
+	return
+}
+
+const incompatibleSuffix = "+incompatible"
+
+// Returns the canonical form of a Go module version. For example, 'v1.2' becomes 'v1.2.0' and build metadata is removed.
+// Pseudo-versions are returned as is, and the '+incompatible' suffix is preserved, since these are part of their canonical forms.
+// If the version is not a valid semantic version, it is returned as is.
+func CanonicalizeGoVersion(version string) string {
+	if module.IsPseudoVersion(version) {
+		return version
+	}
+	if strings.HasSuffix(version, incompatibleSuffix) {
+		baseVersion := strings.TrimSuffix(version, incompatibleSuffix)
+		if canonicalBase := CanonicalizeGoVersion(baseVersion); semver.IsValid(canonicalBase) {
+			return canonicalBase + incompatibleSuffix
+		}
+		return version
+	}
+	if !semver.IsValid(version) {
+		// Go versions must start with 'v', but some tools omit it.
+		if semver.IsValid("v" + version) {
+			return semver.Canonical("v" + version)
+		}
+		return version
+	}
+	return semver.Canonical(version)
+}
@@ -213,3 +213,98 @@ func runGoPathTests(tests []struct {
 		})
 	}
 }
+
+func TestGetGoModRequirements(t *testing.T) {
+	requirements, err := GetGoModRequirements(filepath.Join("testdata", "mods", "testGoList", "go.mod.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"rsc.io/quote": "v1.5.2", "golang.org/x/text": "v0.3.3"}, requirements)
+}
+
+func TestGetGoModToolchain(t *testing.T) {
+	goVersion, toolchain, err := GetGoModToolchain(filepath.Join("..", "build", "testdata", "golang", "tagsproject", "go.mod"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.19", goVersion)
+	assert.Equal(t, "go1.21.0", toolchain)
+
+	// Go versions with patch versions are returned as is.
+	goModPath := filepath.Join(t.TempDir(), "go.mod")
+	assert.NoError(t, os.WriteFile(goModPath, []byte("module example.com/m\n\ngo 1.21.3\n"), 0644))
+	goVersion, toolchain, err = GetGoModToolchain(goModPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.21.3", goVersion)
+	assert.Empty(t, toolchain)
+}
+
+func TestGetGoModDirectRequirements(t *testing.T) {
+	directRequirements, err := GetGoModDirectRequirements(filepath.Join("testdata", "mods", "testGoList", "go.mod.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"rsc.io/quote": true}, directRequirements)
+}
+
+func TestGetWorkspaceModulesDirs(t *testing.T) {
+	workspaceDir, err := filepath.Abs(filepath.Join("..", "build", "testdata", "golang", "workspace"))
+	assert.NoError(t, err)
+	modulesDirs, err := GetWorkspaceModulesDirs(workspaceDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(workspaceDir, "a"), filepath.Join(workspaceDir, "b")}, modulesDirs)
+	modulePath, err := GetModulePathFromGoMod(modulesDirs[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/b", modulePath)
+}
+
+func TestGetGoModReplacements(t *testing.T) {
+	replacements, err := GetGoModReplacements(filepath.Join("..", "build", "testdata", "golang", "replaceproject", "go.mod"))
+	assert.NoError(t, err)
+	assert.Equal(t, []GoReplacement{
+		{OldPath: "github.com/pkg/errors", OldVersion: "v0.8.0", NewPath: "github.com/pkg/errors", NewVersion: "v0.9.1"},
+		{OldPath: "example.com/local", NewPath: "./local"},
+	}, replacements)
+
+	// A replacement of a specific version takes precedence over a replacement of all the versions.
+	replacements = append(replacements, GoReplacement{OldPath: "github.com/pkg/errors", NewPath: "../errors"})
+	assert.Equal(t, &replacements[0], FindGoReplacement(replacements, "github.com/pkg/errors", "v0.8.0"))
+	assert.Equal(t, &replacements[2], FindGoReplacement(replacements, "github.com/pkg/errors", "v0.9.1"))
+	assert.True(t, FindGoReplacement(replacements, "example.com/local", "v0.0.0").IsLocal())
+	assert.Nil(t, FindGoReplacement(replacements, "rsc.io/quote", "v1.5.2"))
+}
+
+func TestGetGoSumVersions(t *testing.T) {
+	versions, err := GetGoSumVersions(filepath.Join("testdata", "mods", "testGoList", "go.sum.txt"))
+	assert.NoError(t, err)
+	// golang.org/x/tools appears only with a go.mod entry, so it's not expected.
+	assert.Equal(t, map[string]string{"golang.org/x/text": "v0.3.3", "rsc.io/quote": "v1.5.2", "rsc.io/sampler": "v1.3.0"}, versions)
+}
+
+func TestGetGoSumHashes(t *testing.T) {
+	hashes, err := GetGoSumHashes(filepath.Join("testdata", "mods", "testGoList", "go.sum.txt"))
+	assert.NoError(t, err)
+	// The hashes of the go.mod files aren't expected.
+	assert.Len(t, hashes, 3)
+	assert.Equal(t, "h1:w5fcysjrx7yqtD/aO+QwRjYZOKnaM9Uh2b40tElTs3Y=", hashes["rsc.io/quote@v1.5.2"])
+}
+
+func TestCanonicalizeGoVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"v1.2.3", "v1.2.3"},
+		{"v1.2", "v1.2.0"},
+		{"v1", "v1.0.0"},
+		{"1.2.3", "v1.2.3"},
+		{"v1.2.3-rc.1", "v1.2.3-rc.1"},
+		{"v1.2.3+meta", "v1.2.3"},
+		{"v2.0.0+incompatible", "v2.0.0+incompatible"},
+		{"v2.0+incompatible", "v2.0.0+incompatible"},
+		{"v0.0.0-20170915032832-14c0d48ead0c", "v0.0.0-20170915032832-14c0d48ead0c"},
+		{"v1.2.4-0.20191109021931-daa7c04131f5", "v1.2.4-0.20191109021931-daa7c04131f5"},
+		{"v2.0.1-0.20180818164646-67afb5ed74ec+incompatible", "v2.0.1-0.20180818164646-67afb5ed74ec+incompatible"},
+		{"", ""},
+		{"latest", "latest"},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			assert.Equal(t, test.expected, CanonicalizeGoVersion(test.version))
+		})
+	}
+}
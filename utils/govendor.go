@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+)
+
+// VendoredModule is a module, which is vendored in the vendor directory of a Go project, as listed in its modules.txt file.
+type VendoredModule struct {
+	Path    string
+	Version string
+	// The module, which replaces it by a 'replace' directive. A module, which is replaced by a local dir, has no replacement version.
+	ReplacementPath    string
+	ReplacementVersion string
+	// True if the go.mod file of the main module requires the module explicitly.
+	Explicit bool
+	// The import paths of the vendored packages of the module.
+	Packages []string
+}
+
+// Parses the modules.txt file in the given vendor dir, as created by 'go mod vendor', and returns the modules it lists, in their order in the file.
+func GetVendoredModules(vendorDir string) ([]VendoredModule, error) {
+	modulesTxtPath := filepath.Join(vendorDir, "modules.txt")
+	content, err := os.ReadFile(modulesTxtPath)
+	if err != nil {
+		return nil, err
+	}
+	var modules []VendoredModule
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "## "):
+			// The annotations of the module, for example: ## explicit; go 1.17
+			if len(modules) == 0 {
+				return nil, fmt.Errorf("unexpected annotation in %s: %s", modulesTxtPath, line)
+			}
+			for _, annotation := range strings.Split(strings.TrimPrefix(line, "## "), ";") {
+				if strings.TrimSpace(annotation) == "explicit" {
+					modules[len(modules)-1].Explicit = true
+				}
+			}
+		case strings.HasPrefix(line, "# "):
+			// The expected syntax : # github.com/name v1.2.3 [=> github.com/replacement v1.2.4]
+			vendoredModule, err := parseVendoredModuleLine(strings.TrimPrefix(line, "# "))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", modulesTxtPath, err)
+			}
+			modules = append(modules, vendoredModule)
+		default:
+			if len(modules) == 0 {
+				return nil, fmt.Errorf("unexpected package in %s: %s", modulesTxtPath, line)
+			}
+			modules[len(modules)-1].Packages = append(modules[len(modules)-1].Packages, line)
+		}
+	}
+	return modules, nil
+}
+
+func parseVendoredModuleLine(line string) (VendoredModule, error) {
+	modulePart, replacementPart, replaced := strings.Cut(line, "=>")
+	moduleFields, replacementFields := strings.Fields(modulePart), strings.Fields(replacementPart)
+	if len(moduleFields) == 0 || len(moduleFields) > 2 || (replaced && (len(replacementFields) == 0 || len(replacementFields) > 2)) {
+		return VendoredModule{}, fmt.Errorf("unexpected module line: # %s", line)
+	}
+	vendoredModule := VendoredModule{Path: moduleFields[0]}
+	if len(moduleFields) == 2 {
+		vendoredModule.Version = moduleFields[1]
+	}
+	if replaced {
+		vendoredModule.ReplacementPath = replacementFields[0]
+		if len(replacementFields) == 2 {
+			vendoredModule.ReplacementVersion = replacementFields[1]
+		}
+	}
+	return vendoredModule, nil
+}
+
+// Calculates the checksums of the vendored content of the module, which are the files in the dirs of its vendored packages and in its root dir (such as its license files).
+// The checksums are calculated from a manifest of the files, as described in GetDirChecksum().
+func GetVendoredModuleChecksum(vendorDir string, vendoredModule VendoredModule, includeSha512 bool) (entities.Checksum, error) {
+	moduleDir := filepath.Join(vendorDir, filepath.FromSlash(vendoredModule.Path))
+	filesPaths := make(map[string]string)
+	for _, importPath := range append([]string{vendoredModule.Path}, vendoredModule.Packages...) {
+		dirPath := filepath.Join(vendorDir, filepath.FromSlash(importPath))
+		entries, err := os.ReadDir(dirPath)
+		if err != nil {
+			// The root dir of the module exists only if it has vendored packages, or files which were copied into it.
+			if os.IsNotExist(err) && importPath == vendoredModule.Path {
+				continue
+			}
+			return entities.Checksum{}, err
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			relativePath := path.Join(strings.TrimPrefix(strings.TrimPrefix(importPath, vendoredModule.Path), "/"), entry.Name())
+			filesPaths[relativePath] = filepath.Join(dirPath, entry.Name())
+		}
+	}
+	if len(filesPaths) == 0 {
+		return entities.Checksum{}, fmt.Errorf("no vendored files of %s were found in %s", vendoredModule.Path, moduleDir)
+	}
+	return calcManifestChecksum(filesPaths, includeSha512)
+}
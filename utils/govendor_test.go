@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var vendorDir = filepath.Join("..", "build", "testdata", "golang", "vendorproject", "vendor")
+
+func TestGetVendoredModules(t *testing.T) {
+	modules, err := GetVendoredModules(vendorDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []VendoredModule{
+		{Path: "golang.org/x/text", Version: "v0.0.0-20170915032832-14c0d48ead0c", Explicit: true, Packages: []string{"golang.org/x/text/internal/tag", "golang.org/x/text/language"}},
+		{Path: "rsc.io/quote", Version: "v1.5.2", Explicit: true, Packages: []string{"rsc.io/quote"}},
+		{Path: "rsc.io/sampler", Version: "v1.3.0", Explicit: true, Packages: []string{"rsc.io/sampler"}},
+	}, modules)
+}
+
+func TestParseVendoredModuleLine(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected VendoredModule
+	}{
+		{"github.com/pkg/errors v0.8.0", VendoredModule{Path: "github.com/pkg/errors", Version: "v0.8.0"}},
+		{"github.com/pkg/errors v0.8.0 => github.com/fork/errors v0.9.0", VendoredModule{Path: "github.com/pkg/errors", Version: "v0.8.0", ReplacementPath: "github.com/fork/errors", ReplacementVersion: "v0.9.0"}},
+		{"github.com/pkg/errors => ../errors", VendoredModule{Path: "github.com/pkg/errors", ReplacementPath: "../errors"}},
+	}
+	for _, testCase := range testCases {
+		vendoredModule, err := parseVendoredModuleLine(testCase.line)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, vendoredModule)
+	}
+	_, err := parseVendoredModuleLine("github.com/pkg/errors v0.8.0 =>")
+	assert.Error(t, err)
+}
+
+func TestGetVendoredModuleChecksum(t *testing.T) {
+	// The checksums are calculated from a manifest of the vendored files, which includes the files in the root dir of the module.
+	quoteChecksum, err := GetFileChecksum(filepath.Join(vendorDir, "rsc.io", "quote", "quote.go"), false)
+	assert.NoError(t, err)
+	licenseChecksum, err := GetFileChecksum(filepath.Join(vendorDir, "rsc.io", "quote", "LICENSE"), false)
+	assert.NoError(t, err)
+	manifest := fmt.Sprintf("%s  LICENSE\n%s  quote.go\n", licenseChecksum.Sha256, quoteChecksum.Sha256)
+	expected, err := calcChecksumDetailsFromReader(strings.NewReader(manifest))
+	assert.NoError(t, err)
+	checksum, err := GetVendoredModuleChecksum(vendorDir, VendoredModule{Path: "rsc.io/quote", Version: "v1.5.2", Packages: []string{"rsc.io/quote"}}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, checksum)
+
+	// The files of the packages are listed by their paths relative to the root dir of the module, which has no files.
+	checksum, err = GetVendoredModuleChecksum(vendorDir, VendoredModule{Path: "golang.org/x/text", Packages: []string{"golang.org/x/text/internal/tag", "golang.org/x/text/language"}}, true)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, checksum.Sha512)
+
+	_, err = GetVendoredModuleChecksum(vendorDir, VendoredModule{Path: "github.com/pkg/errors", Packages: []string{"github.com/pkg/errors"}}, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package pythonutils
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// Prints the license metadata of the installed distributions, as a JSON object by their names.
+const licensesScript = `import json
+from importlib import metadata
+licenses = {}
+for dist in metadata.distributions():
+    name = dist.metadata["Name"]
+    if name:
+        licenses[name] = {
+            "expression": dist.metadata["License-Expression"] or "",
+            "license": dist.metadata["License"] or "",
+            "classifiers": [c for c in (dist.metadata.get_all("Classifier") or []) if c.startswith("License ::")],
+        }
+print(json.dumps(licenses))
+`
+
+// The license classifier, which doesn't identify a license.
+const osiApprovedClassifier = "License :: OSI Approved"
+
+var (
+	packageNameSeparatorsRegex = regexp.MustCompile(`[-_.]+`)
+	// The abbreviation, which follows the name of the license in a classifier (for example, 'Mozilla Public License 2.0 (MPL 2.0)').
+	classifierAbbreviationRegex = regexp.MustCompile(`\s*\([^()]*\)$`)
+)
+
+// The license metadata of an installed distribution, from its METADATA file.
+type distributionLicense struct {
+	// An SPDX license expression (Metadata-Version 2.4 and above).
+	Expression string `json:"expression"`
+	// A free text license.
+	License     string   `json:"license"`
+	Classifiers []string `json:"classifiers"`
+}
+
+// UpdateDepsLicenses sets the licenses of the dependencies, from the METADATA files of the distributions, which are installed in the Python environment of the project.
+// The licenses are taken from the License-Expression field, or from the License field, or from the license classifiers, in that order. Licenses, which aren't recognized, are not collected.
+// dependenciesMap - Dependency name to Dependency map
+func UpdateDepsLicenses(tool PythonTool, srcPath string, dependenciesMap map[string]entities.Dependency) error {
+	licenses, err := getDistributionsLicenses(tool, srcPath)
+	if err != nil {
+		return err
+	}
+	for name, dependency := range dependenciesMap {
+		if license, ok := licenses[normalizePackageName(name)]; ok {
+			dependency.Licenses = license.getLicenses()
+			dependenciesMap[name] = dependency
+		}
+	}
+	return nil
+}
+
+// Returns the license metadata of the installed distributions, by their normalized names.
+func getDistributionsLicenses(tool PythonTool, srcPath string) (map[string]distributionLicense, error) {
+	var licensesCmd *utils.Command
+	switch tool {
+	case Pip:
+		licensesCmd = utils.NewCommand("python", "", []string{"-c", licensesScript})
+	case Pipenv, Poetry:
+		licensesCmd = utils.NewCommand(string(tool), "run", []string{"python", "-c", licensesScript})
+	default:
+		return nil, errors.New(string(tool) + " commands are not supported.")
+	}
+	licensesCmd.Dir = srcPath
+	output, err := licensesCmd.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	return parseDistributionsLicenses(output)
+}
+
+func parseDistributionsLicenses(output []byte) (map[string]distributionLicense, error) {
+	distributions := make(map[string]distributionLicense)
+	if err := json.Unmarshal(output, &distributions); err != nil {
+		return nil, err
+	}
+	licenses := make(map[string]distributionLicense, len(distributions))
+	for name, license := range distributions {
+		licenses[normalizePackageName(name)] = license
+	}
+	return licenses, nil
+}
+
+// Normalizes the name of a package, as defined by PEP 503, so the names of the installed distributions and the dependencies match.
+func normalizePackageName(name string) string {
+	return packageNameSeparatorsRegex.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+func (dl *distributionLicense) getLicenses() []string {
+	if licenses := entities.NormalizeSpdxLicenses([]string{dl.Expression}); len(licenses) > 0 {
+		return licenses
+	}
+	// The License field may hold the full text of the license, which can't be normalized.
+	if licenses := entities.NormalizeSpdxLicenses([]string{dl.License}); len(licenses) > 0 {
+		return licenses
+	}
+	var classifierLicenses []string
+	for _, classifier := range dl.Classifiers {
+		if classifier == osiApprovedClassifier {
+			continue
+		}
+		// For example, 'License :: OSI Approved :: MIT License'.
+		license := classifierAbbreviationRegex.ReplaceAllString(classifier[strings.LastIndex(classifier, "::")+2:], "")
+		// For example, 'GNU Lesser General Public License v2 or later'.
+		classifierLicenses = append(classifierLicenses, strings.Replace(license, " or later", "+", 1))
+	}
+	return entities.NormalizeSpdxLicenses(classifierLicenses)
+}
@@ -0,0 +1,37 @@
+package pythonutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDistributionsLicenses(t *testing.T) {
+	licenses, err := parseDistributionsLicenses([]byte(`{
+		"Flask": {"expression": "", "license": "BSD-3-Clause", "classifiers": []},
+		"typing_extensions": {"expression": "PSF-2.0", "license": "", "classifiers": ["License :: OSI Approved :: Python Software Foundation License"]},
+		"requests": {"expression": "", "license": "", "classifiers": ["License :: OSI Approved", "License :: OSI Approved :: Apache Software License"]},
+		"chardet": {"expression": "", "license": "", "classifiers": ["License :: OSI Approved :: GNU Lesser General Public License v2 or later (LGPLv2+)"]},
+		"certifi": {"expression": "", "license": "Mozilla Public License 2.0 (MPL 2.0)\n\nFull text...", "classifiers": ["License :: OSI Approved :: Mozilla Public License 2.0 (MPL 2.0)"]},
+		"custom": {"expression": "", "license": "Proprietary", "classifiers": ["License :: Other/Proprietary License"]}
+	}`))
+	assert.NoError(t, err)
+	expected := map[string][]string{
+		"flask":             {"BSD-3-Clause"},
+		"typing-extensions": {"PSF-2.0"},
+		"requests":          {"Apache-2.0"},
+		"certifi":           {"MPL-2.0"},
+		"chardet":           {"LGPL-2.0-or-later"},
+		"custom":            nil,
+	}
+	for name, expectedLicenses := range expected {
+		license, ok := licenses[name]
+		if assert.True(t, ok, name) {
+			assert.Equal(t, expectedLicenses, license.getLicenses(), name)
+		}
+	}
+
+	// The names of the dependencies are lowercase, but may use different separators.
+	assert.Equal(t, "typing-extensions", normalizePackageName("Typing_Extensions"))
+	assert.Equal(t, "zope-interface", normalizePackageName("zope.interface"))
+}
@@ -0,0 +1,80 @@
+package signutils
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/jfrog/build-info-go/entities"
+)
+
+const (
+	gpgExecutable        = "gpg"
+	gpgSignatureFileName = "build-info.asc"
+)
+
+// GpgSigner signs build-infos with ASCII-armored detached signatures, created by the gpg executable.
+type GpgSigner struct {
+	// The key to sign with (for example, its fingerprint or email). If it's empty, the default key of GPG is used.
+	KeyId string
+	// The GPG home directory, which holds the key. If it's empty, the default home directory of GPG (or $GNUPGHOME) is used.
+	HomeDir string
+}
+
+func NewGpgSigner(keyId string) *GpgSigner {
+	return &GpgSigner{KeyId: keyId}
+}
+
+func (gs *GpgSigner) SetHomeDir(homeDir string) *GpgSigner {
+	gs.HomeDir = homeDir
+	return gs
+}
+
+func (gs *GpgSigner) Sign(content []byte) (*entities.BuildInfoSignature, error) {
+	args := append(getGpgHomeDirArgs(gs.HomeDir), "--batch", "--yes", "--armor", "--detach-sign", "--output", "-")
+	if gs.KeyId != "" {
+		args = append(args, "--local-user", gs.KeyId)
+	}
+	signature, err := runWithInput(content, gpgExecutable, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.BuildInfoSignature{Type: entities.GpgSignature, Signature: string(signature)}, nil
+}
+
+// GpgVerifier verifies GPG signatures of build-infos, with the public keys, which are imported to the keyring of GPG.
+type GpgVerifier struct {
+	// The GPG home directory, which holds the keyring. If it's empty, the default home directory of GPG (or $GNUPGHOME) is used.
+	HomeDir string
+}
+
+func NewGpgVerifier() *GpgVerifier {
+	return &GpgVerifier{}
+}
+
+func (gv *GpgVerifier) SetHomeDir(homeDir string) *GpgVerifier {
+	gv.HomeDir = homeDir
+	return gv
+}
+
+func (gv *GpgVerifier) Type() entities.BuildInfoSignatureType {
+	return entities.GpgSignature
+}
+
+func (gv *GpgVerifier) Verify(content []byte, signature *entities.BuildInfoSignature) error {
+	if signature.Signature == "" {
+		return errors.New("the GPG signature is empty")
+	}
+	return withTempFiles(map[string][]byte{gpgSignatureFileName: []byte(signature.Signature)}, func(tempDir string) error {
+		// The content is read from stdin.
+		args := append(getGpgHomeDirArgs(gv.HomeDir), "--batch", "--verify", filepath.Join(tempDir, gpgSignatureFileName), "-")
+		_, err := runWithInput(content, gpgExecutable, args...)
+		return err
+	})
+}
+
+func getGpgHomeDirArgs(homeDir string) []string {
+	if homeDir == "" {
+		return []string{}
+	}
+	return []string{"--homedir", homeDir}
+}
@@ -0,0 +1,31 @@
+package signutils
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGpgSignAndVerify(t *testing.T) {
+	if _, err := exec.LookPath(gpgExecutable); err != nil {
+		t.Skip("gpg is not installed")
+	}
+	homeDir := t.TempDir()
+	_, err := runWithInput(nil, gpgExecutable, "--homedir", homeDir, "--batch", "--passphrase", "", "--quick-gen-key", "build-info-test@example.com", "default", "default", "never")
+	require.NoError(t, err)
+
+	buildInfo := &entities.BuildInfo{Name: "my-build", Number: "7", Modules: []entities.Module{{Id: "web", Type: entities.Npm}}}
+	assert.NoError(t, buildInfo.Sign(NewGpgSigner("build-info-test@example.com").SetHomeDir(homeDir)))
+	if assert.Len(t, buildInfo.Signatures, 1) {
+		assert.Equal(t, entities.GpgSignature, buildInfo.Signatures[0].Type)
+		assert.Contains(t, buildInfo.Signatures[0].Signature, "BEGIN PGP SIGNATURE")
+	}
+	verifier := NewGpgVerifier().SetHomeDir(homeDir)
+	assert.NoError(t, buildInfo.Verify(verifier))
+
+	buildInfo.Number = "8"
+	assert.Error(t, buildInfo.Verify(verifier))
+}
@@ -0,0 +1,45 @@
+package signutils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils"
+)
+
+// Runs the executable with the input on its stdin, and returns its stdout.
+func runWithInput(input []byte, executable string, args ...string) ([]byte, error) {
+	cmd := exec.Command(executable, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed running command: '%s' with error: %s - %s", strings.Join(cmd.Args, " "), err.Error(), stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// Writes the files to a new temporary directory, and runs the function with its path. The directory is removed afterwards.
+func withTempFiles(files map[string][]byte, run func(tempDir string) error) (err error) {
+	tempDir, err := utils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := utils.RemoveTempDir(tempDir)
+		if err == nil {
+			err = e
+		}
+	}()
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(tempDir, name), content, 0600); err != nil {
+			return err
+		}
+	}
+	return run(tempDir)
+}
@@ -0,0 +1,97 @@
+package signutils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/build-info-go/entities"
+)
+
+const (
+	cosignExecutable            = "cosign"
+	blobFileName                = "build-info.json"
+	sigstoreSignatureFileName   = "build-info.sig"
+	sigstoreCertificateFileName = "build-info.pem"
+)
+
+// SigstoreSigner signs build-infos with Sigstore keyless signing, by the cosign executable.
+// Cosign gets an OIDC identity token (interactively, from the CI's ambient credentials, or from IdentityToken), gets a short-lived certificate for it from Fulcio, and records the signature in the Rekor transparency log.
+type SigstoreSigner struct {
+	// An OIDC identity token to sign with. If it's empty, cosign gets one by itself.
+	IdentityToken string
+}
+
+func NewSigstoreSigner() *SigstoreSigner {
+	return &SigstoreSigner{}
+}
+
+func (ss *SigstoreSigner) SetIdentityToken(identityToken string) *SigstoreSigner {
+	ss.IdentityToken = identityToken
+	return ss
+}
+
+func (ss *SigstoreSigner) Sign(content []byte) (signature *entities.BuildInfoSignature, err error) {
+	err = withTempFiles(map[string][]byte{blobFileName: content}, func(tempDir string) error {
+		signatureFile, certificateFile := filepath.Join(tempDir, sigstoreSignatureFileName), filepath.Join(tempDir, sigstoreCertificateFileName)
+		args := []string{"sign-blob", "--yes", "--output-signature", signatureFile, "--output-certificate", certificateFile}
+		if ss.IdentityToken != "" {
+			args = append(args, "--identity-token", ss.IdentityToken)
+		}
+		if _, err := runWithInput(nil, cosignExecutable, append(args, filepath.Join(tempDir, blobFileName))...); err != nil {
+			return err
+		}
+		sig, err := os.ReadFile(signatureFile)
+		if err != nil {
+			return err
+		}
+		certificate, err := os.ReadFile(certificateFile)
+		if err != nil {
+			return err
+		}
+		signature = &entities.BuildInfoSignature{Type: entities.SigstoreSignature, Signature: string(sig), Certificate: string(certificate)}
+		return nil
+	})
+	return
+}
+
+// SigstoreVerifier verifies Sigstore signatures of build-infos, by the cosign executable.
+// The certificate of the signature must be issued to the expected identity, by the expected OIDC issuer, and the signature must be recorded in the Rekor transparency log.
+type SigstoreVerifier struct {
+	// The expected identity of the signer (for example, an email or the URL of a CI workflow).
+	CertificateIdentity string
+	// The expected OIDC issuer of the identity (for example, 'https://token.actions.githubusercontent.com').
+	CertificateOidcIssuer string
+}
+
+func NewSigstoreVerifier(certificateIdentity, certificateOidcIssuer string) *SigstoreVerifier {
+	return &SigstoreVerifier{CertificateIdentity: certificateIdentity, CertificateOidcIssuer: certificateOidcIssuer}
+}
+
+func (sv *SigstoreVerifier) Type() entities.BuildInfoSignatureType {
+	return entities.SigstoreSignature
+}
+
+func (sv *SigstoreVerifier) Verify(content []byte, signature *entities.BuildInfoSignature) error {
+	if signature.Signature == "" || signature.Certificate == "" {
+		return errors.New("a Sigstore signature must have both a signature and a certificate")
+	}
+	if sv.CertificateIdentity == "" || sv.CertificateOidcIssuer == "" {
+		return errors.New("the certificate identity and OIDC issuer must be provided in order to verify a Sigstore signature")
+	}
+	files := map[string][]byte{
+		blobFileName:                content,
+		sigstoreSignatureFileName:   []byte(signature.Signature),
+		sigstoreCertificateFileName: []byte(signature.Certificate),
+	}
+	return withTempFiles(files, func(tempDir string) error {
+		args := []string{"verify-blob",
+			"--signature", filepath.Join(tempDir, sigstoreSignatureFileName),
+			"--certificate", filepath.Join(tempDir, sigstoreCertificateFileName),
+			"--certificate-identity", sv.CertificateIdentity,
+			"--certificate-oidc-issuer", sv.CertificateOidcIssuer,
+			filepath.Join(tempDir, blobFileName)}
+		_, err := runWithInput(nil, cosignExecutable, args...)
+		return err
+	})
+}